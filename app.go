@@ -0,0 +1,265 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"github.com/go-redis/redis/v8"
+	"github.com/raychongtk/wallet/archive"
+	"github.com/raychongtk/wallet/config"
+	"github.com/raychongtk/wallet/datastore"
+	"github.com/raychongtk/wallet/escrow"
+	"github.com/raychongtk/wallet/health"
+	"github.com/raychongtk/wallet/hold"
+	"github.com/raychongtk/wallet/integrity"
+	"github.com/raychongtk/wallet/interest"
+	"github.com/raychongtk/wallet/metrics"
+	"github.com/raychongtk/wallet/migration"
+	"github.com/raychongtk/wallet/paymentrequest"
+	"github.com/raychongtk/wallet/payout"
+	"github.com/raychongtk/wallet/repository"
+	"github.com/raychongtk/wallet/scheduler"
+	"github.com/raychongtk/wallet/tracing"
+	"github.com/raychongtk/wallet/util"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"gorm.io/gorm"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// Worker is a background job that runs until its context is cancelled
+type Worker interface {
+	Start(ctx context.Context)
+}
+
+// App holds everything main needs to run: the configuration, the HTTP routes, the gRPC server and the background
+// workers
+type App struct {
+	Config   *config.Config
+	Routes   *gin.Engine
+	GRPC     *grpc.Server
+	Health   *health.Checker
+	Migrator *migration.Migrator
+	Workers  []Worker
+	Tracing  *tracing.Provider
+	db       gorm.DB
+	redis    redis.Client
+}
+
+func ProvideApp(
+	cfg *config.Config,
+	routes *gin.Engine,
+	grpcServer *grpc.Server,
+	checker *health.Checker,
+	migrator *migration.Migrator,
+	archiver *archive.Archiver,
+	replicaRouter *datastore.ReplicaRouter,
+	checkpointer *integrity.Checkpointer,
+	payouts *payout.Processor,
+	scheduler *scheduler.Scheduler,
+	paymentRequests *paymentrequest.Manager,
+	holds *hold.Manager,
+	escrows *escrow.Manager,
+	interests *interest.Manager,
+	balanceRepo repository.BalanceRepository,
+	tracerProvider *tracing.Provider,
+	db gorm.DB,
+	memoryStore redis.Client,
+) *App {
+	if err := metrics.Registry.Register(metrics.NewChartBalanceCollector(chartBalances(balanceRepo))); err != nil {
+		util.Warn("Chart balance metrics not registered", zap.Error(err))
+	}
+	return &App{
+		Config:   cfg,
+		Routes:   routes,
+		GRPC:     grpcServer,
+		Health:   checker,
+		Migrator: migrator,
+		Workers:  []Worker{archiver, replicaRouter, checkpointer, payouts, scheduler, paymentRequests, holds, escrows, interests},
+		Tracing:  tracerProvider,
+		db:       db,
+		redis:    memoryStore,
+	}
+}
+
+func ProvideHealthChecker(
+	cfg *config.Config,
+	db gorm.DB,
+	memoryStore redis.Client,
+	migrator *migration.Migrator,
+	archiver *archive.Archiver,
+	replicaRouter *datastore.ReplicaRouter,
+	checkpointer *integrity.Checkpointer,
+	payouts *payout.Processor,
+	scheduler *scheduler.Scheduler,
+	paymentRequests *paymentrequest.Manager,
+	holds *hold.Manager,
+	escrows *escrow.Manager,
+	interests *interest.Manager,
+) *health.Checker {
+	return health.NewChecker(cfg.Server.HealthTimeout,
+		health.Check{Name: "postgres", Critical: true, Probe: func(ctx context.Context) error {
+			sqlDB, err := db.DB()
+			if err != nil {
+				return err
+			}
+			return sqlDB.PingContext(ctx)
+		}},
+		health.Check{Name: "redis", Critical: true, Probe: func(ctx context.Context) error {
+			return memoryStore.Ping(ctx).Err()
+		}},
+		health.Check{Name: "migration", Critical: true, Probe: func(ctx context.Context) error {
+			pending, err := migrator.Pending(ctx)
+			if err != nil {
+				return err
+			}
+			if len(pending) > 0 {
+				return fmt.Errorf("pending migrations %v", pending)
+			}
+			return nil
+		}},
+		health.Check{Name: "archiver", Critical: false, Probe: func(ctx context.Context) error {
+			return archiver.Health()
+		}},
+		health.Check{Name: "read_replica", Critical: false, Probe: func(ctx context.Context) error {
+			return replicaRouter.Health()
+		}},
+		health.Check{Name: "ledger_checkpoint", Critical: false, Probe: func(ctx context.Context) error {
+			return checkpointer.Health()
+		}},
+		health.Check{Name: "payout", Critical: false, Probe: func(ctx context.Context) error {
+			return payouts.Health()
+		}},
+		health.Check{Name: "scheduler", Critical: false, Probe: func(ctx context.Context) error {
+			return scheduler.Health()
+		}},
+		health.Check{Name: "payment_request", Critical: false, Probe: func(ctx context.Context) error {
+			return paymentRequests.Health()
+		}},
+		health.Check{Name: "hold", Critical: false, Probe: func(ctx context.Context) error {
+			return holds.Health()
+		}},
+		health.Check{Name: "escrow", Critical: false, Probe: func(ctx context.Context) error {
+			return escrows.Health()
+		}},
+		health.Check{Name: "interest", Critical: false, Probe: func(ctx context.Context) error {
+			return interests.Health()
+		}},
+	)
+}
+
+// Run serves HTTP, and gRPC when features.grpc is on, until ctx is cancelled, then drains in-flight requests and stops
+// the workers
+func (a *App) Run(ctx context.Context) error {
+	if a.Config.DB.MigrateOnStart {
+		if err := a.Migrator.Migrate(ctx); err != nil {
+			return fmt.Errorf("migrate database: %w", err)
+		}
+	}
+
+	workerCtx, stopWorkers := context.WithCancel(context.Background())
+	defer stopWorkers()
+	var workers sync.WaitGroup
+	for _, worker := range a.Workers {
+		workers.Add(1)
+		go func(worker Worker) {
+			defer workers.Done()
+			worker.Start(workerCtx)
+		}(worker)
+	}
+
+	server := &http.Server{
+		Addr:         a.Config.Server.Address,
+		Handler:      a.Routes,
+		ReadTimeout:  a.Config.Server.ReadTimeout,
+		WriteTimeout: a.Config.Server.WriteTimeout,
+	}
+	serverErr := make(chan error, 2)
+	go func() {
+		serverErr <- server.ListenAndServe()
+	}()
+	util.Info("Server started", zap.String("address", a.Config.Server.Address))
+	if a.Config.Features.GRPC {
+		listener, err := net.Listen("tcp", a.Config.GRPC.Address)
+		if err != nil {
+			serverErr <- fmt.Errorf("listen grpc: %w", err)
+		} else {
+			go func() {
+				serverErr <- a.GRPC.Serve(listener)
+			}()
+			util.Info("gRPC server started", zap.String("address", a.Config.GRPC.Address))
+		}
+	}
+
+	select {
+	case err := <-serverErr:
+		server.Close()
+		a.GRPC.Stop()
+		stopWorkers()
+		workers.Wait()
+		return err
+	case <-ctx.Done():
+	}
+
+	util.Info("Shutting down")
+	a.Health.Drain()
+	time.Sleep(a.Config.Server.DrainDelay)
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), a.Config.Server.ShutdownTimeout)
+	defer cancel()
+	var shutdownErr error
+	if err := server.Shutdown(shutdownCtx); err != nil {
+		shutdownErr = fmt.Errorf("drain in-flight requests: %w", err)
+	}
+	grpcStopped := make(chan struct{})
+	go func() {
+		a.GRPC.GracefulStop()
+		close(grpcStopped)
+	}()
+	select {
+	case <-grpcStopped:
+	case <-shutdownCtx.Done():
+		a.GRPC.Stop()
+		shutdownErr = errors.Join(shutdownErr, errors.New("gRPC calls did not finish in time"))
+	}
+
+	stopWorkers()
+	stopped := make(chan struct{})
+	go func() {
+		workers.Wait()
+		close(stopped)
+	}()
+	select {
+	case <-stopped:
+	case <-shutdownCtx.Done():
+		shutdownErr = errors.Join(shutdownErr, errors.New("background workers did not stop in time"))
+	}
+
+	if sqlDB, err := a.db.DB(); err == nil {
+		shutdownErr = errors.Join(shutdownErr, sqlDB.Close())
+	}
+	shutdownErr = errors.Join(shutdownErr, a.redis.Close())
+	shutdownErr = errors.Join(shutdownErr, a.Tracing.Shutdown(shutdownCtx))
+	util.Info("Server stopped")
+	return shutdownErr
+}
+
+// chartBalances loads the committed balances of the asset and liability chart accounts, deposits grow the former
+// and withdrawals the latter so their difference is what the wallet owes its customers
+func chartBalances(balanceRepo repository.BalanceRepository) func() (int, int, error) {
+	return func() (int, int, error) {
+		asset, err := balanceRepo.GetBalance(util.GetAssetAccount(), "COMMITTED")
+		if err != nil {
+			return 0, 0, err
+		}
+		liability, err := balanceRepo.GetBalance(util.GetLiabilityAccount(), "COMMITTED")
+		if err != nil {
+			return 0, 0, err
+		}
+		return asset.Balance, liability.Balance, nil
+	}
+}
@@ -0,0 +1,358 @@
+package archive
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/raychongtk/wallet/audit"
+	"github.com/raychongtk/wallet/config"
+	"github.com/raychongtk/wallet/datastore"
+	"github.com/raychongtk/wallet/model/archive"
+	"github.com/raychongtk/wallet/model/movement"
+	"github.com/raychongtk/wallet/repository"
+	"github.com/raychongtk/wallet/util"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"sync"
+	"time"
+)
+
+const (
+	MovementTable       = "movement"
+	TransactionTable    = "transaction"
+	PaymentHistoryTable = "payment_history"
+)
+
+const window = 24 * time.Hour
+
+// Archiver moves ledger rows older than the horizon into the object store, one day per object.
+// Rows are only deleted after the object has been read back and verified, and in the same database
+// transaction that records the manifest, so every deleted row is always recoverable from the archive.
+type Archiver struct {
+	movementRepo       repository.MovementRepository
+	transactionRepo    repository.TransactionRepository
+	paymentHistoryRepo repository.PaymentHistoryRepository
+	manifestRepo       repository.ArchiveManifestRepository
+	store              datastore.ObjectStore
+	db                 gorm.DB
+	enabled            bool
+	config             config.ArchiveConfig
+	auditor            *audit.Auditor
+	mu                 sync.Mutex
+	lastErr            error
+}
+
+type source struct {
+	table  string
+	oldest func() (*time.Time, error)
+	// export encodes the rows of a window, nil when it holds none
+	export func(from time.Time, to time.Time) (*exported, error)
+	remove func(db *gorm.DB, ids []uuid.UUID) (int64, error)
+}
+
+// exported is a window of rows encoded for the object store
+type exported struct {
+	data []byte
+	ids  []uuid.UUID
+	// balances are what the rows add to each wallet balance, kept for transactions only
+	balances []archive.Balance
+}
+
+func ProvideArchiver(
+	movementRepo repository.MovementRepository,
+	transactionRepo repository.TransactionRepository,
+	paymentHistoryRepo repository.PaymentHistoryRepository,
+	manifestRepo repository.ArchiveManifestRepository,
+	store datastore.ObjectStore,
+	db datastore.ArchiverDB,
+	cfg *config.Config,
+	auditor *audit.Auditor,
+) *Archiver {
+	archiver := NewArchiver(movementRepo, transactionRepo, paymentHistoryRepo, manifestRepo, store, db.DB, cfg.Archive)
+	archiver.enabled = cfg.Features.Archival
+	archiver.auditor = auditor
+	return archiver
+}
+
+func NewArchiver(
+	movementRepo repository.MovementRepository,
+	transactionRepo repository.TransactionRepository,
+	paymentHistoryRepo repository.PaymentHistoryRepository,
+	manifestRepo repository.ArchiveManifestRepository,
+	store datastore.ObjectStore,
+	db gorm.DB,
+	archiveConfig config.ArchiveConfig,
+) *Archiver {
+	return &Archiver{
+		movementRepo:       movementRepo,
+		transactionRepo:    transactionRepo,
+		paymentHistoryRepo: paymentHistoryRepo,
+		manifestRepo:       manifestRepo,
+		store:              store,
+		db:                 db,
+		config:             archiveConfig,
+	}
+}
+
+// Start archives periodically until the context is cancelled
+func (a *Archiver) Start(ctx context.Context) {
+	if !a.enabled {
+		return
+	}
+	ticker := time.NewTicker(a.config.Interval)
+	defer ticker.Stop()
+	for {
+		cutoff := time.Now().Add(-a.config.Horizon)
+		err := a.Run(ctx, cutoff)
+		if err != nil && ctx.Err() == nil {
+			util.Error("Archive ledger failed", zap.Error(err))
+		}
+		a.mu.Lock()
+		a.lastErr = err
+		a.mu.Unlock()
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Health reports the error of the last scheduled run
+func (a *Archiver) Health() error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.lastErr
+}
+
+// Run archives every complete day before cutoff that has not been archived yet
+func (a *Archiver) Run(ctx context.Context, cutoff time.Time) error {
+	for _, src := range a.sources() {
+		if err := a.archiveTable(ctx, src, cutoff.UTC().Truncate(window)); err != nil {
+			return fmt.Errorf("archive %s: %w", src.table, err)
+		}
+	}
+	return nil
+}
+
+func (a *Archiver) archiveTable(ctx context.Context, src source, cutoff time.Time) error {
+	latest, err := a.manifestRepo.GetLatestManifest(src.table)
+	if err != nil {
+		return err
+	}
+	var start time.Time
+	if latest != nil {
+		start = latest.RangeEnd.UTC()
+	} else {
+		oldest, err := src.oldest()
+		if err != nil {
+			return err
+		}
+		if oldest == nil {
+			return nil
+		}
+		start = oldest.UTC().Truncate(window)
+	}
+
+	for end := start.Add(window); !end.After(cutoff); start, end = end, end.Add(window) {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		manifest, err := a.archiveWindow(ctx, src, start, end)
+		a.audit(ctx, src.table, start, end, manifest, err)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// archiveWindow returns the manifest of the archived rows, or nil when the window holds none
+func (a *Archiver) archiveWindow(ctx context.Context, src source, start time.Time, end time.Time) (*archive.Manifest, error) {
+	rows, err := src.export(start, end)
+	if err != nil || rows == nil {
+		return nil, err
+	}
+	data, ids := rows.data, rows.ids
+
+	key := fmt.Sprintf("%s/%s.ndjson.gz", src.table, start.Format("2006/01/02"))
+	sum := checksum(data)
+	err = a.store.Put(ctx, key, data)
+	if err != nil && !errors.Is(err, datastore.ErrObjectExists) {
+		return nil, err
+	}
+	// read back what the store holds: a leftover object from an interrupted run is only reused when it is identical
+	stored, err := a.store.Get(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	if checksum(stored) != sum {
+		return nil, fmt.Errorf("archived object %s does not match ledger rows", key)
+	}
+
+	manifest := &archive.Manifest{
+		ID:          uuid.New(),
+		SourceTable: src.table,
+		RangeStart:  start,
+		RangeEnd:    end,
+		ObjectKey:   key,
+		Format:      FormatNDJSONGzip,
+		RecordCount: len(ids),
+		ByteSize:    len(data),
+		Checksum:    sum,
+		CreatedAt:   time.Now(),
+	}
+	err = a.db.Transaction(func(tx *gorm.DB) error {
+		if _, err := a.manifestRepo.CreateManifest(tx, manifest, rows.balances); err != nil {
+			return err
+		}
+		// the immutability triggers only let deletes through for rows covered by this manifest
+		if err := tx.Exec("SELECT set_config('wallet.archive_manifest_id', ?, true)", manifest.ID.String()).Error; err != nil {
+			return err
+		}
+		deleted, err := src.remove(tx, ids)
+		if err != nil {
+			return err
+		}
+		if deleted != int64(len(ids)) {
+			return fmt.Errorf("expected to archive %d rows but deleted %d", len(ids), deleted)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	util.Info("Archive ledger successfully",
+		zap.String("table", src.table),
+		zap.String("object_key", key),
+		zap.Int("records", len(ids)),
+	)
+	return manifest, nil
+}
+
+// audit records an archived window, or a failed attempt to archive it
+func (a *Archiver) audit(ctx context.Context, table string, start time.Time, end time.Time, manifest *archive.Manifest, err error) {
+	if manifest == nil && err == nil {
+		return
+	}
+	entry := audit.Entry{
+		ActorType:  audit.ActorService,
+		ActorID:    "archiver",
+		Action:     "archive.window",
+		TargetType: audit.TargetArchive,
+		TargetID:   fmt.Sprintf("%s/%s", table, start.Format("2006-01-02")),
+		Outcome:    audit.OutcomeSuccess,
+	}
+	if err != nil {
+		entry.Outcome, entry.ErrorCode = audit.OutcomeFailure, "ARCHIVE_FAILED"
+	} else {
+		entry.After = map[string]interface{}{
+			"manifest_id":  manifest.ID,
+			"table":        table,
+			"range_start":  start,
+			"range_end":    end,
+			"object_key":   manifest.ObjectKey,
+			"record_count": manifest.RecordCount,
+			"checksum":     manifest.Checksum,
+		}
+	}
+	// a cancelled run is still worth recording
+	if err := a.auditor.Record(context.WithoutCancel(ctx), entry); err != nil {
+		util.Error("Record audit log failed", zap.String("action", entry.Action), zap.Error(err))
+	}
+}
+
+func (a *Archiver) sources() []source {
+	return []source{
+		{
+			table: MovementTable,
+			oldest: func() (*time.Time, error) {
+				oldest, err := a.movementRepo.GetOldestMovement()
+				if err != nil || oldest == nil {
+					return nil, err
+				}
+				return &oldest.CreatedAt, nil
+			},
+			export: func(from time.Time, to time.Time) (*exported, error) {
+				movements, err := a.movementRepo.SearchMovementsCreatedBetween(from, to)
+				if err != nil || len(movements) == 0 {
+					return nil, err
+				}
+				ids := make([]uuid.UUID, len(movements))
+				for i := range movements {
+					ids[i] = movements[i].ID
+				}
+				data, err := encode(movements)
+				return &exported{data: data, ids: ids}, err
+			},
+			remove: a.movementRepo.DeleteMovements,
+		},
+		{
+			table: TransactionTable,
+			oldest: func() (*time.Time, error) {
+				oldest, err := a.transactionRepo.GetOldestTransaction()
+				if err != nil || oldest == nil {
+					return nil, err
+				}
+				return &oldest.CreatedAt, nil
+			},
+			export: func(from time.Time, to time.Time) (*exported, error) {
+				transactions, err := a.transactionRepo.SearchTransactionsCreatedBetween(from, to)
+				if err != nil || len(transactions) == 0 {
+					return nil, err
+				}
+				ids := make([]uuid.UUID, len(transactions))
+				for i := range transactions {
+					ids[i] = transactions[i].ID
+				}
+				data, err := encode(transactions)
+				return &exported{data: data, ids: ids, balances: balancesOf(transactions)}, err
+			},
+			remove: a.transactionRepo.DeleteTransactions,
+		},
+		{
+			table: PaymentHistoryTable,
+			oldest: func() (*time.Time, error) {
+				oldest, err := a.paymentHistoryRepo.GetOldestPaymentHistory()
+				if err != nil || oldest == nil {
+					return nil, err
+				}
+				return &oldest.CreatedAt, nil
+			},
+			export: func(from time.Time, to time.Time) (*exported, error) {
+				histories, err := a.paymentHistoryRepo.SearchPaymentHistoryCreatedBetween(from, to)
+				if err != nil || len(histories) == 0 {
+					return nil, err
+				}
+				ids := make([]uuid.UUID, len(histories))
+				for i := range histories {
+					ids[i] = histories[i].ID
+				}
+				data, err := encode(histories)
+				return &exported{data: data, ids: ids}, err
+			},
+			remove: a.paymentHistoryRepo.DeletePaymentHistories,
+		},
+	}
+}
+
+// balancesOf adds up transactions by wallet and balance type
+func balancesOf(transactions []movement.Transaction) []archive.Balance {
+	type key struct {
+		walletID    uuid.UUID
+		balanceType string
+	}
+	var balances []archive.Balance
+	index := map[key]int{}
+	for i := range transactions {
+		k := key{transactions[i].WalletID, transactions[i].BalanceType}
+		if _, ok := index[k]; !ok {
+			index[k] = len(balances)
+			balances = append(balances, archive.Balance{WalletID: k.walletID, BalanceType: k.balanceType})
+		}
+		balances[index[k]].Balance += transactions[i].Balance
+	}
+	return balances
+}
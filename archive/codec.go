@@ -0,0 +1,63 @@
+package archive
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+)
+
+const FormatNDJSONGzip = "NDJSON_GZIP"
+
+// encode writes one JSON document per line and gzips the result
+func encode[T any](records []T) ([]byte, error) {
+	var buf bytes.Buffer
+	writer := gzip.NewWriter(&buf)
+	encoder := json.NewEncoder(writer)
+	for i := range records {
+		if err := encoder.Encode(records[i]); err != nil {
+			return nil, err
+		}
+	}
+	if err := writer.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// decode reads the records of the lines keep accepts, every record when keep is nil. It also counts the lines, so the
+// record count of a manifest is checked without decoding the records that are left out.
+func decode[T any](data []byte, keep func(line []byte) bool) ([]T, int, error) {
+	reader, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, 0, err
+	}
+	defer reader.Close()
+
+	var records []T
+	lines := 0
+	scanner := bufio.NewScanner(reader)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		lines++
+		if keep != nil && !keep(scanner.Bytes()) {
+			continue
+		}
+		var record T
+		if err := json.Unmarshal(scanner.Bytes(), &record); err != nil {
+			return nil, 0, err
+		}
+		records = append(records, record)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, 0, err
+	}
+	return records, lines, nil
+}
+
+func checksum(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,133 @@
+package archive
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/raychongtk/wallet/datastore"
+	"github.com/raychongtk/wallet/model/archive"
+	"github.com/raychongtk/wallet/model/movement"
+	"github.com/raychongtk/wallet/model/payment"
+	"github.com/raychongtk/wallet/repository"
+	"time"
+)
+
+// Reader serves ledger rows that were moved to the object store. Every object is verified against the
+// checksum recorded in its manifest before it is used.
+type Reader struct {
+	manifestRepo repository.ArchiveManifestRepository
+	store        datastore.ObjectStore
+}
+
+func ProvideReader(manifestRepo repository.ArchiveManifestRepository, store datastore.ObjectStore) *Reader {
+	return &Reader{manifestRepo: manifestRepo, store: store}
+}
+
+func (r *Reader) Movements(ctx context.Context, from time.Time, to time.Time) ([]movement.Movement, error) {
+	movements, err := load[movement.Movement](ctx, r, MovementTable, from, to, nil)
+	if err != nil {
+		return nil, err
+	}
+	var matched []movement.Movement
+	for i := range movements {
+		if !movements[i].CreatedAt.Before(from) && movements[i].CreatedAt.Before(to) {
+			matched = append(matched, movements[i])
+		}
+	}
+	return matched, nil
+}
+
+func (r *Reader) Transactions(ctx context.Context, from time.Time, to time.Time) ([]movement.Transaction, error) {
+	transactions, err := load[movement.Transaction](ctx, r, TransactionTable, from, to, nil)
+	if err != nil {
+		return nil, err
+	}
+	var matched []movement.Transaction
+	for i := range transactions {
+		if !transactions[i].CreatedAt.Before(from) && transactions[i].CreatedAt.Before(to) {
+			matched = append(matched, transactions[i])
+		}
+	}
+	return matched, nil
+}
+
+// PaymentHistories returns the archived payment histories of a user. Only the lines that mention the user are decoded.
+func (r *Reader) PaymentHistories(ctx context.Context, userId string) ([]payment.PaymentHistory, error) {
+	mentioned := func(line []byte) bool { return bytes.Contains(line, []byte(userId)) }
+	histories, err := load[payment.PaymentHistory](ctx, r, PaymentHistoryTable, time.Time{}, time.Now(), mentioned)
+	if err != nil {
+		return nil, err
+	}
+	var matched []payment.PaymentHistory
+	for i := range histories {
+		if histories[i].PayerUserId == userId || histories[i].PayeeUserId == userId {
+			matched = append(matched, histories[i])
+		}
+	}
+	return matched, nil
+}
+
+// SumBalance adds up the archived transactions of a wallet up to asOf. Manifests that end by then are summed from the
+// balances recorded with them, only the object of the one asOf falls in is read.
+func (r *Reader) SumBalance(ctx context.Context, walletID uuid.UUID, balanceType string, asOf time.Time) (int, error) {
+	// timestamps are kept to the microsecond, so the transactions up to asOf are the ones before through
+	through := asOf.Add(time.Microsecond)
+	sum, err := r.manifestRepo.SumBalance(walletID, balanceType, through)
+	if err != nil {
+		return 0, err
+	}
+	manifests, err := r.manifestRepo.SearchManifests(TransactionTable, through, through)
+	if err != nil {
+		return 0, err
+	}
+	wallet := []byte(walletID.String())
+	for _, manifest := range manifests {
+		transactions, err := fetch[movement.Transaction](ctx, r.store, manifest, func(line []byte) bool { return bytes.Contains(line, wallet) })
+		if err != nil {
+			return 0, err
+		}
+		for i := range transactions {
+			if transactions[i].WalletID == walletID && transactions[i].BalanceType == balanceType && !transactions[i].CreatedAt.After(asOf) {
+				sum += transactions[i].Balance
+			}
+		}
+	}
+	return sum, nil
+}
+
+// load reads the records of the manifests overlapping [from, to) that keep accepts
+func load[T any](ctx context.Context, r *Reader, table string, from time.Time, to time.Time, keep func(line []byte) bool) ([]T, error) {
+	manifests, err := r.manifestRepo.SearchManifests(table, from, to)
+	if err != nil {
+		return nil, err
+	}
+	var records []T
+	for _, manifest := range manifests {
+		decoded, err := fetch[T](ctx, r.store, manifest, keep)
+		if err != nil {
+			return nil, err
+		}
+		records = append(records, decoded...)
+	}
+	return records, nil
+}
+
+// fetch reads the object of a manifest and verifies it against the checksum and record count of the manifest
+func fetch[T any](ctx context.Context, store datastore.ObjectStore, manifest archive.Manifest, keep func(line []byte) bool) ([]T, error) {
+	data, err := store.Get(ctx, manifest.ObjectKey)
+	if err != nil {
+		return nil, err
+	}
+	if checksum(data) != manifest.Checksum {
+		return nil, fmt.Errorf("archived object %s failed checksum verification", manifest.ObjectKey)
+	}
+	decoded, lines, err := decode[T](data, keep)
+	if err != nil {
+		return nil, err
+	}
+	if lines != manifest.RecordCount {
+		return nil, fmt.Errorf("archived object %s has %d records, manifest expects %d", manifest.ObjectKey, lines, manifest.RecordCount)
+	}
+	return decoded, nil
+}
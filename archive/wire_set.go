@@ -0,0 +1,7 @@
+package archive
+
+import "github.com/google/wire"
+
+var (
+	WireSet = wire.NewSet(ProvideArchiver, ProvideReader)
+)
@@ -0,0 +1,110 @@
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/google/wire"
+	auditlog "github.com/raychongtk/wallet/model/audit"
+	"github.com/raychongtk/wallet/repository"
+	"github.com/raychongtk/wallet/tracing"
+	"gorm.io/gorm"
+	"time"
+)
+
+var (
+	WireSet = wire.NewSet(ProvideAuditor)
+)
+
+const (
+	ActorUser     = "user"
+	ActorService  = "service"
+	ActorOperator = "operator"
+
+	TargetUser             = "user"
+	TargetWallet           = "wallet"
+	TargetMovement         = "movement"
+	TargetArchive          = "archive"
+	TargetCheckpoint       = "checkpoint"
+	TargetPayout           = "payout"
+	TargetSchedule         = "schedule"
+	TargetPaymentRequest   = "payment_request"
+	TargetHold             = "hold"
+	TargetEscrow           = "escrow"
+	TargetInterest         = "interest"
+	TargetPocket           = "pocket"
+	TargetAccount          = "account"
+	TargetSharedTransfer   = "shared_transfer"
+	TargetBusiness         = "business"
+	TargetBusinessTransfer = "business_transfer"
+
+	OutcomeSuccess = "success"
+	OutcomeFailure = "failure"
+	OutcomeDenied  = "denied"
+)
+
+// Entry is what a caller knows about an action, before and after are marshalled to JSON as they are
+type Entry struct {
+	ActorType  string
+	ActorID    string
+	Action     string
+	TargetType string
+	TargetID   string
+	Before     interface{}
+	After      interface{}
+	SourceIP   string
+	RequestID  string
+	TraceID    string
+	Outcome    string
+	ErrorCode  string
+}
+
+// Auditor appends audit logs. Records are written outside of the audited transaction so failed and denied
+// attempts are kept even when the business change is rolled back.
+type Auditor struct {
+	auditLogRepo repository.AuditLogRepository
+	db           gorm.DB
+}
+
+func ProvideAuditor(auditLogRepo repository.AuditLogRepository, db gorm.DB) *Auditor {
+	return &Auditor{auditLogRepo: auditLogRepo, db: db}
+}
+
+// Record appends one audit log, a nil auditor records nothing so optional callers do not need to check
+func (a *Auditor) Record(ctx context.Context, entry Entry) error {
+	if a == nil {
+		return nil
+	}
+	before, err := marshal(entry.Before)
+	if err != nil {
+		return err
+	}
+	after, err := marshal(entry.After)
+	if err != nil {
+		return err
+	}
+	if entry.TraceID == "" {
+		entry.TraceID = tracing.TraceID(ctx)
+	}
+	return a.auditLogRepo.CreateAuditLog(a.db.WithContext(ctx), &auditlog.Log{
+		ActorType:  entry.ActorType,
+		ActorID:    entry.ActorID,
+		Action:     entry.Action,
+		TargetType: entry.TargetType,
+		TargetID:   entry.TargetID,
+		Before:     before,
+		After:      after,
+		SourceIP:   entry.SourceIP,
+		RequestID:  entry.RequestID,
+		TraceID:    entry.TraceID,
+		Outcome:    entry.Outcome,
+		ErrorCode:  entry.ErrorCode,
+		CreatedAt:  time.Now(),
+	})
+}
+
+func marshal(value interface{}) (json.RawMessage, error) {
+	if value == nil {
+		return nil, nil
+	}
+	return json.Marshal(value)
+}
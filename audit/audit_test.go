@@ -0,0 +1,107 @@
+package audit
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/raychongtk/wallet/domain"
+	auditlog "github.com/raychongtk/wallet/model/audit"
+	"github.com/raychongtk/wallet/problem"
+	"github.com/raychongtk/wallet/repository"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+type memoryAuditLogRepository struct {
+	logs []auditlog.Log
+}
+
+func (m *memoryAuditLogRepository) CreateAuditLog(db *gorm.DB, log *auditlog.Log) error {
+	m.logs = append(m.logs, *log)
+	return nil
+}
+
+func (m *memoryAuditLogRepository) SearchAuditLogs(filter repository.AuditLogFilter, afterID int64, limit int) ([]auditlog.Log, error) {
+	return m.logs, nil
+}
+
+func newTestAuditor(t *testing.T) (*Auditor, *memoryAuditLogRepository) {
+	// the memory repository never touches the connection, it is only opened lazily
+	db, err := gorm.Open(postgres.Open("host=127.0.0.1"), &gorm.Config{DisableAutomaticPing: true})
+	assert.NoError(t, err)
+	repo := &memoryAuditLogRepository{}
+	return ProvideAuditor(repo, *db), repo
+}
+
+func TestMiddlewareRecordsOutcome(t *testing.T) {
+	auditor, repo := newTestAuditor(t)
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.POST("/success", auditor.Middleware("wallet.deposit"), func(c *gin.Context) {
+		Actor(c, ActorUser, "john")
+		Target(c, TargetWallet, "wallet-1")
+		Change(c, gin.H{"balance": 0}, gin.H{"balance": 100})
+		c.JSON(http.StatusOK, gin.H{"result": true})
+	})
+	r.POST("/denied", auditor.Middleware("wallet.deposit"), func(c *gin.Context) {
+		problem.Respond(c, domain.ErrDuplicateRequest)
+	})
+	r.POST("/failure", auditor.Middleware("wallet.deposit"), func(c *gin.Context) {
+		c.JSON(http.StatusInternalServerError, gin.H{"result": false, "error_code": "INTERNAL_ERROR"})
+	})
+
+	for _, path := range []string{"/success", "/denied", "/failure"} {
+		req := httptest.NewRequest(http.MethodPost, path, nil)
+		req.Header.Set("X-Request-ID", "request"+path)
+		r.ServeHTTP(httptest.NewRecorder(), req)
+	}
+
+	assert.Len(t, repo.logs, 3)
+	assert.Equal(t, OutcomeSuccess, repo.logs[0].Outcome)
+	assert.Equal(t, "", repo.logs[0].ErrorCode)
+	assert.Equal(t, "john", repo.logs[0].ActorID)
+	assert.Equal(t, TargetWallet, repo.logs[0].TargetType)
+	assert.JSONEq(t, `{"balance":0}`, string(repo.logs[0].Before))
+	assert.JSONEq(t, `{"balance":100}`, string(repo.logs[0].After))
+	assert.Equal(t, "request/success", repo.logs[0].RequestID)
+
+	assert.Equal(t, OutcomeDenied, repo.logs[1].Outcome)
+	assert.Equal(t, "DUPLICATE_REQUEST", repo.logs[1].ErrorCode)
+	assert.Equal(t, AnonymousActor, repo.logs[1].ActorID)
+	assert.Nil(t, repo.logs[1].Before)
+
+	assert.Equal(t, OutcomeFailure, repo.logs[2].Outcome)
+	assert.Equal(t, "INTERNAL_ERROR", repo.logs[2].ErrorCode)
+}
+
+func TestMiddlewarePrefersAuthenticatedActor(t *testing.T) {
+	auditor, repo := newTestAuditor(t)
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.POST("/", auditor.Middleware("wallet.withdrawal"), func(c *gin.Context) {
+		Actor(c, ActorUser, "john")
+		c.JSON(http.StatusOK, gin.H{"result": true})
+	})
+
+	req := httptest.NewRequest(http.MethodPost, "/", nil)
+	req.Header.Set(ActorTypeHeader, ActorOperator)
+	req.Header.Set(ActorIDHeader, "alice")
+	r.ServeHTTP(httptest.NewRecorder(), req)
+
+	assert.Len(t, repo.logs, 1)
+	assert.Equal(t, ActorOperator, repo.logs[0].ActorType)
+	assert.Equal(t, "alice", repo.logs[0].ActorID)
+}
+
+func TestSearchRejectsInvalidRange(t *testing.T) {
+	auditor, _ := newTestAuditor(t)
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.GET("/", auditor.Search)
+
+	resp := httptest.NewRecorder()
+	r.ServeHTTP(resp, httptest.NewRequest(http.MethodGet, "/?from=2024-02-01T00:00:00Z&to=2024-01-01T00:00:00Z", nil))
+	assert.Equal(t, http.StatusBadRequest, resp.Code)
+}
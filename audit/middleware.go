@@ -0,0 +1,147 @@
+package audit
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/gin-gonic/gin"
+	"github.com/raychongtk/wallet/tracing"
+	"github.com/raychongtk/wallet/util"
+	"go.uber.org/zap"
+)
+
+const (
+	// ActorTypeHeader and ActorIDHeader are set by the gateway after it authenticated the caller
+	ActorTypeHeader = "X-Actor-Type"
+	ActorIDHeader   = "X-Actor-ID"
+	AnonymousActor  = "anonymous"
+
+	detailsKey = "audit.details"
+	// maxCapture bounds how much of a response is kept, outcomes are read from small JSON bodies and exports are large
+	maxCapture = 4096
+)
+
+type details struct {
+	actorType  string
+	actorID    string
+	targetType string
+	targetID   string
+	before     interface{}
+	after      interface{}
+}
+
+// Middleware records one audit log for every request to the route. It must run before any middleware that can
+// reject the request so denied attempts are audited too. Responses below 400 are a success when they do not
+// report result false, 4xx responses were denied and 5xx responses failed.
+func (a *Auditor) Middleware(action string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		d := &details{}
+		c.Set(detailsKey, d)
+		writer := &capturingWriter{ResponseWriter: c.Writer}
+		c.Writer = writer
+		c.Next()
+
+		var response struct {
+			Result    *bool  `json:"result"`
+			ErrorCode string `json:"error_code"`
+		}
+		_ = json.Unmarshal(writer.body.Bytes(), &response)
+		outcome, errorCode := OutcomeSuccess, response.ErrorCode
+		switch {
+		case writer.Status() >= 500:
+			outcome = OutcomeFailure
+		case writer.Status() >= 400:
+			outcome = OutcomeDenied
+		case response.Result != nil && !*response.Result:
+			outcome = OutcomeFailure
+		default:
+			errorCode = ""
+		}
+
+		actorType, actorID := actor(c, d)
+		entry := Entry{
+			ActorType:  actorType,
+			ActorID:    actorID,
+			Action:     action,
+			TargetType: d.targetType,
+			TargetID:   d.targetID,
+			Before:     d.before,
+			After:      d.after,
+			SourceIP:   c.ClientIP(),
+			RequestID:  c.GetHeader(tracing.RequestIDHeader),
+			Outcome:    outcome,
+			ErrorCode:  errorCode,
+		}
+		if err := a.Record(c.Request.Context(), entry); err != nil {
+			util.Error("Record audit log failed", zap.String("action", action), zap.String("request_id", entry.RequestID), zap.Error(err))
+		}
+	}
+}
+
+// Actor names who acts when the gateway did not, typically the user the request is made for
+func Actor(c *gin.Context, actorType string, actorID string) {
+	if d := get(c); d != nil {
+		d.actorType, d.actorID = actorType, actorID
+	}
+}
+
+// Target names what the request acts on, a later call replaces an earlier one as the handler learns more
+func Target(c *gin.Context, targetType string, targetID string) {
+	if d := get(c); d != nil {
+		d.targetType, d.targetID = targetType, targetID
+	}
+}
+
+// Change keeps the state of the target before and after a successful change
+func Change(c *gin.Context, before interface{}, after interface{}) {
+	if d := get(c); d != nil {
+		d.before, d.after = before, after
+	}
+}
+
+func get(c *gin.Context) *details {
+	value, ok := c.Get(detailsKey)
+	if !ok {
+		return nil
+	}
+	return value.(*details)
+}
+
+func actor(c *gin.Context, d *details) (string, string) {
+	if actorID := c.GetHeader(ActorIDHeader); actorID != "" {
+		switch actorType := c.GetHeader(ActorTypeHeader); actorType {
+		case ActorService, ActorOperator:
+			return actorType, actorID
+		default:
+			return ActorUser, actorID
+		}
+	}
+	if d.actorID != "" {
+		return d.actorType, d.actorID
+	}
+	return ActorUser, AnonymousActor
+}
+
+// capturingWriter keeps a copy of the response body to read the outcome from
+type capturingWriter struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (w *capturingWriter) Write(data []byte) (int, error) {
+	w.keep(data)
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *capturingWriter) WriteString(data string) (int, error) {
+	w.keep([]byte(data))
+	return w.ResponseWriter.WriteString(data)
+}
+
+func (w *capturingWriter) keep(data []byte) {
+	if room := maxCapture - w.body.Len(); room > 0 {
+		if len(data) > room {
+			data = data[:room]
+		}
+		w.body.Write(data)
+	}
+}
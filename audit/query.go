@@ -0,0 +1,202 @@
+package audit
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"github.com/raychongtk/wallet/domain"
+	auditlog "github.com/raychongtk/wallet/model/audit"
+	"github.com/raychongtk/wallet/problem"
+	"github.com/raychongtk/wallet/repository"
+	"github.com/raychongtk/wallet/util"
+	"go.uber.org/zap"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	defaultLimit = 100
+	maxLimit     = 1000
+	exportPage   = 500
+)
+
+// Search lists audit logs matching the filter in the order they were recorded, pass next_after as after for the next page
+func (a *Auditor) Search(ctx *gin.Context) {
+	filter, err := parseFilter(ctx)
+	if err != nil {
+		problem.Respond(ctx, domain.ErrInvalidParameters)
+		return
+	}
+	after, err := parseInt(ctx.Query("after"), 0)
+	if err != nil || after < 0 {
+		problem.Respond(ctx, domain.ErrInvalidParameters)
+		return
+	}
+	limit, err := parseInt(ctx.Query("limit"), defaultLimit)
+	if err != nil || limit <= 0 || limit > maxLimit {
+		problem.Respond(ctx, domain.ErrInvalidParameters)
+		return
+	}
+	logs, err := a.auditLogRepo.SearchAuditLogs(filter, after, int(limit))
+	if err != nil {
+		util.Error("Search audit logs failed", zap.Error(err))
+		problem.Respond(ctx, err)
+		return
+	}
+	response := &SearchAuditLogResponse{Result: true, Logs: make([]AuditLog, 0, len(logs))}
+	for _, log := range logs {
+		response.Logs = append(response.Logs, NewAuditLog(log))
+	}
+	if len(logs) == int(limit) {
+		response.NextAfter = logs[len(logs)-1].ID
+	}
+	ctx.JSON(http.StatusOK, response)
+}
+
+// Export streams every audit log matching the filter as NDJSON, or as CSV with format=csv
+func (a *Auditor) Export(ctx *gin.Context) {
+	filter, err := parseFilter(ctx)
+	format := ctx.DefaultQuery("format", "ndjson")
+	if err != nil || (format != "ndjson" && format != "csv") {
+		problem.Respond(ctx, domain.ErrInvalidParameters)
+		return
+	}
+	logs, err := a.auditLogRepo.SearchAuditLogs(filter, 0, exportPage)
+	if err != nil {
+		util.Error("Export audit logs failed", zap.Error(err))
+		problem.Respond(ctx, err)
+		return
+	}
+
+	var write func(log AuditLog) error
+	var flush func() error
+	if format == "csv" {
+		ctx.Header("Content-Type", "text/csv")
+		writer := csv.NewWriter(ctx.Writer)
+		write = func(log AuditLog) error { return writer.Write(log.csvRecord()) }
+		flush = func() error {
+			writer.Flush()
+			return writer.Error()
+		}
+		if err := writer.Write(csvHeader); err != nil {
+			util.Error("Write audit export failed", zap.Error(err))
+			return
+		}
+	} else {
+		ctx.Header("Content-Type", "application/x-ndjson")
+		encoder := json.NewEncoder(ctx.Writer)
+		write = func(log AuditLog) error { return encoder.Encode(log) }
+		flush = func() error { return nil }
+	}
+	ctx.Status(http.StatusOK)
+
+	for len(logs) > 0 {
+		for _, log := range logs {
+			if err := write(NewAuditLog(log)); err != nil {
+				util.Error("Write audit export failed", zap.Error(err))
+				return
+			}
+		}
+		if err := flush(); err != nil {
+			util.Error("Write audit export failed", zap.Error(err))
+			return
+		}
+		if len(logs) < exportPage {
+			return
+		}
+		// headers are sent already, a failure can only cut the export short
+		logs, err = a.auditLogRepo.SearchAuditLogs(filter, logs[len(logs)-1].ID, exportPage)
+		if err != nil {
+			util.Error("Export audit logs failed", zap.Error(err))
+			return
+		}
+	}
+}
+
+func parseFilter(ctx *gin.Context) (repository.AuditLogFilter, error) {
+	filter := repository.AuditLogFilter{
+		ActorType:  ctx.Query("actor_type"),
+		ActorID:    ctx.Query("actor_id"),
+		Action:     ctx.Query("action"),
+		TargetType: ctx.Query("target_type"),
+		TargetID:   ctx.Query("target_id"),
+	}
+	var err error
+	if filter.From, err = parseTime(ctx.Query("from")); err != nil {
+		return filter, err
+	}
+	if filter.To, err = parseTime(ctx.Query("to")); err != nil {
+		return filter, err
+	}
+	if !filter.From.IsZero() && !filter.To.IsZero() && !filter.From.Before(filter.To) {
+		return filter, errors.New("from must be before to")
+	}
+	return filter, nil
+}
+
+func parseTime(value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	return time.Parse(time.RFC3339, value)
+}
+
+func parseInt(value string, fallback int64) (int64, error) {
+	if value == "" {
+		return fallback, nil
+	}
+	return strconv.ParseInt(value, 10, 64)
+}
+
+var csvHeader = []string{"id", "created_at", "actor_type", "actor_id", "action", "target_type", "target_id",
+	"before", "after", "source_ip", "request_id", "trace_id", "outcome", "error_code"}
+
+func NewAuditLog(log auditlog.Log) AuditLog {
+	return AuditLog{
+		ID:         log.ID,
+		ActorType:  log.ActorType,
+		ActorID:    log.ActorID,
+		Action:     log.Action,
+		TargetType: log.TargetType,
+		TargetID:   log.TargetID,
+		Before:     log.Before,
+		After:      log.After,
+		SourceIP:   log.SourceIP,
+		RequestID:  log.RequestID,
+		TraceID:    log.TraceID,
+		Outcome:    log.Outcome,
+		ErrorCode:  log.ErrorCode,
+		CreatedAt:  log.CreatedAt.UTC(),
+	}
+}
+
+func (log AuditLog) csvRecord() []string {
+	return []string{strconv.FormatInt(log.ID, 10), log.CreatedAt.Format(time.RFC3339Nano), log.ActorType, log.ActorID,
+		log.Action, log.TargetType, log.TargetID, string(log.Before), string(log.After), log.SourceIP, log.RequestID,
+		log.TraceID, log.Outcome, log.ErrorCode}
+}
+
+type SearchAuditLogResponse struct {
+	Result    bool       `json:"result" binding:"required"`
+	Logs      []AuditLog `json:"logs"`
+	NextAfter int64      `json:"next_after,omitempty"`
+}
+
+type AuditLog struct {
+	ID         int64           `json:"id"`
+	ActorType  string          `json:"actor_type"`
+	ActorID    string          `json:"actor_id"`
+	Action     string          `json:"action"`
+	TargetType string          `json:"target_type,omitempty"`
+	TargetID   string          `json:"target_id,omitempty"`
+	Before     json.RawMessage `json:"before,omitempty"`
+	After      json.RawMessage `json:"after,omitempty"`
+	SourceIP   string          `json:"source_ip,omitempty"`
+	RequestID  string          `json:"request_id,omitempty"`
+	TraceID    string          `json:"trace_id,omitempty"`
+	Outcome    string          `json:"outcome"`
+	ErrorCode  string          `json:"error_code,omitempty"`
+	CreatedAt  time.Time       `json:"created_at"`
+}
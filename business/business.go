@@ -0,0 +1,647 @@
+package business
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/google/wire"
+	"github.com/raychongtk/wallet/domain"
+	"github.com/raychongtk/wallet/ledger"
+	"github.com/raychongtk/wallet/model/payment"
+	"github.com/raychongtk/wallet/model/wallet"
+	"github.com/raychongtk/wallet/notify"
+	"github.com/raychongtk/wallet/repository"
+	"github.com/raychongtk/wallet/util"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"regexp"
+	"strings"
+	"time"
+)
+
+var (
+	WireSet = wire.NewSet(ProvideManager)
+
+	countryPattern = regexp.MustCompile(`^[A-Z]{2}$`)
+)
+
+const (
+	maxLegalNameLength          = 100
+	maxRegistrationNumberLength = 50
+	maxMemoLength               = 200
+	maxCommentLength            = 200
+)
+
+// Manager runs the business accounts of organizations. Employees act on them as members with a role, and a transfer
+// above the amount of a payment policy waits in the queue for the approvals the policy asks for.
+type Manager struct {
+	ledger           *ledger.Ledger
+	organizationRepo repository.OrganizationRepository
+	memberRepo       repository.BusinessMemberRepository
+	policyRepo       repository.PaymentPolicyRepository
+	transferRepo     repository.BusinessTransferRepository
+	db               gorm.DB
+	notifier         *notify.Notifier
+}
+
+func ProvideManager(
+	ledger *ledger.Ledger,
+	organizationRepo repository.OrganizationRepository,
+	memberRepo repository.BusinessMemberRepository,
+	policyRepo repository.PaymentPolicyRepository,
+	transferRepo repository.BusinessTransferRepository,
+	db gorm.DB,
+	notifier *notify.Notifier,
+) *Manager {
+	return &Manager{
+		ledger:           ledger,
+		organizationRepo: organizationRepo,
+		memberRepo:       memberRepo,
+		policyRepo:       policyRepo,
+		transferRepo:     transferRepo,
+		db:               db,
+		notifier:         notifier,
+	}
+}
+
+// OpenCommand opens a business account for an organization, AdminUserID becomes its first admin
+type OpenCommand struct {
+	AdminUserID        uuid.UUID
+	LegalName          string
+	RegistrationNumber string
+	Country            string
+	Email              string
+}
+
+// Open stores the organization with its business account, a wallet in the currency of the admin, and the admin as an
+// active member in one transaction
+func (m *Manager) Open(ctx context.Context, cmd OpenCommand) (*wallet.Organization, error) {
+	legalName, registrationNumber := strings.TrimSpace(cmd.LegalName), strings.TrimSpace(cmd.RegistrationNumber)
+	if legalName == "" || len(legalName) > maxLegalNameLength {
+		return nil, domain.ErrInvalidParameters.WithMessage("legal name is 1 to %d characters", maxLegalNameLength)
+	}
+	if registrationNumber == "" || len(registrationNumber) > maxRegistrationNumberLength {
+		return nil, domain.ErrInvalidParameters.WithMessage("registration number is 1 to %d characters", maxRegistrationNumberLength)
+	}
+	if !countryPattern.MatchString(cmd.Country) {
+		return nil, domain.ErrInvalidParameters.WithMessage("country is a two letter code like HK")
+	}
+	if !strings.Contains(cmd.Email, "@") {
+		return nil, domain.ErrInvalidParameters.WithMessage("email is invalid")
+	}
+	admin, err := m.person(cmd.AdminUserID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	organization := &wallet.Organization{
+		ID:                 uuid.New(),
+		LegalName:          legalName,
+		RegistrationNumber: registrationNumber,
+		Country:            cmd.Country,
+		Email:              cmd.Email,
+		CreatedAt:          now,
+		UpdatedAt:          now,
+	}
+	account := &wallet.Account{
+		ID:          uuid.New(),
+		UserID:      organization.ID,
+		AccountType: wallet.AccountTypeBusiness,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+	businessWallet := &wallet.Wallet{
+		ID:           uuid.New(),
+		AccountID:    account.ID,
+		Currency:     admin.Wallet.Currency,
+		DecimalPlace: admin.Wallet.DecimalPlace,
+		WalletStatus: wallet.StatusActive,
+		Kind:         wallet.KindMain,
+		CreatedAt:    now,
+		UpdatedAt:    now,
+	}
+	member := &wallet.BusinessMember{
+		ID:              uuid.New(),
+		OrganizationID:  organization.ID,
+		UserID:          cmd.AdminUserID,
+		Role:            wallet.BusinessRoleAdmin,
+		Status:          wallet.BusinessMemberActive,
+		InvitedByUserID: cmd.AdminUserID,
+		CreatedAt:       now,
+		UpdatedAt:       now,
+		JoinedAt:        &now,
+	}
+	err = m.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := m.organizationRepo.CreateOrganization(tx, organization, account, businessWallet); err != nil {
+			return err
+		}
+		return m.memberRepo.CreateBusinessMember(tx, member)
+	})
+	if err != nil {
+		return nil, repository.DBError(err)
+	}
+	util.Info("Open business account successfully", zap.String("organization_id", organization.ID.String()))
+	return organization, nil
+}
+
+// Member resolves the active membership of a user in an organization. To anyone else, invited members included, the
+// organization is not found.
+func (m *Manager) Member(organizationID uuid.UUID, userID uuid.UUID) (*wallet.Organization, *wallet.BusinessMember, error) {
+	member, err := m.memberRepo.GetBusinessMember(organizationID, userID)
+	if err == nil && !member.Active() {
+		err = domain.ErrNotFound
+	}
+	if err != nil {
+		return nil, nil, domain.NotFound(err, "business %s not found", organizationID.String())
+	}
+	organization, err := m.organizationRepo.GetOrganization(organizationID)
+	if err != nil {
+		return nil, nil, domain.NotFound(err, "business %s not found", organizationID.String())
+	}
+	return organization, member, nil
+}
+
+// Memberships lists the organizations a user is a member of or invited to
+func (m *Manager) Memberships(userID uuid.UUID) ([]wallet.BusinessMember, error) {
+	return m.memberRepo.SearchBusinessMemberships(userID)
+}
+
+// Members lists the members of an organization with the ones still invited, which every member may see
+func (m *Manager) Members(organizationID uuid.UUID, userID uuid.UUID) ([]wallet.BusinessMember, error) {
+	if _, _, err := m.Member(organizationID, userID); err != nil {
+		return nil, err
+	}
+	return m.memberRepo.SearchBusinessMembers(organizationID)
+}
+
+// MemberCommand has AdminUserID invite UserID to an organization with a Role, or change the role of a member
+type MemberCommand struct {
+	OrganizationID uuid.UUID
+	AdminUserID    uuid.UUID
+	UserID         uuid.UUID
+	Role           string
+}
+
+// SaveMember invites a user, who acts on the business account once they accept, or changes the role of a member. An
+// organization keeps at least one active admin.
+func (m *Manager) SaveMember(ctx context.Context, cmd MemberCommand) (*wallet.BusinessMember, error) {
+	switch cmd.Role {
+	case wallet.BusinessRoleAdmin, wallet.BusinessRoleInitiator, wallet.BusinessRoleApprover, wallet.BusinessRoleViewer:
+	default:
+		return nil, domain.ErrInvalidParameters.WithMessage("role must be ADMIN, INITIATOR, APPROVER or VIEWER")
+	}
+	organization, err := m.admin(cmd.OrganizationID, cmd.AdminUserID)
+	if err != nil {
+		return nil, err
+	}
+	member, err := m.memberRepo.GetBusinessMember(cmd.OrganizationID, cmd.UserID)
+	if err == nil {
+		if cmd.Role != wallet.BusinessRoleAdmin {
+			if err := m.keepAdmin(member); err != nil {
+				return nil, err
+			}
+		}
+		member.Role = cmd.Role
+		if err := m.memberRepo.UpdateBusinessMember(member); err != nil {
+			return nil, err
+		}
+		util.Info("Change business member successfully", zap.String("organization_id", cmd.OrganizationID.String()), zap.String("user_id", cmd.UserID.String()), zap.String("role", cmd.Role))
+		return member, nil
+	}
+	if !errors.Is(err, domain.ErrNotFound) {
+		return nil, err
+	}
+	if _, err := m.person(cmd.UserID); err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	member = &wallet.BusinessMember{
+		ID:              uuid.New(),
+		OrganizationID:  cmd.OrganizationID,
+		UserID:          cmd.UserID,
+		Role:            cmd.Role,
+		Status:          wallet.BusinessMemberInvited,
+		InvitedByUserID: cmd.AdminUserID,
+		CreatedAt:       now,
+		UpdatedAt:       now,
+	}
+	if err := m.memberRepo.CreateBusinessMember(&m.db, member); err != nil {
+		return nil, err
+	}
+	data := map[string]interface{}{"organization_id": organization.ID, "role": cmd.Role}
+	message := fmt.Sprintf("You are invited to act on the business account of %s as %s", organization.LegalName, strings.ToLower(cmd.Role))
+	if err := m.notifier.Notify(context.WithoutCancel(ctx), cmd.UserID, notify.KindBusinessInvitation, message, data); err != nil {
+		util.Error("Notify business invitation failed", zap.String("organization_id", organization.ID.String()), zap.Error(err))
+	}
+	util.Info("Invite business member successfully", zap.String("organization_id", cmd.OrganizationID.String()), zap.String("user_id", cmd.UserID.String()), zap.String("role", cmd.Role))
+	return member, nil
+}
+
+// Accept makes an invited user an active member. Accepting again returns the membership as it is.
+func (m *Manager) Accept(ctx context.Context, organizationID uuid.UUID, userID uuid.UUID) (*wallet.BusinessMember, error) {
+	member, err := m.memberRepo.GetBusinessMember(organizationID, userID)
+	if err != nil {
+		return nil, domain.NotFound(err, "business %s not found", organizationID.String())
+	}
+	if member.Active() {
+		return member, nil
+	}
+	now := time.Now()
+	member.Status, member.JoinedAt = wallet.BusinessMemberActive, &now
+	if err := m.memberRepo.UpdateBusinessMember(member); err != nil {
+		return nil, err
+	}
+	util.Info("Accept business invitation successfully", zap.String("organization_id", organizationID.String()), zap.String("user_id", userID.String()))
+	return member, nil
+}
+
+// RemoveMember takes a member or an invitation away. Admins remove anyone, a member may leave and an invited user may
+// decline, as long as an active admin is left.
+func (m *Manager) RemoveMember(ctx context.Context, organizationID uuid.UUID, byUserID uuid.UUID, userID uuid.UUID) error {
+	if byUserID != userID {
+		if _, err := m.admin(organizationID, byUserID); err != nil {
+			return err
+		}
+	}
+	member, err := m.memberRepo.GetBusinessMember(organizationID, userID)
+	if err != nil {
+		return domain.NotFound(err, "business %s not found", organizationID.String())
+	}
+	if err := m.keepAdmin(member); err != nil {
+		return err
+	}
+	if err := m.memberRepo.DeleteBusinessMember(organizationID, userID); err != nil {
+		return err
+	}
+	util.Info("Remove business member successfully", zap.String("organization_id", organizationID.String()), zap.String("user_id", userID.String()))
+	return nil
+}
+
+// Policies lists the payment policies of an organization from the lowest amount up
+func (m *Manager) Policies(organizationID uuid.UUID, userID uuid.UUID) ([]payment.PaymentPolicy, error) {
+	if _, _, err := m.Member(organizationID, userID); err != nil {
+		return nil, err
+	}
+	return m.policyRepo.SearchPaymentPolicies(organizationID)
+}
+
+// PolicyCommand asks for Approvals approvers on a transfer above AboveAmount, in minor units
+type PolicyCommand struct {
+	AboveAmount int
+	Approvals   int
+}
+
+// SetPolicies replaces the payment policies of an organization, which only an admin may do. No policy pays every
+// transfer right away.
+func (m *Manager) SetPolicies(ctx context.Context, organizationID uuid.UUID, adminUserID uuid.UUID, commands []PolicyCommand) ([]payment.PaymentPolicy, error) {
+	if _, err := m.admin(organizationID, adminUserID); err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	amounts := map[int]bool{}
+	policies := make([]payment.PaymentPolicy, 0, len(commands))
+	for _, cmd := range commands {
+		if cmd.AboveAmount < 0 || cmd.Approvals < 1 {
+			return nil, domain.ErrInvalidParameters.WithMessage("a policy is above an amount that is not negative and needs at least one approval")
+		}
+		if amounts[cmd.AboveAmount] {
+			return nil, domain.ErrInvalidParameters.WithMessage("there is one policy above %s", util.DisplayAmount(cmd.AboveAmount))
+		}
+		amounts[cmd.AboveAmount] = true
+		policies = append(policies, payment.PaymentPolicy{
+			ID:             uuid.New(),
+			OrganizationID: organizationID,
+			AboveAmount:    cmd.AboveAmount,
+			Approvals:      cmd.Approvals,
+			CreatedAt:      now,
+			UpdatedAt:      now,
+		})
+	}
+	if err := m.policyRepo.ReplacePaymentPolicies(organizationID, policies); err != nil {
+		return nil, err
+	}
+	util.Info("Set payment policies successfully", zap.String("organization_id", organizationID.String()), zap.Int("policies", len(policies)))
+	return m.policyRepo.SearchPaymentPolicies(organizationID)
+}
+
+// TransferCommand has InitiatorUserID move Amount, in minor units, out of the business account of OrganizationID to
+// ToUserID
+type TransferCommand struct {
+	OrganizationID  uuid.UUID
+	InitiatorUserID uuid.UUID
+	ToUserID        uuid.UUID
+	Amount          int
+	Memo            string
+	RequestID       string
+}
+
+// Transfer pays out of a business account as the organization and keeps who initiated it. When a payment policy covers
+// the amount it is queued as PENDING and the approvers are asked to approve it, otherwise it is stored and paid right
+// away, and rejected when the ledger refuses to pay it. It fails when the organization has fewer approvers than the
+// policy asks for. Retrying a request id returns the transfer it made, paid when no policy covers it.
+func (m *Manager) Transfer(ctx context.Context, cmd TransferCommand) (*payment.BusinessTransfer, error) {
+	organization, member, err := m.Member(cmd.OrganizationID, cmd.InitiatorUserID)
+	if err != nil {
+		return nil, err
+	}
+	existing, err := m.transferRepo.GetBusinessTransferByRequestID(cmd.RequestID)
+	if err == nil {
+		return m.retry(ctx, existing, cmd)
+	}
+	if !errors.Is(err, domain.ErrNotFound) {
+		return nil, err
+	}
+	if !member.CanInitiate() {
+		return nil, domain.ErrForbidden.WithMessage("a %s may not initiate a transfer", strings.ToLower(member.Role))
+	}
+	if err := m.ledger.ValidAmount(cmd.Amount); err != nil {
+		return nil, err
+	}
+	memo := strings.TrimSpace(cmd.Memo)
+	if len(memo) > maxMemoLength {
+		return nil, domain.ErrInvalidParameters.WithMessage("memo is at most %d characters", maxMemoLength)
+	}
+	if cmd.ToUserID == organization.ID {
+		return nil, domain.ErrCannotTransferToSelf
+	}
+	if _, err := m.ledger.ActiveCustomer(organization.ID); err != nil {
+		return nil, err
+	}
+	if _, err := m.ledger.ActiveCustomer(cmd.ToUserID); err != nil {
+		return nil, err
+	}
+	required, err := m.requiredApprovals(organization.ID, cmd.Amount)
+	if err != nil {
+		return nil, err
+	}
+	approvers, err := m.approvers(organization.ID, cmd.InitiatorUserID)
+	if err != nil {
+		return nil, err
+	}
+	if len(approvers) < required {
+		return nil, domain.ErrInvalidParameters.WithMessage("the payment policy asks for %d approvals but %d members may approve", required, len(approvers))
+	}
+
+	now := time.Now()
+	transfer := &payment.BusinessTransfer{
+		ID:                uuid.New(),
+		OrganizationID:    organization.ID,
+		InitiatorUserID:   cmd.InitiatorUserID,
+		ToUserID:          cmd.ToUserID,
+		Amount:            cmd.Amount,
+		Memo:              memo,
+		RequiredApprovals: required,
+		Status:            payment.BusinessTransferPending,
+		RequestID:         cmd.RequestID,
+		CreatedAt:         now,
+		UpdatedAt:         now,
+	}
+	if err := m.transferRepo.CreateBusinessTransfer(m.db.WithContext(ctx), transfer); err != nil {
+		return nil, err
+	}
+	if required == 0 {
+		return m.payNow(ctx, transfer)
+	}
+	for _, approver := range approvers {
+		m.notify(ctx, approver, organization, transfer)
+	}
+	return transfer, nil
+}
+
+// retry returns the transfer a request id made before. One no policy covers that is still pending failed after it was
+// stored, so it is paid now.
+func (m *Manager) retry(ctx context.Context, transfer *payment.BusinessTransfer, cmd TransferCommand) (*payment.BusinessTransfer, error) {
+	if transfer.OrganizationID != cmd.OrganizationID || transfer.InitiatorUserID != cmd.InitiatorUserID {
+		return nil, domain.ErrDuplicateRequest.WithMessage("request id %s was used for another transfer", cmd.RequestID)
+	}
+	if transfer.Status != payment.BusinessTransferPending || transfer.RequiredApprovals > 0 {
+		return transfer, nil
+	}
+	return m.payNow(ctx, transfer)
+}
+
+// payNow pays a transfer no policy covers. When the ledger refuses it, it is rejected so it does not stay in the queue.
+func (m *Manager) payNow(ctx context.Context, transfer *payment.BusinessTransfer) (*payment.BusinessTransfer, error) {
+	paid, err := m.pay(ctx, transfer)
+	if !domain.IsRefusal(err) {
+		return paid, err
+	}
+	rejectErr := m.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		transfer, err := m.transferRepo.LockBusinessTransfer(tx, transfer.ID)
+		if err != nil || transfer.Status != payment.BusinessTransferPending {
+			return err
+		}
+		now := time.Now()
+		transfer.Status, transfer.DecidedAt = payment.BusinessTransferRejected, &now
+		return m.transferRepo.UpdateBusinessTransfer(tx, transfer)
+	})
+	if rejectErr != nil {
+		util.Error("Reject refused business transfer failed", zap.String("business_transfer_id", transfer.ID.String()), zap.Error(rejectErr))
+	}
+	return nil, err
+}
+
+// Approve records the approval of a pending transfer and pays it with the last approval the policy asks for. The
+// approver must be an admin or an approver other than the initiator. Approving again returns the transfer as it is.
+func (m *Manager) Approve(ctx context.Context, id uuid.UUID, userID uuid.UUID, comment string) (*payment.BusinessTransfer, error) {
+	return m.decide(ctx, id, userID, payment.DecisionApproved, comment)
+}
+
+// Reject closes a pending transfer without paying it. Any member who may approve it rejects it, as does the initiator.
+func (m *Manager) Reject(ctx context.Context, id uuid.UUID, userID uuid.UUID, comment string) (*payment.BusinessTransfer, error) {
+	return m.decide(ctx, id, userID, payment.DecisionRejected, comment)
+}
+
+// Approvals are the decisions on a transfer in the order they were made
+func (m *Manager) Approvals(transferID uuid.UUID) ([]payment.BusinessApproval, error) {
+	return m.transferRepo.SearchBusinessApprovals(&m.db, transferID)
+}
+
+// decide keeps the decision of a member next to the transfer, both in one transaction holding the transfer locked. An
+// approval then pays the transfer once it has all the approvals it needs. The organization of a transfer does not
+// change, so the membership is resolved before the transfer is locked.
+func (m *Manager) decide(ctx context.Context, id uuid.UUID, userID uuid.UUID, decision string, comment string) (*payment.BusinessTransfer, error) {
+	comment = strings.TrimSpace(comment)
+	if len(comment) > maxCommentLength {
+		return nil, domain.ErrInvalidParameters.WithMessage("comment is at most %d characters", maxCommentLength)
+	}
+	transfer, err := m.transferRepo.GetBusinessTransfer(id)
+	if err != nil {
+		return nil, err
+	}
+	_, member, err := m.Member(transfer.OrganizationID, userID)
+	if err != nil {
+		return nil, domain.NotFound(err, "business transfer not found")
+	}
+	initiator := transfer.InitiatorUserID == userID
+	if decision == payment.DecisionApproved && initiator {
+		return nil, domain.ErrForbidden.WithMessage("the initiator may not approve their own transfer")
+	}
+	if !member.CanApprove() && !(decision == payment.DecisionRejected && initiator) {
+		return nil, domain.ErrForbidden.WithMessage("a %s may not decide on a transfer", strings.ToLower(member.Role))
+	}
+	err = m.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var err error
+		transfer, err = m.transferRepo.LockBusinessTransfer(tx, id)
+		if err != nil {
+			return err
+		}
+		approvals, err := m.transferRepo.SearchBusinessApprovals(tx, transfer.ID)
+		if err != nil {
+			return err
+		}
+		for _, approval := range approvals {
+			if approval.UserID == userID && approval.Decision == decision {
+				return nil
+			}
+			if approval.UserID == userID {
+				return domain.ErrInvalidParameters.WithMessage("a member decides on a transfer once")
+			}
+		}
+		if transfer.Status != payment.BusinessTransferPending {
+			return domain.ErrBusinessTransferClosed.WithMessage("business transfer is %s", strings.ToLower(transfer.Status))
+		}
+		err = m.transferRepo.CreateBusinessApproval(tx, &payment.BusinessApproval{
+			ID:                 uuid.New(),
+			BusinessTransferID: transfer.ID,
+			UserID:             userID,
+			Decision:           decision,
+			Comment:            comment,
+			CreatedAt:          time.Now(),
+		})
+		if err != nil || decision == payment.DecisionApproved {
+			return err
+		}
+		now := time.Now()
+		transfer.Status, transfer.DecidedAt = payment.BusinessTransferRejected, &now
+		return m.transferRepo.UpdateBusinessTransfer(tx, transfer)
+	})
+	if err != nil {
+		return nil, repository.DBError(err)
+	}
+	util.Info("Decide business transfer successfully", zap.String("business_transfer_id", id.String()), zap.String("decision", decision))
+	if decision == payment.DecisionRejected {
+		return transfer, nil
+	}
+	return m.pay(ctx, transfer)
+}
+
+// pay transfers a business transfer from the organization and completes it in one unit of work once it has all the
+// approvals it needs. A transfer that is closed or still waits for approvals is returned as it is.
+func (m *Manager) pay(ctx context.Context, transfer *payment.BusinessTransfer) (*payment.BusinessTransfer, error) {
+	cmd := ledger.TransferCommand{
+		FromUserID:      transfer.OrganizationID,
+		ToUserID:        transfer.ToUserID,
+		Amount:          transfer.Amount,
+		RequestID:       transfer.TransferRequestID(),
+		InitiatorUserID: transfer.InitiatorUserID,
+	}
+	id := transfer.ID
+	err := m.ledger.PayApproved(ctx, cmd, func(tx ledger.Tx) (bool, error) {
+		var err error
+		transfer, err = tx.LockBusinessTransfer(id)
+		if err != nil || transfer.Status != payment.BusinessTransferPending {
+			return false, err
+		}
+		approvals, err := tx.SearchBusinessApprovals(id)
+		if err != nil {
+			return false, err
+		}
+		approved := 0
+		for _, approval := range approvals {
+			if approval.Decision == payment.DecisionApproved {
+				approved++
+			}
+		}
+		return approved >= transfer.RequiredApprovals, nil
+	}, func(tx ledger.Tx, result *ledger.Result) error {
+		now := time.Now()
+		transfer.Status, transfer.GroupID, transfer.DecidedAt = payment.BusinessTransferCompleted, &result.GroupID, &now
+		return tx.UpdateBusinessTransfer(transfer)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return transfer, nil
+}
+
+// requiredApprovals is what the policy with the highest amount under amount asks for, zero when none covers it
+func (m *Manager) requiredApprovals(organizationID uuid.UUID, amount int) (int, error) {
+	policies, err := m.policyRepo.SearchPaymentPolicies(organizationID)
+	if err != nil {
+		return 0, err
+	}
+	required := 0
+	for _, policy := range policies {
+		if amount > policy.AboveAmount {
+			required = policy.Approvals
+		}
+	}
+	return required, nil
+}
+
+// approvers are the active members who may approve a transfer of initiatorUserID
+func (m *Manager) approvers(organizationID uuid.UUID, initiatorUserID uuid.UUID) ([]uuid.UUID, error) {
+	members, err := m.memberRepo.SearchBusinessMembers(organizationID)
+	if err != nil {
+		return nil, err
+	}
+	var approvers []uuid.UUID
+	for _, member := range members {
+		if member.CanApprove() && member.UserID != initiatorUserID {
+			approvers = append(approvers, member.UserID)
+		}
+	}
+	return approvers, nil
+}
+
+// admin resolves an organization the user is an active admin of, members with another role are forbidden
+func (m *Manager) admin(organizationID uuid.UUID, userID uuid.UUID) (*wallet.Organization, error) {
+	organization, member, err := m.Member(organizationID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if member.Role != wallet.BusinessRoleAdmin {
+		return nil, domain.ErrForbidden.WithMessage("only an admin may do this")
+	}
+	return organization, nil
+}
+
+// keepAdmin fails when member is the last active admin of their organization
+func (m *Manager) keepAdmin(member *wallet.BusinessMember) error {
+	if member.Role != wallet.BusinessRoleAdmin || !member.Active() {
+		return nil
+	}
+	members, err := m.memberRepo.SearchBusinessMembers(member.OrganizationID)
+	if err != nil {
+		return err
+	}
+	for _, other := range members {
+		if other.UserID != member.UserID && other.Role == wallet.BusinessRoleAdmin && other.Active() {
+			return nil
+		}
+	}
+	return domain.ErrInvalidParameters.WithMessage("a business keeps at least one admin")
+}
+
+// person resolves a user, an organization cannot be a member of another one
+func (m *Manager) person(userID uuid.UUID) (*ledger.Customer, error) {
+	customer, err := m.ledger.Customer(userID)
+	if err != nil {
+		return nil, err
+	}
+	if customer.Organization != nil {
+		return nil, domain.ErrInvalidAccount.WithMessage("a member of a business is a user")
+	}
+	return customer, nil
+}
+
+func (m *Manager) notify(ctx context.Context, userID uuid.UUID, organization *wallet.Organization, transfer *payment.BusinessTransfer) {
+	data := map[string]interface{}{"business_transfer_id": transfer.ID, "organization_id": organization.ID, "amount": util.DisplayAmount(transfer.Amount)}
+	message := fmt.Sprintf("A transfer of %s out of the business account of %s waits for your approval", util.DisplayAmount(transfer.Amount), organization.LegalName)
+	if err := m.notifier.Notify(context.WithoutCancel(ctx), userID, notify.KindApprovalRequested, message, data); err != nil {
+		util.Error("Notify business transfer failed", zap.String("business_transfer_id", transfer.ID.String()), zap.Error(err))
+	}
+}
@@ -0,0 +1,12345 @@
+// Package client provides primitives to interact with the openapi HTTP API.
+//
+// Code generated by github.com/oapi-codegen/oapi-codegen/v2 version v2.4.1 DO NOT EDIT.
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/oapi-codegen/runtime"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+)
+
+// Defines values for AccountMemberRole.
+const (
+	AccountMemberRoleOWNER   AccountMemberRole = "OWNER"
+	AccountMemberRoleSPENDER AccountMemberRole = "SPENDER"
+	AccountMemberRoleVIEWER  AccountMemberRole = "VIEWER"
+)
+
+// Defines values for AuditLogOutcome.
+const (
+	Denied  AuditLogOutcome = "denied"
+	Failure AuditLogOutcome = "failure"
+	Success AuditLogOutcome = "success"
+)
+
+// Defines values for BusinessApprovalDecision.
+const (
+	BusinessApprovalDecisionAPPROVED BusinessApprovalDecision = "APPROVED"
+	BusinessApprovalDecisionREJECTED BusinessApprovalDecision = "REJECTED"
+)
+
+// Defines values for BusinessMemberRole.
+const (
+	BusinessMemberRoleADMIN     BusinessMemberRole = "ADMIN"
+	BusinessMemberRoleAPPROVER  BusinessMemberRole = "APPROVER"
+	BusinessMemberRoleINITIATOR BusinessMemberRole = "INITIATOR"
+	BusinessMemberRoleVIEWER    BusinessMemberRole = "VIEWER"
+)
+
+// Defines values for BusinessMemberStatus.
+const (
+	BusinessMemberStatusACTIVE  BusinessMemberStatus = "ACTIVE"
+	BusinessMemberStatusINVITED BusinessMemberStatus = "INVITED"
+)
+
+// Defines values for BusinessTransferStatus.
+const (
+	BusinessTransferStatusCOMPLETED BusinessTransferStatus = "COMPLETED"
+	BusinessTransferStatusPENDING   BusinessTransferStatus = "PENDING"
+	BusinessTransferStatusREJECTED  BusinessTransferStatus = "REJECTED"
+)
+
+// Defines values for CheckResultStatus.
+const (
+	CheckResultStatusDown CheckResultStatus = "down"
+	CheckResultStatusUp   CheckResultStatus = "up"
+)
+
+// Defines values for CreateEscrowRequestDeadlineAction.
+const (
+	CreateEscrowRequestDeadlineActionREFUND  CreateEscrowRequestDeadlineAction = "REFUND"
+	CreateEscrowRequestDeadlineActionRELEASE CreateEscrowRequestDeadlineAction = "RELEASE"
+)
+
+// Defines values for EscrowDeadlineAction.
+const (
+	EscrowDeadlineActionREFUND  EscrowDeadlineAction = "REFUND"
+	EscrowDeadlineActionRELEASE EscrowDeadlineAction = "RELEASE"
+)
+
+// Defines values for EscrowStatus.
+const (
+	EscrowStatusFUNDED   EscrowStatus = "FUNDED"
+	EscrowStatusREFUNDED EscrowStatus = "REFUNDED"
+	EscrowStatusRELEASED EscrowStatus = "RELEASED"
+	EscrowStatusSPLIT    EscrowStatus = "SPLIT"
+)
+
+// Defines values for HoldStatus.
+const (
+	HoldStatusACTIVE   HoldStatus = "ACTIVE"
+	HoldStatusCAPTURED HoldStatus = "CAPTURED"
+	HoldStatusEXPIRED  HoldStatus = "EXPIRED"
+	HoldStatusRELEASED HoldStatus = "RELEASED"
+)
+
+// Defines values for PaymentHistoryPayType.
+const (
+	ADJUSTMENT       PaymentHistoryPayType = "ADJUSTMENT"
+	CAPTURE          PaymentHistoryPayType = "CAPTURE"
+	DEPOSIT          PaymentHistoryPayType = "DEPOSIT"
+	ESCROW           PaymentHistoryPayType = "ESCROW"
+	ESCROWREFUND     PaymentHistoryPayType = "ESCROW_REFUND"
+	ESCROWRELEASE    PaymentHistoryPayType = "ESCROW_RELEASE"
+	INTEREST         PaymentHistoryPayType = "INTEREST"
+	PAYOUT           PaymentHistoryPayType = "PAYOUT"
+	POCKETDEPOSIT    PaymentHistoryPayType = "POCKET_DEPOSIT"
+	POCKETWITHDRAWAL PaymentHistoryPayType = "POCKET_WITHDRAWAL"
+	TRANSFER         PaymentHistoryPayType = "TRANSFER"
+	WITHDRAWAL       PaymentHistoryPayType = "WITHDRAWAL"
+)
+
+// Defines values for PaymentRequestStatus.
+const (
+	PaymentRequestStatusAPPROVED PaymentRequestStatus = "APPROVED"
+	PaymentRequestStatusDECLINED PaymentRequestStatus = "DECLINED"
+	PaymentRequestStatusEXPIRED  PaymentRequestStatus = "EXPIRED"
+	PaymentRequestStatusPENDING  PaymentRequestStatus = "PENDING"
+)
+
+// Defines values for PayoutBatchMode.
+const (
+	PayoutBatchModeALLORNOTHING PayoutBatchMode = "ALL_OR_NOTHING"
+	PayoutBatchModeBESTEFFORT   PayoutBatchMode = "BEST_EFFORT"
+)
+
+// Defines values for PayoutBatchStatus.
+const (
+	PayoutBatchStatusCOMPLETED          PayoutBatchStatus = "COMPLETED"
+	PayoutBatchStatusFAILED             PayoutBatchStatus = "FAILED"
+	PayoutBatchStatusPARTIALLYCOMPLETED PayoutBatchStatus = "PARTIALLY_COMPLETED"
+	PayoutBatchStatusPENDING            PayoutBatchStatus = "PENDING"
+	PayoutBatchStatusRUNNING            PayoutBatchStatus = "RUNNING"
+)
+
+// Defines values for PayoutLineStatus.
+const (
+	PayoutLineStatusFAILED  PayoutLineStatus = "FAILED"
+	PayoutLineStatusPAID    PayoutLineStatus = "PAID"
+	PayoutLineStatusPENDING PayoutLineStatus = "PENDING"
+)
+
+// Defines values for ReadinessResponseStatus.
+const (
+	ReadinessResponseStatusDown     ReadinessResponseStatus = "down"
+	ReadinessResponseStatusDraining ReadinessResponseStatus = "draining"
+	ReadinessResponseStatusUp       ReadinessResponseStatus = "up"
+)
+
+// Defines values for SaveAccountMemberRequestRole.
+const (
+	SaveAccountMemberRequestRoleOWNER   SaveAccountMemberRequestRole = "OWNER"
+	SaveAccountMemberRequestRoleSPENDER SaveAccountMemberRequestRole = "SPENDER"
+	SaveAccountMemberRequestRoleVIEWER  SaveAccountMemberRequestRole = "VIEWER"
+)
+
+// Defines values for SaveBusinessMemberRequestRole.
+const (
+	SaveBusinessMemberRequestRoleADMIN     SaveBusinessMemberRequestRole = "ADMIN"
+	SaveBusinessMemberRequestRoleAPPROVER  SaveBusinessMemberRequestRole = "APPROVER"
+	SaveBusinessMemberRequestRoleINITIATOR SaveBusinessMemberRequestRole = "INITIATOR"
+	SaveBusinessMemberRequestRoleVIEWER    SaveBusinessMemberRequestRole = "VIEWER"
+)
+
+// Defines values for ScheduledTransferStatus.
+const (
+	ScheduledTransferStatusACTIVE    ScheduledTransferStatus = "ACTIVE"
+	ScheduledTransferStatusCANCELLED ScheduledTransferStatus = "CANCELLED"
+	ScheduledTransferStatusCOMPLETED ScheduledTransferStatus = "COMPLETED"
+)
+
+// Defines values for ScheduledTransferRunStatus.
+const (
+	ScheduledTransferRunStatusFAILED    ScheduledTransferRunStatus = "FAILED"
+	ScheduledTransferRunStatusPENDING   ScheduledTransferRunStatus = "PENDING"
+	ScheduledTransferRunStatusRETRYING  ScheduledTransferRunStatus = "RETRYING"
+	ScheduledTransferRunStatusSKIPPED   ScheduledTransferRunStatus = "SKIPPED"
+	ScheduledTransferRunStatusSUCCEEDED ScheduledTransferRunStatus = "SUCCEEDED"
+)
+
+// Defines values for SharedTransferStatus.
+const (
+	SharedTransferStatusCOMPLETED SharedTransferStatus = "COMPLETED"
+	SharedTransferStatusDECLINED  SharedTransferStatus = "DECLINED"
+	SharedTransferStatusPENDING   SharedTransferStatus = "PENDING"
+)
+
+// Defines values for SubmitPayoutRequestMode.
+const (
+	SubmitPayoutRequestModeALLORNOTHING SubmitPayoutRequestMode = "ALL_OR_NOTHING"
+	SubmitPayoutRequestModeAllOrNothing SubmitPayoutRequestMode = "all_or_nothing"
+	SubmitPayoutRequestModeBESTEFFORT   SubmitPayoutRequestMode = "BEST_EFFORT"
+	SubmitPayoutRequestModeBestEffort   SubmitPayoutRequestMode = "best_effort"
+)
+
+// Defines values for ActorType.
+const (
+	ActorTypeOperator ActorType = "operator"
+	ActorTypeService  ActorType = "service"
+	ActorTypeUser     ActorType = "user"
+)
+
+// Defines values for GetSharedTransfersParamsStatus.
+const (
+	GetSharedTransfersParamsStatusCOMPLETED GetSharedTransfersParamsStatus = "COMPLETED"
+	GetSharedTransfersParamsStatusDECLINED  GetSharedTransfersParamsStatus = "DECLINED"
+	GetSharedTransfersParamsStatusPENDING   GetSharedTransfersParamsStatus = "PENDING"
+)
+
+// Defines values for SearchAuditLogsParamsXActorType.
+const (
+	SearchAuditLogsParamsXActorTypeOperator SearchAuditLogsParamsXActorType = "operator"
+	SearchAuditLogsParamsXActorTypeService  SearchAuditLogsParamsXActorType = "service"
+	SearchAuditLogsParamsXActorTypeUser     SearchAuditLogsParamsXActorType = "user"
+)
+
+// Defines values for ExportAuditLogsParamsFormat.
+const (
+	Csv    ExportAuditLogsParamsFormat = "csv"
+	Ndjson ExportAuditLogsParamsFormat = "ndjson"
+)
+
+// Defines values for ExportAuditLogsParamsXActorType.
+const (
+	Operator ExportAuditLogsParamsXActorType = "operator"
+	Service  ExportAuditLogsParamsXActorType = "service"
+	User     ExportAuditLogsParamsXActorType = "user"
+)
+
+// Defines values for GetBusinessTransfersParamsStatus.
+const (
+	GetBusinessTransfersParamsStatusCOMPLETED GetBusinessTransfersParamsStatus = "COMPLETED"
+	GetBusinessTransfersParamsStatusPENDING   GetBusinessTransfersParamsStatus = "PENDING"
+	GetBusinessTransfersParamsStatusREJECTED  GetBusinessTransfersParamsStatus = "REJECTED"
+)
+
+// Defines values for GetEscrowsParamsStatus.
+const (
+	GetEscrowsParamsStatusFUNDED   GetEscrowsParamsStatus = "FUNDED"
+	GetEscrowsParamsStatusREFUNDED GetEscrowsParamsStatus = "REFUNDED"
+	GetEscrowsParamsStatusRELEASED GetEscrowsParamsStatus = "RELEASED"
+	GetEscrowsParamsStatusSPLIT    GetEscrowsParamsStatus = "SPLIT"
+)
+
+// Defines values for GetHoldsParamsStatus.
+const (
+	GetHoldsParamsStatusACTIVE   GetHoldsParamsStatus = "ACTIVE"
+	GetHoldsParamsStatusCAPTURED GetHoldsParamsStatus = "CAPTURED"
+	GetHoldsParamsStatusEXPIRED  GetHoldsParamsStatus = "EXPIRED"
+	GetHoldsParamsStatusRELEASED GetHoldsParamsStatus = "RELEASED"
+)
+
+// Defines values for GetPaymentRequestsParamsRole.
+const (
+	Received GetPaymentRequestsParamsRole = "received"
+	Sent     GetPaymentRequestsParamsRole = "sent"
+)
+
+// Defines values for GetPaymentRequestsParamsStatus.
+const (
+	GetPaymentRequestsParamsStatusAPPROVED GetPaymentRequestsParamsStatus = "APPROVED"
+	GetPaymentRequestsParamsStatusDECLINED GetPaymentRequestsParamsStatus = "DECLINED"
+	GetPaymentRequestsParamsStatusEXPIRED  GetPaymentRequestsParamsStatus = "EXPIRED"
+	GetPaymentRequestsParamsStatusPENDING  GetPaymentRequestsParamsStatus = "PENDING"
+)
+
+// Defines values for SubmitPayoutParamsMode.
+const (
+	ALLORNOTHING SubmitPayoutParamsMode = "ALL_OR_NOTHING"
+	AllOrNothing SubmitPayoutParamsMode = "all_or_nothing"
+	BESTEFFORT   SubmitPayoutParamsMode = "BEST_EFFORT"
+	BestEffort   SubmitPayoutParamsMode = "best_effort"
+)
+
+// Defines values for GetPayoutLinesParamsStatus.
+const (
+	FAILED  GetPayoutLinesParamsStatus = "FAILED"
+	PAID    GetPayoutLinesParamsStatus = "PAID"
+	PENDING GetPayoutLinesParamsStatus = "PENDING"
+)
+
+// Defines values for GetFeeQuoteParamsOperation.
+const (
+	Transfer   GetFeeQuoteParamsOperation = "transfer"
+	Withdrawal GetFeeQuoteParamsOperation = "withdrawal"
+)
+
+// AccountMember defines model for AccountMember.
+type AccountMember struct {
+	AccountId     string            `json:"account_id"`
+	Role          AccountMemberRole `json:"role"`
+	SpendingLimit *string           `json:"spending_limit,omitempty"`
+	UserId        string            `json:"user_id"`
+}
+
+// AccountMemberRole defines model for AccountMember.Role.
+type AccountMemberRole string
+
+// ApprovalThresholdResponse defines model for ApprovalThresholdResponse.
+type ApprovalThresholdResponse struct {
+	AccountId string `json:"account_id"`
+	Amount    string `json:"amount"`
+}
+
+// AuditLog defines model for AuditLog.
+type AuditLog struct {
+	Action    string `json:"action"`
+	ActorId   string `json:"actor_id"`
+	ActorType string `json:"actor_type"`
+
+	// After state after the action, any JSON
+	After *interface{} `json:"after,omitempty"`
+
+	// Before state before the action, any JSON
+	Before     *interface{}    `json:"before,omitempty"`
+	CreatedAt  time.Time       `json:"created_at"`
+	ErrorCode  *string         `json:"error_code,omitempty"`
+	Id         int64           `json:"id"`
+	Outcome    AuditLogOutcome `json:"outcome"`
+	RequestId  *string         `json:"request_id,omitempty"`
+	SourceIp   *string         `json:"source_ip,omitempty"`
+	TargetId   *string         `json:"target_id,omitempty"`
+	TargetType *string         `json:"target_type,omitempty"`
+	TraceId    *string         `json:"trace_id,omitempty"`
+}
+
+// AuditLogOutcome defines model for AuditLog.Outcome.
+type AuditLogOutcome string
+
+// BalanceChange defines model for BalanceChange.
+type BalanceChange struct {
+	Amount      string `json:"amount"`
+	BalanceType string `json:"balance_type"`
+	WalletId    string `json:"wallet_id"`
+}
+
+// Business defines model for Business.
+type Business struct {
+	// BusinessId id of the organization, payments name it like a user
+	BusinessId         string `json:"business_id"`
+	Country            string `json:"country"`
+	CreatedAt          string `json:"created_at"`
+	Email              string `json:"email"`
+	LegalName          string `json:"legal_name"`
+	RegistrationNumber string `json:"registration_number"`
+}
+
+// BusinessApproval defines model for BusinessApproval.
+type BusinessApproval struct {
+	Comment   *string                  `json:"comment,omitempty"`
+	CreatedAt string                   `json:"created_at"`
+	Decision  BusinessApprovalDecision `json:"decision"`
+	UserId    string                   `json:"user_id"`
+}
+
+// BusinessApprovalDecision defines model for BusinessApproval.Decision.
+type BusinessApprovalDecision string
+
+// BusinessMember defines model for BusinessMember.
+type BusinessMember struct {
+	BusinessId string               `json:"business_id"`
+	InvitedBy  string               `json:"invited_by"`
+	JoinedAt   *string              `json:"joined_at,omitempty"`
+	Role       BusinessMemberRole   `json:"role"`
+	Status     BusinessMemberStatus `json:"status"`
+	UserId     string               `json:"user_id"`
+}
+
+// BusinessMemberRole defines model for BusinessMember.Role.
+type BusinessMemberRole string
+
+// BusinessMemberStatus defines model for BusinessMember.Status.
+type BusinessMemberStatus string
+
+// BusinessTransfer defines model for BusinessTransfer.
+type BusinessTransfer struct {
+	Amount string `json:"amount"`
+
+	// Approvals decisions in the order they were made, on a single transfer
+	Approvals  *[]BusinessApproval `json:"approvals,omitempty"`
+	BusinessId string              `json:"business_id"`
+	CreatedAt  string              `json:"created_at"`
+	DecidedAt  *string             `json:"decided_at,omitempty"`
+
+	// GroupId movement group of the payment
+	GroupId *string `json:"group_id,omitempty"`
+
+	// InitiatorUserId member who initiated the transfer
+	InitiatorUserId string  `json:"initiator_user_id"`
+	Memo            *string `json:"memo,omitempty"`
+
+	// RequiredApprovals approvals the payment policy asked for when it was initiated
+	RequiredApprovals int                    `json:"required_approvals"`
+	Status            BusinessTransferStatus `json:"status"`
+	ToUserId          string                 `json:"to_user_id"`
+	TransferId        string                 `json:"transfer_id"`
+}
+
+// BusinessTransferStatus defines model for BusinessTransfer.Status.
+type BusinessTransferStatus string
+
+// CaptureHoldRequest defines model for CaptureHoldRequest.
+type CaptureHoldRequest struct {
+	// Amount at most the held amount
+	Amount string `json:"amount"`
+}
+
+// CheckResult defines model for CheckResult.
+type CheckResult struct {
+	Critical  bool              `json:"critical"`
+	Error     *string           `json:"error,omitempty"`
+	LatencyMs int64             `json:"latency_ms"`
+	Status    CheckResultStatus `json:"status"`
+}
+
+// CheckResultStatus defines model for CheckResult.Status.
+type CheckResultStatus string
+
+// CheckpointExport defines model for CheckpointExport.
+type CheckpointExport struct {
+	Checkpoints []ExportedCheckpoint `json:"checkpoints"`
+	KeyId       *string              `json:"key_id,omitempty"`
+
+	// PublicKey base64 Ed25519 public key, absent when checkpoints are off
+	PublicKey *string `json:"public_key,omitempty"`
+}
+
+// CreateBusinessTransferRequest defines model for CreateBusinessTransferRequest.
+type CreateBusinessTransferRequest struct {
+	Amount string `json:"amount"`
+
+	// InitiatorUserId admin or initiator making the transfer
+	InitiatorUserId string  `json:"initiator_user_id"`
+	Memo            *string `json:"memo,omitempty"`
+	ToUserId        string  `json:"to_user_id"`
+}
+
+// CreateEscrowRequest defines model for CreateEscrowRequest.
+type CreateEscrowRequest struct {
+	Amount string `json:"amount"`
+
+	// BuyerUserId owner of the wallet the escrow is funded from
+	BuyerUserId string `json:"buyer_user_id"`
+
+	// Deadline escrow.default_deadline from now by default, at most escrow.max_deadline
+	Deadline *time.Time `json:"deadline,omitempty"`
+
+	// DeadlineAction what happens to the escrow when it is still funded at the deadline, REFUND by default
+	DeadlineAction *CreateEscrowRequestDeadlineAction `json:"deadline_action,omitempty"`
+
+	// Reference reference of the deal, e.g. an order number
+	Reference    *string `json:"reference,omitempty"`
+	SellerUserId string  `json:"seller_user_id"`
+}
+
+// CreateEscrowRequestDeadlineAction what happens to the escrow when it is still funded at the deadline, REFUND by default
+type CreateEscrowRequestDeadlineAction string
+
+// CreatePaymentRequestRequest defines model for CreatePaymentRequestRequest.
+type CreatePaymentRequestRequest struct {
+	Amount string `json:"amount"`
+
+	// ExpiresAt payment_request.default_expiry from now by default, at most payment_request.max_expiry
+	ExpiresAt       *time.Time `json:"expires_at,omitempty"`
+	Memo            *string    `json:"memo,omitempty"`
+	PayerUserId     string     `json:"payer_user_id"`
+	RequesterUserId string     `json:"requester_user_id"`
+}
+
+// CreatePocketRequest defines model for CreatePocketRequest.
+type CreatePocketRequest struct {
+	// GoalAmount amount the user saves towards
+	GoalAmount *string `json:"goal_amount,omitempty"`
+
+	// Locked nothing may be withdrawn before the target date, needs a target date
+	Locked *bool `json:"locked,omitempty"`
+
+	// Name unique among the pockets of the user, ignoring case
+	Name string `json:"name"`
+
+	// TargetDate date the user saves towards, in the future
+	TargetDate *openapi_types.Date `json:"target_date,omitempty"`
+	UserId     string              `json:"user_id"`
+}
+
+// CreateScheduledTransferRequest defines model for CreateScheduledTransferRequest.
+type CreateScheduledTransferRequest struct {
+	Amount string     `json:"amount"`
+	EndAt  *time.Time `json:"end_at,omitempty"`
+
+	// MaxRuns 0 does not limit the runs
+	MaxRuns     *int   `json:"max_runs,omitempty"`
+	PayeeUserId string `json:"payee_user_id"`
+	PayerUserId string `json:"payer_user_id"`
+
+	// Rule daily, weekly, monthly or a five field cron expression, e.g. 0 9 1 * *
+	Rule    string    `json:"rule"`
+	StartAt time.Time `json:"start_at"`
+
+	// TimeZone IANA time zone the rule is evaluated in, UTC by default
+	TimeZone *string `json:"time_zone,omitempty"`
+}
+
+// CreateSharedTransferRequest defines model for CreateSharedTransferRequest.
+type CreateSharedTransferRequest struct {
+	Amount string `json:"amount"`
+
+	// InitiatorUserId owner or spender making the transfer
+	InitiatorUserId string `json:"initiator_user_id"`
+	ToUserId        string `json:"to_user_id"`
+}
+
+// DecideBusinessTransferRequest defines model for DecideBusinessTransferRequest.
+type DecideBusinessTransferRequest struct {
+	Comment *string `json:"comment,omitempty"`
+	UserId  string  `json:"user_id"`
+}
+
+// DecideSharedTransferRequest defines model for DecideSharedTransferRequest.
+type DecideSharedTransferRequest struct {
+	UserId string `json:"user_id"`
+}
+
+// DepositRequest defines model for DepositRequest.
+type DepositRequest struct {
+	// Balance decimal amount in the wallet currency, e.g. "100.50"
+	Balance string `json:"balance"`
+	UserId  string `json:"user_id"`
+}
+
+// DepositResponse defines model for DepositResponse.
+type DepositResponse struct {
+	ErrorCode *string `json:"error_code,omitempty"`
+	Result    bool    `json:"result"`
+}
+
+// Escrow defines model for Escrow.
+type Escrow struct {
+	Amount         string               `json:"amount"`
+	BuyerUserId    string               `json:"buyer_user_id"`
+	CreatedAt      string               `json:"created_at"`
+	Deadline       string               `json:"deadline"`
+	DeadlineAction EscrowDeadlineAction `json:"deadline_action"`
+	EscrowId       string               `json:"escrow_id"`
+
+	// FundGroupId movement group that funded the escrow
+	FundGroupId string `json:"fund_group_id"`
+	Reference   string `json:"reference"`
+
+	// RefundGroupId movement group of the refund to the buyer
+	RefundGroupId  *string `json:"refund_group_id,omitempty"`
+	RefundedAmount *string `json:"refunded_amount,omitempty"`
+
+	// ReleaseGroupId movement group of the payment to the seller
+	ReleaseGroupId *string      `json:"release_group_id,omitempty"`
+	ReleasedAmount *string      `json:"released_amount,omitempty"`
+	SellerUserId   string       `json:"seller_user_id"`
+	SettledAt      *string      `json:"settled_at,omitempty"`
+	Status         EscrowStatus `json:"status"`
+}
+
+// EscrowDeadlineAction defines model for Escrow.DeadlineAction.
+type EscrowDeadlineAction string
+
+// EscrowStatus defines model for Escrow.Status.
+type EscrowStatus string
+
+// ExportedCheckpoint defines model for ExportedCheckpoint.
+type ExportedCheckpoint struct {
+	CreatedAt string `json:"created_at"`
+	Hash      string `json:"hash"`
+	KeyId     string `json:"key_id"`
+
+	// Sequence sequence of the ledger hash the checkpoint signs
+	Sequence  int64  `json:"sequence"`
+	Signature string `json:"signature"`
+}
+
+// FeeQuoteResponse defines model for FeeQuoteResponse.
+type FeeQuoteResponse struct {
+	Currency string `json:"currency"`
+	Fee      string `json:"fee"`
+
+	// GrossAmount what the user pays, the net amount and the fee
+	GrossAmount string `json:"gross_amount"`
+	NetAmount   string `json:"net_amount"`
+	Operation   string `json:"operation"`
+}
+
+// GetCustomerBalanceResponse defines model for GetCustomerBalanceResponse.
+type GetCustomerBalanceResponse struct {
+	// AccruedInterest interest accrued and not paid out yet, not part of the balance
+	AccruedInterest *string    `json:"accrued_interest,omitempty"`
+	AsOf            *time.Time `json:"as_of,omitempty"`
+
+	// Available balance less held, what may be spent
+	Available *string `json:"available,omitempty"`
+
+	// Balance ledger balance
+	Balance    string `json:"balance"`
+	Currency   string `json:"currency"`
+	CustomerId string `json:"customer_id"`
+
+	// Held total of the active holds, missing for a balance as of a time
+	Held    *string   `json:"held,omitempty"`
+	Pockets *[]Pocket `json:"pockets,omitempty"`
+
+	// Total balance of the main wallet and every pocket together
+	Total *string `json:"total,omitempty"`
+}
+
+// GetInterestResponse defines model for GetInterestResponse.
+type GetInterestResponse struct {
+	Accruals        []InterestAccrual `json:"accruals"`
+	AccruedInterest string            `json:"accrued_interest"`
+	Currency        string            `json:"currency"`
+	CustomerId      string            `json:"customer_id"`
+}
+
+// GetTraceResponse defines model for GetTraceResponse.
+type GetTraceResponse struct {
+	BalanceChanges   *[]BalanceChange       `json:"balance_changes"`
+	Movements        *[]TraceMovement       `json:"movements"`
+	PaymentHistories *[]TracePaymentHistory `json:"payment_histories"`
+	RequestId        string                 `json:"request_id"`
+	TraceId          string                 `json:"trace_id"`
+	Transactions     *[]TraceTransaction    `json:"transactions"`
+}
+
+// Hold defines model for Hold.
+type Hold struct {
+	Amount         string  `json:"amount"`
+	CapturedAmount *string `json:"captured_amount,omitempty"`
+	CreatedAt      string  `json:"created_at"`
+	ExpiresAt      string  `json:"expires_at"`
+
+	// GroupId movement group of the capture
+	GroupId        *string    `json:"group_id,omitempty"`
+	HoldId         string     `json:"hold_id"`
+	MerchantUserId string     `json:"merchant_user_id"`
+	Reference      string     `json:"reference"`
+	ResolvedAt     *string    `json:"resolved_at,omitempty"`
+	Status         HoldStatus `json:"status"`
+	UserId         string     `json:"user_id"`
+}
+
+// HoldStatus defines model for Hold.Status.
+type HoldStatus string
+
+// InterestAccrual defines model for InterestAccrual.
+type InterestAccrual struct {
+	// Balance end of day balance the interest was accrued on
+	Balance  string             `json:"balance"`
+	Day      openapi_types.Date `json:"day"`
+	Interest string             `json:"interest"`
+	Paid     bool               `json:"paid"`
+
+	// PayoutGroupId movement group of the monthly payout
+	PayoutGroupId *string `json:"payout_group_id,omitempty"`
+}
+
+// LivenessResponse defines model for LivenessResponse.
+type LivenessResponse struct {
+	Status string `json:"status"`
+}
+
+// Notification defines model for Notification.
+type Notification struct {
+	CreatedAt string                  `json:"created_at"`
+	Data      *map[string]interface{} `json:"data"`
+	Id        int64                   `json:"id"`
+	Kind      string                  `json:"kind"`
+	Message   string                  `json:"message"`
+}
+
+// OpenBusinessRequest defines model for OpenBusinessRequest.
+type OpenBusinessRequest struct {
+	// Country two letter country code like HK
+	Country   string `json:"country"`
+	Email     string `json:"email"`
+	LegalName string `json:"legal_name"`
+
+	// RegistrationNumber unique per organization
+	RegistrationNumber string `json:"registration_number"`
+
+	// UserId user opening the account, its first admin
+	UserId string `json:"user_id"`
+}
+
+// PaymentHistory defines model for PaymentHistory.
+type PaymentHistory struct {
+	// Amount negative when money left the wallet, the gross amount for the payer
+	Amount string `json:"amount"`
+	Fee    string `json:"fee"`
+
+	// GrossAmount what the payer paid, the net amount and the fee
+	GrossAmount string `json:"gross_amount"`
+
+	// InitiatedBy member who paid out of a shared or business account, missing when the payer did
+	InitiatedBy *string `json:"initiated_by,omitempty"`
+
+	// NetAmount what the payee got
+	NetAmount string                `json:"net_amount"`
+	PayType   PaymentHistoryPayType `json:"pay_type"`
+	PayeeName string                `json:"payee_name"`
+	PayerName string                `json:"payer_name"`
+}
+
+// PaymentHistoryPayType defines model for PaymentHistory.PayType.
+type PaymentHistoryPayType string
+
+// PaymentPolicy defines model for PaymentPolicy.
+type PaymentPolicy struct {
+	// AboveAmount transfers above it need the approvals
+	AboveAmount string `json:"above_amount"`
+
+	// Approvals approvers other than the initiator who have to approve
+	Approvals int `json:"approvals"`
+}
+
+// PaymentRequest defines model for PaymentRequest.
+type PaymentRequest struct {
+	Amount    string                 `json:"amount"`
+	CreatedAt string                 `json:"created_at"`
+	Events    *[]PaymentRequestEvent `json:"events,omitempty"`
+	ExpiresAt string                 `json:"expires_at"`
+
+	// GroupId movement group of the transfer of an approved request
+	GroupId          *string              `json:"group_id,omitempty"`
+	Memo             string               `json:"memo"`
+	PayerUserId      string               `json:"payer_user_id"`
+	PaymentRequestId string               `json:"payment_request_id"`
+	RequesterUserId  string               `json:"requester_user_id"`
+	ResolvedAt       *string              `json:"resolved_at,omitempty"`
+	Status           PaymentRequestStatus `json:"status"`
+}
+
+// PaymentRequestStatus defines model for PaymentRequest.Status.
+type PaymentRequestStatus string
+
+// PaymentRequestEvent defines model for PaymentRequestEvent.
+type PaymentRequestEvent struct {
+	// Actor user id, or system for an expiry
+	Actor     string `json:"actor"`
+	CreatedAt string `json:"created_at"`
+
+	// FromStatus empty for the creation
+	FromStatus string `json:"from_status"`
+	ToStatus   string `json:"to_status"`
+}
+
+// PayoutBatch defines model for PayoutBatch.
+type PayoutBatch struct {
+	BatchId        string            `json:"batch_id"`
+	CompletedAt    *string           `json:"completed_at,omitempty"`
+	CreatedAt      string            `json:"created_at"`
+	ErrorCode      *string           `json:"error_code,omitempty"`
+	FailedCount    int               `json:"failed_count"`
+	FundingUserId  string            `json:"funding_user_id"`
+	LineCount      int               `json:"line_count"`
+	Mode           PayoutBatchMode   `json:"mode"`
+	PaidAmount     string            `json:"paid_amount"`
+	PaidCount      int               `json:"paid_count"`
+	PendingCount   int               `json:"pending_count"`
+	RefundGroupId  *string           `json:"refund_group_id,omitempty"`
+	RequestId      string            `json:"request_id"`
+	ReserveGroupId *string           `json:"reserve_group_id,omitempty"`
+	ReservedAmount string            `json:"reserved_amount"`
+	Status         PayoutBatchStatus `json:"status"`
+	TotalAmount    string            `json:"total_amount"`
+}
+
+// PayoutBatchMode defines model for PayoutBatch.Mode.
+type PayoutBatchMode string
+
+// PayoutBatchStatus defines model for PayoutBatch.Status.
+type PayoutBatchStatus string
+
+// PayoutLine defines model for PayoutLine.
+type PayoutLine struct {
+	Amount          string           `json:"amount"`
+	ErrorCode       *string          `json:"error_code,omitempty"`
+	GroupId         *string          `json:"group_id,omitempty"`
+	LineNo          int              `json:"line_no"`
+	RecipientUserId string           `json:"recipient_user_id"`
+	Reference       string           `json:"reference"`
+	Status          PayoutLineStatus `json:"status"`
+}
+
+// PayoutLineStatus defines model for PayoutLine.Status.
+type PayoutLineStatus string
+
+// PayoutLineError defines model for PayoutLineError.
+type PayoutLineError struct {
+	ErrorCode string  `json:"error_code"`
+	Line      int     `json:"line"`
+	Message   string  `json:"message"`
+	Reference *string `json:"reference,omitempty"`
+}
+
+// PayoutLineRequest defines model for PayoutLineRequest.
+type PayoutLineRequest struct {
+	Amount          string `json:"amount"`
+	RecipientUserId string `json:"recipient_user_id"`
+	Reference       string `json:"reference"`
+}
+
+// PlaceHoldRequest defines model for PlaceHoldRequest.
+type PlaceHoldRequest struct {
+	Amount string `json:"amount"`
+
+	// ExpiresAt hold.default_expiry from now by default, at most hold.max_expiry
+	ExpiresAt      *time.Time `json:"expires_at,omitempty"`
+	MerchantUserId string     `json:"merchant_user_id"`
+
+	// Reference reference of the merchant, e.g. an order number
+	Reference *string `json:"reference,omitempty"`
+
+	// UserId owner of the wallet the amount is held on
+	UserId string `json:"user_id"`
+}
+
+// Pocket defines model for Pocket.
+type Pocket struct {
+	Balance    string  `json:"balance"`
+	CreatedAt  string  `json:"created_at"`
+	GoalAmount *string `json:"goal_amount,omitempty"`
+
+	// Locked whether withdrawals are refused until the target date
+	Locked     bool                `json:"locked"`
+	Name       string              `json:"name"`
+	PocketId   string              `json:"pocket_id"`
+	TargetDate *openapi_types.Date `json:"target_date,omitempty"`
+	UserId     string              `json:"user_id"`
+}
+
+// PocketMovementRequest defines model for PocketMovementRequest.
+type PocketMovementRequest struct {
+	Amount string `json:"amount"`
+
+	// UserId owner of the pocket
+	UserId string `json:"user_id"`
+}
+
+// PocketMovementResponse defines model for PocketMovementResponse.
+type PocketMovementResponse struct {
+	// Balance ledger balance of the main wallet after the move
+	Balance string `json:"balance"`
+	GroupId string `json:"group_id"`
+	Pocket  Pocket `json:"pocket"`
+}
+
+// Problem defines model for Problem.
+type Problem struct {
+	ErrorCode string `json:"error_code"`
+
+	// Lines invalid lines of a rejected payout batch
+	Lines     *[]PayoutLineError `json:"lines,omitempty"`
+	Message   string             `json:"message"`
+	RequestId *string            `json:"request_id,omitempty"`
+	Result    bool               `json:"result"`
+	Retryable bool               `json:"retryable"`
+	Status    int                `json:"status"`
+	TraceId   *string            `json:"trace_id,omitempty"`
+}
+
+// ReadinessResponse defines model for ReadinessResponse.
+type ReadinessResponse struct {
+	Checks map[string]CheckResult  `json:"checks"`
+	Status ReadinessResponseStatus `json:"status"`
+}
+
+// ReadinessResponseStatus defines model for ReadinessResponse.Status.
+type ReadinessResponseStatus string
+
+// ResolvePaymentRequestRequest defines model for ResolvePaymentRequestRequest.
+type ResolvePaymentRequestRequest struct {
+	// PayerUserId the user asked to pay, a request of someone else is not found
+	PayerUserId string `json:"payer_user_id"`
+}
+
+// SaveAccountMemberRequest defines model for SaveAccountMemberRequest.
+type SaveAccountMemberRequest struct {
+	// OwnerUserId owner of the account making the change
+	OwnerUserId string `json:"owner_user_id"`
+
+	// Role an owner manages members and approves transfers, a spender transfers and sees the payments they made, a viewer sees the balance and every payment
+	Role SaveAccountMemberRequestRole `json:"role"`
+
+	// SpendingLimit most a spender may transfer at a time, no limit when missing
+	SpendingLimit *string `json:"spending_limit,omitempty"`
+}
+
+// SaveAccountMemberRequestRole an owner manages members and approves transfers, a spender transfers and sees the payments they made, a viewer sees the balance and every payment
+type SaveAccountMemberRequestRole string
+
+// SaveBusinessMemberRequest defines model for SaveBusinessMemberRequest.
+type SaveBusinessMemberRequest struct {
+	// AdminUserId admin of the organization making the change
+	AdminUserId string `json:"admin_user_id"`
+
+	// Role an admin manages members and policies, initiates and approves, an initiator initiates transfers, an approver approves or rejects them, a viewer sees the balance, payments and transfers
+	Role SaveBusinessMemberRequestRole `json:"role"`
+}
+
+// SaveBusinessMemberRequestRole an admin manages members and policies, initiates and approves, an initiator initiates transfers, an approver approves or rejects them, a viewer sees the balance, payments and transfers
+type SaveBusinessMemberRequestRole string
+
+// ScheduledTransfer defines model for ScheduledTransfer.
+type ScheduledTransfer struct {
+	Amount      string                  `json:"amount"`
+	EndAt       *string                 `json:"end_at,omitempty"`
+	MaxRuns     int                     `json:"max_runs"`
+	NextRunAt   *string                 `json:"next_run_at,omitempty"`
+	PayeeUserId string                  `json:"payee_user_id"`
+	PayerUserId string                  `json:"payer_user_id"`
+	Rule        string                  `json:"rule"`
+	RunCount    int                     `json:"run_count"`
+	ScheduleId  string                  `json:"schedule_id"`
+	StartAt     string                  `json:"start_at"`
+	Status      ScheduledTransferStatus `json:"status"`
+	TimeZone    string                  `json:"time_zone"`
+}
+
+// ScheduledTransferStatus defines model for ScheduledTransfer.Status.
+type ScheduledTransferStatus string
+
+// ScheduledTransferRun defines model for ScheduledTransferRun.
+type ScheduledTransferRun struct {
+	Attempts      int                        `json:"attempts"`
+	ErrorCode     *string                    `json:"error_code,omitempty"`
+	GroupId       *string                    `json:"group_id,omitempty"`
+	NextAttemptAt string                     `json:"next_attempt_at"`
+	RequestId     string                     `json:"request_id"`
+	RunId         string                     `json:"run_id"`
+	ScheduledFor  string                     `json:"scheduled_for"`
+	Status        ScheduledTransferRunStatus `json:"status"`
+}
+
+// ScheduledTransferRunStatus defines model for ScheduledTransferRun.Status.
+type ScheduledTransferRunStatus string
+
+// SearchAccountMemberResponse defines model for SearchAccountMemberResponse.
+type SearchAccountMemberResponse struct {
+	Members *[]AccountMember `json:"members,omitempty"`
+}
+
+// SearchAuditLogResponse defines model for SearchAuditLogResponse.
+type SearchAuditLogResponse struct {
+	Logs      *[]AuditLog `json:"logs"`
+	NextAfter *int64      `json:"next_after,omitempty"`
+	Result    bool        `json:"result"`
+}
+
+// SearchBusinessMemberResponse defines model for SearchBusinessMemberResponse.
+type SearchBusinessMemberResponse struct {
+	Members *[]BusinessMember `json:"members,omitempty"`
+}
+
+// SearchBusinessTransferResponse defines model for SearchBusinessTransferResponse.
+type SearchBusinessTransferResponse struct {
+	Transfers *[]BusinessTransfer `json:"transfers,omitempty"`
+}
+
+// SearchEscrowResponse defines model for SearchEscrowResponse.
+type SearchEscrowResponse struct {
+	Escrows []Escrow `json:"escrows"`
+}
+
+// SearchHoldResponse defines model for SearchHoldResponse.
+type SearchHoldResponse struct {
+	Holds []Hold `json:"holds"`
+}
+
+// SearchNotificationResponse defines model for SearchNotificationResponse.
+type SearchNotificationResponse struct {
+	NextAfter     *int64         `json:"next_after,omitempty"`
+	Notifications []Notification `json:"notifications"`
+}
+
+// SearchPaymentHistoryResponse defines model for SearchPaymentHistoryResponse.
+type SearchPaymentHistoryResponse struct {
+	Histories *[]PaymentHistory `json:"histories"`
+}
+
+// SearchPaymentPolicyResponse defines model for SearchPaymentPolicyResponse.
+type SearchPaymentPolicyResponse struct {
+	Policies *[]PaymentPolicy `json:"policies,omitempty"`
+}
+
+// SearchPaymentRequestResponse defines model for SearchPaymentRequestResponse.
+type SearchPaymentRequestResponse struct {
+	PaymentRequests []PaymentRequest `json:"payment_requests"`
+}
+
+// SearchPayoutLineResponse defines model for SearchPayoutLineResponse.
+type SearchPayoutLineResponse struct {
+	Lines     []PayoutLine `json:"lines"`
+	NextAfter *int         `json:"next_after,omitempty"`
+}
+
+// SearchPocketResponse defines model for SearchPocketResponse.
+type SearchPocketResponse struct {
+	Pockets *[]Pocket `json:"pockets,omitempty"`
+}
+
+// SearchScheduledTransferResponse defines model for SearchScheduledTransferResponse.
+type SearchScheduledTransferResponse struct {
+	ScheduledTransfers []ScheduledTransfer `json:"scheduled_transfers"`
+}
+
+// SearchScheduledTransferRunResponse defines model for SearchScheduledTransferRunResponse.
+type SearchScheduledTransferRunResponse struct {
+	Runs []ScheduledTransferRun `json:"runs"`
+}
+
+// SearchSharedTransferResponse defines model for SearchSharedTransferResponse.
+type SearchSharedTransferResponse struct {
+	Transfers *[]SharedTransfer `json:"transfers,omitempty"`
+}
+
+// SetApprovalThresholdRequest defines model for SetApprovalThresholdRequest.
+type SetApprovalThresholdRequest struct {
+	// Amount transfers above it wait for a second owner, 0 turns approval off
+	Amount      string `json:"amount"`
+	OwnerUserId string `json:"owner_user_id"`
+}
+
+// SetPaymentPoliciesRequest defines model for SetPaymentPoliciesRequest.
+type SetPaymentPoliciesRequest struct {
+	AdminUserId string `json:"admin_user_id"`
+
+	// Policies replace every policy, none pays every transfer right away
+	Policies []PaymentPolicy `json:"policies"`
+}
+
+// SharedTransfer defines model for SharedTransfer.
+type SharedTransfer struct {
+	AccountId string  `json:"account_id"`
+	Amount    string  `json:"amount"`
+	CreatedAt string  `json:"created_at"`
+	DecidedAt *string `json:"decided_at,omitempty"`
+
+	// DecidedBy owner who approved or member who declined it
+	DecidedBy *string `json:"decided_by,omitempty"`
+
+	// GroupId movement group of the payment
+	GroupId *string `json:"group_id,omitempty"`
+
+	// InitiatorUserId member who made the transfer
+	InitiatorUserId string               `json:"initiator_user_id"`
+	Status          SharedTransferStatus `json:"status"`
+	ToUserId        string               `json:"to_user_id"`
+	TransferId      string               `json:"transfer_id"`
+}
+
+// SharedTransferStatus defines model for SharedTransfer.Status.
+type SharedTransferStatus string
+
+// SplitEscrowRequest defines model for SplitEscrowRequest.
+type SplitEscrowRequest struct {
+	// SellerAmount paid to the seller, more than 0 and less than the escrow amount
+	SellerAmount string `json:"seller_amount"`
+}
+
+// SubmitPayoutRequest defines model for SubmitPayoutRequest.
+type SubmitPayoutRequest struct {
+	FundingUserId string                  `json:"funding_user_id"`
+	Lines         []PayoutLineRequest     `json:"lines"`
+	Mode          SubmitPayoutRequestMode `json:"mode"`
+}
+
+// SubmitPayoutRequestMode defines model for SubmitPayoutRequest.Mode.
+type SubmitPayoutRequestMode string
+
+// TraceMovement defines model for TraceMovement.
+type TraceMovement struct {
+	CreatedAt      string `json:"created_at"`
+	CreditBalance  string `json:"credit_balance"`
+	CreditWalletId string `json:"credit_wallet_id"`
+	DebitBalance   string `json:"debit_balance"`
+	DebitWalletId  string `json:"debit_wallet_id"`
+	GroupId        string `json:"group_id"`
+	Id             string `json:"id"`
+	MovementStatus string `json:"movement_status"`
+}
+
+// TracePaymentHistory defines model for TracePaymentHistory.
+type TracePaymentHistory struct {
+	Amount    string `json:"amount"`
+	CreatedAt string `json:"created_at"`
+
+	// Fee paid by the payer on top of the amount
+	Fee         string `json:"fee"`
+	Id          string `json:"id"`
+	PayType     string `json:"pay_type"`
+	PayeeUserId string `json:"payee_user_id"`
+	PayerUserId string `json:"payer_user_id"`
+}
+
+// TraceTransaction defines model for TraceTransaction.
+type TraceTransaction struct {
+	Balance     string `json:"balance"`
+	BalanceType string `json:"balance_type"`
+	CreatedAt   string `json:"created_at"`
+	Id          string `json:"id"`
+	MovementId  string `json:"movement_id"`
+	WalletId    string `json:"wallet_id"`
+}
+
+// TransferRequest defines model for TransferRequest.
+type TransferRequest struct {
+	// Balance decimal amount in the wallet currency, e.g. "100.50"
+	Balance string `json:"balance"`
+
+	// CreditUserId user who pays
+	CreditUserId string `json:"credit_user_id"`
+
+	// DebitUserId user who is paid
+	DebitUserId string `json:"debit_user_id"`
+}
+
+// TransferResponse defines model for TransferResponse.
+type TransferResponse struct {
+	ErrorCode *string `json:"error_code,omitempty"`
+	Result    bool    `json:"result"`
+}
+
+// WithdrawalRequest defines model for WithdrawalRequest.
+type WithdrawalRequest struct {
+	// Balance decimal amount in the wallet currency, e.g. "100.50"
+	Balance string `json:"balance"`
+	UserId  string `json:"user_id"`
+}
+
+// WithdrawalResponse defines model for WithdrawalResponse.
+type WithdrawalResponse struct {
+	ErrorCode *string `json:"error_code,omitempty"`
+	Result    bool    `json:"result"`
+}
+
+// ActorID defines model for ActorID.
+type ActorID = string
+
+// ActorType defines model for ActorType.
+type ActorType string
+
+// RequestID defines model for RequestID.
+type RequestID = string
+
+// GetAccountsParams defines parameters for GetAccounts.
+type GetAccountsParams struct {
+	UserId string `form:"user_id" json:"user_id"`
+}
+
+// GetAccountBalanceParams defines parameters for GetAccountBalance.
+type GetAccountBalanceParams struct {
+	// UserId id of a member of the account
+	UserId string `form:"user_id" json:"user_id"`
+}
+
+// GetAccountMembersParams defines parameters for GetAccountMembers.
+type GetAccountMembersParams struct {
+	// UserId id of a member of the account
+	UserId string `form:"user_id" json:"user_id"`
+}
+
+// RemoveAccountMemberParams defines parameters for RemoveAccountMember.
+type RemoveAccountMemberParams struct {
+	// ByUserId id of the owner removing the member, or of the member leaving
+	ByUserId string `form:"by_user_id" json:"by_user_id"`
+}
+
+// GetAccountPaymentHistoryParams defines parameters for GetAccountPaymentHistory.
+type GetAccountPaymentHistoryParams struct {
+	// UserId id of a member of the account
+	UserId string `form:"user_id" json:"user_id"`
+
+	// IncludeArchived also read payments moved to the archive
+	IncludeArchived *bool `form:"include_archived,omitempty" json:"include_archived,omitempty"`
+}
+
+// GetSharedTransfersParams defines parameters for GetSharedTransfers.
+type GetSharedTransfersParams struct {
+	// UserId id of a member of the account
+	UserId string                          `form:"user_id" json:"user_id"`
+	Status *GetSharedTransfersParamsStatus `form:"status,omitempty" json:"status,omitempty"`
+}
+
+// GetSharedTransfersParamsStatus defines parameters for GetSharedTransfers.
+type GetSharedTransfersParamsStatus string
+
+// CreateSharedTransferParams defines parameters for CreateSharedTransfer.
+type CreateSharedTransferParams struct {
+	// XRequestID idempotency key, a request id that was used already fails with DUPLICATE_REQUEST
+	XRequestID RequestID `json:"X-Request-ID"`
+}
+
+// SearchAuditLogsParams defines parameters for SearchAuditLogs.
+type SearchAuditLogsParams struct {
+	// ActorType user, service or operator
+	ActorType *string `form:"actor_type,omitempty" json:"actor_type,omitempty"`
+
+	// ActorId who acted
+	ActorId *string `form:"actor_id,omitempty" json:"actor_id,omitempty"`
+
+	// Action e.g. wallet.deposit
+	Action *string `form:"action,omitempty" json:"action,omitempty"`
+
+	// TargetType user, wallet, movement, archive, checkpoint, payout or schedule
+	TargetType *string `form:"target_type,omitempty" json:"target_type,omitempty"`
+
+	// TargetId what was acted on
+	TargetId *string `form:"target_id,omitempty" json:"target_id,omitempty"`
+
+	// From RFC 3339 time, inclusive
+	From *time.Time `form:"from,omitempty" json:"from,omitempty"`
+
+	// To RFC 3339 time, exclusive
+	To *time.Time `form:"to,omitempty" json:"to,omitempty"`
+
+	// After id of the last log of the previous page
+	After *int64 `form:"after,omitempty" json:"after,omitempty"`
+
+	// Limit page size
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// XActorType type of the caller recorded in the audit log
+	XActorType *SearchAuditLogsParamsXActorType `json:"X-Actor-Type,omitempty"`
+
+	// XActorID id of the caller recorded in the audit log
+	XActorID *ActorID `json:"X-Actor-ID,omitempty"`
+}
+
+// SearchAuditLogsParamsXActorType defines parameters for SearchAuditLogs.
+type SearchAuditLogsParamsXActorType string
+
+// ExportAuditLogsParams defines parameters for ExportAuditLogs.
+type ExportAuditLogsParams struct {
+	// ActorType user, service or operator
+	ActorType *string `form:"actor_type,omitempty" json:"actor_type,omitempty"`
+
+	// ActorId who acted
+	ActorId *string `form:"actor_id,omitempty" json:"actor_id,omitempty"`
+
+	// Action e.g. wallet.deposit
+	Action *string `form:"action,omitempty" json:"action,omitempty"`
+
+	// TargetType user, wallet, movement, archive, checkpoint, payout or schedule
+	TargetType *string `form:"target_type,omitempty" json:"target_type,omitempty"`
+
+	// TargetId what was acted on
+	TargetId *string `form:"target_id,omitempty" json:"target_id,omitempty"`
+
+	// From RFC 3339 time, inclusive
+	From *time.Time `form:"from,omitempty" json:"from,omitempty"`
+
+	// To RFC 3339 time, exclusive
+	To *time.Time `form:"to,omitempty" json:"to,omitempty"`
+
+	// Format export format
+	Format *ExportAuditLogsParamsFormat `form:"format,omitempty" json:"format,omitempty"`
+
+	// XActorType type of the caller recorded in the audit log
+	XActorType *ExportAuditLogsParamsXActorType `json:"X-Actor-Type,omitempty"`
+
+	// XActorID id of the caller recorded in the audit log
+	XActorID *ActorID `json:"X-Actor-ID,omitempty"`
+}
+
+// ExportAuditLogsParamsFormat defines parameters for ExportAuditLogs.
+type ExportAuditLogsParamsFormat string
+
+// ExportAuditLogsParamsXActorType defines parameters for ExportAuditLogs.
+type ExportAuditLogsParamsXActorType string
+
+// GetBusinessesParams defines parameters for GetBusinesses.
+type GetBusinessesParams struct {
+	UserId string `form:"user_id" json:"user_id"`
+}
+
+// GetBusinessParams defines parameters for GetBusiness.
+type GetBusinessParams struct {
+	// UserId id of an active member of the organization
+	UserId string `form:"user_id" json:"user_id"`
+}
+
+// GetBusinessBalanceParams defines parameters for GetBusinessBalance.
+type GetBusinessBalanceParams struct {
+	// UserId id of an active member of the organization
+	UserId string `form:"user_id" json:"user_id"`
+}
+
+// GetBusinessMembersParams defines parameters for GetBusinessMembers.
+type GetBusinessMembersParams struct {
+	// UserId id of an active member of the organization
+	UserId string `form:"user_id" json:"user_id"`
+}
+
+// RemoveBusinessMemberParams defines parameters for RemoveBusinessMember.
+type RemoveBusinessMemberParams struct {
+	// ByUserId id of the admin removing the member, or of the member leaving
+	ByUserId string `form:"by_user_id" json:"by_user_id"`
+}
+
+// GetBusinessPaymentHistoryParams defines parameters for GetBusinessPaymentHistory.
+type GetBusinessPaymentHistoryParams struct {
+	// UserId id of an active member of the organization
+	UserId string `form:"user_id" json:"user_id"`
+
+	// IncludeArchived also read payments moved to the archive
+	IncludeArchived *bool `form:"include_archived,omitempty" json:"include_archived,omitempty"`
+}
+
+// GetPaymentPoliciesParams defines parameters for GetPaymentPolicies.
+type GetPaymentPoliciesParams struct {
+	// UserId id of an active member of the organization
+	UserId string `form:"user_id" json:"user_id"`
+}
+
+// GetBusinessTransfersParams defines parameters for GetBusinessTransfers.
+type GetBusinessTransfersParams struct {
+	// UserId id of an active member of the organization
+	UserId string                            `form:"user_id" json:"user_id"`
+	Status *GetBusinessTransfersParamsStatus `form:"status,omitempty" json:"status,omitempty"`
+}
+
+// GetBusinessTransfersParamsStatus defines parameters for GetBusinessTransfers.
+type GetBusinessTransfersParamsStatus string
+
+// CreateBusinessTransferParams defines parameters for CreateBusinessTransfer.
+type CreateBusinessTransferParams struct {
+	// XRequestID idempotency key, a request id that was used already fails with DUPLICATE_REQUEST
+	XRequestID RequestID `json:"X-Request-ID"`
+}
+
+// GetBusinessTransferParams defines parameters for GetBusinessTransfer.
+type GetBusinessTransferParams struct {
+	// UserId id of an active member of the organization
+	UserId string `form:"user_id" json:"user_id"`
+}
+
+// GetEscrowsParams defines parameters for GetEscrows.
+type GetEscrowsParams struct {
+	UserId string                  `form:"user_id" json:"user_id"`
+	Status *GetEscrowsParamsStatus `form:"status,omitempty" json:"status,omitempty"`
+}
+
+// GetEscrowsParamsStatus defines parameters for GetEscrows.
+type GetEscrowsParamsStatus string
+
+// CreateEscrowParams defines parameters for CreateEscrow.
+type CreateEscrowParams struct {
+	// XRequestID idempotency key, a request id that was used already fails with DUPLICATE_REQUEST
+	XRequestID RequestID `json:"X-Request-ID"`
+}
+
+// GetHoldsParams defines parameters for GetHolds.
+type GetHoldsParams struct {
+	UserId string                `form:"user_id" json:"user_id"`
+	Status *GetHoldsParamsStatus `form:"status,omitempty" json:"status,omitempty"`
+}
+
+// GetHoldsParamsStatus defines parameters for GetHolds.
+type GetHoldsParamsStatus string
+
+// PlaceHoldParams defines parameters for PlaceHold.
+type PlaceHoldParams struct {
+	// XRequestID idempotency key, a request id that was used already fails with DUPLICATE_REQUEST
+	XRequestID RequestID `json:"X-Request-ID"`
+}
+
+// CaptureHoldParams defines parameters for CaptureHold.
+type CaptureHoldParams struct {
+	// XRequestID idempotency key, a request id that was used already fails with DUPLICATE_REQUEST
+	XRequestID RequestID `json:"X-Request-ID"`
+}
+
+// GetNotificationsParams defines parameters for GetNotifications.
+type GetNotificationsParams struct {
+	// UserId user
+	UserId string `form:"user_id" json:"user_id"`
+
+	// After next_after of the previous page
+	After *int64 `form:"after,omitempty" json:"after,omitempty"`
+}
+
+// GetPaymentRequestsParams defines parameters for GetPaymentRequests.
+type GetPaymentRequestsParams struct {
+	UserId string `form:"user_id" json:"user_id"`
+
+	// Role sent lists the requests of the user, received the ones they are asked to pay, received by default
+	Role   *GetPaymentRequestsParamsRole   `form:"role,omitempty" json:"role,omitempty"`
+	Status *GetPaymentRequestsParamsStatus `form:"status,omitempty" json:"status,omitempty"`
+}
+
+// GetPaymentRequestsParamsRole defines parameters for GetPaymentRequests.
+type GetPaymentRequestsParamsRole string
+
+// GetPaymentRequestsParamsStatus defines parameters for GetPaymentRequests.
+type GetPaymentRequestsParamsStatus string
+
+// CreatePaymentRequestParams defines parameters for CreatePaymentRequest.
+type CreatePaymentRequestParams struct {
+	// XRequestID idempotency key, a request id that was used already fails with DUPLICATE_REQUEST
+	XRequestID RequestID `json:"X-Request-ID"`
+}
+
+// GetPayoutByRequestIdParams defines parameters for GetPayoutByRequestId.
+type GetPayoutByRequestIdParams struct {
+	// RequestId X-Request-ID the batch was submitted with
+	RequestId string `form:"request_id" json:"request_id"`
+}
+
+// SubmitPayoutParams defines parameters for SubmitPayout.
+type SubmitPayoutParams struct {
+	// FundingUserId funding user of a CSV batch
+	FundingUserId *string `form:"funding_user_id,omitempty" json:"funding_user_id,omitempty"`
+
+	// Mode mode of a CSV batch
+	Mode *SubmitPayoutParamsMode `form:"mode,omitempty" json:"mode,omitempty"`
+
+	// XRequestID idempotency key, a request id that was used already fails with DUPLICATE_REQUEST
+	XRequestID RequestID `json:"X-Request-ID"`
+}
+
+// SubmitPayoutParamsMode defines parameters for SubmitPayout.
+type SubmitPayoutParamsMode string
+
+// GetPayoutLinesParams defines parameters for GetPayoutLines.
+type GetPayoutLinesParams struct {
+	// Status only lines in this status
+	Status *GetPayoutLinesParamsStatus `form:"status,omitempty" json:"status,omitempty"`
+
+	// After next_after of the previous page
+	After *int `form:"after,omitempty" json:"after,omitempty"`
+
+	// Limit lines per page
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+}
+
+// GetPayoutLinesParamsStatus defines parameters for GetPayoutLines.
+type GetPayoutLinesParamsStatus string
+
+// GetPocketsParams defines parameters for GetPockets.
+type GetPocketsParams struct {
+	UserId string `form:"user_id" json:"user_id"`
+}
+
+// DepositToPocketParams defines parameters for DepositToPocket.
+type DepositToPocketParams struct {
+	// XRequestID idempotency key, a request id that was used already fails with DUPLICATE_REQUEST
+	XRequestID RequestID `json:"X-Request-ID"`
+}
+
+// WithdrawFromPocketParams defines parameters for WithdrawFromPocket.
+type WithdrawFromPocketParams struct {
+	// XRequestID idempotency key, a request id that was used already fails with DUPLICATE_REQUEST
+	XRequestID RequestID `json:"X-Request-ID"`
+}
+
+// GetScheduledTransfersParams defines parameters for GetScheduledTransfers.
+type GetScheduledTransfersParams struct {
+	// UserId payer
+	UserId string `form:"user_id" json:"user_id"`
+}
+
+// CreateScheduledTransferParams defines parameters for CreateScheduledTransfer.
+type CreateScheduledTransferParams struct {
+	// XRequestID idempotency key, a request id that was used already fails with DUPLICATE_REQUEST
+	XRequestID RequestID `json:"X-Request-ID"`
+}
+
+// GetBalanceParams defines parameters for GetBalance.
+type GetBalanceParams struct {
+	// UserId id of the user who owns the wallet
+	UserId string `form:"user_id" json:"user_id"`
+
+	// AsOf RFC 3339 time to replay the balance up to
+	AsOf *time.Time `form:"as_of,omitempty" json:"as_of,omitempty"`
+}
+
+// DepositParams defines parameters for Deposit.
+type DepositParams struct {
+	// XRequestID idempotency key, a request id that was used already fails with DUPLICATE_REQUEST
+	XRequestID RequestID `json:"X-Request-ID"`
+}
+
+// GetFeeQuoteParams defines parameters for GetFeeQuote.
+type GetFeeQuoteParams struct {
+	UserId    string                     `form:"user_id" json:"user_id"`
+	Operation GetFeeQuoteParamsOperation `form:"operation" json:"operation"`
+
+	// Amount net amount, what the payee gets
+	Amount string `form:"amount" json:"amount"`
+}
+
+// GetFeeQuoteParamsOperation defines parameters for GetFeeQuote.
+type GetFeeQuoteParamsOperation string
+
+// GetInterestParams defines parameters for GetInterest.
+type GetInterestParams struct {
+	UserId string `form:"user_id" json:"user_id"`
+}
+
+// GetPaymentHistoryParams defines parameters for GetPaymentHistory.
+type GetPaymentHistoryParams struct {
+	// UserId id of the user who owns the wallet
+	UserId string `form:"user_id" json:"user_id"`
+
+	// IncludeArchived also read payments moved to the archive
+	IncludeArchived *bool `form:"include_archived,omitempty" json:"include_archived,omitempty"`
+}
+
+// GetTraceParams defines parameters for GetTrace.
+type GetTraceParams struct {
+	// TraceId OpenTelemetry trace id, returned in X-Trace-ID
+	TraceId *string `form:"trace_id,omitempty" json:"trace_id,omitempty"`
+
+	// RequestId X-Request-ID of the request
+	RequestId *string `form:"request_id,omitempty" json:"request_id,omitempty"`
+}
+
+// TransferParams defines parameters for Transfer.
+type TransferParams struct {
+	// XRequestID idempotency key, a request id that was used already fails with DUPLICATE_REQUEST
+	XRequestID RequestID `json:"X-Request-ID"`
+}
+
+// WithdrawParams defines parameters for Withdraw.
+type WithdrawParams struct {
+	// XRequestID idempotency key, a request id that was used already fails with DUPLICATE_REQUEST
+	XRequestID RequestID `json:"X-Request-ID"`
+}
+
+// SetApprovalThresholdJSONRequestBody defines body for SetApprovalThreshold for application/json ContentType.
+type SetApprovalThresholdJSONRequestBody = SetApprovalThresholdRequest
+
+// SaveAccountMemberJSONRequestBody defines body for SaveAccountMember for application/json ContentType.
+type SaveAccountMemberJSONRequestBody = SaveAccountMemberRequest
+
+// CreateSharedTransferJSONRequestBody defines body for CreateSharedTransfer for application/json ContentType.
+type CreateSharedTransferJSONRequestBody = CreateSharedTransferRequest
+
+// ApproveSharedTransferJSONRequestBody defines body for ApproveSharedTransfer for application/json ContentType.
+type ApproveSharedTransferJSONRequestBody = DecideSharedTransferRequest
+
+// DeclineSharedTransferJSONRequestBody defines body for DeclineSharedTransfer for application/json ContentType.
+type DeclineSharedTransferJSONRequestBody = DecideSharedTransferRequest
+
+// OpenBusinessJSONRequestBody defines body for OpenBusiness for application/json ContentType.
+type OpenBusinessJSONRequestBody = OpenBusinessRequest
+
+// SaveBusinessMemberJSONRequestBody defines body for SaveBusinessMember for application/json ContentType.
+type SaveBusinessMemberJSONRequestBody = SaveBusinessMemberRequest
+
+// SetPaymentPoliciesJSONRequestBody defines body for SetPaymentPolicies for application/json ContentType.
+type SetPaymentPoliciesJSONRequestBody = SetPaymentPoliciesRequest
+
+// CreateBusinessTransferJSONRequestBody defines body for CreateBusinessTransfer for application/json ContentType.
+type CreateBusinessTransferJSONRequestBody = CreateBusinessTransferRequest
+
+// ApproveBusinessTransferJSONRequestBody defines body for ApproveBusinessTransfer for application/json ContentType.
+type ApproveBusinessTransferJSONRequestBody = DecideBusinessTransferRequest
+
+// RejectBusinessTransferJSONRequestBody defines body for RejectBusinessTransfer for application/json ContentType.
+type RejectBusinessTransferJSONRequestBody = DecideBusinessTransferRequest
+
+// CreateEscrowJSONRequestBody defines body for CreateEscrow for application/json ContentType.
+type CreateEscrowJSONRequestBody = CreateEscrowRequest
+
+// SplitEscrowJSONRequestBody defines body for SplitEscrow for application/json ContentType.
+type SplitEscrowJSONRequestBody = SplitEscrowRequest
+
+// PlaceHoldJSONRequestBody defines body for PlaceHold for application/json ContentType.
+type PlaceHoldJSONRequestBody = PlaceHoldRequest
+
+// CaptureHoldJSONRequestBody defines body for CaptureHold for application/json ContentType.
+type CaptureHoldJSONRequestBody = CaptureHoldRequest
+
+// CreatePaymentRequestJSONRequestBody defines body for CreatePaymentRequest for application/json ContentType.
+type CreatePaymentRequestJSONRequestBody = CreatePaymentRequestRequest
+
+// ApprovePaymentRequestJSONRequestBody defines body for ApprovePaymentRequest for application/json ContentType.
+type ApprovePaymentRequestJSONRequestBody = ResolvePaymentRequestRequest
+
+// DeclinePaymentRequestJSONRequestBody defines body for DeclinePaymentRequest for application/json ContentType.
+type DeclinePaymentRequestJSONRequestBody = ResolvePaymentRequestRequest
+
+// SubmitPayoutJSONRequestBody defines body for SubmitPayout for application/json ContentType.
+type SubmitPayoutJSONRequestBody = SubmitPayoutRequest
+
+// CreatePocketJSONRequestBody defines body for CreatePocket for application/json ContentType.
+type CreatePocketJSONRequestBody = CreatePocketRequest
+
+// DepositToPocketJSONRequestBody defines body for DepositToPocket for application/json ContentType.
+type DepositToPocketJSONRequestBody = PocketMovementRequest
+
+// WithdrawFromPocketJSONRequestBody defines body for WithdrawFromPocket for application/json ContentType.
+type WithdrawFromPocketJSONRequestBody = PocketMovementRequest
+
+// CreateScheduledTransferJSONRequestBody defines body for CreateScheduledTransfer for application/json ContentType.
+type CreateScheduledTransferJSONRequestBody = CreateScheduledTransferRequest
+
+// DepositJSONRequestBody defines body for Deposit for application/json ContentType.
+type DepositJSONRequestBody = DepositRequest
+
+// TransferJSONRequestBody defines body for Transfer for application/json ContentType.
+type TransferJSONRequestBody = TransferRequest
+
+// WithdrawJSONRequestBody defines body for Withdraw for application/json ContentType.
+type WithdrawJSONRequestBody = WithdrawalRequest
+
+// RequestEditorFn  is the function signature for the RequestEditor callback function
+type RequestEditorFn func(ctx context.Context, req *http.Request) error
+
+// Doer performs HTTP requests.
+//
+// The standard http.Client implements this interface.
+type HttpRequestDoer interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// Client which conforms to the OpenAPI3 specification for this service.
+type Client struct {
+	// The endpoint of the server conforming to this interface, with scheme,
+	// https://api.deepmap.com for example. This can contain a path relative
+	// to the server, such as https://api.deepmap.com/dev-test, and all the
+	// paths in the swagger spec will be appended to the server.
+	Server string
+
+	// Doer for performing requests, typically a *http.Client with any
+	// customized settings, such as certificate chains.
+	Client HttpRequestDoer
+
+	// A list of callbacks for modifying requests which are generated before sending over
+	// the network.
+	RequestEditors []RequestEditorFn
+}
+
+// ClientOption allows setting custom parameters during construction
+type ClientOption func(*Client) error
+
+// Creates a new Client, with reasonable defaults
+func NewClient(server string, opts ...ClientOption) (*Client, error) {
+	// create a client with sane default values
+	client := Client{
+		Server: server,
+	}
+	// mutate client and add all optional params
+	for _, o := range opts {
+		if err := o(&client); err != nil {
+			return nil, err
+		}
+	}
+	// ensure the server URL always has a trailing slash
+	if !strings.HasSuffix(client.Server, "/") {
+		client.Server += "/"
+	}
+	// create httpClient, if not already present
+	if client.Client == nil {
+		client.Client = &http.Client{}
+	}
+	return &client, nil
+}
+
+// WithHTTPClient allows overriding the default Doer, which is
+// automatically created using http.Client. This is useful for tests.
+func WithHTTPClient(doer HttpRequestDoer) ClientOption {
+	return func(c *Client) error {
+		c.Client = doer
+		return nil
+	}
+}
+
+// WithRequestEditorFn allows setting up a callback function, which will be
+// called right before sending the request. This can be used to mutate the request.
+func WithRequestEditorFn(fn RequestEditorFn) ClientOption {
+	return func(c *Client) error {
+		c.RequestEditors = append(c.RequestEditors, fn)
+		return nil
+	}
+}
+
+// The interface specification for the client above.
+type ClientInterface interface {
+	// GetAccounts request
+	GetAccounts(ctx context.Context, params *GetAccountsParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// SetApprovalThresholdWithBody request with any body
+	SetApprovalThresholdWithBody(ctx context.Context, accountId openapi_types.UUID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	SetApprovalThreshold(ctx context.Context, accountId openapi_types.UUID, body SetApprovalThresholdJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetAccountBalance request
+	GetAccountBalance(ctx context.Context, accountId openapi_types.UUID, params *GetAccountBalanceParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetAccountMembers request
+	GetAccountMembers(ctx context.Context, accountId openapi_types.UUID, params *GetAccountMembersParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// RemoveAccountMember request
+	RemoveAccountMember(ctx context.Context, accountId openapi_types.UUID, userId openapi_types.UUID, params *RemoveAccountMemberParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// SaveAccountMemberWithBody request with any body
+	SaveAccountMemberWithBody(ctx context.Context, accountId openapi_types.UUID, userId openapi_types.UUID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	SaveAccountMember(ctx context.Context, accountId openapi_types.UUID, userId openapi_types.UUID, body SaveAccountMemberJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetAccountPaymentHistory request
+	GetAccountPaymentHistory(ctx context.Context, accountId openapi_types.UUID, params *GetAccountPaymentHistoryParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetSharedTransfers request
+	GetSharedTransfers(ctx context.Context, accountId openapi_types.UUID, params *GetSharedTransfersParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// CreateSharedTransferWithBody request with any body
+	CreateSharedTransferWithBody(ctx context.Context, accountId openapi_types.UUID, params *CreateSharedTransferParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	CreateSharedTransfer(ctx context.Context, accountId openapi_types.UUID, params *CreateSharedTransferParams, body CreateSharedTransferJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// ApproveSharedTransferWithBody request with any body
+	ApproveSharedTransferWithBody(ctx context.Context, accountId openapi_types.UUID, transferId openapi_types.UUID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	ApproveSharedTransfer(ctx context.Context, accountId openapi_types.UUID, transferId openapi_types.UUID, body ApproveSharedTransferJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// DeclineSharedTransferWithBody request with any body
+	DeclineSharedTransferWithBody(ctx context.Context, accountId openapi_types.UUID, transferId openapi_types.UUID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	DeclineSharedTransfer(ctx context.Context, accountId openapi_types.UUID, transferId openapi_types.UUID, body DeclineSharedTransferJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// SearchAuditLogs request
+	SearchAuditLogs(ctx context.Context, params *SearchAuditLogsParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// ExportAuditLogs request
+	ExportAuditLogs(ctx context.Context, params *ExportAuditLogsParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetBusinesses request
+	GetBusinesses(ctx context.Context, params *GetBusinessesParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// OpenBusinessWithBody request with any body
+	OpenBusinessWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	OpenBusiness(ctx context.Context, body OpenBusinessJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetBusiness request
+	GetBusiness(ctx context.Context, businessId openapi_types.UUID, params *GetBusinessParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetBusinessBalance request
+	GetBusinessBalance(ctx context.Context, businessId openapi_types.UUID, params *GetBusinessBalanceParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetBusinessMembers request
+	GetBusinessMembers(ctx context.Context, businessId openapi_types.UUID, params *GetBusinessMembersParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// RemoveBusinessMember request
+	RemoveBusinessMember(ctx context.Context, businessId openapi_types.UUID, userId openapi_types.UUID, params *RemoveBusinessMemberParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// SaveBusinessMemberWithBody request with any body
+	SaveBusinessMemberWithBody(ctx context.Context, businessId openapi_types.UUID, userId openapi_types.UUID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	SaveBusinessMember(ctx context.Context, businessId openapi_types.UUID, userId openapi_types.UUID, body SaveBusinessMemberJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// AcceptBusinessInvitation request
+	AcceptBusinessInvitation(ctx context.Context, businessId openapi_types.UUID, userId openapi_types.UUID, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetBusinessPaymentHistory request
+	GetBusinessPaymentHistory(ctx context.Context, businessId openapi_types.UUID, params *GetBusinessPaymentHistoryParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetPaymentPolicies request
+	GetPaymentPolicies(ctx context.Context, businessId openapi_types.UUID, params *GetPaymentPoliciesParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// SetPaymentPoliciesWithBody request with any body
+	SetPaymentPoliciesWithBody(ctx context.Context, businessId openapi_types.UUID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	SetPaymentPolicies(ctx context.Context, businessId openapi_types.UUID, body SetPaymentPoliciesJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetBusinessTransfers request
+	GetBusinessTransfers(ctx context.Context, businessId openapi_types.UUID, params *GetBusinessTransfersParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// CreateBusinessTransferWithBody request with any body
+	CreateBusinessTransferWithBody(ctx context.Context, businessId openapi_types.UUID, params *CreateBusinessTransferParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	CreateBusinessTransfer(ctx context.Context, businessId openapi_types.UUID, params *CreateBusinessTransferParams, body CreateBusinessTransferJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetBusinessTransfer request
+	GetBusinessTransfer(ctx context.Context, businessId openapi_types.UUID, transferId openapi_types.UUID, params *GetBusinessTransferParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// ApproveBusinessTransferWithBody request with any body
+	ApproveBusinessTransferWithBody(ctx context.Context, businessId openapi_types.UUID, transferId openapi_types.UUID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	ApproveBusinessTransfer(ctx context.Context, businessId openapi_types.UUID, transferId openapi_types.UUID, body ApproveBusinessTransferJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// RejectBusinessTransferWithBody request with any body
+	RejectBusinessTransferWithBody(ctx context.Context, businessId openapi_types.UUID, transferId openapi_types.UUID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	RejectBusinessTransfer(ctx context.Context, businessId openapi_types.UUID, transferId openapi_types.UUID, body RejectBusinessTransferJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetEscrows request
+	GetEscrows(ctx context.Context, params *GetEscrowsParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// CreateEscrowWithBody request with any body
+	CreateEscrowWithBody(ctx context.Context, params *CreateEscrowParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	CreateEscrow(ctx context.Context, params *CreateEscrowParams, body CreateEscrowJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetEscrow request
+	GetEscrow(ctx context.Context, escrowId openapi_types.UUID, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// RefundEscrow request
+	RefundEscrow(ctx context.Context, escrowId openapi_types.UUID, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// ReleaseEscrow request
+	ReleaseEscrow(ctx context.Context, escrowId openapi_types.UUID, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// SplitEscrowWithBody request with any body
+	SplitEscrowWithBody(ctx context.Context, escrowId openapi_types.UUID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	SplitEscrow(ctx context.Context, escrowId openapi_types.UUID, body SplitEscrowJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetHolds request
+	GetHolds(ctx context.Context, params *GetHoldsParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PlaceHoldWithBody request with any body
+	PlaceHoldWithBody(ctx context.Context, params *PlaceHoldParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	PlaceHold(ctx context.Context, params *PlaceHoldParams, body PlaceHoldJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetHold request
+	GetHold(ctx context.Context, holdId openapi_types.UUID, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// CaptureHoldWithBody request with any body
+	CaptureHoldWithBody(ctx context.Context, holdId openapi_types.UUID, params *CaptureHoldParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	CaptureHold(ctx context.Context, holdId openapi_types.UUID, params *CaptureHoldParams, body CaptureHoldJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// ReleaseHold request
+	ReleaseHold(ctx context.Context, holdId openapi_types.UUID, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetLedgerCheckpoints request
+	GetLedgerCheckpoints(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetNotifications request
+	GetNotifications(ctx context.Context, params *GetNotificationsParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetPaymentRequests request
+	GetPaymentRequests(ctx context.Context, params *GetPaymentRequestsParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// CreatePaymentRequestWithBody request with any body
+	CreatePaymentRequestWithBody(ctx context.Context, params *CreatePaymentRequestParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	CreatePaymentRequest(ctx context.Context, params *CreatePaymentRequestParams, body CreatePaymentRequestJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetPaymentRequest request
+	GetPaymentRequest(ctx context.Context, paymentRequestId openapi_types.UUID, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// ApprovePaymentRequestWithBody request with any body
+	ApprovePaymentRequestWithBody(ctx context.Context, paymentRequestId openapi_types.UUID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	ApprovePaymentRequest(ctx context.Context, paymentRequestId openapi_types.UUID, body ApprovePaymentRequestJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// DeclinePaymentRequestWithBody request with any body
+	DeclinePaymentRequestWithBody(ctx context.Context, paymentRequestId openapi_types.UUID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	DeclinePaymentRequest(ctx context.Context, paymentRequestId openapi_types.UUID, body DeclinePaymentRequestJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetPayoutByRequestId request
+	GetPayoutByRequestId(ctx context.Context, params *GetPayoutByRequestIdParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// SubmitPayoutWithBody request with any body
+	SubmitPayoutWithBody(ctx context.Context, params *SubmitPayoutParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	SubmitPayout(ctx context.Context, params *SubmitPayoutParams, body SubmitPayoutJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetPayout request
+	GetPayout(ctx context.Context, batchId openapi_types.UUID, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetPayoutLines request
+	GetPayoutLines(ctx context.Context, batchId openapi_types.UUID, params *GetPayoutLinesParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetPockets request
+	GetPockets(ctx context.Context, params *GetPocketsParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// CreatePocketWithBody request with any body
+	CreatePocketWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	CreatePocket(ctx context.Context, body CreatePocketJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetPocket request
+	GetPocket(ctx context.Context, pocketId openapi_types.UUID, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// DepositToPocketWithBody request with any body
+	DepositToPocketWithBody(ctx context.Context, pocketId openapi_types.UUID, params *DepositToPocketParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	DepositToPocket(ctx context.Context, pocketId openapi_types.UUID, params *DepositToPocketParams, body DepositToPocketJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// WithdrawFromPocketWithBody request with any body
+	WithdrawFromPocketWithBody(ctx context.Context, pocketId openapi_types.UUID, params *WithdrawFromPocketParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	WithdrawFromPocket(ctx context.Context, pocketId openapi_types.UUID, params *WithdrawFromPocketParams, body WithdrawFromPocketJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetScheduledTransfers request
+	GetScheduledTransfers(ctx context.Context, params *GetScheduledTransfersParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// CreateScheduledTransferWithBody request with any body
+	CreateScheduledTransferWithBody(ctx context.Context, params *CreateScheduledTransferParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	CreateScheduledTransfer(ctx context.Context, params *CreateScheduledTransferParams, body CreateScheduledTransferJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// CancelScheduledTransfer request
+	CancelScheduledTransfer(ctx context.Context, scheduleId openapi_types.UUID, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetScheduledTransfer request
+	GetScheduledTransfer(ctx context.Context, scheduleId openapi_types.UUID, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetScheduledTransferRuns request
+	GetScheduledTransferRuns(ctx context.Context, scheduleId openapi_types.UUID, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetBalance request
+	GetBalance(ctx context.Context, params *GetBalanceParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// DepositWithBody request with any body
+	DepositWithBody(ctx context.Context, params *DepositParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	Deposit(ctx context.Context, params *DepositParams, body DepositJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetFeeQuote request
+	GetFeeQuote(ctx context.Context, params *GetFeeQuoteParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetInterest request
+	GetInterest(ctx context.Context, params *GetInterestParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetPaymentHistory request
+	GetPaymentHistory(ctx context.Context, params *GetPaymentHistoryParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetTrace request
+	GetTrace(ctx context.Context, params *GetTraceParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// TransferWithBody request with any body
+	TransferWithBody(ctx context.Context, params *TransferParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	Transfer(ctx context.Context, params *TransferParams, body TransferJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// WithdrawWithBody request with any body
+	WithdrawWithBody(ctx context.Context, params *WithdrawParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	Withdraw(ctx context.Context, params *WithdrawParams, body WithdrawJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetLiveness request
+	GetLiveness(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetMetrics request
+	GetMetrics(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetOpenAPI request
+	GetOpenAPI(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetReadiness request
+	GetReadiness(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+}
+
+func (c *Client) GetAccounts(ctx context.Context, params *GetAccountsParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetAccountsRequest(c.Server, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) SetApprovalThresholdWithBody(ctx context.Context, accountId openapi_types.UUID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewSetApprovalThresholdRequestWithBody(c.Server, accountId, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) SetApprovalThreshold(ctx context.Context, accountId openapi_types.UUID, body SetApprovalThresholdJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewSetApprovalThresholdRequest(c.Server, accountId, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetAccountBalance(ctx context.Context, accountId openapi_types.UUID, params *GetAccountBalanceParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetAccountBalanceRequest(c.Server, accountId, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetAccountMembers(ctx context.Context, accountId openapi_types.UUID, params *GetAccountMembersParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetAccountMembersRequest(c.Server, accountId, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) RemoveAccountMember(ctx context.Context, accountId openapi_types.UUID, userId openapi_types.UUID, params *RemoveAccountMemberParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewRemoveAccountMemberRequest(c.Server, accountId, userId, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) SaveAccountMemberWithBody(ctx context.Context, accountId openapi_types.UUID, userId openapi_types.UUID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewSaveAccountMemberRequestWithBody(c.Server, accountId, userId, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) SaveAccountMember(ctx context.Context, accountId openapi_types.UUID, userId openapi_types.UUID, body SaveAccountMemberJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewSaveAccountMemberRequest(c.Server, accountId, userId, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetAccountPaymentHistory(ctx context.Context, accountId openapi_types.UUID, params *GetAccountPaymentHistoryParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetAccountPaymentHistoryRequest(c.Server, accountId, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetSharedTransfers(ctx context.Context, accountId openapi_types.UUID, params *GetSharedTransfersParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetSharedTransfersRequest(c.Server, accountId, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) CreateSharedTransferWithBody(ctx context.Context, accountId openapi_types.UUID, params *CreateSharedTransferParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewCreateSharedTransferRequestWithBody(c.Server, accountId, params, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) CreateSharedTransfer(ctx context.Context, accountId openapi_types.UUID, params *CreateSharedTransferParams, body CreateSharedTransferJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewCreateSharedTransferRequest(c.Server, accountId, params, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) ApproveSharedTransferWithBody(ctx context.Context, accountId openapi_types.UUID, transferId openapi_types.UUID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewApproveSharedTransferRequestWithBody(c.Server, accountId, transferId, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) ApproveSharedTransfer(ctx context.Context, accountId openapi_types.UUID, transferId openapi_types.UUID, body ApproveSharedTransferJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewApproveSharedTransferRequest(c.Server, accountId, transferId, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) DeclineSharedTransferWithBody(ctx context.Context, accountId openapi_types.UUID, transferId openapi_types.UUID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewDeclineSharedTransferRequestWithBody(c.Server, accountId, transferId, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) DeclineSharedTransfer(ctx context.Context, accountId openapi_types.UUID, transferId openapi_types.UUID, body DeclineSharedTransferJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewDeclineSharedTransferRequest(c.Server, accountId, transferId, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) SearchAuditLogs(ctx context.Context, params *SearchAuditLogsParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewSearchAuditLogsRequest(c.Server, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) ExportAuditLogs(ctx context.Context, params *ExportAuditLogsParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewExportAuditLogsRequest(c.Server, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetBusinesses(ctx context.Context, params *GetBusinessesParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetBusinessesRequest(c.Server, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) OpenBusinessWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewOpenBusinessRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) OpenBusiness(ctx context.Context, body OpenBusinessJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewOpenBusinessRequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetBusiness(ctx context.Context, businessId openapi_types.UUID, params *GetBusinessParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetBusinessRequest(c.Server, businessId, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetBusinessBalance(ctx context.Context, businessId openapi_types.UUID, params *GetBusinessBalanceParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetBusinessBalanceRequest(c.Server, businessId, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetBusinessMembers(ctx context.Context, businessId openapi_types.UUID, params *GetBusinessMembersParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetBusinessMembersRequest(c.Server, businessId, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) RemoveBusinessMember(ctx context.Context, businessId openapi_types.UUID, userId openapi_types.UUID, params *RemoveBusinessMemberParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewRemoveBusinessMemberRequest(c.Server, businessId, userId, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) SaveBusinessMemberWithBody(ctx context.Context, businessId openapi_types.UUID, userId openapi_types.UUID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewSaveBusinessMemberRequestWithBody(c.Server, businessId, userId, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) SaveBusinessMember(ctx context.Context, businessId openapi_types.UUID, userId openapi_types.UUID, body SaveBusinessMemberJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewSaveBusinessMemberRequest(c.Server, businessId, userId, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) AcceptBusinessInvitation(ctx context.Context, businessId openapi_types.UUID, userId openapi_types.UUID, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewAcceptBusinessInvitationRequest(c.Server, businessId, userId)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetBusinessPaymentHistory(ctx context.Context, businessId openapi_types.UUID, params *GetBusinessPaymentHistoryParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetBusinessPaymentHistoryRequest(c.Server, businessId, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetPaymentPolicies(ctx context.Context, businessId openapi_types.UUID, params *GetPaymentPoliciesParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetPaymentPoliciesRequest(c.Server, businessId, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) SetPaymentPoliciesWithBody(ctx context.Context, businessId openapi_types.UUID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewSetPaymentPoliciesRequestWithBody(c.Server, businessId, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) SetPaymentPolicies(ctx context.Context, businessId openapi_types.UUID, body SetPaymentPoliciesJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewSetPaymentPoliciesRequest(c.Server, businessId, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetBusinessTransfers(ctx context.Context, businessId openapi_types.UUID, params *GetBusinessTransfersParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetBusinessTransfersRequest(c.Server, businessId, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) CreateBusinessTransferWithBody(ctx context.Context, businessId openapi_types.UUID, params *CreateBusinessTransferParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewCreateBusinessTransferRequestWithBody(c.Server, businessId, params, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) CreateBusinessTransfer(ctx context.Context, businessId openapi_types.UUID, params *CreateBusinessTransferParams, body CreateBusinessTransferJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewCreateBusinessTransferRequest(c.Server, businessId, params, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetBusinessTransfer(ctx context.Context, businessId openapi_types.UUID, transferId openapi_types.UUID, params *GetBusinessTransferParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetBusinessTransferRequest(c.Server, businessId, transferId, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) ApproveBusinessTransferWithBody(ctx context.Context, businessId openapi_types.UUID, transferId openapi_types.UUID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewApproveBusinessTransferRequestWithBody(c.Server, businessId, transferId, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) ApproveBusinessTransfer(ctx context.Context, businessId openapi_types.UUID, transferId openapi_types.UUID, body ApproveBusinessTransferJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewApproveBusinessTransferRequest(c.Server, businessId, transferId, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) RejectBusinessTransferWithBody(ctx context.Context, businessId openapi_types.UUID, transferId openapi_types.UUID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewRejectBusinessTransferRequestWithBody(c.Server, businessId, transferId, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) RejectBusinessTransfer(ctx context.Context, businessId openapi_types.UUID, transferId openapi_types.UUID, body RejectBusinessTransferJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewRejectBusinessTransferRequest(c.Server, businessId, transferId, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetEscrows(ctx context.Context, params *GetEscrowsParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetEscrowsRequest(c.Server, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) CreateEscrowWithBody(ctx context.Context, params *CreateEscrowParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewCreateEscrowRequestWithBody(c.Server, params, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) CreateEscrow(ctx context.Context, params *CreateEscrowParams, body CreateEscrowJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewCreateEscrowRequest(c.Server, params, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetEscrow(ctx context.Context, escrowId openapi_types.UUID, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetEscrowRequest(c.Server, escrowId)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) RefundEscrow(ctx context.Context, escrowId openapi_types.UUID, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewRefundEscrowRequest(c.Server, escrowId)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) ReleaseEscrow(ctx context.Context, escrowId openapi_types.UUID, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewReleaseEscrowRequest(c.Server, escrowId)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) SplitEscrowWithBody(ctx context.Context, escrowId openapi_types.UUID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewSplitEscrowRequestWithBody(c.Server, escrowId, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) SplitEscrow(ctx context.Context, escrowId openapi_types.UUID, body SplitEscrowJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewSplitEscrowRequest(c.Server, escrowId, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetHolds(ctx context.Context, params *GetHoldsParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetHoldsRequest(c.Server, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PlaceHoldWithBody(ctx context.Context, params *PlaceHoldParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPlaceHoldRequestWithBody(c.Server, params, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PlaceHold(ctx context.Context, params *PlaceHoldParams, body PlaceHoldJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPlaceHoldRequest(c.Server, params, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetHold(ctx context.Context, holdId openapi_types.UUID, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetHoldRequest(c.Server, holdId)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) CaptureHoldWithBody(ctx context.Context, holdId openapi_types.UUID, params *CaptureHoldParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewCaptureHoldRequestWithBody(c.Server, holdId, params, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) CaptureHold(ctx context.Context, holdId openapi_types.UUID, params *CaptureHoldParams, body CaptureHoldJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewCaptureHoldRequest(c.Server, holdId, params, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) ReleaseHold(ctx context.Context, holdId openapi_types.UUID, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewReleaseHoldRequest(c.Server, holdId)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetLedgerCheckpoints(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetLedgerCheckpointsRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetNotifications(ctx context.Context, params *GetNotificationsParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetNotificationsRequest(c.Server, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetPaymentRequests(ctx context.Context, params *GetPaymentRequestsParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetPaymentRequestsRequest(c.Server, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) CreatePaymentRequestWithBody(ctx context.Context, params *CreatePaymentRequestParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewCreatePaymentRequestRequestWithBody(c.Server, params, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) CreatePaymentRequest(ctx context.Context, params *CreatePaymentRequestParams, body CreatePaymentRequestJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewCreatePaymentRequestRequest(c.Server, params, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetPaymentRequest(ctx context.Context, paymentRequestId openapi_types.UUID, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetPaymentRequestRequest(c.Server, paymentRequestId)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) ApprovePaymentRequestWithBody(ctx context.Context, paymentRequestId openapi_types.UUID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewApprovePaymentRequestRequestWithBody(c.Server, paymentRequestId, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) ApprovePaymentRequest(ctx context.Context, paymentRequestId openapi_types.UUID, body ApprovePaymentRequestJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewApprovePaymentRequestRequest(c.Server, paymentRequestId, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) DeclinePaymentRequestWithBody(ctx context.Context, paymentRequestId openapi_types.UUID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewDeclinePaymentRequestRequestWithBody(c.Server, paymentRequestId, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) DeclinePaymentRequest(ctx context.Context, paymentRequestId openapi_types.UUID, body DeclinePaymentRequestJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewDeclinePaymentRequestRequest(c.Server, paymentRequestId, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetPayoutByRequestId(ctx context.Context, params *GetPayoutByRequestIdParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetPayoutByRequestIdRequest(c.Server, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) SubmitPayoutWithBody(ctx context.Context, params *SubmitPayoutParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewSubmitPayoutRequestWithBody(c.Server, params, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) SubmitPayout(ctx context.Context, params *SubmitPayoutParams, body SubmitPayoutJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewSubmitPayoutRequest(c.Server, params, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetPayout(ctx context.Context, batchId openapi_types.UUID, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetPayoutRequest(c.Server, batchId)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetPayoutLines(ctx context.Context, batchId openapi_types.UUID, params *GetPayoutLinesParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetPayoutLinesRequest(c.Server, batchId, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetPockets(ctx context.Context, params *GetPocketsParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetPocketsRequest(c.Server, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) CreatePocketWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewCreatePocketRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) CreatePocket(ctx context.Context, body CreatePocketJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewCreatePocketRequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetPocket(ctx context.Context, pocketId openapi_types.UUID, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetPocketRequest(c.Server, pocketId)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) DepositToPocketWithBody(ctx context.Context, pocketId openapi_types.UUID, params *DepositToPocketParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewDepositToPocketRequestWithBody(c.Server, pocketId, params, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) DepositToPocket(ctx context.Context, pocketId openapi_types.UUID, params *DepositToPocketParams, body DepositToPocketJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewDepositToPocketRequest(c.Server, pocketId, params, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) WithdrawFromPocketWithBody(ctx context.Context, pocketId openapi_types.UUID, params *WithdrawFromPocketParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewWithdrawFromPocketRequestWithBody(c.Server, pocketId, params, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) WithdrawFromPocket(ctx context.Context, pocketId openapi_types.UUID, params *WithdrawFromPocketParams, body WithdrawFromPocketJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewWithdrawFromPocketRequest(c.Server, pocketId, params, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetScheduledTransfers(ctx context.Context, params *GetScheduledTransfersParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetScheduledTransfersRequest(c.Server, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) CreateScheduledTransferWithBody(ctx context.Context, params *CreateScheduledTransferParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewCreateScheduledTransferRequestWithBody(c.Server, params, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) CreateScheduledTransfer(ctx context.Context, params *CreateScheduledTransferParams, body CreateScheduledTransferJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewCreateScheduledTransferRequest(c.Server, params, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) CancelScheduledTransfer(ctx context.Context, scheduleId openapi_types.UUID, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewCancelScheduledTransferRequest(c.Server, scheduleId)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetScheduledTransfer(ctx context.Context, scheduleId openapi_types.UUID, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetScheduledTransferRequest(c.Server, scheduleId)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetScheduledTransferRuns(ctx context.Context, scheduleId openapi_types.UUID, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetScheduledTransferRunsRequest(c.Server, scheduleId)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetBalance(ctx context.Context, params *GetBalanceParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetBalanceRequest(c.Server, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) DepositWithBody(ctx context.Context, params *DepositParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewDepositRequestWithBody(c.Server, params, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) Deposit(ctx context.Context, params *DepositParams, body DepositJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewDepositRequest(c.Server, params, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetFeeQuote(ctx context.Context, params *GetFeeQuoteParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetFeeQuoteRequest(c.Server, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetInterest(ctx context.Context, params *GetInterestParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetInterestRequest(c.Server, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetPaymentHistory(ctx context.Context, params *GetPaymentHistoryParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetPaymentHistoryRequest(c.Server, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetTrace(ctx context.Context, params *GetTraceParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetTraceRequest(c.Server, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) TransferWithBody(ctx context.Context, params *TransferParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewTransferRequestWithBody(c.Server, params, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) Transfer(ctx context.Context, params *TransferParams, body TransferJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewTransferRequest(c.Server, params, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) WithdrawWithBody(ctx context.Context, params *WithdrawParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewWithdrawRequestWithBody(c.Server, params, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) Withdraw(ctx context.Context, params *WithdrawParams, body WithdrawJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewWithdrawRequest(c.Server, params, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetLiveness(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetLivenessRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetMetrics(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetMetricsRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetOpenAPI(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetOpenAPIRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetReadiness(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetReadinessRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+// NewGetAccountsRequest generates requests for GetAccounts
+func NewGetAccountsRequest(server string, params *GetAccountsParams) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/v1/accounts")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if queryFrag, err := runtime.StyleParamWithLocation("form", true, "user_id", runtime.ParamLocationQuery, params.UserId); err != nil {
+			return nil, err
+		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+			return nil, err
+		} else {
+			for k, v := range parsed {
+				for _, v2 := range v {
+					queryValues.Add(k, v2)
+				}
+			}
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewSetApprovalThresholdRequest calls the generic SetApprovalThreshold builder with application/json body
+func NewSetApprovalThresholdRequest(server string, accountId openapi_types.UUID, body SetApprovalThresholdJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewSetApprovalThresholdRequestWithBody(server, accountId, "application/json", bodyReader)
+}
+
+// NewSetApprovalThresholdRequestWithBody generates requests for SetApprovalThreshold with any type of body
+func NewSetApprovalThresholdRequestWithBody(server string, accountId openapi_types.UUID, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "account_id", runtime.ParamLocationPath, accountId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/v1/accounts/%s/approval-threshold", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("PUT", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewGetAccountBalanceRequest generates requests for GetAccountBalance
+func NewGetAccountBalanceRequest(server string, accountId openapi_types.UUID, params *GetAccountBalanceParams) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "account_id", runtime.ParamLocationPath, accountId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/v1/accounts/%s/balance", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if queryFrag, err := runtime.StyleParamWithLocation("form", true, "user_id", runtime.ParamLocationQuery, params.UserId); err != nil {
+			return nil, err
+		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+			return nil, err
+		} else {
+			for k, v := range parsed {
+				for _, v2 := range v {
+					queryValues.Add(k, v2)
+				}
+			}
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetAccountMembersRequest generates requests for GetAccountMembers
+func NewGetAccountMembersRequest(server string, accountId openapi_types.UUID, params *GetAccountMembersParams) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "account_id", runtime.ParamLocationPath, accountId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/v1/accounts/%s/members", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if queryFrag, err := runtime.StyleParamWithLocation("form", true, "user_id", runtime.ParamLocationQuery, params.UserId); err != nil {
+			return nil, err
+		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+			return nil, err
+		} else {
+			for k, v := range parsed {
+				for _, v2 := range v {
+					queryValues.Add(k, v2)
+				}
+			}
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewRemoveAccountMemberRequest generates requests for RemoveAccountMember
+func NewRemoveAccountMemberRequest(server string, accountId openapi_types.UUID, userId openapi_types.UUID, params *RemoveAccountMemberParams) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "account_id", runtime.ParamLocationPath, accountId)
+	if err != nil {
+		return nil, err
+	}
+
+	var pathParam1 string
+
+	pathParam1, err = runtime.StyleParamWithLocation("simple", false, "user_id", runtime.ParamLocationPath, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/v1/accounts/%s/members/%s", pathParam0, pathParam1)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if queryFrag, err := runtime.StyleParamWithLocation("form", true, "by_user_id", runtime.ParamLocationQuery, params.ByUserId); err != nil {
+			return nil, err
+		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+			return nil, err
+		} else {
+			for k, v := range parsed {
+				for _, v2 := range v {
+					queryValues.Add(k, v2)
+				}
+			}
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("DELETE", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewSaveAccountMemberRequest calls the generic SaveAccountMember builder with application/json body
+func NewSaveAccountMemberRequest(server string, accountId openapi_types.UUID, userId openapi_types.UUID, body SaveAccountMemberJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewSaveAccountMemberRequestWithBody(server, accountId, userId, "application/json", bodyReader)
+}
+
+// NewSaveAccountMemberRequestWithBody generates requests for SaveAccountMember with any type of body
+func NewSaveAccountMemberRequestWithBody(server string, accountId openapi_types.UUID, userId openapi_types.UUID, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "account_id", runtime.ParamLocationPath, accountId)
+	if err != nil {
+		return nil, err
+	}
+
+	var pathParam1 string
+
+	pathParam1, err = runtime.StyleParamWithLocation("simple", false, "user_id", runtime.ParamLocationPath, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/v1/accounts/%s/members/%s", pathParam0, pathParam1)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("PUT", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewGetAccountPaymentHistoryRequest generates requests for GetAccountPaymentHistory
+func NewGetAccountPaymentHistoryRequest(server string, accountId openapi_types.UUID, params *GetAccountPaymentHistoryParams) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "account_id", runtime.ParamLocationPath, accountId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/v1/accounts/%s/payment-history", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if queryFrag, err := runtime.StyleParamWithLocation("form", true, "user_id", runtime.ParamLocationQuery, params.UserId); err != nil {
+			return nil, err
+		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+			return nil, err
+		} else {
+			for k, v := range parsed {
+				for _, v2 := range v {
+					queryValues.Add(k, v2)
+				}
+			}
+		}
+
+		if params.IncludeArchived != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "include_archived", runtime.ParamLocationQuery, *params.IncludeArchived); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetSharedTransfersRequest generates requests for GetSharedTransfers
+func NewGetSharedTransfersRequest(server string, accountId openapi_types.UUID, params *GetSharedTransfersParams) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "account_id", runtime.ParamLocationPath, accountId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/v1/accounts/%s/transfers", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if queryFrag, err := runtime.StyleParamWithLocation("form", true, "user_id", runtime.ParamLocationQuery, params.UserId); err != nil {
+			return nil, err
+		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+			return nil, err
+		} else {
+			for k, v := range parsed {
+				for _, v2 := range v {
+					queryValues.Add(k, v2)
+				}
+			}
+		}
+
+		if params.Status != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "status", runtime.ParamLocationQuery, *params.Status); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewCreateSharedTransferRequest calls the generic CreateSharedTransfer builder with application/json body
+func NewCreateSharedTransferRequest(server string, accountId openapi_types.UUID, params *CreateSharedTransferParams, body CreateSharedTransferJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewCreateSharedTransferRequestWithBody(server, accountId, params, "application/json", bodyReader)
+}
+
+// NewCreateSharedTransferRequestWithBody generates requests for CreateSharedTransfer with any type of body
+func NewCreateSharedTransferRequestWithBody(server string, accountId openapi_types.UUID, params *CreateSharedTransferParams, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "account_id", runtime.ParamLocationPath, accountId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/v1/accounts/%s/transfers", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	if params != nil {
+
+		var headerParam0 string
+
+		headerParam0, err = runtime.StyleParamWithLocation("simple", false, "X-Request-ID", runtime.ParamLocationHeader, params.XRequestID)
+		if err != nil {
+			return nil, err
+		}
+
+		req.Header.Set("X-Request-ID", headerParam0)
+
+	}
+
+	return req, nil
+}
+
+// NewApproveSharedTransferRequest calls the generic ApproveSharedTransfer builder with application/json body
+func NewApproveSharedTransferRequest(server string, accountId openapi_types.UUID, transferId openapi_types.UUID, body ApproveSharedTransferJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewApproveSharedTransferRequestWithBody(server, accountId, transferId, "application/json", bodyReader)
+}
+
+// NewApproveSharedTransferRequestWithBody generates requests for ApproveSharedTransfer with any type of body
+func NewApproveSharedTransferRequestWithBody(server string, accountId openapi_types.UUID, transferId openapi_types.UUID, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "account_id", runtime.ParamLocationPath, accountId)
+	if err != nil {
+		return nil, err
+	}
+
+	var pathParam1 string
+
+	pathParam1, err = runtime.StyleParamWithLocation("simple", false, "transfer_id", runtime.ParamLocationPath, transferId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/v1/accounts/%s/transfers/%s/approve", pathParam0, pathParam1)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewDeclineSharedTransferRequest calls the generic DeclineSharedTransfer builder with application/json body
+func NewDeclineSharedTransferRequest(server string, accountId openapi_types.UUID, transferId openapi_types.UUID, body DeclineSharedTransferJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewDeclineSharedTransferRequestWithBody(server, accountId, transferId, "application/json", bodyReader)
+}
+
+// NewDeclineSharedTransferRequestWithBody generates requests for DeclineSharedTransfer with any type of body
+func NewDeclineSharedTransferRequestWithBody(server string, accountId openapi_types.UUID, transferId openapi_types.UUID, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "account_id", runtime.ParamLocationPath, accountId)
+	if err != nil {
+		return nil, err
+	}
+
+	var pathParam1 string
+
+	pathParam1, err = runtime.StyleParamWithLocation("simple", false, "transfer_id", runtime.ParamLocationPath, transferId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/v1/accounts/%s/transfers/%s/decline", pathParam0, pathParam1)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewSearchAuditLogsRequest generates requests for SearchAuditLogs
+func NewSearchAuditLogsRequest(server string, params *SearchAuditLogsParams) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/v1/audit/logs")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.ActorType != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "actor_type", runtime.ParamLocationQuery, *params.ActorType); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.ActorId != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "actor_id", runtime.ParamLocationQuery, *params.ActorId); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Action != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "action", runtime.ParamLocationQuery, *params.Action); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.TargetType != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "target_type", runtime.ParamLocationQuery, *params.TargetType); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.TargetId != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "target_id", runtime.ParamLocationQuery, *params.TargetId); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.From != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "from", runtime.ParamLocationQuery, *params.From); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.To != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "to", runtime.ParamLocationQuery, *params.To); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.After != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "after", runtime.ParamLocationQuery, *params.After); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Limit != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "limit", runtime.ParamLocationQuery, *params.Limit); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+
+		if params.XActorType != nil {
+			var headerParam0 string
+
+			headerParam0, err = runtime.StyleParamWithLocation("simple", false, "X-Actor-Type", runtime.ParamLocationHeader, *params.XActorType)
+			if err != nil {
+				return nil, err
+			}
+
+			req.Header.Set("X-Actor-Type", headerParam0)
+		}
+
+		if params.XActorID != nil {
+			var headerParam1 string
+
+			headerParam1, err = runtime.StyleParamWithLocation("simple", false, "X-Actor-ID", runtime.ParamLocationHeader, *params.XActorID)
+			if err != nil {
+				return nil, err
+			}
+
+			req.Header.Set("X-Actor-ID", headerParam1)
+		}
+
+	}
+
+	return req, nil
+}
+
+// NewExportAuditLogsRequest generates requests for ExportAuditLogs
+func NewExportAuditLogsRequest(server string, params *ExportAuditLogsParams) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/v1/audit/logs/export")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.ActorType != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "actor_type", runtime.ParamLocationQuery, *params.ActorType); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.ActorId != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "actor_id", runtime.ParamLocationQuery, *params.ActorId); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Action != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "action", runtime.ParamLocationQuery, *params.Action); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.TargetType != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "target_type", runtime.ParamLocationQuery, *params.TargetType); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.TargetId != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "target_id", runtime.ParamLocationQuery, *params.TargetId); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.From != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "from", runtime.ParamLocationQuery, *params.From); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.To != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "to", runtime.ParamLocationQuery, *params.To); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Format != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "format", runtime.ParamLocationQuery, *params.Format); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+
+		if params.XActorType != nil {
+			var headerParam0 string
+
+			headerParam0, err = runtime.StyleParamWithLocation("simple", false, "X-Actor-Type", runtime.ParamLocationHeader, *params.XActorType)
+			if err != nil {
+				return nil, err
+			}
+
+			req.Header.Set("X-Actor-Type", headerParam0)
+		}
+
+		if params.XActorID != nil {
+			var headerParam1 string
+
+			headerParam1, err = runtime.StyleParamWithLocation("simple", false, "X-Actor-ID", runtime.ParamLocationHeader, *params.XActorID)
+			if err != nil {
+				return nil, err
+			}
+
+			req.Header.Set("X-Actor-ID", headerParam1)
+		}
+
+	}
+
+	return req, nil
+}
+
+// NewGetBusinessesRequest generates requests for GetBusinesses
+func NewGetBusinessesRequest(server string, params *GetBusinessesParams) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/v1/businesses")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if queryFrag, err := runtime.StyleParamWithLocation("form", true, "user_id", runtime.ParamLocationQuery, params.UserId); err != nil {
+			return nil, err
+		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+			return nil, err
+		} else {
+			for k, v := range parsed {
+				for _, v2 := range v {
+					queryValues.Add(k, v2)
+				}
+			}
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewOpenBusinessRequest calls the generic OpenBusiness builder with application/json body
+func NewOpenBusinessRequest(server string, body OpenBusinessJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewOpenBusinessRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewOpenBusinessRequestWithBody generates requests for OpenBusiness with any type of body
+func NewOpenBusinessRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/v1/businesses")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewGetBusinessRequest generates requests for GetBusiness
+func NewGetBusinessRequest(server string, businessId openapi_types.UUID, params *GetBusinessParams) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "business_id", runtime.ParamLocationPath, businessId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/v1/businesses/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if queryFrag, err := runtime.StyleParamWithLocation("form", true, "user_id", runtime.ParamLocationQuery, params.UserId); err != nil {
+			return nil, err
+		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+			return nil, err
+		} else {
+			for k, v := range parsed {
+				for _, v2 := range v {
+					queryValues.Add(k, v2)
+				}
+			}
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetBusinessBalanceRequest generates requests for GetBusinessBalance
+func NewGetBusinessBalanceRequest(server string, businessId openapi_types.UUID, params *GetBusinessBalanceParams) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "business_id", runtime.ParamLocationPath, businessId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/v1/businesses/%s/balance", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if queryFrag, err := runtime.StyleParamWithLocation("form", true, "user_id", runtime.ParamLocationQuery, params.UserId); err != nil {
+			return nil, err
+		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+			return nil, err
+		} else {
+			for k, v := range parsed {
+				for _, v2 := range v {
+					queryValues.Add(k, v2)
+				}
+			}
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetBusinessMembersRequest generates requests for GetBusinessMembers
+func NewGetBusinessMembersRequest(server string, businessId openapi_types.UUID, params *GetBusinessMembersParams) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "business_id", runtime.ParamLocationPath, businessId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/v1/businesses/%s/members", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if queryFrag, err := runtime.StyleParamWithLocation("form", true, "user_id", runtime.ParamLocationQuery, params.UserId); err != nil {
+			return nil, err
+		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+			return nil, err
+		} else {
+			for k, v := range parsed {
+				for _, v2 := range v {
+					queryValues.Add(k, v2)
+				}
+			}
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewRemoveBusinessMemberRequest generates requests for RemoveBusinessMember
+func NewRemoveBusinessMemberRequest(server string, businessId openapi_types.UUID, userId openapi_types.UUID, params *RemoveBusinessMemberParams) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "business_id", runtime.ParamLocationPath, businessId)
+	if err != nil {
+		return nil, err
+	}
+
+	var pathParam1 string
+
+	pathParam1, err = runtime.StyleParamWithLocation("simple", false, "user_id", runtime.ParamLocationPath, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/v1/businesses/%s/members/%s", pathParam0, pathParam1)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if queryFrag, err := runtime.StyleParamWithLocation("form", true, "by_user_id", runtime.ParamLocationQuery, params.ByUserId); err != nil {
+			return nil, err
+		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+			return nil, err
+		} else {
+			for k, v := range parsed {
+				for _, v2 := range v {
+					queryValues.Add(k, v2)
+				}
+			}
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("DELETE", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewSaveBusinessMemberRequest calls the generic SaveBusinessMember builder with application/json body
+func NewSaveBusinessMemberRequest(server string, businessId openapi_types.UUID, userId openapi_types.UUID, body SaveBusinessMemberJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewSaveBusinessMemberRequestWithBody(server, businessId, userId, "application/json", bodyReader)
+}
+
+// NewSaveBusinessMemberRequestWithBody generates requests for SaveBusinessMember with any type of body
+func NewSaveBusinessMemberRequestWithBody(server string, businessId openapi_types.UUID, userId openapi_types.UUID, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "business_id", runtime.ParamLocationPath, businessId)
+	if err != nil {
+		return nil, err
+	}
+
+	var pathParam1 string
+
+	pathParam1, err = runtime.StyleParamWithLocation("simple", false, "user_id", runtime.ParamLocationPath, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/v1/businesses/%s/members/%s", pathParam0, pathParam1)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("PUT", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewAcceptBusinessInvitationRequest generates requests for AcceptBusinessInvitation
+func NewAcceptBusinessInvitationRequest(server string, businessId openapi_types.UUID, userId openapi_types.UUID) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "business_id", runtime.ParamLocationPath, businessId)
+	if err != nil {
+		return nil, err
+	}
+
+	var pathParam1 string
+
+	pathParam1, err = runtime.StyleParamWithLocation("simple", false, "user_id", runtime.ParamLocationPath, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/v1/businesses/%s/members/%s/accept", pathParam0, pathParam1)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetBusinessPaymentHistoryRequest generates requests for GetBusinessPaymentHistory
+func NewGetBusinessPaymentHistoryRequest(server string, businessId openapi_types.UUID, params *GetBusinessPaymentHistoryParams) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "business_id", runtime.ParamLocationPath, businessId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/v1/businesses/%s/payment-history", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if queryFrag, err := runtime.StyleParamWithLocation("form", true, "user_id", runtime.ParamLocationQuery, params.UserId); err != nil {
+			return nil, err
+		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+			return nil, err
+		} else {
+			for k, v := range parsed {
+				for _, v2 := range v {
+					queryValues.Add(k, v2)
+				}
+			}
+		}
+
+		if params.IncludeArchived != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "include_archived", runtime.ParamLocationQuery, *params.IncludeArchived); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetPaymentPoliciesRequest generates requests for GetPaymentPolicies
+func NewGetPaymentPoliciesRequest(server string, businessId openapi_types.UUID, params *GetPaymentPoliciesParams) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "business_id", runtime.ParamLocationPath, businessId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/v1/businesses/%s/policies", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if queryFrag, err := runtime.StyleParamWithLocation("form", true, "user_id", runtime.ParamLocationQuery, params.UserId); err != nil {
+			return nil, err
+		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+			return nil, err
+		} else {
+			for k, v := range parsed {
+				for _, v2 := range v {
+					queryValues.Add(k, v2)
+				}
+			}
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewSetPaymentPoliciesRequest calls the generic SetPaymentPolicies builder with application/json body
+func NewSetPaymentPoliciesRequest(server string, businessId openapi_types.UUID, body SetPaymentPoliciesJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewSetPaymentPoliciesRequestWithBody(server, businessId, "application/json", bodyReader)
+}
+
+// NewSetPaymentPoliciesRequestWithBody generates requests for SetPaymentPolicies with any type of body
+func NewSetPaymentPoliciesRequestWithBody(server string, businessId openapi_types.UUID, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "business_id", runtime.ParamLocationPath, businessId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/v1/businesses/%s/policies", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("PUT", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewGetBusinessTransfersRequest generates requests for GetBusinessTransfers
+func NewGetBusinessTransfersRequest(server string, businessId openapi_types.UUID, params *GetBusinessTransfersParams) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "business_id", runtime.ParamLocationPath, businessId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/v1/businesses/%s/transfers", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if queryFrag, err := runtime.StyleParamWithLocation("form", true, "user_id", runtime.ParamLocationQuery, params.UserId); err != nil {
+			return nil, err
+		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+			return nil, err
+		} else {
+			for k, v := range parsed {
+				for _, v2 := range v {
+					queryValues.Add(k, v2)
+				}
+			}
+		}
+
+		if params.Status != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "status", runtime.ParamLocationQuery, *params.Status); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewCreateBusinessTransferRequest calls the generic CreateBusinessTransfer builder with application/json body
+func NewCreateBusinessTransferRequest(server string, businessId openapi_types.UUID, params *CreateBusinessTransferParams, body CreateBusinessTransferJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewCreateBusinessTransferRequestWithBody(server, businessId, params, "application/json", bodyReader)
+}
+
+// NewCreateBusinessTransferRequestWithBody generates requests for CreateBusinessTransfer with any type of body
+func NewCreateBusinessTransferRequestWithBody(server string, businessId openapi_types.UUID, params *CreateBusinessTransferParams, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "business_id", runtime.ParamLocationPath, businessId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/v1/businesses/%s/transfers", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	if params != nil {
+
+		var headerParam0 string
+
+		headerParam0, err = runtime.StyleParamWithLocation("simple", false, "X-Request-ID", runtime.ParamLocationHeader, params.XRequestID)
+		if err != nil {
+			return nil, err
+		}
+
+		req.Header.Set("X-Request-ID", headerParam0)
+
+	}
+
+	return req, nil
+}
+
+// NewGetBusinessTransferRequest generates requests for GetBusinessTransfer
+func NewGetBusinessTransferRequest(server string, businessId openapi_types.UUID, transferId openapi_types.UUID, params *GetBusinessTransferParams) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "business_id", runtime.ParamLocationPath, businessId)
+	if err != nil {
+		return nil, err
+	}
+
+	var pathParam1 string
+
+	pathParam1, err = runtime.StyleParamWithLocation("simple", false, "transfer_id", runtime.ParamLocationPath, transferId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/v1/businesses/%s/transfers/%s", pathParam0, pathParam1)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if queryFrag, err := runtime.StyleParamWithLocation("form", true, "user_id", runtime.ParamLocationQuery, params.UserId); err != nil {
+			return nil, err
+		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+			return nil, err
+		} else {
+			for k, v := range parsed {
+				for _, v2 := range v {
+					queryValues.Add(k, v2)
+				}
+			}
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewApproveBusinessTransferRequest calls the generic ApproveBusinessTransfer builder with application/json body
+func NewApproveBusinessTransferRequest(server string, businessId openapi_types.UUID, transferId openapi_types.UUID, body ApproveBusinessTransferJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewApproveBusinessTransferRequestWithBody(server, businessId, transferId, "application/json", bodyReader)
+}
+
+// NewApproveBusinessTransferRequestWithBody generates requests for ApproveBusinessTransfer with any type of body
+func NewApproveBusinessTransferRequestWithBody(server string, businessId openapi_types.UUID, transferId openapi_types.UUID, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "business_id", runtime.ParamLocationPath, businessId)
+	if err != nil {
+		return nil, err
+	}
+
+	var pathParam1 string
+
+	pathParam1, err = runtime.StyleParamWithLocation("simple", false, "transfer_id", runtime.ParamLocationPath, transferId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/v1/businesses/%s/transfers/%s/approve", pathParam0, pathParam1)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewRejectBusinessTransferRequest calls the generic RejectBusinessTransfer builder with application/json body
+func NewRejectBusinessTransferRequest(server string, businessId openapi_types.UUID, transferId openapi_types.UUID, body RejectBusinessTransferJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewRejectBusinessTransferRequestWithBody(server, businessId, transferId, "application/json", bodyReader)
+}
+
+// NewRejectBusinessTransferRequestWithBody generates requests for RejectBusinessTransfer with any type of body
+func NewRejectBusinessTransferRequestWithBody(server string, businessId openapi_types.UUID, transferId openapi_types.UUID, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "business_id", runtime.ParamLocationPath, businessId)
+	if err != nil {
+		return nil, err
+	}
+
+	var pathParam1 string
+
+	pathParam1, err = runtime.StyleParamWithLocation("simple", false, "transfer_id", runtime.ParamLocationPath, transferId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/v1/businesses/%s/transfers/%s/reject", pathParam0, pathParam1)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewGetEscrowsRequest generates requests for GetEscrows
+func NewGetEscrowsRequest(server string, params *GetEscrowsParams) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/v1/escrows")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if queryFrag, err := runtime.StyleParamWithLocation("form", true, "user_id", runtime.ParamLocationQuery, params.UserId); err != nil {
+			return nil, err
+		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+			return nil, err
+		} else {
+			for k, v := range parsed {
+				for _, v2 := range v {
+					queryValues.Add(k, v2)
+				}
+			}
+		}
+
+		if params.Status != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "status", runtime.ParamLocationQuery, *params.Status); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewCreateEscrowRequest calls the generic CreateEscrow builder with application/json body
+func NewCreateEscrowRequest(server string, params *CreateEscrowParams, body CreateEscrowJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewCreateEscrowRequestWithBody(server, params, "application/json", bodyReader)
+}
+
+// NewCreateEscrowRequestWithBody generates requests for CreateEscrow with any type of body
+func NewCreateEscrowRequestWithBody(server string, params *CreateEscrowParams, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/v1/escrows")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	if params != nil {
+
+		var headerParam0 string
+
+		headerParam0, err = runtime.StyleParamWithLocation("simple", false, "X-Request-ID", runtime.ParamLocationHeader, params.XRequestID)
+		if err != nil {
+			return nil, err
+		}
+
+		req.Header.Set("X-Request-ID", headerParam0)
+
+	}
+
+	return req, nil
+}
+
+// NewGetEscrowRequest generates requests for GetEscrow
+func NewGetEscrowRequest(server string, escrowId openapi_types.UUID) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "escrow_id", runtime.ParamLocationPath, escrowId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/v1/escrows/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewRefundEscrowRequest generates requests for RefundEscrow
+func NewRefundEscrowRequest(server string, escrowId openapi_types.UUID) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "escrow_id", runtime.ParamLocationPath, escrowId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/v1/escrows/%s/refund", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewReleaseEscrowRequest generates requests for ReleaseEscrow
+func NewReleaseEscrowRequest(server string, escrowId openapi_types.UUID) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "escrow_id", runtime.ParamLocationPath, escrowId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/v1/escrows/%s/release", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewSplitEscrowRequest calls the generic SplitEscrow builder with application/json body
+func NewSplitEscrowRequest(server string, escrowId openapi_types.UUID, body SplitEscrowJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewSplitEscrowRequestWithBody(server, escrowId, "application/json", bodyReader)
+}
+
+// NewSplitEscrowRequestWithBody generates requests for SplitEscrow with any type of body
+func NewSplitEscrowRequestWithBody(server string, escrowId openapi_types.UUID, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "escrow_id", runtime.ParamLocationPath, escrowId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/v1/escrows/%s/split", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewGetHoldsRequest generates requests for GetHolds
+func NewGetHoldsRequest(server string, params *GetHoldsParams) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/v1/holds")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if queryFrag, err := runtime.StyleParamWithLocation("form", true, "user_id", runtime.ParamLocationQuery, params.UserId); err != nil {
+			return nil, err
+		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+			return nil, err
+		} else {
+			for k, v := range parsed {
+				for _, v2 := range v {
+					queryValues.Add(k, v2)
+				}
+			}
+		}
+
+		if params.Status != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "status", runtime.ParamLocationQuery, *params.Status); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewPlaceHoldRequest calls the generic PlaceHold builder with application/json body
+func NewPlaceHoldRequest(server string, params *PlaceHoldParams, body PlaceHoldJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPlaceHoldRequestWithBody(server, params, "application/json", bodyReader)
+}
+
+// NewPlaceHoldRequestWithBody generates requests for PlaceHold with any type of body
+func NewPlaceHoldRequestWithBody(server string, params *PlaceHoldParams, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/v1/holds")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	if params != nil {
+
+		var headerParam0 string
+
+		headerParam0, err = runtime.StyleParamWithLocation("simple", false, "X-Request-ID", runtime.ParamLocationHeader, params.XRequestID)
+		if err != nil {
+			return nil, err
+		}
+
+		req.Header.Set("X-Request-ID", headerParam0)
+
+	}
+
+	return req, nil
+}
+
+// NewGetHoldRequest generates requests for GetHold
+func NewGetHoldRequest(server string, holdId openapi_types.UUID) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "hold_id", runtime.ParamLocationPath, holdId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/v1/holds/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewCaptureHoldRequest calls the generic CaptureHold builder with application/json body
+func NewCaptureHoldRequest(server string, holdId openapi_types.UUID, params *CaptureHoldParams, body CaptureHoldJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewCaptureHoldRequestWithBody(server, holdId, params, "application/json", bodyReader)
+}
+
+// NewCaptureHoldRequestWithBody generates requests for CaptureHold with any type of body
+func NewCaptureHoldRequestWithBody(server string, holdId openapi_types.UUID, params *CaptureHoldParams, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "hold_id", runtime.ParamLocationPath, holdId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/v1/holds/%s/capture", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	if params != nil {
+
+		var headerParam0 string
+
+		headerParam0, err = runtime.StyleParamWithLocation("simple", false, "X-Request-ID", runtime.ParamLocationHeader, params.XRequestID)
+		if err != nil {
+			return nil, err
+		}
+
+		req.Header.Set("X-Request-ID", headerParam0)
+
+	}
+
+	return req, nil
+}
+
+// NewReleaseHoldRequest generates requests for ReleaseHold
+func NewReleaseHoldRequest(server string, holdId openapi_types.UUID) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "hold_id", runtime.ParamLocationPath, holdId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/v1/holds/%s/release", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetLedgerCheckpointsRequest generates requests for GetLedgerCheckpoints
+func NewGetLedgerCheckpointsRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/v1/ledger/checkpoints")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetNotificationsRequest generates requests for GetNotifications
+func NewGetNotificationsRequest(server string, params *GetNotificationsParams) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/v1/notifications")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if queryFrag, err := runtime.StyleParamWithLocation("form", true, "user_id", runtime.ParamLocationQuery, params.UserId); err != nil {
+			return nil, err
+		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+			return nil, err
+		} else {
+			for k, v := range parsed {
+				for _, v2 := range v {
+					queryValues.Add(k, v2)
+				}
+			}
+		}
+
+		if params.After != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "after", runtime.ParamLocationQuery, *params.After); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetPaymentRequestsRequest generates requests for GetPaymentRequests
+func NewGetPaymentRequestsRequest(server string, params *GetPaymentRequestsParams) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/v1/payment-requests")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if queryFrag, err := runtime.StyleParamWithLocation("form", true, "user_id", runtime.ParamLocationQuery, params.UserId); err != nil {
+			return nil, err
+		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+			return nil, err
+		} else {
+			for k, v := range parsed {
+				for _, v2 := range v {
+					queryValues.Add(k, v2)
+				}
+			}
+		}
+
+		if params.Role != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "role", runtime.ParamLocationQuery, *params.Role); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Status != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "status", runtime.ParamLocationQuery, *params.Status); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewCreatePaymentRequestRequest calls the generic CreatePaymentRequest builder with application/json body
+func NewCreatePaymentRequestRequest(server string, params *CreatePaymentRequestParams, body CreatePaymentRequestJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewCreatePaymentRequestRequestWithBody(server, params, "application/json", bodyReader)
+}
+
+// NewCreatePaymentRequestRequestWithBody generates requests for CreatePaymentRequest with any type of body
+func NewCreatePaymentRequestRequestWithBody(server string, params *CreatePaymentRequestParams, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/v1/payment-requests")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	if params != nil {
+
+		var headerParam0 string
+
+		headerParam0, err = runtime.StyleParamWithLocation("simple", false, "X-Request-ID", runtime.ParamLocationHeader, params.XRequestID)
+		if err != nil {
+			return nil, err
+		}
+
+		req.Header.Set("X-Request-ID", headerParam0)
+
+	}
+
+	return req, nil
+}
+
+// NewGetPaymentRequestRequest generates requests for GetPaymentRequest
+func NewGetPaymentRequestRequest(server string, paymentRequestId openapi_types.UUID) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "payment_request_id", runtime.ParamLocationPath, paymentRequestId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/v1/payment-requests/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewApprovePaymentRequestRequest calls the generic ApprovePaymentRequest builder with application/json body
+func NewApprovePaymentRequestRequest(server string, paymentRequestId openapi_types.UUID, body ApprovePaymentRequestJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewApprovePaymentRequestRequestWithBody(server, paymentRequestId, "application/json", bodyReader)
+}
+
+// NewApprovePaymentRequestRequestWithBody generates requests for ApprovePaymentRequest with any type of body
+func NewApprovePaymentRequestRequestWithBody(server string, paymentRequestId openapi_types.UUID, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "payment_request_id", runtime.ParamLocationPath, paymentRequestId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/v1/payment-requests/%s/approve", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewDeclinePaymentRequestRequest calls the generic DeclinePaymentRequest builder with application/json body
+func NewDeclinePaymentRequestRequest(server string, paymentRequestId openapi_types.UUID, body DeclinePaymentRequestJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewDeclinePaymentRequestRequestWithBody(server, paymentRequestId, "application/json", bodyReader)
+}
+
+// NewDeclinePaymentRequestRequestWithBody generates requests for DeclinePaymentRequest with any type of body
+func NewDeclinePaymentRequestRequestWithBody(server string, paymentRequestId openapi_types.UUID, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "payment_request_id", runtime.ParamLocationPath, paymentRequestId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/v1/payment-requests/%s/decline", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewGetPayoutByRequestIdRequest generates requests for GetPayoutByRequestId
+func NewGetPayoutByRequestIdRequest(server string, params *GetPayoutByRequestIdParams) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/v1/payouts")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if queryFrag, err := runtime.StyleParamWithLocation("form", true, "request_id", runtime.ParamLocationQuery, params.RequestId); err != nil {
+			return nil, err
+		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+			return nil, err
+		} else {
+			for k, v := range parsed {
+				for _, v2 := range v {
+					queryValues.Add(k, v2)
+				}
+			}
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewSubmitPayoutRequest calls the generic SubmitPayout builder with application/json body
+func NewSubmitPayoutRequest(server string, params *SubmitPayoutParams, body SubmitPayoutJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewSubmitPayoutRequestWithBody(server, params, "application/json", bodyReader)
+}
+
+// NewSubmitPayoutRequestWithBody generates requests for SubmitPayout with any type of body
+func NewSubmitPayoutRequestWithBody(server string, params *SubmitPayoutParams, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/v1/payouts")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.FundingUserId != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "funding_user_id", runtime.ParamLocationQuery, *params.FundingUserId); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Mode != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "mode", runtime.ParamLocationQuery, *params.Mode); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	if params != nil {
+
+		var headerParam0 string
+
+		headerParam0, err = runtime.StyleParamWithLocation("simple", false, "X-Request-ID", runtime.ParamLocationHeader, params.XRequestID)
+		if err != nil {
+			return nil, err
+		}
+
+		req.Header.Set("X-Request-ID", headerParam0)
+
+	}
+
+	return req, nil
+}
+
+// NewGetPayoutRequest generates requests for GetPayout
+func NewGetPayoutRequest(server string, batchId openapi_types.UUID) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "batch_id", runtime.ParamLocationPath, batchId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/v1/payouts/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetPayoutLinesRequest generates requests for GetPayoutLines
+func NewGetPayoutLinesRequest(server string, batchId openapi_types.UUID, params *GetPayoutLinesParams) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "batch_id", runtime.ParamLocationPath, batchId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/v1/payouts/%s/lines", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Status != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "status", runtime.ParamLocationQuery, *params.Status); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.After != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "after", runtime.ParamLocationQuery, *params.After); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Limit != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "limit", runtime.ParamLocationQuery, *params.Limit); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetPocketsRequest generates requests for GetPockets
+func NewGetPocketsRequest(server string, params *GetPocketsParams) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/v1/pockets")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if queryFrag, err := runtime.StyleParamWithLocation("form", true, "user_id", runtime.ParamLocationQuery, params.UserId); err != nil {
+			return nil, err
+		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+			return nil, err
+		} else {
+			for k, v := range parsed {
+				for _, v2 := range v {
+					queryValues.Add(k, v2)
+				}
+			}
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewCreatePocketRequest calls the generic CreatePocket builder with application/json body
+func NewCreatePocketRequest(server string, body CreatePocketJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewCreatePocketRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewCreatePocketRequestWithBody generates requests for CreatePocket with any type of body
+func NewCreatePocketRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/v1/pockets")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewGetPocketRequest generates requests for GetPocket
+func NewGetPocketRequest(server string, pocketId openapi_types.UUID) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "pocket_id", runtime.ParamLocationPath, pocketId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/v1/pockets/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewDepositToPocketRequest calls the generic DepositToPocket builder with application/json body
+func NewDepositToPocketRequest(server string, pocketId openapi_types.UUID, params *DepositToPocketParams, body DepositToPocketJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewDepositToPocketRequestWithBody(server, pocketId, params, "application/json", bodyReader)
+}
+
+// NewDepositToPocketRequestWithBody generates requests for DepositToPocket with any type of body
+func NewDepositToPocketRequestWithBody(server string, pocketId openapi_types.UUID, params *DepositToPocketParams, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "pocket_id", runtime.ParamLocationPath, pocketId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/v1/pockets/%s/deposit", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	if params != nil {
+
+		var headerParam0 string
+
+		headerParam0, err = runtime.StyleParamWithLocation("simple", false, "X-Request-ID", runtime.ParamLocationHeader, params.XRequestID)
+		if err != nil {
+			return nil, err
+		}
+
+		req.Header.Set("X-Request-ID", headerParam0)
+
+	}
+
+	return req, nil
+}
+
+// NewWithdrawFromPocketRequest calls the generic WithdrawFromPocket builder with application/json body
+func NewWithdrawFromPocketRequest(server string, pocketId openapi_types.UUID, params *WithdrawFromPocketParams, body WithdrawFromPocketJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewWithdrawFromPocketRequestWithBody(server, pocketId, params, "application/json", bodyReader)
+}
+
+// NewWithdrawFromPocketRequestWithBody generates requests for WithdrawFromPocket with any type of body
+func NewWithdrawFromPocketRequestWithBody(server string, pocketId openapi_types.UUID, params *WithdrawFromPocketParams, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "pocket_id", runtime.ParamLocationPath, pocketId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/v1/pockets/%s/withdrawal", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	if params != nil {
+
+		var headerParam0 string
+
+		headerParam0, err = runtime.StyleParamWithLocation("simple", false, "X-Request-ID", runtime.ParamLocationHeader, params.XRequestID)
+		if err != nil {
+			return nil, err
+		}
+
+		req.Header.Set("X-Request-ID", headerParam0)
+
+	}
+
+	return req, nil
+}
+
+// NewGetScheduledTransfersRequest generates requests for GetScheduledTransfers
+func NewGetScheduledTransfersRequest(server string, params *GetScheduledTransfersParams) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/v1/scheduled-transfers")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if queryFrag, err := runtime.StyleParamWithLocation("form", true, "user_id", runtime.ParamLocationQuery, params.UserId); err != nil {
+			return nil, err
+		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+			return nil, err
+		} else {
+			for k, v := range parsed {
+				for _, v2 := range v {
+					queryValues.Add(k, v2)
+				}
+			}
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewCreateScheduledTransferRequest calls the generic CreateScheduledTransfer builder with application/json body
+func NewCreateScheduledTransferRequest(server string, params *CreateScheduledTransferParams, body CreateScheduledTransferJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewCreateScheduledTransferRequestWithBody(server, params, "application/json", bodyReader)
+}
+
+// NewCreateScheduledTransferRequestWithBody generates requests for CreateScheduledTransfer with any type of body
+func NewCreateScheduledTransferRequestWithBody(server string, params *CreateScheduledTransferParams, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/v1/scheduled-transfers")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	if params != nil {
+
+		var headerParam0 string
+
+		headerParam0, err = runtime.StyleParamWithLocation("simple", false, "X-Request-ID", runtime.ParamLocationHeader, params.XRequestID)
+		if err != nil {
+			return nil, err
+		}
+
+		req.Header.Set("X-Request-ID", headerParam0)
+
+	}
+
+	return req, nil
+}
+
+// NewCancelScheduledTransferRequest generates requests for CancelScheduledTransfer
+func NewCancelScheduledTransferRequest(server string, scheduleId openapi_types.UUID) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "schedule_id", runtime.ParamLocationPath, scheduleId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/v1/scheduled-transfers/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("DELETE", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetScheduledTransferRequest generates requests for GetScheduledTransfer
+func NewGetScheduledTransferRequest(server string, scheduleId openapi_types.UUID) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "schedule_id", runtime.ParamLocationPath, scheduleId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/v1/scheduled-transfers/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetScheduledTransferRunsRequest generates requests for GetScheduledTransferRuns
+func NewGetScheduledTransferRunsRequest(server string, scheduleId openapi_types.UUID) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "schedule_id", runtime.ParamLocationPath, scheduleId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/v1/scheduled-transfers/%s/runs", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetBalanceRequest generates requests for GetBalance
+func NewGetBalanceRequest(server string, params *GetBalanceParams) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/v1/wallet/balance")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if queryFrag, err := runtime.StyleParamWithLocation("form", true, "user_id", runtime.ParamLocationQuery, params.UserId); err != nil {
+			return nil, err
+		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+			return nil, err
+		} else {
+			for k, v := range parsed {
+				for _, v2 := range v {
+					queryValues.Add(k, v2)
+				}
+			}
+		}
+
+		if params.AsOf != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "as_of", runtime.ParamLocationQuery, *params.AsOf); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewDepositRequest calls the generic Deposit builder with application/json body
+func NewDepositRequest(server string, params *DepositParams, body DepositJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewDepositRequestWithBody(server, params, "application/json", bodyReader)
+}
+
+// NewDepositRequestWithBody generates requests for Deposit with any type of body
+func NewDepositRequestWithBody(server string, params *DepositParams, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/v1/wallet/deposit")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	if params != nil {
+
+		var headerParam0 string
+
+		headerParam0, err = runtime.StyleParamWithLocation("simple", false, "X-Request-ID", runtime.ParamLocationHeader, params.XRequestID)
+		if err != nil {
+			return nil, err
+		}
+
+		req.Header.Set("X-Request-ID", headerParam0)
+
+	}
+
+	return req, nil
+}
+
+// NewGetFeeQuoteRequest generates requests for GetFeeQuote
+func NewGetFeeQuoteRequest(server string, params *GetFeeQuoteParams) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/v1/wallet/fee-quote")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if queryFrag, err := runtime.StyleParamWithLocation("form", true, "user_id", runtime.ParamLocationQuery, params.UserId); err != nil {
+			return nil, err
+		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+			return nil, err
+		} else {
+			for k, v := range parsed {
+				for _, v2 := range v {
+					queryValues.Add(k, v2)
+				}
+			}
+		}
+
+		if queryFrag, err := runtime.StyleParamWithLocation("form", true, "operation", runtime.ParamLocationQuery, params.Operation); err != nil {
+			return nil, err
+		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+			return nil, err
+		} else {
+			for k, v := range parsed {
+				for _, v2 := range v {
+					queryValues.Add(k, v2)
+				}
+			}
+		}
+
+		if queryFrag, err := runtime.StyleParamWithLocation("form", true, "amount", runtime.ParamLocationQuery, params.Amount); err != nil {
+			return nil, err
+		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+			return nil, err
+		} else {
+			for k, v := range parsed {
+				for _, v2 := range v {
+					queryValues.Add(k, v2)
+				}
+			}
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetInterestRequest generates requests for GetInterest
+func NewGetInterestRequest(server string, params *GetInterestParams) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/v1/wallet/interest")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if queryFrag, err := runtime.StyleParamWithLocation("form", true, "user_id", runtime.ParamLocationQuery, params.UserId); err != nil {
+			return nil, err
+		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+			return nil, err
+		} else {
+			for k, v := range parsed {
+				for _, v2 := range v {
+					queryValues.Add(k, v2)
+				}
+			}
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetPaymentHistoryRequest generates requests for GetPaymentHistory
+func NewGetPaymentHistoryRequest(server string, params *GetPaymentHistoryParams) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/v1/wallet/payment-history")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if queryFrag, err := runtime.StyleParamWithLocation("form", true, "user_id", runtime.ParamLocationQuery, params.UserId); err != nil {
+			return nil, err
+		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+			return nil, err
+		} else {
+			for k, v := range parsed {
+				for _, v2 := range v {
+					queryValues.Add(k, v2)
+				}
+			}
+		}
+
+		if params.IncludeArchived != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "include_archived", runtime.ParamLocationQuery, *params.IncludeArchived); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetTraceRequest generates requests for GetTrace
+func NewGetTraceRequest(server string, params *GetTraceParams) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/v1/wallet/trace")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.TraceId != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "trace_id", runtime.ParamLocationQuery, *params.TraceId); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.RequestId != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "request_id", runtime.ParamLocationQuery, *params.RequestId); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewTransferRequest calls the generic Transfer builder with application/json body
+func NewTransferRequest(server string, params *TransferParams, body TransferJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewTransferRequestWithBody(server, params, "application/json", bodyReader)
+}
+
+// NewTransferRequestWithBody generates requests for Transfer with any type of body
+func NewTransferRequestWithBody(server string, params *TransferParams, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/v1/wallet/transfer")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	if params != nil {
+
+		var headerParam0 string
+
+		headerParam0, err = runtime.StyleParamWithLocation("simple", false, "X-Request-ID", runtime.ParamLocationHeader, params.XRequestID)
+		if err != nil {
+			return nil, err
+		}
+
+		req.Header.Set("X-Request-ID", headerParam0)
+
+	}
+
+	return req, nil
+}
+
+// NewWithdrawRequest calls the generic Withdraw builder with application/json body
+func NewWithdrawRequest(server string, params *WithdrawParams, body WithdrawJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewWithdrawRequestWithBody(server, params, "application/json", bodyReader)
+}
+
+// NewWithdrawRequestWithBody generates requests for Withdraw with any type of body
+func NewWithdrawRequestWithBody(server string, params *WithdrawParams, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/v1/wallet/withdrawal")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	if params != nil {
+
+		var headerParam0 string
+
+		headerParam0, err = runtime.StyleParamWithLocation("simple", false, "X-Request-ID", runtime.ParamLocationHeader, params.XRequestID)
+		if err != nil {
+			return nil, err
+		}
+
+		req.Header.Set("X-Request-ID", headerParam0)
+
+	}
+
+	return req, nil
+}
+
+// NewGetLivenessRequest generates requests for GetLiveness
+func NewGetLivenessRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/healthz")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetMetricsRequest generates requests for GetMetrics
+func NewGetMetricsRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/metrics")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetOpenAPIRequest generates requests for GetOpenAPI
+func NewGetOpenAPIRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/openapi.json")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetReadinessRequest generates requests for GetReadiness
+func NewGetReadinessRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/readyz")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+func (c *Client) applyEditors(ctx context.Context, req *http.Request, additionalEditors []RequestEditorFn) error {
+	for _, r := range c.RequestEditors {
+		if err := r(ctx, req); err != nil {
+			return err
+		}
+	}
+	for _, r := range additionalEditors {
+		if err := r(ctx, req); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ClientWithResponses builds on ClientInterface to offer response payloads
+type ClientWithResponses struct {
+	ClientInterface
+}
+
+// NewClientWithResponses creates a new ClientWithResponses, which wraps
+// Client with return type handling
+func NewClientWithResponses(server string, opts ...ClientOption) (*ClientWithResponses, error) {
+	client, err := NewClient(server, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &ClientWithResponses{client}, nil
+}
+
+// WithBaseURL overrides the baseURL.
+func WithBaseURL(baseURL string) ClientOption {
+	return func(c *Client) error {
+		newBaseURL, err := url.Parse(baseURL)
+		if err != nil {
+			return err
+		}
+		c.Server = newBaseURL.String()
+		return nil
+	}
+}
+
+// ClientWithResponsesInterface is the interface specification for the client with responses above.
+type ClientWithResponsesInterface interface {
+	// GetAccountsWithResponse request
+	GetAccountsWithResponse(ctx context.Context, params *GetAccountsParams, reqEditors ...RequestEditorFn) (*GetAccountsHTTPResponse, error)
+
+	// SetApprovalThresholdWithBodyWithResponse request with any body
+	SetApprovalThresholdWithBodyWithResponse(ctx context.Context, accountId openapi_types.UUID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*SetApprovalThresholdHTTPResponse, error)
+
+	SetApprovalThresholdWithResponse(ctx context.Context, accountId openapi_types.UUID, body SetApprovalThresholdJSONRequestBody, reqEditors ...RequestEditorFn) (*SetApprovalThresholdHTTPResponse, error)
+
+	// GetAccountBalanceWithResponse request
+	GetAccountBalanceWithResponse(ctx context.Context, accountId openapi_types.UUID, params *GetAccountBalanceParams, reqEditors ...RequestEditorFn) (*GetAccountBalanceHTTPResponse, error)
+
+	// GetAccountMembersWithResponse request
+	GetAccountMembersWithResponse(ctx context.Context, accountId openapi_types.UUID, params *GetAccountMembersParams, reqEditors ...RequestEditorFn) (*GetAccountMembersHTTPResponse, error)
+
+	// RemoveAccountMemberWithResponse request
+	RemoveAccountMemberWithResponse(ctx context.Context, accountId openapi_types.UUID, userId openapi_types.UUID, params *RemoveAccountMemberParams, reqEditors ...RequestEditorFn) (*RemoveAccountMemberHTTPResponse, error)
+
+	// SaveAccountMemberWithBodyWithResponse request with any body
+	SaveAccountMemberWithBodyWithResponse(ctx context.Context, accountId openapi_types.UUID, userId openapi_types.UUID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*SaveAccountMemberHTTPResponse, error)
+
+	SaveAccountMemberWithResponse(ctx context.Context, accountId openapi_types.UUID, userId openapi_types.UUID, body SaveAccountMemberJSONRequestBody, reqEditors ...RequestEditorFn) (*SaveAccountMemberHTTPResponse, error)
+
+	// GetAccountPaymentHistoryWithResponse request
+	GetAccountPaymentHistoryWithResponse(ctx context.Context, accountId openapi_types.UUID, params *GetAccountPaymentHistoryParams, reqEditors ...RequestEditorFn) (*GetAccountPaymentHistoryHTTPResponse, error)
+
+	// GetSharedTransfersWithResponse request
+	GetSharedTransfersWithResponse(ctx context.Context, accountId openapi_types.UUID, params *GetSharedTransfersParams, reqEditors ...RequestEditorFn) (*GetSharedTransfersHTTPResponse, error)
+
+	// CreateSharedTransferWithBodyWithResponse request with any body
+	CreateSharedTransferWithBodyWithResponse(ctx context.Context, accountId openapi_types.UUID, params *CreateSharedTransferParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*CreateSharedTransferHTTPResponse, error)
+
+	CreateSharedTransferWithResponse(ctx context.Context, accountId openapi_types.UUID, params *CreateSharedTransferParams, body CreateSharedTransferJSONRequestBody, reqEditors ...RequestEditorFn) (*CreateSharedTransferHTTPResponse, error)
+
+	// ApproveSharedTransferWithBodyWithResponse request with any body
+	ApproveSharedTransferWithBodyWithResponse(ctx context.Context, accountId openapi_types.UUID, transferId openapi_types.UUID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*ApproveSharedTransferHTTPResponse, error)
+
+	ApproveSharedTransferWithResponse(ctx context.Context, accountId openapi_types.UUID, transferId openapi_types.UUID, body ApproveSharedTransferJSONRequestBody, reqEditors ...RequestEditorFn) (*ApproveSharedTransferHTTPResponse, error)
+
+	// DeclineSharedTransferWithBodyWithResponse request with any body
+	DeclineSharedTransferWithBodyWithResponse(ctx context.Context, accountId openapi_types.UUID, transferId openapi_types.UUID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*DeclineSharedTransferHTTPResponse, error)
+
+	DeclineSharedTransferWithResponse(ctx context.Context, accountId openapi_types.UUID, transferId openapi_types.UUID, body DeclineSharedTransferJSONRequestBody, reqEditors ...RequestEditorFn) (*DeclineSharedTransferHTTPResponse, error)
+
+	// SearchAuditLogsWithResponse request
+	SearchAuditLogsWithResponse(ctx context.Context, params *SearchAuditLogsParams, reqEditors ...RequestEditorFn) (*SearchAuditLogsHTTPResponse, error)
+
+	// ExportAuditLogsWithResponse request
+	ExportAuditLogsWithResponse(ctx context.Context, params *ExportAuditLogsParams, reqEditors ...RequestEditorFn) (*ExportAuditLogsHTTPResponse, error)
+
+	// GetBusinessesWithResponse request
+	GetBusinessesWithResponse(ctx context.Context, params *GetBusinessesParams, reqEditors ...RequestEditorFn) (*GetBusinessesHTTPResponse, error)
+
+	// OpenBusinessWithBodyWithResponse request with any body
+	OpenBusinessWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*OpenBusinessHTTPResponse, error)
+
+	OpenBusinessWithResponse(ctx context.Context, body OpenBusinessJSONRequestBody, reqEditors ...RequestEditorFn) (*OpenBusinessHTTPResponse, error)
+
+	// GetBusinessWithResponse request
+	GetBusinessWithResponse(ctx context.Context, businessId openapi_types.UUID, params *GetBusinessParams, reqEditors ...RequestEditorFn) (*GetBusinessHTTPResponse, error)
+
+	// GetBusinessBalanceWithResponse request
+	GetBusinessBalanceWithResponse(ctx context.Context, businessId openapi_types.UUID, params *GetBusinessBalanceParams, reqEditors ...RequestEditorFn) (*GetBusinessBalanceHTTPResponse, error)
+
+	// GetBusinessMembersWithResponse request
+	GetBusinessMembersWithResponse(ctx context.Context, businessId openapi_types.UUID, params *GetBusinessMembersParams, reqEditors ...RequestEditorFn) (*GetBusinessMembersHTTPResponse, error)
+
+	// RemoveBusinessMemberWithResponse request
+	RemoveBusinessMemberWithResponse(ctx context.Context, businessId openapi_types.UUID, userId openapi_types.UUID, params *RemoveBusinessMemberParams, reqEditors ...RequestEditorFn) (*RemoveBusinessMemberHTTPResponse, error)
+
+	// SaveBusinessMemberWithBodyWithResponse request with any body
+	SaveBusinessMemberWithBodyWithResponse(ctx context.Context, businessId openapi_types.UUID, userId openapi_types.UUID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*SaveBusinessMemberHTTPResponse, error)
+
+	SaveBusinessMemberWithResponse(ctx context.Context, businessId openapi_types.UUID, userId openapi_types.UUID, body SaveBusinessMemberJSONRequestBody, reqEditors ...RequestEditorFn) (*SaveBusinessMemberHTTPResponse, error)
+
+	// AcceptBusinessInvitationWithResponse request
+	AcceptBusinessInvitationWithResponse(ctx context.Context, businessId openapi_types.UUID, userId openapi_types.UUID, reqEditors ...RequestEditorFn) (*AcceptBusinessInvitationHTTPResponse, error)
+
+	// GetBusinessPaymentHistoryWithResponse request
+	GetBusinessPaymentHistoryWithResponse(ctx context.Context, businessId openapi_types.UUID, params *GetBusinessPaymentHistoryParams, reqEditors ...RequestEditorFn) (*GetBusinessPaymentHistoryHTTPResponse, error)
+
+	// GetPaymentPoliciesWithResponse request
+	GetPaymentPoliciesWithResponse(ctx context.Context, businessId openapi_types.UUID, params *GetPaymentPoliciesParams, reqEditors ...RequestEditorFn) (*GetPaymentPoliciesHTTPResponse, error)
+
+	// SetPaymentPoliciesWithBodyWithResponse request with any body
+	SetPaymentPoliciesWithBodyWithResponse(ctx context.Context, businessId openapi_types.UUID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*SetPaymentPoliciesHTTPResponse, error)
+
+	SetPaymentPoliciesWithResponse(ctx context.Context, businessId openapi_types.UUID, body SetPaymentPoliciesJSONRequestBody, reqEditors ...RequestEditorFn) (*SetPaymentPoliciesHTTPResponse, error)
+
+	// GetBusinessTransfersWithResponse request
+	GetBusinessTransfersWithResponse(ctx context.Context, businessId openapi_types.UUID, params *GetBusinessTransfersParams, reqEditors ...RequestEditorFn) (*GetBusinessTransfersHTTPResponse, error)
+
+	// CreateBusinessTransferWithBodyWithResponse request with any body
+	CreateBusinessTransferWithBodyWithResponse(ctx context.Context, businessId openapi_types.UUID, params *CreateBusinessTransferParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*CreateBusinessTransferHTTPResponse, error)
+
+	CreateBusinessTransferWithResponse(ctx context.Context, businessId openapi_types.UUID, params *CreateBusinessTransferParams, body CreateBusinessTransferJSONRequestBody, reqEditors ...RequestEditorFn) (*CreateBusinessTransferHTTPResponse, error)
+
+	// GetBusinessTransferWithResponse request
+	GetBusinessTransferWithResponse(ctx context.Context, businessId openapi_types.UUID, transferId openapi_types.UUID, params *GetBusinessTransferParams, reqEditors ...RequestEditorFn) (*GetBusinessTransferHTTPResponse, error)
+
+	// ApproveBusinessTransferWithBodyWithResponse request with any body
+	ApproveBusinessTransferWithBodyWithResponse(ctx context.Context, businessId openapi_types.UUID, transferId openapi_types.UUID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*ApproveBusinessTransferHTTPResponse, error)
+
+	ApproveBusinessTransferWithResponse(ctx context.Context, businessId openapi_types.UUID, transferId openapi_types.UUID, body ApproveBusinessTransferJSONRequestBody, reqEditors ...RequestEditorFn) (*ApproveBusinessTransferHTTPResponse, error)
+
+	// RejectBusinessTransferWithBodyWithResponse request with any body
+	RejectBusinessTransferWithBodyWithResponse(ctx context.Context, businessId openapi_types.UUID, transferId openapi_types.UUID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*RejectBusinessTransferHTTPResponse, error)
+
+	RejectBusinessTransferWithResponse(ctx context.Context, businessId openapi_types.UUID, transferId openapi_types.UUID, body RejectBusinessTransferJSONRequestBody, reqEditors ...RequestEditorFn) (*RejectBusinessTransferHTTPResponse, error)
+
+	// GetEscrowsWithResponse request
+	GetEscrowsWithResponse(ctx context.Context, params *GetEscrowsParams, reqEditors ...RequestEditorFn) (*GetEscrowsHTTPResponse, error)
+
+	// CreateEscrowWithBodyWithResponse request with any body
+	CreateEscrowWithBodyWithResponse(ctx context.Context, params *CreateEscrowParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*CreateEscrowHTTPResponse, error)
+
+	CreateEscrowWithResponse(ctx context.Context, params *CreateEscrowParams, body CreateEscrowJSONRequestBody, reqEditors ...RequestEditorFn) (*CreateEscrowHTTPResponse, error)
+
+	// GetEscrowWithResponse request
+	GetEscrowWithResponse(ctx context.Context, escrowId openapi_types.UUID, reqEditors ...RequestEditorFn) (*GetEscrowHTTPResponse, error)
+
+	// RefundEscrowWithResponse request
+	RefundEscrowWithResponse(ctx context.Context, escrowId openapi_types.UUID, reqEditors ...RequestEditorFn) (*RefundEscrowHTTPResponse, error)
+
+	// ReleaseEscrowWithResponse request
+	ReleaseEscrowWithResponse(ctx context.Context, escrowId openapi_types.UUID, reqEditors ...RequestEditorFn) (*ReleaseEscrowHTTPResponse, error)
+
+	// SplitEscrowWithBodyWithResponse request with any body
+	SplitEscrowWithBodyWithResponse(ctx context.Context, escrowId openapi_types.UUID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*SplitEscrowHTTPResponse, error)
+
+	SplitEscrowWithResponse(ctx context.Context, escrowId openapi_types.UUID, body SplitEscrowJSONRequestBody, reqEditors ...RequestEditorFn) (*SplitEscrowHTTPResponse, error)
+
+	// GetHoldsWithResponse request
+	GetHoldsWithResponse(ctx context.Context, params *GetHoldsParams, reqEditors ...RequestEditorFn) (*GetHoldsHTTPResponse, error)
+
+	// PlaceHoldWithBodyWithResponse request with any body
+	PlaceHoldWithBodyWithResponse(ctx context.Context, params *PlaceHoldParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PlaceHoldHTTPResponse, error)
+
+	PlaceHoldWithResponse(ctx context.Context, params *PlaceHoldParams, body PlaceHoldJSONRequestBody, reqEditors ...RequestEditorFn) (*PlaceHoldHTTPResponse, error)
+
+	// GetHoldWithResponse request
+	GetHoldWithResponse(ctx context.Context, holdId openapi_types.UUID, reqEditors ...RequestEditorFn) (*GetHoldHTTPResponse, error)
+
+	// CaptureHoldWithBodyWithResponse request with any body
+	CaptureHoldWithBodyWithResponse(ctx context.Context, holdId openapi_types.UUID, params *CaptureHoldParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*CaptureHoldHTTPResponse, error)
+
+	CaptureHoldWithResponse(ctx context.Context, holdId openapi_types.UUID, params *CaptureHoldParams, body CaptureHoldJSONRequestBody, reqEditors ...RequestEditorFn) (*CaptureHoldHTTPResponse, error)
+
+	// ReleaseHoldWithResponse request
+	ReleaseHoldWithResponse(ctx context.Context, holdId openapi_types.UUID, reqEditors ...RequestEditorFn) (*ReleaseHoldHTTPResponse, error)
+
+	// GetLedgerCheckpointsWithResponse request
+	GetLedgerCheckpointsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetLedgerCheckpointsHTTPResponse, error)
+
+	// GetNotificationsWithResponse request
+	GetNotificationsWithResponse(ctx context.Context, params *GetNotificationsParams, reqEditors ...RequestEditorFn) (*GetNotificationsHTTPResponse, error)
+
+	// GetPaymentRequestsWithResponse request
+	GetPaymentRequestsWithResponse(ctx context.Context, params *GetPaymentRequestsParams, reqEditors ...RequestEditorFn) (*GetPaymentRequestsHTTPResponse, error)
+
+	// CreatePaymentRequestWithBodyWithResponse request with any body
+	CreatePaymentRequestWithBodyWithResponse(ctx context.Context, params *CreatePaymentRequestParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*CreatePaymentRequestHTTPResponse, error)
+
+	CreatePaymentRequestWithResponse(ctx context.Context, params *CreatePaymentRequestParams, body CreatePaymentRequestJSONRequestBody, reqEditors ...RequestEditorFn) (*CreatePaymentRequestHTTPResponse, error)
+
+	// GetPaymentRequestWithResponse request
+	GetPaymentRequestWithResponse(ctx context.Context, paymentRequestId openapi_types.UUID, reqEditors ...RequestEditorFn) (*GetPaymentRequestHTTPResponse, error)
+
+	// ApprovePaymentRequestWithBodyWithResponse request with any body
+	ApprovePaymentRequestWithBodyWithResponse(ctx context.Context, paymentRequestId openapi_types.UUID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*ApprovePaymentRequestHTTPResponse, error)
+
+	ApprovePaymentRequestWithResponse(ctx context.Context, paymentRequestId openapi_types.UUID, body ApprovePaymentRequestJSONRequestBody, reqEditors ...RequestEditorFn) (*ApprovePaymentRequestHTTPResponse, error)
+
+	// DeclinePaymentRequestWithBodyWithResponse request with any body
+	DeclinePaymentRequestWithBodyWithResponse(ctx context.Context, paymentRequestId openapi_types.UUID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*DeclinePaymentRequestHTTPResponse, error)
+
+	DeclinePaymentRequestWithResponse(ctx context.Context, paymentRequestId openapi_types.UUID, body DeclinePaymentRequestJSONRequestBody, reqEditors ...RequestEditorFn) (*DeclinePaymentRequestHTTPResponse, error)
+
+	// GetPayoutByRequestIdWithResponse request
+	GetPayoutByRequestIdWithResponse(ctx context.Context, params *GetPayoutByRequestIdParams, reqEditors ...RequestEditorFn) (*GetPayoutByRequestIdHTTPResponse, error)
+
+	// SubmitPayoutWithBodyWithResponse request with any body
+	SubmitPayoutWithBodyWithResponse(ctx context.Context, params *SubmitPayoutParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*SubmitPayoutHTTPResponse, error)
+
+	SubmitPayoutWithResponse(ctx context.Context, params *SubmitPayoutParams, body SubmitPayoutJSONRequestBody, reqEditors ...RequestEditorFn) (*SubmitPayoutHTTPResponse, error)
+
+	// GetPayoutWithResponse request
+	GetPayoutWithResponse(ctx context.Context, batchId openapi_types.UUID, reqEditors ...RequestEditorFn) (*GetPayoutHTTPResponse, error)
+
+	// GetPayoutLinesWithResponse request
+	GetPayoutLinesWithResponse(ctx context.Context, batchId openapi_types.UUID, params *GetPayoutLinesParams, reqEditors ...RequestEditorFn) (*GetPayoutLinesHTTPResponse, error)
+
+	// GetPocketsWithResponse request
+	GetPocketsWithResponse(ctx context.Context, params *GetPocketsParams, reqEditors ...RequestEditorFn) (*GetPocketsHTTPResponse, error)
+
+	// CreatePocketWithBodyWithResponse request with any body
+	CreatePocketWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*CreatePocketHTTPResponse, error)
+
+	CreatePocketWithResponse(ctx context.Context, body CreatePocketJSONRequestBody, reqEditors ...RequestEditorFn) (*CreatePocketHTTPResponse, error)
+
+	// GetPocketWithResponse request
+	GetPocketWithResponse(ctx context.Context, pocketId openapi_types.UUID, reqEditors ...RequestEditorFn) (*GetPocketHTTPResponse, error)
+
+	// DepositToPocketWithBodyWithResponse request with any body
+	DepositToPocketWithBodyWithResponse(ctx context.Context, pocketId openapi_types.UUID, params *DepositToPocketParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*DepositToPocketHTTPResponse, error)
+
+	DepositToPocketWithResponse(ctx context.Context, pocketId openapi_types.UUID, params *DepositToPocketParams, body DepositToPocketJSONRequestBody, reqEditors ...RequestEditorFn) (*DepositToPocketHTTPResponse, error)
+
+	// WithdrawFromPocketWithBodyWithResponse request with any body
+	WithdrawFromPocketWithBodyWithResponse(ctx context.Context, pocketId openapi_types.UUID, params *WithdrawFromPocketParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*WithdrawFromPocketHTTPResponse, error)
+
+	WithdrawFromPocketWithResponse(ctx context.Context, pocketId openapi_types.UUID, params *WithdrawFromPocketParams, body WithdrawFromPocketJSONRequestBody, reqEditors ...RequestEditorFn) (*WithdrawFromPocketHTTPResponse, error)
+
+	// GetScheduledTransfersWithResponse request
+	GetScheduledTransfersWithResponse(ctx context.Context, params *GetScheduledTransfersParams, reqEditors ...RequestEditorFn) (*GetScheduledTransfersHTTPResponse, error)
+
+	// CreateScheduledTransferWithBodyWithResponse request with any body
+	CreateScheduledTransferWithBodyWithResponse(ctx context.Context, params *CreateScheduledTransferParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*CreateScheduledTransferHTTPResponse, error)
+
+	CreateScheduledTransferWithResponse(ctx context.Context, params *CreateScheduledTransferParams, body CreateScheduledTransferJSONRequestBody, reqEditors ...RequestEditorFn) (*CreateScheduledTransferHTTPResponse, error)
+
+	// CancelScheduledTransferWithResponse request
+	CancelScheduledTransferWithResponse(ctx context.Context, scheduleId openapi_types.UUID, reqEditors ...RequestEditorFn) (*CancelScheduledTransferHTTPResponse, error)
+
+	// GetScheduledTransferWithResponse request
+	GetScheduledTransferWithResponse(ctx context.Context, scheduleId openapi_types.UUID, reqEditors ...RequestEditorFn) (*GetScheduledTransferHTTPResponse, error)
+
+	// GetScheduledTransferRunsWithResponse request
+	GetScheduledTransferRunsWithResponse(ctx context.Context, scheduleId openapi_types.UUID, reqEditors ...RequestEditorFn) (*GetScheduledTransferRunsHTTPResponse, error)
+
+	// GetBalanceWithResponse request
+	GetBalanceWithResponse(ctx context.Context, params *GetBalanceParams, reqEditors ...RequestEditorFn) (*GetBalanceHTTPResponse, error)
+
+	// DepositWithBodyWithResponse request with any body
+	DepositWithBodyWithResponse(ctx context.Context, params *DepositParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*DepositHTTPResponse, error)
+
+	DepositWithResponse(ctx context.Context, params *DepositParams, body DepositJSONRequestBody, reqEditors ...RequestEditorFn) (*DepositHTTPResponse, error)
+
+	// GetFeeQuoteWithResponse request
+	GetFeeQuoteWithResponse(ctx context.Context, params *GetFeeQuoteParams, reqEditors ...RequestEditorFn) (*GetFeeQuoteHTTPResponse, error)
+
+	// GetInterestWithResponse request
+	GetInterestWithResponse(ctx context.Context, params *GetInterestParams, reqEditors ...RequestEditorFn) (*GetInterestHTTPResponse, error)
+
+	// GetPaymentHistoryWithResponse request
+	GetPaymentHistoryWithResponse(ctx context.Context, params *GetPaymentHistoryParams, reqEditors ...RequestEditorFn) (*GetPaymentHistoryHTTPResponse, error)
+
+	// GetTraceWithResponse request
+	GetTraceWithResponse(ctx context.Context, params *GetTraceParams, reqEditors ...RequestEditorFn) (*GetTraceHTTPResponse, error)
+
+	// TransferWithBodyWithResponse request with any body
+	TransferWithBodyWithResponse(ctx context.Context, params *TransferParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*TransferHTTPResponse, error)
+
+	TransferWithResponse(ctx context.Context, params *TransferParams, body TransferJSONRequestBody, reqEditors ...RequestEditorFn) (*TransferHTTPResponse, error)
+
+	// WithdrawWithBodyWithResponse request with any body
+	WithdrawWithBodyWithResponse(ctx context.Context, params *WithdrawParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*WithdrawHTTPResponse, error)
+
+	WithdrawWithResponse(ctx context.Context, params *WithdrawParams, body WithdrawJSONRequestBody, reqEditors ...RequestEditorFn) (*WithdrawHTTPResponse, error)
+
+	// GetLivenessWithResponse request
+	GetLivenessWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetLivenessHTTPResponse, error)
+
+	// GetMetricsWithResponse request
+	GetMetricsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetMetricsHTTPResponse, error)
+
+	// GetOpenAPIWithResponse request
+	GetOpenAPIWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetOpenAPIHTTPResponse, error)
+
+	// GetReadinessWithResponse request
+	GetReadinessWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetReadinessHTTPResponse, error)
+}
+
+type GetAccountsHTTPResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *SearchAccountMemberResponse
+	JSONDefault  *Problem
+}
+
+// Status returns HTTPResponse.Status
+func (r GetAccountsHTTPResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetAccountsHTTPResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type SetApprovalThresholdHTTPResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *ApprovalThresholdResponse
+	JSONDefault  *Problem
+}
+
+// Status returns HTTPResponse.Status
+func (r SetApprovalThresholdHTTPResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r SetApprovalThresholdHTTPResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetAccountBalanceHTTPResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *GetCustomerBalanceResponse
+	JSONDefault  *Problem
+}
+
+// Status returns HTTPResponse.Status
+func (r GetAccountBalanceHTTPResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetAccountBalanceHTTPResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetAccountMembersHTTPResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *SearchAccountMemberResponse
+	JSONDefault  *Problem
+}
+
+// Status returns HTTPResponse.Status
+func (r GetAccountMembersHTTPResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetAccountMembersHTTPResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type RemoveAccountMemberHTTPResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSONDefault  *Problem
+}
+
+// Status returns HTTPResponse.Status
+func (r RemoveAccountMemberHTTPResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r RemoveAccountMemberHTTPResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type SaveAccountMemberHTTPResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *AccountMember
+	JSONDefault  *Problem
+}
+
+// Status returns HTTPResponse.Status
+func (r SaveAccountMemberHTTPResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r SaveAccountMemberHTTPResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetAccountPaymentHistoryHTTPResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *SearchPaymentHistoryResponse
+	JSONDefault  *Problem
+}
+
+// Status returns HTTPResponse.Status
+func (r GetAccountPaymentHistoryHTTPResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetAccountPaymentHistoryHTTPResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetSharedTransfersHTTPResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *SearchSharedTransferResponse
+	JSONDefault  *Problem
+}
+
+// Status returns HTTPResponse.Status
+func (r GetSharedTransfersHTTPResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetSharedTransfersHTTPResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type CreateSharedTransferHTTPResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON201      *SharedTransfer
+	JSON202      *SharedTransfer
+	JSONDefault  *Problem
+}
+
+// Status returns HTTPResponse.Status
+func (r CreateSharedTransferHTTPResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r CreateSharedTransferHTTPResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type ApproveSharedTransferHTTPResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *SharedTransfer
+	JSONDefault  *Problem
+}
+
+// Status returns HTTPResponse.Status
+func (r ApproveSharedTransferHTTPResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ApproveSharedTransferHTTPResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type DeclineSharedTransferHTTPResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *SharedTransfer
+	JSONDefault  *Problem
+}
+
+// Status returns HTTPResponse.Status
+func (r DeclineSharedTransferHTTPResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r DeclineSharedTransferHTTPResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type SearchAuditLogsHTTPResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *SearchAuditLogResponse
+	JSONDefault  *Problem
+}
+
+// Status returns HTTPResponse.Status
+func (r SearchAuditLogsHTTPResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r SearchAuditLogsHTTPResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type ExportAuditLogsHTTPResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSONDefault  *Problem
+}
+
+// Status returns HTTPResponse.Status
+func (r ExportAuditLogsHTTPResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ExportAuditLogsHTTPResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetBusinessesHTTPResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *SearchBusinessMemberResponse
+	JSONDefault  *Problem
+}
+
+// Status returns HTTPResponse.Status
+func (r GetBusinessesHTTPResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetBusinessesHTTPResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type OpenBusinessHTTPResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON201      *Business
+	JSONDefault  *Problem
+}
+
+// Status returns HTTPResponse.Status
+func (r OpenBusinessHTTPResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r OpenBusinessHTTPResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetBusinessHTTPResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *Business
+	JSONDefault  *Problem
+}
+
+// Status returns HTTPResponse.Status
+func (r GetBusinessHTTPResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetBusinessHTTPResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetBusinessBalanceHTTPResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *GetCustomerBalanceResponse
+	JSONDefault  *Problem
+}
+
+// Status returns HTTPResponse.Status
+func (r GetBusinessBalanceHTTPResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetBusinessBalanceHTTPResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetBusinessMembersHTTPResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *SearchBusinessMemberResponse
+	JSONDefault  *Problem
+}
+
+// Status returns HTTPResponse.Status
+func (r GetBusinessMembersHTTPResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetBusinessMembersHTTPResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type RemoveBusinessMemberHTTPResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSONDefault  *Problem
+}
+
+// Status returns HTTPResponse.Status
+func (r RemoveBusinessMemberHTTPResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r RemoveBusinessMemberHTTPResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type SaveBusinessMemberHTTPResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *BusinessMember
+	JSONDefault  *Problem
+}
+
+// Status returns HTTPResponse.Status
+func (r SaveBusinessMemberHTTPResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r SaveBusinessMemberHTTPResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type AcceptBusinessInvitationHTTPResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *BusinessMember
+	JSONDefault  *Problem
+}
+
+// Status returns HTTPResponse.Status
+func (r AcceptBusinessInvitationHTTPResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r AcceptBusinessInvitationHTTPResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetBusinessPaymentHistoryHTTPResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *SearchPaymentHistoryResponse
+	JSONDefault  *Problem
+}
+
+// Status returns HTTPResponse.Status
+func (r GetBusinessPaymentHistoryHTTPResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetBusinessPaymentHistoryHTTPResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetPaymentPoliciesHTTPResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *SearchPaymentPolicyResponse
+	JSONDefault  *Problem
+}
+
+// Status returns HTTPResponse.Status
+func (r GetPaymentPoliciesHTTPResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetPaymentPoliciesHTTPResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type SetPaymentPoliciesHTTPResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *SearchPaymentPolicyResponse
+	JSONDefault  *Problem
+}
+
+// Status returns HTTPResponse.Status
+func (r SetPaymentPoliciesHTTPResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r SetPaymentPoliciesHTTPResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetBusinessTransfersHTTPResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *SearchBusinessTransferResponse
+	JSONDefault  *Problem
+}
+
+// Status returns HTTPResponse.Status
+func (r GetBusinessTransfersHTTPResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetBusinessTransfersHTTPResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type CreateBusinessTransferHTTPResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON201      *BusinessTransfer
+	JSON202      *BusinessTransfer
+	JSONDefault  *Problem
+}
+
+// Status returns HTTPResponse.Status
+func (r CreateBusinessTransferHTTPResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r CreateBusinessTransferHTTPResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetBusinessTransferHTTPResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *BusinessTransfer
+	JSONDefault  *Problem
+}
+
+// Status returns HTTPResponse.Status
+func (r GetBusinessTransferHTTPResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetBusinessTransferHTTPResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type ApproveBusinessTransferHTTPResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *BusinessTransfer
+	JSONDefault  *Problem
+}
+
+// Status returns HTTPResponse.Status
+func (r ApproveBusinessTransferHTTPResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ApproveBusinessTransferHTTPResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type RejectBusinessTransferHTTPResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *BusinessTransfer
+	JSONDefault  *Problem
+}
+
+// Status returns HTTPResponse.Status
+func (r RejectBusinessTransferHTTPResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r RejectBusinessTransferHTTPResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetEscrowsHTTPResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *SearchEscrowResponse
+	JSONDefault  *Problem
+}
+
+// Status returns HTTPResponse.Status
+func (r GetEscrowsHTTPResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetEscrowsHTTPResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type CreateEscrowHTTPResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON201      *Escrow
+	JSONDefault  *Problem
+}
+
+// Status returns HTTPResponse.Status
+func (r CreateEscrowHTTPResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r CreateEscrowHTTPResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetEscrowHTTPResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *Escrow
+	JSONDefault  *Problem
+}
+
+// Status returns HTTPResponse.Status
+func (r GetEscrowHTTPResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetEscrowHTTPResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type RefundEscrowHTTPResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *Escrow
+	JSONDefault  *Problem
+}
+
+// Status returns HTTPResponse.Status
+func (r RefundEscrowHTTPResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r RefundEscrowHTTPResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type ReleaseEscrowHTTPResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *Escrow
+	JSONDefault  *Problem
+}
+
+// Status returns HTTPResponse.Status
+func (r ReleaseEscrowHTTPResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ReleaseEscrowHTTPResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type SplitEscrowHTTPResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *Escrow
+	JSONDefault  *Problem
+}
+
+// Status returns HTTPResponse.Status
+func (r SplitEscrowHTTPResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r SplitEscrowHTTPResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetHoldsHTTPResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *SearchHoldResponse
+	JSONDefault  *Problem
+}
+
+// Status returns HTTPResponse.Status
+func (r GetHoldsHTTPResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetHoldsHTTPResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PlaceHoldHTTPResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON201      *Hold
+	JSONDefault  *Problem
+}
+
+// Status returns HTTPResponse.Status
+func (r PlaceHoldHTTPResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PlaceHoldHTTPResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetHoldHTTPResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *Hold
+	JSONDefault  *Problem
+}
+
+// Status returns HTTPResponse.Status
+func (r GetHoldHTTPResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetHoldHTTPResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type CaptureHoldHTTPResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *Hold
+	JSONDefault  *Problem
+}
+
+// Status returns HTTPResponse.Status
+func (r CaptureHoldHTTPResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r CaptureHoldHTTPResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type ReleaseHoldHTTPResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *Hold
+	JSONDefault  *Problem
+}
+
+// Status returns HTTPResponse.Status
+func (r ReleaseHoldHTTPResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ReleaseHoldHTTPResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetLedgerCheckpointsHTTPResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *CheckpointExport
+	JSONDefault  *Problem
+}
+
+// Status returns HTTPResponse.Status
+func (r GetLedgerCheckpointsHTTPResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetLedgerCheckpointsHTTPResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetNotificationsHTTPResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *SearchNotificationResponse
+	JSONDefault  *Problem
+}
+
+// Status returns HTTPResponse.Status
+func (r GetNotificationsHTTPResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetNotificationsHTTPResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetPaymentRequestsHTTPResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *SearchPaymentRequestResponse
+	JSONDefault  *Problem
+}
+
+// Status returns HTTPResponse.Status
+func (r GetPaymentRequestsHTTPResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetPaymentRequestsHTTPResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type CreatePaymentRequestHTTPResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON201      *PaymentRequest
+	JSONDefault  *Problem
+}
+
+// Status returns HTTPResponse.Status
+func (r CreatePaymentRequestHTTPResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r CreatePaymentRequestHTTPResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetPaymentRequestHTTPResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *PaymentRequest
+	JSONDefault  *Problem
+}
+
+// Status returns HTTPResponse.Status
+func (r GetPaymentRequestHTTPResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetPaymentRequestHTTPResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type ApprovePaymentRequestHTTPResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *PaymentRequest
+	JSONDefault  *Problem
+}
+
+// Status returns HTTPResponse.Status
+func (r ApprovePaymentRequestHTTPResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ApprovePaymentRequestHTTPResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type DeclinePaymentRequestHTTPResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *PaymentRequest
+	JSONDefault  *Problem
+}
+
+// Status returns HTTPResponse.Status
+func (r DeclinePaymentRequestHTTPResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r DeclinePaymentRequestHTTPResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetPayoutByRequestIdHTTPResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *PayoutBatch
+	JSONDefault  *Problem
+}
+
+// Status returns HTTPResponse.Status
+func (r GetPayoutByRequestIdHTTPResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetPayoutByRequestIdHTTPResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type SubmitPayoutHTTPResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON202      *PayoutBatch
+	JSONDefault  *Problem
+}
+
+// Status returns HTTPResponse.Status
+func (r SubmitPayoutHTTPResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r SubmitPayoutHTTPResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetPayoutHTTPResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *PayoutBatch
+	JSONDefault  *Problem
+}
+
+// Status returns HTTPResponse.Status
+func (r GetPayoutHTTPResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetPayoutHTTPResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetPayoutLinesHTTPResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *SearchPayoutLineResponse
+	JSONDefault  *Problem
+}
+
+// Status returns HTTPResponse.Status
+func (r GetPayoutLinesHTTPResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetPayoutLinesHTTPResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetPocketsHTTPResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *SearchPocketResponse
+	JSONDefault  *Problem
+}
+
+// Status returns HTTPResponse.Status
+func (r GetPocketsHTTPResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetPocketsHTTPResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type CreatePocketHTTPResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON201      *Pocket
+	JSONDefault  *Problem
+}
+
+// Status returns HTTPResponse.Status
+func (r CreatePocketHTTPResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r CreatePocketHTTPResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetPocketHTTPResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *Pocket
+	JSONDefault  *Problem
+}
+
+// Status returns HTTPResponse.Status
+func (r GetPocketHTTPResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetPocketHTTPResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type DepositToPocketHTTPResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *PocketMovementResponse
+	JSONDefault  *Problem
+}
+
+// Status returns HTTPResponse.Status
+func (r DepositToPocketHTTPResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r DepositToPocketHTTPResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type WithdrawFromPocketHTTPResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *PocketMovementResponse
+	JSONDefault  *Problem
+}
+
+// Status returns HTTPResponse.Status
+func (r WithdrawFromPocketHTTPResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r WithdrawFromPocketHTTPResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetScheduledTransfersHTTPResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *SearchScheduledTransferResponse
+	JSONDefault  *Problem
+}
+
+// Status returns HTTPResponse.Status
+func (r GetScheduledTransfersHTTPResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetScheduledTransfersHTTPResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type CreateScheduledTransferHTTPResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON201      *ScheduledTransfer
+	JSONDefault  *Problem
+}
+
+// Status returns HTTPResponse.Status
+func (r CreateScheduledTransferHTTPResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r CreateScheduledTransferHTTPResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type CancelScheduledTransferHTTPResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *ScheduledTransfer
+	JSONDefault  *Problem
+}
+
+// Status returns HTTPResponse.Status
+func (r CancelScheduledTransferHTTPResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r CancelScheduledTransferHTTPResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetScheduledTransferHTTPResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *ScheduledTransfer
+	JSONDefault  *Problem
+}
+
+// Status returns HTTPResponse.Status
+func (r GetScheduledTransferHTTPResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetScheduledTransferHTTPResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetScheduledTransferRunsHTTPResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *SearchScheduledTransferRunResponse
+	JSONDefault  *Problem
+}
+
+// Status returns HTTPResponse.Status
+func (r GetScheduledTransferRunsHTTPResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetScheduledTransferRunsHTTPResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetBalanceHTTPResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *GetCustomerBalanceResponse
+	JSONDefault  *Problem
+}
+
+// Status returns HTTPResponse.Status
+func (r GetBalanceHTTPResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetBalanceHTTPResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type DepositHTTPResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *DepositResponse
+	JSONDefault  *Problem
+}
+
+// Status returns HTTPResponse.Status
+func (r DepositHTTPResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r DepositHTTPResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetFeeQuoteHTTPResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *FeeQuoteResponse
+	JSONDefault  *Problem
+}
+
+// Status returns HTTPResponse.Status
+func (r GetFeeQuoteHTTPResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetFeeQuoteHTTPResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetInterestHTTPResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *GetInterestResponse
+	JSONDefault  *Problem
+}
+
+// Status returns HTTPResponse.Status
+func (r GetInterestHTTPResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetInterestHTTPResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetPaymentHistoryHTTPResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *SearchPaymentHistoryResponse
+	JSONDefault  *Problem
+}
+
+// Status returns HTTPResponse.Status
+func (r GetPaymentHistoryHTTPResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetPaymentHistoryHTTPResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetTraceHTTPResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *GetTraceResponse
+	JSONDefault  *Problem
+}
+
+// Status returns HTTPResponse.Status
+func (r GetTraceHTTPResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetTraceHTTPResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type TransferHTTPResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *TransferResponse
+	JSONDefault  *Problem
+}
+
+// Status returns HTTPResponse.Status
+func (r TransferHTTPResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r TransferHTTPResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type WithdrawHTTPResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *WithdrawalResponse
+	JSONDefault  *Problem
+}
+
+// Status returns HTTPResponse.Status
+func (r WithdrawHTTPResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r WithdrawHTTPResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetLivenessHTTPResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *LivenessResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r GetLivenessHTTPResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetLivenessHTTPResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetMetricsHTTPResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+}
+
+// Status returns HTTPResponse.Status
+func (r GetMetricsHTTPResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetMetricsHTTPResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetOpenAPIHTTPResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *map[string]interface{}
+}
+
+// Status returns HTTPResponse.Status
+func (r GetOpenAPIHTTPResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetOpenAPIHTTPResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetReadinessHTTPResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *ReadinessResponse
+	JSON503      *ReadinessResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r GetReadinessHTTPResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetReadinessHTTPResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+// GetAccountsWithResponse request returning *GetAccountsHTTPResponse
+func (c *ClientWithResponses) GetAccountsWithResponse(ctx context.Context, params *GetAccountsParams, reqEditors ...RequestEditorFn) (*GetAccountsHTTPResponse, error) {
+	rsp, err := c.GetAccounts(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetAccountsHTTPResponse(rsp)
+}
+
+// SetApprovalThresholdWithBodyWithResponse request with arbitrary body returning *SetApprovalThresholdHTTPResponse
+func (c *ClientWithResponses) SetApprovalThresholdWithBodyWithResponse(ctx context.Context, accountId openapi_types.UUID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*SetApprovalThresholdHTTPResponse, error) {
+	rsp, err := c.SetApprovalThresholdWithBody(ctx, accountId, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseSetApprovalThresholdHTTPResponse(rsp)
+}
+
+func (c *ClientWithResponses) SetApprovalThresholdWithResponse(ctx context.Context, accountId openapi_types.UUID, body SetApprovalThresholdJSONRequestBody, reqEditors ...RequestEditorFn) (*SetApprovalThresholdHTTPResponse, error) {
+	rsp, err := c.SetApprovalThreshold(ctx, accountId, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseSetApprovalThresholdHTTPResponse(rsp)
+}
+
+// GetAccountBalanceWithResponse request returning *GetAccountBalanceHTTPResponse
+func (c *ClientWithResponses) GetAccountBalanceWithResponse(ctx context.Context, accountId openapi_types.UUID, params *GetAccountBalanceParams, reqEditors ...RequestEditorFn) (*GetAccountBalanceHTTPResponse, error) {
+	rsp, err := c.GetAccountBalance(ctx, accountId, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetAccountBalanceHTTPResponse(rsp)
+}
+
+// GetAccountMembersWithResponse request returning *GetAccountMembersHTTPResponse
+func (c *ClientWithResponses) GetAccountMembersWithResponse(ctx context.Context, accountId openapi_types.UUID, params *GetAccountMembersParams, reqEditors ...RequestEditorFn) (*GetAccountMembersHTTPResponse, error) {
+	rsp, err := c.GetAccountMembers(ctx, accountId, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetAccountMembersHTTPResponse(rsp)
+}
+
+// RemoveAccountMemberWithResponse request returning *RemoveAccountMemberHTTPResponse
+func (c *ClientWithResponses) RemoveAccountMemberWithResponse(ctx context.Context, accountId openapi_types.UUID, userId openapi_types.UUID, params *RemoveAccountMemberParams, reqEditors ...RequestEditorFn) (*RemoveAccountMemberHTTPResponse, error) {
+	rsp, err := c.RemoveAccountMember(ctx, accountId, userId, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseRemoveAccountMemberHTTPResponse(rsp)
+}
+
+// SaveAccountMemberWithBodyWithResponse request with arbitrary body returning *SaveAccountMemberHTTPResponse
+func (c *ClientWithResponses) SaveAccountMemberWithBodyWithResponse(ctx context.Context, accountId openapi_types.UUID, userId openapi_types.UUID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*SaveAccountMemberHTTPResponse, error) {
+	rsp, err := c.SaveAccountMemberWithBody(ctx, accountId, userId, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseSaveAccountMemberHTTPResponse(rsp)
+}
+
+func (c *ClientWithResponses) SaveAccountMemberWithResponse(ctx context.Context, accountId openapi_types.UUID, userId openapi_types.UUID, body SaveAccountMemberJSONRequestBody, reqEditors ...RequestEditorFn) (*SaveAccountMemberHTTPResponse, error) {
+	rsp, err := c.SaveAccountMember(ctx, accountId, userId, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseSaveAccountMemberHTTPResponse(rsp)
+}
+
+// GetAccountPaymentHistoryWithResponse request returning *GetAccountPaymentHistoryHTTPResponse
+func (c *ClientWithResponses) GetAccountPaymentHistoryWithResponse(ctx context.Context, accountId openapi_types.UUID, params *GetAccountPaymentHistoryParams, reqEditors ...RequestEditorFn) (*GetAccountPaymentHistoryHTTPResponse, error) {
+	rsp, err := c.GetAccountPaymentHistory(ctx, accountId, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetAccountPaymentHistoryHTTPResponse(rsp)
+}
+
+// GetSharedTransfersWithResponse request returning *GetSharedTransfersHTTPResponse
+func (c *ClientWithResponses) GetSharedTransfersWithResponse(ctx context.Context, accountId openapi_types.UUID, params *GetSharedTransfersParams, reqEditors ...RequestEditorFn) (*GetSharedTransfersHTTPResponse, error) {
+	rsp, err := c.GetSharedTransfers(ctx, accountId, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetSharedTransfersHTTPResponse(rsp)
+}
+
+// CreateSharedTransferWithBodyWithResponse request with arbitrary body returning *CreateSharedTransferHTTPResponse
+func (c *ClientWithResponses) CreateSharedTransferWithBodyWithResponse(ctx context.Context, accountId openapi_types.UUID, params *CreateSharedTransferParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*CreateSharedTransferHTTPResponse, error) {
+	rsp, err := c.CreateSharedTransferWithBody(ctx, accountId, params, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseCreateSharedTransferHTTPResponse(rsp)
+}
+
+func (c *ClientWithResponses) CreateSharedTransferWithResponse(ctx context.Context, accountId openapi_types.UUID, params *CreateSharedTransferParams, body CreateSharedTransferJSONRequestBody, reqEditors ...RequestEditorFn) (*CreateSharedTransferHTTPResponse, error) {
+	rsp, err := c.CreateSharedTransfer(ctx, accountId, params, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseCreateSharedTransferHTTPResponse(rsp)
+}
+
+// ApproveSharedTransferWithBodyWithResponse request with arbitrary body returning *ApproveSharedTransferHTTPResponse
+func (c *ClientWithResponses) ApproveSharedTransferWithBodyWithResponse(ctx context.Context, accountId openapi_types.UUID, transferId openapi_types.UUID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*ApproveSharedTransferHTTPResponse, error) {
+	rsp, err := c.ApproveSharedTransferWithBody(ctx, accountId, transferId, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseApproveSharedTransferHTTPResponse(rsp)
+}
+
+func (c *ClientWithResponses) ApproveSharedTransferWithResponse(ctx context.Context, accountId openapi_types.UUID, transferId openapi_types.UUID, body ApproveSharedTransferJSONRequestBody, reqEditors ...RequestEditorFn) (*ApproveSharedTransferHTTPResponse, error) {
+	rsp, err := c.ApproveSharedTransfer(ctx, accountId, transferId, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseApproveSharedTransferHTTPResponse(rsp)
+}
+
+// DeclineSharedTransferWithBodyWithResponse request with arbitrary body returning *DeclineSharedTransferHTTPResponse
+func (c *ClientWithResponses) DeclineSharedTransferWithBodyWithResponse(ctx context.Context, accountId openapi_types.UUID, transferId openapi_types.UUID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*DeclineSharedTransferHTTPResponse, error) {
+	rsp, err := c.DeclineSharedTransferWithBody(ctx, accountId, transferId, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseDeclineSharedTransferHTTPResponse(rsp)
+}
+
+func (c *ClientWithResponses) DeclineSharedTransferWithResponse(ctx context.Context, accountId openapi_types.UUID, transferId openapi_types.UUID, body DeclineSharedTransferJSONRequestBody, reqEditors ...RequestEditorFn) (*DeclineSharedTransferHTTPResponse, error) {
+	rsp, err := c.DeclineSharedTransfer(ctx, accountId, transferId, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseDeclineSharedTransferHTTPResponse(rsp)
+}
+
+// SearchAuditLogsWithResponse request returning *SearchAuditLogsHTTPResponse
+func (c *ClientWithResponses) SearchAuditLogsWithResponse(ctx context.Context, params *SearchAuditLogsParams, reqEditors ...RequestEditorFn) (*SearchAuditLogsHTTPResponse, error) {
+	rsp, err := c.SearchAuditLogs(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseSearchAuditLogsHTTPResponse(rsp)
+}
+
+// ExportAuditLogsWithResponse request returning *ExportAuditLogsHTTPResponse
+func (c *ClientWithResponses) ExportAuditLogsWithResponse(ctx context.Context, params *ExportAuditLogsParams, reqEditors ...RequestEditorFn) (*ExportAuditLogsHTTPResponse, error) {
+	rsp, err := c.ExportAuditLogs(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseExportAuditLogsHTTPResponse(rsp)
+}
+
+// GetBusinessesWithResponse request returning *GetBusinessesHTTPResponse
+func (c *ClientWithResponses) GetBusinessesWithResponse(ctx context.Context, params *GetBusinessesParams, reqEditors ...RequestEditorFn) (*GetBusinessesHTTPResponse, error) {
+	rsp, err := c.GetBusinesses(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetBusinessesHTTPResponse(rsp)
+}
+
+// OpenBusinessWithBodyWithResponse request with arbitrary body returning *OpenBusinessHTTPResponse
+func (c *ClientWithResponses) OpenBusinessWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*OpenBusinessHTTPResponse, error) {
+	rsp, err := c.OpenBusinessWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseOpenBusinessHTTPResponse(rsp)
+}
+
+func (c *ClientWithResponses) OpenBusinessWithResponse(ctx context.Context, body OpenBusinessJSONRequestBody, reqEditors ...RequestEditorFn) (*OpenBusinessHTTPResponse, error) {
+	rsp, err := c.OpenBusiness(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseOpenBusinessHTTPResponse(rsp)
+}
+
+// GetBusinessWithResponse request returning *GetBusinessHTTPResponse
+func (c *ClientWithResponses) GetBusinessWithResponse(ctx context.Context, businessId openapi_types.UUID, params *GetBusinessParams, reqEditors ...RequestEditorFn) (*GetBusinessHTTPResponse, error) {
+	rsp, err := c.GetBusiness(ctx, businessId, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetBusinessHTTPResponse(rsp)
+}
+
+// GetBusinessBalanceWithResponse request returning *GetBusinessBalanceHTTPResponse
+func (c *ClientWithResponses) GetBusinessBalanceWithResponse(ctx context.Context, businessId openapi_types.UUID, params *GetBusinessBalanceParams, reqEditors ...RequestEditorFn) (*GetBusinessBalanceHTTPResponse, error) {
+	rsp, err := c.GetBusinessBalance(ctx, businessId, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetBusinessBalanceHTTPResponse(rsp)
+}
+
+// GetBusinessMembersWithResponse request returning *GetBusinessMembersHTTPResponse
+func (c *ClientWithResponses) GetBusinessMembersWithResponse(ctx context.Context, businessId openapi_types.UUID, params *GetBusinessMembersParams, reqEditors ...RequestEditorFn) (*GetBusinessMembersHTTPResponse, error) {
+	rsp, err := c.GetBusinessMembers(ctx, businessId, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetBusinessMembersHTTPResponse(rsp)
+}
+
+// RemoveBusinessMemberWithResponse request returning *RemoveBusinessMemberHTTPResponse
+func (c *ClientWithResponses) RemoveBusinessMemberWithResponse(ctx context.Context, businessId openapi_types.UUID, userId openapi_types.UUID, params *RemoveBusinessMemberParams, reqEditors ...RequestEditorFn) (*RemoveBusinessMemberHTTPResponse, error) {
+	rsp, err := c.RemoveBusinessMember(ctx, businessId, userId, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseRemoveBusinessMemberHTTPResponse(rsp)
+}
+
+// SaveBusinessMemberWithBodyWithResponse request with arbitrary body returning *SaveBusinessMemberHTTPResponse
+func (c *ClientWithResponses) SaveBusinessMemberWithBodyWithResponse(ctx context.Context, businessId openapi_types.UUID, userId openapi_types.UUID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*SaveBusinessMemberHTTPResponse, error) {
+	rsp, err := c.SaveBusinessMemberWithBody(ctx, businessId, userId, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseSaveBusinessMemberHTTPResponse(rsp)
+}
+
+func (c *ClientWithResponses) SaveBusinessMemberWithResponse(ctx context.Context, businessId openapi_types.UUID, userId openapi_types.UUID, body SaveBusinessMemberJSONRequestBody, reqEditors ...RequestEditorFn) (*SaveBusinessMemberHTTPResponse, error) {
+	rsp, err := c.SaveBusinessMember(ctx, businessId, userId, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseSaveBusinessMemberHTTPResponse(rsp)
+}
+
+// AcceptBusinessInvitationWithResponse request returning *AcceptBusinessInvitationHTTPResponse
+func (c *ClientWithResponses) AcceptBusinessInvitationWithResponse(ctx context.Context, businessId openapi_types.UUID, userId openapi_types.UUID, reqEditors ...RequestEditorFn) (*AcceptBusinessInvitationHTTPResponse, error) {
+	rsp, err := c.AcceptBusinessInvitation(ctx, businessId, userId, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseAcceptBusinessInvitationHTTPResponse(rsp)
+}
+
+// GetBusinessPaymentHistoryWithResponse request returning *GetBusinessPaymentHistoryHTTPResponse
+func (c *ClientWithResponses) GetBusinessPaymentHistoryWithResponse(ctx context.Context, businessId openapi_types.UUID, params *GetBusinessPaymentHistoryParams, reqEditors ...RequestEditorFn) (*GetBusinessPaymentHistoryHTTPResponse, error) {
+	rsp, err := c.GetBusinessPaymentHistory(ctx, businessId, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetBusinessPaymentHistoryHTTPResponse(rsp)
+}
+
+// GetPaymentPoliciesWithResponse request returning *GetPaymentPoliciesHTTPResponse
+func (c *ClientWithResponses) GetPaymentPoliciesWithResponse(ctx context.Context, businessId openapi_types.UUID, params *GetPaymentPoliciesParams, reqEditors ...RequestEditorFn) (*GetPaymentPoliciesHTTPResponse, error) {
+	rsp, err := c.GetPaymentPolicies(ctx, businessId, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetPaymentPoliciesHTTPResponse(rsp)
+}
+
+// SetPaymentPoliciesWithBodyWithResponse request with arbitrary body returning *SetPaymentPoliciesHTTPResponse
+func (c *ClientWithResponses) SetPaymentPoliciesWithBodyWithResponse(ctx context.Context, businessId openapi_types.UUID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*SetPaymentPoliciesHTTPResponse, error) {
+	rsp, err := c.SetPaymentPoliciesWithBody(ctx, businessId, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseSetPaymentPoliciesHTTPResponse(rsp)
+}
+
+func (c *ClientWithResponses) SetPaymentPoliciesWithResponse(ctx context.Context, businessId openapi_types.UUID, body SetPaymentPoliciesJSONRequestBody, reqEditors ...RequestEditorFn) (*SetPaymentPoliciesHTTPResponse, error) {
+	rsp, err := c.SetPaymentPolicies(ctx, businessId, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseSetPaymentPoliciesHTTPResponse(rsp)
+}
+
+// GetBusinessTransfersWithResponse request returning *GetBusinessTransfersHTTPResponse
+func (c *ClientWithResponses) GetBusinessTransfersWithResponse(ctx context.Context, businessId openapi_types.UUID, params *GetBusinessTransfersParams, reqEditors ...RequestEditorFn) (*GetBusinessTransfersHTTPResponse, error) {
+	rsp, err := c.GetBusinessTransfers(ctx, businessId, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetBusinessTransfersHTTPResponse(rsp)
+}
+
+// CreateBusinessTransferWithBodyWithResponse request with arbitrary body returning *CreateBusinessTransferHTTPResponse
+func (c *ClientWithResponses) CreateBusinessTransferWithBodyWithResponse(ctx context.Context, businessId openapi_types.UUID, params *CreateBusinessTransferParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*CreateBusinessTransferHTTPResponse, error) {
+	rsp, err := c.CreateBusinessTransferWithBody(ctx, businessId, params, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseCreateBusinessTransferHTTPResponse(rsp)
+}
+
+func (c *ClientWithResponses) CreateBusinessTransferWithResponse(ctx context.Context, businessId openapi_types.UUID, params *CreateBusinessTransferParams, body CreateBusinessTransferJSONRequestBody, reqEditors ...RequestEditorFn) (*CreateBusinessTransferHTTPResponse, error) {
+	rsp, err := c.CreateBusinessTransfer(ctx, businessId, params, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseCreateBusinessTransferHTTPResponse(rsp)
+}
+
+// GetBusinessTransferWithResponse request returning *GetBusinessTransferHTTPResponse
+func (c *ClientWithResponses) GetBusinessTransferWithResponse(ctx context.Context, businessId openapi_types.UUID, transferId openapi_types.UUID, params *GetBusinessTransferParams, reqEditors ...RequestEditorFn) (*GetBusinessTransferHTTPResponse, error) {
+	rsp, err := c.GetBusinessTransfer(ctx, businessId, transferId, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetBusinessTransferHTTPResponse(rsp)
+}
+
+// ApproveBusinessTransferWithBodyWithResponse request with arbitrary body returning *ApproveBusinessTransferHTTPResponse
+func (c *ClientWithResponses) ApproveBusinessTransferWithBodyWithResponse(ctx context.Context, businessId openapi_types.UUID, transferId openapi_types.UUID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*ApproveBusinessTransferHTTPResponse, error) {
+	rsp, err := c.ApproveBusinessTransferWithBody(ctx, businessId, transferId, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseApproveBusinessTransferHTTPResponse(rsp)
+}
+
+func (c *ClientWithResponses) ApproveBusinessTransferWithResponse(ctx context.Context, businessId openapi_types.UUID, transferId openapi_types.UUID, body ApproveBusinessTransferJSONRequestBody, reqEditors ...RequestEditorFn) (*ApproveBusinessTransferHTTPResponse, error) {
+	rsp, err := c.ApproveBusinessTransfer(ctx, businessId, transferId, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseApproveBusinessTransferHTTPResponse(rsp)
+}
+
+// RejectBusinessTransferWithBodyWithResponse request with arbitrary body returning *RejectBusinessTransferHTTPResponse
+func (c *ClientWithResponses) RejectBusinessTransferWithBodyWithResponse(ctx context.Context, businessId openapi_types.UUID, transferId openapi_types.UUID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*RejectBusinessTransferHTTPResponse, error) {
+	rsp, err := c.RejectBusinessTransferWithBody(ctx, businessId, transferId, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseRejectBusinessTransferHTTPResponse(rsp)
+}
+
+func (c *ClientWithResponses) RejectBusinessTransferWithResponse(ctx context.Context, businessId openapi_types.UUID, transferId openapi_types.UUID, body RejectBusinessTransferJSONRequestBody, reqEditors ...RequestEditorFn) (*RejectBusinessTransferHTTPResponse, error) {
+	rsp, err := c.RejectBusinessTransfer(ctx, businessId, transferId, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseRejectBusinessTransferHTTPResponse(rsp)
+}
+
+// GetEscrowsWithResponse request returning *GetEscrowsHTTPResponse
+func (c *ClientWithResponses) GetEscrowsWithResponse(ctx context.Context, params *GetEscrowsParams, reqEditors ...RequestEditorFn) (*GetEscrowsHTTPResponse, error) {
+	rsp, err := c.GetEscrows(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetEscrowsHTTPResponse(rsp)
+}
+
+// CreateEscrowWithBodyWithResponse request with arbitrary body returning *CreateEscrowHTTPResponse
+func (c *ClientWithResponses) CreateEscrowWithBodyWithResponse(ctx context.Context, params *CreateEscrowParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*CreateEscrowHTTPResponse, error) {
+	rsp, err := c.CreateEscrowWithBody(ctx, params, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseCreateEscrowHTTPResponse(rsp)
+}
+
+func (c *ClientWithResponses) CreateEscrowWithResponse(ctx context.Context, params *CreateEscrowParams, body CreateEscrowJSONRequestBody, reqEditors ...RequestEditorFn) (*CreateEscrowHTTPResponse, error) {
+	rsp, err := c.CreateEscrow(ctx, params, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseCreateEscrowHTTPResponse(rsp)
+}
+
+// GetEscrowWithResponse request returning *GetEscrowHTTPResponse
+func (c *ClientWithResponses) GetEscrowWithResponse(ctx context.Context, escrowId openapi_types.UUID, reqEditors ...RequestEditorFn) (*GetEscrowHTTPResponse, error) {
+	rsp, err := c.GetEscrow(ctx, escrowId, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetEscrowHTTPResponse(rsp)
+}
+
+// RefundEscrowWithResponse request returning *RefundEscrowHTTPResponse
+func (c *ClientWithResponses) RefundEscrowWithResponse(ctx context.Context, escrowId openapi_types.UUID, reqEditors ...RequestEditorFn) (*RefundEscrowHTTPResponse, error) {
+	rsp, err := c.RefundEscrow(ctx, escrowId, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseRefundEscrowHTTPResponse(rsp)
+}
+
+// ReleaseEscrowWithResponse request returning *ReleaseEscrowHTTPResponse
+func (c *ClientWithResponses) ReleaseEscrowWithResponse(ctx context.Context, escrowId openapi_types.UUID, reqEditors ...RequestEditorFn) (*ReleaseEscrowHTTPResponse, error) {
+	rsp, err := c.ReleaseEscrow(ctx, escrowId, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseReleaseEscrowHTTPResponse(rsp)
+}
+
+// SplitEscrowWithBodyWithResponse request with arbitrary body returning *SplitEscrowHTTPResponse
+func (c *ClientWithResponses) SplitEscrowWithBodyWithResponse(ctx context.Context, escrowId openapi_types.UUID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*SplitEscrowHTTPResponse, error) {
+	rsp, err := c.SplitEscrowWithBody(ctx, escrowId, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseSplitEscrowHTTPResponse(rsp)
+}
+
+func (c *ClientWithResponses) SplitEscrowWithResponse(ctx context.Context, escrowId openapi_types.UUID, body SplitEscrowJSONRequestBody, reqEditors ...RequestEditorFn) (*SplitEscrowHTTPResponse, error) {
+	rsp, err := c.SplitEscrow(ctx, escrowId, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseSplitEscrowHTTPResponse(rsp)
+}
+
+// GetHoldsWithResponse request returning *GetHoldsHTTPResponse
+func (c *ClientWithResponses) GetHoldsWithResponse(ctx context.Context, params *GetHoldsParams, reqEditors ...RequestEditorFn) (*GetHoldsHTTPResponse, error) {
+	rsp, err := c.GetHolds(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetHoldsHTTPResponse(rsp)
+}
+
+// PlaceHoldWithBodyWithResponse request with arbitrary body returning *PlaceHoldHTTPResponse
+func (c *ClientWithResponses) PlaceHoldWithBodyWithResponse(ctx context.Context, params *PlaceHoldParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PlaceHoldHTTPResponse, error) {
+	rsp, err := c.PlaceHoldWithBody(ctx, params, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePlaceHoldHTTPResponse(rsp)
+}
+
+func (c *ClientWithResponses) PlaceHoldWithResponse(ctx context.Context, params *PlaceHoldParams, body PlaceHoldJSONRequestBody, reqEditors ...RequestEditorFn) (*PlaceHoldHTTPResponse, error) {
+	rsp, err := c.PlaceHold(ctx, params, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePlaceHoldHTTPResponse(rsp)
+}
+
+// GetHoldWithResponse request returning *GetHoldHTTPResponse
+func (c *ClientWithResponses) GetHoldWithResponse(ctx context.Context, holdId openapi_types.UUID, reqEditors ...RequestEditorFn) (*GetHoldHTTPResponse, error) {
+	rsp, err := c.GetHold(ctx, holdId, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetHoldHTTPResponse(rsp)
+}
+
+// CaptureHoldWithBodyWithResponse request with arbitrary body returning *CaptureHoldHTTPResponse
+func (c *ClientWithResponses) CaptureHoldWithBodyWithResponse(ctx context.Context, holdId openapi_types.UUID, params *CaptureHoldParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*CaptureHoldHTTPResponse, error) {
+	rsp, err := c.CaptureHoldWithBody(ctx, holdId, params, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseCaptureHoldHTTPResponse(rsp)
+}
+
+func (c *ClientWithResponses) CaptureHoldWithResponse(ctx context.Context, holdId openapi_types.UUID, params *CaptureHoldParams, body CaptureHoldJSONRequestBody, reqEditors ...RequestEditorFn) (*CaptureHoldHTTPResponse, error) {
+	rsp, err := c.CaptureHold(ctx, holdId, params, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseCaptureHoldHTTPResponse(rsp)
+}
+
+// ReleaseHoldWithResponse request returning *ReleaseHoldHTTPResponse
+func (c *ClientWithResponses) ReleaseHoldWithResponse(ctx context.Context, holdId openapi_types.UUID, reqEditors ...RequestEditorFn) (*ReleaseHoldHTTPResponse, error) {
+	rsp, err := c.ReleaseHold(ctx, holdId, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseReleaseHoldHTTPResponse(rsp)
+}
+
+// GetLedgerCheckpointsWithResponse request returning *GetLedgerCheckpointsHTTPResponse
+func (c *ClientWithResponses) GetLedgerCheckpointsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetLedgerCheckpointsHTTPResponse, error) {
+	rsp, err := c.GetLedgerCheckpoints(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetLedgerCheckpointsHTTPResponse(rsp)
+}
+
+// GetNotificationsWithResponse request returning *GetNotificationsHTTPResponse
+func (c *ClientWithResponses) GetNotificationsWithResponse(ctx context.Context, params *GetNotificationsParams, reqEditors ...RequestEditorFn) (*GetNotificationsHTTPResponse, error) {
+	rsp, err := c.GetNotifications(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetNotificationsHTTPResponse(rsp)
+}
+
+// GetPaymentRequestsWithResponse request returning *GetPaymentRequestsHTTPResponse
+func (c *ClientWithResponses) GetPaymentRequestsWithResponse(ctx context.Context, params *GetPaymentRequestsParams, reqEditors ...RequestEditorFn) (*GetPaymentRequestsHTTPResponse, error) {
+	rsp, err := c.GetPaymentRequests(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetPaymentRequestsHTTPResponse(rsp)
+}
+
+// CreatePaymentRequestWithBodyWithResponse request with arbitrary body returning *CreatePaymentRequestHTTPResponse
+func (c *ClientWithResponses) CreatePaymentRequestWithBodyWithResponse(ctx context.Context, params *CreatePaymentRequestParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*CreatePaymentRequestHTTPResponse, error) {
+	rsp, err := c.CreatePaymentRequestWithBody(ctx, params, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseCreatePaymentRequestHTTPResponse(rsp)
+}
+
+func (c *ClientWithResponses) CreatePaymentRequestWithResponse(ctx context.Context, params *CreatePaymentRequestParams, body CreatePaymentRequestJSONRequestBody, reqEditors ...RequestEditorFn) (*CreatePaymentRequestHTTPResponse, error) {
+	rsp, err := c.CreatePaymentRequest(ctx, params, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseCreatePaymentRequestHTTPResponse(rsp)
+}
+
+// GetPaymentRequestWithResponse request returning *GetPaymentRequestHTTPResponse
+func (c *ClientWithResponses) GetPaymentRequestWithResponse(ctx context.Context, paymentRequestId openapi_types.UUID, reqEditors ...RequestEditorFn) (*GetPaymentRequestHTTPResponse, error) {
+	rsp, err := c.GetPaymentRequest(ctx, paymentRequestId, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetPaymentRequestHTTPResponse(rsp)
+}
+
+// ApprovePaymentRequestWithBodyWithResponse request with arbitrary body returning *ApprovePaymentRequestHTTPResponse
+func (c *ClientWithResponses) ApprovePaymentRequestWithBodyWithResponse(ctx context.Context, paymentRequestId openapi_types.UUID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*ApprovePaymentRequestHTTPResponse, error) {
+	rsp, err := c.ApprovePaymentRequestWithBody(ctx, paymentRequestId, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseApprovePaymentRequestHTTPResponse(rsp)
+}
+
+func (c *ClientWithResponses) ApprovePaymentRequestWithResponse(ctx context.Context, paymentRequestId openapi_types.UUID, body ApprovePaymentRequestJSONRequestBody, reqEditors ...RequestEditorFn) (*ApprovePaymentRequestHTTPResponse, error) {
+	rsp, err := c.ApprovePaymentRequest(ctx, paymentRequestId, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseApprovePaymentRequestHTTPResponse(rsp)
+}
+
+// DeclinePaymentRequestWithBodyWithResponse request with arbitrary body returning *DeclinePaymentRequestHTTPResponse
+func (c *ClientWithResponses) DeclinePaymentRequestWithBodyWithResponse(ctx context.Context, paymentRequestId openapi_types.UUID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*DeclinePaymentRequestHTTPResponse, error) {
+	rsp, err := c.DeclinePaymentRequestWithBody(ctx, paymentRequestId, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseDeclinePaymentRequestHTTPResponse(rsp)
+}
+
+func (c *ClientWithResponses) DeclinePaymentRequestWithResponse(ctx context.Context, paymentRequestId openapi_types.UUID, body DeclinePaymentRequestJSONRequestBody, reqEditors ...RequestEditorFn) (*DeclinePaymentRequestHTTPResponse, error) {
+	rsp, err := c.DeclinePaymentRequest(ctx, paymentRequestId, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseDeclinePaymentRequestHTTPResponse(rsp)
+}
+
+// GetPayoutByRequestIdWithResponse request returning *GetPayoutByRequestIdHTTPResponse
+func (c *ClientWithResponses) GetPayoutByRequestIdWithResponse(ctx context.Context, params *GetPayoutByRequestIdParams, reqEditors ...RequestEditorFn) (*GetPayoutByRequestIdHTTPResponse, error) {
+	rsp, err := c.GetPayoutByRequestId(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetPayoutByRequestIdHTTPResponse(rsp)
+}
+
+// SubmitPayoutWithBodyWithResponse request with arbitrary body returning *SubmitPayoutHTTPResponse
+func (c *ClientWithResponses) SubmitPayoutWithBodyWithResponse(ctx context.Context, params *SubmitPayoutParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*SubmitPayoutHTTPResponse, error) {
+	rsp, err := c.SubmitPayoutWithBody(ctx, params, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseSubmitPayoutHTTPResponse(rsp)
+}
+
+func (c *ClientWithResponses) SubmitPayoutWithResponse(ctx context.Context, params *SubmitPayoutParams, body SubmitPayoutJSONRequestBody, reqEditors ...RequestEditorFn) (*SubmitPayoutHTTPResponse, error) {
+	rsp, err := c.SubmitPayout(ctx, params, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseSubmitPayoutHTTPResponse(rsp)
+}
+
+// GetPayoutWithResponse request returning *GetPayoutHTTPResponse
+func (c *ClientWithResponses) GetPayoutWithResponse(ctx context.Context, batchId openapi_types.UUID, reqEditors ...RequestEditorFn) (*GetPayoutHTTPResponse, error) {
+	rsp, err := c.GetPayout(ctx, batchId, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetPayoutHTTPResponse(rsp)
+}
+
+// GetPayoutLinesWithResponse request returning *GetPayoutLinesHTTPResponse
+func (c *ClientWithResponses) GetPayoutLinesWithResponse(ctx context.Context, batchId openapi_types.UUID, params *GetPayoutLinesParams, reqEditors ...RequestEditorFn) (*GetPayoutLinesHTTPResponse, error) {
+	rsp, err := c.GetPayoutLines(ctx, batchId, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetPayoutLinesHTTPResponse(rsp)
+}
+
+// GetPocketsWithResponse request returning *GetPocketsHTTPResponse
+func (c *ClientWithResponses) GetPocketsWithResponse(ctx context.Context, params *GetPocketsParams, reqEditors ...RequestEditorFn) (*GetPocketsHTTPResponse, error) {
+	rsp, err := c.GetPockets(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetPocketsHTTPResponse(rsp)
+}
+
+// CreatePocketWithBodyWithResponse request with arbitrary body returning *CreatePocketHTTPResponse
+func (c *ClientWithResponses) CreatePocketWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*CreatePocketHTTPResponse, error) {
+	rsp, err := c.CreatePocketWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseCreatePocketHTTPResponse(rsp)
+}
+
+func (c *ClientWithResponses) CreatePocketWithResponse(ctx context.Context, body CreatePocketJSONRequestBody, reqEditors ...RequestEditorFn) (*CreatePocketHTTPResponse, error) {
+	rsp, err := c.CreatePocket(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseCreatePocketHTTPResponse(rsp)
+}
+
+// GetPocketWithResponse request returning *GetPocketHTTPResponse
+func (c *ClientWithResponses) GetPocketWithResponse(ctx context.Context, pocketId openapi_types.UUID, reqEditors ...RequestEditorFn) (*GetPocketHTTPResponse, error) {
+	rsp, err := c.GetPocket(ctx, pocketId, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetPocketHTTPResponse(rsp)
+}
+
+// DepositToPocketWithBodyWithResponse request with arbitrary body returning *DepositToPocketHTTPResponse
+func (c *ClientWithResponses) DepositToPocketWithBodyWithResponse(ctx context.Context, pocketId openapi_types.UUID, params *DepositToPocketParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*DepositToPocketHTTPResponse, error) {
+	rsp, err := c.DepositToPocketWithBody(ctx, pocketId, params, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseDepositToPocketHTTPResponse(rsp)
+}
+
+func (c *ClientWithResponses) DepositToPocketWithResponse(ctx context.Context, pocketId openapi_types.UUID, params *DepositToPocketParams, body DepositToPocketJSONRequestBody, reqEditors ...RequestEditorFn) (*DepositToPocketHTTPResponse, error) {
+	rsp, err := c.DepositToPocket(ctx, pocketId, params, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseDepositToPocketHTTPResponse(rsp)
+}
+
+// WithdrawFromPocketWithBodyWithResponse request with arbitrary body returning *WithdrawFromPocketHTTPResponse
+func (c *ClientWithResponses) WithdrawFromPocketWithBodyWithResponse(ctx context.Context, pocketId openapi_types.UUID, params *WithdrawFromPocketParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*WithdrawFromPocketHTTPResponse, error) {
+	rsp, err := c.WithdrawFromPocketWithBody(ctx, pocketId, params, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseWithdrawFromPocketHTTPResponse(rsp)
+}
+
+func (c *ClientWithResponses) WithdrawFromPocketWithResponse(ctx context.Context, pocketId openapi_types.UUID, params *WithdrawFromPocketParams, body WithdrawFromPocketJSONRequestBody, reqEditors ...RequestEditorFn) (*WithdrawFromPocketHTTPResponse, error) {
+	rsp, err := c.WithdrawFromPocket(ctx, pocketId, params, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseWithdrawFromPocketHTTPResponse(rsp)
+}
+
+// GetScheduledTransfersWithResponse request returning *GetScheduledTransfersHTTPResponse
+func (c *ClientWithResponses) GetScheduledTransfersWithResponse(ctx context.Context, params *GetScheduledTransfersParams, reqEditors ...RequestEditorFn) (*GetScheduledTransfersHTTPResponse, error) {
+	rsp, err := c.GetScheduledTransfers(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetScheduledTransfersHTTPResponse(rsp)
+}
+
+// CreateScheduledTransferWithBodyWithResponse request with arbitrary body returning *CreateScheduledTransferHTTPResponse
+func (c *ClientWithResponses) CreateScheduledTransferWithBodyWithResponse(ctx context.Context, params *CreateScheduledTransferParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*CreateScheduledTransferHTTPResponse, error) {
+	rsp, err := c.CreateScheduledTransferWithBody(ctx, params, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseCreateScheduledTransferHTTPResponse(rsp)
+}
+
+func (c *ClientWithResponses) CreateScheduledTransferWithResponse(ctx context.Context, params *CreateScheduledTransferParams, body CreateScheduledTransferJSONRequestBody, reqEditors ...RequestEditorFn) (*CreateScheduledTransferHTTPResponse, error) {
+	rsp, err := c.CreateScheduledTransfer(ctx, params, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseCreateScheduledTransferHTTPResponse(rsp)
+}
+
+// CancelScheduledTransferWithResponse request returning *CancelScheduledTransferHTTPResponse
+func (c *ClientWithResponses) CancelScheduledTransferWithResponse(ctx context.Context, scheduleId openapi_types.UUID, reqEditors ...RequestEditorFn) (*CancelScheduledTransferHTTPResponse, error) {
+	rsp, err := c.CancelScheduledTransfer(ctx, scheduleId, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseCancelScheduledTransferHTTPResponse(rsp)
+}
+
+// GetScheduledTransferWithResponse request returning *GetScheduledTransferHTTPResponse
+func (c *ClientWithResponses) GetScheduledTransferWithResponse(ctx context.Context, scheduleId openapi_types.UUID, reqEditors ...RequestEditorFn) (*GetScheduledTransferHTTPResponse, error) {
+	rsp, err := c.GetScheduledTransfer(ctx, scheduleId, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetScheduledTransferHTTPResponse(rsp)
+}
+
+// GetScheduledTransferRunsWithResponse request returning *GetScheduledTransferRunsHTTPResponse
+func (c *ClientWithResponses) GetScheduledTransferRunsWithResponse(ctx context.Context, scheduleId openapi_types.UUID, reqEditors ...RequestEditorFn) (*GetScheduledTransferRunsHTTPResponse, error) {
+	rsp, err := c.GetScheduledTransferRuns(ctx, scheduleId, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetScheduledTransferRunsHTTPResponse(rsp)
+}
+
+// GetBalanceWithResponse request returning *GetBalanceHTTPResponse
+func (c *ClientWithResponses) GetBalanceWithResponse(ctx context.Context, params *GetBalanceParams, reqEditors ...RequestEditorFn) (*GetBalanceHTTPResponse, error) {
+	rsp, err := c.GetBalance(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetBalanceHTTPResponse(rsp)
+}
+
+// DepositWithBodyWithResponse request with arbitrary body returning *DepositHTTPResponse
+func (c *ClientWithResponses) DepositWithBodyWithResponse(ctx context.Context, params *DepositParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*DepositHTTPResponse, error) {
+	rsp, err := c.DepositWithBody(ctx, params, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseDepositHTTPResponse(rsp)
+}
+
+func (c *ClientWithResponses) DepositWithResponse(ctx context.Context, params *DepositParams, body DepositJSONRequestBody, reqEditors ...RequestEditorFn) (*DepositHTTPResponse, error) {
+	rsp, err := c.Deposit(ctx, params, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseDepositHTTPResponse(rsp)
+}
+
+// GetFeeQuoteWithResponse request returning *GetFeeQuoteHTTPResponse
+func (c *ClientWithResponses) GetFeeQuoteWithResponse(ctx context.Context, params *GetFeeQuoteParams, reqEditors ...RequestEditorFn) (*GetFeeQuoteHTTPResponse, error) {
+	rsp, err := c.GetFeeQuote(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetFeeQuoteHTTPResponse(rsp)
+}
+
+// GetInterestWithResponse request returning *GetInterestHTTPResponse
+func (c *ClientWithResponses) GetInterestWithResponse(ctx context.Context, params *GetInterestParams, reqEditors ...RequestEditorFn) (*GetInterestHTTPResponse, error) {
+	rsp, err := c.GetInterest(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetInterestHTTPResponse(rsp)
+}
+
+// GetPaymentHistoryWithResponse request returning *GetPaymentHistoryHTTPResponse
+func (c *ClientWithResponses) GetPaymentHistoryWithResponse(ctx context.Context, params *GetPaymentHistoryParams, reqEditors ...RequestEditorFn) (*GetPaymentHistoryHTTPResponse, error) {
+	rsp, err := c.GetPaymentHistory(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetPaymentHistoryHTTPResponse(rsp)
+}
+
+// GetTraceWithResponse request returning *GetTraceHTTPResponse
+func (c *ClientWithResponses) GetTraceWithResponse(ctx context.Context, params *GetTraceParams, reqEditors ...RequestEditorFn) (*GetTraceHTTPResponse, error) {
+	rsp, err := c.GetTrace(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetTraceHTTPResponse(rsp)
+}
+
+// TransferWithBodyWithResponse request with arbitrary body returning *TransferHTTPResponse
+func (c *ClientWithResponses) TransferWithBodyWithResponse(ctx context.Context, params *TransferParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*TransferHTTPResponse, error) {
+	rsp, err := c.TransferWithBody(ctx, params, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseTransferHTTPResponse(rsp)
+}
+
+func (c *ClientWithResponses) TransferWithResponse(ctx context.Context, params *TransferParams, body TransferJSONRequestBody, reqEditors ...RequestEditorFn) (*TransferHTTPResponse, error) {
+	rsp, err := c.Transfer(ctx, params, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseTransferHTTPResponse(rsp)
+}
+
+// WithdrawWithBodyWithResponse request with arbitrary body returning *WithdrawHTTPResponse
+func (c *ClientWithResponses) WithdrawWithBodyWithResponse(ctx context.Context, params *WithdrawParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*WithdrawHTTPResponse, error) {
+	rsp, err := c.WithdrawWithBody(ctx, params, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseWithdrawHTTPResponse(rsp)
+}
+
+func (c *ClientWithResponses) WithdrawWithResponse(ctx context.Context, params *WithdrawParams, body WithdrawJSONRequestBody, reqEditors ...RequestEditorFn) (*WithdrawHTTPResponse, error) {
+	rsp, err := c.Withdraw(ctx, params, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseWithdrawHTTPResponse(rsp)
+}
+
+// GetLivenessWithResponse request returning *GetLivenessHTTPResponse
+func (c *ClientWithResponses) GetLivenessWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetLivenessHTTPResponse, error) {
+	rsp, err := c.GetLiveness(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetLivenessHTTPResponse(rsp)
+}
+
+// GetMetricsWithResponse request returning *GetMetricsHTTPResponse
+func (c *ClientWithResponses) GetMetricsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetMetricsHTTPResponse, error) {
+	rsp, err := c.GetMetrics(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetMetricsHTTPResponse(rsp)
+}
+
+// GetOpenAPIWithResponse request returning *GetOpenAPIHTTPResponse
+func (c *ClientWithResponses) GetOpenAPIWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetOpenAPIHTTPResponse, error) {
+	rsp, err := c.GetOpenAPI(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetOpenAPIHTTPResponse(rsp)
+}
+
+// GetReadinessWithResponse request returning *GetReadinessHTTPResponse
+func (c *ClientWithResponses) GetReadinessWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetReadinessHTTPResponse, error) {
+	rsp, err := c.GetReadiness(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetReadinessHTTPResponse(rsp)
+}
+
+// ParseGetAccountsHTTPResponse parses an HTTP response from a GetAccountsWithResponse call
+func ParseGetAccountsHTTPResponse(rsp *http.Response) (*GetAccountsHTTPResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetAccountsHTTPResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest SearchAccountMemberResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Problem
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseSetApprovalThresholdHTTPResponse parses an HTTP response from a SetApprovalThresholdWithResponse call
+func ParseSetApprovalThresholdHTTPResponse(rsp *http.Response) (*SetApprovalThresholdHTTPResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &SetApprovalThresholdHTTPResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest ApprovalThresholdResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Problem
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetAccountBalanceHTTPResponse parses an HTTP response from a GetAccountBalanceWithResponse call
+func ParseGetAccountBalanceHTTPResponse(rsp *http.Response) (*GetAccountBalanceHTTPResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetAccountBalanceHTTPResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest GetCustomerBalanceResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Problem
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetAccountMembersHTTPResponse parses an HTTP response from a GetAccountMembersWithResponse call
+func ParseGetAccountMembersHTTPResponse(rsp *http.Response) (*GetAccountMembersHTTPResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetAccountMembersHTTPResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest SearchAccountMemberResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Problem
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseRemoveAccountMemberHTTPResponse parses an HTTP response from a RemoveAccountMemberWithResponse call
+func ParseRemoveAccountMemberHTTPResponse(rsp *http.Response) (*RemoveAccountMemberHTTPResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &RemoveAccountMemberHTTPResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Problem
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseSaveAccountMemberHTTPResponse parses an HTTP response from a SaveAccountMemberWithResponse call
+func ParseSaveAccountMemberHTTPResponse(rsp *http.Response) (*SaveAccountMemberHTTPResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &SaveAccountMemberHTTPResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest AccountMember
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Problem
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetAccountPaymentHistoryHTTPResponse parses an HTTP response from a GetAccountPaymentHistoryWithResponse call
+func ParseGetAccountPaymentHistoryHTTPResponse(rsp *http.Response) (*GetAccountPaymentHistoryHTTPResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetAccountPaymentHistoryHTTPResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest SearchPaymentHistoryResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Problem
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetSharedTransfersHTTPResponse parses an HTTP response from a GetSharedTransfersWithResponse call
+func ParseGetSharedTransfersHTTPResponse(rsp *http.Response) (*GetSharedTransfersHTTPResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetSharedTransfersHTTPResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest SearchSharedTransferResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Problem
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseCreateSharedTransferHTTPResponse parses an HTTP response from a CreateSharedTransferWithResponse call
+func ParseCreateSharedTransferHTTPResponse(rsp *http.Response) (*CreateSharedTransferHTTPResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &CreateSharedTransferHTTPResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 201:
+		var dest SharedTransfer
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON201 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 202:
+		var dest SharedTransfer
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON202 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Problem
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseApproveSharedTransferHTTPResponse parses an HTTP response from a ApproveSharedTransferWithResponse call
+func ParseApproveSharedTransferHTTPResponse(rsp *http.Response) (*ApproveSharedTransferHTTPResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ApproveSharedTransferHTTPResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest SharedTransfer
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Problem
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseDeclineSharedTransferHTTPResponse parses an HTTP response from a DeclineSharedTransferWithResponse call
+func ParseDeclineSharedTransferHTTPResponse(rsp *http.Response) (*DeclineSharedTransferHTTPResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &DeclineSharedTransferHTTPResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest SharedTransfer
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Problem
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseSearchAuditLogsHTTPResponse parses an HTTP response from a SearchAuditLogsWithResponse call
+func ParseSearchAuditLogsHTTPResponse(rsp *http.Response) (*SearchAuditLogsHTTPResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &SearchAuditLogsHTTPResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest SearchAuditLogResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Problem
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseExportAuditLogsHTTPResponse parses an HTTP response from a ExportAuditLogsWithResponse call
+func ParseExportAuditLogsHTTPResponse(rsp *http.Response) (*ExportAuditLogsHTTPResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ExportAuditLogsHTTPResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Problem
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetBusinessesHTTPResponse parses an HTTP response from a GetBusinessesWithResponse call
+func ParseGetBusinessesHTTPResponse(rsp *http.Response) (*GetBusinessesHTTPResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetBusinessesHTTPResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest SearchBusinessMemberResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Problem
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseOpenBusinessHTTPResponse parses an HTTP response from a OpenBusinessWithResponse call
+func ParseOpenBusinessHTTPResponse(rsp *http.Response) (*OpenBusinessHTTPResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &OpenBusinessHTTPResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 201:
+		var dest Business
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON201 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Problem
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetBusinessHTTPResponse parses an HTTP response from a GetBusinessWithResponse call
+func ParseGetBusinessHTTPResponse(rsp *http.Response) (*GetBusinessHTTPResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetBusinessHTTPResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest Business
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Problem
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetBusinessBalanceHTTPResponse parses an HTTP response from a GetBusinessBalanceWithResponse call
+func ParseGetBusinessBalanceHTTPResponse(rsp *http.Response) (*GetBusinessBalanceHTTPResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetBusinessBalanceHTTPResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest GetCustomerBalanceResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Problem
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetBusinessMembersHTTPResponse parses an HTTP response from a GetBusinessMembersWithResponse call
+func ParseGetBusinessMembersHTTPResponse(rsp *http.Response) (*GetBusinessMembersHTTPResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetBusinessMembersHTTPResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest SearchBusinessMemberResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Problem
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseRemoveBusinessMemberHTTPResponse parses an HTTP response from a RemoveBusinessMemberWithResponse call
+func ParseRemoveBusinessMemberHTTPResponse(rsp *http.Response) (*RemoveBusinessMemberHTTPResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &RemoveBusinessMemberHTTPResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Problem
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseSaveBusinessMemberHTTPResponse parses an HTTP response from a SaveBusinessMemberWithResponse call
+func ParseSaveBusinessMemberHTTPResponse(rsp *http.Response) (*SaveBusinessMemberHTTPResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &SaveBusinessMemberHTTPResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest BusinessMember
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Problem
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseAcceptBusinessInvitationHTTPResponse parses an HTTP response from a AcceptBusinessInvitationWithResponse call
+func ParseAcceptBusinessInvitationHTTPResponse(rsp *http.Response) (*AcceptBusinessInvitationHTTPResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &AcceptBusinessInvitationHTTPResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest BusinessMember
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Problem
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetBusinessPaymentHistoryHTTPResponse parses an HTTP response from a GetBusinessPaymentHistoryWithResponse call
+func ParseGetBusinessPaymentHistoryHTTPResponse(rsp *http.Response) (*GetBusinessPaymentHistoryHTTPResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetBusinessPaymentHistoryHTTPResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest SearchPaymentHistoryResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Problem
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetPaymentPoliciesHTTPResponse parses an HTTP response from a GetPaymentPoliciesWithResponse call
+func ParseGetPaymentPoliciesHTTPResponse(rsp *http.Response) (*GetPaymentPoliciesHTTPResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetPaymentPoliciesHTTPResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest SearchPaymentPolicyResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Problem
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseSetPaymentPoliciesHTTPResponse parses an HTTP response from a SetPaymentPoliciesWithResponse call
+func ParseSetPaymentPoliciesHTTPResponse(rsp *http.Response) (*SetPaymentPoliciesHTTPResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &SetPaymentPoliciesHTTPResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest SearchPaymentPolicyResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Problem
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetBusinessTransfersHTTPResponse parses an HTTP response from a GetBusinessTransfersWithResponse call
+func ParseGetBusinessTransfersHTTPResponse(rsp *http.Response) (*GetBusinessTransfersHTTPResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetBusinessTransfersHTTPResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest SearchBusinessTransferResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Problem
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseCreateBusinessTransferHTTPResponse parses an HTTP response from a CreateBusinessTransferWithResponse call
+func ParseCreateBusinessTransferHTTPResponse(rsp *http.Response) (*CreateBusinessTransferHTTPResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &CreateBusinessTransferHTTPResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 201:
+		var dest BusinessTransfer
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON201 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 202:
+		var dest BusinessTransfer
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON202 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Problem
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetBusinessTransferHTTPResponse parses an HTTP response from a GetBusinessTransferWithResponse call
+func ParseGetBusinessTransferHTTPResponse(rsp *http.Response) (*GetBusinessTransferHTTPResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetBusinessTransferHTTPResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest BusinessTransfer
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Problem
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseApproveBusinessTransferHTTPResponse parses an HTTP response from a ApproveBusinessTransferWithResponse call
+func ParseApproveBusinessTransferHTTPResponse(rsp *http.Response) (*ApproveBusinessTransferHTTPResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ApproveBusinessTransferHTTPResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest BusinessTransfer
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Problem
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseRejectBusinessTransferHTTPResponse parses an HTTP response from a RejectBusinessTransferWithResponse call
+func ParseRejectBusinessTransferHTTPResponse(rsp *http.Response) (*RejectBusinessTransferHTTPResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &RejectBusinessTransferHTTPResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest BusinessTransfer
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Problem
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetEscrowsHTTPResponse parses an HTTP response from a GetEscrowsWithResponse call
+func ParseGetEscrowsHTTPResponse(rsp *http.Response) (*GetEscrowsHTTPResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetEscrowsHTTPResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest SearchEscrowResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Problem
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseCreateEscrowHTTPResponse parses an HTTP response from a CreateEscrowWithResponse call
+func ParseCreateEscrowHTTPResponse(rsp *http.Response) (*CreateEscrowHTTPResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &CreateEscrowHTTPResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 201:
+		var dest Escrow
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON201 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Problem
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetEscrowHTTPResponse parses an HTTP response from a GetEscrowWithResponse call
+func ParseGetEscrowHTTPResponse(rsp *http.Response) (*GetEscrowHTTPResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetEscrowHTTPResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest Escrow
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Problem
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseRefundEscrowHTTPResponse parses an HTTP response from a RefundEscrowWithResponse call
+func ParseRefundEscrowHTTPResponse(rsp *http.Response) (*RefundEscrowHTTPResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &RefundEscrowHTTPResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest Escrow
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Problem
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseReleaseEscrowHTTPResponse parses an HTTP response from a ReleaseEscrowWithResponse call
+func ParseReleaseEscrowHTTPResponse(rsp *http.Response) (*ReleaseEscrowHTTPResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ReleaseEscrowHTTPResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest Escrow
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Problem
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseSplitEscrowHTTPResponse parses an HTTP response from a SplitEscrowWithResponse call
+func ParseSplitEscrowHTTPResponse(rsp *http.Response) (*SplitEscrowHTTPResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &SplitEscrowHTTPResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest Escrow
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Problem
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetHoldsHTTPResponse parses an HTTP response from a GetHoldsWithResponse call
+func ParseGetHoldsHTTPResponse(rsp *http.Response) (*GetHoldsHTTPResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetHoldsHTTPResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest SearchHoldResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Problem
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePlaceHoldHTTPResponse parses an HTTP response from a PlaceHoldWithResponse call
+func ParsePlaceHoldHTTPResponse(rsp *http.Response) (*PlaceHoldHTTPResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PlaceHoldHTTPResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 201:
+		var dest Hold
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON201 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Problem
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetHoldHTTPResponse parses an HTTP response from a GetHoldWithResponse call
+func ParseGetHoldHTTPResponse(rsp *http.Response) (*GetHoldHTTPResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetHoldHTTPResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest Hold
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Problem
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseCaptureHoldHTTPResponse parses an HTTP response from a CaptureHoldWithResponse call
+func ParseCaptureHoldHTTPResponse(rsp *http.Response) (*CaptureHoldHTTPResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &CaptureHoldHTTPResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest Hold
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Problem
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseReleaseHoldHTTPResponse parses an HTTP response from a ReleaseHoldWithResponse call
+func ParseReleaseHoldHTTPResponse(rsp *http.Response) (*ReleaseHoldHTTPResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ReleaseHoldHTTPResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest Hold
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Problem
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetLedgerCheckpointsHTTPResponse parses an HTTP response from a GetLedgerCheckpointsWithResponse call
+func ParseGetLedgerCheckpointsHTTPResponse(rsp *http.Response) (*GetLedgerCheckpointsHTTPResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetLedgerCheckpointsHTTPResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest CheckpointExport
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Problem
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetNotificationsHTTPResponse parses an HTTP response from a GetNotificationsWithResponse call
+func ParseGetNotificationsHTTPResponse(rsp *http.Response) (*GetNotificationsHTTPResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetNotificationsHTTPResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest SearchNotificationResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Problem
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetPaymentRequestsHTTPResponse parses an HTTP response from a GetPaymentRequestsWithResponse call
+func ParseGetPaymentRequestsHTTPResponse(rsp *http.Response) (*GetPaymentRequestsHTTPResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetPaymentRequestsHTTPResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest SearchPaymentRequestResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Problem
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseCreatePaymentRequestHTTPResponse parses an HTTP response from a CreatePaymentRequestWithResponse call
+func ParseCreatePaymentRequestHTTPResponse(rsp *http.Response) (*CreatePaymentRequestHTTPResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &CreatePaymentRequestHTTPResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 201:
+		var dest PaymentRequest
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON201 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Problem
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetPaymentRequestHTTPResponse parses an HTTP response from a GetPaymentRequestWithResponse call
+func ParseGetPaymentRequestHTTPResponse(rsp *http.Response) (*GetPaymentRequestHTTPResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetPaymentRequestHTTPResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest PaymentRequest
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Problem
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseApprovePaymentRequestHTTPResponse parses an HTTP response from a ApprovePaymentRequestWithResponse call
+func ParseApprovePaymentRequestHTTPResponse(rsp *http.Response) (*ApprovePaymentRequestHTTPResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ApprovePaymentRequestHTTPResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest PaymentRequest
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Problem
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseDeclinePaymentRequestHTTPResponse parses an HTTP response from a DeclinePaymentRequestWithResponse call
+func ParseDeclinePaymentRequestHTTPResponse(rsp *http.Response) (*DeclinePaymentRequestHTTPResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &DeclinePaymentRequestHTTPResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest PaymentRequest
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Problem
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetPayoutByRequestIdHTTPResponse parses an HTTP response from a GetPayoutByRequestIdWithResponse call
+func ParseGetPayoutByRequestIdHTTPResponse(rsp *http.Response) (*GetPayoutByRequestIdHTTPResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetPayoutByRequestIdHTTPResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest PayoutBatch
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Problem
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseSubmitPayoutHTTPResponse parses an HTTP response from a SubmitPayoutWithResponse call
+func ParseSubmitPayoutHTTPResponse(rsp *http.Response) (*SubmitPayoutHTTPResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &SubmitPayoutHTTPResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 202:
+		var dest PayoutBatch
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON202 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Problem
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetPayoutHTTPResponse parses an HTTP response from a GetPayoutWithResponse call
+func ParseGetPayoutHTTPResponse(rsp *http.Response) (*GetPayoutHTTPResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetPayoutHTTPResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest PayoutBatch
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Problem
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetPayoutLinesHTTPResponse parses an HTTP response from a GetPayoutLinesWithResponse call
+func ParseGetPayoutLinesHTTPResponse(rsp *http.Response) (*GetPayoutLinesHTTPResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetPayoutLinesHTTPResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest SearchPayoutLineResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Problem
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetPocketsHTTPResponse parses an HTTP response from a GetPocketsWithResponse call
+func ParseGetPocketsHTTPResponse(rsp *http.Response) (*GetPocketsHTTPResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetPocketsHTTPResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest SearchPocketResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Problem
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseCreatePocketHTTPResponse parses an HTTP response from a CreatePocketWithResponse call
+func ParseCreatePocketHTTPResponse(rsp *http.Response) (*CreatePocketHTTPResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &CreatePocketHTTPResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 201:
+		var dest Pocket
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON201 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Problem
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetPocketHTTPResponse parses an HTTP response from a GetPocketWithResponse call
+func ParseGetPocketHTTPResponse(rsp *http.Response) (*GetPocketHTTPResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetPocketHTTPResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest Pocket
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Problem
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseDepositToPocketHTTPResponse parses an HTTP response from a DepositToPocketWithResponse call
+func ParseDepositToPocketHTTPResponse(rsp *http.Response) (*DepositToPocketHTTPResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &DepositToPocketHTTPResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest PocketMovementResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Problem
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseWithdrawFromPocketHTTPResponse parses an HTTP response from a WithdrawFromPocketWithResponse call
+func ParseWithdrawFromPocketHTTPResponse(rsp *http.Response) (*WithdrawFromPocketHTTPResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &WithdrawFromPocketHTTPResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest PocketMovementResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Problem
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetScheduledTransfersHTTPResponse parses an HTTP response from a GetScheduledTransfersWithResponse call
+func ParseGetScheduledTransfersHTTPResponse(rsp *http.Response) (*GetScheduledTransfersHTTPResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetScheduledTransfersHTTPResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest SearchScheduledTransferResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Problem
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseCreateScheduledTransferHTTPResponse parses an HTTP response from a CreateScheduledTransferWithResponse call
+func ParseCreateScheduledTransferHTTPResponse(rsp *http.Response) (*CreateScheduledTransferHTTPResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &CreateScheduledTransferHTTPResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 201:
+		var dest ScheduledTransfer
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON201 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Problem
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseCancelScheduledTransferHTTPResponse parses an HTTP response from a CancelScheduledTransferWithResponse call
+func ParseCancelScheduledTransferHTTPResponse(rsp *http.Response) (*CancelScheduledTransferHTTPResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &CancelScheduledTransferHTTPResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest ScheduledTransfer
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Problem
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetScheduledTransferHTTPResponse parses an HTTP response from a GetScheduledTransferWithResponse call
+func ParseGetScheduledTransferHTTPResponse(rsp *http.Response) (*GetScheduledTransferHTTPResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetScheduledTransferHTTPResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest ScheduledTransfer
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Problem
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetScheduledTransferRunsHTTPResponse parses an HTTP response from a GetScheduledTransferRunsWithResponse call
+func ParseGetScheduledTransferRunsHTTPResponse(rsp *http.Response) (*GetScheduledTransferRunsHTTPResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetScheduledTransferRunsHTTPResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest SearchScheduledTransferRunResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Problem
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetBalanceHTTPResponse parses an HTTP response from a GetBalanceWithResponse call
+func ParseGetBalanceHTTPResponse(rsp *http.Response) (*GetBalanceHTTPResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetBalanceHTTPResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest GetCustomerBalanceResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Problem
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseDepositHTTPResponse parses an HTTP response from a DepositWithResponse call
+func ParseDepositHTTPResponse(rsp *http.Response) (*DepositHTTPResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &DepositHTTPResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest DepositResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Problem
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetFeeQuoteHTTPResponse parses an HTTP response from a GetFeeQuoteWithResponse call
+func ParseGetFeeQuoteHTTPResponse(rsp *http.Response) (*GetFeeQuoteHTTPResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetFeeQuoteHTTPResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest FeeQuoteResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Problem
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetInterestHTTPResponse parses an HTTP response from a GetInterestWithResponse call
+func ParseGetInterestHTTPResponse(rsp *http.Response) (*GetInterestHTTPResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetInterestHTTPResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest GetInterestResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Problem
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetPaymentHistoryHTTPResponse parses an HTTP response from a GetPaymentHistoryWithResponse call
+func ParseGetPaymentHistoryHTTPResponse(rsp *http.Response) (*GetPaymentHistoryHTTPResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetPaymentHistoryHTTPResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest SearchPaymentHistoryResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Problem
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetTraceHTTPResponse parses an HTTP response from a GetTraceWithResponse call
+func ParseGetTraceHTTPResponse(rsp *http.Response) (*GetTraceHTTPResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetTraceHTTPResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest GetTraceResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Problem
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseTransferHTTPResponse parses an HTTP response from a TransferWithResponse call
+func ParseTransferHTTPResponse(rsp *http.Response) (*TransferHTTPResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &TransferHTTPResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest TransferResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Problem
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseWithdrawHTTPResponse parses an HTTP response from a WithdrawWithResponse call
+func ParseWithdrawHTTPResponse(rsp *http.Response) (*WithdrawHTTPResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &WithdrawHTTPResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest WithdrawalResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Problem
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetLivenessHTTPResponse parses an HTTP response from a GetLivenessWithResponse call
+func ParseGetLivenessHTTPResponse(rsp *http.Response) (*GetLivenessHTTPResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetLivenessHTTPResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest LivenessResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetMetricsHTTPResponse parses an HTTP response from a GetMetricsWithResponse call
+func ParseGetMetricsHTTPResponse(rsp *http.Response) (*GetMetricsHTTPResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetMetricsHTTPResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	return response, nil
+}
+
+// ParseGetOpenAPIHTTPResponse parses an HTTP response from a GetOpenAPIWithResponse call
+func ParseGetOpenAPIHTTPResponse(rsp *http.Response) (*GetOpenAPIHTTPResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetOpenAPIHTTPResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest map[string]interface{}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetReadinessHTTPResponse parses an HTTP response from a GetReadinessWithResponse call
+func ParseGetReadinessHTTPResponse(rsp *http.Response) (*GetReadinessHTTPResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetReadinessHTTPResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest ReadinessResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 503:
+		var dest ReadinessResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON503 = &dest
+
+	}
+
+	return response, nil
+}
@@ -0,0 +1,5 @@
+// Package client is the typed Go client of the wallet REST API, generated from openapi/openapi.json. Regenerate it
+// with go generate ./client after changing the document, a consumer that stops compiling has hit a breaking change.
+package client
+
+//go:generate go run github.com/oapi-codegen/oapi-codegen/v2/cmd/oapi-codegen@v2.4.1 --config=oapi-codegen.yaml ../openapi/openapi.json
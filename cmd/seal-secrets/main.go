@@ -0,0 +1,54 @@
+// Command seal-secrets encrypts a JSON object of secret name to value for the encrypted_file secrets backend.
+//
+//	WALLET_SECRETS_KEY=$(head -c 32 /dev/urandom | base64) go run ./cmd/seal-secrets -in secrets.json -out secrets.enc
+package main
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"flag"
+	"github.com/raychongtk/wallet/secret"
+	"log"
+	"os"
+	"strings"
+)
+
+func main() {
+	in := flag.String("in", "", "plain JSON secrets file")
+	out := flag.String("out", "", "encrypted secrets file to write")
+	keyFile := flag.String("key-file", "", "file with the base64 key, WALLET_SECRETS_KEY takes precedence")
+	flag.Parse()
+	if *in == "" || *out == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	encoded := os.Getenv("WALLET_SECRETS_KEY")
+	if encoded == "" && *keyFile != "" {
+		content, err := os.ReadFile(*keyFile)
+		if err != nil {
+			log.Fatalf("read key file failed: %v", err)
+		}
+		encoded = string(content)
+	}
+	key, err := base64.StdEncoding.DecodeString(strings.TrimSpace(encoded))
+	if err != nil || len(key) != 32 {
+		log.Fatalln("a base64 encoded 32 byte key is required")
+	}
+
+	content, err := os.ReadFile(*in)
+	if err != nil {
+		log.Fatalf("read secrets failed: %v", err)
+	}
+	var secrets map[string]string
+	if err := json.Unmarshal(content, &secrets); err != nil {
+		log.Fatalf("secrets must be a JSON object of strings: %v", err)
+	}
+	sealed, err := secret.Seal(key, secrets)
+	if err != nil {
+		log.Fatalf("seal secrets failed: %v", err)
+	}
+	if err := os.WriteFile(*out, sealed, 0o600); err != nil {
+		log.Fatalf("write secrets failed: %v", err)
+	}
+}
@@ -0,0 +1,99 @@
+// Command verify-ledger recomputes the ledger hash chains and checks the signed checkpoints. It prints a JSON report
+// and exits with 1 when a record was altered or is missing, the first failure is the earliest one in the chain.
+//
+//	WALLET_PROFILE=prod go run ./cmd/verify-ledger -checkpoints checkpoints.json
+package main
+
+import (
+	"context"
+	"crypto/ed25519"
+	"encoding/base64"
+	"encoding/json"
+	"flag"
+	"github.com/raychongtk/wallet/archive"
+	"github.com/raychongtk/wallet/config"
+	"github.com/raychongtk/wallet/datastore"
+	"github.com/raychongtk/wallet/integrity"
+	"github.com/raychongtk/wallet/repository"
+	"github.com/raychongtk/wallet/secret"
+	"github.com/raychongtk/wallet/util"
+	"log"
+	"os"
+)
+
+func main() {
+	publicKeyFlag := flag.String("public-key", "", "base64 Ed25519 public key of the checkpoints, derived from ledger.signing_key when empty")
+	checkpointsOut := flag.String("checkpoints", "", "also export the signed checkpoints as JSON to this file")
+	flag.Parse()
+	util.InitializeLogger(false)
+	ctx := context.Background()
+
+	cfg, err := config.ProvideConfig()
+	if err != nil {
+		log.Fatalf("load config failed: %v", err)
+	}
+	secrets, err := secret.ProvideProvider(cfg)
+	if err != nil {
+		log.Fatalf("create secret provider failed: %v", err)
+	}
+	db, err := datastore.ProvideDBConnection(cfg, secrets)
+	if err != nil {
+		log.Fatalf("connect database failed: %v", err)
+	}
+	store, err := datastore.ProvideObjectStore(cfg)
+	if err != nil {
+		log.Fatalf("open archive failed: %v", err)
+	}
+
+	var signer *integrity.Signer
+	if cfg.Ledger.SigningKey != "" {
+		if encodedSeed, err := secret.Resolve(ctx, secrets, cfg.Ledger.SigningKey); err == nil {
+			signer, _ = integrity.NewSigner(encodedSeed)
+		}
+	}
+	var publicKey ed25519.PublicKey
+	switch {
+	case *publicKeyFlag != "":
+		decoded, err := base64.StdEncoding.DecodeString(*publicKeyFlag)
+		if err != nil || len(decoded) != ed25519.PublicKeySize {
+			log.Fatalln("public key must be a base64 encoded Ed25519 public key")
+		}
+		publicKey = decoded
+	case signer != nil:
+		publicKey = signer.PublicKey()
+	default:
+		log.Println("no public key, checkpoint signatures are not verified")
+	}
+
+	router := datastore.NewReplicaRouter(&db, nil, nil, cfg.DB.Replica)
+	hashRepo := repository.ProvideLedgerHashRepository(db)
+	verifier := integrity.NewVerifier(
+		repository.ProvideMovementRepository(db),
+		repository.ProvideTransactionRepository(db, router),
+		hashRepo,
+		archive.ProvideReader(repository.ProvideArchiveManifestRepository(db), store),
+		publicKey,
+	)
+	report, err := verifier.Verify(ctx)
+	if err != nil {
+		log.Fatalf("verify ledger failed: %v", err)
+	}
+
+	if *checkpointsOut != "" {
+		checkpoints, err := hashRepo.SearchCheckpoints()
+		if err != nil {
+			log.Fatalf("search checkpoints failed: %v", err)
+		}
+		content, _ := json.MarshalIndent(integrity.NewCheckpointExport(signer, checkpoints), "", "  ")
+		if err := os.WriteFile(*checkpointsOut, content, 0o644); err != nil {
+			log.Fatalf("write checkpoints failed: %v", err)
+		}
+	}
+
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	_ = encoder.Encode(report)
+	if !report.OK() {
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,39 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"github.com/raychongtk/wallet/audit"
+	"github.com/raychongtk/wallet/domain"
+	"time"
+)
+
+type accrual struct {
+	Day    string `json:"day"`
+	Status string `json:"status"`
+}
+
+// accrue runs the interest accrual of a day that ended again, wallets that accrued it already are left alone
+func accrue(ctx context.Context, c *cli, args []string) error {
+	fs := flag.NewFlagSet("accrue", flag.ContinueOnError)
+	dayFlag := fs.String("day", "", "day to accrue as 2006-01-02, yesterday when empty")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	day := time.Now().UTC().AddDate(0, 0, -1)
+	if *dayFlag != "" {
+		var err error
+		if day, err = time.Parse(time.DateOnly, *dayFlag); err != nil {
+			return domain.ErrInvalidParameters.WithMessage("day must be a date like 2006-01-02")
+		}
+	}
+	result := accrual{Day: day.Format(time.DateOnly), Status: "ACCRUED"}
+	c.entry.TargetType, c.entry.TargetID = audit.TargetInterest, result.Day
+	if err := c.interests.Accrue(ctx, day); err != nil {
+		return err
+	}
+	return c.out.Print(result, section{
+		header: []string{"DAY", "STATUS"},
+		rows:   [][]string{{result.Day, result.Status}},
+	})
+}
@@ -0,0 +1,87 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"github.com/raychongtk/wallet/audit"
+	"github.com/raychongtk/wallet/domain"
+	"github.com/raychongtk/wallet/ledger"
+	"github.com/raychongtk/wallet/util"
+	"strings"
+)
+
+type adjustment struct {
+	UserID     string `json:"user_id"`
+	WalletID   string `json:"wallet_id"`
+	GroupID    string `json:"group_id"`
+	Amount     string `json:"amount"`
+	Before     string `json:"before"`
+	After      string `json:"after"`
+	Reason     string `json:"reason"`
+	ApprovedBy string `json:"approved_by"`
+	Ticket     string `json:"ticket"`
+}
+
+// adjust credits or debits a wallet by hand. The ticket doubles as the request id of the movement, so running the
+// same approved adjustment twice is rejected instead of booked twice.
+func adjust(ctx context.Context, c *cli, args []string) error {
+	fs := flag.NewFlagSet("adjust", flag.ContinueOnError)
+	user := fs.String("user", "", "user id")
+	amount := fs.String("amount", "", "signed amount, negative debits the wallet")
+	reason := fs.String("reason", "", "why the wallet is adjusted")
+	approvedBy := fs.String("approved-by", "", "second operator who approved the adjustment")
+	ticket := fs.String("ticket", "", "ticket of the approval, an adjustment is booked once per ticket")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	userID, err := parseUserID(*user)
+	if err != nil {
+		return err
+	}
+	c.entry.TargetType, c.entry.TargetID = audit.TargetUser, userID.String()
+	if strings.TrimSpace(*ticket) == "" {
+		return domain.ErrInvalidParameters.WithMessage("adjustment needs the ticket of its approval")
+	}
+	c.entry.RequestID = "walletctl-" + *ticket
+	minorUnits, err := util.ConvertToInt(*amount)
+	if err != nil {
+		return domain.ErrInvalidParameters.Wrap(err)
+	}
+	result, err := c.ledger.Adjust(ctx, ledger.AdjustCommand{
+		UserID:      userID,
+		Amount:      minorUnits,
+		Reason:      *reason,
+		RequestedBy: c.operator,
+		ApprovedBy:  *approvedBy,
+		RequestID:   c.entry.RequestID,
+	})
+	if err != nil {
+		return err
+	}
+	if result.Replayed {
+		return domain.ErrDuplicateRequest.WithMessage("ticket %s was booked in group %s", *ticket, result.GroupID.String())
+	}
+	done := adjustment{
+		UserID:     userID.String(),
+		WalletID:   result.WalletID.String(),
+		GroupID:    result.GroupID.String(),
+		Amount:     util.DisplayAmount(minorUnits),
+		Before:     util.DisplayAmount(result.Before[result.WalletID]),
+		After:      util.DisplayAmount(result.After[result.WalletID]),
+		Reason:     *reason,
+		ApprovedBy: *approvedBy,
+		Ticket:     *ticket,
+	}
+	c.entry.TargetType, c.entry.TargetID = audit.TargetWallet, done.WalletID
+	c.entry.Before = map[string]interface{}{"balances": result.Before}
+	c.entry.After = map[string]interface{}{
+		"balances":    result.After,
+		"group_id":    result.GroupID,
+		"reason":      done.Reason,
+		"approved_by": done.ApprovedBy,
+	}
+	return c.out.Print(done, section{
+		header: []string{"USER", "WALLET", "GROUP", "AMOUNT", "BEFORE", "AFTER", "APPROVED BY", "TICKET"},
+		rows:   [][]string{{done.UserID, done.WalletID, done.GroupID, done.Amount, done.Before, done.After, done.ApprovedBy, done.Ticket}},
+	})
+}
@@ -0,0 +1,56 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"github.com/raychongtk/wallet/audit"
+	"github.com/raychongtk/wallet/util"
+	"time"
+)
+
+type walletBalance struct {
+	UserID    string    `json:"user_id"`
+	Name      string    `json:"name"`
+	WalletID  string    `json:"wallet_id"`
+	Currency  string    `json:"currency"`
+	Status    string    `json:"status"`
+	Balance   string    `json:"balance"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// balances looks up the wallets of a user with their committed balance
+func balances(ctx context.Context, c *cli, args []string) error {
+	fs := flag.NewFlagSet("balances", flag.ContinueOnError)
+	user := fs.String("user", "", "user id")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	userID, err := parseUserID(*user)
+	if err != nil {
+		return err
+	}
+	c.entry.TargetType, c.entry.TargetID = audit.TargetUser, userID.String()
+
+	customer, err := c.ledger.Customer(userID)
+	if err != nil {
+		return err
+	}
+	balance, err := c.balanceRepo.GetBalance(customer.Wallet.ID, "COMMITTED")
+	if err != nil {
+		return err
+	}
+	wallets := []walletBalance{{
+		UserID:    userID.String(),
+		Name:      customer.Name(),
+		WalletID:  customer.Wallet.ID.String(),
+		Currency:  customer.Wallet.Currency,
+		Status:    customer.Wallet.WalletStatus,
+		Balance:   util.DisplayAmount(balance.Balance),
+		UpdatedAt: balance.UpdatedAt,
+	}}
+	s := section{header: []string{"USER", "NAME", "WALLET", "CURRENCY", "STATUS", "BALANCE", "UPDATED"}}
+	for _, w := range wallets {
+		s.rows = append(s.rows, []string{w.UserID, w.Name, w.WalletID, w.Currency, w.Status, w.Balance, w.UpdatedAt.Format(time.RFC3339)})
+	}
+	return c.out.Print(wallets, s)
+}
@@ -0,0 +1,64 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"github.com/raychongtk/wallet/audit"
+	"github.com/raychongtk/wallet/domain"
+	"github.com/raychongtk/wallet/model/wallet"
+	"strings"
+)
+
+type statusChange struct {
+	UserID   string `json:"user_id"`
+	WalletID string `json:"wallet_id"`
+	From     string `json:"from"`
+	To       string `json:"to"`
+	Reason   string `json:"reason"`
+}
+
+// freeze stops money moving in or out of the wallet of a user
+func freeze(ctx context.Context, c *cli, args []string) error {
+	return setWalletStatus(c, "freeze", wallet.StatusFrozen, args)
+}
+
+// unfreeze lets money move again
+func unfreeze(ctx context.Context, c *cli, args []string) error {
+	return setWalletStatus(c, "unfreeze", wallet.StatusActive, args)
+}
+
+func setWalletStatus(c *cli, name string, status string, args []string) error {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	user := fs.String("user", "", "user id")
+	reason := fs.String("reason", "", "why the wallet status changes, recorded in the audit log")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	userID, err := parseUserID(*user)
+	if err != nil {
+		return err
+	}
+	c.entry.TargetType, c.entry.TargetID = audit.TargetUser, userID.String()
+	if strings.TrimSpace(*reason) == "" {
+		return domain.ErrInvalidParameters.WithMessage("%s needs a reason", name)
+	}
+
+	before, err := c.ledger.SetWalletStatus(userID, status)
+	if err != nil {
+		return err
+	}
+	change := statusChange{
+		UserID:   userID.String(),
+		WalletID: before.Wallet.ID.String(),
+		From:     before.Wallet.WalletStatus,
+		To:       status,
+		Reason:   *reason,
+	}
+	c.entry.TargetType, c.entry.TargetID = audit.TargetWallet, change.WalletID
+	c.entry.Before = map[string]interface{}{"wallet_status": change.From}
+	c.entry.After = map[string]interface{}{"wallet_status": change.To, "reason": change.Reason}
+	return c.out.Print(change, section{
+		header: []string{"USER", "WALLET", "FROM", "TO", "REASON"},
+		rows:   [][]string{{change.UserID, change.WalletID, change.From, change.To, change.Reason}},
+	})
+}
@@ -0,0 +1,101 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"github.com/google/uuid"
+	"github.com/raychongtk/wallet/audit"
+	"github.com/raychongtk/wallet/domain"
+	"github.com/raychongtk/wallet/util"
+	"time"
+)
+
+type movementGroup struct {
+	GroupID      string            `json:"group_id"`
+	Movements    []movementView    `json:"movements"`
+	Transactions []transactionView `json:"transactions"`
+}
+
+type movementView struct {
+	ID             string    `json:"id"`
+	DebitWalletID  string    `json:"debit_wallet_id"`
+	DebitBalance   string    `json:"debit_balance"`
+	CreditWalletID string    `json:"credit_wallet_id"`
+	CreditBalance  string    `json:"credit_balance"`
+	MovementStatus string    `json:"movement_status"`
+	TraceID        string    `json:"trace_id"`
+	RequestID      string    `json:"request_id"`
+	CreatedAt      time.Time `json:"created_at"`
+}
+
+type transactionView struct {
+	ID          string `json:"id"`
+	MovementID  string `json:"movement_id"`
+	WalletID    string `json:"wallet_id"`
+	BalanceType string `json:"balance_type"`
+	Balance     string `json:"balance"`
+}
+
+// group shows the movements of one movement group and the transactions they wrote
+func group(ctx context.Context, c *cli, args []string) error {
+	fs := flag.NewFlagSet("group", flag.ContinueOnError)
+	id := fs.String("id", "", "movement group id")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	groupID, err := uuid.Parse(*id)
+	if err != nil {
+		return domain.ErrInvalidParameters.Wrap(err)
+	}
+	c.entry.TargetType, c.entry.TargetID = audit.TargetMovement, groupID.String()
+
+	movements, err := c.movementRepo.SearchMovementsByGroupIDs([]uuid.UUID{groupID})
+	if err != nil {
+		return err
+	}
+	if len(movements) == 0 {
+		return domain.ErrNotFound.WithMessage("movement group %s not found", groupID.String())
+	}
+	var movementIDs []uuid.UUID
+	for _, m := range movements {
+		movementIDs = append(movementIDs, m.ID)
+	}
+	transactions, err := c.transactionRepo.SearchTransactionsByMovementIDs(movementIDs)
+	if err != nil {
+		return err
+	}
+
+	result := movementGroup{GroupID: groupID.String()}
+	movementSection := section{header: []string{"MOVEMENT", "DEBIT WALLET", "DEBIT", "CREDIT WALLET", "CREDIT", "STATUS", "REQUEST", "CREATED"}}
+	for _, m := range movements {
+		view := movementView{
+			ID:             m.ID.String(),
+			DebitWalletID:  m.DebitWalletID.String(),
+			DebitBalance:   util.DisplayAmount(m.DebitBalance),
+			CreditWalletID: m.CreditWalletID.String(),
+			CreditBalance:  util.DisplayAmount(m.CreditBalance),
+			MovementStatus: m.MovementStatus,
+			TraceID:        m.TraceID,
+			RequestID:      m.RequestID,
+			CreatedAt:      m.CreatedAt,
+		}
+		result.Movements = append(result.Movements, view)
+		movementSection.rows = append(movementSection.rows, []string{
+			view.ID, view.DebitWalletID, view.DebitBalance, view.CreditWalletID, view.CreditBalance, view.MovementStatus, view.RequestID,
+			view.CreatedAt.Format(time.RFC3339),
+		})
+	}
+	transactionSection := section{header: []string{"TRANSACTION", "MOVEMENT", "WALLET", "TYPE", "AMOUNT"}}
+	for _, t := range transactions {
+		view := transactionView{
+			ID:          t.ID.String(),
+			MovementID:  t.MovementID.String(),
+			WalletID:    t.WalletID.String(),
+			BalanceType: t.BalanceType,
+			Balance:     util.DisplayAmount(t.Balance),
+		}
+		result.Transactions = append(result.Transactions, view)
+		transactionSection.rows = append(transactionSection.rows, []string{view.ID, view.MovementID, view.WalletID, view.BalanceType, view.Balance})
+	}
+	return c.out.Print(result, movementSection, transactionSection)
+}
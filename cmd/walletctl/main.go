@@ -0,0 +1,207 @@
+// Command walletctl answers the questions ops used to answer with psql and makes the few changes operators are
+// allowed to make by hand. It talks to the ledger core directly with the same configuration as the server, and every
+// invocation is written to the audit log with the operator as the actor, including lookups and rejected commands.
+//
+//	WALLET_PROFILE=prod go run ./cmd/walletctl -operator alice balances -user 2d988f4a-a037-4ce9-a350-f13445793e88
+//	WALLET_PROFILE=prod go run ./cmd/walletctl -o json group -id 6c1f0ad4-5b43-4bfb-9f55-0e3d1c7e2b10
+//	WALLET_PROFILE=prod go run ./cmd/walletctl freeze -user 2d988f4a-a037-4ce9-a350-f13445793e88 -reason "fraud case 812"
+//	WALLET_PROFILE=prod go run ./cmd/walletctl accrue -day 2026-10-18
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/raychongtk/wallet/archive"
+	"github.com/raychongtk/wallet/audit"
+	"github.com/raychongtk/wallet/config"
+	"github.com/raychongtk/wallet/datastore"
+	"github.com/raychongtk/wallet/domain"
+	"github.com/raychongtk/wallet/integrity"
+	"github.com/raychongtk/wallet/interest"
+	"github.com/raychongtk/wallet/ledger"
+	"github.com/raychongtk/wallet/problem"
+	"github.com/raychongtk/wallet/repository"
+	"github.com/raychongtk/wallet/secret"
+	"github.com/raychongtk/wallet/util"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"sort"
+)
+
+// command is one subcommand, run parses its own flags from args
+type command struct {
+	usage string
+	run   func(ctx context.Context, c *cli, args []string) error
+}
+
+var commands = map[string]command{
+	"balances":  {usage: "balances -user <id>", run: balances},
+	"group":     {usage: "group -id <movement group id>", run: group},
+	"freeze":    {usage: "freeze -user <id> -reason <text>", run: freeze},
+	"unfreeze":  {usage: "unfreeze -user <id> -reason <text>", run: unfreeze},
+	"verify":    {usage: "verify", run: verify},
+	"statement": {usage: "statement -user <id> -from <date> -to <date>", run: statement},
+	"adjust":    {usage: "adjust -user <id> -amount <signed amount> -reason <text> -approved-by <operator> -ticket <id>", run: adjust},
+	"accrue":    {usage: "accrue -day <date>", run: accrue},
+}
+
+// cli holds what the subcommands share. Commands fill in the target and the change of the audit entry as they learn
+// them, main records it whatever the outcome.
+type cli struct {
+	ledger             *ledger.Ledger
+	balanceRepo        repository.BalanceRepository
+	movementRepo       repository.MovementRepository
+	transactionRepo    repository.TransactionRepository
+	paymentHistoryRepo repository.PaymentHistoryRepository
+	hashRepo           repository.LedgerHashRepository
+	archiveReader      *archive.Reader
+	interests          *interest.Manager
+	cfg                *config.Config
+	secrets            secret.Provider
+	operator           string
+	out                *printer
+	entry              audit.Entry
+}
+
+func main() {
+	flag.Usage = usage
+	output := flag.String("o", "table", "output format: table, json or csv")
+	operator := flag.String("operator", os.Getenv("USER"), "operator running the command, recorded in the audit log")
+	flag.Parse()
+	if flag.NArg() == 0 {
+		usage()
+		os.Exit(2)
+	}
+	name := flag.Arg(0)
+	cmd, ok := commands[name]
+	if !ok {
+		fmt.Fprintf(os.Stderr, "unknown command %q\n", name)
+		usage()
+		os.Exit(2)
+	}
+	if *operator == "" {
+		log.Fatalln("operator is required, pass -operator or set USER")
+	}
+	out, err := newPrinter(os.Stdout, *output)
+	if err != nil {
+		log.Fatalln(err)
+	}
+	util.InitializeLoggerWithLevel(false, "warn")
+	ctx := context.Background()
+
+	cfg, err := config.ProvideConfig()
+	if err != nil {
+		log.Fatalf("load config failed: %v", err)
+	}
+	secrets, err := secret.ProvideProvider(cfg)
+	if err != nil {
+		log.Fatalf("create secret provider failed: %v", err)
+	}
+	db, err := datastore.ProvideDBConnection(cfg, secrets)
+	if err != nil {
+		log.Fatalf("connect database failed: %v", err)
+	}
+	store, err := datastore.ProvideObjectStore(cfg)
+	if err != nil {
+		log.Fatalf("open archive failed: %v", err)
+	}
+
+	// the CLI reads its own writes, so every query goes to the primary
+	router := datastore.NewReplicaRouter(&db, nil, nil, cfg.DB.Replica)
+	movementRepo := repository.ProvideMovementRepository(db)
+	transactionRepo := repository.ProvideTransactionRepository(db, router)
+	balanceRepo := repository.ProvideBalanceRepository(db, router)
+	paymentHistoryRepo := repository.ProvidePaymentHistoryRepository(db, router)
+	hashRepo := repository.ProvideLedgerHashRepository(db)
+	memberRepo := repository.ProvideMemberRepository(db)
+	uow := ledger.ProvideUnitOfWork(db, movementRepo, transactionRepo, balanceRepo, paymentHistoryRepo, repository.ProvideHoldRepository(db), repository.ProvideEscrowRepository(db), repository.ProvideInterestRepository(db), repository.ProvidePocketRepository(db), memberRepo, repository.ProvideSharedTransferRepository(db), repository.ProvideBusinessTransferRepository(db), integrity.ProvideChain(hashRepo), router)
+	c := &cli{
+		ledger: ledger.ProvideLedger(
+			repository.ProvideUserRepository(db),
+			repository.ProvideAccountRepository(db),
+			repository.ProvideOrganizationRepository(db),
+			repository.ProvideWalletRepository(db),
+			memberRepo,
+			uow,
+			cfg,
+		),
+		balanceRepo:        balanceRepo,
+		movementRepo:       movementRepo,
+		transactionRepo:    transactionRepo,
+		paymentHistoryRepo: paymentHistoryRepo,
+		hashRepo:           hashRepo,
+		archiveReader:      archive.ProvideReader(repository.ProvideArchiveManifestRepository(db), store),
+		cfg:                cfg,
+		secrets:            secrets,
+		operator:           *operator,
+		out:                out,
+		entry: audit.Entry{
+			ActorType: audit.ActorOperator,
+			ActorID:   *operator,
+			Action:    "walletctl." + name,
+			RequestID: uuid.New().String(),
+			Outcome:   audit.OutcomeSuccess,
+		},
+	}
+
+	c.interests = interest.ProvideManager(c.ledger, repository.ProvideInterestRepository(db), transactionRepo, c.archiveReader, cfg)
+
+	runErr := cmd.run(ctx, c, flag.Args()[1:])
+	if runErr != nil {
+		domainErr := domain.From(runErr)
+		c.entry.Outcome, c.entry.ErrorCode = audit.OutcomeDenied, domainErr.Code
+		if problem.Status(domainErr.Kind) >= http.StatusInternalServerError {
+			c.entry.Outcome = audit.OutcomeFailure
+		}
+	}
+	// what was printed before a failure is still worth seeing, a failed verification prints its report
+	if err := out.Flush(); err != nil {
+		log.Printf("write output failed: %v", err)
+	}
+	if err := audit.ProvideAuditor(repository.ProvideAuditLogRepository(db), db).Record(ctx, c.entry); err != nil {
+		log.Fatalf("record audit log failed: %v", err)
+	}
+	if runErr != nil {
+		log.Fatalf("%s failed: %v", name, runErr)
+	}
+}
+
+func usage() {
+	out := flag.CommandLine.Output()
+	fmt.Fprintln(out, "usage: walletctl [-o table|json|csv] [-operator name] <command> [flags]")
+	fmt.Fprintln(out, "\ncommands:")
+	var names []string
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fmt.Fprintf(out, "  %s\n", commands[name].usage)
+	}
+	fmt.Fprintln(out, "\nglobal flags:")
+	flag.PrintDefaults()
+}
+
+// parseFlags parses the flags of a subcommand, a usage error is an invalid parameter like any other
+func parseFlags(fs *flag.FlagSet, args []string) error {
+	fs.SetOutput(io.Discard)
+	if err := fs.Parse(args); err != nil {
+		return domain.ErrInvalidParameters.Wrap(err)
+	}
+	if fs.NArg() > 0 {
+		return domain.ErrInvalidParameters.WithMessage("unexpected argument %q", fs.Arg(0))
+	}
+	return nil
+}
+
+func parseUserID(value string) (uuid.UUID, error) {
+	userID, err := uuid.Parse(value)
+	if err != nil {
+		return uuid.UUID{}, domain.ErrInvalidAccount.Wrap(err)
+	}
+	return userID, nil
+}
@@ -0,0 +1,87 @@
+package main
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+)
+
+const (
+	formatTable = "table"
+	formatJSON  = "json"
+	formatCSV   = "csv"
+)
+
+// section is one table of a result, a statement for example prints its summary and its entries
+type section struct {
+	header []string
+	rows   [][]string
+}
+
+// printer writes the result of a command as aligned tables for people, or as JSON or CSV for scripts and
+// spreadsheets. Output is buffered until main flushes it once the command is done.
+type printer struct {
+	w      *bufio.Writer
+	format string
+}
+
+func newPrinter(w io.Writer, format string) (*printer, error) {
+	switch format {
+	case formatTable, formatJSON, formatCSV:
+		return &printer{w: bufio.NewWriter(w), format: format}, nil
+	default:
+		return nil, fmt.Errorf("unknown output format %q, use table, json or csv", format)
+	}
+}
+
+// Print writes value as one JSON document, or the sections one after another for table and CSV output
+func (p *printer) Print(value interface{}, sections ...section) error {
+	if p.format == formatJSON {
+		encoder := json.NewEncoder(p.w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(value)
+	}
+	for i, s := range sections {
+		if i > 0 {
+			fmt.Fprintln(p.w)
+		}
+		var err error
+		if p.format == formatCSV {
+			err = p.csv(s)
+		} else {
+			err = p.table(s)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (p *printer) Flush() error {
+	return p.w.Flush()
+}
+
+func (p *printer) table(s section) error {
+	w := tabwriter.NewWriter(p.w, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, strings.Join(s.header, "\t"))
+	for _, row := range s.rows {
+		fmt.Fprintln(w, strings.Join(row, "\t"))
+	}
+	return w.Flush()
+}
+
+func (p *printer) csv(s section) error {
+	w := csv.NewWriter(p.w)
+	if err := w.Write(s.header); err != nil {
+		return err
+	}
+	if err := w.WriteAll(s.rows); err != nil {
+		return err
+	}
+	return w.Error()
+}
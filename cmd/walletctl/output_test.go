@@ -0,0 +1,34 @@
+package main
+
+import (
+	"bytes"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestPrinterFormats(t *testing.T) {
+	value := map[string]string{"balance": "12.50"}
+	s := section{header: []string{"WALLET", "BALANCE"}, rows: [][]string{{"1cc535a5", "12.50"}}}
+
+	var out bytes.Buffer
+	p, err := newPrinter(&out, formatTable)
+	assert.NoError(t, err)
+	assert.NoError(t, p.Print(value, s, s))
+	assert.NoError(t, p.Flush())
+	assert.Equal(t, "WALLET    BALANCE\n1cc535a5  12.50\n\nWALLET    BALANCE\n1cc535a5  12.50\n", out.String())
+
+	out.Reset()
+	p, _ = newPrinter(&out, formatCSV)
+	assert.NoError(t, p.Print(value, s))
+	assert.NoError(t, p.Flush())
+	assert.Equal(t, "WALLET,BALANCE\n1cc535a5,12.50\n", out.String())
+
+	out.Reset()
+	p, _ = newPrinter(&out, formatJSON)
+	assert.NoError(t, p.Print(value, s))
+	assert.NoError(t, p.Flush())
+	assert.JSONEq(t, `{"balance": "12.50"}`, out.String())
+
+	_, err = newPrinter(&out, "xml")
+	assert.Error(t, err)
+}
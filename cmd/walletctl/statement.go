@@ -0,0 +1,157 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"github.com/google/uuid"
+	"github.com/raychongtk/wallet/audit"
+	"github.com/raychongtk/wallet/domain"
+	"github.com/raychongtk/wallet/model/payment"
+	"github.com/raychongtk/wallet/util"
+	"sort"
+	"time"
+)
+
+type walletStatement struct {
+	UserID   string           `json:"user_id"`
+	Name     string           `json:"name"`
+	WalletID string           `json:"wallet_id"`
+	Currency string           `json:"currency"`
+	From     time.Time        `json:"from"`
+	To       time.Time        `json:"to"`
+	Opening  string           `json:"opening_balance"`
+	Closing  string           `json:"closing_balance"`
+	Entries  []statementEntry `json:"entries"`
+}
+
+type statementEntry struct {
+	CreatedAt time.Time `json:"created_at"`
+	PayType   string    `json:"pay_type"`
+	PayerName string    `json:"payer_name"`
+	PayeeName string    `json:"payee_name"`
+	Amount    string    `json:"amount"`
+	Balance   string    `json:"balance"`
+	RequestID string    `json:"request_id"`
+}
+
+// statement exports the payments of a user after from up to and including to, archived ones included, with the
+// balance before and after the period and a running balance per entry
+func statement(ctx context.Context, c *cli, args []string) error {
+	fs := flag.NewFlagSet("statement", flag.ContinueOnError)
+	user := fs.String("user", "", "user id")
+	fromFlag := fs.String("from", "", "start of the period as 2006-01-02 or RFC3339, 30 days before -to when empty")
+	toFlag := fs.String("to", "", "end of the period as 2006-01-02 or RFC3339, now when empty")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	userID, err := parseUserID(*user)
+	if err != nil {
+		return err
+	}
+	c.entry.TargetType, c.entry.TargetID = audit.TargetUser, userID.String()
+	to, err := parseTime(*toFlag, time.Now())
+	if err != nil {
+		return err
+	}
+	from, err := parseTime(*fromFlag, to.AddDate(0, 0, -30))
+	if err != nil {
+		return err
+	}
+	if !from.Before(to) {
+		return domain.ErrInvalidParameters.WithMessage("from must be before to")
+	}
+
+	customer, err := c.ledger.Customer(userID)
+	if err != nil {
+		return err
+	}
+	opening, err := c.balanceAt(ctx, customer.Wallet.ID, from)
+	if err != nil {
+		return err
+	}
+	closing, err := c.balanceAt(ctx, customer.Wallet.ID, to)
+	if err != nil {
+		return err
+	}
+	histories, err := c.paymentHistoryRepo.SearchPaymentHistory(userID.String())
+	if err != nil {
+		return err
+	}
+	archived, err := c.archiveReader.PaymentHistories(ctx, userID.String())
+	if err != nil {
+		return err
+	}
+	var inPeriod []payment.PaymentHistory
+	for _, history := range append(archived, histories...) {
+		if history.CreatedAt.After(from) && !history.CreatedAt.After(to) {
+			inPeriod = append(inPeriod, history)
+		}
+	}
+	sort.SliceStable(inPeriod, func(i, j int) bool { return inPeriod[i].CreatedAt.Before(inPeriod[j].CreatedAt) })
+
+	result := walletStatement{
+		UserID:   userID.String(),
+		Name:     customer.Name(),
+		WalletID: customer.Wallet.ID.String(),
+		Currency: customer.Wallet.Currency,
+		From:     from,
+		To:       to,
+		Opening:  util.DisplayAmount(opening),
+		Closing:  util.DisplayAmount(closing),
+		Entries:  []statementEntry{},
+	}
+	entries := section{header: []string{"DATE", "TYPE", "PAYER", "PAYEE", "AMOUNT", "BALANCE", "REQUEST"}}
+	balance := opening
+	for _, history := range inPeriod {
+		amount := history.SignedAmount(userID.String())
+		balance += amount
+		entry := statementEntry{
+			CreatedAt: history.CreatedAt,
+			PayType:   history.PayType,
+			PayerName: history.PayerName,
+			PayeeName: history.PayeeName,
+			Amount:    util.DisplayAmount(amount),
+			Balance:   util.DisplayAmount(balance),
+			RequestID: history.RequestID,
+		}
+		result.Entries = append(result.Entries, entry)
+		entries.rows = append(entries.rows, []string{
+			entry.CreatedAt.Format(time.RFC3339), entry.PayType, entry.PayerName, entry.PayeeName, entry.Amount, entry.Balance, entry.RequestID,
+		})
+	}
+	summary := section{
+		header: []string{"USER", "NAME", "WALLET", "CURRENCY", "FROM", "TO", "OPENING", "CLOSING"},
+		rows: [][]string{{
+			result.UserID, result.Name, result.WalletID, result.Currency,
+			from.Format(time.RFC3339), to.Format(time.RFC3339), result.Opening, result.Closing,
+		}},
+	}
+	return c.out.Print(result, summary, entries)
+}
+
+// balanceAt replays the hot and the archived transactions of a wallet up to asOf
+func (c *cli) balanceAt(ctx context.Context, walletID uuid.UUID, asOf time.Time) (int, error) {
+	hot, err := c.transactionRepo.SumBalance(walletID, "COMMITTED", asOf)
+	if err != nil {
+		return 0, err
+	}
+	archived, err := c.archiveReader.SumBalance(ctx, walletID, "COMMITTED", asOf)
+	if err != nil {
+		return 0, err
+	}
+	return hot + archived, nil
+}
+
+func parseTime(value string, fallback time.Time) (time.Time, error) {
+	if value == "" {
+		return fallback, nil
+	}
+	if date, err := time.Parse(time.DateOnly, value); err == nil {
+		return date, nil
+	}
+	parsed, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, domain.ErrInvalidParameters.WithMessage("time must be a date like 2006-01-02 or RFC3339")
+	}
+	return parsed, nil
+}
@@ -0,0 +1,90 @@
+package main
+
+import (
+	"context"
+	"crypto/ed25519"
+	"encoding/base64"
+	"flag"
+	"github.com/raychongtk/wallet/audit"
+	"github.com/raychongtk/wallet/domain"
+	"github.com/raychongtk/wallet/integrity"
+	"github.com/raychongtk/wallet/secret"
+	"strconv"
+)
+
+// errLedgerTampered reports a verification that ran and found altered or missing records
+var errLedgerTampered = &domain.Error{Kind: domain.KindInternal, Code: "LEDGER_TAMPERED", Message: "ledger does not match its hash chains"}
+
+// verify runs the same integrity check as verify-ledger and fails when the report is not clean
+func verify(ctx context.Context, c *cli, args []string) error {
+	fs := flag.NewFlagSet("verify", flag.ContinueOnError)
+	publicKeyFlag := fs.String("public-key", "", "base64 Ed25519 public key of the checkpoints, derived from ledger.signing_key when empty")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	c.entry.TargetType = audit.TargetCheckpoint
+	publicKey, err := c.checkpointKey(ctx, *publicKeyFlag)
+	if err != nil {
+		return err
+	}
+
+	verifier := integrity.NewVerifier(c.movementRepo, c.transactionRepo, c.hashRepo, c.archiveReader, publicKey)
+	report, err := verifier.Verify(ctx)
+	if err != nil {
+		return err
+	}
+	c.entry.TargetID = strconv.FormatInt(report.HeadSequence, 10)
+	c.entry.After = report
+
+	summary := section{
+		header: []string{"ENTRIES", "WALLET ENTRIES", "CHECKPOINTS", "UNCHAINED", "HEAD SEQUENCE", "HEAD HASH", "OK"},
+		rows: [][]string{{
+			strconv.FormatInt(report.Entries, 10),
+			strconv.FormatInt(report.WalletEntries, 10),
+			strconv.Itoa(report.Checkpoints),
+			strconv.FormatInt(report.Unchained, 10),
+			strconv.FormatInt(report.HeadSequence, 10),
+			report.HeadHash,
+			strconv.FormatBool(report.OK()),
+		}},
+	}
+	sections := []section{summary}
+	if len(report.Failures) > 0 {
+		failures := section{header: []string{"SEQUENCE", "WALLET", "GROUP", "REASON"}}
+		for _, failure := range report.Failures {
+			failures.rows = append(failures.rows, []string{strconv.FormatInt(failure.Sequence, 10), failure.WalletID, failure.GroupID, failure.Reason})
+		}
+		sections = append(sections, failures)
+	}
+	if err := c.out.Print(report, sections...); err != nil {
+		return err
+	}
+	if !report.OK() {
+		return errLedgerTampered
+	}
+	return nil
+}
+
+// checkpointKey is the given public key or the one of the configured signing key, without either checkpoint
+// signatures are not verified
+func (c *cli) checkpointKey(ctx context.Context, encoded string) (ed25519.PublicKey, error) {
+	if encoded != "" {
+		decoded, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil || len(decoded) != ed25519.PublicKeySize {
+			return nil, domain.ErrInvalidParameters.WithMessage("public key must be a base64 encoded Ed25519 public key")
+		}
+		return decoded, nil
+	}
+	if c.cfg.Ledger.SigningKey == "" {
+		return nil, nil
+	}
+	encodedSeed, err := secret.Resolve(ctx, c.secrets, c.cfg.Ledger.SigningKey)
+	if err != nil {
+		return nil, err
+	}
+	signer, err := integrity.NewSigner(encodedSeed)
+	if err != nil {
+		return nil, err
+	}
+	return signer.PublicKey(), nil
+}
@@ -0,0 +1,495 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"github.com/spf13/viper"
+	"go.uber.org/zap/zapcore"
+	"os"
+	"reflect"
+	"strings"
+	"time"
+)
+
+const (
+	ProfileDev  = "dev"
+	ProfileTest = "test"
+	ProfileProd = "prod"
+
+	envPrefix = "WALLET"
+)
+
+// Config is the single source of configuration. It is loaded from config/<profile>.yaml and every key can be
+// overridden by an environment variable, e.g. db.host by WALLET_DB_HOST.
+type Config struct {
+	Profile        string               `mapstructure:"profile"`
+	DB             DBConfig             `mapstructure:"db"`
+	Redis          RedisConfig          `mapstructure:"redis"`
+	Server         ServerConfig         `mapstructure:"server"`
+	Log            LogConfig            `mapstructure:"log"`
+	Limits         LimitsConfig         `mapstructure:"limits"`
+	Features       FeaturesConfig       `mapstructure:"features"`
+	Archive        ArchiveConfig        `mapstructure:"archive"`
+	Secrets        SecretsConfig        `mapstructure:"secrets"`
+	Tracing        TracingConfig        `mapstructure:"tracing"`
+	Ledger         LedgerConfig         `mapstructure:"ledger"`
+	GRPC           GRPCConfig           `mapstructure:"grpc"`
+	Payout         PayoutConfig         `mapstructure:"payout"`
+	Scheduler      SchedulerConfig      `mapstructure:"scheduler"`
+	PaymentRequest PaymentRequestConfig `mapstructure:"payment_request"`
+	Hold           HoldConfig           `mapstructure:"hold"`
+	Escrow         EscrowConfig         `mapstructure:"escrow"`
+	Fees           FeeConfig            `mapstructure:"fees"`
+	Interest       InterestConfig       `mapstructure:"interest"`
+}
+
+type DBConfig struct {
+	Host            string        `mapstructure:"host"`
+	Port            int           `mapstructure:"port"`
+	Name            string        `mapstructure:"name"`
+	Username        string        `mapstructure:"username"`
+	Password        string        `mapstructure:"password"`
+	SSLMode         string        `mapstructure:"ssl_mode"`
+	TimeZone        string        `mapstructure:"time_zone"`
+	MaxOpenConns    int           `mapstructure:"max_open_conns"`
+	MaxIdleConns    int           `mapstructure:"max_idle_conns"`
+	ConnMaxLifetime time.Duration `mapstructure:"conn_max_lifetime"`
+	MigrateOnStart  bool          `mapstructure:"migrate_on_start"`
+	Replica         ReplicaConfig `mapstructure:"replica"`
+}
+
+type ReplicaConfig struct {
+	Hosts            []string      `mapstructure:"hosts"`
+	Stickiness       time.Duration `mapstructure:"stickiness"`
+	MaxLag           time.Duration `mapstructure:"max_lag"`
+	LagCheckInterval time.Duration `mapstructure:"lag_check_interval"`
+}
+
+type RedisConfig struct {
+	Address         string        `mapstructure:"address"`
+	Password        string        `mapstructure:"password"`
+	DB              int           `mapstructure:"db"`
+	ConnMaxLifetime time.Duration `mapstructure:"conn_max_lifetime"`
+}
+
+type ServerConfig struct {
+	Address      string        `mapstructure:"address"`
+	Mode         string        `mapstructure:"mode"`
+	ReadTimeout  time.Duration `mapstructure:"read_timeout"`
+	WriteTimeout time.Duration `mapstructure:"write_timeout"`
+	// DrainDelay keeps serving after readiness turns unready so that load balancers stop routing first
+	DrainDelay      time.Duration `mapstructure:"drain_delay"`
+	ShutdownTimeout time.Duration `mapstructure:"shutdown_timeout"`
+	HealthTimeout   time.Duration `mapstructure:"health_timeout"`
+}
+
+type LogConfig struct {
+	Production bool   `mapstructure:"production"`
+	Level      string `mapstructure:"level"`
+}
+
+type LimitsConfig struct {
+	// MaxAmount caps a single deposit, withdrawal or transfer in minor units. Zero means no cap.
+	MaxAmount      int           `mapstructure:"max_amount"`
+	IdempotencyTTL time.Duration `mapstructure:"idempotency_ttl"`
+}
+
+type FeaturesConfig struct {
+	Archival     bool `mapstructure:"archival"`
+	ReadReplicas bool `mapstructure:"read_replicas"`
+	// LedgerCheckpoints signs the head of the ledger hash chain every ledger.checkpoint_interval
+	LedgerCheckpoints bool `mapstructure:"ledger_checkpoints"`
+	// GRPC serves the internal LedgerService next to the REST API
+	GRPC bool `mapstructure:"grpc"`
+	// ResponseValidation checks every JSON response against the OpenAPI document and logs the ones that drifted
+	ResponseValidation bool `mapstructure:"response_validation"`
+	// Interest accrues interest on customer wallets every day and pays it out every month
+	Interest bool `mapstructure:"interest"`
+}
+
+// SecretsConfig selects where secret://name references in other keys are resolved
+type SecretsConfig struct {
+	Backend  string        `mapstructure:"backend"`
+	Dir      string        `mapstructure:"dir"`
+	File     string        `mapstructure:"file"`
+	KeyFile  string        `mapstructure:"key_file"`
+	CacheTTL time.Duration `mapstructure:"cache_ttl"`
+}
+
+// TracingConfig selects the OpenTelemetry span exporter, one of none, stdout, file and otlp
+type TracingConfig struct {
+	Exporter    string  `mapstructure:"exporter"`
+	ServiceName string  `mapstructure:"service_name"`
+	Endpoint    string  `mapstructure:"endpoint"`
+	Insecure    bool    `mapstructure:"insecure"`
+	File        string  `mapstructure:"file"`
+	SampleRatio float64 `mapstructure:"sample_ratio"`
+}
+
+type LedgerConfig struct {
+	// SigningKey is the base64 Ed25519 seed that signs checkpoints, usually a secret://name reference
+	SigningKey         string        `mapstructure:"signing_key"`
+	CheckpointInterval time.Duration `mapstructure:"checkpoint_interval"`
+}
+
+type GRPCConfig struct {
+	Address string `mapstructure:"address"`
+	// Token is the bearer token internal callers present, usually a secret://name reference
+	Token string `mapstructure:"token"`
+}
+
+// PayoutConfig bounds bulk payouts: lines per batch, lines per database transaction, how often batches are picked up
+// and how long an instance holds a batch before another may take it over
+type PayoutConfig struct {
+	MaxLines  int           `mapstructure:"max_lines"`
+	ChunkSize int           `mapstructure:"chunk_size"`
+	Interval  time.Duration `mapstructure:"interval"`
+	Lease     time.Duration `mapstructure:"lease"`
+}
+
+// SchedulerConfig drives standing orders. An occurrence that fails for insufficient funds is tried MaxAttempts times
+// in all, RetryBackoff apart and doubling, and occurrences due longer than MisfireWindow ago are skipped rather than
+// paid late.
+type SchedulerConfig struct {
+	Interval      time.Duration `mapstructure:"interval"`
+	Lease         time.Duration `mapstructure:"lease"`
+	MaxAttempts   int           `mapstructure:"max_attempts"`
+	RetryBackoff  time.Duration `mapstructure:"retry_backoff"`
+	MisfireWindow time.Duration `mapstructure:"misfire_window"`
+}
+
+// PaymentRequestConfig is the expiry of a payment request when the requester does not choose one, the longest one
+// they may choose and how often expired requests are closed
+type PaymentRequestConfig struct {
+	DefaultExpiry time.Duration `mapstructure:"default_expiry"`
+	MaxExpiry     time.Duration `mapstructure:"max_expiry"`
+	Interval      time.Duration `mapstructure:"interval"`
+}
+
+// HoldConfig is how long a hold stays active when the merchant does not say, the longest it may stay and how often
+// expired holds are released
+type HoldConfig struct {
+	DefaultExpiry time.Duration `mapstructure:"default_expiry"`
+	MaxExpiry     time.Duration `mapstructure:"max_expiry"`
+	Interval      time.Duration `mapstructure:"interval"`
+}
+
+// EscrowConfig is the deadline of an escrow when the buyer does not say, the latest it may be and how often escrows
+// past their deadline are settled
+type EscrowConfig struct {
+	DefaultDeadline time.Duration `mapstructure:"default_deadline"`
+	MaxDeadline     time.Duration `mapstructure:"max_deadline"`
+	Interval        time.Duration `mapstructure:"interval"`
+}
+
+const (
+	FeeFlat       = "FLAT"
+	FeePercentage = "PERCENTAGE"
+	FeeTiered     = "TIERED"
+)
+
+// FeeConfig prices withdrawals and transfers. The first rule matching the operation, the wallet currency and the user
+// tier of a payment sets its fee, a payment no rule matches is free.
+type FeeConfig struct {
+	Rules []FeeRule `mapstructure:"rules"`
+}
+
+// FeeRule charges Flat, RateBps basis points of the amount, or for TIERED the rate and flat fee of the tier the
+// amount falls in, then keeps the fee between Min and Max. Amounts are in minor units, a zero Max is no cap and an
+// empty Currency or Tier matches any.
+type FeeRule struct {
+	Operation string    `mapstructure:"operation"`
+	Currency  string    `mapstructure:"currency"`
+	Tier      string    `mapstructure:"tier"`
+	Type      string    `mapstructure:"type"`
+	Flat      int       `mapstructure:"flat"`
+	RateBps   int       `mapstructure:"rate_bps"`
+	Tiers     []FeeTier `mapstructure:"tiers"`
+	Min       int       `mapstructure:"min"`
+	Max       int       `mapstructure:"max"`
+}
+
+// FeeTier covers amounts up to and including UpTo, the last tier has no UpTo and covers the rest
+type FeeTier struct {
+	UpTo    int `mapstructure:"up_to"`
+	RateBps int `mapstructure:"rate_bps"`
+	Flat    int `mapstructure:"flat"`
+}
+
+const (
+	DayCountActual365    = "ACT/365"
+	DayCountActual360    = "ACT/360"
+	DayCountActualActual = "ACT/ACT"
+)
+
+// InterestConfig is the annual rate of the end of day balance of a wallet and how often days that ended are accrued.
+// Each tier pays its rate on the part of the balance that falls in it. DayCount divides the annual rate into days by
+// 365, 360 or the days of the year.
+type InterestConfig struct {
+	DayCount string         `mapstructure:"day_count"`
+	Tiers    []InterestTier `mapstructure:"tiers"`
+	Interval time.Duration  `mapstructure:"interval"`
+}
+
+// InterestTier covers the balance up to and including UpTo, in minor units, the last tier has no UpTo and covers the
+// rest
+type InterestTier struct {
+	UpTo    int `mapstructure:"up_to"`
+	RateBps int `mapstructure:"rate_bps"`
+}
+
+type ArchiveConfig struct {
+	Path     string        `mapstructure:"path"`
+	Horizon  time.Duration `mapstructure:"horizon"`
+	Interval time.Duration `mapstructure:"interval"`
+	// Username logs the archiver in as a member of wallet_archiver, the only role that may record manifests and
+	// delete archived ledger rows. Archival requires it.
+	Username string `mapstructure:"username"`
+	Password string `mapstructure:"password"`
+}
+
+// ProvideConfig loads the profile named by WALLET_PROFILE (dev by default) from WALLET_CONFIG_PATH (./config by default)
+func ProvideConfig() (*Config, error) {
+	profile := os.Getenv(envPrefix + "_PROFILE")
+	if profile == "" {
+		profile = ProfileDev
+	}
+	path := os.Getenv(envPrefix + "_CONFIG_PATH")
+	if path == "" {
+		path = "./config"
+	}
+	return Load(profile, path)
+}
+
+func Load(profile string, path string) (*Config, error) {
+	if profile != ProfileDev && profile != ProfileTest && profile != ProfileProd {
+		return nil, fmt.Errorf("unknown profile %q, expected one of dev, test, prod", profile)
+	}
+
+	v := viper.New()
+	v.SetConfigName(profile)
+	v.SetConfigType("yaml")
+	v.AddConfigPath(path)
+	v.SetEnvPrefix(envPrefix)
+	v.SetEnvKeyReplacer(strings.NewReplacer(".", "_"))
+	v.AutomaticEnv()
+	bindEnvs(v, reflect.TypeOf(Config{}), "")
+
+	if err := v.ReadInConfig(); err != nil {
+		return nil, fmt.Errorf("read %s config from %s: %w", profile, path, err)
+	}
+	var config Config
+	if err := v.Unmarshal(&config); err != nil {
+		return nil, fmt.Errorf("decode %s config: %w", profile, err)
+	}
+	config.Profile = profile
+	if err := config.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid %s config: %w", profile, err)
+	}
+	return &config, nil
+}
+
+// bindEnvs registers every key of the struct so that environment variables override keys missing from the file
+func bindEnvs(v *viper.Viper, t reflect.Type, prefix string) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		key := prefix + field.Tag.Get("mapstructure")
+		if field.Type.Kind() == reflect.Struct && field.Type != reflect.TypeOf(time.Duration(0)) {
+			bindEnvs(v, field.Type, key+".")
+			continue
+		}
+		_ = v.BindEnv(key)
+	}
+}
+
+func (c *Config) Validate() error {
+	var errs []error
+	require := func(value string, key string) {
+		if value == "" {
+			errs = append(errs, fmt.Errorf("%s is required (env %s)", key, envName(key)))
+		}
+	}
+
+	require(c.DB.Host, "db.host")
+	require(c.DB.Name, "db.name")
+	require(c.DB.Username, "db.username")
+	require(c.DB.Password, "db.password")
+	if c.DB.Port <= 0 || c.DB.Port > 65535 {
+		errs = append(errs, fmt.Errorf("db.port must be between 1 and 65535, got %d", c.DB.Port))
+	}
+	if c.Features.ReadReplicas {
+		if len(c.DB.Replica.Hosts) == 0 {
+			errs = append(errs, errors.New("db.replica.hosts is required when features.read_replicas is on"))
+		}
+		if c.DB.Replica.MaxLag <= 0 {
+			errs = append(errs, errors.New("db.replica.max_lag must be positive"))
+		}
+	}
+	require(c.Redis.Address, "redis.address")
+	require(c.Server.Address, "server.address")
+	switch c.Server.Mode {
+	case "debug", "release", "test":
+	default:
+		errs = append(errs, fmt.Errorf("server.mode must be one of debug, release, test, got %q", c.Server.Mode))
+	}
+	if c.Server.ShutdownTimeout <= 0 || c.Server.HealthTimeout <= 0 {
+		errs = append(errs, errors.New("server.shutdown_timeout and server.health_timeout must be positive"))
+	}
+	if c.Limits.MaxAmount < 0 {
+		errs = append(errs, fmt.Errorf("limits.max_amount must not be negative, got %d", c.Limits.MaxAmount))
+	}
+	if c.Limits.IdempotencyTTL <= 0 {
+		errs = append(errs, errors.New("limits.idempotency_ttl must be positive"))
+	}
+	if c.Features.Archival {
+		require(c.Archive.Path, "archive.path")
+		if c.Archive.Horizon <= 0 || c.Archive.Interval <= 0 {
+			errs = append(errs, errors.New("archive.horizon and archive.interval must be positive"))
+		}
+		require(c.Archive.Username, "archive.username")
+		if c.Profile == ProfileProd {
+			require(c.Archive.Password, "archive.password")
+		}
+	}
+	if c.Features.LedgerCheckpoints {
+		require(c.Ledger.SigningKey, "ledger.signing_key")
+		if c.Ledger.CheckpointInterval <= 0 {
+			errs = append(errs, errors.New("ledger.checkpoint_interval must be positive"))
+		}
+	}
+	if c.Features.GRPC {
+		require(c.GRPC.Address, "grpc.address")
+		require(c.GRPC.Token, "grpc.token")
+	}
+	if c.Payout.MaxLines <= 0 || c.Payout.ChunkSize <= 0 {
+		errs = append(errs, errors.New("payout.max_lines and payout.chunk_size must be positive"))
+	}
+	if c.Payout.Interval <= 0 || c.Payout.Lease <= 0 {
+		errs = append(errs, errors.New("payout.interval and payout.lease must be positive"))
+	}
+	if c.Scheduler.Interval <= 0 || c.Scheduler.Lease <= 0 || c.Scheduler.RetryBackoff <= 0 || c.Scheduler.MisfireWindow <= 0 {
+		errs = append(errs, errors.New("scheduler.interval, scheduler.lease, scheduler.retry_backoff and scheduler.misfire_window must be positive"))
+	}
+	if c.Scheduler.MaxAttempts <= 0 {
+		errs = append(errs, errors.New("scheduler.max_attempts must be positive"))
+	}
+	if c.PaymentRequest.DefaultExpiry <= 0 || c.PaymentRequest.Interval <= 0 || c.PaymentRequest.MaxExpiry < c.PaymentRequest.DefaultExpiry {
+		errs = append(errs, errors.New("payment_request.default_expiry and payment_request.interval must be positive and payment_request.max_expiry at least the default"))
+	}
+	if c.Hold.DefaultExpiry <= 0 || c.Hold.Interval <= 0 || c.Hold.MaxExpiry < c.Hold.DefaultExpiry {
+		errs = append(errs, errors.New("hold.default_expiry and hold.interval must be positive and hold.max_expiry at least the default"))
+	}
+	if c.Escrow.DefaultDeadline <= 0 || c.Escrow.Interval <= 0 || c.Escrow.MaxDeadline < c.Escrow.DefaultDeadline {
+		errs = append(errs, errors.New("escrow.default_deadline and escrow.interval must be positive and escrow.max_deadline at least the default"))
+	}
+	for i, rule := range c.Fees.Rules {
+		if err := rule.validate(); err != nil {
+			errs = append(errs, fmt.Errorf("fees.rules[%d]: %w", i, err))
+		}
+	}
+	if c.Interest.Interval <= 0 {
+		errs = append(errs, errors.New("interest.interval must be positive"))
+	}
+	if c.Features.Interest {
+		if err := c.Interest.validate(); err != nil {
+			errs = append(errs, fmt.Errorf("interest: %w", err))
+		}
+	}
+	require(c.Secrets.Backend, "secrets.backend")
+	switch c.Secrets.Backend {
+	case "file":
+		require(c.Secrets.Dir, "secrets.dir")
+	case "encrypted_file":
+		require(c.Secrets.File, "secrets.file")
+	}
+	if c.Secrets.CacheTTL <= 0 {
+		errs = append(errs, errors.New("secrets.cache_ttl must be positive"))
+	}
+	switch c.Tracing.Exporter {
+	case "none", "stdout":
+	case "file":
+		require(c.Tracing.File, "tracing.file")
+	case "otlp":
+		require(c.Tracing.Endpoint, "tracing.endpoint")
+	default:
+		errs = append(errs, fmt.Errorf("tracing.exporter must be one of none, stdout, file, otlp, got %q", c.Tracing.Exporter))
+	}
+	require(c.Tracing.ServiceName, "tracing.service_name")
+	if c.Tracing.SampleRatio < 0 || c.Tracing.SampleRatio > 1 {
+		errs = append(errs, fmt.Errorf("tracing.sample_ratio must be between 0 and 1, got %v", c.Tracing.SampleRatio))
+	}
+	if c.Log.Level != "" {
+		if _, err := zapcore.ParseLevel(c.Log.Level); err != nil {
+			errs = append(errs, fmt.Errorf("log.level must be one of debug, info, warn, error, dpanic, panic, fatal, got %q", c.Log.Level))
+		}
+	}
+	if c.Profile == ProfileProd && !c.Log.Production {
+		errs = append(errs, errors.New("log.production must be on in the prod profile"))
+	}
+	return errors.Join(errs...)
+}
+
+func envName(key string) string {
+	return envPrefix + "_" + strings.ToUpper(strings.ReplaceAll(key, ".", "_"))
+}
+
+func (c InterestConfig) validate() error {
+	switch c.DayCount {
+	case DayCountActual365, DayCountActual360, DayCountActualActual:
+	default:
+		return fmt.Errorf("day_count must be one of ACT/365, ACT/360, ACT/ACT, got %q", c.DayCount)
+	}
+	if len(c.Tiers) == 0 {
+		return errors.New("tiers are required")
+	}
+	upTo := 0
+	for i, tier := range c.Tiers {
+		if tier.RateBps < 0 {
+			return fmt.Errorf("tiers[%d].rate_bps must not be negative", i)
+		}
+		last := i == len(c.Tiers)-1
+		if !last && tier.UpTo <= upTo {
+			return fmt.Errorf("tiers[%d].up_to must be above the tier before", i)
+		}
+		if last && tier.UpTo != 0 {
+			return fmt.Errorf("tiers[%d] is the last tier and has no up_to", i)
+		}
+		upTo = tier.UpTo
+	}
+	return nil
+}
+
+func (r FeeRule) validate() error {
+	if r.Operation != "withdrawal" && r.Operation != "transfer" {
+		return fmt.Errorf("operation must be withdrawal or transfer, got %q", r.Operation)
+	}
+	if r.Min < 0 || r.Max < 0 || (r.Max > 0 && r.Min > r.Max) {
+		return errors.New("min and max must not be negative and max at least min")
+	}
+	switch r.Type {
+	case FeeFlat:
+		if r.Flat < 0 {
+			return errors.New("flat must not be negative")
+		}
+	case FeePercentage:
+		if r.RateBps < 0 || r.RateBps > 10000 {
+			return errors.New("rate_bps must be between 0 and 10000")
+		}
+	case FeeTiered:
+		if len(r.Tiers) == 0 {
+			return errors.New("tiers are required")
+		}
+		for i, tier := range r.Tiers {
+			last := i == len(r.Tiers)-1
+			if last != (tier.UpTo == 0) || (i > 0 && !last && tier.UpTo <= r.Tiers[i-1].UpTo) {
+				return errors.New("tiers must go up by up_to and only the last one has no up_to")
+			}
+			if tier.RateBps < 0 || tier.RateBps > 10000 || tier.Flat < 0 {
+				return errors.New("tier rate_bps must be between 0 and 10000 and flat not negative")
+			}
+		}
+	default:
+		return fmt.Errorf("type must be FLAT, PERCENTAGE or TIERED, got %q", r.Type)
+	}
+	return nil
+}
@@ -0,0 +1,75 @@
+package config
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestLoadProfile(t *testing.T) {
+	cfg, err := Load(ProfileDev, ".")
+	assert.NoError(t, err)
+	assert.Equal(t, ProfileDev, cfg.Profile)
+	assert.Equal(t, 5432, cfg.DB.Port)
+	assert.Equal(t, time.Hour, cfg.Limits.IdempotencyTTL)
+}
+
+func TestLoadProfileWithEnvOverride(t *testing.T) {
+	t.Setenv("WALLET_DB_HOST", "db.internal")
+	t.Setenv("WALLET_DB_REPLICA_HOSTS", "replica-1,replica-2")
+
+	cfg, err := Load(ProfileDev, ".")
+	assert.NoError(t, err)
+	assert.Equal(t, "db.internal", cfg.DB.Host)
+	assert.Equal(t, []string{"replica-1", "replica-2"}, cfg.DB.Replica.Hosts)
+}
+
+func TestLoadProfileFailedWithMissingSecrets(t *testing.T) {
+	_, err := Load(ProfileProd, ".")
+	assert.ErrorContains(t, err, "db.host is required (env WALLET_DB_HOST)")
+	assert.ErrorContains(t, err, "redis.address is required (env WALLET_REDIS_ADDRESS)")
+}
+
+func TestLoadProfileFailedWithUnknownProfile(t *testing.T) {
+	_, err := Load("staging", ".")
+	assert.Error(t, err)
+}
+
+func TestLoadProfileFailedWithUnknownTracingExporter(t *testing.T) {
+	t.Setenv("WALLET_TRACING_EXPORTER", "zipkin")
+	_, err := Load(ProfileDev, ".")
+	assert.ErrorContains(t, err, "tracing.exporter must be one of none, stdout, file, otlp")
+}
+
+func TestLoadProfileFailedWithUnknownLogLevel(t *testing.T) {
+	t.Setenv("WALLET_LOG_LEVEL", "verbose")
+	_, err := Load(ProfileDev, ".")
+	assert.ErrorContains(t, err, `log.level must be one of debug, info, warn, error, dpanic, panic, fatal, got "verbose"`)
+}
+
+func TestLoadProfileFailedWithArchivalWithoutArchiver(t *testing.T) {
+	t.Setenv("WALLET_FEATURES_ARCHIVAL", "true")
+	_, err := Load(ProfileDev, ".")
+	assert.ErrorContains(t, err, "archive.username is required (env WALLET_ARCHIVE_USERNAME)")
+
+	t.Setenv("WALLET_ARCHIVE_USERNAME", "wallet_archive")
+	_, err = Load(ProfileDev, ".")
+	assert.NoError(t, err)
+}
+
+func TestLoadFeeRules(t *testing.T) {
+	cfg, err := Load(ProfileDev, ".")
+	assert.NoError(t, err)
+	assert.Len(t, cfg.Fees.Rules, 3)
+	assert.Equal(t, FeeTiered, cfg.Fees.Rules[2].Type)
+	assert.Equal(t, 0, cfg.Fees.Rules[2].Tiers[2].UpTo)
+
+	cfg.Fees.Rules[2].Tiers[1].UpTo = 5000
+	assert.ErrorContains(t, cfg.Validate(), "fees.rules[2]: tiers must go up by up_to")
+}
+
+func TestLoadInterestFailedWithUnknownDayCount(t *testing.T) {
+	t.Setenv("WALLET_INTEREST_DAY_COUNT", "30/360")
+	_, err := Load(ProfileDev, ".")
+	assert.ErrorContains(t, err, "interest: day_count must be one of ACT/365, ACT/360, ACT/ACT")
+}
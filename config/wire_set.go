@@ -0,0 +1,7 @@
+package config
+
+import "github.com/google/wire"
+
+var (
+	WireSet = wire.NewSet(ProvideConfig)
+)
@@ -1,32 +1,88 @@
 package datastore
 
 import (
+	"context"
+	"errors"
 	"fmt"
-	"github.com/raychongtk/wallet/util"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/stdlib"
+	"github.com/raychongtk/wallet/config"
+	"github.com/raychongtk/wallet/metrics"
+	"github.com/raychongtk/wallet/secret"
+	"github.com/raychongtk/wallet/tracing"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
-	"log"
 )
 
-func ProvideDBConnection() gorm.DB {
-	return *gormConnection()
+func ProvideDBConnection(cfg *config.Config, secrets secret.Provider) (gorm.DB, error) {
+	db, err := gormConnection(cfg.DB.Host, cfg.DB, secrets)
+	if err != nil {
+		return gorm.DB{}, err
+	}
+	return *db, nil
+}
+
+// ArchiverDB is the connection the archiver records manifests and deletes archived ledger rows with
+type ArchiverDB struct {
+	gorm.DB
 }
 
-func gormConnection() *gorm.DB {
-	var config *DBConfig
-	util.Load("dev", ".", &config)
-	dsn := fmt.Sprintf("host=%s user=%s password=%s dbname=postgres port=5432 sslmode=disable TimeZone=Asia/Shanghai", config.DBHost, config.DBUsername, config.DBPassword)
-	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{})
+// ProvideArchiverDB logs in as archive.username when archival is on. The application connection may not archive, so
+// without the archiver's login it fails. The archiver runs one transaction at a time, so one connection is enough.
+func ProvideArchiverDB(db gorm.DB, cfg *config.Config, secrets secret.Provider) (ArchiverDB, error) {
+	if !cfg.Features.Archival {
+		return ArchiverDB{db}, nil
+	}
+	if cfg.Archive.Username == "" {
+		return ArchiverDB{}, errors.New("archival needs archive.username to log in as a member of wallet_archiver")
+	}
+	archiverConfig := cfg.DB
+	archiverConfig.Username, archiverConfig.Password = cfg.Archive.Username, cfg.Archive.Password
+	archiverConfig.MaxOpenConns, archiverConfig.MaxIdleConns = 1, 1
+	archiverDB, err := gormConnection(cfg.DB.Host, archiverConfig, secrets)
+	if err != nil {
+		return ArchiverDB{}, err
+	}
+	return ArchiverDB{*archiverDB}, nil
+}
 
+// gormConnection resolves the password every time the pool dials so that a rotated credential is picked up by
+// new connections. Old connections are recycled after conn_max_lifetime.
+func gormConnection(host string, dbConfig config.DBConfig, secrets secret.Provider) (*gorm.DB, error) {
+	if _, err := secret.Resolve(context.Background(), secrets, dbConfig.Password); err != nil {
+		return nil, err
+	}
+	connConfig, err := pgx.ParseConfig(dsn(host, dbConfig))
 	if err != nil {
-		log.Fatalln(err)
+		return nil, err
 	}
+	sqlDB := stdlib.OpenDB(*connConfig, stdlib.OptionBeforeConnect(func(ctx context.Context, connConfig *pgx.ConnConfig) error {
+		password, err := secret.Resolve(ctx, secrets, dbConfig.Password)
+		if err != nil {
+			return err
+		}
+		connConfig.Password = password
+		return nil
+	}))
+	sqlDB.SetMaxOpenConns(dbConfig.MaxOpenConns)
+	sqlDB.SetMaxIdleConns(dbConfig.MaxIdleConns)
+	sqlDB.SetConnMaxLifetime(dbConfig.ConnMaxLifetime)
 
-	return db
+	db, err := gorm.Open(postgres.New(postgres.Config{Conn: sqlDB}), &gorm.Config{})
+	if err != nil {
+		return nil, fmt.Errorf("connect to postgres at %s: %w", host, err)
+	}
+	if err := db.Use(metrics.GormPlugin{}); err != nil {
+		return nil, fmt.Errorf("register metrics plugin: %w", err)
+	}
+	if err := db.Use(tracing.GormPlugin{}); err != nil {
+		return nil, fmt.Errorf("register tracing plugin: %w", err)
+	}
+	return db, nil
 }
 
-type DBConfig struct {
-	DBHost     string `mapstructure:"DB_HOST"`
-	DBUsername string `mapstructure:"DB_USERNAME"`
-	DBPassword string `mapstructure:"DB_PASSWORD"`
+// dsn leaves the password out, it is resolved when a connection is opened
+func dsn(host string, dbConfig config.DBConfig) string {
+	return fmt.Sprintf("host=%s user=%s dbname=%s port=%d sslmode=%s TimeZone=%s",
+		host, dbConfig.Username, dbConfig.Name, dbConfig.Port, dbConfig.SSLMode, dbConfig.TimeZone)
 }
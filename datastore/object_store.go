@@ -0,0 +1,71 @@
+package datastore
+
+import (
+	"context"
+	"errors"
+	"github.com/raychongtk/wallet/config"
+	"os"
+	"path/filepath"
+)
+
+var ErrObjectExists = errors.New("object already exists")
+
+// ObjectStore keeps archived ledger files. Objects are write-once: Put must fail with ErrObjectExists instead of
+// replacing an existing object so that archived history can never be rewritten.
+type ObjectStore interface {
+	Put(ctx context.Context, key string, data []byte) error
+	Get(ctx context.Context, key string) ([]byte, error)
+}
+
+type LocalObjectStore struct {
+	root string
+}
+
+func ProvideObjectStore(cfg *config.Config) (ObjectStore, error) {
+	return NewLocalObjectStore(cfg.Archive.Path)
+}
+
+func NewLocalObjectStore(root string) (*LocalObjectStore, error) {
+	if root == "" {
+		return nil, errors.New("archive path is empty")
+	}
+	if err := os.MkdirAll(root, 0o755); err != nil {
+		return nil, err
+	}
+	return &LocalObjectStore{root: root}, nil
+}
+
+func (s *LocalObjectStore) Put(_ context.Context, key string, data []byte) error {
+	path := filepath.Join(s.root, filepath.FromSlash(key))
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".upload-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	// link fails when the target exists, which gives us write-once semantics without a race
+	if err := os.Link(tmp.Name(), path); err != nil {
+		if errors.Is(err, os.ErrExist) {
+			return ErrObjectExists
+		}
+		return err
+	}
+	return os.Chmod(path, 0o444)
+}
+
+func (s *LocalObjectStore) Get(_ context.Context, key string) ([]byte, error) {
+	return os.ReadFile(filepath.Join(s.root, filepath.FromSlash(key)))
+}
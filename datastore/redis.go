@@ -2,37 +2,49 @@ package datastore
 
 import (
 	"context"
+	"fmt"
 	"github.com/go-redis/redis/v8"
-	"github.com/raychongtk/wallet/util"
+	"github.com/raychongtk/wallet/config"
+	"github.com/raychongtk/wallet/secret"
+	"github.com/raychongtk/wallet/tracing"
 	"log"
 )
 
-func ProvideRedis() redis.Client {
-	return *redisConnection()
+func ProvideRedis(cfg *config.Config, secrets secret.Provider) (redis.Client, error) {
+	client, err := redisConnection(cfg.Redis, secrets)
+	if err != nil {
+		return redis.Client{}, err
+	}
+	return *client, nil
 }
 
-func redisConnection() *redis.Client {
-	var config *RedisConfig
-	util.Load("dev", ".", &config)
-
+// redisConnection authenticates in OnConnect instead of setting Options.Password so that every new connection
+// uses the current password and rotation needs no restart
+func redisConnection(redisConfig config.RedisConfig, secrets secret.Provider) (*redis.Client, error) {
 	redisClient := redis.NewClient(&redis.Options{
-		Addr:     config.RedisHost,
-		Password: config.RedisPassword,
-		DB:       0,
+		Addr:       redisConfig.Address,
+		DB:         redisConfig.DB,
+		MaxConnAge: redisConfig.ConnMaxLifetime,
+		OnConnect: func(ctx context.Context, cn *redis.Conn) error {
+			password, err := secret.Resolve(ctx, secrets, redisConfig.Password)
+			if err != nil {
+				return err
+			}
+			if password == "" {
+				return nil
+			}
+			return cn.Auth(ctx, password).Err()
+		},
 	})
+	redisClient.AddHook(tracing.RedisHook{})
 
 	// Test connection
 	_, err := redisClient.Ping(context.Background()).Result()
 	if err != nil {
-		log.Fatalf("Failed to connect to Redis: %v", err)
+		return nil, fmt.Errorf("connect to redis at %s: %w", redisConfig.Address, err)
 	}
 
 	log.Println("Connected to Redis")
 
-	return redisClient
-}
-
-type RedisConfig struct {
-	RedisHost     string `mapstructure:"REDIS_HOST"`
-	RedisPassword string `mapstructure:"REDIS_PASSWORD"`
+	return redisClient, nil
 }
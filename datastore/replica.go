@@ -0,0 +1,168 @@
+package datastore
+
+import (
+	"context"
+	"errors"
+	"github.com/go-redis/redis/v8"
+	"github.com/raychongtk/wallet/config"
+	"github.com/raychongtk/wallet/secret"
+	"github.com/raychongtk/wallet/util"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"sync/atomic"
+	"time"
+)
+
+// replicaLagQuery reports how far a standby is behind in seconds. A standby that has replayed everything it
+// received is not lagging even if the primary has been idle for a while.
+const replicaLagQuery = `SELECT COALESCE(CASE
+    WHEN NOT pg_is_in_recovery() OR pg_last_wal_receive_lsn() = pg_last_wal_replay_lsn() THEN 0
+    ELSE EXTRACT(EPOCH FROM now() - pg_last_xact_replay_timestamp())
+END, 0)`
+
+const stickyKeyPrefix = "replica-sticky:"
+
+// ReplicaRouter decides which connection serves a read-only query. Reads go to a replica unless the caller
+// wrote recently (read-your-writes) or every replica lags behind the configured limit, in which case the
+// primary serves the read.
+type ReplicaRouter struct {
+	primary     *gorm.DB
+	replicas    []*replica
+	memoryStore *redis.Client
+	config      config.ReplicaConfig
+	next        atomic.Uint32
+}
+
+type replica struct {
+	db *gorm.DB
+	// lag in milliseconds, negative until the first successful check
+	lag atomic.Int64
+}
+
+func ProvideReplicaRouter(db gorm.DB, memoryStore redis.Client, cfg *config.Config, secrets secret.Provider) (*ReplicaRouter, error) {
+	var replicas []*gorm.DB
+	if cfg.Features.ReadReplicas {
+		for _, host := range cfg.DB.Replica.Hosts {
+			replicaDB, err := gormConnection(host, cfg.DB, secrets)
+			if err != nil {
+				return nil, err
+			}
+			replicas = append(replicas, replicaDB)
+		}
+	}
+	return NewReplicaRouter(&db, replicas, &memoryStore, cfg.DB.Replica), nil
+}
+
+func NewReplicaRouter(primary *gorm.DB, replicaDBs []*gorm.DB, memoryStore *redis.Client, replicaConfig config.ReplicaConfig) *ReplicaRouter {
+	router := &ReplicaRouter{
+		primary:     primary,
+		memoryStore: memoryStore,
+		config:      replicaConfig,
+	}
+	for _, replicaDB := range replicaDBs {
+		r := &replica{db: replicaDB}
+		r.lag.Store(-1)
+		router.replicas = append(router.replicas, r)
+	}
+	return router
+}
+
+func (r *ReplicaRouter) Primary() *gorm.DB {
+	return r.primary
+}
+
+// Reader returns the connection for a read-only query. keys identify whose data is read, e.g. a user id or a
+// wallet id, and are matched against recent writes for read-your-writes stickiness.
+func (r *ReplicaRouter) Reader(keys ...string) *gorm.DB {
+	if len(r.replicas) == 0 || r.isSticky(keys) {
+		return r.primary
+	}
+	maxLag := r.config.MaxLag.Milliseconds()
+	for range r.replicas {
+		candidate := r.replicas[int(r.next.Add(1))%len(r.replicas)]
+		lag := candidate.lag.Load()
+		if lag >= 0 && lag <= maxLag {
+			return candidate.db
+		}
+	}
+	return r.primary
+}
+
+// MarkWrite pins reads of the given keys to the primary for the stickiness window
+func (r *ReplicaRouter) MarkWrite(keys ...string) {
+	if len(r.replicas) == 0 || r.config.Stickiness <= 0 {
+		return
+	}
+	pipe := r.memoryStore.Pipeline()
+	for _, key := range keys {
+		pipe.Set(context.Background(), stickyKeyPrefix+key, "true", r.config.Stickiness)
+	}
+	if _, err := pipe.Exec(context.Background()); err != nil {
+		util.Warn("Mark replica stickiness failed", zap.Error(err))
+	}
+}
+
+func (r *ReplicaRouter) isSticky(keys []string) bool {
+	if len(keys) == 0 || r.config.Stickiness <= 0 {
+		return false
+	}
+	stickyKeys := make([]string, len(keys))
+	for i, key := range keys {
+		stickyKeys[i] = stickyKeyPrefix + key
+	}
+	exists, err := r.memoryStore.Exists(context.Background(), stickyKeys...).Result()
+	if err != nil {
+		// we cannot tell whether the caller wrote recently, so stay consistent
+		return true
+	}
+	return exists > 0
+}
+
+// Start measures replica lag until the context is cancelled
+func (r *ReplicaRouter) Start(ctx context.Context) {
+	if len(r.replicas) == 0 {
+		return
+	}
+	interval := r.config.LagCheckInterval
+	if interval <= 0 {
+		interval = 5 * time.Second
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		r.CheckLag(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Health fails when replicas are configured but none of them can serve reads, which means the primary takes
+// every read
+func (r *ReplicaRouter) Health() error {
+	if len(r.replicas) == 0 {
+		return nil
+	}
+	maxLag := r.config.MaxLag.Milliseconds()
+	for _, candidate := range r.replicas {
+		lag := candidate.lag.Load()
+		if lag >= 0 && lag <= maxLag {
+			return nil
+		}
+	}
+	return errors.New("no replica within max lag, reads are served by the primary")
+}
+
+func (r *ReplicaRouter) CheckLag(ctx context.Context) {
+	for i, candidate := range r.replicas {
+		var lagSeconds float64
+		if err := candidate.db.WithContext(ctx).Raw(replicaLagQuery).Scan(&lagSeconds).Error; err != nil {
+			util.Warn("Check replica lag failed", zap.Int("replica", i), zap.Error(err))
+			candidate.lag.Store(-1)
+			continue
+		}
+		candidate.lag.Store(int64(lagSeconds * 1000))
+	}
+}
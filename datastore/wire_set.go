@@ -3,5 +3,5 @@ package datastore
 import "github.com/google/wire"
 
 var (
-	WireSet = wire.NewSet(ProvideDBConnection, ProvideRedis)
+	WireSet = wire.NewSet(ProvideDBConnection, ProvideArchiverDB, ProvideRedis, ProvideObjectStore, ProvideReplicaRouter)
 )
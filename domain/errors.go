@@ -0,0 +1,122 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// Kind groups errors that callers handle the same way, whatever the transport
+type Kind string
+
+const (
+	KindInvalid           Kind = "invalid"
+	KindNotFound          Kind = "not_found"
+	KindInsufficientFunds Kind = "insufficient_funds"
+	KindWalletFrozen      Kind = "wallet_frozen"
+	KindForbidden         Kind = "forbidden"
+	KindLimitExceeded     Kind = "limit_exceeded"
+	KindConflict          Kind = "conflict"
+	KindUnavailable       Kind = "unavailable"
+	KindInternal          Kind = "internal"
+)
+
+// Error is a typed domain error. Code is stable and meant for clients, Message is for humans and Retryable tells
+// whether the same request may succeed later unchanged.
+type Error struct {
+	Kind      Kind
+	Code      string
+	Message   string
+	Retryable bool
+	Err       error
+}
+
+var (
+	ErrInvalidParameters      = &Error{Kind: KindInvalid, Code: "INVALID_PARAMETERS", Message: "request parameters are invalid"}
+	ErrInvalidAccount         = &Error{Kind: KindInvalid, Code: "INVALID_ACCOUNT", Message: "account does not exist or cannot be used"}
+	ErrCannotTransferToSelf   = &Error{Kind: KindInvalid, Code: "CANNOT_TRANSFER_TO_SELF", Message: "payer and payee must be different users"}
+	ErrMissingRequestID       = &Error{Kind: KindInvalid, Code: "MISSING_REQUEST_ID", Message: "X-Request-ID header is required"}
+	ErrNotFound               = &Error{Kind: KindNotFound, Code: "NOT_FOUND", Message: "resource not found"}
+	ErrInsufficientFunds      = &Error{Kind: KindInsufficientFunds, Code: "INSUFFICIENT_FUNDS", Message: "wallet balance is not enough"}
+	ErrWalletFrozen           = &Error{Kind: KindWalletFrozen, Code: "WALLET_FROZEN", Message: "wallet is frozen"}
+	ErrLimitExceeded          = &Error{Kind: KindLimitExceeded, Code: "LIMIT_EXCEEDED", Message: "amount is above the single payment limit"}
+	ErrSpendingLimit          = &Error{Kind: KindLimitExceeded, Code: "SPENDING_LIMIT_EXCEEDED", Message: "amount is above the spending limit of the member"}
+	ErrForbidden              = &Error{Kind: KindForbidden, Code: "FORBIDDEN", Message: "the role of the member does not allow this"}
+	ErrDuplicateRequest       = &Error{Kind: KindConflict, Code: "DUPLICATE_REQUEST", Message: "request id was used already"}
+	ErrConflict               = &Error{Kind: KindConflict, Code: "CONFLICT", Message: "concurrent update, retry the request", Retryable: true}
+	ErrPaymentRequestClosed   = &Error{Kind: KindConflict, Code: "PAYMENT_REQUEST_CLOSED", Message: "payment request is not pending any more"}
+	ErrHoldClosed             = &Error{Kind: KindConflict, Code: "HOLD_CLOSED", Message: "hold is not active any more"}
+	ErrEscrowClosed           = &Error{Kind: KindConflict, Code: "ESCROW_CLOSED", Message: "escrow is settled already"}
+	ErrPocketLocked           = &Error{Kind: KindConflict, Code: "POCKET_LOCKED", Message: "pocket is locked until its target date"}
+	ErrSharedTransferClosed   = &Error{Kind: KindConflict, Code: "SHARED_TRANSFER_CLOSED", Message: "shared transfer is not pending any more"}
+	ErrBusinessTransferClosed = &Error{Kind: KindConflict, Code: "BUSINESS_TRANSFER_CLOSED", Message: "business transfer is not pending any more"}
+	ErrUnavailable            = &Error{Kind: KindUnavailable, Code: "SERVICE_UNAVAILABLE", Message: "a dependency is unavailable, retry later", Retryable: true}
+	ErrInternal               = &Error{Kind: KindInternal, Code: "INTERNAL_ERROR", Message: "internal error"}
+)
+
+func (e *Error) Error() string {
+	if e.Err != nil {
+		return fmt.Sprintf("%s: %s: %v", e.Code, e.Message, e.Err)
+	}
+	return e.Code + ": " + e.Message
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// Is matches errors of the same code, so a wrapped copy still matches the catalog entry it came from
+func (e *Error) Is(target error) bool {
+	t, ok := target.(*Error)
+	return ok && t.Code == e.Code
+}
+
+// Wrap returns a copy of the catalog entry caused by err
+func (e *Error) Wrap(err error) *Error {
+	wrapped := *e
+	wrapped.Err = err
+	return &wrapped
+}
+
+// WithMessage returns a copy of the catalog entry with a more specific message
+func (e *Error) WithMessage(format string, args ...interface{}) *Error {
+	wrapped := *e
+	wrapped.Message = fmt.Sprintf(format, args...)
+	return &wrapped
+}
+
+// From finds the domain error in the chain of err. Timeouts and cancellations are unavailable and anything else is
+// internal, so every error has a kind.
+func From(err error) *Error {
+	if err == nil {
+		return nil
+	}
+	var domainErr *Error
+	if errors.As(err, &domainErr) {
+		return domainErr
+	}
+	if errors.Is(err, context.DeadlineExceeded) || errors.Is(err, context.Canceled) {
+		return ErrUnavailable.Wrap(err)
+	}
+	return ErrInternal.Wrap(err)
+}
+
+// IsRetryable tells whether the same request may succeed later
+func IsRetryable(err error) bool {
+	return From(err).Retryable
+}
+
+// NotFound names the resource in a not found error, so a user it does not exist for learns nothing more. Other errors
+// are returned as they are.
+func NotFound(err error, format string, args ...interface{}) error {
+	if errors.Is(err, ErrNotFound) {
+		return ErrNotFound.WithMessage(format, args...)
+	}
+	return err
+}
+
+// IsRefusal tells whether err turned a request down for good, so the same request fails the same way when retried
+func IsRefusal(err error) bool {
+	domainErr := From(err)
+	return domainErr != nil && !domainErr.Retryable && domainErr.Kind != KindInternal
+}
@@ -0,0 +1,35 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestWrappedErrorMatchesCatalog(t *testing.T) {
+	cause := errors.New("balance row locked")
+	err := fmt.Errorf("deduct balance: %w", ErrConflict.Wrap(cause))
+
+	assert.ErrorIs(t, err, ErrConflict)
+	assert.ErrorIs(t, err, cause)
+	assert.NotErrorIs(t, err, ErrInsufficientFunds)
+	assert.True(t, IsRetryable(err))
+	assert.Equal(t, "CONFLICT", From(err).Code)
+}
+
+func TestFromClassifiesUntypedErrors(t *testing.T) {
+	assert.Nil(t, From(nil))
+	assert.Equal(t, KindUnavailable, From(context.DeadlineExceeded).Kind)
+	assert.Equal(t, KindInternal, From(errors.New("boom")).Kind)
+	assert.False(t, IsRetryable(ErrInsufficientFunds))
+	assert.True(t, IsRefusal(fmt.Errorf("transfer: %w", ErrInsufficientFunds)))
+	assert.False(t, IsRefusal(ErrConflict))
+	assert.False(t, IsRefusal(errors.New("boom")))
+	assert.False(t, IsRefusal(nil))
+	assert.Equal(t, "account 1 not found", From(NotFound(ErrNotFound.Wrap(errors.New("record not found")), "account %d not found", 1)).Message)
+	assert.Equal(t, ErrConflict, NotFound(ErrConflict, "account not found"))
+	assert.Equal(t, "wallet 1 is frozen", ErrWalletFrozen.WithMessage("wallet %d is frozen", 1).Message)
+	assert.Equal(t, "wallet is frozen", ErrWalletFrozen.Message)
+}
@@ -0,0 +1,171 @@
+package escrow
+
+import (
+	"context"
+	"errors"
+	"github.com/google/uuid"
+	"github.com/google/wire"
+	"github.com/raychongtk/wallet/config"
+	"github.com/raychongtk/wallet/domain"
+	"github.com/raychongtk/wallet/ledger"
+	"github.com/raychongtk/wallet/model/payment"
+	"github.com/raychongtk/wallet/repository"
+	"github.com/raychongtk/wallet/util"
+	"go.uber.org/zap"
+	"strings"
+	"sync"
+	"time"
+)
+
+var (
+	WireSet = wire.NewSet(ProvideManager)
+)
+
+const (
+	maxReferenceLength = 200
+	// settleBatchSize is how many escrows past their deadline one sweep settles at most
+	settleBatchSize = 100
+)
+
+// Manager funds escrows with the configured deadline and settles the ones still funded at their deadline with their
+// deadline action. The money is moved by the ledger, which settles an escrow under a lock on it.
+type Manager struct {
+	ledger     *ledger.Ledger
+	escrowRepo repository.EscrowRepository
+	config     config.EscrowConfig
+	mu         sync.Mutex
+	lastErr    error
+}
+
+func ProvideManager(ledger *ledger.Ledger, escrowRepo repository.EscrowRepository, cfg *config.Config) *Manager {
+	return &Manager{ledger: ledger, escrowRepo: escrowRepo, config: cfg.Escrow}
+}
+
+// CreateCommand funds an escrow of Amount, in minor units, from BuyerUserID for SellerUserID. A zero DeadlineIn takes
+// escrow.default_deadline and an empty DeadlineAction refunds the buyer.
+type CreateCommand struct {
+	BuyerUserID    uuid.UUID
+	SellerUserID   uuid.UUID
+	Amount         int
+	Reference      string
+	DeadlineIn     time.Duration
+	DeadlineAction string
+	RequestID      string
+}
+
+func (m *Manager) Create(ctx context.Context, cmd CreateCommand) (*payment.Escrow, error) {
+	reference := strings.TrimSpace(cmd.Reference)
+	if len(reference) > maxReferenceLength {
+		return nil, domain.ErrInvalidParameters.WithMessage("reference is at most %d characters", maxReferenceLength)
+	}
+	deadlineIn := cmd.DeadlineIn
+	if deadlineIn == 0 {
+		deadlineIn = m.config.DefaultDeadline
+	}
+	if deadlineIn < 0 || deadlineIn > m.config.MaxDeadline {
+		return nil, domain.ErrInvalidParameters.WithMessage("an escrow deadline is within %s", m.config.MaxDeadline)
+	}
+	action := cmd.DeadlineAction
+	switch action {
+	case "":
+		action = payment.EscrowActionRefund
+	case payment.EscrowActionRelease, payment.EscrowActionRefund:
+	default:
+		return nil, domain.ErrInvalidParameters.WithMessage("deadline_action is RELEASE or REFUND")
+	}
+	return m.ledger.CreateEscrow(ctx, ledger.CreateEscrowCommand{
+		BuyerUserID:    cmd.BuyerUserID,
+		SellerUserID:   cmd.SellerUserID,
+		Amount:         cmd.Amount,
+		Reference:      reference,
+		Deadline:       time.Now().Add(deadlineIn),
+		DeadlineAction: action,
+		RequestID:      cmd.RequestID,
+	})
+}
+
+// Release pays the whole escrow to the seller
+func (m *Manager) Release(ctx context.Context, id uuid.UUID) (*payment.Escrow, error) {
+	escrow, err := m.escrowRepo.GetEscrow(id)
+	if err != nil {
+		return nil, err
+	}
+	return m.ledger.SettleEscrow(ctx, ledger.SettleEscrowCommand{EscrowID: id, SellerAmount: escrow.Amount})
+}
+
+// Refund gives the whole escrow back to the buyer
+func (m *Manager) Refund(ctx context.Context, id uuid.UUID) (*payment.Escrow, error) {
+	return m.ledger.SettleEscrow(ctx, ledger.SettleEscrowCommand{EscrowID: id})
+}
+
+// Split pays sellerAmount to the seller and refunds the rest, both sides get something
+func (m *Manager) Split(ctx context.Context, id uuid.UUID, sellerAmount int) (*payment.Escrow, error) {
+	escrow, err := m.escrowRepo.GetEscrow(id)
+	if err != nil {
+		return nil, err
+	}
+	if sellerAmount <= 0 || sellerAmount >= escrow.Amount {
+		return nil, domain.ErrInvalidParameters.WithMessage("a split pays the seller more than 0 and less than the escrow amount")
+	}
+	return m.ledger.SettleEscrow(ctx, ledger.SettleEscrowCommand{EscrowID: id, SellerAmount: sellerAmount})
+}
+
+// Start settles escrows past their deadline every escrow.interval until the context is cancelled
+func (m *Manager) Start(ctx context.Context) {
+	ticker := time.NewTicker(m.config.Interval)
+	defer ticker.Stop()
+	for {
+		err := m.SettleDue(ctx)
+		if err != nil && ctx.Err() == nil {
+			util.Error("Settle due escrows failed", zap.Error(err))
+		}
+		m.mu.Lock()
+		m.lastErr = err
+		m.mu.Unlock()
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Health reports the error of the last deadline sweep
+func (m *Manager) Health() error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.lastErr
+}
+
+// SettleDue applies the deadline action of the funded escrows whose deadline has passed. An escrow settled meanwhile
+// is left alone. One that cannot be settled, say the seller is frozen, does not hold up the rest of the batch, the
+// sweep stops after it and tries again next time.
+func (m *Manager) SettleDue(ctx context.Context) error {
+	for ctx.Err() == nil {
+		escrows, err := m.escrowRepo.SearchDueEscrows(time.Now(), settleBatchSize)
+		if err != nil || len(escrows) == 0 {
+			return err
+		}
+		var failed error
+		for _, escrow := range escrows {
+			sellerAmount := 0
+			if escrow.DeadlineAction == payment.EscrowActionRelease {
+				sellerAmount = escrow.Amount
+			}
+			_, err := m.ledger.SettleEscrow(ctx, ledger.SettleEscrowCommand{EscrowID: escrow.ID, SellerAmount: sellerAmount})
+			if errors.Is(err, domain.ErrEscrowClosed) {
+				continue
+			}
+			if err != nil {
+				util.Error("Settle escrow at deadline failed", zap.String("escrow_id", escrow.ID.String()), zap.Error(err))
+				failed = err
+				continue
+			}
+			util.Info("Settle escrow at deadline successfully", zap.String("escrow_id", escrow.ID.String()), zap.String("action", escrow.DeadlineAction))
+		}
+		if failed != nil {
+			return failed
+		}
+	}
+	return ctx.Err()
+}
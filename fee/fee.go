@@ -0,0 +1,60 @@
+package fee
+
+import (
+	"github.com/raychongtk/wallet/config"
+)
+
+// Schedule prices payments with the configured fee rules
+type Schedule struct {
+	rules []config.FeeRule
+}
+
+func NewSchedule(cfg config.FeeConfig) *Schedule {
+	return &Schedule{rules: cfg.Rules}
+}
+
+// Fee is the fee in minor units of a payment of amount, zero when no rule matches. The first matching rule wins, so
+// rules for a tier or a currency come before the general ones.
+func (s *Schedule) Fee(operation string, currency string, tier string, amount int) int {
+	for _, rule := range s.rules {
+		if rule.Operation != operation || !matches(rule.Currency, currency) || !matches(rule.Tier, tier) {
+			continue
+		}
+		return charge(rule, amount)
+	}
+	return 0
+}
+
+func matches(ruleValue string, value string) bool {
+	return ruleValue == "" || ruleValue == value
+}
+
+func charge(rule config.FeeRule, amount int) int {
+	var fee int
+	switch rule.Type {
+	case config.FeeFlat:
+		fee = rule.Flat
+	case config.FeePercentage:
+		fee = percentage(amount, rule.RateBps)
+	case config.FeeTiered:
+		for _, tier := range rule.Tiers {
+			if tier.UpTo == 0 || amount <= tier.UpTo {
+				fee = percentage(amount, tier.RateBps) + tier.Flat
+				break
+			}
+		}
+	}
+	if fee < rule.Min {
+		fee = rule.Min
+	}
+	if rule.Max > 0 && fee > rule.Max {
+		fee = rule.Max
+	}
+	return fee
+}
+
+// percentage is rateBps basis points of amount, half a minor unit is rounded up so the same payment always costs the
+// same
+func percentage(amount int, rateBps int) int {
+	return int((int64(amount)*int64(rateBps) + 5000) / 10000)
+}
@@ -0,0 +1,31 @@
+package fee
+
+import (
+	"github.com/raychongtk/wallet/config"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestFee(t *testing.T) {
+	schedule := NewSchedule(config.FeeConfig{Rules: []config.FeeRule{
+		{Operation: "withdrawal", Type: config.FeeFlat, Flat: 150},
+		{Operation: "transfer", Tier: "PREMIUM", Type: config.FeeFlat},
+		{Operation: "transfer", Currency: "USD", Type: config.FeeTiered, Max: 500, Tiers: []config.FeeTier{
+			{UpTo: 10000},
+			{UpTo: 100000, RateBps: 50, Flat: 10},
+			{RateBps: 25},
+		}},
+		{Operation: "transfer", Type: config.FeePercentage, RateBps: 100, Min: 50},
+	}})
+
+	assert.Equal(t, 150, schedule.Fee("withdrawal", "USD", "STANDARD", 1))
+	assert.Equal(t, 0, schedule.Fee("transfer", "USD", "PREMIUM", 50000))
+	// the tier the amount falls in prices all of it
+	assert.Equal(t, 0, schedule.Fee("transfer", "USD", "STANDARD", 10000))
+	assert.Equal(t, 61, schedule.Fee("transfer", "USD", "STANDARD", 10100))
+	assert.Equal(t, 500, schedule.Fee("transfer", "USD", "STANDARD", 1000000))
+	// other currencies fall through to the percentage, half a cent is rounded up and the minimum applies
+	assert.Equal(t, 50, schedule.Fee("transfer", "HKD", "STANDARD", 1250))
+	assert.Equal(t, 51, schedule.Fee("transfer", "HKD", "STANDARD", 5050))
+	assert.Equal(t, 0, schedule.Fee("deposit", "USD", "STANDARD", 10000))
+}
@@ -0,0 +1,106 @@
+package health
+
+import (
+	"context"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	StatusUp       = "up"
+	StatusDown     = "down"
+	StatusDraining = "draining"
+)
+
+// Check probes one dependency. A failing critical check makes the instance unready, a failing non-critical
+// check is only reported.
+type Check struct {
+	Name     string
+	Critical bool
+	Probe    func(ctx context.Context) error
+}
+
+type Checker struct {
+	checks   []Check
+	timeout  time.Duration
+	draining atomic.Bool
+}
+
+func NewChecker(timeout time.Duration, checks ...Check) *Checker {
+	return &Checker{checks: checks, timeout: timeout}
+}
+
+// Drain makes readiness fail so that the orchestrator stops routing traffic before the server shuts down
+func (c *Checker) Drain() {
+	c.draining.Store(true)
+}
+
+// Liveness only tells that the process is serving requests, dependencies are not probed
+func (c *Checker) Liveness(ctx *gin.Context) {
+	ctx.JSON(http.StatusOK, &LivenessResponse{Status: StatusUp})
+}
+
+func (c *Checker) Readiness(ctx *gin.Context) {
+	response := c.Check(ctx.Request.Context())
+	if response.Status != StatusUp {
+		ctx.JSON(http.StatusServiceUnavailable, response)
+		return
+	}
+	ctx.JSON(http.StatusOK, response)
+}
+
+// Check runs every probe concurrently, each bounded by the checker timeout
+func (c *Checker) Check(ctx context.Context) *ReadinessResponse {
+	results := make(map[string]CheckResult, len(c.checks))
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for _, check := range c.checks {
+		wg.Add(1)
+		go func(check Check) {
+			defer wg.Done()
+			probeCtx, cancel := context.WithTimeout(ctx, c.timeout)
+			defer cancel()
+			start := time.Now()
+			err := check.Probe(probeCtx)
+			result := CheckResult{Status: StatusUp, Critical: check.Critical, LatencyMs: time.Since(start).Milliseconds()}
+			if err != nil {
+				result.Status = StatusDown
+				result.Error = err.Error()
+			}
+			mu.Lock()
+			results[check.Name] = result
+			mu.Unlock()
+		}(check)
+	}
+	wg.Wait()
+
+	status := StatusUp
+	for _, result := range results {
+		if result.Critical && result.Status != StatusUp {
+			status = StatusDown
+		}
+	}
+	if c.draining.Load() {
+		status = StatusDraining
+	}
+	return &ReadinessResponse{Status: status, Checks: results}
+}
+
+type LivenessResponse struct {
+	Status string `json:"status"`
+}
+
+type ReadinessResponse struct {
+	Status string                 `json:"status"`
+	Checks map[string]CheckResult `json:"checks"`
+}
+
+type CheckResult struct {
+	Status    string `json:"status"`
+	Critical  bool   `json:"critical"`
+	LatencyMs int64  `json:"latency_ms"`
+	Error     string `json:"error,omitempty"`
+}
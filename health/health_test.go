@@ -0,0 +1,70 @@
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestReadiness(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	checker := NewChecker(time.Second,
+		Check{Name: "postgres", Critical: true, Probe: func(ctx context.Context) error { return nil }},
+		Check{Name: "read_replica", Critical: false, Probe: func(ctx context.Context) error { return errors.New("lagging") }},
+	)
+	router := gin.New()
+	router.GET("/readyz", checker.Readiness)
+
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+
+	assert.Equal(t, http.StatusOK, resp.Code)
+	var response ReadinessResponse
+	assert.NoError(t, json.Unmarshal(resp.Body.Bytes(), &response))
+	assert.Equal(t, StatusUp, response.Status)
+	assert.Equal(t, StatusDown, response.Checks["read_replica"].Status)
+	assert.Equal(t, "lagging", response.Checks["read_replica"].Error)
+}
+
+func TestReadinessFailedWithCriticalDependency(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	checker := NewChecker(10*time.Millisecond,
+		Check{Name: "postgres", Critical: true, Probe: func(ctx context.Context) error {
+			<-ctx.Done()
+			return ctx.Err()
+		}},
+	)
+	router := gin.New()
+	router.GET("/readyz", checker.Readiness)
+
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+
+	assert.Equal(t, http.StatusServiceUnavailable, resp.Code)
+	var response ReadinessResponse
+	assert.NoError(t, json.Unmarshal(resp.Body.Bytes(), &response))
+	assert.Equal(t, StatusDown, response.Status)
+}
+
+func TestReadinessWhileDraining(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	checker := NewChecker(time.Second)
+	router := gin.New()
+	router.GET("/healthz", checker.Liveness)
+	router.GET("/readyz", checker.Readiness)
+	checker.Drain()
+
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+	assert.Equal(t, http.StatusServiceUnavailable, resp.Code)
+
+	resp = httptest.NewRecorder()
+	router.ServeHTTP(resp, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+	assert.Equal(t, http.StatusOK, resp.Code)
+}
@@ -0,0 +1,123 @@
+package hold
+
+import (
+	"context"
+	"errors"
+	"github.com/google/uuid"
+	"github.com/google/wire"
+	"github.com/raychongtk/wallet/config"
+	"github.com/raychongtk/wallet/domain"
+	"github.com/raychongtk/wallet/ledger"
+	"github.com/raychongtk/wallet/model/wallet"
+	"github.com/raychongtk/wallet/repository"
+	"github.com/raychongtk/wallet/util"
+	"go.uber.org/zap"
+	"strings"
+	"sync"
+	"time"
+)
+
+var (
+	WireSet = wire.NewSet(ProvideManager)
+)
+
+const (
+	maxReferenceLength = 200
+	// expireBatchSize is how many expired holds one sweep releases at most
+	expireBatchSize = 100
+)
+
+// Manager places holds with the configured expiry and releases the ones nobody captured or released in time. The
+// balances are changed by the ledger, which captures and releases a hold under a lock on it.
+type Manager struct {
+	ledger   *ledger.Ledger
+	holdRepo repository.HoldRepository
+	config   config.HoldConfig
+	mu       sync.Mutex
+	lastErr  error
+}
+
+func ProvideManager(ledger *ledger.Ledger, holdRepo repository.HoldRepository, cfg *config.Config) *Manager {
+	return &Manager{ledger: ledger, holdRepo: holdRepo, config: cfg.Hold}
+}
+
+// PlaceCommand holds Amount, in minor units, of the wallet of UserID for MerchantUserID. A zero ExpiresIn takes
+// hold.default_expiry.
+type PlaceCommand struct {
+	UserID         uuid.UUID
+	MerchantUserID uuid.UUID
+	Amount         int
+	Reference      string
+	ExpiresIn      time.Duration
+	RequestID      string
+}
+
+func (m *Manager) Place(ctx context.Context, cmd PlaceCommand) (*wallet.Hold, error) {
+	reference := strings.TrimSpace(cmd.Reference)
+	if len(reference) > maxReferenceLength {
+		return nil, domain.ErrInvalidParameters.WithMessage("reference is at most %d characters", maxReferenceLength)
+	}
+	expiresIn := cmd.ExpiresIn
+	if expiresIn == 0 {
+		expiresIn = m.config.DefaultExpiry
+	}
+	if expiresIn < 0 || expiresIn > m.config.MaxExpiry {
+		return nil, domain.ErrInvalidParameters.WithMessage("a hold expires within %s", m.config.MaxExpiry)
+	}
+	return m.ledger.PlaceHold(ctx, ledger.PlaceHoldCommand{
+		UserID:         cmd.UserID,
+		MerchantUserID: cmd.MerchantUserID,
+		Amount:         cmd.Amount,
+		Reference:      reference,
+		ExpiresAt:      time.Now().Add(expiresIn),
+		RequestID:      cmd.RequestID,
+	})
+}
+
+// Start releases expired holds every hold.interval until the context is cancelled
+func (m *Manager) Start(ctx context.Context) {
+	ticker := time.NewTicker(m.config.Interval)
+	defer ticker.Stop()
+	for {
+		err := m.Expire(ctx)
+		if err != nil && ctx.Err() == nil {
+			util.Error("Expire holds failed", zap.Error(err))
+		}
+		m.mu.Lock()
+		m.lastErr = err
+		m.mu.Unlock()
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Health reports the error of the last expiry sweep
+func (m *Manager) Health() error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.lastErr
+}
+
+// Expire releases the active holds whose expiry has passed. A hold captured or released meanwhile is left alone.
+func (m *Manager) Expire(ctx context.Context) error {
+	for ctx.Err() == nil {
+		holds, err := m.holdRepo.SearchExpiredHolds(time.Now(), expireBatchSize)
+		if err != nil || len(holds) == 0 {
+			return err
+		}
+		for _, hold := range holds {
+			_, err := m.ledger.ReleaseHold(ctx, hold.ID, wallet.HoldExpired)
+			if errors.Is(err, domain.ErrHoldClosed) {
+				continue
+			}
+			if err != nil {
+				return err
+			}
+			util.Info("Expire hold successfully", zap.String("hold_id", hold.ID.String()))
+		}
+	}
+	return ctx.Err()
+}
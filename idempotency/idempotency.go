@@ -0,0 +1,26 @@
+package idempotency
+
+import (
+	"context"
+	"github.com/go-redis/redis/v8"
+	"github.com/raychongtk/wallet/domain"
+	"github.com/raychongtk/wallet/metrics"
+	"time"
+)
+
+// Claim marks a request id as used for ttl, whatever the transport it came from. It fails with
+// domain.ErrDuplicateRequest when the id was claimed before, so a retried money movement is applied once.
+func Claim(ctx context.Context, memoryStore *redis.Client, requestID string, ttl time.Duration) error {
+	if requestID == "" {
+		return domain.ErrMissingRequestID
+	}
+	claimed, err := memoryStore.SetNX(ctx, requestID, "true", ttl).Result()
+	if err != nil {
+		return domain.ErrUnavailable.Wrap(err)
+	}
+	if !claimed {
+		metrics.IdempotencyHits.Inc()
+		return domain.ErrDuplicateRequest
+	}
+	return nil
+}
@@ -0,0 +1,61 @@
+//go:build wireinject
+// +build wireinject
+
+package main
+
+import (
+	"context"
+	"github.com/raychongtk/wallet/archive"
+	"github.com/raychongtk/wallet/audit"
+	"github.com/raychongtk/wallet/business"
+	"github.com/raychongtk/wallet/config"
+	"github.com/raychongtk/wallet/datastore"
+	"github.com/raychongtk/wallet/escrow"
+	"github.com/raychongtk/wallet/hold"
+	"github.com/raychongtk/wallet/integrity"
+	"github.com/raychongtk/wallet/interest"
+	"github.com/raychongtk/wallet/joint"
+	"github.com/raychongtk/wallet/ledger"
+	"github.com/raychongtk/wallet/migration"
+	"github.com/raychongtk/wallet/notify"
+	"github.com/raychongtk/wallet/openapi"
+	"github.com/raychongtk/wallet/paymentrequest"
+	"github.com/raychongtk/wallet/payout"
+	"github.com/raychongtk/wallet/repository"
+	"github.com/raychongtk/wallet/rpc"
+	"github.com/raychongtk/wallet/scheduler"
+	"github.com/raychongtk/wallet/secret"
+	"github.com/raychongtk/wallet/service"
+	"github.com/raychongtk/wallet/tracing"
+)
+
+import "github.com/google/wire"
+
+func injectApp(ctx context.Context) (*App, error) {
+	panic(wire.Build(
+		config.WireSet,
+		secret.WireSet,
+		datastore.WireSet,
+		repository.WireSet,
+		audit.WireSet,
+		archive.WireSet,
+		integrity.WireSet,
+		ledger.WireSet,
+		migration.WireSet,
+		openapi.WireSet,
+		payout.WireSet,
+		notify.WireSet,
+		scheduler.WireSet,
+		paymentrequest.WireSet,
+		hold.WireSet,
+		escrow.WireSet,
+		interest.WireSet,
+		joint.WireSet,
+		business.WireSet,
+		tracing.WireSet,
+		service.WireSet,
+		rpc.WireSet,
+		ProvideHealthChecker,
+		ProvideApp,
+	))
+}
@@ -0,0 +1,155 @@
+package integrity
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/raychongtk/wallet/model/ledger"
+	"github.com/raychongtk/wallet/model/movement"
+	"github.com/raychongtk/wallet/repository"
+	"gorm.io/gorm"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// GenesisHash is the previous hash of the first entry of every chain
+var GenesisHash = strings.Repeat("0", 64)
+
+// lockKey serializes appends to the global chain, it is taken after the balance rows are locked so it cannot deadlock
+// with them
+const lockKey = 7256380422
+
+// Chain commits every movement group with a hash over its movements and transactions and the previous hash, once
+// in the global chain and once in the chain of every wallet the group touches. Rewriting or removing a ledger row
+// breaks every hash after it.
+type Chain struct {
+	hashRepo repository.LedgerHashRepository
+}
+
+func ProvideChain(hashRepo repository.LedgerHashRepository) *Chain {
+	return &Chain{hashRepo: hashRepo}
+}
+
+// Append must run in the database transaction that writes the movements and transactions of the group
+func (c *Chain) Append(tx *gorm.DB, movements []movement.Movement, transactions []movement.Transaction) error {
+	if len(movements) == 0 {
+		return errors.New("append an empty movement group")
+	}
+	groupID := movements[0].GroupID
+	for _, m := range movements {
+		if m.GroupID != groupID {
+			return fmt.Errorf("movement %s is not in group %s", m.ID, groupID)
+		}
+	}
+	contentHash := ContentHash(movements, transactions)
+
+	if err := tx.Exec("SELECT pg_advisory_xact_lock(?)", lockKey).Error; err != nil {
+		return err
+	}
+	last, err := c.hashRepo.GetLastEntry(tx)
+	if err != nil {
+		return err
+	}
+	entry := &ledger.Entry{
+		Sequence:         1,
+		GroupID:          groupID,
+		ContentHash:      contentHash,
+		MovementCount:    len(movements),
+		TransactionCount: len(transactions),
+		PrevHash:         GenesisHash,
+		CreatedAt:        movements[0].CreatedAt,
+	}
+	if last != nil {
+		entry.Sequence = last.Sequence + 1
+		entry.PrevHash = last.Hash
+	}
+	entry.Hash = EntryHash(entry.PrevHash, entry.Sequence, groupID, contentHash)
+	if err := c.hashRepo.CreateEntry(tx, entry); err != nil {
+		return err
+	}
+
+	var walletEntries []ledger.WalletEntry
+	for _, walletID := range touchedWallets(transactions) {
+		lastWalletEntry, err := c.hashRepo.GetLastWalletEntry(tx, walletID)
+		if err != nil {
+			return err
+		}
+		walletEntry := ledger.WalletEntry{
+			WalletID:    walletID,
+			Sequence:    1,
+			GroupID:     groupID,
+			ContentHash: contentHash,
+			PrevHash:    GenesisHash,
+			CreatedAt:   entry.CreatedAt,
+		}
+		if lastWalletEntry != nil {
+			walletEntry.Sequence = lastWalletEntry.Sequence + 1
+			walletEntry.PrevHash = lastWalletEntry.Hash
+		}
+		walletEntry.Hash = WalletEntryHash(walletEntry.PrevHash, walletID, walletEntry.Sequence, groupID, contentHash)
+		walletEntries = append(walletEntries, walletEntry)
+	}
+	if len(walletEntries) == 0 {
+		return nil
+	}
+	return c.hashRepo.CreateWalletEntries(tx, walletEntries)
+}
+
+// ContentHash covers the immutable fields of a movement group. Timestamps and the movement status are left out,
+// the status is allowed to move on and timestamps lose precision in the database.
+func ContentHash(movements []movement.Movement, transactions []movement.Transaction) string {
+	sortedMovements := append([]movement.Movement(nil), movements...)
+	sort.Slice(sortedMovements, func(i, j int) bool {
+		return sortedMovements[i].ID.String() < sortedMovements[j].ID.String()
+	})
+	sortedTransactions := append([]movement.Transaction(nil), transactions...)
+	sort.Slice(sortedTransactions, func(i, j int) bool {
+		return sortedTransactions[i].ID.String() < sortedTransactions[j].ID.String()
+	})
+
+	var content strings.Builder
+	for _, m := range sortedMovements {
+		content.WriteString(strings.Join([]string{"movement", m.ID.String(), m.GroupID.String(),
+			m.DebitWalletID.String(), m.CreditWalletID.String(),
+			strconv.Itoa(m.DebitBalance), strconv.Itoa(m.CreditBalance)}, "|"))
+		content.WriteString("\n")
+	}
+	for _, t := range sortedTransactions {
+		content.WriteString(strings.Join([]string{"transaction", t.ID.String(), t.MovementID.String(),
+			t.WalletID.String(), t.BalanceType, strconv.Itoa(t.Balance)}, "|"))
+		content.WriteString("\n")
+	}
+	return digest(content.String())
+}
+
+func EntryHash(prevHash string, sequence int64, groupID uuid.UUID, contentHash string) string {
+	return digest(strings.Join([]string{prevHash, strconv.FormatInt(sequence, 10), groupID.String(), contentHash}, "|"))
+}
+
+func WalletEntryHash(prevHash string, walletID uuid.UUID, sequence int64, groupID uuid.UUID, contentHash string) string {
+	return digest(strings.Join([]string{prevHash, walletID.String(), strconv.FormatInt(sequence, 10), groupID.String(), contentHash}, "|"))
+}
+
+// touchedWallets is sorted so that wallet chains are always extended in the same order
+func touchedWallets(transactions []movement.Transaction) []uuid.UUID {
+	seen := map[uuid.UUID]bool{}
+	var wallets []uuid.UUID
+	for _, t := range transactions {
+		if !seen[t.WalletID] {
+			seen[t.WalletID] = true
+			wallets = append(wallets, t.WalletID)
+		}
+	}
+	sort.Slice(wallets, func(i, j int) bool {
+		return wallets[i].String() < wallets[j].String()
+	})
+	return wallets
+}
+
+func digest(value string) string {
+	sum := sha256.Sum256([]byte(value))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,234 @@
+package integrity
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"github.com/raychongtk/wallet/audit"
+	"github.com/raychongtk/wallet/config"
+	"github.com/raychongtk/wallet/model/ledger"
+	"github.com/raychongtk/wallet/problem"
+	"github.com/raychongtk/wallet/repository"
+	"github.com/raychongtk/wallet/secret"
+	"github.com/raychongtk/wallet/util"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Signer signs checkpoints with an Ed25519 key. Auditors only need the public key to check them.
+type Signer struct {
+	privateKey ed25519.PrivateKey
+	keyID      string
+}
+
+// NewSigner takes the base64 encoded 32 byte seed of the key
+func NewSigner(encodedSeed string) (*Signer, error) {
+	seed, err := base64.StdEncoding.DecodeString(strings.TrimSpace(encodedSeed))
+	if err != nil {
+		return nil, fmt.Errorf("decode signing key: %w", err)
+	}
+	if len(seed) != ed25519.SeedSize {
+		return nil, fmt.Errorf("signing key must be %d bytes, got %d", ed25519.SeedSize, len(seed))
+	}
+	privateKey := ed25519.NewKeyFromSeed(seed)
+	return &Signer{privateKey: privateKey, keyID: KeyID(privateKey.Public().(ed25519.PublicKey))}, nil
+}
+
+func (s *Signer) PublicKey() ed25519.PublicKey {
+	return s.privateKey.Public().(ed25519.PublicKey)
+}
+
+func (s *Signer) Sign(sequence int64, hash string) *ledger.Checkpoint {
+	return &ledger.Checkpoint{
+		Sequence:  sequence,
+		Hash:      hash,
+		KeyID:     s.keyID,
+		Signature: base64.StdEncoding.EncodeToString(ed25519.Sign(s.privateKey, CheckpointMessage(sequence, hash))),
+		CreatedAt: time.Now(),
+	}
+}
+
+// KeyID is a short fingerprint of a public key so that rotated keys can be told apart
+func KeyID(publicKey ed25519.PublicKey) string {
+	sum := sha256.Sum256(publicKey)
+	return hex.EncodeToString(sum[:8])
+}
+
+// CheckpointMessage is what is signed, it is versioned so that the format can change without ambiguity
+func CheckpointMessage(sequence int64, hash string) []byte {
+	return []byte("wallet-ledger-checkpoint:v1:" + strconv.FormatInt(sequence, 10) + ":" + hash)
+}
+
+func VerifyCheckpoint(publicKey ed25519.PublicKey, checkpoint ledger.Checkpoint) bool {
+	signature, err := base64.StdEncoding.DecodeString(checkpoint.Signature)
+	if err != nil {
+		return false
+	}
+	return ed25519.Verify(publicKey, CheckpointMessage(checkpoint.Sequence, checkpoint.Hash), signature)
+}
+
+// Checkpointer signs the head of the global chain periodically. A published checkpoint pins every entry before
+// it, so history cannot be rewritten later even by someone able to recompute all hashes.
+type Checkpointer struct {
+	hashRepo repository.LedgerHashRepository
+	db       gorm.DB
+	signer   *Signer
+	interval time.Duration
+	auditor  *audit.Auditor
+	mu       sync.Mutex
+	lastErr  error
+}
+
+func ProvideCheckpointer(hashRepo repository.LedgerHashRepository, db gorm.DB, cfg *config.Config, secrets secret.Provider, auditor *audit.Auditor) (*Checkpointer, error) {
+	checkpointer := &Checkpointer{hashRepo: hashRepo, db: db, interval: cfg.Ledger.CheckpointInterval, auditor: auditor}
+	if !cfg.Features.LedgerCheckpoints {
+		return checkpointer, nil
+	}
+	encodedSeed, err := secret.Resolve(context.Background(), secrets, cfg.Ledger.SigningKey)
+	if err != nil {
+		return nil, fmt.Errorf("resolve ledger signing key: %w", err)
+	}
+	signer, err := NewSigner(encodedSeed)
+	if err != nil {
+		return nil, err
+	}
+	checkpointer.signer = signer
+	return checkpointer, nil
+}
+
+func NewCheckpointer(hashRepo repository.LedgerHashRepository, db gorm.DB, signer *Signer, interval time.Duration) *Checkpointer {
+	return &Checkpointer{hashRepo: hashRepo, db: db, signer: signer, interval: interval}
+}
+
+// Start signs a checkpoint every interval until the context is cancelled
+func (c *Checkpointer) Start(ctx context.Context) {
+	if c.signer == nil {
+		return
+	}
+	ticker := time.NewTicker(c.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		_, err := c.Checkpoint(ctx)
+		c.mu.Lock()
+		c.lastErr = err
+		c.mu.Unlock()
+		if err != nil {
+			util.Error("Ledger checkpoint failed", zap.Error(err))
+		}
+	}
+}
+
+// Checkpoint signs the current head of the global chain, it returns nil when nothing was appended since the last one
+func (c *Checkpointer) Checkpoint(ctx context.Context) (*ledger.Checkpoint, error) {
+	if c.signer == nil {
+		return nil, fmt.Errorf("ledger checkpoints are disabled")
+	}
+	head, err := c.hashRepo.GetLastEntry(c.db.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	if head == nil {
+		return nil, nil
+	}
+	last, err := c.hashRepo.GetLastCheckpoint()
+	if err != nil {
+		return nil, err
+	}
+	if last != nil && last.Sequence >= head.Sequence {
+		return nil, nil
+	}
+	checkpoint := c.signer.Sign(head.Sequence, head.Hash)
+	err = c.hashRepo.CreateCheckpoint(c.db.WithContext(ctx), checkpoint)
+	c.audit(ctx, checkpoint, err)
+	if err != nil {
+		return nil, err
+	}
+	util.Info("Sign ledger checkpoint successfully", zap.Int64("sequence", checkpoint.Sequence), zap.String("hash", checkpoint.Hash))
+	return checkpoint, nil
+}
+
+// audit records a signed checkpoint, or a failed attempt to store it
+func (c *Checkpointer) audit(ctx context.Context, checkpoint *ledger.Checkpoint, err error) {
+	entry := audit.Entry{
+		ActorType:  audit.ActorService,
+		ActorID:    "checkpointer",
+		Action:     "ledger.checkpoint",
+		TargetType: audit.TargetCheckpoint,
+		TargetID:   strconv.FormatInt(checkpoint.Sequence, 10),
+		After: map[string]interface{}{
+			"sequence": checkpoint.Sequence,
+			"hash":     checkpoint.Hash,
+			"key_id":   checkpoint.KeyID,
+		},
+		Outcome: audit.OutcomeSuccess,
+	}
+	if err != nil {
+		entry.After, entry.Outcome, entry.ErrorCode = nil, audit.OutcomeFailure, "CHECKPOINT_FAILED"
+	}
+	if err := c.auditor.Record(context.WithoutCancel(ctx), entry); err != nil {
+		util.Error("Record audit log failed", zap.String("action", entry.Action), zap.Error(err))
+	}
+}
+
+func (c *Checkpointer) Health() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.lastErr
+}
+
+// Export lists every signed checkpoint with the public key that verifies them
+func (c *Checkpointer) Export(ctx *gin.Context) {
+	checkpoints, err := c.hashRepo.SearchCheckpoints()
+	if err != nil {
+		util.Error("search checkpoints failed", zap.Error(err))
+		problem.Respond(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, NewCheckpointExport(c.signer, checkpoints))
+}
+
+func NewCheckpointExport(signer *Signer, checkpoints []ledger.Checkpoint) *CheckpointExport {
+	export := &CheckpointExport{Checkpoints: []ExportedCheckpoint{}}
+	if signer != nil {
+		export.PublicKey = base64.StdEncoding.EncodeToString(signer.PublicKey())
+		export.KeyID = signer.keyID
+	}
+	for _, checkpoint := range checkpoints {
+		export.Checkpoints = append(export.Checkpoints, ExportedCheckpoint{
+			Sequence:  checkpoint.Sequence,
+			Hash:      checkpoint.Hash,
+			KeyID:     checkpoint.KeyID,
+			Signature: checkpoint.Signature,
+			CreatedAt: checkpoint.CreatedAt.UTC().Format(time.RFC3339),
+		})
+	}
+	return export
+}
+
+type CheckpointExport struct {
+	PublicKey   string               `json:"public_key,omitempty"`
+	KeyID       string               `json:"key_id,omitempty"`
+	Checkpoints []ExportedCheckpoint `json:"checkpoints"`
+}
+
+type ExportedCheckpoint struct {
+	Sequence  int64  `json:"sequence"`
+	Hash      string `json:"hash"`
+	KeyID     string `json:"key_id"`
+	Signature string `json:"signature"`
+	CreatedAt string `json:"created_at"`
+}
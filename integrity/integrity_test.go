@@ -0,0 +1,64 @@
+package integrity
+
+import (
+	"crypto/ed25519"
+	"encoding/base64"
+	"github.com/google/uuid"
+	"github.com/raychongtk/wallet/model/movement"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+func group() ([]movement.Movement, []movement.Transaction) {
+	groupID := uuid.New()
+	m := movement.Movement{ID: uuid.New(), GroupID: groupID, DebitWalletID: uuid.New(), CreditWalletID: uuid.New(), DebitBalance: 100, CreditBalance: 100}
+	return []movement.Movement{m}, []movement.Transaction{
+		{ID: uuid.New(), MovementID: m.ID, WalletID: m.DebitWalletID, BalanceType: "COMMITTED", Balance: 100},
+		{ID: uuid.New(), MovementID: m.ID, WalletID: m.CreditWalletID, BalanceType: "COMMITTED", Balance: 100},
+	}
+}
+
+func TestContentHashIgnoresOrderButNotValues(t *testing.T) {
+	movements, transactions := group()
+	hash := ContentHash(movements, transactions)
+
+	reversed := []movement.Transaction{transactions[1], transactions[0]}
+	assert.Equal(t, hash, ContentHash(movements, reversed))
+
+	movements[0].MovementStatus = "REVERSED"
+	assert.Equal(t, hash, ContentHash(movements, transactions))
+
+	transactions[0].Balance = 101
+	assert.NotEqual(t, hash, ContentHash(movements, transactions))
+	assert.NotEqual(t, hash, ContentHash(movements, transactions[:1]))
+}
+
+func TestEntryHashLinksPreviousHash(t *testing.T) {
+	groupID := uuid.New()
+	first := EntryHash(GenesisHash, 1, groupID, "content")
+	assert.Len(t, first, 64)
+	assert.NotEqual(t, first, EntryHash(strings.Repeat("1", 64), 1, groupID, "content"))
+	assert.NotEqual(t, first, EntryHash(GenesisHash, 2, groupID, "content"))
+	assert.NotEqual(t, first, WalletEntryHash(GenesisHash, uuid.New(), 1, groupID, "content"))
+}
+
+func TestSignerSignsVerifiableCheckpoint(t *testing.T) {
+	seed := make([]byte, ed25519.SeedSize)
+	signer, err := NewSigner(base64.StdEncoding.EncodeToString(seed) + "\n")
+	assert.NoError(t, err)
+
+	checkpoint := signer.Sign(42, EntryHash(GenesisHash, 42, uuid.New(), "content"))
+	assert.Equal(t, KeyID(signer.PublicKey()), checkpoint.KeyID)
+	assert.True(t, VerifyCheckpoint(signer.PublicKey(), *checkpoint))
+
+	checkpoint.Sequence = 43
+	assert.False(t, VerifyCheckpoint(signer.PublicKey(), *checkpoint))
+}
+
+func TestNewSignerWithInvalidKey(t *testing.T) {
+	_, err := NewSigner("not base64")
+	assert.Error(t, err)
+	_, err = NewSigner(base64.StdEncoding.EncodeToString([]byte("short")))
+	assert.ErrorContains(t, err, "signing key must be 32 bytes")
+}
@@ -0,0 +1,300 @@
+package integrity
+
+import (
+	"context"
+	"crypto/ed25519"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/raychongtk/wallet/archive"
+	"github.com/raychongtk/wallet/model/ledger"
+	"github.com/raychongtk/wallet/model/movement"
+	"github.com/raychongtk/wallet/repository"
+	"time"
+)
+
+const (
+	pageSize    = 500
+	maxFailures = 100
+)
+
+// Failure pinpoints where a chain stops matching the ledger
+type Failure struct {
+	Sequence int64  `json:"sequence"`
+	WalletID string `json:"wallet_id,omitempty"`
+	GroupID  string `json:"group_id,omitempty"`
+	Reason   string `json:"reason"`
+}
+
+type Report struct {
+	Entries       int64     `json:"entries"`
+	WalletEntries int64     `json:"wallet_entries"`
+	Checkpoints   int       `json:"checkpoints"`
+	Unchained     int64     `json:"unchained_movements"`
+	HeadSequence  int64     `json:"head_sequence"`
+	HeadHash      string    `json:"head_hash"`
+	Failures      []Failure `json:"failures"`
+}
+
+func (r *Report) OK() bool {
+	return len(r.Failures) == 0 && r.Unchained == 0
+}
+
+func (r *Report) fail(failure Failure) {
+	if len(r.Failures) < maxFailures {
+		r.Failures = append(r.Failures, failure)
+	}
+}
+
+// Verifier recomputes both chains from the ledger rows, reading archived rows from the object store
+type Verifier struct {
+	movementRepo    repository.MovementRepository
+	transactionRepo repository.TransactionRepository
+	hashRepo        repository.LedgerHashRepository
+	archiveReader   *archive.Reader
+	// publicKey checks checkpoint signatures, they are skipped when it is nil
+	publicKey ed25519.PublicKey
+}
+
+func NewVerifier(
+	movementRepo repository.MovementRepository,
+	transactionRepo repository.TransactionRepository,
+	hashRepo repository.LedgerHashRepository,
+	archiveReader *archive.Reader,
+	publicKey ed25519.PublicKey,
+) *Verifier {
+	return &Verifier{
+		movementRepo:    movementRepo,
+		transactionRepo: transactionRepo,
+		hashRepo:        hashRepo,
+		archiveReader:   archiveReader,
+		publicKey:       publicKey,
+	}
+}
+
+// Verify walks the global chain, then every wallet chain, then the checkpoints. Failures are reported in chain order
+// so the first one is the earliest altered or missing record.
+func (v *Verifier) Verify(ctx context.Context) (*Report, error) {
+	report := &Report{}
+	if err := v.verifyEntries(ctx, report); err != nil {
+		return nil, err
+	}
+	if err := v.verifyWalletEntries(report); err != nil {
+		return nil, err
+	}
+	if err := v.verifyCheckpoints(report); err != nil {
+		return nil, err
+	}
+	unchained, err := v.movementRepo.CountUnchainedMovements()
+	if err != nil {
+		return nil, err
+	}
+	report.Unchained = unchained
+	return report, nil
+}
+
+func (v *Verifier) verifyEntries(ctx context.Context, report *Report) error {
+	archived := newArchivedDays(v.archiveReader)
+	prevHash := GenesisHash
+	var sequence int64
+	for {
+		entries, err := v.hashRepo.SearchEntries(sequence, pageSize)
+		if err != nil {
+			return err
+		}
+		if len(entries) == 0 {
+			return nil
+		}
+		movementsByGroup, transactionsByMovement, err := v.loadHot(entries)
+		if err != nil {
+			return err
+		}
+		for _, entry := range entries {
+			if entry.Sequence != sequence+1 {
+				report.fail(Failure{Sequence: sequence + 1, Reason: fmt.Sprintf("entries %d to %d are missing", sequence+1, entry.Sequence-1)})
+			}
+			if entry.PrevHash != prevHash {
+				report.fail(Failure{Sequence: entry.Sequence, GroupID: entry.GroupID.String(), Reason: "previous hash does not match the chain"})
+			}
+			if EntryHash(entry.PrevHash, entry.Sequence, entry.GroupID, entry.ContentHash) != entry.Hash {
+				report.fail(Failure{Sequence: entry.Sequence, GroupID: entry.GroupID.String(), Reason: "entry hash was altered"})
+			}
+
+			movements := movementsByGroup[entry.GroupID]
+			var transactions []movement.Transaction
+			if len(movements) == 0 {
+				movements, transactions, err = archived.group(ctx, entry)
+				if err != nil {
+					return err
+				}
+			} else {
+				for _, m := range movements {
+					transactions = append(transactions, transactionsByMovement[m.ID]...)
+				}
+			}
+			switch {
+			case len(movements) != entry.MovementCount:
+				report.fail(Failure{Sequence: entry.Sequence, GroupID: entry.GroupID.String(),
+					Reason: fmt.Sprintf("expected %d movements, found %d", entry.MovementCount, len(movements))})
+			case len(transactions) != entry.TransactionCount:
+				report.fail(Failure{Sequence: entry.Sequence, GroupID: entry.GroupID.String(),
+					Reason: fmt.Sprintf("expected %d transactions, found %d", entry.TransactionCount, len(transactions))})
+			case ContentHash(movements, transactions) != entry.ContentHash:
+				report.fail(Failure{Sequence: entry.Sequence, GroupID: entry.GroupID.String(), Reason: "movement or transaction was altered"})
+			}
+
+			sequence = entry.Sequence
+			prevHash = entry.Hash
+			report.Entries++
+			report.HeadSequence = entry.Sequence
+			report.HeadHash = entry.Hash
+		}
+	}
+}
+
+func (v *Verifier) loadHot(entries []ledger.Entry) (map[uuid.UUID][]movement.Movement, map[uuid.UUID][]movement.Transaction, error) {
+	groupIDs := make([]uuid.UUID, len(entries))
+	for i, entry := range entries {
+		groupIDs[i] = entry.GroupID
+	}
+	movements, err := v.movementRepo.SearchMovementsByGroupIDs(groupIDs)
+	if err != nil {
+		return nil, nil, err
+	}
+	movementsByGroup := map[uuid.UUID][]movement.Movement{}
+	var movementIDs []uuid.UUID
+	for _, m := range movements {
+		movementsByGroup[m.GroupID] = append(movementsByGroup[m.GroupID], m)
+		movementIDs = append(movementIDs, m.ID)
+	}
+	transactions, err := v.transactionRepo.SearchTransactionsByMovementIDs(movementIDs)
+	if err != nil {
+		return nil, nil, err
+	}
+	transactionsByMovement := map[uuid.UUID][]movement.Transaction{}
+	for _, t := range transactions {
+		transactionsByMovement[t.MovementID] = append(transactionsByMovement[t.MovementID], t)
+	}
+	return movementsByGroup, transactionsByMovement, nil
+}
+
+func (v *Verifier) verifyWalletEntries(report *Report) error {
+	var walletID uuid.UUID
+	var sequence int64
+	prevHash := GenesisHash
+	for {
+		walletEntries, err := v.hashRepo.SearchWalletEntries(walletID, sequence, pageSize)
+		if err != nil {
+			return err
+		}
+		if len(walletEntries) == 0 {
+			return nil
+		}
+		groupIDs := make([]uuid.UUID, len(walletEntries))
+		for i, walletEntry := range walletEntries {
+			groupIDs[i] = walletEntry.GroupID
+		}
+		entries, err := v.hashRepo.SearchEntriesByGroupIDs(groupIDs)
+		if err != nil {
+			return err
+		}
+		contentHashes := map[uuid.UUID]string{}
+		for _, entry := range entries {
+			contentHashes[entry.GroupID] = entry.ContentHash
+		}
+
+		for _, walletEntry := range walletEntries {
+			if walletEntry.WalletID != walletID {
+				walletID, sequence, prevHash = walletEntry.WalletID, 0, GenesisHash
+			}
+			failure := Failure{Sequence: walletEntry.Sequence, WalletID: walletID.String(), GroupID: walletEntry.GroupID.String()}
+			if walletEntry.Sequence != sequence+1 {
+				failure.Reason = fmt.Sprintf("wallet entries %d to %d are missing", sequence+1, walletEntry.Sequence-1)
+				report.fail(failure)
+			}
+			if walletEntry.PrevHash != prevHash {
+				failure.Reason = "previous hash does not match the wallet chain"
+				report.fail(failure)
+			}
+			if WalletEntryHash(walletEntry.PrevHash, walletID, walletEntry.Sequence, walletEntry.GroupID, walletEntry.ContentHash) != walletEntry.Hash {
+				failure.Reason = "wallet entry hash was altered"
+				report.fail(failure)
+			}
+			if contentHash, ok := contentHashes[walletEntry.GroupID]; !ok || contentHash != walletEntry.ContentHash {
+				failure.Reason = "wallet entry does not match the global chain"
+				report.fail(failure)
+			}
+			sequence = walletEntry.Sequence
+			prevHash = walletEntry.Hash
+			report.WalletEntries++
+		}
+	}
+}
+
+func (v *Verifier) verifyCheckpoints(report *Report) error {
+	checkpoints, err := v.hashRepo.SearchCheckpoints()
+	if err != nil {
+		return err
+	}
+	for _, checkpoint := range checkpoints {
+		report.Checkpoints++
+		if v.publicKey != nil && checkpoint.KeyID == KeyID(v.publicKey) && !VerifyCheckpoint(v.publicKey, checkpoint) {
+			report.fail(Failure{Sequence: checkpoint.Sequence, Reason: "checkpoint signature is invalid"})
+			continue
+		}
+		entry, err := v.hashRepo.GetEntry(checkpoint.Sequence)
+		if err != nil {
+			return err
+		}
+		if entry == nil || entry.Hash != checkpoint.Hash {
+			report.fail(Failure{Sequence: checkpoint.Sequence, Reason: "chain does not match the signed checkpoint"})
+		}
+	}
+	return nil
+}
+
+// archivedDays loads archived rows one day at a time, the window the archiver writes objects in
+type archivedDays struct {
+	reader       *archive.Reader
+	movements    map[uuid.UUID][]movement.Movement
+	transactions map[uuid.UUID][]movement.Transaction
+	loaded       map[time.Time]bool
+}
+
+func newArchivedDays(reader *archive.Reader) *archivedDays {
+	return &archivedDays{
+		reader:       reader,
+		movements:    map[uuid.UUID][]movement.Movement{},
+		transactions: map[uuid.UUID][]movement.Transaction{},
+		loaded:       map[time.Time]bool{},
+	}
+}
+
+func (a *archivedDays) group(ctx context.Context, entry ledger.Entry) ([]movement.Movement, []movement.Transaction, error) {
+	if a.reader == nil {
+		return nil, nil, nil
+	}
+	day := entry.CreatedAt.UTC().Truncate(24 * time.Hour)
+	if !a.loaded[day] {
+		movements, err := a.reader.Movements(ctx, day, day.Add(24*time.Hour))
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, m := range movements {
+			a.movements[m.GroupID] = append(a.movements[m.GroupID], m)
+		}
+		transactions, err := a.reader.Transactions(ctx, day, day.Add(24*time.Hour))
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, t := range transactions {
+			a.transactions[t.MovementID] = append(a.transactions[t.MovementID], t)
+		}
+		a.loaded[day] = true
+	}
+	movements := a.movements[entry.GroupID]
+	var transactions []movement.Transaction
+	for _, m := range movements {
+		transactions = append(transactions, a.transactions[m.ID]...)
+	}
+	return movements, transactions, nil
+}
@@ -0,0 +1,7 @@
+package integrity
+
+import "github.com/google/wire"
+
+var (
+	WireSet = wire.NewSet(ProvideChain, ProvideCheckpointer)
+)
@@ -0,0 +1,247 @@
+package interest
+
+import (
+	"context"
+	"errors"
+	"github.com/google/uuid"
+	"github.com/google/wire"
+	"github.com/raychongtk/wallet/archive"
+	"github.com/raychongtk/wallet/config"
+	"github.com/raychongtk/wallet/domain"
+	"github.com/raychongtk/wallet/ledger"
+	"github.com/raychongtk/wallet/model/wallet"
+	"github.com/raychongtk/wallet/repository"
+	"github.com/raychongtk/wallet/util"
+	"go.uber.org/zap"
+	"sync"
+	"time"
+)
+
+var (
+	WireSet = wire.NewSet(ProvideManager)
+)
+
+const (
+	// accrueBatchSize is how many wallets are read at a time while a day is accrued
+	accrueBatchSize = 500
+	// payoutBatchSize is how many wallets one payout round pays at most
+	payoutBatchSize = 100
+	// microsPerMinorUnit is the precision interest is computed in before it is rounded down to minor units
+	microsPerMinorUnit = 1_000_000
+)
+
+// Manager accrues interest on the end of day balance of every customer wallet for each day that ended, in UTC, and pays
+// the accrued interest of a month out once the month ended. Both are safe to run again, a wallet accrues a day once and
+// an accrual is paid once.
+type Manager struct {
+	ledger          *ledger.Ledger
+	interestRepo    repository.InterestRepository
+	transactionRepo repository.TransactionRepository
+	archiveReader   *archive.Reader
+	config          config.InterestConfig
+	enabled         bool
+	mu              sync.Mutex
+	lastErr         error
+}
+
+func ProvideManager(
+	ledger *ledger.Ledger,
+	interestRepo repository.InterestRepository,
+	transactionRepo repository.TransactionRepository,
+	archiveReader *archive.Reader,
+	cfg *config.Config,
+) *Manager {
+	return &Manager{
+		ledger:          ledger,
+		interestRepo:    interestRepo,
+		transactionRepo: transactionRepo,
+		archiveReader:   archiveReader,
+		config:          cfg.Interest,
+		enabled:         cfg.Features.Interest,
+	}
+}
+
+func (m *Manager) Start(ctx context.Context) {
+	if !m.enabled {
+		return
+	}
+	ticker := time.NewTicker(m.config.Interval)
+	defer ticker.Stop()
+	for {
+		err := m.Run(ctx, time.Now())
+		if err != nil && ctx.Err() == nil {
+			util.Error("Interest run failed", zap.Error(err))
+		}
+		m.mu.Lock()
+		m.lastErr = err
+		m.mu.Unlock()
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Health reports the error of the last run
+func (m *Manager) Health() error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.lastErr
+}
+
+// Run accrues the days that ended since the last completed day, yesterday on the first run, then pays out the months
+// that ended. A month is only paid once all of its days accrued.
+func (m *Manager) Run(ctx context.Context, now time.Time) error {
+	today := startOfDay(now)
+	next := today.AddDate(0, 0, -1)
+	last, err := m.interestRepo.LastRun()
+	if err == nil {
+		next = startOfDay(last.Day).AddDate(0, 0, 1)
+	} else if !errors.Is(err, domain.ErrNotFound) {
+		return err
+	}
+	for ; next.Before(today); next = next.AddDate(0, 0, 1) {
+		if err := m.accrueDay(ctx, next); err != nil {
+			return err
+		}
+	}
+	return m.PayOut(ctx, time.Date(today.Year(), today.Month(), 1, 0, 0, 0, 0, time.UTC))
+}
+
+// Accrue accrues a day that ended for every customer wallet and records the day as complete. Running a day again
+// accrues only the wallets it missed.
+func (m *Manager) Accrue(ctx context.Context, day time.Time) error {
+	day = startOfDay(day)
+	if !day.Before(startOfDay(time.Now())) {
+		return domain.ErrInvalidParameters.WithMessage("day %s has not ended", day.Format(time.DateOnly))
+	}
+	return m.accrueDay(ctx, day)
+}
+
+func (m *Manager) accrueDay(ctx context.Context, day time.Time) error {
+	after := uuid.Nil
+	accrued := 0
+	for ctx.Err() == nil {
+		wallets, err := m.interestRepo.SearchInterestWallets(after, accrueBatchSize)
+		if err != nil {
+			return err
+		}
+		for _, interestWallet := range wallets {
+			created, err := m.accrue(ctx, interestWallet, day)
+			if err != nil {
+				util.Error("Accrue interest failed", zap.String("wallet_id", interestWallet.WalletID.String()), zap.Error(err))
+				return err
+			}
+			if created {
+				accrued++
+			}
+			after = interestWallet.WalletID
+		}
+		if len(wallets) < accrueBatchSize {
+			util.Info("Accrue interest successfully", zap.String("day", day.Format(time.DateOnly)), zap.Int("wallets", accrued))
+			return m.interestRepo.CreateRun(day)
+		}
+	}
+	return ctx.Err()
+}
+
+// accrue adds the interest of the end of day balance to the residual carried from the last accrual of the wallet. A
+// wallet with nothing in it at the end of the day accrues nothing and keeps its residual.
+func (m *Manager) accrue(ctx context.Context, interestWallet repository.InterestWallet, day time.Time) (bool, error) {
+	// timestamps are kept to the microsecond, so this is the last instant of the day
+	endOfDay := day.AddDate(0, 0, 1).Add(-time.Microsecond)
+	hotBalance, err := m.transactionRepo.SumBalance(interestWallet.WalletID, "COMMITTED", endOfDay)
+	if err != nil {
+		return false, err
+	}
+	archivedBalance, err := m.archiveReader.SumBalance(ctx, interestWallet.WalletID, "COMMITTED", endOfDay)
+	if err != nil {
+		return false, err
+	}
+	balance := hotBalance + archivedBalance
+	if balance <= 0 {
+		return false, nil
+	}
+	residual := 0
+	last, err := m.interestRepo.LastAccrual(interestWallet.WalletID, day)
+	if err == nil {
+		residual = last.Residual
+	} else if !errors.Is(err, domain.ErrNotFound) {
+		return false, err
+	}
+	micros := residual + dailyInterest(m.config, balance, day)
+	return m.ledger.AccrueInterest(ctx, &wallet.InterestAccrual{
+		ID:        uuid.New(),
+		WalletID:  interestWallet.WalletID,
+		UserID:    interestWallet.UserID,
+		Day:       day,
+		Balance:   balance,
+		Amount:    micros / microsPerMinorUnit,
+		Residual:  micros % microsPerMinorUnit,
+		CreatedAt: time.Now(),
+	})
+}
+
+// PayOut pays the interest accrued before a day to every wallet with unpaid accruals. A wallet that cannot be paid
+// does not hold up the rest of the round, the payout stops after it and tries again next time.
+func (m *Manager) PayOut(ctx context.Context, before time.Time) error {
+	for ctx.Err() == nil {
+		wallets, err := m.interestRepo.SearchUnpaidWallets(before, payoutBatchSize)
+		if err != nil || len(wallets) == 0 {
+			return err
+		}
+		var failed error
+		for _, interestWallet := range wallets {
+			_, err := m.ledger.PayInterest(ctx, ledger.PayInterestCommand{UserID: interestWallet.UserID, WalletID: interestWallet.WalletID, Before: before})
+			if err != nil {
+				util.Error("Pay interest failed", zap.String("wallet_id", interestWallet.WalletID.String()), zap.Error(err))
+				failed = err
+			}
+		}
+		if failed != nil {
+			return failed
+		}
+	}
+	return ctx.Err()
+}
+
+// dailyInterest is the interest of one day on balance, in millionths of a minor unit and rounded half up. Each tier
+// pays its annual rate on the part of the balance in it, the day count divides the year.
+func dailyInterest(cfg config.InterestConfig, balance int, day time.Time) int {
+	// balance times the annual rate in basis points, summed over the tiers
+	numerator := 0
+	lower := 0
+	for _, tier := range cfg.Tiers {
+		upper := balance
+		if tier.UpTo != 0 && tier.UpTo < balance {
+			upper = tier.UpTo
+		}
+		if upper > lower {
+			numerator += (upper - lower) * tier.RateBps
+		}
+		if upper == balance {
+			break
+		}
+		lower = upper
+	}
+	denominator := 10000 * daysInYear(cfg.DayCount, day)
+	// split before scaling so a large balance does not overflow
+	whole, rest := numerator/denominator, numerator%denominator
+	return whole*microsPerMinorUnit + (rest*microsPerMinorUnit+denominator/2)/denominator
+}
+
+func daysInYear(dayCount string, day time.Time) int {
+	switch dayCount {
+	case config.DayCountActual360:
+		return 360
+	case config.DayCountActualActual:
+		return time.Date(day.Year(), 12, 31, 0, 0, 0, 0, time.UTC).YearDay()
+	}
+	return 365
+}
+
+func startOfDay(t time.Time) time.Time {
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,29 @@
+package interest
+
+import (
+	"github.com/raychongtk/wallet/config"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestDailyInterest(t *testing.T) {
+	cfg := config.InterestConfig{DayCount: config.DayCountActual365, Tiers: []config.InterestTier{
+		{UpTo: 10000, RateBps: 3650},
+		{RateBps: 1825},
+	}}
+	day := time.Date(2026, 10, 18, 0, 0, 0, 0, time.UTC)
+
+	assert.Equal(t, 10_000_000, dailyInterest(cfg, 10000, day))
+	// each tier pays its rate on its part of the balance
+	assert.Equal(t, 15_000_000, dailyInterest(cfg, 20000, day))
+	// far below a minor unit a day, the residual carries it
+	assert.Equal(t, 1000, dailyInterest(cfg, 1, day))
+
+	cfg.Tiers = []config.InterestTier{{RateBps: 3650}}
+	cfg.DayCount = config.DayCountActual360
+	assert.Equal(t, 10_138_889, dailyInterest(cfg, 10000, day))
+	cfg.DayCount = config.DayCountActualActual
+	assert.Equal(t, 10_000_000, dailyInterest(cfg, 10000, day))
+	assert.Equal(t, 9_972_678, dailyInterest(cfg, 10000, time.Date(2028, 2, 29, 0, 0, 0, 0, time.UTC)))
+}
@@ -0,0 +1,490 @@
+package joint
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/google/wire"
+	"github.com/raychongtk/wallet/domain"
+	"github.com/raychongtk/wallet/ledger"
+	"github.com/raychongtk/wallet/model/payment"
+	"github.com/raychongtk/wallet/model/wallet"
+	"github.com/raychongtk/wallet/notify"
+	"github.com/raychongtk/wallet/repository"
+	"github.com/raychongtk/wallet/util"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"strings"
+	"time"
+)
+
+var (
+	WireSet = wire.NewSet(ProvideManager)
+)
+
+const accountTypeCustomer = "CUSTOMER"
+
+// Manager shares the account of a customer with other users. The holder is always an owner, the members get a role
+// that decides what they may do and see. Transfers out of a shared account are kept with the member who made them and,
+// above the approval threshold of the account, wait for a second owner.
+type Manager struct {
+	ledger       *ledger.Ledger
+	accountRepo  repository.AccountRepository
+	memberRepo   repository.MemberRepository
+	transferRepo repository.SharedTransferRepository
+	db           gorm.DB
+	notifier     *notify.Notifier
+}
+
+func ProvideManager(
+	ledger *ledger.Ledger,
+	accountRepo repository.AccountRepository,
+	memberRepo repository.MemberRepository,
+	transferRepo repository.SharedTransferRepository,
+	db gorm.DB,
+	notifier *notify.Notifier,
+) *Manager {
+	return &Manager{
+		ledger:       ledger,
+		accountRepo:  accountRepo,
+		memberRepo:   memberRepo,
+		transferRepo: transferRepo,
+		db:           db,
+		notifier:     notifier,
+	}
+}
+
+// Member resolves what a user is to an account. The holder is an owner without a spending limit, the account of
+// anyone else who is not a member is not found.
+func (m *Manager) Member(accountID uuid.UUID, userID uuid.UUID) (*wallet.Account, *wallet.AccountMember, error) {
+	account, err := m.accountRepo.GetAccountByID(accountID)
+	if err == nil && account.AccountType != accountTypeCustomer {
+		err = domain.ErrNotFound
+	}
+	if err != nil {
+		return nil, nil, domain.NotFound(err, "account %s not found", accountID.String())
+	}
+	if account.UserID == userID {
+		return account, holder(account), nil
+	}
+	member, err := m.memberRepo.GetMember(accountID, userID)
+	if err != nil {
+		return nil, nil, domain.NotFound(err, "account %s not found", accountID.String())
+	}
+	return account, member, nil
+}
+
+// Accounts lists the account a user holds and the accounts shared with them as their memberships
+func (m *Manager) Accounts(userID uuid.UUID) ([]wallet.AccountMember, error) {
+	var accounts []wallet.AccountMember
+	customer, err := m.ledger.Customer(userID)
+	if err != nil {
+		return nil, err
+	}
+	account, _, err := m.Member(customer.Wallet.AccountID, userID)
+	if err != nil {
+		return nil, err
+	}
+	accounts = append(accounts, *holder(account))
+	memberships, err := m.memberRepo.SearchMemberships(userID)
+	if err != nil {
+		return nil, err
+	}
+	return append(accounts, memberships...), nil
+}
+
+// Members lists the holder of an account and its members, which every member may see
+func (m *Manager) Members(accountID uuid.UUID, userID uuid.UUID) ([]wallet.AccountMember, error) {
+	account, _, err := m.Member(accountID, userID)
+	if err != nil {
+		return nil, err
+	}
+	members, err := m.memberRepo.SearchMembers(accountID)
+	if err != nil {
+		return nil, err
+	}
+	return append([]wallet.AccountMember{*holder(account)}, members...), nil
+}
+
+// MemberCommand gives UserID a Role in an account of OwnerUserID. SpendingLimit, in minor units, caps every transfer
+// of a spender, zero is no limit.
+type MemberCommand struct {
+	AccountID     uuid.UUID
+	OwnerUserID   uuid.UUID
+	UserID        uuid.UUID
+	Role          string
+	SpendingLimit int
+}
+
+// SaveMember adds a member or changes the role and spending limit of one. Only owners manage members and the holder
+// stays an owner.
+func (m *Manager) SaveMember(ctx context.Context, cmd MemberCommand) (*wallet.AccountMember, error) {
+	switch cmd.Role {
+	case wallet.RoleOwner, wallet.RoleSpender, wallet.RoleViewer:
+	default:
+		return nil, domain.ErrInvalidParameters.WithMessage("role must be OWNER, SPENDER or VIEWER")
+	}
+	if cmd.SpendingLimit < 0 || (cmd.SpendingLimit > 0 && cmd.Role != wallet.RoleSpender) {
+		return nil, domain.ErrInvalidParameters.WithMessage("only a spender has a spending limit, and it must not be negative")
+	}
+	account, err := m.owner(cmd.AccountID, cmd.OwnerUserID)
+	if err != nil {
+		return nil, err
+	}
+	if cmd.UserID == account.UserID {
+		return nil, domain.ErrInvalidParameters.WithMessage("the holder of an account is always an owner")
+	}
+	if _, err := m.ledger.Customer(cmd.UserID); err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	member := &wallet.AccountMember{
+		ID:            uuid.New(),
+		AccountID:     cmd.AccountID,
+		UserID:        cmd.UserID,
+		Role:          cmd.Role,
+		SpendingLimit: cmd.SpendingLimit,
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	}
+	existing, err := m.memberRepo.GetMember(cmd.AccountID, cmd.UserID)
+	if err == nil {
+		member.ID, member.CreatedAt = existing.ID, existing.CreatedAt
+	} else if !errors.Is(err, domain.ErrNotFound) {
+		return nil, err
+	}
+	if err := m.memberRepo.SaveMember(member); err != nil {
+		return nil, err
+	}
+	util.Info("Save account member successfully", zap.String("account_id", cmd.AccountID.String()), zap.String("user_id", cmd.UserID.String()), zap.String("role", cmd.Role))
+	return member, nil
+}
+
+// RemoveMember takes an account away from a member. Owners remove anyone but the holder, members may leave.
+func (m *Manager) RemoveMember(ctx context.Context, accountID uuid.UUID, byUserID uuid.UUID, userID uuid.UUID) error {
+	account, _, err := m.Member(accountID, byUserID)
+	if err != nil {
+		return err
+	}
+	if userID == account.UserID {
+		return domain.ErrInvalidParameters.WithMessage("the holder of an account is always an owner")
+	}
+	if byUserID != userID {
+		if _, err := m.owner(accountID, byUserID); err != nil {
+			return err
+		}
+	}
+	if err := m.memberRepo.DeleteMember(accountID, userID); err != nil {
+		return err
+	}
+	util.Info("Remove account member successfully", zap.String("account_id", accountID.String()), zap.String("user_id", userID.String()))
+	return nil
+}
+
+// SetApprovalThreshold has transfers above threshold, in minor units, wait for a second owner. Zero turns it off.
+func (m *Manager) SetApprovalThreshold(ctx context.Context, accountID uuid.UUID, ownerUserID uuid.UUID, threshold int) (*wallet.Account, error) {
+	if threshold < 0 {
+		return nil, domain.ErrInvalidParameters.WithMessage("approval threshold must not be negative")
+	}
+	account, err := m.owner(accountID, ownerUserID)
+	if err != nil {
+		return nil, err
+	}
+	if err := m.accountRepo.UpdateApprovalThreshold(accountID, threshold); err != nil {
+		return nil, err
+	}
+	account.ApprovalThreshold = threshold
+	return account, nil
+}
+
+// TransferCommand has InitiatorUserID move Amount, in minor units, out of a shared account to ToUserID
+type TransferCommand struct {
+	AccountID       uuid.UUID
+	InitiatorUserID uuid.UUID
+	ToUserID        uuid.UUID
+	Amount          int
+	RequestID       string
+}
+
+// Transfer pays out of a shared account as its holder and keeps who made the transfer. Above the approval threshold it
+// is stored as PENDING and the other owners are asked to approve it, otherwise it is stored and paid right away. A
+// transfer the ledger refuses to pay right away is declined. Retrying a request id returns the transfer it made, paid
+// when it needs no approval.
+func (m *Manager) Transfer(ctx context.Context, cmd TransferCommand) (*payment.SharedTransfer, error) {
+	account, member, err := m.Member(cmd.AccountID, cmd.InitiatorUserID)
+	if err != nil {
+		return nil, err
+	}
+	existing, err := m.transferRepo.GetSharedTransferByRequestID(cmd.RequestID)
+	if err == nil {
+		return m.retry(ctx, account, existing, cmd)
+	}
+	if !errors.Is(err, domain.ErrNotFound) {
+		return nil, err
+	}
+	if !member.CanSpend() {
+		return nil, domain.ErrForbidden.WithMessage("a %s may not transfer", strings.ToLower(member.Role))
+	}
+	if err := m.ledger.ValidAmount(cmd.Amount); err != nil {
+		return nil, err
+	}
+	if member.SpendingLimit > 0 && cmd.Amount > member.SpendingLimit {
+		return nil, domain.ErrSpendingLimit.WithMessage("a transfer is at most %s", util.DisplayAmount(member.SpendingLimit))
+	}
+	if cmd.ToUserID == account.UserID {
+		return nil, domain.ErrCannotTransferToSelf
+	}
+	if _, err := m.ledger.ActiveCustomer(account.UserID); err != nil {
+		return nil, err
+	}
+	if _, err := m.ledger.ActiveCustomer(cmd.ToUserID); err != nil {
+		return nil, err
+	}
+	approvers, err := m.approvers(account, cmd.InitiatorUserID, cmd.Amount)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	transfer := &payment.SharedTransfer{
+		ID:              uuid.New(),
+		AccountID:       account.ID,
+		InitiatorUserID: cmd.InitiatorUserID,
+		ToUserID:        cmd.ToUserID,
+		Amount:          cmd.Amount,
+		Status:          payment.SharedTransferPending,
+		RequestID:       cmd.RequestID,
+		CreatedAt:       now,
+		UpdatedAt:       now,
+	}
+	if err := m.transferRepo.CreateSharedTransfer(m.db.WithContext(ctx), transfer); err != nil {
+		return nil, err
+	}
+	if len(approvers) == 0 {
+		return m.payNow(ctx, account, transfer)
+	}
+	for _, approver := range approvers {
+		m.notify(ctx, approver, notify.KindApprovalRequested,
+			fmt.Sprintf("A transfer of %s out of a shared account waits for your approval", util.DisplayAmount(transfer.Amount)), transfer)
+	}
+	return transfer, nil
+}
+
+// retry returns the transfer a request id made before. One that needs no approval and is still pending failed after it
+// was stored, so it is paid now.
+func (m *Manager) retry(ctx context.Context, account *wallet.Account, transfer *payment.SharedTransfer, cmd TransferCommand) (*payment.SharedTransfer, error) {
+	if transfer.AccountID != account.ID || transfer.InitiatorUserID != cmd.InitiatorUserID {
+		return nil, domain.ErrDuplicateRequest.WithMessage("request id %s was used for another transfer", cmd.RequestID)
+	}
+	if transfer.Status != payment.SharedTransferPending {
+		return transfer, nil
+	}
+	approvers, err := m.approvers(account, transfer.InitiatorUserID, transfer.Amount)
+	if err != nil || len(approvers) > 0 {
+		return transfer, err
+	}
+	return m.payNow(ctx, account, transfer)
+}
+
+// payNow pays a transfer that needs no approval. When the ledger refuses it, it is declined so nobody approves it
+// later.
+func (m *Manager) payNow(ctx context.Context, account *wallet.Account, transfer *payment.SharedTransfer) (*payment.SharedTransfer, error) {
+	paid, err := m.pay(ctx, account, transfer, nil)
+	if !domain.IsRefusal(err) {
+		return paid, err
+	}
+	declineErr := m.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		transfer, err := m.transferRepo.LockSharedTransfer(tx, transfer.ID)
+		if err != nil || transfer.Status != payment.SharedTransferPending {
+			return err
+		}
+		now := time.Now()
+		transfer.Status, transfer.DecidedAt = payment.SharedTransferDeclined, &now
+		return m.transferRepo.UpdateSharedTransfer(tx, transfer)
+	})
+	if declineErr != nil {
+		util.Error("Decline refused shared transfer failed", zap.String("shared_transfer_id", transfer.ID.String()), zap.Error(declineErr))
+	}
+	return nil, err
+}
+
+// Approve pays a pending transfer. The approver must be an owner other than the member who made it. Approving a paid
+// transfer returns it as it is.
+func (m *Manager) Approve(ctx context.Context, id uuid.UUID, approverUserID uuid.UUID) (*payment.SharedTransfer, error) {
+	transfer, err := m.transferRepo.GetSharedTransfer(id)
+	if err != nil {
+		return nil, err
+	}
+	account, member, err := m.transferMember(transfer, approverUserID)
+	if err != nil {
+		return nil, err
+	}
+	if member.Role != wallet.RoleOwner {
+		return nil, domain.ErrForbidden.WithMessage("only an owner may approve a transfer")
+	}
+	if transfer.Status == payment.SharedTransferPending && transfer.InitiatorUserID == approverUserID {
+		return nil, domain.ErrForbidden.WithMessage("a second owner has to approve the transfer")
+	}
+	return m.pay(ctx, account, transfer, &approverUserID)
+}
+
+// Decline closes a pending transfer without paying it. Any owner may decline it, as may the member who made it.
+func (m *Manager) Decline(ctx context.Context, id uuid.UUID, userID uuid.UUID) (*payment.SharedTransfer, error) {
+	transfer, err := m.transferRepo.GetSharedTransfer(id)
+	if err != nil {
+		return nil, err
+	}
+	_, member, err := m.transferMember(transfer, userID)
+	if err != nil {
+		return nil, err
+	}
+	if member.Role != wallet.RoleOwner && transfer.InitiatorUserID != userID {
+		return nil, domain.ErrForbidden.WithMessage("only an owner or the member who made it may decline a transfer")
+	}
+	err = m.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var err error
+		transfer, err = m.transferRepo.LockSharedTransfer(tx, id)
+		if err != nil {
+			return err
+		}
+		if err := pending(transfer); err != nil {
+			return err
+		}
+		now := time.Now()
+		transfer.Status, transfer.ApproverUserID, transfer.DecidedAt = payment.SharedTransferDeclined, &userID, &now
+		return m.transferRepo.UpdateSharedTransfer(tx, transfer)
+	})
+	if err != nil {
+		return nil, repository.DBError(err)
+	}
+	return transfer, nil
+}
+
+// pay transfers a pending shared transfer from the holder of account and completes it in one unit of work, a paid
+// transfer is returned as it is. The members may have changed since the transfer was made, so the role and spending
+// limit of the member who made it, and the role of the approver, are checked again under a lock on their memberships.
+func (m *Manager) pay(ctx context.Context, account *wallet.Account, transfer *payment.SharedTransfer, approverUserID *uuid.UUID) (*payment.SharedTransfer, error) {
+	cmd := ledger.TransferCommand{
+		FromUserID:      account.UserID,
+		ToUserID:        transfer.ToUserID,
+		Amount:          transfer.Amount,
+		RequestID:       transfer.TransferRequestID(),
+		InitiatorUserID: transfer.InitiatorUserID,
+	}
+	id := transfer.ID
+	err := m.ledger.PayApproved(ctx, cmd, func(tx ledger.Tx) (bool, error) {
+		var err error
+		transfer, err = tx.LockSharedTransfer(id)
+		if err != nil || transfer.Status == payment.SharedTransferCompleted {
+			return false, err
+		}
+		if err := pending(transfer); err != nil {
+			return false, err
+		}
+		initiator, err := lockMember(tx, account, transfer.InitiatorUserID)
+		if err != nil {
+			return false, err
+		}
+		if !initiator.CanSpend() {
+			return false, domain.ErrForbidden.WithMessage("a %s may not transfer", strings.ToLower(initiator.Role))
+		}
+		if initiator.SpendingLimit > 0 && transfer.Amount > initiator.SpendingLimit {
+			return false, domain.ErrSpendingLimit.WithMessage("a transfer is at most %s", util.DisplayAmount(initiator.SpendingLimit))
+		}
+		if approverUserID == nil {
+			return true, nil
+		}
+		approver, err := lockMember(tx, account, *approverUserID)
+		if err != nil {
+			return false, err
+		}
+		if approver.Role != wallet.RoleOwner {
+			return false, domain.ErrForbidden.WithMessage("only an owner may approve a transfer")
+		}
+		return true, nil
+	}, func(tx ledger.Tx, result *ledger.Result) error {
+		now := time.Now()
+		transfer.Status, transfer.GroupID, transfer.ApproverUserID, transfer.DecidedAt = payment.SharedTransferCompleted, &result.GroupID, approverUserID, &now
+		return tx.UpdateSharedTransfer(transfer)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return transfer, nil
+}
+
+// approvers are the owners who may approve a transfer of amount by initiatorUserID, none when it needs no approval.
+// Without a second owner there is nobody to ask, so the threshold does not apply.
+func (m *Manager) approvers(account *wallet.Account, initiatorUserID uuid.UUID, amount int) ([]uuid.UUID, error) {
+	if account.ApprovalThreshold == 0 || amount <= account.ApprovalThreshold {
+		return nil, nil
+	}
+	members, err := m.memberRepo.SearchMembers(account.ID)
+	if err != nil {
+		return nil, err
+	}
+	var approvers []uuid.UUID
+	for _, member := range append([]wallet.AccountMember{*holder(account)}, members...) {
+		if member.Role == wallet.RoleOwner && member.UserID != initiatorUserID {
+			approvers = append(approvers, member.UserID)
+		}
+	}
+	return approvers, nil
+}
+
+// owner resolves an account the user owns, members with another role are forbidden
+func (m *Manager) owner(accountID uuid.UUID, userID uuid.UUID) (*wallet.Account, error) {
+	account, member, err := m.Member(accountID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if member.Role != wallet.RoleOwner {
+		return nil, domain.ErrForbidden.WithMessage("only an owner may do this")
+	}
+	return account, nil
+}
+
+// transferMember resolves what a user is to the account of a transfer, which is not found for anyone else
+func (m *Manager) transferMember(transfer *payment.SharedTransfer, userID uuid.UUID) (*wallet.Account, *wallet.AccountMember, error) {
+	account, member, err := m.Member(transfer.AccountID, userID)
+	return account, member, domain.NotFound(err, "shared transfer not found")
+}
+
+func (m *Manager) notify(ctx context.Context, userID uuid.UUID, kind string, message string, transfer *payment.SharedTransfer) {
+	data := map[string]interface{}{"shared_transfer_id": transfer.ID, "account_id": transfer.AccountID, "amount": util.DisplayAmount(transfer.Amount)}
+	if err := m.notifier.Notify(context.WithoutCancel(ctx), userID, kind, message, data); err != nil {
+		util.Error("Notify shared transfer failed", zap.String("shared_transfer_id", transfer.ID.String()), zap.Error(err))
+	}
+}
+
+// lockMember locks the membership of a user in account in the unit of work that pays out of it. The holder has no row
+// to lock, a member who left may no longer act on the account.
+func lockMember(tx ledger.Tx, account *wallet.Account, userID uuid.UUID) (*wallet.AccountMember, error) {
+	if userID == account.UserID {
+		return holder(account), nil
+	}
+	member, err := tx.LockMember(account.ID, userID)
+	if errors.Is(err, domain.ErrNotFound) {
+		return nil, domain.ErrForbidden.WithMessage("user %s is no longer a member of the account", userID.String())
+	}
+	return member, err
+}
+
+// holder is the membership the holder of an account has without a row of its own
+func holder(account *wallet.Account) *wallet.AccountMember {
+	return &wallet.AccountMember{
+		AccountID: account.ID,
+		UserID:    account.UserID,
+		Role:      wallet.RoleOwner,
+		CreatedAt: account.CreatedAt,
+		UpdatedAt: account.UpdatedAt,
+	}
+}
+
+func pending(transfer *payment.SharedTransfer) error {
+	if transfer.Status != payment.SharedTransferPending {
+		return domain.ErrSharedTransferClosed.WithMessage("shared transfer is %s", strings.ToLower(transfer.Status))
+	}
+	return nil
+}
@@ -0,0 +1,86 @@
+package ledger
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"github.com/raychongtk/wallet/domain"
+	"github.com/raychongtk/wallet/model/movement"
+	"github.com/raychongtk/wallet/model/wallet"
+	"github.com/raychongtk/wallet/util"
+	"go.uber.org/zap"
+	"strings"
+)
+
+// AdjustCommand corrects a customer wallet by hand. Amount is signed minor units, a positive amount credits the
+// wallet and a negative one debits it. RequestedBy and ApprovedBy are the operators behind the change and must differ.
+type AdjustCommand struct {
+	UserID      uuid.UUID
+	Amount      int
+	Reason      string
+	RequestedBy string
+	ApprovedBy  string
+	RequestID   string
+}
+
+// Adjust posts a manual adjustment. A credit is booked like a deposit and a debit like a withdrawal, so the chart
+// accounts stay balanced and a debit still cannot take the wallet below zero. Frozen wallets may be adjusted, that is
+// usually why an operator looks at them.
+func (l *Ledger) Adjust(ctx context.Context, cmd AdjustCommand) (*Result, error) {
+	if strings.TrimSpace(cmd.Reason) == "" {
+		return nil, domain.ErrInvalidParameters.WithMessage("adjustment needs a reason")
+	}
+	if cmd.RequestedBy == "" || cmd.ApprovedBy == "" || cmd.RequestedBy == cmd.ApprovedBy {
+		return nil, domain.ErrInvalidParameters.WithMessage("adjustment must be approved by a second operator")
+	}
+	amount := cmd.Amount
+	if amount < 0 {
+		amount = -amount
+	}
+	if err := l.ValidAmount(amount); err != nil {
+		return nil, err
+	}
+	customer, err := l.Customer(cmd.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if status := customer.Wallet.WalletStatus; status != wallet.StatusActive && status != wallet.StatusFrozen {
+		return nil, domain.ErrInvalidAccount.WithMessage("wallet is %s", strings.ToLower(status))
+	}
+	result, err := l.post(ctx, newRequest(OperationAdjustment, cmd.RequestID, cmd), func(s stamp) posting {
+		if cmd.Amount > 0 {
+			m, transactions := s.leg(util.GetAssetAccount(), customer.Wallet.ID, amount, amount)
+			return posting{
+				movements:    []movement.Movement{m},
+				transactions: transactions,
+				history:      s.paymentHistory("ADJUSTMENT", "System", "System", cmd.UserID.String(), customer.Name(), amount),
+				changes: []balanceChange{
+					{walletID: customer.Wallet.ID, amount: amount},
+					{walletID: util.GetAssetAccount(), amount: amount},
+				},
+				customers: []uuid.UUID{customer.Wallet.ID},
+			}
+		}
+		m, transactions := s.leg(util.GetLiabilityAccount(), customer.Wallet.ID, amount, -amount)
+		return posting{
+			movements:    []movement.Movement{m},
+			transactions: transactions,
+			history:      s.paymentHistory("ADJUSTMENT", cmd.UserID.String(), customer.Name(), "System", "System", amount),
+			changes: []balanceChange{
+				{walletID: customer.Wallet.ID, amount: -amount, accountType: accountTypeCustomer},
+				{walletID: util.GetLiabilityAccount(), amount: amount},
+			},
+			customers: []uuid.UUID{customer.Wallet.ID},
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+	util.Info("Adjustment successfully",
+		zap.String("user_id", cmd.UserID.String()),
+		zap.Int("balance", cmd.Amount),
+		zap.String("reason", cmd.Reason),
+		zap.String("requested_by", cmd.RequestedBy),
+		zap.String("approved_by", cmd.ApprovedBy),
+	)
+	return result, nil
+}
@@ -0,0 +1,49 @@
+package ledger
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"github.com/raychongtk/wallet/model/movement"
+	"github.com/raychongtk/wallet/util"
+	"go.uber.org/zap"
+)
+
+// DepositCommand adds money from outside to a customer wallet. Amount is in minor units and RequestID is the
+// idempotency key the caller already checked.
+type DepositCommand struct {
+	UserID    uuid.UUID
+	Amount    int
+	RequestID string
+}
+
+// Deposit increases the customer wallet and the asset chart account by the same amount
+func (l *Ledger) Deposit(ctx context.Context, cmd DepositCommand) (*Result, error) {
+	if err := l.ValidAmount(cmd.Amount); err != nil {
+		return nil, err
+	}
+	customer, err := l.ActiveCustomer(cmd.UserID)
+	if err != nil {
+		return nil, err
+	}
+	result, err := l.post(ctx, newRequest(OperationDeposit, cmd.RequestID, cmd), func(s stamp) posting {
+		m, transactions := s.leg(util.GetAssetAccount(), customer.Wallet.ID, cmd.Amount, cmd.Amount)
+		return posting{
+			movements:    []movement.Movement{m},
+			transactions: transactions,
+			history:      s.paymentHistory("DEPOSIT", cmd.UserID.String(), customer.Name(), "System", "System", cmd.Amount),
+			changes: []balanceChange{
+				{walletID: customer.Wallet.ID, amount: cmd.Amount},
+				{walletID: util.GetAssetAccount(), amount: cmd.Amount},
+			},
+			customers: []uuid.UUID{customer.Wallet.ID},
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+	util.Info("Deposit successfully",
+		zap.String("user_id", cmd.UserID.String()),
+		zap.Int("balance", cmd.Amount),
+	)
+	return result, nil
+}
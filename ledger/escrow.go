@@ -0,0 +1,189 @@
+package ledger
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"github.com/raychongtk/wallet/domain"
+	"github.com/raychongtk/wallet/model/movement"
+	"github.com/raychongtk/wallet/model/payment"
+	"github.com/raychongtk/wallet/util"
+	"go.uber.org/zap"
+	"strings"
+	"time"
+)
+
+// An escrow moves the money of the buyer to the escrow chart account when it is created and out of it when it
+// settles, so the escrow account always holds exactly the funded escrows.
+
+const escrowName = "Escrow"
+
+// CreateEscrowCommand funds an escrow of Amount, in minor units, from the wallet of BuyerUserID for SellerUserID.
+// DeadlineAction is applied when it is still funded at Deadline.
+type CreateEscrowCommand struct {
+	BuyerUserID    uuid.UUID
+	SellerUserID   uuid.UUID
+	Amount         int
+	Reference      string
+	Deadline       time.Time
+	DeadlineAction string
+	RequestID      string
+}
+
+// CreateEscrow moves the amount from the buyer to the escrow account and stores the funded escrow in one unit of work
+func (l *Ledger) CreateEscrow(ctx context.Context, cmd CreateEscrowCommand) (*payment.Escrow, error) {
+	if cmd.BuyerUserID == cmd.SellerUserID {
+		return nil, domain.ErrCannotTransferToSelf
+	}
+	if err := l.ValidAmount(cmd.Amount); err != nil {
+		return nil, err
+	}
+	buyer, err := l.Payer(cmd.BuyerUserID)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := l.ActiveCustomer(cmd.SellerUserID); err != nil {
+		return nil, err
+	}
+	var escrow *payment.Escrow
+	err = l.uow.Do(ctx, OperationEscrow, func(tx Tx) error {
+		s := newStamp(ctx, newRequest(OperationEscrow, cmd.RequestID, cmd))
+		in, transactions := s.leg(util.GetEscrowAccount(), buyer.Wallet.ID, cmd.Amount, -cmd.Amount)
+		p := posting{
+			request:      s.request,
+			movements:    []movement.Movement{in},
+			transactions: transactions,
+			history:      s.paymentHistory("ESCROW", cmd.BuyerUserID.String(), buyer.Name(), util.GetEscrowUser().String(), escrowName, cmd.Amount),
+			changes: []balanceChange{
+				{walletID: buyer.Wallet.ID, amount: -cmd.Amount, accountType: accountTypeCustomer},
+				{walletID: util.GetEscrowAccount(), amount: cmd.Amount},
+			},
+			customers: []uuid.UUID{buyer.Wallet.ID},
+		}
+		if _, err := applyAll(tx, []posting{p}); err != nil {
+			return err
+		}
+		escrow = &payment.Escrow{
+			ID:             uuid.New(),
+			BuyerUserID:    cmd.BuyerUserID,
+			SellerUserID:   cmd.SellerUserID,
+			Amount:         cmd.Amount,
+			Reference:      cmd.Reference,
+			Status:         payment.EscrowFunded,
+			Deadline:       cmd.Deadline,
+			DeadlineAction: cmd.DeadlineAction,
+			FundGroupID:    s.groupID,
+			RequestID:      cmd.RequestID,
+			CreatedAt:      s.now,
+			UpdatedAt:      s.now,
+		}
+		if err := tx.CreateEscrow(escrow); err != nil {
+			return err
+		}
+		return sealAll(tx, []posting{p})
+	})
+	if err != nil {
+		return nil, err
+	}
+	util.Info("Escrow successfully", zap.String("escrow_id", escrow.ID.String()), zap.Int("balance", cmd.Amount))
+	return escrow, nil
+}
+
+// SettleEscrowCommand pays SellerAmount of an escrow to the seller and refunds the rest to the buyer. The whole
+// amount releases the escrow, zero refunds it and anything between splits it.
+type SettleEscrowCommand struct {
+	EscrowID     uuid.UUID
+	SellerAmount int
+}
+
+// SettleEscrow moves the money out of the escrow account under a lock on the escrow, the release and the refund are
+// a movement group each. Settling a settled escrow the same way again returns it as it is, any other way fails with
+// domain.ErrEscrowClosed. The buyer may have been frozen since, a refund gives back what was theirs already.
+func (l *Ledger) SettleEscrow(ctx context.Context, cmd SettleEscrowCommand) (*payment.Escrow, error) {
+	var escrow *payment.Escrow
+	settled := false
+	err := l.uow.Do(ctx, OperationEscrow, func(tx Tx) error {
+		var err error
+		settled = false
+		if escrow, err = tx.LockEscrow(cmd.EscrowID); err != nil {
+			return err
+		}
+		if cmd.SellerAmount < 0 || cmd.SellerAmount > escrow.Amount {
+			return domain.ErrInvalidParameters.WithMessage("seller amount is between 0 and the escrow amount")
+		}
+		if escrow.Status != payment.EscrowFunded {
+			if escrow.ReleasedAmount == cmd.SellerAmount {
+				return nil
+			}
+			return domain.ErrEscrowClosed.WithMessage("escrow is %s", strings.ToLower(escrow.Status))
+		}
+		refundAmount := escrow.Amount - cmd.SellerAmount
+		var postings []posting
+		if cmd.SellerAmount > 0 {
+			seller, err := l.ActiveCustomer(escrow.SellerUserID)
+			if err != nil {
+				return err
+			}
+			s := newStamp(ctx, newRequest(OperationEscrow, escrow.ReleaseRequestID(), cmd))
+			postings = append(postings, l.escrowPayment(s, "ESCROW_RELEASE", seller, cmd.SellerAmount))
+			escrow.ReleaseGroupID = &s.groupID
+		}
+		if refundAmount > 0 {
+			buyer, err := l.Customer(escrow.BuyerUserID)
+			if err != nil {
+				return err
+			}
+			s := newStamp(ctx, newRequest(OperationEscrow, escrow.RefundRequestID(), cmd))
+			postings = append(postings, l.escrowPayment(s, "ESCROW_REFUND", buyer, refundAmount))
+			escrow.RefundGroupID = &s.groupID
+		}
+		if _, err := applyAll(tx, postings); err != nil {
+			return err
+		}
+		now := time.Now()
+		escrow.Status, escrow.ReleasedAmount, escrow.RefundedAmount, escrow.SettledAt = escrowStatus(escrow.Amount, cmd.SellerAmount), cmd.SellerAmount, refundAmount, &now
+		if err := tx.UpdateEscrow(escrow); err != nil {
+			return err
+		}
+		settled = true
+		return sealAll(tx, postings)
+	})
+	if err != nil {
+		return nil, err
+	}
+	if settled {
+		util.Info("Settle escrow successfully",
+			zap.String("escrow_id", escrow.ID.String()),
+			zap.String("status", escrow.Status),
+			zap.Int("released", escrow.ReleasedAmount),
+			zap.Int("refunded", escrow.RefundedAmount),
+		)
+	}
+	return escrow, nil
+}
+
+// escrowPayment pays amount out of the escrow account to a customer
+func (l *Ledger) escrowPayment(s stamp, payType string, payee *Customer, amount int) posting {
+	out, transactions := s.leg(payee.Wallet.ID, util.GetEscrowAccount(), amount, -amount)
+	return posting{
+		request:      s.request,
+		movements:    []movement.Movement{out},
+		transactions: transactions,
+		history:      s.paymentHistory(payType, util.GetEscrowUser().String(), escrowName, payee.User.ID.String(), payee.Name(), amount),
+		changes: []balanceChange{
+			{walletID: util.GetEscrowAccount(), amount: -amount, accountType: accountTypeChart},
+			{walletID: payee.Wallet.ID, amount: amount},
+		},
+		customers: []uuid.UUID{payee.Wallet.ID},
+	}
+}
+
+func escrowStatus(amount int, sellerAmount int) string {
+	switch sellerAmount {
+	case amount:
+		return payment.EscrowReleased
+	case 0:
+		return payment.EscrowRefunded
+	default:
+		return payment.EscrowSplit
+	}
+}
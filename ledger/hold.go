@@ -0,0 +1,196 @@
+package ledger
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"github.com/raychongtk/wallet/domain"
+	"github.com/raychongtk/wallet/model/movement"
+	"github.com/raychongtk/wallet/model/wallet"
+	"github.com/raychongtk/wallet/util"
+	"go.uber.org/zap"
+	"strings"
+	"time"
+)
+
+// A hold moves no money. It raises the HELD balance of the wallet, which is taken off the available balance every
+// debit is checked against, until it is captured into a transfer to the merchant, released or expired.
+
+// PlaceHoldCommand reserves Amount, in minor units, of the available balance of UserID for MerchantUserID
+type PlaceHoldCommand struct {
+	UserID         uuid.UUID
+	MerchantUserID uuid.UUID
+	Amount         int
+	Reference      string
+	ExpiresAt      time.Time
+	RequestID      string
+}
+
+// PlaceHold stores an active hold and raises the held balance in one unit of work. It fails with
+// domain.ErrInsufficientFunds when the available balance is lower than the amount.
+func (l *Ledger) PlaceHold(ctx context.Context, cmd PlaceHoldCommand) (*wallet.Hold, error) {
+	if cmd.UserID == cmd.MerchantUserID {
+		return nil, domain.ErrCannotTransferToSelf
+	}
+	if err := l.ValidAmount(cmd.Amount); err != nil {
+		return nil, err
+	}
+	customer, err := l.Payer(cmd.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := l.ActiveCustomer(cmd.MerchantUserID); err != nil {
+		return nil, err
+	}
+	var hold *wallet.Hold
+	err = l.uow.Do(ctx, OperationHold, func(tx Tx) error {
+		if err := tx.LockBalances([]uuid.UUID{customer.Wallet.ID}); err != nil {
+			return err
+		}
+		if err := ensureAvailable(tx, customer.Wallet.ID, cmd.Amount); err != nil {
+			return err
+		}
+		now := time.Now()
+		hold = &wallet.Hold{
+			ID:             uuid.New(),
+			UserID:         cmd.UserID,
+			WalletID:       customer.Wallet.ID,
+			MerchantUserID: cmd.MerchantUserID,
+			Amount:         cmd.Amount,
+			Reference:      cmd.Reference,
+			Status:         wallet.HoldActive,
+			ExpiresAt:      cmd.ExpiresAt,
+			RequestID:      cmd.RequestID,
+			CreatedAt:      now,
+			UpdatedAt:      now,
+		}
+		if err := tx.AddHeld(customer.Wallet.ID, cmd.Amount); err != nil {
+			return err
+		}
+		return tx.CreateHold(hold)
+	})
+	if err != nil {
+		return nil, err
+	}
+	util.Info("Hold successfully", zap.String("hold_id", hold.ID.String()), zap.Int("balance", cmd.Amount))
+	return hold, nil
+}
+
+// ReleaseHold gives the held amount back and closes the hold as RELEASED or EXPIRED. Releasing a hold that was
+// closed the same way already returns it as it is.
+func (l *Ledger) ReleaseHold(ctx context.Context, id uuid.UUID, status string) (*wallet.Hold, error) {
+	var hold *wallet.Hold
+	err := l.uow.Do(ctx, OperationHold, func(tx Tx) error {
+		var err error
+		if hold, err = tx.LockHold(id); err != nil {
+			return err
+		}
+		if hold.Status == status {
+			return nil
+		}
+		if err := active(hold); err != nil {
+			return err
+		}
+		if err := tx.LockBalances([]uuid.UUID{hold.WalletID}); err != nil {
+			return err
+		}
+		if err := tx.AddHeld(hold.WalletID, -hold.Amount); err != nil {
+			return err
+		}
+		now := time.Now()
+		hold.Status, hold.ResolvedAt = status, &now
+		return tx.UpdateHold(hold)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return hold, nil
+}
+
+// CaptureHoldCommand books Amount of a hold, at most the held amount, the rest is given back
+type CaptureHoldCommand struct {
+	HoldID    uuid.UUID
+	Amount    int
+	RequestID string
+}
+
+// CaptureHold transfers the captured amount to the merchant through the liability chart account, like Transfer, and
+// releases the whole hold in the same unit of work. A hold is captured once, capturing it again returns it with a nil
+// result. The wallet may have been frozen since the hold was placed, the money was set aside for the merchant already.
+func (l *Ledger) CaptureHold(ctx context.Context, cmd CaptureHoldCommand) (*wallet.Hold, *Result, error) {
+	if err := l.ValidAmount(cmd.Amount); err != nil {
+		return nil, nil, err
+	}
+	var hold *wallet.Hold
+	var result *Result
+	err := l.uow.Do(ctx, OperationCapture, func(tx Tx) error {
+		var err error
+		result = nil
+		if hold, err = tx.LockHold(cmd.HoldID); err != nil {
+			return err
+		}
+		if hold.Status == wallet.HoldCaptured {
+			return nil
+		}
+		if err := active(hold); err != nil {
+			return err
+		}
+		if !time.Now().Before(hold.ExpiresAt) {
+			return domain.ErrHoldClosed.WithMessage("hold expired")
+		}
+		if cmd.Amount > hold.Amount {
+			return domain.ErrInvalidParameters.WithMessage("capture is above the held amount")
+		}
+		payer, err := l.Customer(hold.UserID)
+		if err != nil {
+			return err
+		}
+		merchant, err := l.ActiveCustomer(hold.MerchantUserID)
+		if err != nil {
+			return err
+		}
+
+		s := newStamp(ctx, newRequest(OperationCapture, cmd.RequestID, cmd))
+		out, outTransactions := s.leg(util.GetLiabilityAccount(), payer.Wallet.ID, cmd.Amount, -cmd.Amount)
+		in, inTransactions := s.leg(merchant.Wallet.ID, util.GetLiabilityAccount(), cmd.Amount, -cmd.Amount)
+		p := posting{
+			request:      s.request,
+			movements:    []movement.Movement{in, out},
+			transactions: append(outTransactions, inTransactions...),
+			history:      s.paymentHistory("CAPTURE", hold.UserID.String(), payer.Name(), hold.MerchantUserID.String(), merchant.Name(), cmd.Amount),
+			changes: []balanceChange{
+				// the whole hold is given back first, so the capture is checked against what it set aside
+				{walletID: payer.Wallet.ID, amount: -hold.Amount, held: true},
+				{walletID: payer.Wallet.ID, amount: -cmd.Amount, accountType: accountTypeCustomer},
+				{walletID: util.GetLiabilityAccount(), amount: cmd.Amount},
+				{walletID: merchant.Wallet.ID, amount: cmd.Amount},
+				{walletID: util.GetLiabilityAccount(), amount: -cmd.Amount, accountType: accountTypeChart},
+			},
+			customers: []uuid.UUID{payer.Wallet.ID, merchant.Wallet.ID},
+		}
+		results, err := applyAll(tx, []posting{p})
+		if err != nil {
+			return err
+		}
+		result = results[0]
+		now := time.Now()
+		hold.Status, hold.CapturedAmount, hold.GroupID, hold.ResolvedAt = wallet.HoldCaptured, cmd.Amount, &s.groupID, &now
+		if err := tx.UpdateHold(hold); err != nil {
+			return err
+		}
+		return sealAll(tx, []posting{p})
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	if result != nil {
+		util.Info("Capture successfully", zap.String("hold_id", hold.ID.String()), zap.Int("balance", cmd.Amount))
+	}
+	return hold, result, nil
+}
+
+func active(hold *wallet.Hold) error {
+	if hold.Status != wallet.HoldActive {
+		return domain.ErrHoldClosed.WithMessage("hold is %s", strings.ToLower(hold.Status))
+	}
+	return nil
+}
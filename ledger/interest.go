@@ -0,0 +1,104 @@
+package ledger
+
+import (
+	"context"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/raychongtk/wallet/model/movement"
+	"github.com/raychongtk/wallet/model/wallet"
+	"github.com/raychongtk/wallet/util"
+	"go.uber.org/zap"
+	"time"
+)
+
+// Accrued interest moves no money. It raises the ACCRUED_INTEREST balance of the wallet every day, the monthly payout
+// moves the accrued amount from the interest expense chart account to the wallet and brings the balance back down.
+
+const interestExpenseName = "Interest"
+
+// AccrueInterest stores the accrual of a day and adds its amount to the accrued interest balance in one unit of work.
+// A wallet accrues a day once, accruing it again stores nothing and reports false.
+func (l *Ledger) AccrueInterest(ctx context.Context, accrual *wallet.InterestAccrual) (bool, error) {
+	created := false
+	err := l.uow.Do(ctx, OperationInterest, func(tx Tx) error {
+		var err error
+		if created, err = tx.CreateAccrual(accrual); err != nil || !created {
+			return err
+		}
+		if accrual.Amount == 0 {
+			return nil
+		}
+		return tx.AddAccruedInterest(accrual.WalletID, accrual.Amount)
+	})
+	if err != nil {
+		return false, err
+	}
+	return created, nil
+}
+
+// PayInterestCommand pays the interest UserID accrued in WalletID before Before
+type PayInterestCommand struct {
+	UserID   uuid.UUID
+	WalletID uuid.UUID
+	Before   time.Time
+}
+
+// PayInterest pays the unpaid accruals of a wallet out of the interest expense account under a lock on the accruals,
+// so they are paid once. Accruals that add up to nothing are marked paid with no movement and a nil result. The wallet
+// may be frozen, the interest was earned already.
+func (l *Ledger) PayInterest(ctx context.Context, cmd PayInterestCommand) (*Result, error) {
+	customer, err := l.Customer(cmd.UserID)
+	if err != nil {
+		return nil, err
+	}
+	var result *Result
+	total := 0
+	err = l.uow.Do(ctx, OperationInterest, func(tx Tx) error {
+		result, total = nil, 0
+		accruals, err := tx.LockUnpaidAccruals(cmd.WalletID, cmd.Before)
+		if err != nil || len(accruals) == 0 {
+			return err
+		}
+		ids := make([]uuid.UUID, 0, len(accruals))
+		for _, accrual := range accruals {
+			ids = append(ids, accrual.ID)
+			total += accrual.Amount
+		}
+		if total == 0 {
+			return tx.MarkAccrualsPaid(ids, nil, time.Now())
+		}
+
+		s := newStamp(ctx, newRequest(OperationInterest, fmt.Sprintf("interest/%s/%s", cmd.WalletID, cmd.Before.Format(time.DateOnly)), cmd))
+		in, transactions := s.leg(cmd.WalletID, util.GetInterestExpenseAccount(), total, -total)
+		p := posting{
+			request:      s.request,
+			movements:    []movement.Movement{in},
+			transactions: transactions,
+			history:      s.paymentHistory("INTEREST", util.GetInterestExpenseUser().String(), interestExpenseName, cmd.UserID.String(), customer.Name(), total),
+			changes: []balanceChange{
+				{walletID: util.GetInterestExpenseAccount(), amount: -total, accountType: accountTypeChart},
+				{walletID: cmd.WalletID, amount: total},
+			},
+			customers: []uuid.UUID{cmd.WalletID},
+		}
+		results, err := applyAll(tx, []posting{p})
+		if err != nil {
+			return err
+		}
+		result = results[0]
+		if err := tx.AddAccruedInterest(cmd.WalletID, -total); err != nil {
+			return err
+		}
+		if err := tx.MarkAccrualsPaid(ids, &s.groupID, s.now); err != nil {
+			return err
+		}
+		return sealAll(tx, []posting{p})
+	})
+	if err != nil {
+		return nil, err
+	}
+	if result != nil {
+		util.Info("Pay interest successfully", zap.String("wallet_id", cmd.WalletID.String()), zap.Int("balance", total))
+	}
+	return result, nil
+}
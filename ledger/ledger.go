@@ -0,0 +1,583 @@
+package ledger
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"github.com/google/uuid"
+	"github.com/raychongtk/wallet/config"
+	"github.com/raychongtk/wallet/domain"
+	"github.com/raychongtk/wallet/fee"
+	"github.com/raychongtk/wallet/model/movement"
+	"github.com/raychongtk/wallet/model/payment"
+	"github.com/raychongtk/wallet/model/user"
+	"github.com/raychongtk/wallet/model/wallet"
+	"github.com/raychongtk/wallet/repository"
+	"github.com/raychongtk/wallet/tracing"
+	"github.com/raychongtk/wallet/util"
+	"strings"
+	"time"
+)
+
+const (
+	OperationDeposit    = "deposit"
+	OperationWithdrawal = "withdrawal"
+	OperationTransfer   = "transfer"
+	OperationAdjustment = "adjustment"
+	OperationPayout     = "payout"
+	OperationHold       = "hold"
+	OperationCapture    = "capture"
+	OperationEscrow     = "escrow"
+	OperationInterest   = "interest"
+	OperationPocket     = "pocket"
+
+	accountTypeCustomer = "CUSTOMER"
+	accountTypeChart    = "CHART"
+)
+
+// Ledger moves money between customer wallets and the chart accounts. It knows nothing about the transport, HTTP
+// handlers, jobs and other protocols all call the same methods and get domain errors back.
+type Ledger struct {
+	userRepo         repository.UserRepository
+	accountRepo      repository.AccountRepository
+	organizationRepo repository.OrganizationRepository
+	walletRepo       repository.WalletRepository
+	memberRepo       repository.MemberRepository
+	uow              UnitOfWork
+	fees             *fee.Schedule
+	maxAmount        int
+}
+
+func ProvideLedger(
+	userRepo repository.UserRepository,
+	accountRepo repository.AccountRepository,
+	organizationRepo repository.OrganizationRepository,
+	walletRepo repository.WalletRepository,
+	memberRepo repository.MemberRepository,
+	uow UnitOfWork,
+	cfg *config.Config,
+) *Ledger {
+	return &Ledger{
+		userRepo:         userRepo,
+		accountRepo:      accountRepo,
+		organizationRepo: organizationRepo,
+		walletRepo:       walletRepo,
+		memberRepo:       memberRepo,
+		uow:              uow,
+		fees:             fee.NewSchedule(cfg.Fees),
+		maxAmount:        cfg.Limits.MaxAmount,
+	}
+}
+
+// Customer is a user with the wallet money moves in and out of. An organization with a business account is a customer
+// too, its User carries the id of the organization so payments name it like anyone else.
+type Customer struct {
+	User         *user.AppUser
+	Organization *wallet.Organization
+	Account      *wallet.Account
+	Wallet       *wallet.Wallet
+}
+
+func (c *Customer) Name() string {
+	if c.Organization != nil {
+		return c.Organization.LegalName
+	}
+	return c.User.FirstName + " " + c.User.LastName
+}
+
+// Result is what a money movement committed. Before and After are the committed balances of the customer wallets
+// involved, keyed by wallet id. A replayed result is the group booked under the request id before, with its movements
+// and no balances since nothing moved.
+type Result struct {
+	GroupID      uuid.UUID
+	WalletID     uuid.UUID
+	Movements    []movement.Movement
+	Transactions []movement.Transaction
+	Before       map[uuid.UUID]int
+	After        map[uuid.UUID]int
+	Replayed     bool
+}
+
+// Customer resolves the wallet of a user named in a command. Unknown users are the caller's mistake so they are
+// reported as an invalid account rather than not found.
+func (l *Ledger) Customer(userID uuid.UUID) (*Customer, error) {
+	customer := &Customer{}
+	appUser, err := l.userRepo.GetUser(userID)
+	if errors.Is(err, domain.ErrNotFound) {
+		if organization, orgErr := l.organizationRepo.GetOrganization(userID); orgErr == nil {
+			appUser, err = &user.AppUser{ID: organization.ID, FirstName: organization.LegalName, Tier: user.TierStandard}, nil
+			customer.Organization = organization
+		} else if !errors.Is(orgErr, domain.ErrNotFound) {
+			err = orgErr
+		}
+	}
+	if err != nil {
+		return nil, invalidAccount(err)
+	}
+	account, err := l.accountRepo.GetAccount(appUser.ID)
+	if err != nil {
+		return nil, invalidAccount(err)
+	}
+	userWallet, err := l.walletRepo.GetWallet(account.ID)
+	if err != nil {
+		return nil, invalidAccount(err)
+	}
+	customer.User, customer.Account, customer.Wallet = appUser, account, userWallet
+	return customer, nil
+}
+
+func invalidAccount(err error) error {
+	if errors.Is(err, domain.ErrNotFound) {
+		return domain.ErrInvalidAccount.Wrap(err)
+	}
+	return err
+}
+
+// ActiveCustomer resolves a customer whose wallet money may move in or out of
+func (l *Ledger) ActiveCustomer(userID uuid.UUID) (*Customer, error) {
+	customer, err := l.Customer(userID)
+	if err != nil {
+		return nil, err
+	}
+	switch customer.Wallet.WalletStatus {
+	case wallet.StatusActive:
+		return customer, nil
+	case wallet.StatusFrozen:
+		return nil, domain.ErrWalletFrozen
+	default:
+		return nil, domain.ErrInvalidAccount.WithMessage("wallet is %s", strings.ToLower(customer.Wallet.WalletStatus))
+	}
+}
+
+// Payer resolves an active customer money is paid out of. A business pays only the transfers its members initiated,
+// which went through its payment policy and are paid with TransferApproved, so it is forbidden anywhere else. So is a
+// shared account, or one with an approval threshold, which pays only through the transfers of the account where the
+// spending limits and approvals of its members apply.
+func (l *Ledger) Payer(userID uuid.UUID) (*Customer, error) {
+	customer, err := l.ActiveCustomer(userID)
+	if err != nil {
+		return nil, err
+	}
+	if customer.Organization != nil {
+		return nil, domain.ErrForbidden.WithMessage("a business pays through the approvals of its members")
+	}
+	if customer.Account.ApprovalThreshold > 0 {
+		return nil, domain.ErrForbidden.WithMessage("an account with an approval threshold pays through its transfers")
+	}
+	members, err := l.memberRepo.SearchMembers(customer.Account.ID)
+	if err != nil {
+		return nil, err
+	}
+	if len(members) > 0 {
+		return nil, domain.ErrForbidden.WithMessage("a shared account pays through its transfers")
+	}
+	return customer, nil
+}
+
+// ValidAmount checks an amount in minor units against the configured single payment limit
+func (l *Ledger) ValidAmount(amount int) error {
+	if amount <= 0 {
+		return domain.ErrInvalidParameters.WithMessage("amount must be positive")
+	}
+	if l.maxAmount > 0 && amount > l.maxAmount {
+		return domain.ErrLimitExceeded
+	}
+	return nil
+}
+
+// FeeQuote is what a withdrawal or a transfer of Amount, in minor units, costs the customer. They pay Fee on top.
+type FeeQuote struct {
+	Operation string
+	Currency  string
+	Amount    int
+	Fee       int
+}
+
+func (q FeeQuote) GrossAmount() int {
+	return q.Amount + q.Fee
+}
+
+// QuoteFee prices a withdrawal or a transfer with the same rules the payment itself is charged with, nothing is posted
+func (l *Ledger) QuoteFee(userID uuid.UUID, operation string, amount int) (*FeeQuote, error) {
+	if operation != OperationWithdrawal && operation != OperationTransfer {
+		return nil, domain.ErrInvalidParameters.WithMessage("operation is withdrawal or transfer")
+	}
+	if err := l.ValidAmount(amount); err != nil {
+		return nil, err
+	}
+	customer, err := l.Customer(userID)
+	if err != nil {
+		return nil, err
+	}
+	return &FeeQuote{Operation: operation, Currency: customer.Wallet.Currency, Amount: amount, Fee: l.fee(operation, customer, amount)}, nil
+}
+
+// fee prices a payment of the customer by the tier of the user and the currency of the wallet
+func (l *Ledger) fee(operation string, customer *Customer, amount int) int {
+	tier := customer.User.Tier
+	if tier == "" {
+		tier = user.TierStandard
+	}
+	return l.fees.Fee(operation, customer.Wallet.Currency, tier, amount)
+}
+
+// posting is everything one money movement writes. It is built again for every attempt of the unit of work.
+type posting struct {
+	movements    []movement.Movement
+	transactions []movement.Transaction
+	history      *payment.PaymentHistory
+	changes      []balanceChange
+	customers    []uuid.UUID
+	request      request
+}
+
+// balanceChange adds a positive amount and deducts a negative one, deducting from a customer wallet fails below its
+// available balance. A held change moves the held balance instead of the committed one.
+type balanceChange struct {
+	walletID    uuid.UUID
+	amount      int
+	accountType string
+	held        bool
+}
+
+// post writes a posting in one unit of work and reports the balances of its customer wallets before and after
+func (l *Ledger) post(ctx context.Context, r request, build func(s stamp) posting) (*Result, error) {
+	results, err := l.postGroups(ctx, r.operation, []request{r}, func(i int, s stamp) posting {
+		return build(s)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return results[0], nil
+}
+
+// postGroups writes one movement group per request in a single unit of work, so either all of them commit or none.
+// A request booked before is not posted again, its result is the group booked then. The balances of every group are
+// locked up front and the groups are sealed last, in order.
+func (l *Ledger) postGroups(ctx context.Context, operation string, requests []request, build func(i int, s stamp) posting) ([]*Result, error) {
+	var results []*Result
+	err := l.uow.Do(ctx, operation, func(tx Tx) error {
+		results = make([]*Result, len(requests))
+		var postings []posting
+		var posted []int
+		for i, r := range requests {
+			s := newStamp(ctx, r)
+			p := build(i, s)
+			p.request = s.request
+			replayed, err := claim(tx, p)
+			if err != nil {
+				return err
+			}
+			if replayed != nil {
+				results[i] = replayed
+				continue
+			}
+			postings, posted = append(postings, p), append(posted, i)
+		}
+		applied, err := applyClaimed(tx, postings)
+		if err != nil {
+			return err
+		}
+		for n, i := range posted {
+			results[i] = applied[n]
+		}
+		return sealAll(tx, postings)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// charge adds the fee the payer pays on top of the amount to the posting, as a leg into the fee revenue chart account
+// in the same movement group
+func (p posting) charge(s stamp, payerWalletID uuid.UUID, fee int) posting {
+	if fee == 0 {
+		return p
+	}
+	m, transactions := s.leg(util.GetFeeRevenueAccount(), payerWalletID, fee, -fee)
+	p.movements = append(p.movements, m)
+	p.transactions = append(p.transactions, transactions...)
+	p.changes = append(p.changes,
+		balanceChange{walletID: payerWalletID, amount: -fee, accountType: accountTypeCustomer},
+		balanceChange{walletID: util.GetFeeRevenueAccount(), amount: fee},
+	)
+	p.history.Fee = fee
+	return p
+}
+
+// applyAll claims the request id of every posting, locks their balances up front and applies them in order. Callers
+// that write records of their own in the same unit of work do it between applyAll and sealAll, a request id booked
+// before fails with domain.ErrDuplicateRequest since those records were written with it.
+func applyAll(tx Tx, postings []posting) ([]*Result, error) {
+	for _, p := range postings {
+		replayed, err := claim(tx, p)
+		if err != nil {
+			return nil, err
+		}
+		if replayed != nil {
+			return nil, domain.ErrDuplicateRequest.WithMessage("request id %s was booked in group %s", p.movements[0].RequestID, replayed.GroupID)
+		}
+	}
+	return applyClaimed(tx, postings)
+}
+
+// claim books the request id of a posting to its group in the unit of work that writes it. A request id booked before
+// for the same command is a replay, its result is the group booked then. Postings without a request id claim nothing.
+func claim(tx Tx, p posting) (*Result, error) {
+	if p.request.id == "" {
+		return nil, nil
+	}
+	booked, err := tx.ClaimRequest(&movement.LedgerRequest{
+		RequestID:   p.request.id,
+		GroupID:     p.movements[0].GroupID,
+		Operation:   p.request.operation,
+		CommandHash: p.request.hash,
+		CreatedAt:   p.movements[0].CreatedAt,
+	})
+	if err != nil || booked == nil {
+		return nil, err
+	}
+	if err := p.request.matches(booked); err != nil {
+		return nil, err
+	}
+	return replayed(tx, booked.GroupID)
+}
+
+func replayed(tx Tx, groupID uuid.UUID) (*Result, error) {
+	movements, err := tx.GroupMovements(groupID)
+	if err != nil {
+		return nil, err
+	}
+	return &Result{GroupID: groupID, Movements: movements, Before: map[uuid.UUID]int{}, After: map[uuid.UUID]int{}, Replayed: true}, nil
+}
+
+// replay finds the result of a request booked before, nil when none was. A command validated again may fail once its
+// wallets changed, so commands that are retried with the same request id look it up first. The claim made when
+// posting still settles two requests racing each other.
+func (l *Ledger) replay(ctx context.Context, r request) (*Result, error) {
+	if r.id == "" {
+		return nil, nil
+	}
+	var result *Result
+	err := l.uow.Do(ctx, r.operation, func(tx Tx) error {
+		booked, err := tx.BookedRequest(r.id)
+		if err != nil || booked == nil {
+			return err
+		}
+		if err := r.matches(booked); err != nil {
+			return err
+		}
+		result, err = replayed(tx, booked.GroupID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// applyClaimed locks the balances of postings whose request ids are claimed and applies them in order
+func applyClaimed(tx Tx, postings []posting) ([]*Result, error) {
+	var walletIDs []uuid.UUID
+	for _, p := range postings {
+		for _, change := range p.changes {
+			walletIDs = append(walletIDs, change.walletID)
+		}
+	}
+	if err := tx.LockBalances(walletIDs); err != nil {
+		return nil, err
+	}
+	results := make([]*Result, 0, len(postings))
+	for _, p := range postings {
+		result, err := apply(tx, p)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+// sealAll appends the movement groups to the hash chain in order, it must be the last write of the unit of work
+func sealAll(tx Tx, postings []posting) error {
+	for _, p := range postings {
+		if err := tx.Seal(p.movements, p.transactions); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// apply writes the rows of a posting and changes the balances, the balances must be locked already
+func apply(tx Tx, p posting) (*Result, error) {
+	if err := tx.CreateMovements(p.movements); err != nil {
+		return nil, err
+	}
+	if err := tx.CreateTransactions(p.transactions); err != nil {
+		return nil, err
+	}
+	// movements that only pass through the chart accounts, such as a payout reservation, show in no history
+	if p.history != nil {
+		if err := tx.CreatePaymentHistory(p.history); err != nil {
+			return nil, err
+		}
+	}
+	// Skip reserved balance because we don't need to wait for external clearing operations
+	net := map[uuid.UUID]int{}
+	for _, change := range p.changes {
+		if change.held {
+			if err := tx.AddHeld(change.walletID, change.amount); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		var err error
+		if change.amount >= 0 {
+			err = tx.AddBalance(change.walletID, change.amount)
+		} else if change.accountType == accountTypeCustomer {
+			if err = ensureAvailable(tx, change.walletID, -change.amount); err == nil {
+				err = tx.DeductBalance(change.walletID, -change.amount, change.accountType)
+			}
+		} else {
+			err = tx.DeductBalance(change.walletID, -change.amount, change.accountType)
+		}
+		if err != nil {
+			return nil, err
+		}
+		net[change.walletID] += change.amount
+	}
+
+	result := &Result{
+		GroupID:      p.movements[0].GroupID,
+		WalletID:     p.customers[0],
+		Movements:    p.movements,
+		Transactions: p.transactions,
+		Before:       map[uuid.UUID]int{},
+		After:        map[uuid.UUID]int{},
+	}
+	// the rows stay locked until commit so the balances before are exactly the ones after minus the changes
+	for _, walletID := range p.customers {
+		balance, err := tx.Balance(walletID)
+		if err != nil {
+			return nil, err
+		}
+		result.After[walletID] = balance
+		result.Before[walletID] = balance - net[walletID]
+	}
+	return result, nil
+}
+
+// ensureAvailable fails with domain.ErrInsufficientFunds when the committed balance less the held one is below amount.
+// The committed balance must be locked already, every change of the held balance locks it first.
+func ensureAvailable(tx Tx, walletID uuid.UUID, amount int) error {
+	balance, err := tx.Balance(walletID)
+	if err != nil {
+		return err
+	}
+	held, err := tx.Held(walletID)
+	if err != nil {
+		return err
+	}
+	if balance-held < amount {
+		return domain.ErrInsufficientFunds
+	}
+	return nil
+}
+
+// request is the request id a posting is booked under with the operation and a hash of the command it came with, a
+// request id reused for another command is not a retry
+type request struct {
+	id        string
+	operation string
+	hash      string
+}
+
+func newRequest(operation string, id string, command any) request {
+	r := request{id: id, operation: operation}
+	if id == "" {
+		return r
+	}
+	// commands are plain structs, marshalling them does not fail
+	data, _ := json.Marshal(command)
+	sum := sha256.Sum256(data)
+	r.hash = hex.EncodeToString(sum[:])
+	return r
+}
+
+// matches fails with domain.ErrDuplicateRequest when the request id was booked for another operation or command
+func (r request) matches(booked *movement.LedgerRequest) error {
+	if booked.Operation != r.operation || booked.CommandHash != r.hash {
+		return domain.ErrDuplicateRequest.WithMessage("request id %s was booked for another %s", r.id, booked.Operation)
+	}
+	return nil
+}
+
+// stamp carries what every row of one attempt shares
+type stamp struct {
+	groupID uuid.UUID
+	traceID string
+	request request
+	now     time.Time
+}
+
+func newStamp(ctx context.Context, r request) stamp {
+	return stamp{groupID: uuid.New(), traceID: tracing.TraceID(ctx), request: r, now: time.Now()}
+}
+
+// leg is one movement of the group with its two transactions: amount on the debit wallet and creditAmount on the
+// credit wallet
+func (s stamp) leg(debitWalletID uuid.UUID, creditWalletID uuid.UUID, amount int, creditAmount int) (movement.Movement, []movement.Transaction) {
+	m := movement.Movement{
+		ID:             uuid.New(),
+		GroupID:        s.groupID,
+		DebitWalletID:  debitWalletID,
+		CreditWalletID: creditWalletID,
+		DebitBalance:   amount,
+		CreditBalance:  creditAmount,
+		MovementStatus: "COMPLETED",
+		TraceID:        s.traceID,
+		RequestID:      s.request.id,
+		CreatedAt:      s.now,
+		UpdatedAt:      s.now,
+	}
+	transactions := []movement.Transaction{
+		{
+			ID:          uuid.New(),
+			MovementID:  m.ID,
+			WalletID:    debitWalletID,
+			BalanceType: balanceType,
+			Balance:     amount,
+			CreatedAt:   s.now,
+			UpdatedAt:   s.now,
+		},
+		{
+			ID:          uuid.New(),
+			MovementID:  m.ID,
+			WalletID:    creditWalletID,
+			BalanceType: balanceType,
+			Balance:     creditAmount,
+			CreatedAt:   s.now,
+			UpdatedAt:   s.now,
+		},
+	}
+	return m, transactions
+}
+
+func (s stamp) paymentHistory(payType string, payerID string, payerName string, payeeID string, payeeName string, amount int) *payment.PaymentHistory {
+	return &payment.PaymentHistory{
+		ID:          uuid.New(),
+		PayerUserId: payerID,
+		PayerName:   payerName,
+		PayeeUserId: payeeID,
+		PayeeName:   payeeName,
+		PayType:     payType,
+		Amount:      amount,
+		TraceID:     s.traceID,
+		RequestID:   s.request.id,
+		CreatedAt:   s.now,
+		UpdatedAt:   s.now,
+	}
+}
@@ -0,0 +1,979 @@
+package ledger
+
+import (
+	"context"
+	"errors"
+	"github.com/google/uuid"
+	"github.com/raychongtk/wallet/config"
+	"github.com/raychongtk/wallet/domain"
+	"github.com/raychongtk/wallet/model/movement"
+	"github.com/raychongtk/wallet/model/payment"
+	"github.com/raychongtk/wallet/model/user"
+	"github.com/raychongtk/wallet/model/wallet"
+	"github.com/raychongtk/wallet/util"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+	"testing"
+	"time"
+)
+
+// memoryLedger keeps users, wallets and balances in maps. A unit of work runs against a copy of the balances that is
+// only kept when fn succeeds, which is all the rollback the ledger relies on.
+type memoryLedger struct {
+	users         map[uuid.UUID]*user.AppUser
+	organizations map[uuid.UUID]*wallet.Organization
+	wallets       map[uuid.UUID]*wallet.Wallet
+	thresholds    map[uuid.UUID]int
+	members       map[uuid.UUID][]wallet.AccountMember
+	balances      map[uuid.UUID]int
+	held          map[uuid.UUID]int
+	holds         map[uuid.UUID]wallet.Hold
+	escrows       map[uuid.UUID]payment.Escrow
+	accrued       map[uuid.UUID]int
+	accruals      map[uuid.UUID]wallet.InterestAccrual
+	pockets       map[uuid.UUID]wallet.Pocket
+	requests      map[string]movement.LedgerRequest
+	shared        map[uuid.UUID]payment.SharedTransfer
+	movements     []movement.Movement
+	histories     []*payment.PaymentHistory
+	attempts      int
+}
+
+func newMemoryLedger() *memoryLedger {
+	return &memoryLedger{
+		users:         map[uuid.UUID]*user.AppUser{},
+		organizations: map[uuid.UUID]*wallet.Organization{},
+		wallets:       map[uuid.UUID]*wallet.Wallet{},
+		thresholds:    map[uuid.UUID]int{},
+		members:       map[uuid.UUID][]wallet.AccountMember{},
+		balances: map[uuid.UUID]int{
+			util.GetAssetAccount():           0,
+			util.GetLiabilityAccount():       0,
+			util.GetEscrowAccount():          0,
+			util.GetFeeRevenueAccount():      0,
+			util.GetInterestExpenseAccount(): 0,
+		},
+		held:     map[uuid.UUID]int{},
+		holds:    map[uuid.UUID]wallet.Hold{},
+		escrows:  map[uuid.UUID]payment.Escrow{},
+		accrued:  map[uuid.UUID]int{},
+		accruals: map[uuid.UUID]wallet.InterestAccrual{},
+		pockets:  map[uuid.UUID]wallet.Pocket{},
+		requests: map[string]movement.LedgerRequest{},
+		shared:   map[uuid.UUID]payment.SharedTransfer{},
+	}
+}
+
+func (m *memoryLedger) addCustomer(name string, balance int, status string) uuid.UUID {
+	userID := uuid.New()
+	m.users[userID] = &user.AppUser{ID: userID, FirstName: name, LastName: "Doe"}
+	// the account shares the user id so GetAccount needs no table of its own
+	m.wallets[userID] = &wallet.Wallet{ID: uuid.New(), AccountID: userID, WalletStatus: status}
+	m.balances[m.wallets[userID].ID] = balance
+	return userID
+}
+
+func (m *memoryLedger) addBusiness(legalName string, balance int) uuid.UUID {
+	organizationID := uuid.New()
+	m.organizations[organizationID] = &wallet.Organization{ID: organizationID, LegalName: legalName}
+	m.wallets[organizationID] = &wallet.Wallet{ID: uuid.New(), AccountID: organizationID, WalletStatus: wallet.StatusActive}
+	m.balances[m.wallets[organizationID].ID] = balance
+	return organizationID
+}
+
+func (m *memoryLedger) GetUser(id uuid.UUID) (*user.AppUser, error) {
+	if appUser, ok := m.users[id]; ok {
+		return appUser, nil
+	}
+	return nil, domain.ErrNotFound
+}
+
+func (m *memoryLedger) CreateOrganization(db *gorm.DB, organization *wallet.Organization, account *wallet.Account, businessWallet *wallet.Wallet) error {
+	return nil
+}
+
+func (m *memoryLedger) GetOrganization(id uuid.UUID) (*wallet.Organization, error) {
+	if organization, ok := m.organizations[id]; ok {
+		return organization, nil
+	}
+	return nil, domain.ErrNotFound
+}
+
+func (m *memoryLedger) GetAccount(userId uuid.UUID) (*wallet.Account, error) {
+	return &wallet.Account{ID: userId, UserID: userId, ApprovalThreshold: m.thresholds[userId]}, nil
+}
+
+func (m *memoryLedger) GetAccountByID(id uuid.UUID) (*wallet.Account, error) {
+	return m.GetAccount(id)
+}
+
+func (m *memoryLedger) UpdateApprovalThreshold(id uuid.UUID, threshold int) error {
+	m.thresholds[id] = threshold
+	return nil
+}
+
+func (m *memoryLedger) GetMember(accountID uuid.UUID, userID uuid.UUID) (*wallet.AccountMember, error) {
+	for _, member := range m.members[accountID] {
+		if member.UserID == userID {
+			return &member, nil
+		}
+	}
+	return nil, domain.ErrNotFound
+}
+
+func (m *memoryLedger) LockMember(db *gorm.DB, accountID uuid.UUID, userID uuid.UUID) (*wallet.AccountMember, error) {
+	return m.GetMember(accountID, userID)
+}
+
+func (m *memoryLedger) SaveMember(member *wallet.AccountMember) error {
+	_ = m.DeleteMember(member.AccountID, member.UserID)
+	m.members[member.AccountID] = append(m.members[member.AccountID], *member)
+	return nil
+}
+
+func (m *memoryLedger) DeleteMember(accountID uuid.UUID, userID uuid.UUID) error {
+	var members []wallet.AccountMember
+	for _, member := range m.members[accountID] {
+		if member.UserID != userID {
+			members = append(members, member)
+		}
+	}
+	m.members[accountID] = members
+	return nil
+}
+
+func (m *memoryLedger) SearchMembers(accountID uuid.UUID) ([]wallet.AccountMember, error) {
+	return m.members[accountID], nil
+}
+
+func (m *memoryLedger) SearchMemberships(userID uuid.UUID) ([]wallet.AccountMember, error) {
+	var memberships []wallet.AccountMember
+	for _, members := range m.members {
+		for _, member := range members {
+			if member.UserID == userID {
+				memberships = append(memberships, member)
+			}
+		}
+	}
+	return memberships, nil
+}
+
+func (m *memoryLedger) GetWallet(accountId uuid.UUID) (*wallet.Wallet, error) {
+	if userWallet, ok := m.wallets[accountId]; ok {
+		return userWallet, nil
+	}
+	return nil, domain.ErrNotFound
+}
+
+func (m *memoryLedger) UpdateWalletStatus(walletID uuid.UUID, status string) error {
+	for _, userWallet := range m.wallets {
+		if userWallet.ID == walletID {
+			userWallet.WalletStatus = status
+			return nil
+		}
+	}
+	return domain.ErrNotFound
+}
+
+func (m *memoryLedger) Do(ctx context.Context, operation string, fn func(tx Tx) error) error {
+	m.attempts++
+	tx := &memoryTx{
+		ledger:   m,
+		balances: map[uuid.UUID]int{},
+		held:     map[uuid.UUID]int{},
+		holds:    map[uuid.UUID]wallet.Hold{},
+		escrows:  map[uuid.UUID]payment.Escrow{},
+		accrued:  map[uuid.UUID]int{},
+		accruals: map[uuid.UUID]wallet.InterestAccrual{},
+		pockets:  map[uuid.UUID]wallet.Pocket{},
+		requests: map[string]movement.LedgerRequest{},
+		shared:   map[uuid.UUID]payment.SharedTransfer{},
+	}
+	for walletID, balance := range m.balances {
+		tx.balances[walletID] = balance
+	}
+	for walletID, held := range m.held {
+		tx.held[walletID] = held
+	}
+	for id, hold := range m.holds {
+		tx.holds[id] = hold
+	}
+	for id, escrow := range m.escrows {
+		tx.escrows[id] = escrow
+	}
+	for walletID, accrued := range m.accrued {
+		tx.accrued[walletID] = accrued
+	}
+	for id, accrual := range m.accruals {
+		tx.accruals[id] = accrual
+	}
+	for id, pocket := range m.pockets {
+		tx.pockets[id] = pocket
+	}
+	for requestID, request := range m.requests {
+		tx.requests[requestID] = request
+	}
+	for id, transfer := range m.shared {
+		tx.shared[id] = transfer
+	}
+	if err := fn(tx); err != nil {
+		return err
+	}
+	m.balances, m.held, m.holds, m.escrows, m.accrued, m.accruals, m.pockets = tx.balances, tx.held, tx.holds, tx.escrows, tx.accrued, tx.accruals, tx.pockets
+	m.requests, m.shared = tx.requests, tx.shared
+	m.movements = append(m.movements, tx.movements...)
+	m.histories = append(m.histories, tx.histories...)
+	return nil
+}
+
+type memoryTx struct {
+	ledger    *memoryLedger
+	balances  map[uuid.UUID]int
+	held      map[uuid.UUID]int
+	holds     map[uuid.UUID]wallet.Hold
+	escrows   map[uuid.UUID]payment.Escrow
+	accrued   map[uuid.UUID]int
+	accruals  map[uuid.UUID]wallet.InterestAccrual
+	pockets   map[uuid.UUID]wallet.Pocket
+	requests  map[string]movement.LedgerRequest
+	shared    map[uuid.UUID]payment.SharedTransfer
+	movements []movement.Movement
+	histories []*payment.PaymentHistory
+}
+
+func (t *memoryTx) LockBalances(walletIDs []uuid.UUID) error {
+	return nil
+}
+
+func (t *memoryTx) Balance(walletID uuid.UUID) (int, error) {
+	return t.balances[walletID], nil
+}
+
+func (t *memoryTx) AddBalance(walletID uuid.UUID, amount int) error {
+	t.balances[walletID] += amount
+	return nil
+}
+
+func (t *memoryTx) DeductBalance(walletID uuid.UUID, amount int, accountType string) error {
+	if accountType == accountTypeCustomer && t.balances[walletID] < amount {
+		return domain.ErrInsufficientFunds
+	}
+	t.balances[walletID] -= amount
+	return nil
+}
+
+func (t *memoryTx) CreateMovements(movements []movement.Movement) error {
+	t.movements = append(t.movements, movements...)
+	return nil
+}
+
+func (t *memoryTx) CreateTransactions(transactions []movement.Transaction) error {
+	return nil
+}
+
+func (t *memoryTx) CreatePaymentHistory(history *payment.PaymentHistory) error {
+	t.histories = append(t.histories, history)
+	return nil
+}
+
+func (t *memoryTx) ClaimRequest(request *movement.LedgerRequest) (*movement.LedgerRequest, error) {
+	if booked, ok := t.requests[request.RequestID]; ok {
+		return &booked, nil
+	}
+	t.requests[request.RequestID] = *request
+	return nil, nil
+}
+
+func (t *memoryTx) BookedRequest(requestID string) (*movement.LedgerRequest, error) {
+	if booked, ok := t.requests[requestID]; ok {
+		return &booked, nil
+	}
+	return nil, nil
+}
+
+func (t *memoryTx) GroupMovements(groupID uuid.UUID) ([]movement.Movement, error) {
+	var movements []movement.Movement
+	for _, m := range t.ledger.movements {
+		if m.GroupID == groupID {
+			movements = append(movements, m)
+		}
+	}
+	return movements, nil
+}
+
+func (t *memoryTx) Held(walletID uuid.UUID) (int, error) {
+	return t.held[walletID], nil
+}
+
+func (t *memoryTx) AddHeld(walletID uuid.UUID, amount int) error {
+	t.held[walletID] += amount
+	return nil
+}
+
+func (t *memoryTx) CreateHold(hold *wallet.Hold) error {
+	t.holds[hold.ID] = *hold
+	return nil
+}
+
+func (t *memoryTx) LockHold(id uuid.UUID) (*wallet.Hold, error) {
+	hold, ok := t.holds[id]
+	if !ok {
+		return nil, domain.ErrNotFound
+	}
+	return &hold, nil
+}
+
+func (t *memoryTx) UpdateHold(hold *wallet.Hold) error {
+	t.holds[hold.ID] = *hold
+	return nil
+}
+
+func (t *memoryTx) CreateEscrow(escrow *payment.Escrow) error {
+	t.escrows[escrow.ID] = *escrow
+	return nil
+}
+
+func (t *memoryTx) LockEscrow(id uuid.UUID) (*payment.Escrow, error) {
+	escrow, ok := t.escrows[id]
+	if !ok {
+		return nil, domain.ErrNotFound
+	}
+	return &escrow, nil
+}
+
+func (t *memoryTx) UpdateEscrow(escrow *payment.Escrow) error {
+	t.escrows[escrow.ID] = *escrow
+	return nil
+}
+
+func (t *memoryTx) CreateAccrual(accrual *wallet.InterestAccrual) (bool, error) {
+	for _, existing := range t.accruals {
+		if existing.WalletID == accrual.WalletID && existing.Day.Equal(accrual.Day) {
+			return false, nil
+		}
+	}
+	t.accruals[accrual.ID] = *accrual
+	return true, nil
+}
+
+func (t *memoryTx) AddAccruedInterest(walletID uuid.UUID, amount int) error {
+	t.accrued[walletID] += amount
+	return nil
+}
+
+func (t *memoryTx) LockUnpaidAccruals(walletID uuid.UUID, before time.Time) ([]wallet.InterestAccrual, error) {
+	var accruals []wallet.InterestAccrual
+	for _, accrual := range t.accruals {
+		if accrual.WalletID == walletID && accrual.Day.Before(before) && accrual.PaidAt == nil {
+			accruals = append(accruals, accrual)
+		}
+	}
+	return accruals, nil
+}
+
+func (t *memoryTx) MarkAccrualsPaid(ids []uuid.UUID, groupID *uuid.UUID, paidAt time.Time) error {
+	for _, id := range ids {
+		accrual := t.accruals[id]
+		accrual.PayoutGroupID, accrual.PaidAt = groupID, &paidAt
+		t.accruals[id] = accrual
+	}
+	return nil
+}
+
+func (t *memoryTx) CreatePocket(pocket *wallet.Pocket, pocketWallet *wallet.Wallet) error {
+	for _, existing := range t.pockets {
+		if existing.UserID == pocket.UserID && existing.Name == pocket.Name {
+			return domain.ErrInvalidParameters
+		}
+	}
+	t.pockets[pocket.ID] = *pocket
+	t.balances[pocketWallet.ID] = 0
+	return nil
+}
+
+func (t *memoryTx) LockPocket(id uuid.UUID) (*wallet.Pocket, error) {
+	pocket, ok := t.pockets[id]
+	if !ok {
+		return nil, domain.ErrNotFound
+	}
+	return &pocket, nil
+}
+
+func (t *memoryTx) LockMember(accountID uuid.UUID, userID uuid.UUID) (*wallet.AccountMember, error) {
+	return t.ledger.GetMember(accountID, userID)
+}
+
+func (t *memoryTx) LockSharedTransfer(id uuid.UUID) (*payment.SharedTransfer, error) {
+	transfer, ok := t.shared[id]
+	if !ok {
+		return nil, domain.ErrNotFound
+	}
+	return &transfer, nil
+}
+
+func (t *memoryTx) UpdateSharedTransfer(transfer *payment.SharedTransfer) error {
+	t.shared[transfer.ID] = *transfer
+	return nil
+}
+
+func (t *memoryTx) LockBusinessTransfer(id uuid.UUID) (*payment.BusinessTransfer, error) {
+	return nil, domain.ErrNotFound
+}
+
+func (t *memoryTx) UpdateBusinessTransfer(transfer *payment.BusinessTransfer) error {
+	return domain.ErrNotFound
+}
+
+func (t *memoryTx) SearchBusinessApprovals(transferID uuid.UUID) ([]payment.BusinessApproval, error) {
+	return nil, nil
+}
+
+func (t *memoryTx) Seal(movements []movement.Movement, transactions []movement.Transaction) error {
+	return nil
+}
+
+func newTestLedger(maxAmount int) (*Ledger, *memoryLedger) {
+	util.InitializeLogger(false)
+	m := newMemoryLedger()
+	cfg := &config.Config{Limits: config.LimitsConfig{MaxAmount: maxAmount}}
+	return ProvideLedger(m, m, m, m, m, m, cfg), m
+}
+
+func TestDepositAndTransfer(t *testing.T) {
+	l, m := newTestLedger(0)
+	john := m.addCustomer("John", 0, wallet.StatusActive)
+	ray := m.addCustomer("Ray", 0, wallet.StatusActive)
+
+	result, err := l.Deposit(context.Background(), DepositCommand{UserID: john, Amount: 10000, RequestID: "deposit"})
+	assert.NoError(t, err)
+	johnWallet := m.wallets[john].ID
+	assert.Equal(t, johnWallet, result.WalletID)
+	assert.Equal(t, 0, result.Before[johnWallet])
+	assert.Equal(t, 10000, result.After[johnWallet])
+	assert.Equal(t, "deposit", result.Movements[0].RequestID)
+
+	result, err = l.Transfer(context.Background(), TransferCommand{FromUserID: john, ToUserID: ray, Amount: 2500})
+	assert.NoError(t, err)
+	rayWallet := m.wallets[ray].ID
+	assert.Equal(t, 7500, result.After[johnWallet])
+	assert.Equal(t, 2500, result.After[rayWallet])
+	assert.Equal(t, 0, result.Before[rayWallet])
+	// a transfer ends where it started on the liability chart account
+	assert.Equal(t, 0, m.balances[util.GetLiabilityAccount()])
+	assert.Equal(t, "John Doe", m.histories[1].PayerName)
+	assert.Equal(t, "Ray Doe", m.histories[1].PayeeName)
+	for _, mv := range result.Movements {
+		assert.Equal(t, result.GroupID, mv.GroupID)
+	}
+	assert.Empty(t, m.histories[1].InitiatorUserId)
+
+	// a member of a shared account pays from the holder and is recorded on the payment
+	_, err = l.Transfer(context.Background(), TransferCommand{FromUserID: john, ToUserID: ray, Amount: 500, InitiatorUserID: ray})
+	assert.NoError(t, err)
+	assert.Equal(t, ray.String(), m.histories[2].InitiatorUserId)
+	assert.Equal(t, -500, m.histories[2].SignedAmount(john.String()))
+}
+
+func TestRequestIDBooksOneGroup(t *testing.T) {
+	l, m := newTestLedger(0)
+	john := m.addCustomer("John", 10000, wallet.StatusActive)
+	ray := m.addCustomer("Ray", 0, wallet.StatusActive)
+	ctx := context.Background()
+
+	first, err := l.Transfer(ctx, TransferCommand{FromUserID: john, ToUserID: ray, Amount: 2500, RequestID: "transfer"})
+	assert.NoError(t, err)
+	assert.False(t, first.Replayed)
+	// posting the request again, after its caller failed to record it, returns the group booked the first time
+	again, err := l.Transfer(ctx, TransferCommand{FromUserID: john, ToUserID: ray, Amount: 2500, RequestID: "transfer"})
+	assert.NoError(t, err)
+	assert.True(t, again.Replayed)
+	assert.Equal(t, first.GroupID, again.GroupID)
+	assert.Len(t, again.Movements, 2)
+	assert.Equal(t, 7500, m.balances[m.wallets[john].ID])
+	assert.Len(t, m.histories, 1)
+	// it is found before the wallets are checked again, so a payer frozen since still gets the booked group
+	_, err = l.SetWalletStatus(john, wallet.StatusFrozen)
+	assert.NoError(t, err)
+	again, err = l.Transfer(ctx, TransferCommand{FromUserID: john, ToUserID: ray, Amount: 2500, RequestID: "transfer"})
+	assert.NoError(t, err)
+	assert.Equal(t, first.GroupID, again.GroupID)
+	_, err = l.SetWalletStatus(john, wallet.StatusActive)
+	assert.NoError(t, err)
+
+	// the request id reused for another command is refused, before the command is validated again
+	_, err = l.Transfer(ctx, TransferCommand{FromUserID: john, ToUserID: ray, Amount: 9000, RequestID: "transfer"})
+	assert.ErrorIs(t, err, domain.ErrDuplicateRequest)
+	_, err = l.Deposit(ctx, DepositCommand{UserID: john, Amount: 2500, RequestID: "transfer"})
+	assert.ErrorIs(t, err, domain.ErrDuplicateRequest)
+	assert.Equal(t, 7500, m.balances[m.wallets[john].ID])
+
+	// a batch posts the lines it has not booked yet
+	_, err = l.ReservePayout(ctx, PayoutReservationCommand{UserID: john, Amount: 200, RequestID: "reserve"})
+	assert.NoError(t, err)
+	line := PayoutLine{UserID: ray, Amount: 100, RequestID: "first-line"}
+	results, err := l.Payout(ctx, PayoutCommand{FunderID: john, Lines: []PayoutLine{line}})
+	assert.NoError(t, err)
+	booked := results[0].GroupID
+	results, err = l.Payout(ctx, PayoutCommand{FunderID: john, Lines: []PayoutLine{line, {UserID: ray, Amount: 100, RequestID: "line"}}})
+	assert.NoError(t, err)
+	assert.Equal(t, booked, results[0].GroupID)
+	assert.True(t, results[0].Replayed)
+	assert.False(t, results[1].Replayed)
+	assert.Equal(t, 2700, m.balances[m.wallets[ray].ID])
+	// a line booked under the request id of another command fails the whole batch
+	_, err = l.Payout(ctx, PayoutCommand{FunderID: john, Lines: []PayoutLine{{UserID: ray, Amount: 100, RequestID: "transfer"}}})
+	assert.ErrorIs(t, err, domain.ErrDuplicateRequest)
+	assert.Equal(t, 2700, m.balances[m.wallets[ray].ID])
+
+	// a movement that writes records of its own with the request id refuses to book it twice
+	hold, err := l.PlaceHold(ctx, PlaceHoldCommand{UserID: john, MerchantUserID: ray, Amount: 100, ExpiresAt: time.Now().Add(time.Hour)})
+	assert.NoError(t, err)
+	_, _, err = l.CaptureHold(ctx, CaptureHoldCommand{HoldID: hold.ID, Amount: 100, RequestID: "line"})
+	assert.ErrorIs(t, err, domain.ErrDuplicateRequest)
+	assert.Equal(t, 100, m.held[m.wallets[john].ID])
+}
+
+// payApproved pays an approved transfer that has no row of its own
+func payApproved(l *Ledger, cmd TransferCommand) (*Result, error) {
+	var paid *Result
+	err := l.PayApproved(context.Background(), cmd, func(tx Tx) (bool, error) {
+		return true, nil
+	}, func(tx Tx, result *Result) error {
+		paid = result
+		return nil
+	})
+	return paid, err
+}
+
+func TestBusinessPaysOnlyTransfersOfItsMembers(t *testing.T) {
+	l, m := newTestLedger(0)
+	acme := m.addBusiness("Acme Ltd", 10000)
+	ray := m.addCustomer("Ray", 5000, wallet.StatusActive)
+
+	// anyone pays a business, but money leaves it only through a transfer one of its members initiated
+	_, err := l.Transfer(context.Background(), TransferCommand{FromUserID: ray, ToUserID: acme, Amount: 1000})
+	assert.NoError(t, err)
+	assert.Equal(t, "Acme Ltd", m.histories[0].PayeeName)
+	_, err = l.Withdraw(context.Background(), WithdrawCommand{UserID: acme, Amount: 100})
+	assert.ErrorIs(t, err, domain.ErrForbidden)
+	_, err = l.Transfer(context.Background(), TransferCommand{FromUserID: acme, ToUserID: ray, Amount: 100})
+	assert.ErrorIs(t, err, domain.ErrForbidden)
+	// naming an initiator is not an approval
+	_, err = l.Transfer(context.Background(), TransferCommand{FromUserID: acme, ToUserID: ray, Amount: 100, InitiatorUserID: ray})
+	assert.ErrorIs(t, err, domain.ErrForbidden)
+
+	result, err := payApproved(l, TransferCommand{FromUserID: acme, ToUserID: ray, Amount: 2000, InitiatorUserID: ray})
+	assert.NoError(t, err)
+	assert.Equal(t, 9000, result.After[m.wallets[acme].ID])
+	assert.Equal(t, ray.String(), m.histories[1].InitiatorUserId)
+	assert.Len(t, m.histories, 2)
+}
+
+func TestSharedAccountPaysOnlyThroughItsTransfers(t *testing.T) {
+	l, m := newTestLedger(0)
+	john := m.addCustomer("John", 10000, wallet.StatusActive)
+	ray := m.addCustomer("Ray", 0, wallet.StatusActive)
+	ctx := context.Background()
+
+	// the approval threshold applies to the holder as well, so the wallet endpoints cannot go around it
+	assert.NoError(t, m.UpdateApprovalThreshold(john, 1000))
+	_, err := l.Withdraw(ctx, WithdrawCommand{UserID: john, Amount: 5000})
+	assert.ErrorIs(t, err, domain.ErrForbidden)
+	assert.NoError(t, m.UpdateApprovalThreshold(john, 0))
+
+	// nor the spending limits and roles of the members the account is shared with
+	assert.NoError(t, m.SaveMember(&wallet.AccountMember{AccountID: john, UserID: ray, Role: wallet.RoleSpender, SpendingLimit: 100}))
+	_, err = l.Transfer(ctx, TransferCommand{FromUserID: john, ToUserID: ray, Amount: 5000})
+	assert.ErrorIs(t, err, domain.ErrForbidden)
+	_, err = l.PlaceHold(ctx, PlaceHoldCommand{UserID: john, MerchantUserID: ray, Amount: 100, ExpiresAt: time.Now().Add(time.Hour)})
+	assert.ErrorIs(t, err, domain.ErrForbidden)
+	// money still comes in
+	_, err = l.Deposit(ctx, DepositCommand{UserID: john, Amount: 100})
+	assert.NoError(t, err)
+
+	result, err := payApproved(l, TransferCommand{FromUserID: john, ToUserID: ray, Amount: 100, InitiatorUserID: ray})
+	assert.NoError(t, err)
+	assert.Equal(t, 10000, result.After[m.wallets[john].ID])
+
+	assert.NoError(t, m.DeleteMember(john, ray))
+	_, err = l.Withdraw(ctx, WithdrawCommand{UserID: john, Amount: 100})
+	assert.NoError(t, err)
+}
+
+func TestPayApprovedCompletesTheRowInTheSameUnitOfWork(t *testing.T) {
+	l, m := newTestLedger(0)
+	john := m.addCustomer("John", 10000, wallet.StatusActive)
+	ray := m.addCustomer("Ray", 0, wallet.StatusActive)
+	ctx := context.Background()
+	transfer := payment.SharedTransfer{ID: uuid.New(), AccountID: john, InitiatorUserID: ray, ToUserID: ray, Amount: 2500, Status: payment.SharedTransferPending, RequestID: "shared"}
+	m.shared[transfer.ID] = transfer
+	cmd := TransferCommand{FromUserID: john, ToUserID: ray, Amount: 2500, RequestID: transfer.TransferRequestID(), InitiatorUserID: ray}
+	lock := func(tx Tx) (bool, error) {
+		locked, err := tx.LockSharedTransfer(transfer.ID)
+		return err == nil && locked.Status == payment.SharedTransferPending, err
+	}
+	complete := func(tx Tx, result *Result) error {
+		transfer.Status, transfer.GroupID = payment.SharedTransferCompleted, &result.GroupID
+		return tx.UpdateSharedTransfer(&transfer)
+	}
+
+	// a row that fails to complete takes the transfer with it
+	failed := errors.New("connection reset by peer")
+	err := l.PayApproved(ctx, cmd, lock, func(tx Tx, result *Result) error { return failed })
+	assert.ErrorIs(t, err, failed)
+	assert.Equal(t, 10000, m.balances[m.wallets[john].ID])
+	assert.Empty(t, m.movements)
+	assert.Empty(t, m.requests)
+
+	assert.NoError(t, l.PayApproved(ctx, cmd, lock, complete))
+	assert.Equal(t, payment.SharedTransferCompleted, m.shared[transfer.ID].Status)
+	assert.Equal(t, *m.shared[transfer.ID].GroupID, m.movements[0].GroupID)
+	// a completed row is not paid again, whatever became of its payer since
+	_, err = l.SetWalletStatus(john, wallet.StatusFrozen)
+	assert.NoError(t, err)
+	assert.NoError(t, l.PayApproved(ctx, cmd, lock, complete))
+	assert.Equal(t, 7500, m.balances[m.wallets[john].ID])
+	assert.Equal(t, 2500, m.balances[m.wallets[ray].ID])
+	assert.Len(t, m.histories, 1)
+}
+
+func TestRejectedMovementsLeaveBalancesUntouched(t *testing.T) {
+	l, m := newTestLedger(50000)
+	john := m.addCustomer("John", 1000, wallet.StatusActive)
+	ray := m.addCustomer("Ray", 0, wallet.StatusActive)
+	frozen := m.addCustomer("Frozen", 1000, wallet.StatusFrozen)
+	ctx := context.Background()
+
+	_, err := l.Withdraw(ctx, WithdrawCommand{UserID: john, Amount: 1001})
+	assert.ErrorIs(t, err, domain.ErrInsufficientFunds)
+	_, err = l.Transfer(ctx, TransferCommand{FromUserID: john, ToUserID: ray, Amount: 1001})
+	assert.ErrorIs(t, err, domain.ErrInsufficientFunds)
+	_, err = l.Transfer(ctx, TransferCommand{FromUserID: john, ToUserID: john, Amount: 100})
+	assert.ErrorIs(t, err, domain.ErrCannotTransferToSelf)
+	_, err = l.Transfer(ctx, TransferCommand{FromUserID: john, ToUserID: frozen, Amount: 100})
+	assert.ErrorIs(t, err, domain.ErrWalletFrozen)
+	_, err = l.Deposit(ctx, DepositCommand{UserID: john, Amount: 50001})
+	assert.ErrorIs(t, err, domain.ErrLimitExceeded)
+	_, err = l.Deposit(ctx, DepositCommand{UserID: john, Amount: 0})
+	assert.ErrorIs(t, err, domain.ErrInvalidParameters)
+	_, err = l.Deposit(ctx, DepositCommand{UserID: uuid.New(), Amount: 100})
+	assert.ErrorIs(t, err, domain.ErrInvalidAccount)
+
+	assert.Equal(t, 1000, m.balances[m.wallets[john].ID])
+	assert.Equal(t, 0, m.balances[m.wallets[ray].ID])
+	assert.Empty(t, m.movements)
+	// only the two insufficient funds attempts got as far as a unit of work
+	assert.Equal(t, 2, m.attempts)
+}
+
+func TestCustomerMayBeFrozen(t *testing.T) {
+	l, m := newTestLedger(0)
+	frozen := m.addCustomer("Frozen", 1000, wallet.StatusFrozen)
+
+	customer, err := l.Customer(frozen)
+	assert.NoError(t, err)
+	assert.Equal(t, "Frozen Doe", customer.Name())
+	assert.Equal(t, m.wallets[frozen].ID, customer.Wallet.ID)
+}
+
+func TestAdjustNeedsASecondOperator(t *testing.T) {
+	l, m := newTestLedger(0)
+	frozen := m.addCustomer("Frozen", 1000, wallet.StatusFrozen)
+	ctx := context.Background()
+	frozenWallet := m.wallets[frozen].ID
+
+	_, err := l.Adjust(ctx, AdjustCommand{UserID: frozen, Amount: 500, Reason: "refund", RequestedBy: "alice", ApprovedBy: "alice"})
+	assert.ErrorIs(t, err, domain.ErrInvalidParameters)
+	_, err = l.Adjust(ctx, AdjustCommand{UserID: frozen, Amount: 500, RequestedBy: "alice", ApprovedBy: "bob"})
+	assert.ErrorIs(t, err, domain.ErrInvalidParameters)
+	_, err = l.Adjust(ctx, AdjustCommand{UserID: frozen, Amount: -1001, Reason: "chargeback", RequestedBy: "alice", ApprovedBy: "bob"})
+	assert.ErrorIs(t, err, domain.ErrInsufficientFunds)
+
+	// frozen wallets may be corrected, a credit books like a deposit and a debit like a withdrawal
+	result, err := l.Adjust(ctx, AdjustCommand{UserID: frozen, Amount: 500, Reason: "refund", RequestedBy: "alice", ApprovedBy: "bob"})
+	assert.NoError(t, err)
+	assert.Equal(t, 1500, result.After[frozenWallet])
+	result, err = l.Adjust(ctx, AdjustCommand{UserID: frozen, Amount: -300, Reason: "chargeback", RequestedBy: "alice", ApprovedBy: "bob"})
+	assert.NoError(t, err)
+	assert.Equal(t, 1200, result.After[frozenWallet])
+	assert.Equal(t, 500, m.balances[util.GetAssetAccount()])
+	assert.Equal(t, 300, m.balances[util.GetLiabilityAccount()])
+	assert.Equal(t, 500, m.histories[0].SignedAmount(frozen.String()))
+	assert.Equal(t, -300, m.histories[1].SignedAmount(frozen.String()))
+}
+
+func TestSetWalletStatus(t *testing.T) {
+	l, m := newTestLedger(0)
+	john := m.addCustomer("John", 1000, wallet.StatusActive)
+	ctx := context.Background()
+
+	before, err := l.SetWalletStatus(john, wallet.StatusFrozen)
+	assert.NoError(t, err)
+	assert.Equal(t, m.wallets[john].ID, before.Wallet.ID)
+	_, err = l.Withdraw(ctx, WithdrawCommand{UserID: john, Amount: 100})
+	assert.ErrorIs(t, err, domain.ErrWalletFrozen)
+	_, err = l.SetWalletStatus(john, wallet.StatusFrozen)
+	assert.ErrorIs(t, err, domain.ErrInvalidParameters)
+	_, err = l.SetWalletStatus(john, "CLOSED")
+	assert.ErrorIs(t, err, domain.ErrInvalidParameters)
+
+	_, err = l.SetWalletStatus(john, wallet.StatusActive)
+	assert.NoError(t, err)
+	_, err = l.Withdraw(ctx, WithdrawCommand{UserID: john, Amount: 100})
+	assert.NoError(t, err)
+}
+
+func TestPayoutReservesPaysAndRefunds(t *testing.T) {
+	l, m := newTestLedger(0)
+	funder := m.addCustomer("Funder", 1000, wallet.StatusActive)
+	alice := m.addCustomer("Alice", 0, wallet.StatusActive)
+	bob := m.addCustomer("Bob", 0, wallet.StatusFrozen)
+	ctx := context.Background()
+	liability := util.GetLiabilityAccount()
+
+	_, err := l.ReservePayout(ctx, PayoutReservationCommand{UserID: funder, Amount: 1001, RequestID: "batch-1/reserve"})
+	assert.ErrorIs(t, err, domain.ErrInsufficientFunds)
+	_, err = l.ReservePayout(ctx, PayoutReservationCommand{UserID: funder, Amount: 600, RequestID: "batch-1/reserve"})
+	assert.NoError(t, err)
+	assert.Equal(t, 400, m.balances[m.wallets[funder].ID])
+	assert.Equal(t, 600, m.balances[liability])
+	assert.Empty(t, m.histories)
+
+	_, err = l.Payout(ctx, PayoutCommand{FunderID: funder, Lines: []PayoutLine{{UserID: funder, Amount: 100, RequestID: "batch-1/1"}}})
+	assert.ErrorIs(t, err, domain.ErrCannotTransferToSelf)
+
+	// a chunk is one unit of work with a movement group per line, recipients frozen since validation are still paid
+	results, err := l.Payout(ctx, PayoutCommand{FunderID: funder, Lines: []PayoutLine{
+		{UserID: alice, Amount: 200, RequestID: "batch-1/1"},
+		{UserID: bob, Amount: 100, RequestID: "batch-1/2"},
+	}})
+	assert.NoError(t, err)
+	assert.Len(t, results, 2)
+	assert.NotEqual(t, results[0].GroupID, results[1].GroupID)
+	assert.Equal(t, 200, m.balances[m.wallets[alice].ID])
+	assert.Equal(t, 100, m.balances[m.wallets[bob].ID])
+	assert.Equal(t, 300, m.balances[liability])
+	assert.Len(t, m.histories, 2)
+	assert.Equal(t, -200, m.histories[0].SignedAmount(funder.String()))
+	assert.Equal(t, 200, m.histories[0].SignedAmount(alice.String()))
+
+	_, err = l.RefundPayout(ctx, PayoutReservationCommand{UserID: funder, Amount: 300, RequestID: "batch-1/refund"})
+	assert.NoError(t, err)
+	assert.Equal(t, 700, m.balances[m.wallets[funder].ID])
+	assert.Equal(t, 0, m.balances[liability])
+}
+
+func TestHoldsReduceTheAvailableBalance(t *testing.T) {
+	l, m := newTestLedger(0)
+	customer := m.addCustomer("Customer", 1000, wallet.StatusActive)
+	merchant := m.addCustomer("Merchant", 0, wallet.StatusActive)
+	ctx := context.Background()
+	customerWallet := m.wallets[customer].ID
+	expiresAt := time.Now().Add(time.Hour)
+
+	hold, err := l.PlaceHold(ctx, PlaceHoldCommand{UserID: customer, MerchantUserID: merchant, Amount: 600, ExpiresAt: expiresAt})
+	assert.NoError(t, err)
+	assert.Equal(t, 600, m.held[customerWallet])
+	assert.Equal(t, 1000, m.balances[customerWallet])
+
+	// the held money can neither be held again nor spent
+	_, err = l.PlaceHold(ctx, PlaceHoldCommand{UserID: customer, MerchantUserID: merchant, Amount: 500, ExpiresAt: expiresAt})
+	assert.ErrorIs(t, err, domain.ErrInsufficientFunds)
+	_, err = l.Transfer(ctx, TransferCommand{FromUserID: customer, ToUserID: merchant, Amount: 401, RequestID: "transfer-1"})
+	assert.ErrorIs(t, err, domain.ErrInsufficientFunds)
+
+	_, _, err = l.CaptureHold(ctx, CaptureHoldCommand{HoldID: hold.ID, Amount: 601, RequestID: "capture-1"})
+	assert.ErrorIs(t, err, domain.ErrInvalidParameters)
+	captured, result, err := l.CaptureHold(ctx, CaptureHoldCommand{HoldID: hold.ID, Amount: 450, RequestID: "capture-1"})
+	assert.NoError(t, err)
+	assert.Equal(t, wallet.HoldCaptured, captured.Status)
+	assert.Equal(t, result.GroupID, *captured.GroupID)
+	assert.Equal(t, 0, m.held[customerWallet])
+	assert.Equal(t, 550, m.balances[customerWallet])
+	assert.Equal(t, 450, m.balances[m.wallets[merchant].ID])
+	assert.Equal(t, 0, m.balances[util.GetLiabilityAccount()])
+	assert.Equal(t, "CAPTURE", m.histories[0].PayType)
+
+	// a retried capture books nothing
+	again, result, err := l.CaptureHold(ctx, CaptureHoldCommand{HoldID: hold.ID, Amount: 450, RequestID: "capture-2"})
+	assert.NoError(t, err)
+	assert.Nil(t, result)
+	assert.Equal(t, captured.GroupID, again.GroupID)
+	assert.Len(t, m.histories, 1)
+	_, err = l.ReleaseHold(ctx, hold.ID, wallet.HoldReleased)
+	assert.ErrorIs(t, err, domain.ErrHoldClosed)
+
+	released, err := l.PlaceHold(ctx, PlaceHoldCommand{UserID: customer, MerchantUserID: merchant, Amount: 550, ExpiresAt: expiresAt})
+	assert.NoError(t, err)
+	released, err = l.ReleaseHold(ctx, released.ID, wallet.HoldReleased)
+	assert.NoError(t, err)
+	assert.Equal(t, wallet.HoldReleased, released.Status)
+	assert.Equal(t, 0, m.held[customerWallet])
+	_, _, err = l.CaptureHold(ctx, CaptureHoldCommand{HoldID: released.ID, Amount: 100, RequestID: "capture-3"})
+	assert.ErrorIs(t, err, domain.ErrHoldClosed)
+}
+
+func TestEscrowMovesThroughTheEscrowAccount(t *testing.T) {
+	l, m := newTestLedger(0)
+	buyer := m.addCustomer("Buyer", 1000, wallet.StatusActive)
+	seller := m.addCustomer("Seller", 0, wallet.StatusActive)
+	ctx := context.Background()
+	buyerWallet, sellerWallet := m.wallets[buyer].ID, m.wallets[seller].ID
+	create := CreateEscrowCommand{BuyerUserID: buyer, SellerUserID: seller, Amount: 600, Deadline: time.Now().Add(time.Hour), DeadlineAction: payment.EscrowActionRefund}
+
+	escrow, err := l.CreateEscrow(ctx, create)
+	assert.NoError(t, err)
+	assert.Equal(t, payment.EscrowFunded, escrow.Status)
+	assert.Equal(t, 400, m.balances[buyerWallet])
+	assert.Equal(t, 600, m.balances[util.GetEscrowAccount()])
+	assert.Equal(t, -600, m.histories[0].SignedAmount(buyer.String()))
+
+	_, err = l.SettleEscrow(ctx, SettleEscrowCommand{EscrowID: escrow.ID, SellerAmount: 601})
+	assert.ErrorIs(t, err, domain.ErrInvalidParameters)
+	split, err := l.SettleEscrow(ctx, SettleEscrowCommand{EscrowID: escrow.ID, SellerAmount: 250})
+	assert.NoError(t, err)
+	assert.Equal(t, payment.EscrowSplit, split.Status)
+	assert.Equal(t, 350, split.RefundedAmount)
+	assert.NotEqual(t, *split.ReleaseGroupID, *split.RefundGroupID)
+	assert.Equal(t, 750, m.balances[buyerWallet])
+	assert.Equal(t, 250, m.balances[sellerWallet])
+	assert.Equal(t, 0, m.balances[util.GetEscrowAccount()])
+
+	// settling the same way again books nothing, any other way is refused
+	again, err := l.SettleEscrow(ctx, SettleEscrowCommand{EscrowID: escrow.ID, SellerAmount: 250})
+	assert.NoError(t, err)
+	assert.Equal(t, split.ReleaseGroupID, again.ReleaseGroupID)
+	assert.Len(t, m.histories, 3)
+	_, err = l.SettleEscrow(ctx, SettleEscrowCommand{EscrowID: escrow.ID, SellerAmount: 600})
+	assert.ErrorIs(t, err, domain.ErrEscrowClosed)
+
+	// a frozen buyer still gets a refund
+	refunded, err := l.CreateEscrow(ctx, create)
+	assert.NoError(t, err)
+	assert.NoError(t, m.UpdateWalletStatus(buyerWallet, wallet.StatusFrozen))
+	refunded, err = l.SettleEscrow(ctx, SettleEscrowCommand{EscrowID: refunded.ID})
+	assert.NoError(t, err)
+	assert.Equal(t, payment.EscrowRefunded, refunded.Status)
+	assert.Nil(t, refunded.ReleaseGroupID)
+	assert.Equal(t, 750, m.balances[buyerWallet])
+	assert.Equal(t, 0, m.balances[util.GetEscrowAccount()])
+}
+
+func TestFeesArePaidOnTop(t *testing.T) {
+	util.InitializeLogger(false)
+	m := newMemoryLedger()
+	cfg := &config.Config{Fees: config.FeeConfig{Rules: []config.FeeRule{
+		{Operation: OperationWithdrawal, Type: config.FeeFlat, Flat: 100},
+		{Operation: OperationTransfer, Tier: user.TierStandard, Type: config.FeePercentage, RateBps: 100, Min: 50},
+	}}}
+	l := ProvideLedger(m, m, m, m, m, m, cfg)
+	john := m.addCustomer("John", 10000, wallet.StatusActive)
+	ray := m.addCustomer("Ray", 0, wallet.StatusActive)
+	ctx := context.Background()
+	johnWallet := m.wallets[john].ID
+
+	quote, err := l.QuoteFee(john, OperationTransfer, 2000)
+	assert.NoError(t, err)
+	assert.Equal(t, 50, quote.Fee)
+	assert.Equal(t, 2050, quote.GrossAmount())
+	_, err = l.QuoteFee(john, OperationDeposit, 2000)
+	assert.ErrorIs(t, err, domain.ErrInvalidParameters)
+
+	result, err := l.Transfer(ctx, TransferCommand{FromUserID: john, ToUserID: ray, Amount: 8000})
+	assert.NoError(t, err)
+	assert.Len(t, result.Movements, 3)
+	assert.Equal(t, 1920, m.balances[johnWallet])
+	assert.Equal(t, 8000, m.balances[m.wallets[ray].ID])
+	assert.Equal(t, 80, m.balances[util.GetFeeRevenueAccount()])
+	assert.Equal(t, 0, m.balances[util.GetLiabilityAccount()])
+	assert.Equal(t, 80, m.histories[0].Fee)
+	assert.Equal(t, -8080, m.histories[0].SignedAmount(john.String()))
+	assert.Equal(t, 8000, m.histories[0].SignedAmount(ray.String()))
+
+	// the fee counts against the balance too
+	_, err = l.Withdraw(ctx, WithdrawCommand{UserID: john, Amount: 1900})
+	assert.ErrorIs(t, err, domain.ErrInsufficientFunds)
+	_, err = l.Withdraw(ctx, WithdrawCommand{UserID: john, Amount: 1820})
+	assert.NoError(t, err)
+	assert.Equal(t, 0, m.balances[johnWallet])
+	assert.Equal(t, 180, m.balances[util.GetFeeRevenueAccount()])
+}
+
+func TestInterestIsAccruedOnceAndPaidMonthly(t *testing.T) {
+	l, m := newTestLedger(0)
+	saver := m.addCustomer("Saver", 10000, wallet.StatusActive)
+	saverWallet := m.wallets[saver].ID
+	ctx := context.Background()
+	accrual := func(day time.Time, amount int) *wallet.InterestAccrual {
+		return &wallet.InterestAccrual{ID: uuid.New(), WalletID: saverWallet, UserID: saver, Day: day, Balance: 10000, Amount: amount}
+	}
+
+	first := time.Date(2026, 9, 29, 0, 0, 0, 0, time.UTC)
+	for i, amount := range []int{3, 2, 4} {
+		created, err := l.AccrueInterest(ctx, accrual(first.AddDate(0, 0, i), amount))
+		assert.NoError(t, err)
+		assert.True(t, created)
+	}
+	// a day accrued again is left as it was
+	created, err := l.AccrueInterest(ctx, accrual(first, 3))
+	assert.NoError(t, err)
+	assert.False(t, created)
+	assert.Equal(t, 9, m.accrued[saverWallet])
+	assert.Equal(t, 10000, m.balances[saverWallet])
+
+	// September is paid, the first of October keeps accruing
+	result, err := l.PayInterest(ctx, PayInterestCommand{UserID: saver, WalletID: saverWallet, Before: time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)})
+	assert.NoError(t, err)
+	assert.Equal(t, 10005, result.After[saverWallet])
+	assert.Equal(t, -5, m.balances[util.GetInterestExpenseAccount()])
+	assert.Equal(t, 4, m.accrued[saverWallet])
+	assert.Equal(t, "INTEREST", m.histories[0].PayType)
+	assert.Equal(t, 5, m.histories[0].SignedAmount(saver.String()))
+
+	result, err = l.PayInterest(ctx, PayInterestCommand{UserID: saver, WalletID: saverWallet, Before: time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)})
+	assert.NoError(t, err)
+	assert.Nil(t, result)
+	assert.Len(t, m.histories, 1)
+}
+
+func TestPocketsKeepMoneyInsideTheAccount(t *testing.T) {
+	l, m := newTestLedger(0)
+	saver := m.addCustomer("Saver", 10000, wallet.StatusActive)
+	other := m.addCustomer("Other", 0, wallet.StatusActive)
+	saverWallet := m.wallets[saver].ID
+	ctx := context.Background()
+
+	targetDate := time.Now().AddDate(0, 1, 0)
+	_, err := l.CreatePocket(ctx, CreatePocketCommand{UserID: saver, Name: "Locked", Locked: true})
+	assert.ErrorIs(t, err, domain.ErrInvalidParameters)
+	holiday, err := l.CreatePocket(ctx, CreatePocketCommand{UserID: saver, Name: " Holiday ", GoalAmount: 50000, TargetDate: &targetDate, Locked: true})
+	assert.NoError(t, err)
+	assert.Equal(t, "Holiday", holiday.Name)
+	_, err = l.CreatePocket(ctx, CreatePocketCommand{UserID: saver, Name: "Holiday"})
+	assert.ErrorIs(t, err, domain.ErrInvalidParameters)
+
+	result, err := l.MoveToPocket(ctx, PocketCommand{UserID: saver, PocketID: holiday.ID, Amount: 4000})
+	assert.NoError(t, err)
+	assert.Equal(t, 6000, result.After[saverWallet])
+	assert.Equal(t, 4000, result.After[holiday.WalletID])
+	// nothing passes through a chart account
+	assert.Equal(t, 0, m.balances[util.GetLiabilityAccount()])
+	assert.Equal(t, -4000, m.histories[0].SignedAmount(saver.String()))
+
+	_, err = l.MoveToPocket(ctx, PocketCommand{UserID: saver, PocketID: holiday.ID, Amount: 6001})
+	assert.ErrorIs(t, err, domain.ErrInsufficientFunds)
+	_, err = l.MoveFromPocket(ctx, PocketCommand{UserID: saver, PocketID: holiday.ID, Amount: 1000})
+	assert.ErrorIs(t, err, domain.ErrPocketLocked)
+	_, err = l.MoveToPocket(ctx, PocketCommand{UserID: other, PocketID: holiday.ID, Amount: 1})
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+
+	// once unlocked the pocket gives its money back
+	unlocked := m.pockets[holiday.ID]
+	unlocked.Locked = false
+	m.pockets[holiday.ID] = unlocked
+	result, err = l.MoveFromPocket(ctx, PocketCommand{UserID: saver, PocketID: holiday.ID, Amount: 1000})
+	assert.NoError(t, err)
+	assert.Equal(t, 7000, result.After[saverWallet])
+	assert.Equal(t, 3000, result.After[holiday.WalletID])
+	assert.Equal(t, 1000, m.histories[1].SignedAmount(saver.String()))
+}
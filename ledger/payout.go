@@ -0,0 +1,137 @@
+package ledger
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"github.com/raychongtk/wallet/domain"
+	"github.com/raychongtk/wallet/model/movement"
+	"github.com/raychongtk/wallet/util"
+	"go.uber.org/zap"
+)
+
+// A payout batch is paid in three steps. The total is reserved from the funding wallet into the liability chart
+// account, the lines are paid out of it chunk by chunk, and whatever was not paid is refunded at the end. Only the
+// lines show in the payment history, so the funder sees one payment per recipient.
+
+// PayoutReservationCommand takes the total of a payout batch out of the funding wallet. Amount is in minor units.
+type PayoutReservationCommand struct {
+	UserID    uuid.UUID
+	Amount    int
+	RequestID string
+}
+
+// ReservePayout moves the total of a batch from the funding wallet to the liability chart account. It fails with
+// domain.ErrInsufficientFunds when the funding wallet cannot cover the batch. The single payment limit applies to
+// the lines, not to their total. A batch reserved before gets the group booked then.
+func (l *Ledger) ReservePayout(ctx context.Context, cmd PayoutReservationCommand) (*Result, error) {
+	r := newRequest(OperationPayout, cmd.RequestID, cmd)
+	if replayed, err := l.replay(ctx, r); err != nil || replayed != nil {
+		return replayed, err
+	}
+	if cmd.Amount <= 0 {
+		return nil, domain.ErrInvalidParameters.WithMessage("amount must be positive")
+	}
+	funder, err := l.Payer(cmd.UserID)
+	if err != nil {
+		return nil, err
+	}
+	return l.post(ctx, r, func(s stamp) posting {
+		m, transactions := s.leg(util.GetLiabilityAccount(), funder.Wallet.ID, cmd.Amount, -cmd.Amount)
+		return posting{
+			movements:    []movement.Movement{m},
+			transactions: transactions,
+			changes: []balanceChange{
+				{walletID: funder.Wallet.ID, amount: -cmd.Amount, accountType: accountTypeCustomer},
+				{walletID: util.GetLiabilityAccount(), amount: cmd.Amount},
+			},
+			customers: []uuid.UUID{funder.Wallet.ID},
+		}
+	})
+}
+
+// RefundPayout returns the reserved money a batch did not pay out to the funding wallet, frozen or not
+func (l *Ledger) RefundPayout(ctx context.Context, cmd PayoutReservationCommand) (*Result, error) {
+	if cmd.Amount <= 0 {
+		return nil, domain.ErrInvalidParameters.WithMessage("amount must be positive")
+	}
+	funder, err := l.Customer(cmd.UserID)
+	if err != nil {
+		return nil, err
+	}
+	return l.post(ctx, newRequest(OperationPayout, cmd.RequestID, cmd), func(s stamp) posting {
+		m, transactions := s.leg(funder.Wallet.ID, util.GetLiabilityAccount(), cmd.Amount, -cmd.Amount)
+		return posting{
+			movements:    []movement.Movement{m},
+			transactions: transactions,
+			changes: []balanceChange{
+				{walletID: funder.Wallet.ID, amount: cmd.Amount},
+				{walletID: util.GetLiabilityAccount(), amount: -cmd.Amount, accountType: accountTypeChart},
+			},
+			customers: []uuid.UUID{funder.Wallet.ID},
+		}
+	})
+}
+
+// PayoutLine credits one recipient, RequestID makes its movement group traceable to the line
+type PayoutLine struct {
+	UserID    uuid.UUID
+	Amount    int
+	RequestID string
+}
+
+// PayoutCommand pays lines of a batch whose total was reserved already
+type PayoutCommand struct {
+	FunderID uuid.UUID
+	Lines    []PayoutLine
+}
+
+// Payout pays every line out of the liability chart account in one unit of work, one movement group per line. The
+// money was reserved when the batch started, so recipients are credited whatever the status of their wallet, callers
+// that want to skip frozen wallets check before.
+func (l *Ledger) Payout(ctx context.Context, cmd PayoutCommand) ([]*Result, error) {
+	if len(cmd.Lines) == 0 {
+		return nil, nil
+	}
+	funder, err := l.Customer(cmd.FunderID)
+	if err != nil {
+		return nil, err
+	}
+	recipients := make([]*Customer, len(cmd.Lines))
+	requests := make([]request, len(cmd.Lines))
+	for i, line := range cmd.Lines {
+		if err := l.ValidAmount(line.Amount); err != nil {
+			return nil, err
+		}
+		if line.UserID == cmd.FunderID {
+			return nil, domain.ErrCannotTransferToSelf
+		}
+		if recipients[i], err = l.Customer(line.UserID); err != nil {
+			return nil, err
+		}
+		requests[i] = newRequest(OperationPayout, line.RequestID, line)
+	}
+	results, err := l.postGroups(ctx, OperationPayout, requests, func(i int, s stamp) posting {
+		line, recipient := cmd.Lines[i], recipients[i]
+		m, transactions := s.leg(recipient.Wallet.ID, util.GetLiabilityAccount(), line.Amount, -line.Amount)
+		return posting{
+			movements:    []movement.Movement{m},
+			transactions: transactions,
+			history:      s.paymentHistory("PAYOUT", cmd.FunderID.String(), funder.Name(), line.UserID.String(), recipient.Name(), line.Amount),
+			changes: []balanceChange{
+				// customer asset increased
+				{walletID: recipient.Wallet.ID, amount: line.Amount},
+				// company liability decreased
+				{walletID: util.GetLiabilityAccount(), amount: -line.Amount, accountType: accountTypeChart},
+			},
+			customers: []uuid.UUID{recipient.Wallet.ID},
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+	util.Info("Payout successfully",
+		zap.String("funder_user_id", cmd.FunderID.String()),
+		zap.Int("lines", len(cmd.Lines)),
+	)
+	return results, nil
+}
@@ -0,0 +1,159 @@
+package ledger
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"github.com/raychongtk/wallet/domain"
+	"github.com/raychongtk/wallet/model/movement"
+	"github.com/raychongtk/wallet/model/wallet"
+	"github.com/raychongtk/wallet/util"
+	"go.uber.org/zap"
+	"strings"
+	"time"
+)
+
+// A pocket is a wallet of its own under the account of the user. Money moves between the main wallet and a pocket in
+// one leg with no chart account in between, it never leaves the user.
+
+const (
+	PayTypePocketDeposit    = "POCKET_DEPOSIT"
+	PayTypePocketWithdrawal = "POCKET_WITHDRAWAL"
+
+	maxPocketNameLength = 50
+)
+
+// CreatePocketCommand opens a pocket named Name for UserID. GoalAmount, in minor units, and TargetDate are optional, a
+// Locked pocket needs a TargetDate and gives nothing back before it.
+type CreatePocketCommand struct {
+	UserID     uuid.UUID
+	Name       string
+	GoalAmount int
+	TargetDate *time.Time
+	Locked     bool
+}
+
+// CreatePocket stores the pocket with a wallet in the currency of the main wallet in one unit of work
+func (l *Ledger) CreatePocket(ctx context.Context, cmd CreatePocketCommand) (*wallet.Pocket, error) {
+	name := strings.TrimSpace(cmd.Name)
+	if name == "" || len(name) > maxPocketNameLength {
+		return nil, domain.ErrInvalidParameters.WithMessage("name is 1 to %d characters", maxPocketNameLength)
+	}
+	if cmd.GoalAmount < 0 {
+		return nil, domain.ErrInvalidParameters.WithMessage("goal amount must not be negative")
+	}
+	if cmd.TargetDate != nil && !cmd.TargetDate.After(time.Now()) {
+		return nil, domain.ErrInvalidParameters.WithMessage("target date must be in the future")
+	}
+	if cmd.Locked && cmd.TargetDate == nil {
+		return nil, domain.ErrInvalidParameters.WithMessage("a locked pocket needs a target date")
+	}
+	customer, err := l.Payer(cmd.UserID)
+	if err != nil {
+		return nil, err
+	}
+	var pocket *wallet.Pocket
+	err = l.uow.Do(ctx, OperationPocket, func(tx Tx) error {
+		now := time.Now()
+		pocketWallet := &wallet.Wallet{
+			ID:           uuid.New(),
+			AccountID:    customer.Wallet.AccountID,
+			Currency:     customer.Wallet.Currency,
+			DecimalPlace: customer.Wallet.DecimalPlace,
+			WalletStatus: wallet.StatusActive,
+			Kind:         wallet.KindPocket,
+			CreatedAt:    now,
+			UpdatedAt:    now,
+		}
+		pocket = &wallet.Pocket{
+			ID:         uuid.New(),
+			UserID:     cmd.UserID,
+			WalletID:   pocketWallet.ID,
+			Name:       name,
+			GoalAmount: cmd.GoalAmount,
+			TargetDate: cmd.TargetDate,
+			Locked:     cmd.Locked,
+			CreatedAt:  now,
+			UpdatedAt:  now,
+		}
+		return tx.CreatePocket(pocket, pocketWallet)
+	})
+	if err != nil {
+		return nil, err
+	}
+	util.Info("Create pocket successfully", zap.String("pocket_id", pocket.ID.String()))
+	return pocket, nil
+}
+
+// PocketCommand moves Amount, in minor units, between the main wallet of UserID and one of their pockets
+type PocketCommand struct {
+	UserID    uuid.UUID
+	PocketID  uuid.UUID
+	Amount    int
+	RequestID string
+}
+
+// MoveToPocket sets money of the main wallet aside in a pocket, out of what is available to spend
+func (l *Ledger) MoveToPocket(ctx context.Context, cmd PocketCommand) (*Result, error) {
+	return l.movePocket(ctx, cmd, PayTypePocketDeposit)
+}
+
+// MoveFromPocket gives money of a pocket back to the main wallet. It fails with domain.ErrPocketLocked while the
+// pocket is locked.
+func (l *Ledger) MoveFromPocket(ctx context.Context, cmd PocketCommand) (*Result, error) {
+	return l.movePocket(ctx, cmd, PayTypePocketWithdrawal)
+}
+
+// movePocket posts one leg between the main wallet and the pocket. A pocket of another user is not found, so its id
+// tells nothing.
+func (l *Ledger) movePocket(ctx context.Context, cmd PocketCommand, payType string) (*Result, error) {
+	if err := l.ValidAmount(cmd.Amount); err != nil {
+		return nil, err
+	}
+	customer, err := l.Payer(cmd.UserID)
+	if err != nil {
+		return nil, err
+	}
+	var result *Result
+	err = l.uow.Do(ctx, OperationPocket, func(tx Tx) error {
+		pocket, err := tx.LockPocket(cmd.PocketID)
+		if err != nil {
+			return err
+		}
+		if pocket.UserID != cmd.UserID {
+			return domain.ErrNotFound.WithMessage("pocket %s not found", cmd.PocketID)
+		}
+		s := newStamp(ctx, newRequest(OperationPocket, cmd.RequestID, []any{payType, cmd}))
+		from, to := customer.Wallet.ID, pocket.WalletID
+		history := s.paymentHistory(payType, cmd.UserID.String(), customer.Name(), cmd.UserID.String(), pocket.Name, cmd.Amount)
+		if payType == PayTypePocketWithdrawal {
+			if pocket.LockedAt(s.now) {
+				return domain.ErrPocketLocked.WithMessage("pocket is locked until %s", pocket.TargetDate.Format(time.DateOnly))
+			}
+			from, to = pocket.WalletID, customer.Wallet.ID
+			history = s.paymentHistory(payType, cmd.UserID.String(), pocket.Name, cmd.UserID.String(), customer.Name(), cmd.Amount)
+		}
+		leg, transactions := s.leg(to, from, cmd.Amount, -cmd.Amount)
+		p := posting{
+			request:      s.request,
+			movements:    []movement.Movement{leg},
+			transactions: transactions,
+			history:      history,
+			changes: []balanceChange{
+				{walletID: from, amount: -cmd.Amount, accountType: accountTypeCustomer},
+				{walletID: to, amount: cmd.Amount},
+			},
+			customers: []uuid.UUID{customer.Wallet.ID, pocket.WalletID},
+		}
+		results, err := applyAll(tx, []posting{p})
+		if err != nil {
+			return err
+		}
+		result = results[0]
+		return sealAll(tx, []posting{p})
+	})
+	if err != nil {
+		return nil, err
+	}
+	util.Info("Move pocket successfully", zap.String("pocket_id", cmd.PocketID.String()), zap.String("pay_type", payType), zap.Int("balance", cmd.Amount))
+	return result, nil
+}
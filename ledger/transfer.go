@@ -0,0 +1,155 @@
+package ledger
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"github.com/raychongtk/wallet/domain"
+	"github.com/raychongtk/wallet/model/movement"
+	"github.com/raychongtk/wallet/util"
+	"go.uber.org/zap"
+)
+
+// TransferCommand moves money from the wallet of one customer to another. Amount is in minor units. InitiatorUserID is
+// the member who made a transfer out of the shared or business account of FromUserID, zero when FromUserID made it. It
+// is kept on the payment and authorizes nothing.
+type TransferCommand struct {
+	FromUserID      uuid.UUID
+	ToUserID        uuid.UUID
+	Amount          int
+	RequestID       string
+	InitiatorUserID uuid.UUID
+}
+
+// Transfer moves money through the liability chart account, which ends where it started, and charges the payer its fee.
+// It fails with domain.ErrInsufficientFunds when the payer balance is lower than the amount and the fee. A request id
+// booked before returns the group booked then.
+func (l *Ledger) Transfer(ctx context.Context, cmd TransferCommand) (*Result, error) {
+	return l.transfer(ctx, cmd, l.Payer)
+}
+
+// PayApproved pays a transfer out of a shared or business account that the policy of the account approved, which Payer
+// refuses for any other transfer. Only the managers enforcing those policies call it. The transfer is kept in a row of
+// its own, committed as pending before it is paid, and the row and the transfer are one unit of work: lock reads the
+// row under a lock and reports whether it is paid now, and complete records the booked group on it. The payer, payee
+// and amount of a pending row do not change, so callers read cmd off the row before and the customers are resolved
+// before anything is locked.
+func (l *Ledger) PayApproved(ctx context.Context, cmd TransferCommand, lock func(tx Tx) (bool, error), complete func(tx Tx, result *Result) error) error {
+	r := newRequest(OperationTransfer, cmd.RequestID, cmd)
+	// a row that is paid already is returned by its caller whatever its customers are now, so failing to resolve them
+	// only matters once the row is to be paid
+	payer, payee, resolveErr := l.parties(cmd, l.ActiveCustomer)
+	fee := 0
+	if resolveErr == nil {
+		fee = l.fee(OperationTransfer, payer, cmd.Amount)
+	}
+	var result *Result
+	err := l.uow.Do(ctx, OperationTransfer, func(tx Tx) error {
+		result = nil
+		pay, err := lock(tx)
+		if err != nil || !pay {
+			return err
+		}
+		if resolveErr != nil {
+			return resolveErr
+		}
+		p := transferPosting(newStamp(ctx, r), cmd, payer, payee, fee)
+		replayed, err := claim(tx, p)
+		if err != nil {
+			return err
+		}
+		if replayed != nil {
+			result = replayed
+			return complete(tx, replayed)
+		}
+		results, err := applyClaimed(tx, []posting{p})
+		if err != nil {
+			return err
+		}
+		result = results[0]
+		if err := complete(tx, result); err != nil {
+			return err
+		}
+		return sealAll(tx, []posting{p})
+	})
+	if err != nil || result == nil || result.Replayed {
+		return err
+	}
+	logTransfer(cmd, fee)
+	return nil
+}
+
+func (l *Ledger) transfer(ctx context.Context, cmd TransferCommand, resolvePayer func(userID uuid.UUID) (*Customer, error)) (*Result, error) {
+	r := newRequest(OperationTransfer, cmd.RequestID, cmd)
+	if replayed, err := l.replay(ctx, r); err != nil || replayed != nil {
+		return replayed, err
+	}
+	payer, payee, err := l.parties(cmd, resolvePayer)
+	if err != nil {
+		return nil, err
+	}
+	fee := l.fee(OperationTransfer, payer, cmd.Amount)
+	result, err := l.post(ctx, r, func(s stamp) posting {
+		return transferPosting(s, cmd, payer, payee, fee)
+	})
+	if err != nil {
+		return nil, err
+	}
+	logTransfer(cmd, fee)
+	return result, nil
+}
+
+// parties validates a transfer and resolves its payer and payee
+func (l *Ledger) parties(cmd TransferCommand, resolvePayer func(userID uuid.UUID) (*Customer, error)) (*Customer, *Customer, error) {
+	if cmd.FromUserID == cmd.ToUserID {
+		return nil, nil, domain.ErrCannotTransferToSelf
+	}
+	if err := l.ValidAmount(cmd.Amount); err != nil {
+		return nil, nil, err
+	}
+	payer, err := resolvePayer(cmd.FromUserID)
+	if err != nil {
+		return nil, nil, err
+	}
+	payee, err := l.ActiveCustomer(cmd.ToUserID)
+	if err != nil {
+		return nil, nil, err
+	}
+	return payer, payee, nil
+}
+
+// transferPosting moves the amount through the liability chart account and charges the payer fee
+func transferPosting(s stamp, cmd TransferCommand, payer *Customer, payee *Customer, fee int) posting {
+	out, outTransactions := s.leg(util.GetLiabilityAccount(), payer.Wallet.ID, cmd.Amount, -cmd.Amount)
+	in, inTransactions := s.leg(payee.Wallet.ID, util.GetLiabilityAccount(), cmd.Amount, -cmd.Amount)
+	history := s.paymentHistory("TRANSFER", cmd.FromUserID.String(), payer.Name(), cmd.ToUserID.String(), payee.Name(), cmd.Amount)
+	if cmd.InitiatorUserID != uuid.Nil {
+		history.InitiatorUserId = cmd.InitiatorUserID.String()
+	}
+	p := posting{
+		movements:    []movement.Movement{in, out},
+		transactions: append(outTransactions, inTransactions...),
+		history:      history,
+		changes: []balanceChange{
+			// customer asset decreased
+			{walletID: payer.Wallet.ID, amount: -cmd.Amount, accountType: accountTypeCustomer},
+			// company liability increased
+			{walletID: util.GetLiabilityAccount(), amount: cmd.Amount},
+			// customer asset increased
+			{walletID: payee.Wallet.ID, amount: cmd.Amount},
+			// company liability decreased
+			{walletID: util.GetLiabilityAccount(), amount: -cmd.Amount, accountType: accountTypeChart},
+		},
+		customers: []uuid.UUID{payer.Wallet.ID, payee.Wallet.ID},
+		request:   s.request,
+	}
+	return p.charge(s, payer.Wallet.ID, fee)
+}
+
+func logTransfer(cmd TransferCommand, fee int) {
+	util.Info("Transfer successfully",
+		zap.String("credit_user_id", cmd.FromUserID.String()),
+		zap.String("debit_user_id", cmd.ToUserID.String()),
+		zap.Int("balance", cmd.Amount),
+		zap.Int("fee", fee),
+	)
+}
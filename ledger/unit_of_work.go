@@ -0,0 +1,325 @@
+package ledger
+
+import (
+	"context"
+	"errors"
+	"github.com/google/uuid"
+	"github.com/raychongtk/wallet/datastore"
+	"github.com/raychongtk/wallet/domain"
+	"github.com/raychongtk/wallet/integrity"
+	"github.com/raychongtk/wallet/metrics"
+	"github.com/raychongtk/wallet/model/movement"
+	"github.com/raychongtk/wallet/model/payment"
+	"github.com/raychongtk/wallet/model/wallet"
+	"github.com/raychongtk/wallet/repository"
+	"github.com/raychongtk/wallet/util"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"sort"
+	"time"
+)
+
+const (
+	maxAttempts  = 3
+	retryBackoff = 20 * time.Millisecond
+	balanceType  = "COMMITTED"
+	heldType     = "HELD"
+	accruedType  = "ACCRUED_INTEREST"
+)
+
+// UnitOfWork runs a money movement atomically. Attempts that fail with a retryable conflict, such as a
+// serialization failure or a deadlock, are run again from scratch, so fn must not keep state between attempts.
+type UnitOfWork interface {
+	Do(ctx context.Context, operation string, fn func(tx Tx) error) error
+}
+
+// Tx is what a money movement may do inside a unit of work
+type Tx interface {
+	// LockBalances locks the committed balances of the wallets in a fixed order, so concurrent movements over the
+	// same wallets queue instead of deadlocking
+	LockBalances(walletIDs []uuid.UUID) error
+	Balance(walletID uuid.UUID) (int, error)
+	AddBalance(walletID uuid.UUID, amount int) error
+	DeductBalance(walletID uuid.UUID, amount int, accountType string) error
+	CreateMovements(movements []movement.Movement) error
+	CreateTransactions(transactions []movement.Transaction) error
+	CreatePaymentHistory(history *payment.PaymentHistory) error
+	// ClaimRequest books a request id to the movement group the unit of work writes. It reports the claim booked under
+	// the request id before, nil when this group claimed it.
+	ClaimRequest(request *movement.LedgerRequest) (*movement.LedgerRequest, error)
+	// BookedRequest is the claim booked under a request id, nil when none was
+	BookedRequest(requestID string) (*movement.LedgerRequest, error)
+	// GroupMovements reads the movements of a group booked before
+	GroupMovements(groupID uuid.UUID) ([]movement.Movement, error)
+	// Held is the total of the active holds on a wallet, a wallet without a HELD balance holds nothing
+	Held(walletID uuid.UUID) (int, error)
+	// AddHeld adds a positive amount to the held balance and gives a negative one back
+	AddHeld(walletID uuid.UUID, amount int) error
+	CreateHold(hold *wallet.Hold) error
+	// LockHold reads a hold and locks it, holds are locked before balances
+	LockHold(id uuid.UUID) (*wallet.Hold, error)
+	UpdateHold(hold *wallet.Hold) error
+	CreateEscrow(escrow *payment.Escrow) error
+	// LockEscrow reads an escrow and locks it, escrows are locked before balances
+	LockEscrow(id uuid.UUID) (*payment.Escrow, error)
+	UpdateEscrow(escrow *payment.Escrow) error
+	// CreateAccrual stores an accrual and reports false when the wallet accrued the day already
+	CreateAccrual(accrual *wallet.InterestAccrual) (bool, error)
+	// AddAccruedInterest adds to the accrued interest balance, a payout gives a negative amount
+	AddAccruedInterest(walletID uuid.UUID, amount int) error
+	// LockUnpaidAccruals reads the accruals of a wallet before a day that are not paid out and locks them
+	LockUnpaidAccruals(walletID uuid.UUID, before time.Time) ([]wallet.InterestAccrual, error)
+	MarkAccrualsPaid(ids []uuid.UUID, groupID *uuid.UUID, paidAt time.Time) error
+	// CreatePocket stores a pocket with its wallet and zero balances
+	CreatePocket(pocket *wallet.Pocket, pocketWallet *wallet.Wallet) error
+	// LockPocket reads a pocket and locks it, pockets are locked before balances
+	LockPocket(id uuid.UUID) (*wallet.Pocket, error)
+	// LockMember reads the membership of a user in a shared account and locks it, memberships are locked before
+	// balances
+	LockMember(accountID uuid.UUID, userID uuid.UUID) (*wallet.AccountMember, error)
+	// LockSharedTransfer reads a transfer out of a shared account and locks it, transfers are locked before balances
+	LockSharedTransfer(id uuid.UUID) (*payment.SharedTransfer, error)
+	UpdateSharedTransfer(transfer *payment.SharedTransfer) error
+	// LockBusinessTransfer reads a transfer out of a business account and locks it, transfers are locked before balances
+	LockBusinessTransfer(id uuid.UUID) (*payment.BusinessTransfer, error)
+	UpdateBusinessTransfer(transfer *payment.BusinessTransfer) error
+	SearchBusinessApprovals(transferID uuid.UUID) ([]payment.BusinessApproval, error)
+	// Seal appends the movement group to the hash chain, it must be the last write of the unit of work
+	Seal(movements []movement.Movement, transactions []movement.Transaction) error
+}
+
+type PgUnitOfWork struct {
+	db                   gorm.DB
+	movementRepo         repository.MovementRepository
+	transactionRepo      repository.TransactionRepository
+	balanceRepo          repository.BalanceRepository
+	paymentHistoryRepo   repository.PaymentHistoryRepository
+	holdRepo             repository.HoldRepository
+	escrowRepo           repository.EscrowRepository
+	interestRepo         repository.InterestRepository
+	pocketRepo           repository.PocketRepository
+	memberRepo           repository.MemberRepository
+	sharedTransferRepo   repository.SharedTransferRepository
+	businessTransferRepo repository.BusinessTransferRepository
+	chain                *integrity.Chain
+	router               *datastore.ReplicaRouter
+}
+
+func ProvideUnitOfWork(
+	db gorm.DB,
+	movementRepo repository.MovementRepository,
+	transactionRepo repository.TransactionRepository,
+	balanceRepo repository.BalanceRepository,
+	paymentHistoryRepo repository.PaymentHistoryRepository,
+	holdRepo repository.HoldRepository,
+	escrowRepo repository.EscrowRepository,
+	interestRepo repository.InterestRepository,
+	pocketRepo repository.PocketRepository,
+	memberRepo repository.MemberRepository,
+	sharedTransferRepo repository.SharedTransferRepository,
+	businessTransferRepo repository.BusinessTransferRepository,
+	chain *integrity.Chain,
+	router *datastore.ReplicaRouter,
+) UnitOfWork {
+	return &PgUnitOfWork{
+		db:                   db,
+		movementRepo:         movementRepo,
+		transactionRepo:      transactionRepo,
+		balanceRepo:          balanceRepo,
+		paymentHistoryRepo:   paymentHistoryRepo,
+		holdRepo:             holdRepo,
+		escrowRepo:           escrowRepo,
+		interestRepo:         interestRepo,
+		pocketRepo:           pocketRepo,
+		memberRepo:           memberRepo,
+		sharedTransferRepo:   sharedTransferRepo,
+		businessTransferRepo: businessTransferRepo,
+		chain:                chain,
+		router:               router,
+	}
+}
+
+// Do pins reads of the wallets and users a unit of work wrote to the primary once it commits, replicas only see the
+// writes after that
+func (u *PgUnitOfWork) Do(ctx context.Context, operation string, fn func(tx Tx) error) error {
+	for attempt := 1; ; attempt++ {
+		tx := &pgTx{uow: u}
+		err := repository.DBError(u.db.WithContext(ctx).Transaction(func(db *gorm.DB) error {
+			tx.db = db
+			return fn(tx)
+		}))
+		if err == nil {
+			u.router.MarkWrite(tx.written...)
+			return nil
+		}
+		if attempt == maxAttempts || !errors.Is(err, domain.ErrConflict) {
+			return err
+		}
+		metrics.RetryTotal.WithLabelValues(operation).Inc()
+		util.Warn("Retry money movement", zap.String("operation", operation), zap.Int("attempt", attempt), zap.Error(err))
+		select {
+		case <-ctx.Done():
+			return domain.ErrUnavailable.Wrap(ctx.Err())
+		case <-time.After(time.Duration(attempt) * retryBackoff):
+		}
+	}
+}
+
+type pgTx struct {
+	db  *gorm.DB
+	uow *PgUnitOfWork
+	// written are the keys of the wallets and users whose reads are pinned to the primary after commit
+	written []string
+}
+
+func (t *pgTx) LockBalances(walletIDs []uuid.UUID) error {
+	sorted := append([]uuid.UUID(nil), walletIDs...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].String() < sorted[j].String() })
+	for i, walletID := range sorted {
+		if i > 0 && walletID == sorted[i-1] {
+			continue
+		}
+		if _, err := t.uow.balanceRepo.GetBalanceWithLock(t.db, walletID, balanceType); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (t *pgTx) Balance(walletID uuid.UUID) (int, error) {
+	balance, err := t.uow.balanceRepo.GetBalanceWithLock(t.db, walletID, balanceType)
+	if err != nil {
+		return 0, err
+	}
+	return balance.Balance, nil
+}
+
+func (t *pgTx) AddBalance(walletID uuid.UUID, amount int) error {
+	t.written = append(t.written, walletID.String())
+	return t.uow.balanceRepo.AddBalance(t.db, walletID, amount, balanceType)
+}
+
+func (t *pgTx) DeductBalance(walletID uuid.UUID, amount int, accountType string) error {
+	t.written = append(t.written, walletID.String())
+	return t.uow.balanceRepo.DeductBalance(t.db, walletID, amount, balanceType, accountType)
+}
+
+func (t *pgTx) CreateMovements(movements []movement.Movement) error {
+	return t.uow.movementRepo.CreateMovements(t.db, movements)
+}
+
+func (t *pgTx) CreateTransactions(transactions []movement.Transaction) error {
+	return t.uow.transactionRepo.CreateTransactions(t.db, transactions)
+}
+
+func (t *pgTx) CreatePaymentHistory(history *payment.PaymentHistory) error {
+	t.written = append(t.written, history.PayerUserId, history.PayeeUserId)
+	_, err := t.uow.paymentHistoryRepo.CreatePaymentHistory(t.db, history)
+	return err
+}
+
+func (t *pgTx) ClaimRequest(request *movement.LedgerRequest) (*movement.LedgerRequest, error) {
+	return t.uow.movementRepo.ClaimRequestID(t.db, request)
+}
+
+func (t *pgTx) BookedRequest(requestID string) (*movement.LedgerRequest, error) {
+	return t.uow.movementRepo.GetLedgerRequest(t.db, requestID)
+}
+
+func (t *pgTx) GroupMovements(groupID uuid.UUID) ([]movement.Movement, error) {
+	return t.uow.movementRepo.SearchMovementsByGroupIDs([]uuid.UUID{groupID})
+}
+
+func (t *pgTx) Held(walletID uuid.UUID) (int, error) {
+	balance, err := t.uow.balanceRepo.GetBalanceWithLock(t.db, walletID, heldType)
+	if errors.Is(err, domain.ErrNotFound) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	return balance.Balance, nil
+}
+
+func (t *pgTx) AddHeld(walletID uuid.UUID, amount int) error {
+	t.written = append(t.written, walletID.String())
+	return t.uow.balanceRepo.AddBalance(t.db, walletID, amount, heldType)
+}
+
+func (t *pgTx) CreateHold(hold *wallet.Hold) error {
+	return t.uow.holdRepo.CreateHold(t.db, hold)
+}
+
+func (t *pgTx) LockHold(id uuid.UUID) (*wallet.Hold, error) {
+	return t.uow.holdRepo.LockHold(t.db, id)
+}
+
+func (t *pgTx) UpdateHold(hold *wallet.Hold) error {
+	return t.uow.holdRepo.UpdateHold(t.db, hold)
+}
+
+func (t *pgTx) CreateEscrow(escrow *payment.Escrow) error {
+	return t.uow.escrowRepo.CreateEscrow(t.db, escrow)
+}
+
+func (t *pgTx) LockEscrow(id uuid.UUID) (*payment.Escrow, error) {
+	return t.uow.escrowRepo.LockEscrow(t.db, id)
+}
+
+func (t *pgTx) UpdateEscrow(escrow *payment.Escrow) error {
+	return t.uow.escrowRepo.UpdateEscrow(t.db, escrow)
+}
+
+func (t *pgTx) CreateAccrual(accrual *wallet.InterestAccrual) (bool, error) {
+	return t.uow.interestRepo.CreateAccrual(t.db, accrual)
+}
+
+func (t *pgTx) AddAccruedInterest(walletID uuid.UUID, amount int) error {
+	t.written = append(t.written, walletID.String())
+	return t.uow.balanceRepo.AddBalance(t.db, walletID, amount, accruedType)
+}
+
+func (t *pgTx) LockUnpaidAccruals(walletID uuid.UUID, before time.Time) ([]wallet.InterestAccrual, error) {
+	return t.uow.interestRepo.LockUnpaidAccruals(t.db, walletID, before)
+}
+
+func (t *pgTx) MarkAccrualsPaid(ids []uuid.UUID, groupID *uuid.UUID, paidAt time.Time) error {
+	return t.uow.interestRepo.MarkAccrualsPaid(t.db, ids, groupID, paidAt)
+}
+
+func (t *pgTx) CreatePocket(pocket *wallet.Pocket, pocketWallet *wallet.Wallet) error {
+	t.written = append(t.written, pocketWallet.ID.String())
+	return t.uow.pocketRepo.CreatePocket(t.db, pocket, pocketWallet)
+}
+
+func (t *pgTx) LockPocket(id uuid.UUID) (*wallet.Pocket, error) {
+	return t.uow.pocketRepo.LockPocket(t.db, id)
+}
+
+func (t *pgTx) LockMember(accountID uuid.UUID, userID uuid.UUID) (*wallet.AccountMember, error) {
+	return t.uow.memberRepo.LockMember(t.db, accountID, userID)
+}
+
+func (t *pgTx) LockSharedTransfer(id uuid.UUID) (*payment.SharedTransfer, error) {
+	return t.uow.sharedTransferRepo.LockSharedTransfer(t.db, id)
+}
+
+func (t *pgTx) UpdateSharedTransfer(transfer *payment.SharedTransfer) error {
+	return t.uow.sharedTransferRepo.UpdateSharedTransfer(t.db, transfer)
+}
+
+func (t *pgTx) LockBusinessTransfer(id uuid.UUID) (*payment.BusinessTransfer, error) {
+	return t.uow.businessTransferRepo.LockBusinessTransfer(t.db, id)
+}
+
+func (t *pgTx) UpdateBusinessTransfer(transfer *payment.BusinessTransfer) error {
+	return t.uow.businessTransferRepo.UpdateBusinessTransfer(t.db, transfer)
+}
+
+func (t *pgTx) SearchBusinessApprovals(transferID uuid.UUID) ([]payment.BusinessApproval, error) {
+	return t.uow.businessTransferRepo.SearchBusinessApprovals(t.db, transferID)
+}
+
+func (t *pgTx) Seal(movements []movement.Movement, transactions []movement.Transaction) error {
+	return t.uow.chain.Append(t.db, movements, transactions)
+}
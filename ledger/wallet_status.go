@@ -0,0 +1,38 @@
+package ledger
+
+import (
+	"github.com/google/uuid"
+	"github.com/raychongtk/wallet/domain"
+	"github.com/raychongtk/wallet/model/wallet"
+	"github.com/raychongtk/wallet/util"
+	"go.uber.org/zap"
+	"strings"
+)
+
+// SetWalletStatus freezes or unfreezes the wallet of a customer and returns the customer as it was before the change.
+// Only active and frozen wallets move between the two, asking for the status a wallet already has is rejected.
+func (l *Ledger) SetWalletStatus(userID uuid.UUID, status string) (*Customer, error) {
+	if status != wallet.StatusActive && status != wallet.StatusFrozen {
+		return nil, domain.ErrInvalidParameters.WithMessage("wallet status must be %s or %s", wallet.StatusActive, wallet.StatusFrozen)
+	}
+	customer, err := l.Customer(userID)
+	if err != nil {
+		return nil, err
+	}
+	current := customer.Wallet.WalletStatus
+	if current != wallet.StatusActive && current != wallet.StatusFrozen {
+		return nil, domain.ErrInvalidAccount.WithMessage("wallet is %s", strings.ToLower(current))
+	}
+	if current == status {
+		return nil, domain.ErrInvalidParameters.WithMessage("wallet is already %s", strings.ToLower(status))
+	}
+	if err := l.walletRepo.UpdateWalletStatus(customer.Wallet.ID, status); err != nil {
+		return nil, err
+	}
+	util.Info("Wallet status changed",
+		zap.String("user_id", userID.String()),
+		zap.String("from", current),
+		zap.String("to", status),
+	)
+	return customer, nil
+}
@@ -0,0 +1,7 @@
+package ledger
+
+import "github.com/google/wire"
+
+var (
+	WireSet = wire.NewSet(ProvideUnitOfWork, ProvideLedger)
+)
@@ -0,0 +1,54 @@
+package ledger
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"github.com/raychongtk/wallet/model/movement"
+	"github.com/raychongtk/wallet/util"
+	"go.uber.org/zap"
+)
+
+// WithdrawCommand takes money out of a customer wallet. Amount is in minor units.
+type WithdrawCommand struct {
+	UserID    uuid.UUID
+	Amount    int
+	RequestID string
+}
+
+// Withdraw decreases the customer wallet by the amount and its fee and increases the liability chart account by the
+// amount, it fails with domain.ErrInsufficientFunds when the wallet balance is lower than both
+func (l *Ledger) Withdraw(ctx context.Context, cmd WithdrawCommand) (*Result, error) {
+	if err := l.ValidAmount(cmd.Amount); err != nil {
+		return nil, err
+	}
+	customer, err := l.Payer(cmd.UserID)
+	if err != nil {
+		return nil, err
+	}
+	fee := l.fee(OperationWithdrawal, customer, cmd.Amount)
+	result, err := l.post(ctx, newRequest(OperationWithdrawal, cmd.RequestID, cmd), func(s stamp) posting {
+		m, transactions := s.leg(util.GetLiabilityAccount(), customer.Wallet.ID, cmd.Amount, -cmd.Amount)
+		p := posting{
+			movements:    []movement.Movement{m},
+			transactions: transactions,
+			history:      s.paymentHistory("WITHDRAWAL", "System", "System", cmd.UserID.String(), customer.Name(), cmd.Amount),
+			changes: []balanceChange{
+				// customer asset decreased
+				{walletID: customer.Wallet.ID, amount: -cmd.Amount, accountType: accountTypeCustomer},
+				// company liability increased
+				{walletID: util.GetLiabilityAccount(), amount: cmd.Amount},
+			},
+			customers: []uuid.UUID{customer.Wallet.ID},
+		}
+		return p.charge(s, customer.Wallet.ID, fee)
+	})
+	if err != nil {
+		return nil, err
+	}
+	util.Info("Withdrawal successfully",
+		zap.String("user_id", cmd.UserID.String()),
+		zap.Int("balance", cmd.Amount),
+		zap.Int("fee", fee),
+	)
+	return result, nil
+}
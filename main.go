@@ -4,16 +4,20 @@ import (
 	"context"
 	"github.com/raychongtk/wallet/util"
 	"log"
+	"os/signal"
+	"syscall"
 )
 
 func main() {
-	ctx := context.TODO()
-	routes, err := injectRoutes(ctx)
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	app, err := injectApp(ctx)
 	if err != nil {
-		log.Fatalln("inject routes failed")
+		log.Fatalf("inject app failed: %v", err)
 	}
-	util.InitializeLogger(false)
-	if err := routes.Run(":8080"); err != nil {
-		log.Fatalln("start server failed")
+	util.InitializeLoggerWithLevel(app.Config.Log.Production, app.Config.Log.Level)
+	if err := app.Run(ctx); err != nil {
+		log.Fatalf("run server failed: %v", err)
 	}
 }
@@ -0,0 +1,61 @@
+package metrics
+
+import (
+	"gorm.io/gorm"
+	"time"
+)
+
+const startKey = "metrics:start"
+
+// GormPlugin times every statement issued through gorm
+type GormPlugin struct{}
+
+func (GormPlugin) Name() string {
+	return "metrics"
+}
+
+func (GormPlugin) Initialize(db *gorm.DB) error {
+	callback := db.Callback()
+	if err := callback.Create().Before("gorm:create").Register("metrics:before_create", start); err != nil {
+		return err
+	}
+	if err := callback.Create().After("gorm:create").Register("metrics:after_create", observe("create")); err != nil {
+		return err
+	}
+	if err := callback.Query().Before("gorm:query").Register("metrics:before_query", start); err != nil {
+		return err
+	}
+	if err := callback.Query().After("gorm:query").Register("metrics:after_query", observe("query")); err != nil {
+		return err
+	}
+	if err := callback.Update().Before("gorm:update").Register("metrics:before_update", start); err != nil {
+		return err
+	}
+	if err := callback.Update().After("gorm:update").Register("metrics:after_update", observe("update")); err != nil {
+		return err
+	}
+	if err := callback.Delete().Before("gorm:delete").Register("metrics:before_delete", start); err != nil {
+		return err
+	}
+	if err := callback.Delete().After("gorm:delete").Register("metrics:after_delete", observe("delete")); err != nil {
+		return err
+	}
+	if err := callback.Raw().Before("gorm:raw").Register("metrics:before_raw", start); err != nil {
+		return err
+	}
+	return callback.Raw().After("gorm:raw").Register("metrics:after_raw", observe("raw"))
+}
+
+func start(db *gorm.DB) {
+	db.InstanceSet(startKey, time.Now())
+}
+
+func observe(kind string) func(db *gorm.DB) {
+	return func(db *gorm.DB) {
+		value, ok := db.InstanceGet(startKey)
+		if !ok {
+			return
+		}
+		DBQueryDuration.WithLabelValues(kind, db.Statement.Table).Observe(time.Since(value.(time.Time)).Seconds())
+	}
+}
@@ -0,0 +1,79 @@
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"time"
+)
+
+const namespace = "wallet"
+
+// Registry holds every wallet metric and is served at /metrics
+var Registry = prometheus.NewRegistry()
+
+var (
+	OperationTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "operation_total",
+		Help:      "Money movement operations by result and error code.",
+	}, []string{"operation", "result", "error_code"})
+
+	OperationDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "operation_duration_seconds",
+		Help:      "Latency of money movement operations.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"operation", "result"})
+
+	HTTPRequestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "http_request_duration_seconds",
+		Help:      "Latency of HTTP requests by route and status.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method", "route", "status"})
+
+	BalanceLockWait = prometheus.NewHistogram(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "balance_lock_wait_seconds",
+		Help:      "Time spent acquiring a balance row lock.",
+		Buckets:   []float64{.0005, .001, .0025, .005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5},
+	})
+
+	DBQueryDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "db_query_duration_seconds",
+		Help:      "Latency of database statements by kind and table.",
+		Buckets:   []float64{.0005, .001, .0025, .005, .01, .025, .05, .1, .25, .5, 1, 2.5},
+	}, []string{"kind", "table"})
+
+	IdempotencyHits = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "idempotency_hits_total",
+		Help:      "Requests rejected because their X-Request-ID was already used.",
+	})
+
+	RetryTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "retry_total",
+		Help:      "Retried attempts by operation.",
+	}, []string{"operation"})
+)
+
+func init() {
+	Registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		OperationTotal,
+		OperationDuration,
+		HTTPRequestDuration,
+		BalanceLockWait,
+		DBQueryDuration,
+		IdempotencyHits,
+		RetryTotal,
+	)
+}
+
+// ObserveLockWait is meant to be deferred right before a locking read: defer metrics.ObserveLockWait(time.Now())
+func ObserveLockWait(start time.Time) {
+	BalanceLockWait.Observe(time.Since(start).Seconds())
+}
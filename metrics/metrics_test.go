@@ -0,0 +1,58 @@
+package metrics
+
+import (
+	"errors"
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestOperationRecordsErrorCode(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.POST("/ok", Operation("test_ok"), func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"result": true})
+	})
+	r.POST("/fail", Operation("test_fail"), func(c *gin.Context) {
+		c.JSON(http.StatusBadRequest, gin.H{"result": false, "error_code": "INSUFFICIENT_BALANCE"})
+	})
+	r.POST("/accepted", Operation("test_accepted"), func(c *gin.Context) {
+		c.JSON(http.StatusAccepted, gin.H{"batch_id": "1"})
+	})
+
+	for _, path := range []string{"/ok", "/fail", "/fail", "/accepted"} {
+		r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, path, nil))
+	}
+
+	assert.Equal(t, 1.0, testutil.ToFloat64(OperationTotal.WithLabelValues("test_ok", ResultSuccess, "")))
+	assert.Equal(t, 2.0, testutil.ToFloat64(OperationTotal.WithLabelValues("test_fail", ResultFailure, "INSUFFICIENT_BALANCE")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(OperationTotal.WithLabelValues("test_accepted", ResultSuccess, "")))
+}
+
+func TestChartBalanceCollector(t *testing.T) {
+	collector := NewChartBalanceCollector(func() (int, int, error) {
+		return 1500, 500, nil
+	})
+	expected := `
+# HELP wallet_chart_balance_difference Asset minus liability chart balance in minor units, the funds held for customers.
+# TYPE wallet_chart_balance_difference gauge
+wallet_chart_balance_difference 1000
+`
+	assert.NoError(t, testutil.CollectAndCompare(collector, strings.NewReader(expected), "wallet_chart_balance_difference"))
+
+	failing := NewChartBalanceCollector(func() (int, int, error) {
+		return 0, 0, errors.New("db down")
+	})
+	registry := prometheus.NewRegistry()
+	registry.MustRegister(failing)
+	families, err := registry.Gather()
+	assert.NoError(t, err)
+	assert.Len(t, families, 1)
+	assert.Equal(t, "wallet_chart_balance_up", families[0].GetName())
+	assert.Equal(t, 0.0, families[0].GetMetric()[0].GetGauge().GetValue())
+}
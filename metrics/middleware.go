@@ -0,0 +1,75 @@
+package metrics
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"strconv"
+	"time"
+)
+
+const (
+	ResultSuccess = "success"
+	ResultFailure = "failure"
+)
+
+// HTTPMiddleware records the latency of every request by route template, so ids in paths do not explode cardinality
+func HTTPMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+		c.Next()
+		route := c.FullPath()
+		if route == "" {
+			route = "unmatched"
+		}
+		HTTPRequestDuration.WithLabelValues(c.Request.Method, route, strconv.Itoa(c.Writer.Status())).Observe(time.Since(start).Seconds())
+	}
+}
+
+// Operation records the result and error code of a money movement endpoint. The error code is taken from the
+// error_code field of the JSON response, which every error envelope carries. A response fails when its status does or
+// when it has a result field that is false, responses without one, such as an accepted payout batch, succeed.
+func Operation(operation string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+		writer := &capturingWriter{ResponseWriter: c.Writer}
+		c.Writer = writer
+		c.Next()
+
+		result, errorCode := ResultSuccess, ""
+		var response struct {
+			Result    *bool  `json:"result"`
+			ErrorCode string `json:"error_code"`
+		}
+		_ = json.Unmarshal(writer.body.Bytes(), &response)
+		if writer.Status() >= 400 || (response.Result != nil && !*response.Result) {
+			result, errorCode = ResultFailure, response.ErrorCode
+			if errorCode == "" {
+				errorCode = "UNKNOWN"
+			}
+		}
+		OperationTotal.WithLabelValues(operation, result, errorCode).Inc()
+		OperationDuration.WithLabelValues(operation, result).Observe(time.Since(start).Seconds())
+	}
+}
+
+func Handler() gin.HandlerFunc {
+	return gin.WrapH(promhttp.HandlerFor(Registry, promhttp.HandlerOpts{}))
+}
+
+// capturingWriter keeps a copy of the response body, money movement responses are small
+type capturingWriter struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (w *capturingWriter) Write(data []byte) (int, error) {
+	w.body.Write(data)
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *capturingWriter) WriteString(data string) (int, error) {
+	w.body.WriteString(data)
+	return w.ResponseWriter.WriteString(data)
+}
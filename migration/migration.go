@@ -0,0 +1,132 @@
+package migration
+
+import (
+	"context"
+	"embed"
+	"fmt"
+	"github.com/raychongtk/wallet/util"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"io/fs"
+	"sort"
+	"strings"
+	"time"
+)
+
+//go:embed sql/*.sql
+var files embed.FS
+
+// lockKey serializes migrations when several instances start at the same time
+const lockKey = 7256380421
+
+// Migrator applies the versioned scripts in migration/sql on top of the baseline schema in script/init.sql.
+// A script runs once and its version is recorded in schema_migration.
+type Migrator struct {
+	db *gorm.DB
+}
+
+type SchemaMigration struct {
+	Version   string
+	AppliedAt time.Time
+}
+
+func (migration SchemaMigration) TableName() string {
+	return "schema_migration"
+}
+
+func ProvideMigrator(db gorm.DB) *Migrator {
+	return &Migrator{&db}
+}
+
+// Migrate applies every pending script in one transaction, so a failing script leaves the schema untouched
+func (m *Migrator) Migrate(ctx context.Context) error {
+	versions, err := versions()
+	if err != nil {
+		return err
+	}
+	return m.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec("SELECT pg_advisory_xact_lock(?)", lockKey).Error; err != nil {
+			return err
+		}
+		if err := createTable(tx); err != nil {
+			return err
+		}
+		applied, err := appliedVersions(tx)
+		if err != nil {
+			return err
+		}
+		for _, version := range versions {
+			if applied[version] {
+				continue
+			}
+			script, err := files.ReadFile("sql/" + version + ".sql")
+			if err != nil {
+				return err
+			}
+			if err := tx.Exec(string(script)).Error; err != nil {
+				return fmt.Errorf("apply migration %s: %w", version, err)
+			}
+			if err := tx.Create(&SchemaMigration{Version: version, AppliedAt: time.Now()}).Error; err != nil {
+				return err
+			}
+			util.Info("Apply migration successfully", zap.String("version", version))
+		}
+		return nil
+	})
+}
+
+// Pending lists the versions that are not applied yet
+func (m *Migrator) Pending(ctx context.Context) ([]string, error) {
+	versions, err := versions()
+	if err != nil {
+		return nil, err
+	}
+	db := m.db.WithContext(ctx)
+	if !db.Migrator().HasTable(&SchemaMigration{}) {
+		return versions, nil
+	}
+	applied, err := appliedVersions(db)
+	if err != nil {
+		return nil, err
+	}
+	var pending []string
+	for _, version := range versions {
+		if !applied[version] {
+			pending = append(pending, version)
+		}
+	}
+	return pending, nil
+}
+
+func createTable(db *gorm.DB) error {
+	return db.Exec(`create table if not exists schema_migration
+(
+    version    varchar(255) primary key,
+    applied_at timestamp default current_timestamp
+)`).Error
+}
+
+func appliedVersions(db *gorm.DB) (map[string]bool, error) {
+	var migrations []SchemaMigration
+	if err := db.Find(&migrations).Error; err != nil {
+		return nil, err
+	}
+	applied := make(map[string]bool, len(migrations))
+	for _, migration := range migrations {
+		applied[migration.Version] = true
+	}
+	return applied, nil
+}
+
+func versions() ([]string, error) {
+	entries, err := fs.ReadDir(files, "sql")
+	if err != nil {
+		return nil, err
+	}
+	var versions []string
+	for _, entry := range entries {
+		versions = append(versions, strings.TrimSuffix(entry.Name(), ".sql"))
+	}
+	sort.Strings(versions)
+	return versions, nil
+}
@@ -0,0 +1,31 @@
+create table if not exists archive_manifest
+(
+    id           uuid primary key,
+    source_table varchar(50)  not null,
+    range_start  timestamp    not null,
+    range_end    timestamp    not null,
+    object_key   varchar(255) not null,
+    format       varchar(30)  not null,
+    record_count int          not null,
+    byte_size    int          not null,
+    checksum     varchar(64)  not null,
+    created_at   timestamp default current_timestamp
+);
+
+create
+    unique index if not exists archive_manifest_source_table_range_start_uindex
+    on archive_manifest (source_table, range_start);
+
+-- what the archived transactions of a manifest add to each balance of a wallet, so archived balances are summed
+-- without reading the archive
+create table if not exists archive_balance
+(
+    manifest_id  uuid        not null references archive_manifest (id),
+    wallet_id    uuid        not null,
+    balance_type varchar(50) not null,
+    balance      bigint      not null,
+    primary key (manifest_id, wallet_id, balance_type)
+);
+
+create index if not exists archive_balance_wallet_id_balance_type_index
+    on archive_balance (wallet_id, balance_type);
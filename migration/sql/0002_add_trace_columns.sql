@@ -0,0 +1,13 @@
+alter table movement
+    add column if not exists trace_id   varchar(32) not null default '',
+    add column if not exists request_id varchar(255) not null default '';
+
+alter table payment_history
+    add column if not exists trace_id   varchar(32) not null default '',
+    add column if not exists request_id varchar(255) not null default '';
+
+create index if not exists movement_trace_id_index on movement (trace_id);
+create index if not exists movement_request_id_index on movement (request_id);
+create index if not exists payment_history_trace_id_index on payment_history (trace_id);
+create index if not exists payment_history_request_id_index on payment_history (request_id);
+create index if not exists transaction_movement_id_index on transaction (movement_id);
@@ -0,0 +1,38 @@
+create table if not exists ledger_hash
+(
+    sequence          bigint primary key,
+    group_id          uuid        not null,
+    content_hash      varchar(64) not null,
+    movement_count    int         not null,
+    transaction_count int         not null,
+    prev_hash         varchar(64) not null,
+    hash              varchar(64) not null,
+    created_at        timestamp default current_timestamp
+);
+
+create
+    unique index if not exists ledger_hash_group_id_uindex
+    on ledger_hash (group_id);
+
+create table if not exists wallet_hash
+(
+    wallet_id    uuid        not null,
+    sequence     bigint      not null,
+    group_id     uuid        not null,
+    content_hash varchar(64) not null,
+    prev_hash    varchar(64) not null,
+    hash         varchar(64) not null,
+    created_at   timestamp default current_timestamp,
+    primary key (wallet_id, sequence)
+);
+
+create table if not exists ledger_checkpoint
+(
+    sequence   bigint primary key,
+    hash       varchar(64) not null,
+    key_id     varchar(64) not null,
+    signature  text        not null,
+    created_at timestamp default current_timestamp
+);
+
+create index if not exists movement_group_id_index on movement (group_id);
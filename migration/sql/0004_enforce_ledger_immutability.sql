@@ -0,0 +1,193 @@
+-- Ledger tables are append-only. The only changes allowed are movement status transitions listed in
+-- movement_status_transition and deletes by the archiver of rows covered by the archive manifest named in the
+-- wallet.archive_manifest_id setting of the deleting transaction.
+
+create table if not exists movement_status_transition
+(
+    from_status varchar(50) not null,
+    to_status   varchar(50) not null,
+    primary key (from_status, to_status)
+);
+
+insert into movement_status_transition (from_status, to_status)
+values ('PENDING', 'COMPLETED'),
+       ('PENDING', 'FAILED'),
+       ('PENDING', 'CANCELLED'),
+       ('COMPLETED', 'REVERSED')
+on conflict do nothing;
+
+-- a manifest named in the setting only lets a delete through when the deleting role is the archiver's
+create or replace function ledger_archived_delete(source_table text, created_at timestamp) returns boolean as
+$$
+declare
+    manifest_id text := current_setting('wallet.archive_manifest_id', true);
+begin
+    if manifest_id is null or manifest_id = '' or not pg_has_role('wallet_archiver', 'MEMBER') then
+        return false;
+    end if;
+    return exists (select 1
+                   from archive_manifest
+                   where id = manifest_id::uuid
+                     and archive_manifest.source_table = ledger_archived_delete.source_table
+                     and ledger_archived_delete.created_at >= range_start
+                     and ledger_archived_delete.created_at < range_end);
+end;
+$$ language plpgsql;
+
+create or replace function reject_ledger_mutation() returns trigger as
+$$
+begin
+    if tg_op = 'DELETE' and ledger_archived_delete(tg_table_name, old.created_at) then
+        return old;
+    end if;
+    raise exception using
+        errcode = 'WL001',
+        message = format('%s on %s is not allowed, ledger records are immutable', tg_op, tg_table_name),
+        table = tg_table_name;
+end;
+$$ language plpgsql;
+
+create or replace function guard_movement_mutation() returns trigger as
+$$
+begin
+    if tg_op = 'DELETE' then
+        if ledger_archived_delete(tg_table_name, old.created_at) then
+            return old;
+        end if;
+        raise exception using
+            errcode = 'WL001',
+            message = 'DELETE on movement is not allowed, ledger records are immutable',
+            table = tg_table_name;
+    end if;
+    if (to_jsonb(new) - 'movement_status' - 'updated_at') <> (to_jsonb(old) - 'movement_status' - 'updated_at') then
+        raise exception using
+            errcode = 'WL001',
+            message = 'UPDATE on movement may only change movement_status',
+            table = tg_table_name;
+    end if;
+    if new.movement_status <> old.movement_status and not exists (select 1
+                                                                  from movement_status_transition
+                                                                  where from_status = old.movement_status
+                                                                    and to_status = new.movement_status) then
+        raise exception using
+            errcode = 'WL002',
+            message = format('movement status cannot change from %s to %s', old.movement_status, new.movement_status),
+            table = tg_table_name;
+    end if;
+    return new;
+end;
+$$ language plpgsql;
+
+create or replace function reject_ledger_truncate() returns trigger as
+$$
+begin
+    raise exception using
+        errcode = 'WL001',
+        message = format('TRUNCATE on %s is not allowed, ledger records are immutable', tg_table_name),
+        table = tg_table_name;
+end;
+$$ language plpgsql;
+
+drop trigger if exists movement_immutable on movement;
+create trigger movement_immutable
+    before update or delete
+    on movement
+    for each row
+execute function guard_movement_mutation();
+
+drop trigger if exists transaction_immutable on transaction;
+create trigger transaction_immutable
+    before update or delete
+    on transaction
+    for each row
+execute function reject_ledger_mutation();
+
+drop trigger if exists payment_history_immutable on payment_history;
+create trigger payment_history_immutable
+    before update or delete
+    on payment_history
+    for each row
+execute function reject_ledger_mutation();
+
+drop trigger if exists ledger_hash_immutable on ledger_hash;
+create trigger ledger_hash_immutable
+    before update or delete
+    on ledger_hash
+    for each row
+execute function reject_ledger_mutation();
+
+drop trigger if exists wallet_hash_immutable on wallet_hash;
+create trigger wallet_hash_immutable
+    before update or delete
+    on wallet_hash
+    for each row
+execute function reject_ledger_mutation();
+
+drop trigger if exists ledger_checkpoint_immutable on ledger_checkpoint;
+create trigger ledger_checkpoint_immutable
+    before update or delete
+    on ledger_checkpoint
+    for each row
+execute function reject_ledger_mutation();
+
+drop trigger if exists movement_no_truncate on movement;
+create trigger movement_no_truncate
+    before truncate
+    on movement
+execute function reject_ledger_truncate();
+
+drop trigger if exists transaction_no_truncate on transaction;
+create trigger transaction_no_truncate
+    before truncate
+    on transaction
+execute function reject_ledger_truncate();
+
+drop trigger if exists payment_history_no_truncate on payment_history;
+create trigger payment_history_no_truncate
+    before truncate
+    on payment_history
+execute function reject_ledger_truncate();
+
+drop trigger if exists ledger_hash_no_truncate on ledger_hash;
+create trigger ledger_hash_no_truncate
+    before truncate
+    on ledger_hash
+execute function reject_ledger_truncate();
+
+drop trigger if exists wallet_hash_no_truncate on wallet_hash;
+create trigger wallet_hash_no_truncate
+    before truncate
+    on wallet_hash
+execute function reject_ledger_truncate();
+
+drop trigger if exists ledger_checkpoint_no_truncate on ledger_checkpoint;
+create trigger ledger_checkpoint_no_truncate
+    before truncate
+    on ledger_checkpoint
+execute function reject_ledger_truncate();
+
+-- the application connects as a member of wallet_app in production. Triggers bind every role, grants additionally
+-- keep the application from disabling them or touching rows it has no business changing. Only the archiver records
+-- archive manifests and deletes the ledger rows they cover. It logs in as its own user, a member of wallet_archiver,
+-- so the application cannot write a manifest and delete ledger rows under it.
+do
+$$
+    begin
+        if not exists (select 1 from pg_roles where rolname = 'wallet_app') then
+            create role wallet_app nologin;
+        end if;
+        if not exists (select 1 from pg_roles where rolname = 'wallet_archiver') then
+            create role wallet_archiver nologin;
+        end if;
+    end
+$$;
+
+revoke update, delete, truncate on movement, transaction, payment_history, ledger_hash, wallet_hash, ledger_checkpoint from wallet_app;
+grant select, insert on movement, transaction, payment_history, ledger_hash, wallet_hash, ledger_checkpoint to wallet_app;
+grant update (movement_status, updated_at) on movement to wallet_app;
+grant select on movement_status_transition to wallet_app;
+grant select on archive_manifest, archive_balance to wallet_app;
+
+-- deletes are only let through by the triggers for rows covered by an archive manifest
+grant select, insert on archive_manifest, archive_balance to wallet_archiver;
+grant select, delete on movement, transaction, payment_history to wallet_archiver;
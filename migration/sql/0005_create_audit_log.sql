@@ -0,0 +1,38 @@
+create table if not exists audit_log
+(
+    id          bigserial primary key,
+    actor_type  varchar(30)  not null,
+    actor_id    varchar(255) not null,
+    action      varchar(100) not null,
+    target_type varchar(30)  not null default '',
+    target_id   varchar(255) not null default '',
+    before      jsonb,
+    after       jsonb,
+    source_ip   varchar(64)  not null default '',
+    request_id  varchar(255) not null default '',
+    trace_id    varchar(32)  not null default '',
+    outcome     varchar(20)  not null,
+    error_code  varchar(100) not null default '',
+    created_at  timestamp default current_timestamp
+);
+
+create index if not exists audit_log_actor_index on audit_log (actor_id, created_at);
+create index if not exists audit_log_target_index on audit_log (target_id, created_at);
+create index if not exists audit_log_created_at_index on audit_log (created_at);
+
+drop trigger if exists audit_log_immutable on audit_log;
+create trigger audit_log_immutable
+    before update or delete
+    on audit_log
+    for each row
+execute function reject_ledger_mutation();
+
+drop trigger if exists audit_log_no_truncate on audit_log;
+create trigger audit_log_no_truncate
+    before truncate
+    on audit_log
+execute function reject_ledger_truncate();
+
+revoke update, delete, truncate on audit_log from wallet_app;
+grant select, insert on audit_log to wallet_app;
+grant usage on sequence audit_log_id_seq to wallet_app;
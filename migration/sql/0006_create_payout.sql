@@ -0,0 +1,55 @@
+create table if not exists payout_batch
+(
+    id                uuid primary key,
+    request_id        varchar(200) not null,
+    funding_user_id   uuid         not null,
+    funding_wallet_id uuid         not null,
+    mode              varchar(30)  not null,
+    batch_status      varchar(30)  not null,
+    line_count        int          not null,
+    total_amount      bigint       not null,
+    reserved_amount   bigint       not null default 0,
+    paid_count        int          not null default 0,
+    paid_amount       bigint       not null default 0,
+    failed_count      int          not null default 0,
+    reserve_group_id  uuid,
+    refund_group_id   uuid,
+    error_code        varchar(100) not null default '',
+    trace_id          varchar(32)  not null default '',
+    locked_until      timestamp,
+    created_at        timestamp default current_timestamp,
+    updated_at        timestamp,
+    completed_at      timestamp
+);
+
+create
+    unique index if not exists payout_batch_request_id_uindex
+    on payout_batch (request_id);
+
+create index if not exists payout_batch_status_index on payout_batch (batch_status, created_at);
+
+create table if not exists payout_line
+(
+    id                uuid primary key,
+    batch_id          uuid         not null references payout_batch (id),
+    line_no           int          not null,
+    recipient_user_id uuid         not null,
+    amount            bigint       not null,
+    reference         varchar(255) not null,
+    line_status       varchar(30)  not null,
+    group_id          uuid,
+    error_code        varchar(100) not null default '',
+    created_at        timestamp default current_timestamp,
+    updated_at        timestamp
+);
+
+create
+    unique index if not exists payout_line_batch_id_line_no_uindex
+    on payout_line (batch_id, line_no);
+
+create
+    unique index if not exists payout_line_batch_id_reference_uindex
+    on payout_line (batch_id, reference);
+
+-- batches and lines are work items, their status moves on while the ledger rows they point at stay immutable
+grant select, insert, update on payout_batch, payout_line to wallet_app;
@@ -0,0 +1,14 @@
+create table if not exists notification
+(
+    id         bigserial primary key,
+    user_id    uuid         not null,
+    kind       varchar(100) not null,
+    message    text         not null,
+    data       jsonb,
+    created_at timestamp default current_timestamp
+);
+
+create index if not exists notification_user_id_index on notification (user_id, id);
+
+grant select, insert on notification to wallet_app;
+grant usage on sequence notification_id_seq to wallet_app;
@@ -0,0 +1,47 @@
+create table if not exists scheduled_transfer
+(
+    id            uuid primary key,
+    payer_user_id uuid         not null,
+    payee_user_id uuid         not null,
+    amount        bigint       not null,
+    rule          varchar(100) not null,
+    time_zone     varchar(64)  not null,
+    start_at      timestamp    not null,
+    end_at        timestamp,
+    max_runs      int          not null default 0,
+    run_count     int          not null default 0,
+    next_run_at   timestamp,
+    status        varchar(30)  not null,
+    request_id    varchar(200) not null,
+    created_at    timestamp default current_timestamp,
+    updated_at    timestamp
+);
+
+create index if not exists scheduled_transfer_payer_user_id_index on scheduled_transfer (payer_user_id, created_at);
+
+create index if not exists scheduled_transfer_due_index on scheduled_transfer (next_run_at) where status = 'ACTIVE';
+
+create table if not exists scheduled_transfer_run
+(
+    id              uuid primary key,
+    schedule_id     uuid         not null references scheduled_transfer (id),
+    scheduled_for   timestamp    not null,
+    run_status      varchar(30)  not null,
+    attempts        int          not null default 0,
+    next_attempt_at timestamp    not null,
+    group_id        uuid,
+    error_code      varchar(100) not null default '',
+    locked_until    timestamp,
+    created_at      timestamp default current_timestamp,
+    updated_at      timestamp
+);
+
+-- an occurrence is created once however many instances see it due
+create
+    unique index if not exists scheduled_transfer_run_schedule_id_scheduled_for_uindex
+    on scheduled_transfer_run (schedule_id, scheduled_for);
+
+create index if not exists scheduled_transfer_run_due_index
+    on scheduled_transfer_run (next_attempt_at) where run_status in ('PENDING', 'RETRYING');
+
+grant select, insert, update on scheduled_transfer, scheduled_transfer_run to wallet_app;
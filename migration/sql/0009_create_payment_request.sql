@@ -0,0 +1,38 @@
+create table if not exists payment_request
+(
+    id                uuid primary key,
+    requester_user_id uuid         not null,
+    payer_user_id     uuid         not null,
+    amount            bigint       not null,
+    memo              varchar(140) not null default '',
+    status            varchar(30)  not null,
+    expires_at        timestamp    not null,
+    group_id          uuid,
+    request_id        varchar(200) not null,
+    created_at        timestamp default current_timestamp,
+    updated_at        timestamp,
+    resolved_at       timestamp
+);
+
+create index if not exists payment_request_requester_user_id_index on payment_request (requester_user_id, created_at);
+
+create index if not exists payment_request_payer_user_id_index on payment_request (payer_user_id, created_at);
+
+create index if not exists payment_request_expires_at_index on payment_request (expires_at) where status = 'PENDING';
+
+create table if not exists payment_request_event
+(
+    id                 bigserial primary key,
+    payment_request_id uuid        not null references payment_request (id),
+    from_status        varchar(30) not null,
+    to_status          varchar(30) not null,
+    actor              varchar(64) not null,
+    created_at         timestamp default current_timestamp
+);
+
+create index if not exists payment_request_event_payment_request_id_index on payment_request_event (payment_request_id, id);
+
+-- a request moves on from PENDING once, its events are history and never change
+grant select, insert, update on payment_request to wallet_app;
+grant select, insert on payment_request_event to wallet_app;
+grant usage on sequence payment_request_event_id_seq to wallet_app;
@@ -0,0 +1,29 @@
+create table if not exists hold
+(
+    id               uuid primary key,
+    user_id          uuid         not null,
+    wallet_id        uuid         not null,
+    merchant_user_id uuid         not null,
+    amount           bigint       not null,
+    captured_amount  bigint       not null default 0,
+    reference        varchar(200) not null default '',
+    status           varchar(30)  not null,
+    expires_at       timestamp    not null,
+    group_id         uuid,
+    request_id       varchar(200) not null,
+    created_at       timestamp default current_timestamp,
+    updated_at       timestamp,
+    resolved_at      timestamp
+);
+
+create index if not exists hold_user_id_index on hold (user_id, created_at);
+
+create index if not exists hold_expires_at_index on hold (expires_at) where status = 'ACTIVE';
+
+-- the HELD balance of a wallet is the total of its active holds, available is COMMITTED minus HELD
+insert into balance (id, wallet_id, balance_type, balance, created_at)
+select gen_random_uuid(), wallet.id, 'HELD', 0, current_timestamp
+from wallet
+where not exists (select 1 from balance where balance.wallet_id = wallet.id and balance.balance_type = 'HELD');
+
+grant select, insert, update on hold to wallet_app;
@@ -0,0 +1,42 @@
+-- the escrow chart account holds the money of funded escrows until it is released to the seller or refunded
+insert into account (id, user_id, account_type)
+values ('4b0c6f1e-2a7d-4f3b-9c85-6d1e0a9b7c21', '9e3f5a70-1c2b-4d8e-a6f4-3b7c2d1e0f59', 'CHART')
+on conflict (id) do nothing;
+
+insert into wallet (id, account_id, currency, decimal_place, wallet_status)
+values ('a8f2d9c4-5e61-4b07-8d3a-2c9e7f1b6a40', '4b0c6f1e-2a7d-4f3b-9c85-6d1e0a9b7c21', 'USD', 2, 'ACTIVE')
+on conflict (id) do nothing;
+
+insert into balance (id, wallet_id, balance_type, balance, created_at)
+values ('d61e8b27-3f4a-4c9d-b052-7a1f6e3c8d94', 'a8f2d9c4-5e61-4b07-8d3a-2c9e7f1b6a40', 'COMMITTED', 0, current_timestamp),
+       ('57c3a9e0-8b2d-4f16-9e7a-0d4b1c6f2e83', 'a8f2d9c4-5e61-4b07-8d3a-2c9e7f1b6a40', 'HELD', 0, current_timestamp)
+on conflict (id) do nothing;
+
+create table if not exists escrow
+(
+    id               uuid primary key,
+    buyer_user_id    uuid         not null,
+    seller_user_id   uuid         not null,
+    amount           bigint       not null,
+    released_amount  bigint       not null default 0,
+    refunded_amount  bigint       not null default 0,
+    reference        varchar(200) not null default '',
+    status           varchar(30)  not null,
+    deadline         timestamp    not null,
+    deadline_action  varchar(30)  not null,
+    fund_group_id    uuid         not null,
+    release_group_id uuid,
+    refund_group_id  uuid,
+    request_id       varchar(200) not null,
+    created_at       timestamp default current_timestamp,
+    updated_at       timestamp,
+    settled_at       timestamp
+);
+
+create index if not exists escrow_buyer_user_id_index on escrow (buyer_user_id, created_at);
+
+create index if not exists escrow_seller_user_id_index on escrow (seller_user_id, created_at);
+
+create index if not exists escrow_deadline_index on escrow (deadline) where status = 'FUNDED';
+
+grant select, insert, update on escrow to wallet_app;
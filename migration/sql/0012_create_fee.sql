@@ -0,0 +1,21 @@
+-- the fee revenue chart account collects the fees paid on top of withdrawals and transfers
+insert into account (id, user_id, account_type)
+values ('e2b7c4a9-0f3d-4e68-b1a5-8c6d9f2e7b13', '6c1d8e4f-7a2b-4f95-8e03-d5a9b7c3f610', 'CHART')
+on conflict (id) do nothing;
+
+insert into wallet (id, account_id, currency, decimal_place, wallet_status)
+values ('f4a6c8e2-9b1d-4c37-a5e0-3d7b2f8c1a96', 'e2b7c4a9-0f3d-4e68-b1a5-8c6d9f2e7b13', 'USD', 2, 'ACTIVE')
+on conflict (id) do nothing;
+
+insert into balance (id, wallet_id, balance_type, balance, created_at)
+values ('0b9e3d5f-6a2c-4e81-9f47-c2d8a1b6e305', 'f4a6c8e2-9b1d-4c37-a5e0-3d7b2f8c1a96', 'COMMITTED', 0, current_timestamp),
+       ('8d2f6a1c-3e7b-4d09-b6c5-a4e9f0d3b782', 'f4a6c8e2-9b1d-4c37-a5e0-3d7b2f8c1a96', 'HELD', 0, current_timestamp)
+on conflict (id) do nothing;
+
+-- the tier of a user picks their fee rules
+alter table app_user
+    add column if not exists tier varchar(30) not null default 'STANDARD';
+
+-- amount stays the net amount the payee got, the payer paid amount plus fee
+alter table payment_history
+    add column if not exists fee bigint not null default 0;
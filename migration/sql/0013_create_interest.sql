@@ -0,0 +1,48 @@
+-- the interest expense chart account pays the monthly interest of savings wallets
+insert into account (id, user_id, account_type)
+values ('7a3e9c15-d2b8-4f60-8e4a-1b5c7d9f3e28', '3f8b2d6e-5c1a-4e97-b0d4-9a6e2c8f1b75', 'CHART')
+on conflict (id) do nothing;
+
+insert into wallet (id, account_id, currency, decimal_place, wallet_status)
+values ('b5d1f7a3-4e9c-4b28-a6f0-e3c8b2d4f917', '7a3e9c15-d2b8-4f60-8e4a-1b5c7d9f3e28', 'USD', 2, 'ACTIVE')
+on conflict (id) do nothing;
+
+insert into balance (id, wallet_id, balance_type, balance, created_at)
+values ('c9e4a2f6-1b7d-4d35-8f93-5a0e6c3b8d21', 'b5d1f7a3-4e9c-4b28-a6f0-e3c8b2d4f917', 'COMMITTED', 0, current_timestamp),
+       ('e1f8b3c7-6a4d-4c92-b5e0-7d2a9f4c6e38', 'b5d1f7a3-4e9c-4b28-a6f0-e3c8b2d4f917', 'HELD', 0, current_timestamp)
+on conflict (id) do nothing;
+
+-- the ACCRUED_INTEREST balance of a wallet is the interest accrued and not paid out yet, it is not spendable
+insert into balance (id, wallet_id, balance_type, balance, created_at)
+select gen_random_uuid(), wallet.id, 'ACCRUED_INTEREST', 0, current_timestamp
+from wallet
+where not exists (select 1 from balance where balance.wallet_id = wallet.id and balance.balance_type = 'ACCRUED_INTEREST');
+
+-- one accrual per wallet and day, so a day run again accrues nothing twice. residual is what was left after rounding
+-- the accrual down to minor units, in millionths of a minor unit, it is carried to the next day of the wallet.
+create table if not exists interest_accrual
+(
+    id              uuid primary key,
+    wallet_id       uuid        not null,
+    user_id         uuid        not null,
+    day             date        not null,
+    balance         bigint      not null,
+    amount          bigint      not null,
+    residual        bigint      not null,
+    payout_group_id uuid,
+    created_at      timestamp default current_timestamp,
+    paid_at         timestamp,
+    unique (wallet_id, day)
+);
+
+create index if not exists interest_accrual_unpaid_index on interest_accrual (day, wallet_id) where paid_at is null;
+
+-- a day is in interest_run once every wallet accrued it
+create table if not exists interest_run
+(
+    day        date primary key,
+    accrued_at timestamp default current_timestamp
+);
+
+grant select, insert, update on interest_accrual to wallet_app;
+grant select, insert on interest_run to wallet_app;
@@ -0,0 +1,20 @@
+-- a pocket is a wallet of its own under the account of the user, the main wallet is the one money moves in and out of
+alter table wallet
+    add column if not exists kind varchar(30) not null default 'MAIN';
+
+create table if not exists pocket
+(
+    id          uuid primary key,
+    user_id     uuid        not null,
+    wallet_id   uuid        not null unique,
+    name        varchar(50) not null,
+    goal_amount bigint      not null default 0,
+    target_date date,
+    locked      boolean     not null default false,
+    created_at  timestamp default current_timestamp,
+    updated_at  timestamp
+);
+
+create unique index if not exists pocket_user_id_name_index on pocket (user_id, lower(name));
+
+grant select, insert, update on pocket to wallet_app;
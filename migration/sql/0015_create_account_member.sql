@@ -0,0 +1,43 @@
+-- the holder of an account is its first owner, members are the other users it is shared with
+alter table account
+    add column if not exists approval_threshold bigint not null default 0;
+
+create table if not exists account_member
+(
+    id             uuid primary key,
+    account_id     uuid        not null,
+    user_id        uuid        not null,
+    role           varchar(30) not null,
+    spending_limit bigint      not null default 0,
+    created_at     timestamp default current_timestamp,
+    updated_at     timestamp,
+    unique (account_id, user_id)
+);
+
+create index if not exists account_member_user_id_index on account_member (user_id);
+
+-- a transfer out of a shared account, waiting for a second owner above the approval threshold
+create table if not exists shared_transfer
+(
+    id                uuid primary key,
+    account_id        uuid         not null,
+    initiator_user_id uuid         not null,
+    to_user_id        uuid         not null,
+    amount            bigint       not null,
+    status            varchar(30)  not null,
+    approver_user_id  uuid,
+    group_id          uuid,
+    request_id        varchar(200) not null unique,
+    created_at        timestamp default current_timestamp,
+    updated_at        timestamp,
+    decided_at        timestamp
+);
+
+create index if not exists shared_transfer_account_id_index on shared_transfer (account_id, created_at);
+
+-- the member who made a payment out of a shared account, empty when the holder made it
+alter table payment_history
+    add column if not exists initiator_user_id varchar(255) not null default '';
+
+grant select, insert, update, delete on account_member to wallet_app;
+grant select, insert, update on shared_transfer to wallet_app;
@@ -0,0 +1,77 @@
+-- an organization holds a BUSINESS account whose user_id is the id of the organization, so payments name it like a user
+create table if not exists organization
+(
+    id                  uuid primary key,
+    legal_name          varchar(100) not null,
+    registration_number varchar(50)  not null unique,
+    country             varchar(2)   not null,
+    email               varchar(255) not null,
+    created_at          timestamp default current_timestamp,
+    updated_at          timestamp
+);
+
+-- employees acting on the business account, invited members become active once they accept
+create table if not exists business_member
+(
+    id                 uuid primary key,
+    organization_id    uuid        not null,
+    user_id            uuid        not null,
+    role               varchar(30) not null,
+    status             varchar(30) not null,
+    invited_by_user_id uuid        not null,
+    created_at         timestamp default current_timestamp,
+    updated_at         timestamp,
+    joined_at          timestamp,
+    unique (organization_id, user_id)
+);
+
+create index if not exists business_member_user_id_index on business_member (user_id);
+
+-- a transfer above above_amount needs the approval of that many approvers, the highest policy below the amount applies
+create table if not exists payment_policy
+(
+    id              uuid primary key,
+    organization_id uuid   not null,
+    above_amount    bigint not null,
+    approvals       int    not null,
+    created_at      timestamp default current_timestamp,
+    updated_at      timestamp,
+    unique (organization_id, above_amount)
+);
+
+create table if not exists business_transfer
+(
+    id                 uuid primary key,
+    organization_id    uuid         not null,
+    initiator_user_id  uuid         not null,
+    to_user_id         uuid         not null,
+    amount             bigint       not null,
+    memo               varchar(200) not null default '',
+    required_approvals int          not null,
+    status             varchar(30)  not null,
+    group_id           uuid,
+    request_id         varchar(200) not null unique,
+    created_at         timestamp default current_timestamp,
+    updated_at         timestamp,
+    decided_at         timestamp
+);
+
+create index if not exists business_transfer_organization_id_index on business_transfer (organization_id, status, created_at);
+
+-- every decision on a business transfer, kept as its trail next to the audit log
+create table if not exists business_approval
+(
+    id                   uuid primary key,
+    business_transfer_id uuid         not null,
+    user_id              uuid         not null,
+    decision             varchar(30)  not null,
+    comment              varchar(200) not null default '',
+    created_at           timestamp default current_timestamp,
+    unique (business_transfer_id, user_id)
+);
+
+grant select, insert on organization to wallet_app;
+grant select, insert, update, delete on business_member to wallet_app;
+grant select, insert, delete on payment_policy to wallet_app;
+grant select, insert, update on business_transfer to wallet_app;
+grant select, insert on business_approval to wallet_app;
@@ -0,0 +1,27 @@
+-- a request id books one movement group. The unit of work claims it in the transaction that writes the group, so a
+-- request posted again, concurrently or after a crash, finds the group booked the first time instead of a second one.
+-- The operation and a hash of the command tell that retry from another command reusing the request id.
+create table if not exists ledger_request
+(
+    request_id   varchar(255) primary key,
+    group_id     uuid         not null,
+    operation    varchar(50)  not null,
+    command_hash varchar(64)  not null,
+    created_at   timestamp default current_timestamp
+);
+
+-- the claims outlive archiving, a request id stays booked after its movements moved to the object store
+drop trigger if exists ledger_request_immutable on ledger_request;
+create trigger ledger_request_immutable
+    before update or delete
+    on ledger_request
+    for each row
+execute function reject_ledger_mutation();
+
+drop trigger if exists ledger_request_no_truncate on ledger_request;
+create trigger ledger_request_no_truncate
+    before truncate
+    on ledger_request
+execute function reject_ledger_truncate();
+
+grant select, insert on ledger_request to wallet_app;
@@ -0,0 +1,7 @@
+package migration
+
+import "github.com/google/wire"
+
+var (
+	WireSet = wire.NewSet(ProvideMigrator)
+)
@@ -0,0 +1,35 @@
+package archive
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+type Manifest struct {
+	ID          uuid.UUID
+	SourceTable string
+	RangeStart  time.Time
+	RangeEnd    time.Time
+	ObjectKey   string
+	Format      string
+	RecordCount int
+	ByteSize    int
+	Checksum    string
+	CreatedAt   time.Time
+}
+
+func (manifest Manifest) TableName() string {
+	return "archive_manifest"
+}
+
+// Balance is what the archived transactions of a manifest add to one balance of a wallet
+type Balance struct {
+	ManifestID  uuid.UUID
+	WalletID    uuid.UUID
+	BalanceType string
+	Balance     int
+}
+
+func (balance Balance) TableName() string {
+	return "archive_balance"
+}
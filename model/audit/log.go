@@ -0,0 +1,28 @@
+package audit
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Log is one append-only audit record of who did what to which target and how it ended
+type Log struct {
+	ID         int64 `gorm:"primaryKey"`
+	ActorType  string
+	ActorID    string
+	Action     string
+	TargetType string
+	TargetID   string
+	Before     json.RawMessage
+	After      json.RawMessage
+	SourceIP   string
+	RequestID  string
+	TraceID    string
+	Outcome    string
+	ErrorCode  string
+	CreatedAt  time.Time
+}
+
+func (log Log) TableName() string {
+	return "audit_log"
+}
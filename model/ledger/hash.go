@@ -0,0 +1,50 @@
+package ledger
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+// Entry links one movement group into the global hash chain
+type Entry struct {
+	Sequence         int64 `gorm:"primaryKey;autoIncrement:false"`
+	GroupID          uuid.UUID
+	ContentHash      string
+	MovementCount    int
+	TransactionCount int
+	PrevHash         string
+	Hash             string
+	CreatedAt        time.Time
+}
+
+func (entry Entry) TableName() string {
+	return "ledger_hash"
+}
+
+// WalletEntry links one movement group into the hash chain of every wallet it touches
+type WalletEntry struct {
+	WalletID    uuid.UUID `gorm:"primaryKey"`
+	Sequence    int64     `gorm:"primaryKey;autoIncrement:false"`
+	GroupID     uuid.UUID
+	ContentHash string
+	PrevHash    string
+	Hash        string
+	CreatedAt   time.Time
+}
+
+func (walletEntry WalletEntry) TableName() string {
+	return "wallet_hash"
+}
+
+// Checkpoint is a signed statement of the global chain head at a sequence
+type Checkpoint struct {
+	Sequence  int64 `gorm:"primaryKey;autoIncrement:false"`
+	Hash      string
+	KeyID     string
+	Signature string
+	CreatedAt time.Time
+}
+
+func (checkpoint Checkpoint) TableName() string {
+	return "ledger_checkpoint"
+}
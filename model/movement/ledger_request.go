@@ -0,0 +1,20 @@
+package movement
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+// LedgerRequest books a request id to the movement group it posted, a request id books one group. Operation and
+// CommandHash tell a retry of the command from another command reusing its request id.
+type LedgerRequest struct {
+	RequestID   string
+	GroupID     uuid.UUID
+	Operation   string
+	CommandHash string
+	CreatedAt   time.Time
+}
+
+func (request LedgerRequest) TableName() string {
+	return "ledger_request"
+}
@@ -13,6 +13,8 @@ type Movement struct {
 	DebitBalance   int
 	CreditBalance  int
 	MovementStatus string
+	TraceID        string
+	RequestID      string
 	CreatedAt      time.Time
 	UpdatedAt      time.Time
 }
@@ -0,0 +1,21 @@
+package notification
+
+import (
+	"encoding/json"
+	"github.com/google/uuid"
+	"time"
+)
+
+// Notification is a message for a user about something that happened to their wallet without them asking
+type Notification struct {
+	ID        int64 `gorm:"primaryKey"`
+	UserID    uuid.UUID
+	Kind      string
+	Message   string
+	Data      json.RawMessage
+	CreatedAt time.Time
+}
+
+func (n Notification) TableName() string {
+	return "notification"
+}
@@ -0,0 +1,73 @@
+package payment
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+const (
+	BusinessTransferPending   = "PENDING"
+	BusinessTransferCompleted = "COMPLETED"
+	BusinessTransferRejected  = "REJECTED"
+
+	DecisionApproved = "APPROVED"
+	DecisionRejected = "REJECTED"
+)
+
+// PaymentPolicy has a transfer out of a business account above AboveAmount, in minor units, wait for Approvals
+// approvers. The policy with the highest AboveAmount under the amount applies.
+type PaymentPolicy struct {
+	ID             uuid.UUID
+	OrganizationID uuid.UUID
+	AboveAmount    int
+	Approvals      int
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
+
+func (policy PaymentPolicy) TableName() string {
+	return "payment_policy"
+}
+
+// BusinessTransfer is a transfer a member initiated out of a business account. It waits in the queue as PENDING until
+// RequiredApprovals approvers other than InitiatorUserID approved it, or one rejected it. GroupID is the movement group
+// once it is paid.
+type BusinessTransfer struct {
+	ID                uuid.UUID
+	OrganizationID    uuid.UUID
+	InitiatorUserID   uuid.UUID
+	ToUserID          uuid.UUID
+	Amount            int
+	Memo              string
+	RequiredApprovals int
+	Status            string
+	GroupID           *uuid.UUID
+	RequestID         string
+	CreatedAt         time.Time
+	UpdatedAt         time.Time
+	DecidedAt         *time.Time
+}
+
+func (transfer BusinessTransfer) TableName() string {
+	return "business_transfer"
+}
+
+// TransferRequestID is the request id of the transfer that pays it, there is one per business transfer however many
+// approvals it gets
+func (transfer BusinessTransfer) TransferRequestID() string {
+	return "business-transfer/" + transfer.ID.String()
+}
+
+// BusinessApproval is the decision of a member on a business transfer
+type BusinessApproval struct {
+	ID                 uuid.UUID
+	BusinessTransferID uuid.UUID
+	UserID             uuid.UUID
+	Decision           string
+	Comment            string
+	CreatedAt          time.Time
+}
+
+func (approval BusinessApproval) TableName() string {
+	return "business_approval"
+}
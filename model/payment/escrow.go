@@ -0,0 +1,54 @@
+package payment
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+const (
+	EscrowFunded   = "FUNDED"
+	EscrowReleased = "RELEASED"
+	EscrowRefunded = "REFUNDED"
+	EscrowSplit    = "SPLIT"
+
+	// EscrowActionRelease and EscrowActionRefund are what happens to a funded escrow at its deadline
+	EscrowActionRelease = "RELEASE"
+	EscrowActionRefund  = "REFUND"
+)
+
+// Escrow keeps Amount of the buyer in the escrow chart account until it is released to the seller, refunded to the
+// buyer or split between them. ReleasedAmount and RefundedAmount always add up to Amount once it settled. FundGroupID,
+// ReleaseGroupID and RefundGroupID are the movement groups that moved the money in and out.
+type Escrow struct {
+	ID             uuid.UUID
+	BuyerUserID    uuid.UUID
+	SellerUserID   uuid.UUID
+	Amount         int
+	ReleasedAmount int
+	RefundedAmount int
+	Reference      string
+	Status         string
+	Deadline       time.Time
+	DeadlineAction string
+	FundGroupID    uuid.UUID
+	ReleaseGroupID *uuid.UUID
+	RefundGroupID  *uuid.UUID
+	RequestID      string
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+	SettledAt      *time.Time
+}
+
+func (escrow Escrow) TableName() string {
+	return "escrow"
+}
+
+// ReleaseRequestID and RefundRequestID are the request ids of the movements that settle the escrow, there is one of
+// each per escrow however many times it is settled
+func (escrow Escrow) ReleaseRequestID() string {
+	return "escrow/" + escrow.ID.String() + "/release"
+}
+
+func (escrow Escrow) RefundRequestID() string {
+	return "escrow/" + escrow.ID.String() + "/refund"
+}
@@ -5,18 +5,44 @@ import (
 	"time"
 )
 
+// PaymentHistory is one payment between two users. Amount is what the payee got, the payer paid Fee on top of it.
+// InitiatorUserId is the member who paid out of a shared account, empty when the payer did.
 type PaymentHistory struct {
-	ID          uuid.UUID
-	PayerUserId string
-	PayerName   string
-	PayeeUserId string
-	PayeeName   string
-	Amount      int
-	PayType     string
-	CreatedAt   time.Time
-	UpdatedAt   time.Time
+	ID              uuid.UUID
+	PayerUserId     string
+	PayerName       string
+	PayeeUserId     string
+	PayeeName       string
+	Amount          int
+	Fee             int
+	PayType         string
+	InitiatorUserId string
+	TraceID         string
+	RequestID       string
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
 }
 
 func (paymentHistory PaymentHistory) TableName() string {
 	return "payment_history"
 }
+
+// GrossAmount is what the payer paid, the amount and the fee
+func (paymentHistory PaymentHistory) GrossAmount() int {
+	return paymentHistory.Amount + paymentHistory.Fee
+}
+
+// SignedAmount is the amount as seen by the given user, the gross amount negated when the money left their wallet
+func (paymentHistory PaymentHistory) SignedAmount(userID string) int {
+	switch paymentHistory.PayType {
+	// a move into a pocket is seen from the main wallet
+	case "WITHDRAWAL", "POCKET_DEPOSIT":
+		return -paymentHistory.GrossAmount()
+	// transfers, adjustments, payouts, captures and escrows name the wallet the money left as the payer
+	case "TRANSFER", "ADJUSTMENT", "PAYOUT", "CAPTURE", "ESCROW":
+		if paymentHistory.PayerUserId == userID {
+			return -paymentHistory.GrossAmount()
+		}
+	}
+	return paymentHistory.Amount
+}
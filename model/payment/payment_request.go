@@ -0,0 +1,57 @@
+package payment
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+const (
+	RequestPending  = "PENDING"
+	RequestApproved = "APPROVED"
+	RequestDeclined = "DECLINED"
+	RequestExpired  = "EXPIRED"
+
+	// ActorSystem is the actor of transitions nobody asked for, such as an expiry
+	ActorSystem = "system"
+)
+
+// PaymentRequest asks PayerUserID to pay Amount to RequesterUserID. GroupID is the movement group of the transfer once
+// the payer approved.
+type PaymentRequest struct {
+	ID              uuid.UUID
+	RequesterUserID uuid.UUID
+	PayerUserID     uuid.UUID
+	Amount          int
+	Memo            string
+	Status          string
+	ExpiresAt       time.Time
+	GroupID         *uuid.UUID
+	RequestID       string
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+	ResolvedAt      *time.Time
+}
+
+func (r PaymentRequest) TableName() string {
+	return "payment_request"
+}
+
+// TransferRequestID is the request id of the transfer that pays the request, there is one per request however many
+// times it is approved
+func (r PaymentRequest) TransferRequestID() string {
+	return "payment-request/" + r.ID.String()
+}
+
+// PaymentRequestEvent is one status transition, the creation included with an empty FromStatus
+type PaymentRequestEvent struct {
+	ID               int64 `gorm:"primaryKey"`
+	PaymentRequestID uuid.UUID
+	FromStatus       string
+	ToStatus         string
+	Actor            string
+	CreatedAt        time.Time
+}
+
+func (e PaymentRequestEvent) TableName() string {
+	return "payment_request_event"
+}
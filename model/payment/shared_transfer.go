@@ -0,0 +1,39 @@
+package payment
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+const (
+	SharedTransferPending   = "PENDING"
+	SharedTransferCompleted = "COMPLETED"
+	SharedTransferDeclined  = "DECLINED"
+)
+
+// SharedTransfer is a transfer a member made out of a shared account. Above the approval threshold of the account it
+// stays PENDING until an owner other than InitiatorUserID approves it. GroupID is the movement group once it is paid.
+type SharedTransfer struct {
+	ID              uuid.UUID
+	AccountID       uuid.UUID
+	InitiatorUserID uuid.UUID
+	ToUserID        uuid.UUID
+	Amount          int
+	Status          string
+	ApproverUserID  *uuid.UUID
+	GroupID         *uuid.UUID
+	RequestID       string
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+	DecidedAt       *time.Time
+}
+
+func (transfer SharedTransfer) TableName() string {
+	return "shared_transfer"
+}
+
+// TransferRequestID is the request id of the transfer that pays it, there is one per shared transfer however many
+// times it is approved
+func (transfer SharedTransfer) TransferRequestID() string {
+	return "shared-transfer/" + transfer.ID.String()
+}
@@ -0,0 +1,80 @@
+package payout
+
+import (
+	"github.com/google/uuid"
+	"strconv"
+	"time"
+)
+
+const (
+	// ModeAllOrNothing pays every line or none, one invalid line rejects the batch
+	ModeAllOrNothing = "ALL_OR_NOTHING"
+	// ModeBestEffort pays the lines it can and reports the others as failed
+	ModeBestEffort = "BEST_EFFORT"
+
+	BatchPending   = "PENDING"
+	BatchRunning   = "RUNNING"
+	BatchCompleted = "COMPLETED"
+	// BatchPartial is a best effort batch that finished with failed lines
+	BatchPartial = "PARTIALLY_COMPLETED"
+	BatchFailed  = "FAILED"
+
+	LinePending = "PENDING"
+	LinePaid    = "PAID"
+	LineFailed  = "FAILED"
+)
+
+type Batch struct {
+	ID              uuid.UUID
+	RequestID       string
+	FundingUserID   uuid.UUID
+	FundingWalletID uuid.UUID
+	Mode            string
+	BatchStatus     string
+	LineCount       int
+	TotalAmount     int
+	ReservedAmount  int
+	PaidCount       int
+	PaidAmount      int
+	FailedCount     int
+	ReserveGroupID  *uuid.UUID
+	RefundGroupID   *uuid.UUID
+	ErrorCode       string
+	TraceID         string
+	LockedUntil     *time.Time
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+	CompletedAt     *time.Time
+}
+
+func (batch Batch) TableName() string {
+	return "payout_batch"
+}
+
+// Done tells whether the batch reached a final status
+func (batch Batch) Done() bool {
+	return batch.BatchStatus == BatchCompleted || batch.BatchStatus == BatchPartial || batch.BatchStatus == BatchFailed
+}
+
+type Line struct {
+	ID              uuid.UUID
+	BatchID         uuid.UUID
+	LineNo          int
+	RecipientUserID uuid.UUID
+	Amount          int
+	Reference       string
+	LineStatus      string
+	GroupID         *uuid.UUID
+	ErrorCode       string
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+}
+
+func (line Line) TableName() string {
+	return "payout_line"
+}
+
+// RequestID is the request id of the movement group that pays the line
+func (line Line) RequestID(batch *Batch) string {
+	return batch.RequestID + "/" + strconv.Itoa(line.LineNo)
+}
@@ -0,0 +1,74 @@
+package schedule
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+const (
+	StatusActive    = "ACTIVE"
+	StatusCancelled = "CANCELLED"
+	// StatusCompleted is a schedule past its end date or its maximum number of runs
+	StatusCompleted = "COMPLETED"
+
+	RunPending = "PENDING"
+	// RunRetrying is an occurrence that failed for insufficient funds and is tried again at NextAttemptAt
+	RunRetrying  = "RETRYING"
+	RunSucceeded = "SUCCEEDED"
+	RunFailed    = "FAILED"
+	// RunSkipped is an occurrence that was due longer than scheduler.misfire_window ago, e.g. during an outage
+	RunSkipped = "SKIPPED"
+)
+
+// ScheduledTransfer is a standing order from the wallet of PayerUserID to the one of PayeeUserID. NextRunAt is the
+// next occurrence that has not been created yet, nil once the schedule ended.
+type ScheduledTransfer struct {
+	ID          uuid.UUID
+	PayerUserID uuid.UUID
+	PayeeUserID uuid.UUID
+	Amount      int
+	Rule        string
+	TimeZone    string
+	StartAt     time.Time
+	EndAt       *time.Time
+	MaxRuns     int
+	RunCount    int
+	NextRunAt   *time.Time
+	Status      string
+	RequestID   string
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+func (s ScheduledTransfer) TableName() string {
+	return "scheduled_transfer"
+}
+
+// Run is one occurrence of a schedule, ScheduledFor is unique per schedule so an occurrence is only created once
+type Run struct {
+	ID            uuid.UUID
+	ScheduleID    uuid.UUID
+	ScheduledFor  time.Time
+	RunStatus     string
+	Attempts      int
+	NextAttemptAt time.Time
+	GroupID       *uuid.UUID
+	ErrorCode     string
+	LockedUntil   *time.Time
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+}
+
+func (run Run) TableName() string {
+	return "scheduled_transfer_run"
+}
+
+// RequestID is the idempotency key of the transfer of an occurrence, the same on every attempt and every instance
+func (run Run) RequestID() string {
+	return "schedule/" + run.ScheduleID.String() + "/" + run.ScheduledFor.UTC().Format(time.RFC3339)
+}
+
+// Done tells whether the occurrence reached a final status
+func (run Run) Done() bool {
+	return run.RunStatus == RunSucceeded || run.RunStatus == RunFailed || run.RunStatus == RunSkipped
+}
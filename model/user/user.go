@@ -5,6 +5,9 @@ import (
 	"time"
 )
 
+// TierStandard is the tier of a user nobody put in another one, the tier picks the fee rules of their payments
+const TierStandard = "STANDARD"
+
 type AppUser struct {
 	ID          uuid.UUID
 	Email       string
@@ -12,6 +15,7 @@ type AppUser struct {
 	Password    string
 	FirstName   string
 	LastName    string
+	Tier        string
 	CreatedAt   time.Time
 	UpdatedAt   time.Time
 }
@@ -5,12 +5,15 @@ import (
 	"time"
 )
 
+// Account is held by UserID and may be shared with other members. A transfer out of it above ApprovalThreshold, in minor
+// units, waits for a second owner, zero never does.
 type Account struct {
-	ID          uuid.UUID
-	UserID      uuid.UUID
-	AccountType string
-	CreatedAt   time.Time
-	UpdatedAt   time.Time
+	ID                uuid.UUID
+	UserID            uuid.UUID
+	AccountType       string
+	ApprovalThreshold int
+	CreatedAt         time.Time
+	UpdatedAt         time.Time
 }
 
 func (account Account) TableName() string {
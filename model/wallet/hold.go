@@ -0,0 +1,37 @@
+package wallet
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+const (
+	HoldActive   = "ACTIVE"
+	HoldCaptured = "CAPTURED"
+	HoldReleased = "RELEASED"
+	HoldExpired  = "EXPIRED"
+)
+
+// Hold reserves Amount of the available balance of a wallet for MerchantUserID without moving money. While it is
+// ACTIVE the amount counts in the HELD balance of the wallet. A capture books CapturedAmount to the merchant and gives
+// the rest back, GroupID is the movement group of the capture.
+type Hold struct {
+	ID             uuid.UUID
+	UserID         uuid.UUID
+	WalletID       uuid.UUID
+	MerchantUserID uuid.UUID
+	Amount         int
+	CapturedAmount int
+	Reference      string
+	Status         string
+	ExpiresAt      time.Time
+	GroupID        *uuid.UUID
+	RequestID      string
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+	ResolvedAt     *time.Time
+}
+
+func (hold Hold) TableName() string {
+	return "hold"
+}
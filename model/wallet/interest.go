@@ -0,0 +1,36 @@
+package wallet
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+// InterestAccrual is the interest a wallet earned over Day on its end of day Balance. Amount is in minor units, what
+// was left after rounding it down is carried to the next accrual as Residual, in millionths of a minor unit. It counts
+// in the ACCRUED_INTEREST balance of the wallet until it is paid out in PayoutGroupID.
+type InterestAccrual struct {
+	ID            uuid.UUID
+	WalletID      uuid.UUID
+	UserID        uuid.UUID
+	Day           time.Time
+	Balance       int
+	Amount        int
+	Residual      int
+	PayoutGroupID *uuid.UUID
+	CreatedAt     time.Time
+	PaidAt        *time.Time
+}
+
+func (accrual InterestAccrual) TableName() string {
+	return "interest_accrual"
+}
+
+// InterestRun marks a day every wallet accrued interest for
+type InterestRun struct {
+	Day       time.Time
+	AccruedAt time.Time
+}
+
+func (run InterestRun) TableName() string {
+	return "interest_run"
+}
@@ -0,0 +1,41 @@
+package wallet
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+const (
+	// RoleOwner manages the members and approves transfers, the holder of an account is always an owner
+	RoleOwner = "OWNER"
+	// RoleSpender may transfer out of the account up to their spending limit and sees the payments they made
+	RoleSpender = "SPENDER"
+	// RoleViewer sees the balance and every payment and moves no money
+	RoleViewer = "VIEWER"
+)
+
+// AccountMember is a user an account is shared with. SpendingLimit, in minor units, caps every transfer of a spender,
+// zero is no limit.
+type AccountMember struct {
+	ID            uuid.UUID
+	AccountID     uuid.UUID
+	UserID        uuid.UUID
+	Role          string
+	SpendingLimit int
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+}
+
+func (member AccountMember) TableName() string {
+	return "account_member"
+}
+
+// CanSpend tells whether the member may transfer money out of the account
+func (member AccountMember) CanSpend() bool {
+	return member.Role == RoleOwner || member.Role == RoleSpender
+}
+
+// SeesEveryPayment tells whether the member sees the payments of the other members as well as their own
+func (member AccountMember) SeesEveryPayment() bool {
+	return member.Role != RoleSpender
+}
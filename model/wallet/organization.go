@@ -0,0 +1,71 @@
+package wallet
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+// AccountTypeBusiness is the account of an organization, next to the CUSTOMER accounts of users and the CHART accounts
+// of the platform. Its UserID is the id of the organization.
+const AccountTypeBusiness = "BUSINESS"
+
+const (
+	// BusinessRoleAdmin manages the members and the payment policies, initiates and approves transfers
+	BusinessRoleAdmin = "ADMIN"
+	// BusinessRoleInitiator initiates transfers out of the business account
+	BusinessRoleInitiator = "INITIATOR"
+	// BusinessRoleApprover approves or rejects the transfers other members initiated
+	BusinessRoleApprover = "APPROVER"
+	// BusinessRoleViewer sees the balance, the payments and the transfers and moves no money
+	BusinessRoleViewer = "VIEWER"
+
+	BusinessMemberInvited = "INVITED"
+	BusinessMemberActive  = "ACTIVE"
+)
+
+// Organization is the profile of a company holding a business account
+type Organization struct {
+	ID                 uuid.UUID
+	LegalName          string
+	RegistrationNumber string
+	Country            string
+	Email              string
+	CreatedAt          time.Time
+	UpdatedAt          time.Time
+}
+
+func (organization Organization) TableName() string {
+	return "organization"
+}
+
+// BusinessMember is an employee acting on the account of an organization. An invited member does nothing until they
+// accept and become active.
+type BusinessMember struct {
+	ID              uuid.UUID
+	OrganizationID  uuid.UUID
+	UserID          uuid.UUID
+	Role            string
+	Status          string
+	InvitedByUserID uuid.UUID
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+	JoinedAt        *time.Time
+}
+
+func (member BusinessMember) TableName() string {
+	return "business_member"
+}
+
+func (member BusinessMember) Active() bool {
+	return member.Status == BusinessMemberActive
+}
+
+// CanInitiate tells whether the member may initiate transfers out of the business account
+func (member BusinessMember) CanInitiate() bool {
+	return member.Active() && (member.Role == BusinessRoleAdmin || member.Role == BusinessRoleInitiator)
+}
+
+// CanApprove tells whether the member may approve or reject the transfers of other members
+func (member BusinessMember) CanApprove() bool {
+	return member.Active() && (member.Role == BusinessRoleAdmin || member.Role == BusinessRoleApprover)
+}
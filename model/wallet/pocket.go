@@ -0,0 +1,29 @@
+package wallet
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+// Pocket is money a user set aside under a name, kept in a POCKET wallet of their account. GoalAmount, in minor units,
+// is zero without a goal. A Locked pocket gives nothing back before its TargetDate.
+type Pocket struct {
+	ID         uuid.UUID
+	UserID     uuid.UUID
+	WalletID   uuid.UUID
+	Name       string
+	GoalAmount int
+	TargetDate *time.Time
+	Locked     bool
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
+
+func (pocket Pocket) TableName() string {
+	return "pocket"
+}
+
+// LockedAt tells whether money may not leave the pocket at a time
+func (pocket Pocket) LockedAt(now time.Time) bool {
+	return pocket.Locked && pocket.TargetDate != nil && now.Before(*pocket.TargetDate)
+}
@@ -5,12 +5,23 @@ import (
 	"time"
 )
 
+const (
+	StatusActive = "ACTIVE"
+	StatusFrozen = "FROZEN"
+
+	KindMain   = "MAIN"
+	KindPocket = "POCKET"
+)
+
+// Wallet holds the balances of an account. An account has one MAIN wallet money moves in and out of, and a POCKET
+// wallet for each of its pockets.
 type Wallet struct {
 	ID           uuid.UUID
 	AccountID    uuid.UUID
 	Currency     string
 	DecimalPlace int
 	WalletStatus string
+	Kind         string
 	CreatedAt    time.Time
 	UpdatedAt    time.Time
 }
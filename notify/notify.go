@@ -0,0 +1,57 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/google/uuid"
+	"github.com/google/wire"
+	"github.com/raychongtk/wallet/model/notification"
+	"github.com/raychongtk/wallet/repository"
+	"github.com/raychongtk/wallet/util"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"time"
+)
+
+var (
+	WireSet = wire.NewSet(ProvideNotifier)
+)
+
+const (
+	KindScheduledTransferFailed = "SCHEDULED_TRANSFER_FAILED"
+	KindPaymentRequested        = "PAYMENT_REQUESTED"
+	KindPaymentRequestApproved  = "PAYMENT_REQUEST_APPROVED"
+	KindPaymentRequestDeclined  = "PAYMENT_REQUEST_DECLINED"
+	KindPaymentRequestExpired   = "PAYMENT_REQUEST_EXPIRED"
+	KindApprovalRequested       = "APPROVAL_REQUESTED"
+	KindBusinessInvitation      = "BUSINESS_INVITATION"
+)
+
+// Notifier keeps a message in the inbox of a user. Delivery to a device is left to whoever reads the inbox.
+type Notifier struct {
+	notificationRepo repository.NotificationRepository
+	db               gorm.DB
+}
+
+func ProvideNotifier(notificationRepo repository.NotificationRepository, db gorm.DB) *Notifier {
+	return &Notifier{notificationRepo: notificationRepo, db: db}
+}
+
+// Notify sends a message to a user, a nil notifier sends nothing so optional callers do not need to check
+func (n *Notifier) Notify(ctx context.Context, userID uuid.UUID, kind string, message string, data interface{}) error {
+	if n == nil {
+		return nil
+	}
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+	util.Info("Notify user", zap.String("user_id", userID.String()), zap.String("kind", kind))
+	return n.notificationRepo.CreateNotification(n.db.WithContext(ctx), &notification.Notification{
+		UserID:    userID,
+		Kind:      kind,
+		Message:   message,
+		Data:      raw,
+		CreatedAt: time.Now(),
+	})
+}
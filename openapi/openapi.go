@@ -0,0 +1,169 @@
+package openapi
+
+import (
+	"bytes"
+	"context"
+	_ "embed"
+	"errors"
+	"fmt"
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/getkin/kin-openapi/routers"
+	"github.com/getkin/kin-openapi/routers/gorillamux"
+	"github.com/gin-gonic/gin"
+	"github.com/google/wire"
+	"github.com/raychongtk/wallet/config"
+	"github.com/raychongtk/wallet/domain"
+	"github.com/raychongtk/wallet/problem"
+	"github.com/raychongtk/wallet/util"
+	"go.uber.org/zap"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// maxResponseBody is the largest response that is kept for validation, exports are bigger and skipped
+const maxResponseBody = 1 << 20
+
+var (
+	WireSet = wire.NewSet(ProvideValidator)
+
+	//go:embed openapi.json
+	document []byte
+)
+
+// Load parses and validates the embedded OpenAPI document
+func Load() (*openapi3.T, error) {
+	spec, err := openapi3.NewLoader().LoadFromData(document)
+	if err != nil {
+		return nil, fmt.Errorf("load openapi document: %w", err)
+	}
+	if err := spec.Validate(context.Background()); err != nil {
+		return nil, fmt.Errorf("invalid openapi document: %w", err)
+	}
+	return spec, nil
+}
+
+// Handler serves the document at /openapi.json
+func Handler(ctx *gin.Context) {
+	ctx.Data(http.StatusOK, "application/json", document)
+}
+
+// Validator checks requests and responses against the OpenAPI document, so the routes cannot drift from the contract
+// that clients are generated from
+type Validator struct {
+	spec              *openapi3.T
+	router            routers.Router
+	validateResponses bool
+}
+
+func ProvideValidator(cfg *config.Config) (*Validator, error) {
+	spec, err := Load()
+	if err != nil {
+		return nil, err
+	}
+	return NewValidator(spec, cfg.Features.ResponseValidation)
+}
+
+func NewValidator(spec *openapi3.T, validateResponses bool) (*Validator, error) {
+	router, err := gorillamux.NewRouter(spec)
+	if err != nil {
+		return nil, fmt.Errorf("route openapi document: %w", err)
+	}
+	return &Validator{spec: spec, router: router, validateResponses: validateResponses}, nil
+}
+
+// Spec is the document the validator checks against
+func (v *Validator) Spec() *openapi3.T {
+	return v.spec
+}
+
+// Middleware rejects requests that do not match the document with INVALID_PARAMETERS. Responses that do not match
+// are logged rather than rejected because the change was already made, they mean the document is out of date. A nil
+// validator lets everything through.
+func (v *Validator) Middleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if v == nil {
+			c.Next()
+			return
+		}
+		route, pathParams, err := v.router.FindRoute(c.Request)
+		if err != nil {
+			util.Error("Route is missing from the openapi document", zap.String("path", c.FullPath()), zap.Error(err))
+			c.Next()
+			return
+		}
+		input := &openapi3filter.RequestValidationInput{
+			Request:    c.Request,
+			PathParams: pathParams,
+			Route:      route,
+			Options:    &openapi3filter.Options{AuthenticationFunc: openapi3filter.NoopAuthenticationFunc},
+		}
+		if err := openapi3filter.ValidateRequest(c.Request.Context(), input); err != nil {
+			problem.Respond(c, domain.ErrInvalidParameters.WithMessage("%s", reason(err)))
+			return
+		}
+		if !v.validateResponses {
+			c.Next()
+			return
+		}
+
+		writer := &capturingWriter{ResponseWriter: c.Writer}
+		c.Writer = writer
+		c.Next()
+		if writer.truncated || !strings.HasPrefix(writer.Header().Get("Content-Type"), "application/json") {
+			return
+		}
+		output := &openapi3filter.ResponseValidationInput{
+			RequestValidationInput: input,
+			Status:                 writer.Status(),
+			Header:                 writer.Header(),
+			Body:                   io.NopCloser(bytes.NewReader(writer.body.Bytes())),
+		}
+		if err := openapi3filter.ValidateResponse(c.Request.Context(), output); err != nil {
+			util.Error("Response does not match the openapi document", zap.String("path", c.FullPath()), zap.Int("status", writer.Status()), zap.Error(err))
+		}
+	}
+}
+
+// reason keeps the part of a validation error that tells the client what to fix
+func reason(err error) string {
+	var requestErr *openapi3filter.RequestError
+	if errors.As(err, &requestErr) {
+		if requestErr.Parameter != nil {
+			return fmt.Sprintf("parameter %s: %s", requestErr.Parameter.Name, requestErr.Reason)
+		}
+		var schemaErr *openapi3.SchemaError
+		if errors.As(requestErr.Err, &schemaErr) {
+			return fmt.Sprintf("request body: %s", schemaErr.Reason)
+		}
+		if requestErr.Reason != "" {
+			return requestErr.Reason
+		}
+	}
+	return err.Error()
+}
+
+type capturingWriter struct {
+	gin.ResponseWriter
+	body      bytes.Buffer
+	truncated bool
+}
+
+func (w *capturingWriter) Write(data []byte) (int, error) {
+	w.keep(data)
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *capturingWriter) WriteString(data string) (int, error) {
+	w.keep([]byte(data))
+	return w.ResponseWriter.WriteString(data)
+}
+
+func (w *capturingWriter) keep(data []byte) {
+	if w.truncated || w.body.Len()+len(data) > maxResponseBody {
+		w.truncated = true
+		return
+	}
+	w.body.Write(data)
+}
@@ -0,0 +1,81 @@
+package openapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/gin-gonic/gin"
+	"github.com/raychongtk/wallet/problem"
+	"github.com/raychongtk/wallet/util"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func newTestRouter(t *testing.T) *gin.Engine {
+	util.InitializeLogger(false)
+	spec, err := Load()
+	assert.NoError(t, err)
+	validator, err := NewValidator(spec, true)
+	assert.NoError(t, err)
+
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.POST("/api/v1/wallet/deposit", validator.Middleware(), func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"result": true})
+	})
+	r.GET("/api/v1/wallet/balance", validator.Middleware(), func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"customer_id": c.Query("user_id"), "currency": "HKD", "balance": "1.00"})
+	})
+	r.GET("/openapi.json", Handler)
+	return r
+}
+
+func TestMiddlewareRejectsRequestsOffTheContract(t *testing.T) {
+	r := newTestRouter(t)
+	cases := []struct {
+		name   string
+		body   string
+		status int
+	}{
+		{"valid", `{"user_id": "2d988f4a-a037-4ce9-a350-f13445793e88", "balance": "100"}`, http.StatusOK},
+		{"missing balance", `{"user_id": "2d988f4a-a037-4ce9-a350-f13445793e88"}`, http.StatusBadRequest},
+		{"numeric balance", `{"user_id": "2d988f4a-a037-4ce9-a350-f13445793e88", "balance": 100}`, http.StatusBadRequest},
+		{"not json", `user_id=1`, http.StatusBadRequest},
+	}
+	for _, c := range cases {
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/wallet/deposit", bytes.NewBufferString(c.body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("X-Request-ID", "request-1")
+		resp := httptest.NewRecorder()
+		r.ServeHTTP(resp, req)
+
+		assert.Equal(t, c.status, resp.Code, c.name)
+		if c.status != http.StatusOK {
+			var details problem.Details
+			assert.NoError(t, json.Unmarshal(resp.Body.Bytes(), &details))
+			assert.Equal(t, "INVALID_PARAMETERS", details.ErrorCode, c.name)
+		}
+	}
+
+	resp := httptest.NewRecorder()
+	r.ServeHTTP(resp, httptest.NewRequest(http.MethodGet, "/api/v1/wallet/balance", nil))
+	assert.Equal(t, http.StatusBadRequest, resp.Code)
+	resp = httptest.NewRecorder()
+	r.ServeHTTP(resp, httptest.NewRequest(http.MethodGet, "/api/v1/wallet/balance?user_id=john&as_of=yesterday", nil))
+	assert.Equal(t, http.StatusBadRequest, resp.Code)
+	resp = httptest.NewRecorder()
+	r.ServeHTTP(resp, httptest.NewRequest(http.MethodGet, "/api/v1/wallet/balance?user_id=john", nil))
+	assert.Equal(t, http.StatusOK, resp.Code)
+}
+
+func TestHandlerServesTheDocument(t *testing.T) {
+	r := newTestRouter(t)
+	resp := httptest.NewRecorder()
+	r.ServeHTTP(resp, httptest.NewRequest(http.MethodGet, "/openapi.json", nil))
+
+	assert.Equal(t, http.StatusOK, resp.Code)
+	var document map[string]interface{}
+	assert.NoError(t, json.Unmarshal(resp.Body.Bytes(), &document))
+	assert.Equal(t, "3.0.3", document["openapi"])
+}
@@ -0,0 +1,294 @@
+package paymentrequest
+
+import (
+	"context"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/google/wire"
+	"github.com/raychongtk/wallet/config"
+	"github.com/raychongtk/wallet/domain"
+	"github.com/raychongtk/wallet/ledger"
+	"github.com/raychongtk/wallet/model/payment"
+	"github.com/raychongtk/wallet/notify"
+	"github.com/raychongtk/wallet/repository"
+	"github.com/raychongtk/wallet/util"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"strings"
+	"sync"
+	"time"
+)
+
+var (
+	WireSet = wire.NewSet(ProvideManager)
+)
+
+const (
+	maxMemoLength = 140
+	// expireBatchSize is how many expired requests one sweep closes at most
+	expireBatchSize = 100
+)
+
+// Manager runs the request-money flow: a requester asks a payer for money, the payer approves, which transfers it,
+// or declines, and requests nobody answered expire. Every transition is made under a row lock on the request and
+// kept as an event.
+type Manager struct {
+	ledger      *ledger.Ledger
+	requestRepo repository.PaymentRequestRepository
+	db          gorm.DB
+	config      config.PaymentRequestConfig
+	notifier    *notify.Notifier
+	mu          sync.Mutex
+	lastErr     error
+}
+
+func ProvideManager(
+	ledger *ledger.Ledger,
+	requestRepo repository.PaymentRequestRepository,
+	db gorm.DB,
+	cfg *config.Config,
+	notifier *notify.Notifier,
+) *Manager {
+	return &Manager{
+		ledger:      ledger,
+		requestRepo: requestRepo,
+		db:          db,
+		config:      cfg.PaymentRequest,
+		notifier:    notifier,
+	}
+}
+
+// CreateCommand asks PayerUserID for Amount, in minor units. A zero ExpiresIn takes payment_request.default_expiry.
+type CreateCommand struct {
+	RequesterUserID uuid.UUID
+	PayerUserID     uuid.UUID
+	Amount          int
+	Memo            string
+	ExpiresIn       time.Duration
+	RequestID       string
+}
+
+// Create stores a pending request and lets the payer know. Both wallets must be active, as for a transfer.
+func (m *Manager) Create(ctx context.Context, cmd CreateCommand) (*payment.PaymentRequest, error) {
+	if cmd.RequesterUserID == cmd.PayerUserID {
+		return nil, domain.ErrCannotTransferToSelf
+	}
+	if err := m.ledger.ValidAmount(cmd.Amount); err != nil {
+		return nil, err
+	}
+	requester, err := m.ledger.ActiveCustomer(cmd.RequesterUserID)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := m.ledger.Payer(cmd.PayerUserID); err != nil {
+		return nil, err
+	}
+	memo := strings.TrimSpace(cmd.Memo)
+	if len(memo) > maxMemoLength {
+		return nil, domain.ErrInvalidParameters.WithMessage("memo is at most %d characters", maxMemoLength)
+	}
+	expiresIn := cmd.ExpiresIn
+	if expiresIn == 0 {
+		expiresIn = m.config.DefaultExpiry
+	}
+	if expiresIn < 0 || expiresIn > m.config.MaxExpiry {
+		return nil, domain.ErrInvalidParameters.WithMessage("a payment request expires within %s", m.config.MaxExpiry)
+	}
+
+	now := time.Now()
+	request := &payment.PaymentRequest{
+		ID:              uuid.New(),
+		RequesterUserID: cmd.RequesterUserID,
+		PayerUserID:     cmd.PayerUserID,
+		Amount:          cmd.Amount,
+		Memo:            memo,
+		Status:          payment.RequestPending,
+		ExpiresAt:       now.Add(expiresIn),
+		RequestID:       cmd.RequestID,
+		CreatedAt:       now,
+		UpdatedAt:       now,
+	}
+	err = m.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := m.requestRepo.CreatePaymentRequest(tx, request); err != nil {
+			return err
+		}
+		return m.requestRepo.CreatePaymentRequestEvent(tx, event(request, "", cmd.RequesterUserID.String()))
+	})
+	if err != nil {
+		return nil, repository.DBError(err)
+	}
+	m.notify(ctx, request.PayerUserID, notify.KindPaymentRequested,
+		fmt.Sprintf("%s requests %s from you", requester.Name(), util.DisplayAmount(request.Amount)), request)
+	return request, nil
+}
+
+// Approve pays a pending request from the wallet of the payer. Approving a request that was approved already returns
+// it as it is, and the transfer has one request id per request, so a request is never paid twice: when the transfer
+// was booked but the request was not updated, the ledger returns the booked transfer and it is recorded.
+func (m *Manager) Approve(ctx context.Context, id uuid.UUID, payerUserID uuid.UUID) (*payment.PaymentRequest, error) {
+	var request *payment.PaymentRequest
+	expired := false
+	err := m.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var err error
+		request, err = m.lockAsPayer(tx, id, payerUserID)
+		if err != nil || request.Status == payment.RequestApproved {
+			return err
+		}
+		if err := pending(request); err != nil {
+			return err
+		}
+		if !time.Now().Before(request.ExpiresAt) {
+			expired = true
+			return m.transition(tx, request, payment.RequestExpired, payment.ActorSystem)
+		}
+
+		// the transfer commits on its own, the lock keeps other approvals waiting until the request says so
+		result, err := m.ledger.Transfer(ctx, ledger.TransferCommand{
+			FromUserID: request.PayerUserID,
+			ToUserID:   request.RequesterUserID,
+			Amount:     request.Amount,
+			RequestID:  request.TransferRequestID(),
+		})
+		if err != nil {
+			return err
+		}
+		request.GroupID = &result.GroupID
+		return m.transition(tx, request, payment.RequestApproved, payerUserID.String())
+	})
+	if err != nil {
+		return nil, repository.DBError(err)
+	}
+	if expired {
+		return nil, domain.ErrPaymentRequestClosed.WithMessage("payment request expired")
+	}
+	m.notify(ctx, request.RequesterUserID, notify.KindPaymentRequestApproved,
+		fmt.Sprintf("Your request for %s was paid", util.DisplayAmount(request.Amount)), request)
+	return request, nil
+}
+
+// Decline closes a pending request without paying it
+func (m *Manager) Decline(ctx context.Context, id uuid.UUID, payerUserID uuid.UUID) (*payment.PaymentRequest, error) {
+	var request *payment.PaymentRequest
+	err := m.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var err error
+		request, err = m.lockAsPayer(tx, id, payerUserID)
+		if err != nil {
+			return err
+		}
+		if err := pending(request); err != nil {
+			return err
+		}
+		return m.transition(tx, request, payment.RequestDeclined, payerUserID.String())
+	})
+	if err != nil {
+		return nil, repository.DBError(err)
+	}
+	m.notify(ctx, request.RequesterUserID, notify.KindPaymentRequestDeclined,
+		fmt.Sprintf("Your request for %s was declined", util.DisplayAmount(request.Amount)), request)
+	return request, nil
+}
+
+// Start expires requests every payment_request.interval until the context is cancelled
+func (m *Manager) Start(ctx context.Context) {
+	ticker := time.NewTicker(m.config.Interval)
+	defer ticker.Stop()
+	for {
+		err := m.Expire(ctx)
+		if err != nil && ctx.Err() == nil {
+			util.Error("Expire payment requests failed", zap.Error(err))
+		}
+		m.mu.Lock()
+		m.lastErr = err
+		m.mu.Unlock()
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Health reports the error of the last expiry sweep
+func (m *Manager) Health() error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.lastErr
+}
+
+// Expire closes the pending requests whose expiry has passed. A request approved or declined meanwhile is left alone.
+func (m *Manager) Expire(ctx context.Context) error {
+	for ctx.Err() == nil {
+		requests, err := m.requestRepo.SearchExpiredPaymentRequests(time.Now(), expireBatchSize)
+		if err != nil || len(requests) == 0 {
+			return err
+		}
+		for _, candidate := range requests {
+			var request *payment.PaymentRequest
+			err := m.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+				var err error
+				request, err = m.requestRepo.LockPaymentRequest(tx, candidate.ID)
+				if err != nil || request.Status != payment.RequestPending {
+					return err
+				}
+				return m.transition(tx, request, payment.RequestExpired, payment.ActorSystem)
+			})
+			if err != nil {
+				return repository.DBError(err)
+			}
+			if request.Status == payment.RequestExpired {
+				m.notify(ctx, request.RequesterUserID, notify.KindPaymentRequestExpired,
+					fmt.Sprintf("Your request for %s expired", util.DisplayAmount(request.Amount)), request)
+			}
+		}
+	}
+	return ctx.Err()
+}
+
+// lockAsPayer locks a request the given user is asked to pay, the requests of other payers do not exist for them
+func (m *Manager) lockAsPayer(tx *gorm.DB, id uuid.UUID, payerUserID uuid.UUID) (*payment.PaymentRequest, error) {
+	request, err := m.requestRepo.LockPaymentRequest(tx, id)
+	if err != nil {
+		return nil, err
+	}
+	if request.PayerUserID != payerUserID {
+		return nil, domain.ErrNotFound.WithMessage("payment request not found")
+	}
+	return request, nil
+}
+
+// transition moves a request to its final status and keeps the event
+func (m *Manager) transition(tx *gorm.DB, request *payment.PaymentRequest, status string, actor string) error {
+	from := request.Status
+	now := time.Now()
+	request.Status, request.ResolvedAt = status, &now
+	if err := m.requestRepo.UpdatePaymentRequest(tx, request); err != nil {
+		return err
+	}
+	e := event(request, from, actor)
+	e.CreatedAt = now
+	return m.requestRepo.CreatePaymentRequestEvent(tx, e)
+}
+
+func (m *Manager) notify(ctx context.Context, userID uuid.UUID, kind string, message string, request *payment.PaymentRequest) {
+	data := map[string]interface{}{"payment_request_id": request.ID, "amount": util.DisplayAmount(request.Amount), "status": request.Status}
+	if err := m.notifier.Notify(context.WithoutCancel(ctx), userID, kind, message, data); err != nil {
+		util.Error("Notify payment request failed", zap.String("payment_request_id", request.ID.String()), zap.Error(err))
+	}
+}
+
+func pending(request *payment.PaymentRequest) error {
+	if request.Status != payment.RequestPending {
+		return domain.ErrPaymentRequestClosed.WithMessage("payment request is %s", strings.ToLower(request.Status))
+	}
+	return nil
+}
+
+func event(request *payment.PaymentRequest, from string, actor string) *payment.PaymentRequestEvent {
+	return &payment.PaymentRequestEvent{
+		PaymentRequestID: request.ID,
+		FromStatus:       from,
+		ToStatus:         request.Status,
+		Actor:            actor,
+		CreatedAt:        request.CreatedAt,
+	}
+}
@@ -0,0 +1,370 @@
+package payout
+
+import (
+	"context"
+	"errors"
+	"github.com/google/uuid"
+	"github.com/google/wire"
+	"github.com/raychongtk/wallet/audit"
+	"github.com/raychongtk/wallet/config"
+	"github.com/raychongtk/wallet/domain"
+	"github.com/raychongtk/wallet/ledger"
+	payoutbatch "github.com/raychongtk/wallet/model/payout"
+	"github.com/raychongtk/wallet/repository"
+	"github.com/raychongtk/wallet/util"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"sync"
+	"time"
+)
+
+var (
+	WireSet = wire.NewSet(ProvideProcessor)
+)
+
+// ErrorCodeBatchFailed marks the lines of an all-or-nothing batch that failed because another line or the
+// reservation did
+const ErrorCodeBatchFailed = "BATCH_FAILED"
+
+// Processor accepts payout batches and pays them in the background. A batch is leased by one instance at a time and
+// paid in chunks of payout.chunk_size lines, one database transaction each. Every step can be run again after a crash:
+// the reservation, every line and the refund are movement groups with their own request id, and a step whose group
+// exists already is only recorded, not posted again.
+type Processor struct {
+	ledger       *ledger.Ledger
+	payoutRepo   repository.PayoutRepository
+	movementRepo repository.MovementRepository
+	balanceRepo  repository.BalanceRepository
+	db           gorm.DB
+	config       config.PayoutConfig
+	auditor      *audit.Auditor
+	mu           sync.Mutex
+	lastErr      error
+}
+
+func ProvideProcessor(
+	ledger *ledger.Ledger,
+	payoutRepo repository.PayoutRepository,
+	movementRepo repository.MovementRepository,
+	balanceRepo repository.BalanceRepository,
+	db gorm.DB,
+	cfg *config.Config,
+	auditor *audit.Auditor,
+) *Processor {
+	return &Processor{
+		ledger:       ledger,
+		payoutRepo:   payoutRepo,
+		movementRepo: movementRepo,
+		balanceRepo:  balanceRepo,
+		db:           db,
+		config:       cfg.Payout,
+		auditor:      auditor,
+	}
+}
+
+// Start runs batches every payout.interval until the context is cancelled
+func (p *Processor) Start(ctx context.Context) {
+	ticker := time.NewTicker(p.config.Interval)
+	defer ticker.Stop()
+	for {
+		err := p.Run(ctx)
+		if err != nil && ctx.Err() == nil {
+			util.Error("Run payout batches failed", zap.Error(err))
+		}
+		p.mu.Lock()
+		p.lastErr = err
+		p.mu.Unlock()
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Health reports the error of the last scheduled run
+func (p *Processor) Health() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.lastErr
+}
+
+// Run pays every batch it can claim. A batch that fails keeps its lease, so it is tried again once the lease expired
+// and the other batches are not held up meanwhile.
+func (p *Processor) Run(ctx context.Context) error {
+	var errs []error
+	for ctx.Err() == nil {
+		batch, err := p.payoutRepo.ClaimBatch(time.Now(), p.config.Lease)
+		if err != nil {
+			return errors.Join(append(errs, err)...)
+		}
+		if batch == nil {
+			break
+		}
+		if err := p.process(ctx, batch); err != nil {
+			util.Error("Payout batch failed", zap.String("batch_id", batch.ID.String()), zap.Error(err))
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (p *Processor) process(ctx context.Context, batch *payoutbatch.Batch) error {
+	if batch.BatchStatus == payoutbatch.BatchPending {
+		if err := p.reserve(ctx, batch); err != nil {
+			return err
+		}
+		if batch.Done() {
+			return nil
+		}
+	}
+	for ctx.Err() == nil {
+		lines, err := p.payoutRepo.SearchLines(batch.ID, payoutbatch.LinePending, 0, p.config.ChunkSize)
+		if err != nil {
+			return err
+		}
+		if len(lines) == 0 {
+			return p.finish(ctx, batch)
+		}
+		if err := p.pay(ctx, batch, lines); err != nil {
+			return err
+		}
+	}
+	return ctx.Err()
+}
+
+// reserve checks the pending lines again and reserves what they add up to from the funding wallet. An all-or-nothing
+// batch fails as a whole when a line became unpayable since it was submitted, a best effort one only fails the line.
+func (p *Processor) reserve(ctx context.Context, batch *payoutbatch.Batch) error {
+	var pending, failed []payoutbatch.Line
+	recipients := newRecipients(p.ledger)
+	for after := 0; ; {
+		lines, err := p.payoutRepo.SearchLines(batch.ID, payoutbatch.LinePending, after, p.config.ChunkSize)
+		if err != nil {
+			return err
+		}
+		if len(lines) == 0 {
+			break
+		}
+		after = lines[len(lines)-1].LineNo
+		for _, line := range lines {
+			if err := p.payable(recipients, batch.FundingUserID, line); err != nil {
+				if domainErr := domain.From(err); domainErr.Kind != domain.KindInternal && !domainErr.Retryable {
+					line.LineStatus, line.ErrorCode = payoutbatch.LineFailed, domainErr.Code
+					failed = append(failed, line)
+					continue
+				}
+				return err
+			}
+			pending = append(pending, line)
+		}
+	}
+	if len(failed) > 0 && batch.Mode == payoutbatch.ModeAllOrNothing {
+		return p.fail(ctx, batch, failed[0].ErrorCode, failed, pending)
+	}
+
+	amount := 0
+	for _, line := range pending {
+		amount += line.Amount
+	}
+	if amount == 0 {
+		errorCode := ""
+		if len(failed) > 0 {
+			errorCode = failed[0].ErrorCode
+		}
+		return p.fail(ctx, batch, errorCode, failed, nil)
+	}
+	result, err := p.ledger.ReservePayout(ctx, ledger.PayoutReservationCommand{
+		UserID:    batch.FundingUserID,
+		Amount:    amount,
+		RequestID: reserveRequestID(batch),
+	})
+	if err != nil {
+		if domainErr := domain.From(err); domainErr.Kind != domain.KindInternal && !domainErr.Retryable {
+			return p.fail(ctx, batch, domainErr.Code, failed, pending)
+		}
+		return err
+	}
+	// a reservation booked before a crash reserved what its movement says
+	batch.BatchStatus = payoutbatch.BatchRunning
+	batch.ReserveGroupID = &result.GroupID
+	batch.ReservedAmount = result.Movements[0].DebitBalance
+	batch.FailedCount += len(failed)
+	return p.save(ctx, batch, failed)
+}
+
+// pay pays a chunk of pending lines in one unit of work. Lines whose movement group exists already were paid before a
+// crash and are only marked paid.
+func (p *Processor) pay(ctx context.Context, batch *payoutbatch.Batch, lines []payoutbatch.Line) error {
+	requestIDs := make([]string, len(lines))
+	for i, line := range lines {
+		requestIDs[i] = line.RequestID(batch)
+	}
+	movements, err := p.movementRepo.SearchMovementsByRequestIDs(requestIDs)
+	if err != nil {
+		return err
+	}
+	paidGroups := map[string]uuid.UUID{}
+	for _, m := range movements {
+		paidGroups[m.RequestID] = m.GroupID
+	}
+
+	var changed []payoutbatch.Line
+	var toPay []int
+	recipients := newRecipients(p.ledger)
+	committed := batch.PaidAmount
+	for i := range lines {
+		line := &lines[i]
+		if groupID, ok := paidGroups[requestIDs[i]]; ok {
+			p.markPaid(batch, line, groupID)
+			changed = append(changed, *line)
+			committed += line.Amount
+			continue
+		}
+		// a best effort batch skips recipients frozen since the reservation, an all-or-nothing one pays them
+		if batch.Mode == payoutbatch.ModeBestEffort {
+			if _, err := recipients.active(line.RecipientUserID); err != nil {
+				domainErr := domain.From(err)
+				if domainErr.Kind == domain.KindInternal || domainErr.Retryable {
+					return err
+				}
+				p.markFailed(batch, line, domainErr.Code)
+				changed = append(changed, *line)
+				continue
+			}
+		}
+		// never pay out more than was reserved
+		if committed+line.Amount > batch.ReservedAmount {
+			p.markFailed(batch, line, domain.ErrInsufficientFunds.Code)
+			changed = append(changed, *line)
+			continue
+		}
+		committed += line.Amount
+		toPay = append(toPay, i)
+	}
+
+	if len(toPay) > 0 {
+		cmd := ledger.PayoutCommand{FunderID: batch.FundingUserID}
+		for _, i := range toPay {
+			cmd.Lines = append(cmd.Lines, ledger.PayoutLine{UserID: lines[i].RecipientUserID, Amount: lines[i].Amount, RequestID: requestIDs[i]})
+		}
+		results, err := p.ledger.Payout(ctx, cmd)
+		if err != nil {
+			return err
+		}
+		for n, i := range toPay {
+			p.markPaid(batch, &lines[i], results[n].GroupID)
+			changed = append(changed, lines[i])
+		}
+	}
+	lockedUntil := time.Now().Add(p.config.Lease)
+	batch.LockedUntil = &lockedUntil
+	return p.save(ctx, batch, changed)
+}
+
+// finish refunds what the batch reserved and did not pay, then records how the batch ended
+func (p *Processor) finish(ctx context.Context, batch *payoutbatch.Batch) error {
+	if refund := batch.ReservedAmount - batch.PaidAmount; refund > 0 && batch.RefundGroupID == nil {
+		result, err := p.ledger.RefundPayout(ctx, ledger.PayoutReservationCommand{
+			UserID:    batch.FundingUserID,
+			Amount:    refund,
+			RequestID: refundRequestID(batch),
+		})
+		if err != nil {
+			return err
+		}
+		batch.RefundGroupID = &result.GroupID
+	}
+	switch {
+	case batch.FailedCount == 0:
+		batch.BatchStatus = payoutbatch.BatchCompleted
+	case batch.PaidCount == 0:
+		batch.BatchStatus = payoutbatch.BatchFailed
+	default:
+		batch.BatchStatus = payoutbatch.BatchPartial
+	}
+	return p.complete(ctx, batch, nil)
+}
+
+// fail ends a batch that paid nothing, every line that was still pending fails with the batch
+func (p *Processor) fail(ctx context.Context, batch *payoutbatch.Batch, errorCode string, failed []payoutbatch.Line, pending []payoutbatch.Line) error {
+	for _, line := range pending {
+		line.LineStatus, line.ErrorCode = payoutbatch.LineFailed, ErrorCodeBatchFailed
+		failed = append(failed, line)
+	}
+	batch.BatchStatus = payoutbatch.BatchFailed
+	batch.ErrorCode = errorCode
+	batch.FailedCount += len(failed)
+	return p.complete(ctx, batch, failed)
+}
+
+func (p *Processor) complete(ctx context.Context, batch *payoutbatch.Batch, lines []payoutbatch.Line) error {
+	now := time.Now()
+	batch.CompletedAt = &now
+	batch.LockedUntil = nil
+	if err := p.save(ctx, batch, lines); err != nil {
+		return err
+	}
+	util.Info("Payout batch done",
+		zap.String("batch_id", batch.ID.String()),
+		zap.String("status", batch.BatchStatus),
+		zap.Int("paid", batch.PaidCount),
+		zap.Int("failed", batch.FailedCount),
+	)
+	entry := audit.Entry{
+		ActorType:  audit.ActorService,
+		ActorID:    "payout",
+		Action:     "payout.complete",
+		TargetType: audit.TargetPayout,
+		TargetID:   batch.ID.String(),
+		RequestID:  batch.RequestID,
+		TraceID:    batch.TraceID,
+		Outcome:    audit.OutcomeSuccess,
+		After: map[string]interface{}{
+			"status":           batch.BatchStatus,
+			"paid_count":       batch.PaidCount,
+			"paid_amount":      batch.PaidAmount,
+			"failed_count":     batch.FailedCount,
+			"reserve_group_id": batch.ReserveGroupID,
+			"refund_group_id":  batch.RefundGroupID,
+		},
+	}
+	if batch.BatchStatus == payoutbatch.BatchFailed {
+		entry.Outcome, entry.ErrorCode = audit.OutcomeFailure, batch.ErrorCode
+	}
+	if err := p.auditor.Record(context.WithoutCancel(ctx), entry); err != nil {
+		util.Error("Record audit log failed", zap.String("action", entry.Action), zap.Error(err))
+	}
+	return nil
+}
+
+// save writes the batch and the lines that changed in one transaction
+func (p *Processor) save(ctx context.Context, batch *payoutbatch.Batch, lines []payoutbatch.Line) error {
+	return repository.DBError(p.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if len(lines) > 0 {
+			if err := p.payoutRepo.UpdateLines(tx, lines); err != nil {
+				return err
+			}
+		}
+		return p.payoutRepo.UpdateBatch(tx, batch)
+	}))
+}
+
+func (p *Processor) markPaid(batch *payoutbatch.Batch, line *payoutbatch.Line, groupID uuid.UUID) {
+	line.LineStatus, line.GroupID, line.ErrorCode = payoutbatch.LinePaid, &groupID, ""
+	batch.PaidCount++
+	batch.PaidAmount += line.Amount
+}
+
+func (p *Processor) markFailed(batch *payoutbatch.Batch, line *payoutbatch.Line, errorCode string) {
+	line.LineStatus, line.ErrorCode = payoutbatch.LineFailed, errorCode
+	batch.FailedCount++
+}
+
+func reserveRequestID(batch *payoutbatch.Batch) string {
+	return batch.RequestID + "/reserve"
+}
+
+func refundRequestID(batch *payoutbatch.Batch) string {
+	return batch.RequestID + "/refund"
+}
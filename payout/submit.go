@@ -0,0 +1,249 @@
+package payout
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/raychongtk/wallet/domain"
+	"github.com/raychongtk/wallet/ledger"
+	payoutbatch "github.com/raychongtk/wallet/model/payout"
+	"github.com/raychongtk/wallet/repository"
+	"github.com/raychongtk/wallet/tracing"
+	"github.com/raychongtk/wallet/util"
+	"gorm.io/gorm"
+	"io"
+	"strings"
+	"time"
+)
+
+const (
+	maxRequestIDLength = 200
+	maxReferenceLength = 255
+)
+
+// Input is one line as the caller sent it, it is only trusted after Submit validated it
+type Input struct {
+	RecipientUserID string
+	Amount          string
+	Reference       string
+}
+
+// SubmitCommand is a batch paid from the wallet of FundingUserID. RequestID is the idempotency key of the whole batch.
+type SubmitCommand struct {
+	FundingUserID uuid.UUID
+	Mode          string
+	RequestID     string
+	Lines         []Input
+}
+
+// LineError is why a line was rejected, Line counts from 1 in the order the lines were sent
+type LineError struct {
+	Line      int
+	Reference string
+	Code      string
+	Message   string
+}
+
+// RejectedError lists every line that made Submit reject the batch. It matches domain.ErrInvalidParameters.
+type RejectedError struct {
+	Lines []LineError
+}
+
+func (e *RejectedError) Error() string {
+	return fmt.Sprintf("payout batch rejected, %d invalid lines", len(e.Lines))
+}
+
+func (e *RejectedError) Unwrap() error {
+	return domain.ErrInvalidParameters.WithMessage("payout batch has invalid lines")
+}
+
+// ParseCSV reads lines from CSV with a recipient_user_id,amount,reference header, columns may come in any order
+func ParseCSV(r io.Reader) ([]Input, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+	header, err := reader.Read()
+	if err != nil {
+		return nil, domain.ErrInvalidParameters.WithMessage("payout file has no header")
+	}
+	columns := map[string]int{}
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	for _, name := range []string{"recipient_user_id", "amount", "reference"} {
+		if _, ok := columns[name]; !ok {
+			return nil, domain.ErrInvalidParameters.WithMessage("payout file has no %s column", name)
+		}
+	}
+	var inputs []Input
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return inputs, nil
+		}
+		if err != nil {
+			return nil, domain.ErrInvalidParameters.Wrap(err)
+		}
+		inputs = append(inputs, Input{
+			RecipientUserID: record[columns["recipient_user_id"]],
+			Amount:          record[columns["amount"]],
+			Reference:       record[columns["reference"]],
+		})
+	}
+}
+
+// Submit validates a batch up front and stores it for the processor. Malformed lines, such as a bad amount or a
+// repeated reference, reject the batch in both modes. Lines that are well formed but cannot be paid, such as one to a
+// frozen wallet, reject an all-or-nothing batch and are stored as failed in a best effort one. A funding wallet that
+// cannot cover the payable lines rejects the batch.
+func (p *Processor) Submit(ctx context.Context, cmd SubmitCommand) (*payoutbatch.Batch, []payoutbatch.Line, error) {
+	if cmd.Mode != payoutbatch.ModeAllOrNothing && cmd.Mode != payoutbatch.ModeBestEffort {
+		return nil, nil, domain.ErrInvalidParameters.WithMessage("mode must be %s or %s", payoutbatch.ModeAllOrNothing, payoutbatch.ModeBestEffort)
+	}
+	if len(cmd.RequestID) > maxRequestIDLength {
+		return nil, nil, domain.ErrInvalidParameters.WithMessage("request id of a payout batch is at most %d characters", maxRequestIDLength)
+	}
+	if len(cmd.Lines) == 0 || len(cmd.Lines) > p.config.MaxLines {
+		return nil, nil, domain.ErrInvalidParameters.WithMessage("a payout batch has 1 to %d lines", p.config.MaxLines)
+	}
+	funder, err := p.ledger.Payer(cmd.FundingUserID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	now := time.Now()
+	batch := &payoutbatch.Batch{
+		ID:              uuid.New(),
+		RequestID:       cmd.RequestID,
+		FundingUserID:   cmd.FundingUserID,
+		FundingWalletID: funder.Wallet.ID,
+		Mode:            cmd.Mode,
+		BatchStatus:     payoutbatch.BatchPending,
+		LineCount:       len(cmd.Lines),
+		TraceID:         tracing.TraceID(ctx),
+		CreatedAt:       now,
+		UpdatedAt:       now,
+	}
+	lines := make([]payoutbatch.Line, 0, len(cmd.Lines))
+	var malformed, unpayable []LineError
+	references := map[string]bool{}
+	recipients := newRecipients(p.ledger)
+	payable := 0
+	for i, input := range cmd.Lines {
+		lineError := LineError{Line: i + 1, Reference: input.Reference}
+		reject := func(err *domain.Error) LineError {
+			lineError.Code, lineError.Message = err.Code, err.Message
+			return lineError
+		}
+		reference := strings.TrimSpace(input.Reference)
+		if reference == "" || len(reference) > maxReferenceLength {
+			malformed = append(malformed, reject(domain.ErrInvalidParameters.WithMessage("reference is required and at most %d characters", maxReferenceLength)))
+			continue
+		}
+		if references[reference] {
+			malformed = append(malformed, reject(domain.ErrInvalidParameters.WithMessage("reference %s is repeated", reference)))
+			continue
+		}
+		references[reference] = true
+		recipientID, err := uuid.Parse(strings.TrimSpace(input.RecipientUserID))
+		if err != nil {
+			malformed = append(malformed, reject(domain.ErrInvalidAccount))
+			continue
+		}
+		amount, err := util.ConvertToInt(strings.TrimSpace(input.Amount))
+		if err != nil {
+			malformed = append(malformed, reject(domain.ErrInvalidParameters.WithMessage("amount %q is not a number", input.Amount)))
+			continue
+		}
+
+		line := payoutbatch.Line{
+			ID:              uuid.New(),
+			BatchID:         batch.ID,
+			LineNo:          i + 1,
+			RecipientUserID: recipientID,
+			Amount:          amount,
+			Reference:       reference,
+			LineStatus:      payoutbatch.LinePending,
+			CreatedAt:       now,
+			UpdatedAt:       now,
+		}
+		if err := p.payable(recipients, cmd.FundingUserID, line); err != nil {
+			domainErr := domain.From(err)
+			if domainErr.Kind == domain.KindInternal || domainErr.Retryable {
+				return nil, nil, err
+			}
+			unpayable = append(unpayable, reject(domainErr))
+			line.LineStatus, line.ErrorCode = payoutbatch.LineFailed, domainErr.Code
+			batch.FailedCount++
+		} else {
+			payable += amount
+		}
+		batch.TotalAmount += amount
+		lines = append(lines, line)
+	}
+	if len(malformed) > 0 {
+		return nil, nil, &RejectedError{Lines: append(malformed, unpayable...)}
+	}
+	if len(unpayable) > 0 && cmd.Mode == payoutbatch.ModeAllOrNothing {
+		return nil, nil, &RejectedError{Lines: unpayable}
+	}
+	if payable == 0 {
+		return nil, nil, &RejectedError{Lines: unpayable}
+	}
+	balance, err := p.balanceRepo.GetBalance(funder.Wallet.ID, "COMMITTED")
+	if err != nil {
+		return nil, nil, err
+	}
+	if balance.Balance < payable {
+		return nil, nil, domain.ErrInsufficientFunds.WithMessage("funding wallet cannot cover the payout of %s", util.DisplayAmount(payable))
+	}
+
+	err = p.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return p.payoutRepo.CreateBatch(tx, batch, lines)
+	})
+	if err != nil {
+		return nil, nil, repository.DBError(err)
+	}
+	return batch, lines, nil
+}
+
+// payable checks a line against the ledger rules without moving money
+func (p *Processor) payable(recipients *recipients, funderID uuid.UUID, line payoutbatch.Line) error {
+	if err := p.ledger.ValidAmount(line.Amount); err != nil {
+		return err
+	}
+	if line.RecipientUserID == funderID {
+		return domain.ErrCannotTransferToSelf
+	}
+	_, err := recipients.active(line.RecipientUserID)
+	return err
+}
+
+// recipients caches the customers of a batch, payroll files name the same wallet more than once
+type recipients struct {
+	ledger    *ledger.Ledger
+	customers map[uuid.UUID]*ledger.Customer
+	errs      map[uuid.UUID]error
+}
+
+func newRecipients(l *ledger.Ledger) *recipients {
+	return &recipients{ledger: l, customers: map[uuid.UUID]*ledger.Customer{}, errs: map[uuid.UUID]error{}}
+}
+
+// active resolves a recipient whose wallet may be credited, it fails with domain.ErrWalletFrozen otherwise
+func (r *recipients) active(userID uuid.UUID) (*ledger.Customer, error) {
+	if err, ok := r.errs[userID]; ok {
+		return nil, err
+	}
+	if customer, ok := r.customers[userID]; ok {
+		return customer, nil
+	}
+	customer, err := r.ledger.ActiveCustomer(userID)
+	if err != nil {
+		r.errs[userID] = err
+		return nil, err
+	}
+	r.customers[userID] = customer
+	return customer, nil
+}
@@ -0,0 +1,32 @@
+package payout
+
+import (
+	"github.com/raychongtk/wallet/domain"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+func TestParseCSV(t *testing.T) {
+	inputs, err := ParseCSV(strings.NewReader("reference, amount, recipient_user_id\n" +
+		"payroll-1, 12.50, 2d988f4a-a037-4ce9-a350-f13445793e88\n" +
+		"payroll-2, 7, 1cc535a5-bc57-4731-a64b-041b7ff41c30\n"))
+	assert.NoError(t, err)
+	assert.Equal(t, []Input{
+		{RecipientUserID: "2d988f4a-a037-4ce9-a350-f13445793e88", Amount: "12.50", Reference: "payroll-1"},
+		{RecipientUserID: "1cc535a5-bc57-4731-a64b-041b7ff41c30", Amount: "7", Reference: "payroll-2"},
+	}, inputs)
+
+	_, err = ParseCSV(strings.NewReader("recipient_user_id,amount\n2d988f4a-a037-4ce9-a350-f13445793e88,1\n"))
+	assert.ErrorIs(t, err, domain.ErrInvalidParameters)
+	_, err = ParseCSV(strings.NewReader(""))
+	assert.ErrorIs(t, err, domain.ErrInvalidParameters)
+	_, err = ParseCSV(strings.NewReader("recipient_user_id,amount,reference\n2d988f4a-a037-4ce9-a350-f13445793e88,1\n"))
+	assert.ErrorIs(t, err, domain.ErrInvalidParameters)
+}
+
+func TestRejectedErrorIsInvalidParameters(t *testing.T) {
+	err := &RejectedError{Lines: []LineError{{Line: 2, Reference: "payroll-2", Code: domain.ErrInvalidAccount.Code}}}
+	assert.ErrorIs(t, err, domain.ErrInvalidParameters)
+	assert.Equal(t, "INVALID_PARAMETERS", domain.From(err).Code)
+}
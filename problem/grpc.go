@@ -0,0 +1,66 @@
+package problem
+
+import (
+	"github.com/raychongtk/wallet/domain"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"strconv"
+)
+
+const grpcDomain = "wallet.raychongtk.github.com"
+
+// Code maps a domain error to its gRPC code. A used request id is ALREADY_EXISTS and other conflicts are ABORTED, which
+// gRPC clients already treat as retryable at a higher level.
+func Code(err *domain.Error) codes.Code {
+	switch err.Kind {
+	case domain.KindInvalid:
+		return codes.InvalidArgument
+	case domain.KindInsufficientFunds, domain.KindWalletFrozen, domain.KindLimitExceeded:
+		return codes.FailedPrecondition
+	case domain.KindForbidden:
+		return codes.PermissionDenied
+	case domain.KindNotFound:
+		return codes.NotFound
+	case domain.KindConflict:
+		if err.Is(domain.ErrDuplicateRequest) {
+			return codes.AlreadyExists
+		}
+		return codes.Aborted
+	case domain.KindUnavailable:
+		return codes.Unavailable
+	default:
+		return codes.Internal
+	}
+}
+
+// GRPCStatus is the gRPC counterpart of Respond. The error code and whether it is retryable travel in an ErrorInfo
+// detail, internal causes are never sent.
+func GRPCStatus(err error, requestID string) *status.Status {
+	domainErr := domain.From(err)
+	st := status.New(Code(domainErr), domainErr.Message)
+	info := &errdetails.ErrorInfo{
+		Reason: domainErr.Code,
+		Domain: grpcDomain,
+		Metadata: map[string]string{
+			"retryable": strconv.FormatBool(domainErr.Retryable),
+		},
+	}
+	if requestID != "" {
+		info.Metadata["request_id"] = requestID
+	}
+	if detailed, detailErr := st.WithDetails(info); detailErr == nil {
+		return detailed
+	}
+	return st
+}
+
+// ErrorCode reads the domain error code back from a status made by GRPCStatus
+func ErrorCode(st *status.Status) string {
+	for _, detail := range st.Details() {
+		if info, ok := detail.(*errdetails.ErrorInfo); ok && info.Domain == grpcDomain {
+			return info.Reason
+		}
+	}
+	return ""
+}
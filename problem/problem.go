@@ -0,0 +1,66 @@
+package problem
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/raychongtk/wallet/domain"
+	"github.com/raychongtk/wallet/tracing"
+	"github.com/raychongtk/wallet/util"
+	"go.uber.org/zap"
+	"net/http"
+)
+
+// Status maps a domain error kind to its HTTP status. Business rules the request broke are a bad request so clients
+// can tell them from the ones worth retrying, which are 409 and 503.
+func Status(kind domain.Kind) int {
+	switch kind {
+	case domain.KindInvalid, domain.KindInsufficientFunds, domain.KindLimitExceeded:
+		return http.StatusBadRequest
+	case domain.KindWalletFrozen, domain.KindForbidden:
+		return http.StatusForbidden
+	case domain.KindNotFound:
+		return http.StatusNotFound
+	case domain.KindConflict:
+		return http.StatusConflict
+	case domain.KindUnavailable:
+		return http.StatusServiceUnavailable
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// Respond aborts the request with the error envelope. Internal errors are logged with their cause and only the
+// catalog message is sent.
+func Respond(ctx *gin.Context, err error) {
+	details := New(ctx, err)
+	ctx.AbortWithStatusJSON(details.Status, details)
+}
+
+// New builds the error envelope of err, for handlers that send it with more fields
+func New(ctx *gin.Context, err error) *Details {
+	domainErr := domain.From(err)
+	status := Status(domainErr.Kind)
+	if status >= http.StatusInternalServerError {
+		util.Error("Request failed", zap.String("path", ctx.FullPath()), zap.String("error_code", domainErr.Code), zap.Error(err))
+	}
+	return &Details{
+		Result:    false,
+		ErrorCode: domainErr.Code,
+		Message:   domainErr.Message,
+		Status:    status,
+		RequestID: ctx.GetHeader(tracing.RequestIDHeader),
+		TraceID:   tracing.TraceID(ctx.Request.Context()),
+		Retryable: domainErr.Retryable,
+	}
+}
+
+// Details is the error envelope of every endpoint. result and error_code are kept from the original money movement
+// responses so existing clients still read them.
+type Details struct {
+	Result    bool   `json:"result"`
+	ErrorCode string `json:"error_code"`
+	Message   string `json:"message"`
+	Status    int    `json:"status"`
+	RequestID string `json:"request_id,omitempty"`
+	TraceID   string `json:"trace_id,omitempty"`
+	Retryable bool   `json:"retryable"`
+}
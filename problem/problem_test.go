@@ -0,0 +1,77 @@
+package problem
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"github.com/raychongtk/wallet/domain"
+	"github.com/raychongtk/wallet/util"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestRespondWritesEnvelope(t *testing.T) {
+	util.InitializeLogger(false)
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.GET("/funds", func(c *gin.Context) {
+		Respond(c, domain.ErrInsufficientFunds)
+	})
+	r.GET("/busy", func(c *gin.Context) {
+		Respond(c, domain.ErrConflict.Wrap(errors.New("deadlock detected")))
+	})
+	r.GET("/boom", func(c *gin.Context) {
+		Respond(c, errors.New("connection string with a password"))
+	})
+
+	cases := []struct {
+		path      string
+		status    int
+		code      string
+		retryable bool
+	}{
+		{"/funds", http.StatusBadRequest, "INSUFFICIENT_FUNDS", false},
+		{"/busy", http.StatusConflict, "CONFLICT", true},
+		{"/boom", http.StatusInternalServerError, "INTERNAL_ERROR", false},
+	}
+	for _, c := range cases {
+		req := httptest.NewRequest(http.MethodGet, c.path, nil)
+		req.Header.Set("X-Request-ID", "request-1")
+		resp := httptest.NewRecorder()
+		r.ServeHTTP(resp, req)
+
+		assert.Equal(t, c.status, resp.Code, c.path)
+		var details Details
+		assert.NoError(t, json.Unmarshal(resp.Body.Bytes(), &details))
+		assert.False(t, details.Result)
+		assert.Equal(t, c.code, details.ErrorCode)
+		assert.Equal(t, c.status, details.Status)
+		assert.Equal(t, "request-1", details.RequestID)
+		assert.Equal(t, c.retryable, details.Retryable)
+		assert.NotContains(t, details.Message, "password")
+	}
+}
+
+func TestGRPCStatusCarriesErrorCode(t *testing.T) {
+	cases := []struct {
+		err  error
+		code codes.Code
+		name string
+	}{
+		{domain.ErrInsufficientFunds, codes.FailedPrecondition, "INSUFFICIENT_FUNDS"},
+		{domain.ErrInvalidAccount.WithMessage("wallet is closed"), codes.InvalidArgument, "INVALID_ACCOUNT"},
+		{domain.ErrDuplicateRequest, codes.AlreadyExists, "DUPLICATE_REQUEST"},
+		{domain.ErrForbidden, codes.PermissionDenied, "FORBIDDEN"},
+		{domain.ErrConflict.Wrap(errors.New("deadlock detected")), codes.Aborted, "CONFLICT"},
+		{errors.New("connection string with a password"), codes.Internal, "INTERNAL_ERROR"},
+	}
+	for _, c := range cases {
+		st := GRPCStatus(c.err, "request-1")
+		assert.Equal(t, c.code, st.Code(), c.name)
+		assert.Equal(t, c.name, ErrorCode(st))
+		assert.NotContains(t, st.Message(), "password")
+	}
+}
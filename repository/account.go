@@ -1,14 +1,18 @@
 package repository
 
 import (
-	"errors"
 	"github.com/google/uuid"
+	"github.com/raychongtk/wallet/domain"
 	"github.com/raychongtk/wallet/model/wallet"
 	"gorm.io/gorm"
+	"time"
 )
 
 type AccountRepository interface {
+	// GetAccount is the account a user holds, the accounts shared with them are found through their memberships
 	GetAccount(userId uuid.UUID) (*wallet.Account, error)
+	GetAccountByID(id uuid.UUID) (*wallet.Account, error)
+	UpdateApprovalThreshold(id uuid.UUID, threshold int) error
 }
 
 type PgAccountRepository struct {
@@ -31,11 +35,28 @@ func (m *PgAccountRepository) find(userId uuid.UUID) (*wallet.Account, error) {
 	var account wallet.Account
 	result := m.db.Where("user_id = ?", userId.String()).Find(&account)
 	if result.Error != nil {
-		panic(result.Error)
+		return nil, dbError(result.Error)
 	}
 	if result.RowsAffected == 0 {
-		return nil, errors.New("account not found")
+		return nil, domain.ErrNotFound.WithMessage("account of user %s not found", userId.String())
 	}
 
 	return &account, nil
 }
+
+func (m *PgAccountRepository) GetAccountByID(id uuid.UUID) (*wallet.Account, error) {
+	var account wallet.Account
+	result := m.db.First(&account, "id = ?", id.String())
+	if result.Error != nil {
+		return nil, dbError(result.Error)
+	}
+	return &account, nil
+}
+
+func (m *PgAccountRepository) UpdateApprovalThreshold(id uuid.UUID, threshold int) error {
+	result := m.db.Model(&wallet.Account{}).Where("id = ?", id.String()).Updates(map[string]interface{}{
+		"approval_threshold": threshold,
+		"updated_at":         time.Now(),
+	})
+	return dbError(result.Error)
+}
@@ -0,0 +1,79 @@
+package repository
+
+import (
+	"errors"
+	"github.com/google/uuid"
+	"github.com/raychongtk/wallet/model/archive"
+	"gorm.io/gorm"
+	"time"
+)
+
+type ArchiveManifestRepository interface {
+	CreateManifest(db *gorm.DB, manifest *archive.Manifest, balances []archive.Balance) (*archive.Manifest, error)
+	GetLatestManifest(sourceTable string) (*archive.Manifest, error)
+	SearchManifests(sourceTable string, from time.Time, to time.Time) ([]archive.Manifest, error)
+	SumBalance(walletID uuid.UUID, balanceType string, through time.Time) (int, error)
+}
+
+type PgArchiveManifestRepository struct {
+	db *gorm.DB
+}
+
+func ProvideArchiveManifestRepository(db gorm.DB) ArchiveManifestRepository {
+	return &PgArchiveManifestRepository{&db}
+}
+
+// CreateManifest stores a manifest with the balances its transactions add to each wallet
+func (m *PgArchiveManifestRepository) CreateManifest(db *gorm.DB, manifest *archive.Manifest, balances []archive.Balance) (*archive.Manifest, error) {
+	result := db.Create(manifest)
+	if result.Error != nil {
+		return nil, dbError(result.Error)
+	}
+	if len(balances) == 0 {
+		return manifest, nil
+	}
+	for i := range balances {
+		balances[i].ManifestID = manifest.ID
+	}
+	result = db.CreateInBatches(balances, 1000)
+	if result.Error != nil {
+		return nil, dbError(result.Error)
+	}
+	return manifest, nil
+}
+
+func (m *PgArchiveManifestRepository) GetLatestManifest(sourceTable string) (*archive.Manifest, error) {
+	var manifest archive.Manifest
+	result := m.db.Where("source_table = ?", sourceTable).Order("range_end desc").First(&manifest)
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if result.Error != nil {
+		return nil, dbError(result.Error)
+	}
+	return &manifest, nil
+}
+
+// SearchManifests returns manifests of the table whose range overlaps [from, to)
+func (m *PgArchiveManifestRepository) SearchManifests(sourceTable string, from time.Time, to time.Time) ([]archive.Manifest, error) {
+	var manifests []archive.Manifest
+	result := m.db.Where("source_table = ? AND range_start < ? AND range_end > ?", sourceTable, to, from).Order("range_start").Find(&manifests)
+	if result.Error != nil {
+		return nil, dbError(result.Error)
+	}
+	return manifests, nil
+}
+
+// SumBalance adds up the recorded balances of a wallet in the manifests that end by through
+func (m *PgArchiveManifestRepository) SumBalance(walletID uuid.UUID, balanceType string, through time.Time) (int, error) {
+	var sum int
+	result := m.db.Model(&archive.Balance{}).
+		Select("COALESCE(SUM(archive_balance.balance), 0)").
+		Joins("JOIN archive_manifest ON archive_manifest.id = archive_balance.manifest_id").
+		Where("archive_balance.wallet_id = ? AND archive_balance.balance_type = ? AND archive_manifest.range_end <= ?", walletID.String(), balanceType, through).
+		Scan(&sum)
+	if result.Error != nil {
+		return 0, dbError(result.Error)
+	}
+	return sum, nil
+}
@@ -0,0 +1,67 @@
+package repository
+
+import (
+	"github.com/raychongtk/wallet/model/audit"
+	"gorm.io/gorm"
+	"time"
+)
+
+type AuditLogRepository interface {
+	CreateAuditLog(db *gorm.DB, log *audit.Log) error
+	SearchAuditLogs(filter AuditLogFilter, afterID int64, limit int) ([]audit.Log, error)
+}
+
+// AuditLogFilter matches on every field that is not empty
+type AuditLogFilter struct {
+	ActorType  string
+	ActorID    string
+	Action     string
+	TargetType string
+	TargetID   string
+	From       time.Time
+	To         time.Time
+}
+
+type PgAuditLogRepository struct {
+	db *gorm.DB
+}
+
+func ProvideAuditLogRepository(db gorm.DB) AuditLogRepository {
+	return &PgAuditLogRepository{&db}
+}
+
+func (m *PgAuditLogRepository) CreateAuditLog(db *gorm.DB, log *audit.Log) error {
+	return dbError(db.Create(log).Error)
+}
+
+// SearchAuditLogs pages by id, which follows the order the records were written in
+func (m *PgAuditLogRepository) SearchAuditLogs(filter AuditLogFilter, afterID int64, limit int) ([]audit.Log, error) {
+	query := m.db.Where("id > ?", afterID)
+	if filter.ActorType != "" {
+		query = query.Where("actor_type = ?", filter.ActorType)
+	}
+	if filter.ActorID != "" {
+		query = query.Where("actor_id = ?", filter.ActorID)
+	}
+	if filter.Action != "" {
+		query = query.Where("action = ?", filter.Action)
+	}
+	if filter.TargetType != "" {
+		query = query.Where("target_type = ?", filter.TargetType)
+	}
+	if filter.TargetID != "" {
+		query = query.Where("target_id = ?", filter.TargetID)
+	}
+	if !filter.From.IsZero() {
+		query = query.Where("created_at >= ?", filter.From)
+	}
+	if !filter.To.IsZero() {
+		query = query.Where("created_at < ?", filter.To)
+	}
+	var logs []audit.Log
+	result := query.Order("id").Limit(limit).Find(&logs)
+	if result.Error != nil {
+		return nil, dbError(result.Error)
+	}
+	return logs, nil
+}
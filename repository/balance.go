@@ -1,11 +1,14 @@
 package repository
 
 import (
-	"errors"
 	"github.com/google/uuid"
+	"github.com/raychongtk/wallet/datastore"
+	"github.com/raychongtk/wallet/domain"
+	"github.com/raychongtk/wallet/metrics"
 	"github.com/raychongtk/wallet/model/wallet"
 	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
+	"time"
 )
 
 type BalanceRepository interface {
@@ -16,11 +19,12 @@ type BalanceRepository interface {
 }
 
 type PgBalanceRepository struct {
-	db *gorm.DB
+	db     *gorm.DB
+	router *datastore.ReplicaRouter
 }
 
-func ProvideBalanceRepository(db gorm.DB) BalanceRepository {
-	return &PgBalanceRepository{&db}
+func ProvideBalanceRepository(db gorm.DB, router *datastore.ReplicaRouter) BalanceRepository {
+	return &PgBalanceRepository{&db, router}
 }
 
 func (m *PgBalanceRepository) AddBalance(db *gorm.DB, walletID uuid.UUID, balance int, balanceType string) error {
@@ -31,7 +35,7 @@ func (m *PgBalanceRepository) AddBalance(db *gorm.DB, walletID uuid.UUID, balanc
 	walletBalance.Balance += balance
 	result := db.Save(walletBalance)
 	if result.Error != nil {
-		return result.Error
+		return dbError(result.Error)
 	}
 	return nil
 }
@@ -42,30 +46,31 @@ func (m *PgBalanceRepository) DeductBalance(db *gorm.DB, walletID uuid.UUID, bal
 		return err
 	}
 	if accountType == "CUSTOMER" && walletBalance.Balance < balance {
-		return errors.New("insufficient balance")
+		return domain.ErrInsufficientFunds
 	}
 	walletBalance.Balance -= balance
 	result := db.Save(walletBalance)
 	if result.Error != nil {
-		return result.Error
+		return dbError(result.Error)
 	}
 	return nil
 }
 
 func (m *PgBalanceRepository) GetBalanceWithLock(db *gorm.DB, walletID uuid.UUID, balanceType string) (*wallet.Balance, error) {
+	defer metrics.ObserveLockWait(time.Now())
 	var balance wallet.Balance
 	result := db.Clauses(clause.Locking{Strength: clause.LockingStrengthUpdate}).First(&balance, "wallet_id = ? AND balance_type = ?", walletID.String(), balanceType)
 	if result.Error != nil {
-		return nil, result.Error
+		return nil, dbError(result.Error)
 	}
 	return &balance, nil
 }
 
 func (m *PgBalanceRepository) GetBalance(walletID uuid.UUID, balanceType string) (*wallet.Balance, error) {
 	var balance wallet.Balance
-	result := m.db.First(&balance, "wallet_id = ? AND balance_type = ?", walletID.String(), balanceType)
+	result := m.router.Reader(walletID.String()).First(&balance, "wallet_id = ? AND balance_type = ?", walletID.String(), balanceType)
 	if result.Error != nil {
-		return nil, result.Error
+		return nil, dbError(result.Error)
 	}
 	return &balance, nil
 }
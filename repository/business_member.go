@@ -0,0 +1,82 @@
+package repository
+
+import (
+	"github.com/google/uuid"
+	"github.com/raychongtk/wallet/domain"
+	"github.com/raychongtk/wallet/model/wallet"
+	"gorm.io/gorm"
+	"time"
+)
+
+type BusinessMemberRepository interface {
+	// GetBusinessMember is the membership of a user in an organization, domain.ErrNotFound when they were not invited
+	GetBusinessMember(organizationID uuid.UUID, userID uuid.UUID) (*wallet.BusinessMember, error)
+	CreateBusinessMember(db *gorm.DB, member *wallet.BusinessMember) error
+	UpdateBusinessMember(member *wallet.BusinessMember) error
+	DeleteBusinessMember(organizationID uuid.UUID, userID uuid.UUID) error
+	SearchBusinessMembers(organizationID uuid.UUID) ([]wallet.BusinessMember, error)
+	// SearchBusinessMemberships returns the organizations a user is a member of or invited to
+	SearchBusinessMemberships(userID uuid.UUID) ([]wallet.BusinessMember, error)
+}
+
+type PgBusinessMemberRepository struct {
+	db *gorm.DB
+}
+
+func ProvideBusinessMemberRepository(db gorm.DB) BusinessMemberRepository {
+	return &PgBusinessMemberRepository{&db}
+}
+
+func (m *PgBusinessMemberRepository) GetBusinessMember(organizationID uuid.UUID, userID uuid.UUID) (*wallet.BusinessMember, error) {
+	var member wallet.BusinessMember
+	result := m.db.Where("organization_id = ? AND user_id = ?", organizationID.String(), userID.String()).Find(&member)
+	if result.Error != nil {
+		return nil, dbError(result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return nil, domain.ErrNotFound.WithMessage("user %s is not a member of business %s", userID.String(), organizationID.String())
+	}
+	return &member, nil
+}
+
+func (m *PgBusinessMemberRepository) CreateBusinessMember(db *gorm.DB, member *wallet.BusinessMember) error {
+	result := db.Create(member)
+	if isUniqueViolation(result.Error) {
+		return domain.ErrInvalidParameters.WithMessage("user %s is a member of the business already", member.UserID.String())
+	}
+	return dbError(result.Error)
+}
+
+func (m *PgBusinessMemberRepository) UpdateBusinessMember(member *wallet.BusinessMember) error {
+	member.UpdatedAt = time.Now()
+	return dbError(m.db.Save(member).Error)
+}
+
+func (m *PgBusinessMemberRepository) DeleteBusinessMember(organizationID uuid.UUID, userID uuid.UUID) error {
+	result := m.db.Where("organization_id = ? AND user_id = ?", organizationID.String(), userID.String()).Delete(&wallet.BusinessMember{})
+	if result.Error != nil {
+		return dbError(result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return domain.ErrNotFound.WithMessage("user %s is not a member of business %s", userID.String(), organizationID.String())
+	}
+	return nil
+}
+
+func (m *PgBusinessMemberRepository) SearchBusinessMembers(organizationID uuid.UUID) ([]wallet.BusinessMember, error) {
+	var members []wallet.BusinessMember
+	result := m.db.Where("organization_id = ?", organizationID.String()).Order("created_at").Find(&members)
+	if result.Error != nil {
+		return nil, dbError(result.Error)
+	}
+	return members, nil
+}
+
+func (m *PgBusinessMemberRepository) SearchBusinessMemberships(userID uuid.UUID) ([]wallet.BusinessMember, error) {
+	var members []wallet.BusinessMember
+	result := m.db.Where("user_id = ?", userID.String()).Order("created_at").Find(&members)
+	if result.Error != nil {
+		return nil, dbError(result.Error)
+	}
+	return members, nil
+}
@@ -0,0 +1,105 @@
+package repository
+
+import (
+	"github.com/google/uuid"
+	"github.com/raychongtk/wallet/domain"
+	"github.com/raychongtk/wallet/model/payment"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"time"
+)
+
+type BusinessTransferRepository interface {
+	CreateBusinessTransfer(db *gorm.DB, transfer *payment.BusinessTransfer) error
+	GetBusinessTransfer(id uuid.UUID) (*payment.BusinessTransfer, error)
+	GetBusinessTransferByRequestID(requestID string) (*payment.BusinessTransfer, error)
+	LockBusinessTransfer(db *gorm.DB, id uuid.UUID) (*payment.BusinessTransfer, error)
+	UpdateBusinessTransfer(db *gorm.DB, transfer *payment.BusinessTransfer) error
+	// SearchBusinessTransfers returns the transfers of an organization of one status unless it is empty. The latest
+	// come first, except for the pending queue which starts with the one waiting longest.
+	SearchBusinessTransfers(organizationID uuid.UUID, status string, limit int) ([]payment.BusinessTransfer, error)
+	CreateBusinessApproval(db *gorm.DB, approval *payment.BusinessApproval) error
+	// SearchBusinessApprovals returns the decisions on a transfer in the order they were made
+	SearchBusinessApprovals(db *gorm.DB, transferID uuid.UUID) ([]payment.BusinessApproval, error)
+}
+
+type PgBusinessTransferRepository struct {
+	db *gorm.DB
+}
+
+func ProvideBusinessTransferRepository(db gorm.DB) BusinessTransferRepository {
+	return &PgBusinessTransferRepository{&db}
+}
+
+// CreateBusinessTransfer stores a transfer, a request id that was used for another transfer is a duplicate request
+func (m *PgBusinessTransferRepository) CreateBusinessTransfer(db *gorm.DB, transfer *payment.BusinessTransfer) error {
+	result := db.Create(transfer)
+	if isUniqueViolation(result.Error) {
+		return domain.ErrDuplicateRequest.Wrap(result.Error)
+	}
+	return dbError(result.Error)
+}
+
+func (m *PgBusinessTransferRepository) GetBusinessTransfer(id uuid.UUID) (*payment.BusinessTransfer, error) {
+	var transfer payment.BusinessTransfer
+	result := m.db.First(&transfer, "id = ?", id.String())
+	if result.Error != nil {
+		return nil, dbError(result.Error)
+	}
+	return &transfer, nil
+}
+
+// GetBusinessTransferByRequestID finds a transfer by its idempotency key, for members retrying a transfer
+func (m *PgBusinessTransferRepository) GetBusinessTransferByRequestID(requestID string) (*payment.BusinessTransfer, error) {
+	var transfer payment.BusinessTransfer
+	result := m.db.First(&transfer, "request_id = ?", requestID)
+	if result.Error != nil {
+		return nil, dbError(result.Error)
+	}
+	return &transfer, nil
+}
+
+// LockBusinessTransfer reads a transfer and holds it until the transaction ends, so its decisions are counted once
+func (m *PgBusinessTransferRepository) LockBusinessTransfer(db *gorm.DB, id uuid.UUID) (*payment.BusinessTransfer, error) {
+	var transfer payment.BusinessTransfer
+	result := db.Clauses(clause.Locking{Strength: clause.LockingStrengthUpdate}).First(&transfer, "id = ?", id.String())
+	if result.Error != nil {
+		return nil, dbError(result.Error)
+	}
+	return &transfer, nil
+}
+
+func (m *PgBusinessTransferRepository) UpdateBusinessTransfer(db *gorm.DB, transfer *payment.BusinessTransfer) error {
+	transfer.UpdatedAt = time.Now()
+	return dbError(db.Save(transfer).Error)
+}
+
+func (m *PgBusinessTransferRepository) SearchBusinessTransfers(organizationID uuid.UUID, status string, limit int) ([]payment.BusinessTransfer, error) {
+	var transfers []payment.BusinessTransfer
+	query := m.db.Where("organization_id = ?", organizationID.String())
+	order := "created_at desc"
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+	if status == payment.BusinessTransferPending {
+		order = "created_at"
+	}
+	result := query.Order(order).Limit(limit).Find(&transfers)
+	if result.Error != nil {
+		return nil, dbError(result.Error)
+	}
+	return transfers, nil
+}
+
+func (m *PgBusinessTransferRepository) CreateBusinessApproval(db *gorm.DB, approval *payment.BusinessApproval) error {
+	return dbError(db.Create(approval).Error)
+}
+
+func (m *PgBusinessTransferRepository) SearchBusinessApprovals(db *gorm.DB, transferID uuid.UUID) ([]payment.BusinessApproval, error) {
+	var approvals []payment.BusinessApproval
+	result := db.Where("business_transfer_id = ?", transferID.String()).Order("created_at").Find(&approvals)
+	if result.Error != nil {
+		return nil, dbError(result.Error)
+	}
+	return approvals, nil
+}
@@ -0,0 +1,91 @@
+package repository
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/raychongtk/wallet/domain"
+	"gorm.io/gorm"
+	"net"
+	"strings"
+)
+
+// SQLSTATE codes raised by the ledger immutability triggers in migration/sql
+const (
+	immutableRecordCode         = "WL001"
+	invalidStatusTransitionCode = "WL002"
+)
+
+// uniqueViolationCode is raised when an insert repeats the key of a unique index
+const uniqueViolationCode = "23505"
+
+// SQLSTATE codes of failures that go away when the transaction is retried
+const (
+	serializationFailureCode = "40001"
+	deadlockDetectedCode     = "40P01"
+	lockNotAvailableCode     = "55P03"
+)
+
+var (
+	ErrImmutableRecord         = errors.New("ledger record is immutable")
+	ErrInvalidStatusTransition = errors.New("movement status transition is not allowed")
+)
+
+// ImmutabilityError is returned when the database refuses to change a ledger record. It matches
+// ErrImmutableRecord or ErrInvalidStatusTransition with errors.Is.
+type ImmutabilityError struct {
+	Table  string
+	Detail string
+	kind   error
+}
+
+func (e *ImmutabilityError) Error() string {
+	return e.kind.Error() + ": " + e.Detail
+}
+
+func (e *ImmutabilityError) Unwrap() error {
+	return e.kind
+}
+
+// dbError types a database error: a trigger violation becomes an ImmutabilityError, a missing row domain.ErrNotFound,
+// contention domain.ErrConflict and a lost connection or timeout domain.ErrUnavailable. Other errors are untouched.
+func dbError(err error) error {
+	if err == nil {
+		return nil
+	}
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return domain.ErrNotFound.Wrap(err)
+	}
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		switch {
+		case pgErr.Code == immutableRecordCode:
+			return &ImmutabilityError{Table: pgErr.TableName, Detail: pgErr.Message, kind: ErrImmutableRecord}
+		case pgErr.Code == invalidStatusTransitionCode:
+			return &ImmutabilityError{Table: pgErr.TableName, Detail: pgErr.Message, kind: ErrInvalidStatusTransition}
+		case pgErr.Code == serializationFailureCode, pgErr.Code == deadlockDetectedCode, pgErr.Code == lockNotAvailableCode:
+			return domain.ErrConflict.Wrap(err)
+		// connection exceptions, insufficient resources and operator intervention such as a server shutdown
+		case strings.HasPrefix(pgErr.Code, "08"), strings.HasPrefix(pgErr.Code, "53"), strings.HasPrefix(pgErr.Code, "57"):
+			return domain.ErrUnavailable.Wrap(err)
+		}
+		return err
+	}
+	var netErr net.Error
+	if errors.As(err, &netErr) || errors.Is(err, driver.ErrBadConn) || pgconn.Timeout(err) ||
+		errors.Is(err, context.DeadlineExceeded) {
+		return domain.ErrUnavailable.Wrap(err)
+	}
+	return err
+}
+
+func isUniqueViolation(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == uniqueViolationCode
+}
+
+// DBError types an error the database raised outside of a repository, such as a failed commit
+func DBError(err error) error {
+	return dbError(err)
+}
@@ -0,0 +1,78 @@
+package repository
+
+import (
+	"github.com/google/uuid"
+	"github.com/raychongtk/wallet/model/payment"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"time"
+)
+
+type EscrowRepository interface {
+	CreateEscrow(db *gorm.DB, escrow *payment.Escrow) error
+	GetEscrow(id uuid.UUID) (*payment.Escrow, error)
+	LockEscrow(db *gorm.DB, id uuid.UUID) (*payment.Escrow, error)
+	UpdateEscrow(db *gorm.DB, escrow *payment.Escrow) error
+	SearchEscrows(userID uuid.UUID, status string, limit int) ([]payment.Escrow, error)
+	SearchDueEscrows(now time.Time, limit int) ([]payment.Escrow, error)
+}
+
+type PgEscrowRepository struct {
+	db *gorm.DB
+}
+
+func ProvideEscrowRepository(db gorm.DB) EscrowRepository {
+	return &PgEscrowRepository{&db}
+}
+
+func (m *PgEscrowRepository) CreateEscrow(db *gorm.DB, escrow *payment.Escrow) error {
+	return dbError(db.Create(escrow).Error)
+}
+
+func (m *PgEscrowRepository) GetEscrow(id uuid.UUID) (*payment.Escrow, error) {
+	var escrow payment.Escrow
+	result := m.db.First(&escrow, "id = ?", id.String())
+	if result.Error != nil {
+		return nil, dbError(result.Error)
+	}
+	return &escrow, nil
+}
+
+// LockEscrow reads an escrow and keeps it locked until the transaction ends, so it is settled once
+func (m *PgEscrowRepository) LockEscrow(db *gorm.DB, id uuid.UUID) (*payment.Escrow, error) {
+	var escrow payment.Escrow
+	result := db.Clauses(clause.Locking{Strength: clause.LockingStrengthUpdate}).First(&escrow, "id = ?", id.String())
+	if result.Error != nil {
+		return nil, dbError(result.Error)
+	}
+	return &escrow, nil
+}
+
+func (m *PgEscrowRepository) UpdateEscrow(db *gorm.DB, escrow *payment.Escrow) error {
+	escrow.UpdatedAt = time.Now()
+	return dbError(db.Save(escrow).Error)
+}
+
+// SearchEscrows returns the newest escrows a user buys or sells in first, an empty status matches every status
+func (m *PgEscrowRepository) SearchEscrows(userID uuid.UUID, status string, limit int) ([]payment.Escrow, error) {
+	var escrows []payment.Escrow
+	query := m.db.Where("buyer_user_id = ? OR seller_user_id = ?", userID.String(), userID.String())
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+	result := query.Order("created_at desc").Limit(limit).Find(&escrows)
+	if result.Error != nil {
+		return nil, dbError(result.Error)
+	}
+	return escrows, nil
+}
+
+// SearchDueEscrows finds funded escrows whose deadline has passed, oldest first
+func (m *PgEscrowRepository) SearchDueEscrows(now time.Time, limit int) ([]payment.Escrow, error) {
+	var escrows []payment.Escrow
+	result := m.db.Where("status = ? AND deadline <= ?", payment.EscrowFunded, now).Order("deadline").Limit(limit).Find(&escrows)
+	if result.Error != nil {
+		return nil, dbError(result.Error)
+	}
+	return escrows, nil
+}
@@ -0,0 +1,78 @@
+package repository
+
+import (
+	"github.com/google/uuid"
+	"github.com/raychongtk/wallet/model/wallet"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"time"
+)
+
+type HoldRepository interface {
+	CreateHold(db *gorm.DB, hold *wallet.Hold) error
+	GetHold(id uuid.UUID) (*wallet.Hold, error)
+	LockHold(db *gorm.DB, id uuid.UUID) (*wallet.Hold, error)
+	UpdateHold(db *gorm.DB, hold *wallet.Hold) error
+	SearchHolds(userID uuid.UUID, status string, limit int) ([]wallet.Hold, error)
+	SearchExpiredHolds(now time.Time, limit int) ([]wallet.Hold, error)
+}
+
+type PgHoldRepository struct {
+	db *gorm.DB
+}
+
+func ProvideHoldRepository(db gorm.DB) HoldRepository {
+	return &PgHoldRepository{&db}
+}
+
+func (m *PgHoldRepository) CreateHold(db *gorm.DB, hold *wallet.Hold) error {
+	return dbError(db.Create(hold).Error)
+}
+
+func (m *PgHoldRepository) GetHold(id uuid.UUID) (*wallet.Hold, error) {
+	var hold wallet.Hold
+	result := m.db.First(&hold, "id = ?", id.String())
+	if result.Error != nil {
+		return nil, dbError(result.Error)
+	}
+	return &hold, nil
+}
+
+// LockHold reads a hold and keeps it locked until the transaction ends, so it is captured or released once
+func (m *PgHoldRepository) LockHold(db *gorm.DB, id uuid.UUID) (*wallet.Hold, error) {
+	var hold wallet.Hold
+	result := db.Clauses(clause.Locking{Strength: clause.LockingStrengthUpdate}).First(&hold, "id = ?", id.String())
+	if result.Error != nil {
+		return nil, dbError(result.Error)
+	}
+	return &hold, nil
+}
+
+func (m *PgHoldRepository) UpdateHold(db *gorm.DB, hold *wallet.Hold) error {
+	hold.UpdatedAt = time.Now()
+	return dbError(db.Save(hold).Error)
+}
+
+// SearchHolds returns the newest holds of a user first, an empty status matches every status
+func (m *PgHoldRepository) SearchHolds(userID uuid.UUID, status string, limit int) ([]wallet.Hold, error) {
+	var holds []wallet.Hold
+	query := m.db.Where("user_id = ?", userID.String())
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+	result := query.Order("created_at desc").Limit(limit).Find(&holds)
+	if result.Error != nil {
+		return nil, dbError(result.Error)
+	}
+	return holds, nil
+}
+
+// SearchExpiredHolds finds active holds whose expiry has passed, oldest first
+func (m *PgHoldRepository) SearchExpiredHolds(now time.Time, limit int) ([]wallet.Hold, error) {
+	var holds []wallet.Hold
+	result := m.db.Where("status = ? AND expires_at <= ?", wallet.HoldActive, now).Order("expires_at").Limit(limit).Find(&holds)
+	if result.Error != nil {
+		return nil, dbError(result.Error)
+	}
+	return holds, nil
+}
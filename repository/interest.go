@@ -0,0 +1,139 @@
+package repository
+
+import (
+	"github.com/google/uuid"
+	"github.com/raychongtk/wallet/domain"
+	"github.com/raychongtk/wallet/model/wallet"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"time"
+)
+
+// InterestWallet is a customer wallet that earns interest and the user it belongs to
+type InterestWallet struct {
+	WalletID uuid.UUID
+	UserID   uuid.UUID
+}
+
+type InterestRepository interface {
+	// CreateAccrual stores an accrual unless the wallet accrued the day already, it reports whether it was stored
+	CreateAccrual(db *gorm.DB, accrual *wallet.InterestAccrual) (bool, error)
+	// LastAccrual is the latest accrual of a wallet before a day, domain.ErrNotFound when it has none
+	LastAccrual(walletID uuid.UUID, before time.Time) (*wallet.InterestAccrual, error)
+	LockUnpaidAccruals(db *gorm.DB, walletID uuid.UUID, before time.Time) ([]wallet.InterestAccrual, error)
+	MarkAccrualsPaid(db *gorm.DB, ids []uuid.UUID, groupID *uuid.UUID, paidAt time.Time) error
+	SearchAccruals(walletID uuid.UUID, limit int) ([]wallet.InterestAccrual, error)
+	SearchInterestWallets(after uuid.UUID, limit int) ([]InterestWallet, error)
+	SearchUnpaidWallets(before time.Time, limit int) ([]InterestWallet, error)
+	CreateRun(day time.Time) error
+	// LastRun is the latest day every wallet accrued, domain.ErrNotFound before the first run
+	LastRun() (*wallet.InterestRun, error)
+}
+
+type PgInterestRepository struct {
+	db *gorm.DB
+}
+
+func ProvideInterestRepository(db gorm.DB) InterestRepository {
+	return &PgInterestRepository{&db}
+}
+
+func (m *PgInterestRepository) CreateAccrual(db *gorm.DB, accrual *wallet.InterestAccrual) (bool, error) {
+	result := db.Clauses(clause.OnConflict{DoNothing: true}).Create(accrual)
+	if result.Error != nil {
+		return false, dbError(result.Error)
+	}
+	return result.RowsAffected == 1, nil
+}
+
+func (m *PgInterestRepository) LastAccrual(walletID uuid.UUID, before time.Time) (*wallet.InterestAccrual, error) {
+	var accrual wallet.InterestAccrual
+	result := m.db.Where("wallet_id = ? AND day < ?", walletID.String(), before.Format(time.DateOnly)).Order("day desc").Limit(1).Find(&accrual)
+	if result.Error != nil {
+		return nil, dbError(result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return nil, domain.ErrNotFound.WithMessage("wallet %s has no accrual", walletID.String())
+	}
+	return &accrual, nil
+}
+
+// LockUnpaidAccruals reads the accruals of a wallet before a day that are not paid out and keeps them locked until the
+// transaction ends, so they are paid once
+func (m *PgInterestRepository) LockUnpaidAccruals(db *gorm.DB, walletID uuid.UUID, before time.Time) ([]wallet.InterestAccrual, error) {
+	var accruals []wallet.InterestAccrual
+	result := db.Clauses(clause.Locking{Strength: clause.LockingStrengthUpdate}).
+		Where("wallet_id = ? AND day < ? AND paid_at IS NULL", walletID.String(), before.Format(time.DateOnly)).
+		Order("day").
+		Find(&accruals)
+	if result.Error != nil {
+		return nil, dbError(result.Error)
+	}
+	return accruals, nil
+}
+
+func (m *PgInterestRepository) MarkAccrualsPaid(db *gorm.DB, ids []uuid.UUID, groupID *uuid.UUID, paidAt time.Time) error {
+	result := db.Model(&wallet.InterestAccrual{}).Where("id IN ?", ids).Updates(map[string]interface{}{
+		"payout_group_id": groupID,
+		"paid_at":         paidAt,
+	})
+	return dbError(result.Error)
+}
+
+// SearchAccruals returns the latest accruals of a wallet first
+func (m *PgInterestRepository) SearchAccruals(walletID uuid.UUID, limit int) ([]wallet.InterestAccrual, error) {
+	var accruals []wallet.InterestAccrual
+	result := m.db.Where("wallet_id = ?", walletID.String()).Order("day desc").Limit(limit).Find(&accruals)
+	if result.Error != nil {
+		return nil, dbError(result.Error)
+	}
+	return accruals, nil
+}
+
+// SearchInterestWallets pages through the customer wallets in wallet id order, starting after a wallet id
+func (m *PgInterestRepository) SearchInterestWallets(after uuid.UUID, limit int) ([]InterestWallet, error) {
+	var wallets []InterestWallet
+	result := m.db.Table("wallet").
+		Select("wallet.id AS wallet_id, account.user_id AS user_id").
+		Joins("JOIN account ON account.id = wallet.account_id").
+		Where("account.account_type = ? AND wallet.id > ?", "CUSTOMER", after.String()).
+		Order("wallet.id").
+		Limit(limit).
+		Scan(&wallets)
+	if result.Error != nil {
+		return nil, dbError(result.Error)
+	}
+	return wallets, nil
+}
+
+// SearchUnpaidWallets finds the wallets with accruals before a day that are not paid out
+func (m *PgInterestRepository) SearchUnpaidWallets(before time.Time, limit int) ([]InterestWallet, error) {
+	var wallets []InterestWallet
+	result := m.db.Model(&wallet.InterestAccrual{}).
+		Distinct("wallet_id", "user_id").
+		Where("day < ? AND paid_at IS NULL", before.Format(time.DateOnly)).
+		Order("wallet_id").
+		Limit(limit).
+		Scan(&wallets)
+	if result.Error != nil {
+		return nil, dbError(result.Error)
+	}
+	return wallets, nil
+}
+
+func (m *PgInterestRepository) CreateRun(day time.Time) error {
+	run := wallet.InterestRun{Day: day, AccruedAt: time.Now()}
+	return dbError(m.db.Clauses(clause.OnConflict{DoNothing: true}).Create(&run).Error)
+}
+
+func (m *PgInterestRepository) LastRun() (*wallet.InterestRun, error) {
+	var run wallet.InterestRun
+	result := m.db.Order("day desc").Limit(1).Find(&run)
+	if result.Error != nil {
+		return nil, dbError(result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return nil, domain.ErrNotFound.WithMessage("interest never accrued")
+	}
+	return &run, nil
+}
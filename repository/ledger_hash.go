@@ -0,0 +1,131 @@
+package repository
+
+import (
+	"errors"
+	"github.com/google/uuid"
+	"github.com/raychongtk/wallet/model/ledger"
+	"gorm.io/gorm"
+)
+
+type LedgerHashRepository interface {
+	CreateEntry(db *gorm.DB, entry *ledger.Entry) error
+	GetLastEntry(db *gorm.DB) (*ledger.Entry, error)
+	GetEntry(sequence int64) (*ledger.Entry, error)
+	SearchEntries(afterSequence int64, limit int) ([]ledger.Entry, error)
+	SearchEntriesByGroupIDs(groupIDs []uuid.UUID) ([]ledger.Entry, error)
+	CreateWalletEntries(db *gorm.DB, entries []ledger.WalletEntry) error
+	GetLastWalletEntry(db *gorm.DB, walletID uuid.UUID) (*ledger.WalletEntry, error)
+	SearchWalletEntries(afterWalletID uuid.UUID, afterSequence int64, limit int) ([]ledger.WalletEntry, error)
+	CreateCheckpoint(db *gorm.DB, checkpoint *ledger.Checkpoint) error
+	GetLastCheckpoint() (*ledger.Checkpoint, error)
+	SearchCheckpoints() ([]ledger.Checkpoint, error)
+}
+
+type PgLedgerHashRepository struct {
+	db *gorm.DB
+}
+
+func ProvideLedgerHashRepository(db gorm.DB) LedgerHashRepository {
+	return &PgLedgerHashRepository{&db}
+}
+
+func (m *PgLedgerHashRepository) CreateEntry(db *gorm.DB, entry *ledger.Entry) error {
+	return db.Create(entry).Error
+}
+
+func (m *PgLedgerHashRepository) GetLastEntry(db *gorm.DB) (*ledger.Entry, error) {
+	var entry ledger.Entry
+	result := db.Order("sequence desc").First(&entry)
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if result.Error != nil {
+		return nil, dbError(result.Error)
+	}
+	return &entry, nil
+}
+
+func (m *PgLedgerHashRepository) GetEntry(sequence int64) (*ledger.Entry, error) {
+	var entry ledger.Entry
+	result := m.db.First(&entry, "sequence = ?", sequence)
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if result.Error != nil {
+		return nil, dbError(result.Error)
+	}
+	return &entry, nil
+}
+
+func (m *PgLedgerHashRepository) SearchEntries(afterSequence int64, limit int) ([]ledger.Entry, error) {
+	var entries []ledger.Entry
+	result := m.db.Where("sequence > ?", afterSequence).Order("sequence").Limit(limit).Find(&entries)
+	if result.Error != nil {
+		return nil, dbError(result.Error)
+	}
+	return entries, nil
+}
+
+func (m *PgLedgerHashRepository) SearchEntriesByGroupIDs(groupIDs []uuid.UUID) ([]ledger.Entry, error) {
+	var entries []ledger.Entry
+	if len(groupIDs) == 0 {
+		return entries, nil
+	}
+	result := m.db.Where("group_id IN ?", groupIDs).Find(&entries)
+	if result.Error != nil {
+		return nil, dbError(result.Error)
+	}
+	return entries, nil
+}
+
+func (m *PgLedgerHashRepository) CreateWalletEntries(db *gorm.DB, entries []ledger.WalletEntry) error {
+	return db.Create(entries).Error
+}
+
+func (m *PgLedgerHashRepository) GetLastWalletEntry(db *gorm.DB, walletID uuid.UUID) (*ledger.WalletEntry, error) {
+	var entry ledger.WalletEntry
+	result := db.Where("wallet_id = ?", walletID).Order("sequence desc").First(&entry)
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if result.Error != nil {
+		return nil, dbError(result.Error)
+	}
+	return &entry, nil
+}
+
+// SearchWalletEntries pages through every wallet chain ordered by wallet and sequence
+func (m *PgLedgerHashRepository) SearchWalletEntries(afterWalletID uuid.UUID, afterSequence int64, limit int) ([]ledger.WalletEntry, error) {
+	var entries []ledger.WalletEntry
+	result := m.db.Where("(wallet_id, sequence) > (?, ?)", afterWalletID, afterSequence).
+		Order("wallet_id").Order("sequence").Limit(limit).Find(&entries)
+	if result.Error != nil {
+		return nil, dbError(result.Error)
+	}
+	return entries, nil
+}
+
+func (m *PgLedgerHashRepository) CreateCheckpoint(db *gorm.DB, checkpoint *ledger.Checkpoint) error {
+	return db.Create(checkpoint).Error
+}
+
+func (m *PgLedgerHashRepository) GetLastCheckpoint() (*ledger.Checkpoint, error) {
+	var checkpoint ledger.Checkpoint
+	result := m.db.Order("sequence desc").First(&checkpoint)
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if result.Error != nil {
+		return nil, dbError(result.Error)
+	}
+	return &checkpoint, nil
+}
+
+func (m *PgLedgerHashRepository) SearchCheckpoints() ([]ledger.Checkpoint, error) {
+	var checkpoints []ledger.Checkpoint
+	result := m.db.Order("sequence").Find(&checkpoints)
+	if result.Error != nil {
+		return nil, dbError(result.Error)
+	}
+	return checkpoints, nil
+}
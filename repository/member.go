@@ -0,0 +1,92 @@
+package repository
+
+import (
+	"github.com/google/uuid"
+	"github.com/raychongtk/wallet/domain"
+	"github.com/raychongtk/wallet/model/wallet"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type MemberRepository interface {
+	// GetMember is the membership of a user in an account, domain.ErrNotFound when it is not shared with them
+	GetMember(accountID uuid.UUID, userID uuid.UUID) (*wallet.AccountMember, error)
+	// LockMember reads the membership of a user in an account and locks it until db commits
+	LockMember(db *gorm.DB, accountID uuid.UUID, userID uuid.UUID) (*wallet.AccountMember, error)
+	// SaveMember adds a member or changes the role and spending limit of one
+	SaveMember(member *wallet.AccountMember) error
+	DeleteMember(accountID uuid.UUID, userID uuid.UUID) error
+	SearchMembers(accountID uuid.UUID) ([]wallet.AccountMember, error)
+	// SearchMemberships returns the accounts shared with a user, the one they hold is not among them
+	SearchMemberships(userID uuid.UUID) ([]wallet.AccountMember, error)
+}
+
+type PgMemberRepository struct {
+	db *gorm.DB
+}
+
+func ProvideMemberRepository(db gorm.DB) MemberRepository {
+	return &PgMemberRepository{&db}
+}
+
+func (m *PgMemberRepository) GetMember(accountID uuid.UUID, userID uuid.UUID) (*wallet.AccountMember, error) {
+	var member wallet.AccountMember
+	result := m.db.Where("account_id = ? AND user_id = ?", accountID.String(), userID.String()).Find(&member)
+	if result.Error != nil {
+		return nil, dbError(result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return nil, domain.ErrNotFound.WithMessage("user %s is not a member of account %s", userID.String(), accountID.String())
+	}
+	return &member, nil
+}
+
+func (m *PgMemberRepository) LockMember(db *gorm.DB, accountID uuid.UUID, userID uuid.UUID) (*wallet.AccountMember, error) {
+	var member wallet.AccountMember
+	result := db.Clauses(clause.Locking{Strength: clause.LockingStrengthUpdate}).
+		Where("account_id = ? AND user_id = ?", accountID.String(), userID.String()).Find(&member)
+	if result.Error != nil {
+		return nil, dbError(result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return nil, domain.ErrNotFound.WithMessage("user %s is not a member of account %s", userID.String(), accountID.String())
+	}
+	return &member, nil
+}
+
+func (m *PgMemberRepository) SaveMember(member *wallet.AccountMember) error {
+	result := m.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "account_id"}, {Name: "user_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"role", "spending_limit", "updated_at"}),
+	}).Create(member)
+	return dbError(result.Error)
+}
+
+func (m *PgMemberRepository) DeleteMember(accountID uuid.UUID, userID uuid.UUID) error {
+	result := m.db.Where("account_id = ? AND user_id = ?", accountID.String(), userID.String()).Delete(&wallet.AccountMember{})
+	if result.Error != nil {
+		return dbError(result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return domain.ErrNotFound.WithMessage("user %s is not a member of account %s", userID.String(), accountID.String())
+	}
+	return nil
+}
+
+func (m *PgMemberRepository) SearchMembers(accountID uuid.UUID) ([]wallet.AccountMember, error) {
+	var members []wallet.AccountMember
+	result := m.db.Where("account_id = ?", accountID.String()).Order("created_at").Find(&members)
+	if result.Error != nil {
+		return nil, dbError(result.Error)
+	}
+	return members, nil
+}
+
+func (m *PgMemberRepository) SearchMemberships(userID uuid.UUID) ([]wallet.AccountMember, error) {
+	var members []wallet.AccountMember
+	result := m.db.Where("user_id = ?", userID.String()).Order("created_at").Find(&members)
+	if result.Error != nil {
+		return nil, dbError(result.Error)
+	}
+	return members, nil
+}
@@ -1,13 +1,30 @@
 package repository
 
 import (
+	"errors"
+	"github.com/google/uuid"
 	"github.com/raychongtk/wallet/model/movement"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"time"
 )
 
 type MovementRepository interface {
 	CreateMovement(db *gorm.DB, movement *movement.Movement) (*movement.Movement, error)
 	CreateMovements(db *gorm.DB, movements []movement.Movement) error
+	// ClaimRequestID books a request id to a movement group and reports the claim booked under it before, nil when
+	// the group claimed it. A claim made by a transaction in flight waits for it to end.
+	ClaimRequestID(db *gorm.DB, request *movement.LedgerRequest) (*movement.LedgerRequest, error)
+	// GetLedgerRequest is the claim booked under a request id, nil when none was
+	GetLedgerRequest(db *gorm.DB, requestID string) (*movement.LedgerRequest, error)
+	GetOldestMovement() (*movement.Movement, error)
+	SearchMovementsCreatedBetween(from time.Time, to time.Time) ([]movement.Movement, error)
+	DeleteMovements(db *gorm.DB, ids []uuid.UUID) (int64, error)
+	SearchMovementsByTrace(traceID string, requestID string) ([]movement.Movement, error)
+	SearchMovementsByGroupIDs(groupIDs []uuid.UUID) ([]movement.Movement, error)
+	SearchMovementsByRequestIDs(requestIDs []string) ([]movement.Movement, error)
+	CountUnchainedMovements() (int64, error)
+	UpdateMovementStatus(db *gorm.DB, id uuid.UUID, status string) error
 }
 
 type PgMovementRepository struct {
@@ -21,7 +38,7 @@ func ProvideMovementRepository(db gorm.DB) MovementRepository {
 func (m *PgMovementRepository) CreateMovement(db *gorm.DB, movement *movement.Movement) (*movement.Movement, error) {
 	result := db.Create(movement)
 	if result.Error != nil {
-		return nil, result.Error
+		return nil, dbError(result.Error)
 	}
 	return movement, nil
 }
@@ -29,7 +46,120 @@ func (m *PgMovementRepository) CreateMovement(db *gorm.DB, movement *movement.Mo
 func (m *PgMovementRepository) CreateMovements(db *gorm.DB, movements []movement.Movement) error {
 	result := db.Create(movements)
 	if result.Error != nil {
-		return result.Error
+		return dbError(result.Error)
+	}
+	return nil
+}
+
+func (m *PgMovementRepository) ClaimRequestID(db *gorm.DB, request *movement.LedgerRequest) (*movement.LedgerRequest, error) {
+	result := db.Clauses(clause.OnConflict{DoNothing: true}).Create(request)
+	if result.Error != nil {
+		return nil, dbError(result.Error)
+	}
+	if result.RowsAffected == 1 {
+		return nil, nil
+	}
+	return m.GetLedgerRequest(db, request.RequestID)
+}
+
+func (m *PgMovementRepository) GetLedgerRequest(db *gorm.DB, requestID string) (*movement.LedgerRequest, error) {
+	var booked movement.LedgerRequest
+	result := db.Where("request_id = ?", requestID).Limit(1).Find(&booked)
+	if result.Error != nil {
+		return nil, dbError(result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return nil, nil
+	}
+	return &booked, nil
+}
+
+func (m *PgMovementRepository) GetOldestMovement() (*movement.Movement, error) {
+	var oldest movement.Movement
+	result := m.db.Order("created_at").First(&oldest)
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if result.Error != nil {
+		return nil, dbError(result.Error)
+	}
+	return &oldest, nil
+}
+
+func (m *PgMovementRepository) SearchMovementsCreatedBetween(from time.Time, to time.Time) ([]movement.Movement, error) {
+	var movements []movement.Movement
+	result := m.db.Where("created_at >= ? AND created_at < ?", from, to).Order("created_at").Find(&movements)
+	if result.Error != nil {
+		return nil, dbError(result.Error)
+	}
+	return movements, nil
+}
+
+func (m *PgMovementRepository) DeleteMovements(db *gorm.DB, ids []uuid.UUID) (int64, error) {
+	result := db.Where("id IN ?", ids).Delete(&movement.Movement{})
+	if result.Error != nil {
+		return 0, dbError(result.Error)
+	}
+	return result.RowsAffected, nil
+}
+
+// SearchMovementsByTrace matches on every id that is not empty
+func (m *PgMovementRepository) SearchMovementsByTrace(traceID string, requestID string) ([]movement.Movement, error) {
+	var movements []movement.Movement
+	result := byTrace(m.db, traceID, requestID).Order("created_at").Find(&movements)
+	if result.Error != nil {
+		return nil, dbError(result.Error)
+	}
+	return movements, nil
+}
+
+func (m *PgMovementRepository) SearchMovementsByGroupIDs(groupIDs []uuid.UUID) ([]movement.Movement, error) {
+	var movements []movement.Movement
+	if len(groupIDs) == 0 {
+		return movements, nil
+	}
+	result := m.db.Where("group_id IN ?", groupIDs).Find(&movements)
+	if result.Error != nil {
+		return nil, dbError(result.Error)
+	}
+	return movements, nil
+}
+
+func (m *PgMovementRepository) SearchMovementsByRequestIDs(requestIDs []string) ([]movement.Movement, error) {
+	var movements []movement.Movement
+	if len(requestIDs) == 0 {
+		return movements, nil
+	}
+	result := m.db.Where("request_id IN ?", requestIDs).Find(&movements)
+	if result.Error != nil {
+		return nil, dbError(result.Error)
+	}
+	return movements, nil
+}
+
+// CountUnchainedMovements counts movements written after the hash chain started that are not part of it
+func (m *PgMovementRepository) CountUnchainedMovements() (int64, error) {
+	var count int64
+	result := m.db.Model(&movement.Movement{}).
+		Where("created_at >= (SELECT min(created_at) FROM ledger_hash)").
+		Where("NOT EXISTS (SELECT 1 FROM ledger_hash WHERE ledger_hash.group_id = movement.group_id)").
+		Count(&count)
+	if result.Error != nil {
+		return 0, dbError(result.Error)
+	}
+	return count, nil
+}
+
+// UpdateMovementStatus is the only change the database accepts on a movement, and only for the transitions listed in
+// movement_status_transition
+func (m *PgMovementRepository) UpdateMovementStatus(db *gorm.DB, id uuid.UUID, status string) error {
+	result := db.Model(&movement.Movement{}).Where("id = ?", id).
+		Updates(map[string]interface{}{"movement_status": status, "updated_at": time.Now()})
+	if result.Error != nil {
+		return dbError(result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
 	}
 	return nil
 }
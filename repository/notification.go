@@ -0,0 +1,34 @@
+package repository
+
+import (
+	"github.com/google/uuid"
+	"github.com/raychongtk/wallet/model/notification"
+	"gorm.io/gorm"
+)
+
+type NotificationRepository interface {
+	CreateNotification(db *gorm.DB, notification *notification.Notification) error
+	SearchNotifications(userID uuid.UUID, afterID int64, limit int) ([]notification.Notification, error)
+}
+
+type PgNotificationRepository struct {
+	db *gorm.DB
+}
+
+func ProvideNotificationRepository(db gorm.DB) NotificationRepository {
+	return &PgNotificationRepository{&db}
+}
+
+func (m *PgNotificationRepository) CreateNotification(db *gorm.DB, notification *notification.Notification) error {
+	return dbError(db.Create(notification).Error)
+}
+
+// SearchNotifications pages by id, which follows the order the notifications were sent in
+func (m *PgNotificationRepository) SearchNotifications(userID uuid.UUID, afterID int64, limit int) ([]notification.Notification, error) {
+	var notifications []notification.Notification
+	result := m.db.Where("user_id = ? AND id > ?", userID.String(), afterID).Order("id").Limit(limit).Find(&notifications)
+	if result.Error != nil {
+		return nil, dbError(result.Error)
+	}
+	return notifications, nil
+}
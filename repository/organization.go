@@ -0,0 +1,69 @@
+package repository
+
+import (
+	"github.com/google/uuid"
+	"github.com/raychongtk/wallet/domain"
+	"github.com/raychongtk/wallet/model/wallet"
+	"gorm.io/gorm"
+)
+
+// walletBalanceTypes are the balances every wallet has, a new wallet starts with all of them at zero
+var walletBalanceTypes = []string{"COMMITTED", "HELD", "ACCRUED_INTEREST"}
+
+type OrganizationRepository interface {
+	// CreateOrganization stores an organization with its business account and the wallet of the account, an
+	// organization registered under the same number already is invalid
+	CreateOrganization(db *gorm.DB, organization *wallet.Organization, account *wallet.Account, businessWallet *wallet.Wallet) error
+	GetOrganization(id uuid.UUID) (*wallet.Organization, error)
+}
+
+type PgOrganizationRepository struct {
+	db *gorm.DB
+}
+
+func ProvideOrganizationRepository(db gorm.DB) OrganizationRepository {
+	return &PgOrganizationRepository{&db}
+}
+
+func (m *PgOrganizationRepository) CreateOrganization(db *gorm.DB, organization *wallet.Organization, account *wallet.Account, businessWallet *wallet.Wallet) error {
+	if result := db.Create(organization); result.Error != nil {
+		if isUniqueViolation(result.Error) {
+			return domain.ErrInvalidParameters.WithMessage("an organization registered as %s exists already", organization.RegistrationNumber)
+		}
+		return dbError(result.Error)
+	}
+	if result := db.Create(account); result.Error != nil {
+		return dbError(result.Error)
+	}
+	return createWallet(db, businessWallet)
+}
+
+func (m *PgOrganizationRepository) GetOrganization(id uuid.UUID) (*wallet.Organization, error) {
+	var organization wallet.Organization
+	result := m.db.First(&organization, "id = ?", id.String())
+	if result.Error != nil {
+		return nil, dbError(result.Error)
+	}
+	return &organization, nil
+}
+
+// createWallet stores a wallet with its balances
+func createWallet(db *gorm.DB, newWallet *wallet.Wallet) error {
+	if result := db.Create(newWallet); result.Error != nil {
+		return dbError(result.Error)
+	}
+	balances := make([]wallet.Balance, 0, len(walletBalanceTypes))
+	for _, balanceType := range walletBalanceTypes {
+		balances = append(balances, wallet.Balance{
+			ID:          uuid.New(),
+			WalletID:    newWallet.ID,
+			BalanceType: balanceType,
+			CreatedAt:   newWallet.CreatedAt,
+			UpdatedAt:   newWallet.CreatedAt,
+		})
+	}
+	if result := db.Create(balances); result.Error != nil {
+		return dbError(result.Error)
+	}
+	return nil
+}
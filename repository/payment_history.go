@@ -1,36 +1,83 @@
 package repository
 
 import (
+	"errors"
+	"github.com/google/uuid"
+	"github.com/raychongtk/wallet/datastore"
 	"github.com/raychongtk/wallet/model/payment"
 	"gorm.io/gorm"
+	"time"
 )
 
 type PaymentHistoryRepository interface {
 	CreatePaymentHistory(db *gorm.DB, paymentHistory *payment.PaymentHistory) (*payment.PaymentHistory, error)
 	SearchPaymentHistory(userId string) ([]payment.PaymentHistory, error)
+	GetOldestPaymentHistory() (*payment.PaymentHistory, error)
+	SearchPaymentHistoryCreatedBetween(from time.Time, to time.Time) ([]payment.PaymentHistory, error)
+	DeletePaymentHistories(db *gorm.DB, ids []uuid.UUID) (int64, error)
+	SearchPaymentHistoryByTrace(traceID string, requestID string) ([]payment.PaymentHistory, error)
 }
 
 type PgPaymentHistoryRepository struct {
-	db *gorm.DB
+	db     *gorm.DB
+	router *datastore.ReplicaRouter
 }
 
-func ProvidePaymentHistoryRepository(db gorm.DB) PaymentHistoryRepository {
-	return &PgPaymentHistoryRepository{&db}
+func ProvidePaymentHistoryRepository(db gorm.DB, router *datastore.ReplicaRouter) PaymentHistoryRepository {
+	return &PgPaymentHistoryRepository{&db, router}
 }
 
 func (m *PgPaymentHistoryRepository) CreatePaymentHistory(db *gorm.DB, paymentHistory *payment.PaymentHistory) (*payment.PaymentHistory, error) {
 	result := db.Create(paymentHistory)
 	if result.Error != nil {
-		return nil, result.Error
+		return nil, dbError(result.Error)
 	}
 	return paymentHistory, nil
 }
 
 func (m *PgPaymentHistoryRepository) SearchPaymentHistory(userId string) ([]payment.PaymentHistory, error) {
 	var paymentHistories []payment.PaymentHistory
-	result := m.db.Where("payer_user_id = ? OR payee_user_id = ?", userId, userId).Find(&paymentHistories)
+	result := m.router.Reader(userId).Where("payer_user_id = ? OR payee_user_id = ?", userId, userId).Find(&paymentHistories)
 	if result.Error != nil {
-		return nil, result.Error
+		return nil, dbError(result.Error)
+	}
+	return paymentHistories, nil
+}
+
+func (m *PgPaymentHistoryRepository) GetOldestPaymentHistory() (*payment.PaymentHistory, error) {
+	var oldest payment.PaymentHistory
+	result := m.db.Order("created_at").First(&oldest)
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if result.Error != nil {
+		return nil, dbError(result.Error)
+	}
+	return &oldest, nil
+}
+
+func (m *PgPaymentHistoryRepository) SearchPaymentHistoryCreatedBetween(from time.Time, to time.Time) ([]payment.PaymentHistory, error) {
+	var paymentHistories []payment.PaymentHistory
+	result := m.db.Where("created_at >= ? AND created_at < ?", from, to).Order("created_at").Find(&paymentHistories)
+	if result.Error != nil {
+		return nil, dbError(result.Error)
+	}
+	return paymentHistories, nil
+}
+
+func (m *PgPaymentHistoryRepository) DeletePaymentHistories(db *gorm.DB, ids []uuid.UUID) (int64, error) {
+	result := db.Where("id IN ?", ids).Delete(&payment.PaymentHistory{})
+	if result.Error != nil {
+		return 0, dbError(result.Error)
+	}
+	return result.RowsAffected, nil
+}
+
+func (m *PgPaymentHistoryRepository) SearchPaymentHistoryByTrace(traceID string, requestID string) ([]payment.PaymentHistory, error) {
+	var paymentHistories []payment.PaymentHistory
+	result := byTrace(m.db, traceID, requestID).Order("created_at").Find(&paymentHistories)
+	if result.Error != nil {
+		return nil, dbError(result.Error)
 	}
 	return paymentHistories, nil
 }
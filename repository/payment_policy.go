@@ -0,0 +1,44 @@
+package repository
+
+import (
+	"github.com/google/uuid"
+	"github.com/raychongtk/wallet/model/payment"
+	"gorm.io/gorm"
+)
+
+type PaymentPolicyRepository interface {
+	// SearchPaymentPolicies returns the policies of an organization from the lowest amount up
+	SearchPaymentPolicies(organizationID uuid.UUID) ([]payment.PaymentPolicy, error)
+	// ReplacePaymentPolicies swaps every policy of an organization for the given ones in one transaction
+	ReplacePaymentPolicies(organizationID uuid.UUID, policies []payment.PaymentPolicy) error
+}
+
+type PgPaymentPolicyRepository struct {
+	db *gorm.DB
+}
+
+func ProvidePaymentPolicyRepository(db gorm.DB) PaymentPolicyRepository {
+	return &PgPaymentPolicyRepository{&db}
+}
+
+func (m *PgPaymentPolicyRepository) SearchPaymentPolicies(organizationID uuid.UUID) ([]payment.PaymentPolicy, error) {
+	var policies []payment.PaymentPolicy
+	result := m.db.Where("organization_id = ?", organizationID.String()).Order("above_amount").Find(&policies)
+	if result.Error != nil {
+		return nil, dbError(result.Error)
+	}
+	return policies, nil
+}
+
+func (m *PgPaymentPolicyRepository) ReplacePaymentPolicies(organizationID uuid.UUID, policies []payment.PaymentPolicy) error {
+	err := m.db.Transaction(func(tx *gorm.DB) error {
+		if result := tx.Where("organization_id = ?", organizationID.String()).Delete(&payment.PaymentPolicy{}); result.Error != nil {
+			return result.Error
+		}
+		if len(policies) == 0 {
+			return nil
+		}
+		return tx.Create(policies).Error
+	})
+	return dbError(err)
+}
@@ -0,0 +1,106 @@
+package repository
+
+import (
+	"github.com/google/uuid"
+	"github.com/raychongtk/wallet/model/payment"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"time"
+)
+
+type PaymentRequestRepository interface {
+	CreatePaymentRequest(db *gorm.DB, request *payment.PaymentRequest) error
+	GetPaymentRequest(id uuid.UUID) (*payment.PaymentRequest, error)
+	LockPaymentRequest(db *gorm.DB, id uuid.UUID) (*payment.PaymentRequest, error)
+	UpdatePaymentRequest(db *gorm.DB, request *payment.PaymentRequest) error
+	SearchPaymentRequests(filter PaymentRequestFilter, limit int) ([]payment.PaymentRequest, error)
+	SearchExpiredPaymentRequests(now time.Time, limit int) ([]payment.PaymentRequest, error)
+	CreatePaymentRequestEvent(db *gorm.DB, event *payment.PaymentRequestEvent) error
+	SearchPaymentRequestEvents(id uuid.UUID) ([]payment.PaymentRequestEvent, error)
+}
+
+// PaymentRequestFilter matches on every field that is not empty
+type PaymentRequestFilter struct {
+	RequesterUserID uuid.UUID
+	PayerUserID     uuid.UUID
+	Status          string
+}
+
+type PgPaymentRequestRepository struct {
+	db *gorm.DB
+}
+
+func ProvidePaymentRequestRepository(db gorm.DB) PaymentRequestRepository {
+	return &PgPaymentRequestRepository{&db}
+}
+
+func (m *PgPaymentRequestRepository) CreatePaymentRequest(db *gorm.DB, request *payment.PaymentRequest) error {
+	return dbError(db.Create(request).Error)
+}
+
+func (m *PgPaymentRequestRepository) GetPaymentRequest(id uuid.UUID) (*payment.PaymentRequest, error) {
+	var request payment.PaymentRequest
+	result := m.db.First(&request, "id = ?", id.String())
+	if result.Error != nil {
+		return nil, dbError(result.Error)
+	}
+	return &request, nil
+}
+
+// LockPaymentRequest reads a request and holds it until the transaction ends, so its transitions happen one at a time
+func (m *PgPaymentRequestRepository) LockPaymentRequest(db *gorm.DB, id uuid.UUID) (*payment.PaymentRequest, error) {
+	var request payment.PaymentRequest
+	result := db.Clauses(clause.Locking{Strength: "UPDATE"}).First(&request, "id = ?", id.String())
+	if result.Error != nil {
+		return nil, dbError(result.Error)
+	}
+	return &request, nil
+}
+
+func (m *PgPaymentRequestRepository) UpdatePaymentRequest(db *gorm.DB, request *payment.PaymentRequest) error {
+	request.UpdatedAt = time.Now()
+	return dbError(db.Save(request).Error)
+}
+
+// SearchPaymentRequests returns the newest requests first
+func (m *PgPaymentRequestRepository) SearchPaymentRequests(filter PaymentRequestFilter, limit int) ([]payment.PaymentRequest, error) {
+	var requests []payment.PaymentRequest
+	query := m.db
+	if filter.RequesterUserID != uuid.Nil {
+		query = query.Where("requester_user_id = ?", filter.RequesterUserID.String())
+	}
+	if filter.PayerUserID != uuid.Nil {
+		query = query.Where("payer_user_id = ?", filter.PayerUserID.String())
+	}
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
+	}
+	result := query.Order("created_at desc").Limit(limit).Find(&requests)
+	if result.Error != nil {
+		return nil, dbError(result.Error)
+	}
+	return requests, nil
+}
+
+// SearchExpiredPaymentRequests finds pending requests whose expiry has passed, oldest first
+func (m *PgPaymentRequestRepository) SearchExpiredPaymentRequests(now time.Time, limit int) ([]payment.PaymentRequest, error) {
+	var requests []payment.PaymentRequest
+	result := m.db.Where("status = ? AND expires_at <= ?", payment.RequestPending, now).Order("expires_at").Limit(limit).Find(&requests)
+	if result.Error != nil {
+		return nil, dbError(result.Error)
+	}
+	return requests, nil
+}
+
+func (m *PgPaymentRequestRepository) CreatePaymentRequestEvent(db *gorm.DB, event *payment.PaymentRequestEvent) error {
+	return dbError(db.Create(event).Error)
+}
+
+func (m *PgPaymentRequestRepository) SearchPaymentRequestEvents(id uuid.UUID) ([]payment.PaymentRequestEvent, error) {
+	var events []payment.PaymentRequestEvent
+	result := m.db.Where("payment_request_id = ?", id.String()).Order("id").Find(&events)
+	if result.Error != nil {
+		return nil, dbError(result.Error)
+	}
+	return events, nil
+}
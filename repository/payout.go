@@ -0,0 +1,126 @@
+package repository
+
+import (
+	"github.com/google/uuid"
+	"github.com/raychongtk/wallet/domain"
+	"github.com/raychongtk/wallet/model/payout"
+	"gorm.io/gorm"
+	"time"
+)
+
+const lineInsertSize = 500
+
+type PayoutRepository interface {
+	CreateBatch(db *gorm.DB, batch *payout.Batch, lines []payout.Line) error
+	GetBatch(id uuid.UUID) (*payout.Batch, error)
+	GetBatchByRequestID(requestID string) (*payout.Batch, error)
+	SearchLines(batchID uuid.UUID, status string, afterLineNo int, limit int) ([]payout.Line, error)
+	ClaimBatch(now time.Time, lease time.Duration) (*payout.Batch, error)
+	UpdateBatch(db *gorm.DB, batch *payout.Batch) error
+	UpdateLines(db *gorm.DB, lines []payout.Line) error
+}
+
+type PgPayoutRepository struct {
+	db *gorm.DB
+}
+
+func ProvidePayoutRepository(db gorm.DB) PayoutRepository {
+	return &PgPayoutRepository{&db}
+}
+
+// CreateBatch stores a batch with its lines, a request id that was used for another batch is a duplicate request
+func (m *PgPayoutRepository) CreateBatch(db *gorm.DB, batch *payout.Batch, lines []payout.Line) error {
+	if result := db.Create(batch); result.Error != nil {
+		if isUniqueViolation(result.Error) {
+			return domain.ErrDuplicateRequest.Wrap(result.Error)
+		}
+		return dbError(result.Error)
+	}
+	if result := db.CreateInBatches(lines, lineInsertSize); result.Error != nil {
+		return dbError(result.Error)
+	}
+	return nil
+}
+
+func (m *PgPayoutRepository) GetBatch(id uuid.UUID) (*payout.Batch, error) {
+	var batch payout.Batch
+	result := m.db.First(&batch, "id = ?", id.String())
+	if result.Error != nil {
+		return nil, dbError(result.Error)
+	}
+	return &batch, nil
+}
+
+// GetBatchByRequestID finds a batch by its idempotency key, for clients that lost the response of a submit
+func (m *PgPayoutRepository) GetBatchByRequestID(requestID string) (*payout.Batch, error) {
+	var batch payout.Batch
+	result := m.db.First(&batch, "request_id = ?", requestID)
+	if result.Error != nil {
+		return nil, dbError(result.Error)
+	}
+	return &batch, nil
+}
+
+// SearchLines pages through the lines of a batch in line order, an empty status matches every line
+func (m *PgPayoutRepository) SearchLines(batchID uuid.UUID, status string, afterLineNo int, limit int) ([]payout.Line, error) {
+	var lines []payout.Line
+	query := m.db.Where("batch_id = ? AND line_no > ?", batchID.String(), afterLineNo)
+	if status != "" {
+		query = query.Where("line_status = ?", status)
+	}
+	result := query.Order("line_no").Limit(limit).Find(&lines)
+	if result.Error != nil {
+		return nil, dbError(result.Error)
+	}
+	return lines, nil
+}
+
+// ClaimBatch leases the oldest unfinished batch nobody else holds, so each batch is run by one instance at a time. It
+// returns nil when there is nothing to run.
+func (m *PgPayoutRepository) ClaimBatch(now time.Time, lease time.Duration) (*payout.Batch, error) {
+	var batches []payout.Batch
+	result := m.db.Raw(`UPDATE payout_batch SET locked_until = ?, updated_at = ?
+		WHERE id = (
+			SELECT id FROM payout_batch
+			WHERE batch_status IN ? AND (locked_until IS NULL OR locked_until < ?)
+			ORDER BY created_at
+			LIMIT 1
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING *`,
+		now.Add(lease), now, []string{payout.BatchPending, payout.BatchRunning}, now,
+	).Scan(&batches)
+	if result.Error != nil {
+		return nil, dbError(result.Error)
+	}
+	if len(batches) == 0 {
+		return nil, nil
+	}
+	return &batches[0], nil
+}
+
+func (m *PgPayoutRepository) UpdateBatch(db *gorm.DB, batch *payout.Batch) error {
+	batch.UpdatedAt = time.Now()
+	result := db.Save(batch)
+	if result.Error != nil {
+		return dbError(result.Error)
+	}
+	return nil
+}
+
+func (m *PgPayoutRepository) UpdateLines(db *gorm.DB, lines []payout.Line) error {
+	now := time.Now()
+	for i := range lines {
+		lines[i].UpdatedAt = now
+		result := db.Model(&payout.Line{}).Where("id = ?", lines[i].ID.String()).Updates(map[string]interface{}{
+			"line_status": lines[i].LineStatus,
+			"group_id":    lines[i].GroupID,
+			"error_code":  lines[i].ErrorCode,
+			"updated_at":  now,
+		})
+		if result.Error != nil {
+			return dbError(result.Error)
+		}
+	}
+	return nil
+}
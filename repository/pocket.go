@@ -0,0 +1,68 @@
+package repository
+
+import (
+	"github.com/google/uuid"
+	"github.com/raychongtk/wallet/domain"
+	"github.com/raychongtk/wallet/model/wallet"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type PocketRepository interface {
+	// CreatePocket stores a pocket with its wallet and the balances of the wallet, a name the user has a pocket
+	// under already is invalid
+	CreatePocket(db *gorm.DB, pocket *wallet.Pocket, pocketWallet *wallet.Wallet) error
+	GetPocket(id uuid.UUID) (*wallet.Pocket, error)
+	LockPocket(db *gorm.DB, id uuid.UUID) (*wallet.Pocket, error)
+	SearchPockets(userID uuid.UUID) ([]wallet.Pocket, error)
+}
+
+type PgPocketRepository struct {
+	db *gorm.DB
+}
+
+func ProvidePocketRepository(db gorm.DB) PocketRepository {
+	return &PgPocketRepository{&db}
+}
+
+func (m *PgPocketRepository) CreatePocket(db *gorm.DB, pocket *wallet.Pocket, pocketWallet *wallet.Wallet) error {
+	if err := createWallet(db, pocketWallet); err != nil {
+		return err
+	}
+	if result := db.Create(pocket); result.Error != nil {
+		if isUniqueViolation(result.Error) {
+			return domain.ErrInvalidParameters.WithMessage("a pocket named %s exists already", pocket.Name)
+		}
+		return dbError(result.Error)
+	}
+	return nil
+}
+
+func (m *PgPocketRepository) GetPocket(id uuid.UUID) (*wallet.Pocket, error) {
+	var pocket wallet.Pocket
+	result := m.db.First(&pocket, "id = ?", id.String())
+	if result.Error != nil {
+		return nil, dbError(result.Error)
+	}
+	return &pocket, nil
+}
+
+// LockPocket reads a pocket and keeps it locked until the transaction ends
+func (m *PgPocketRepository) LockPocket(db *gorm.DB, id uuid.UUID) (*wallet.Pocket, error) {
+	var pocket wallet.Pocket
+	result := db.Clauses(clause.Locking{Strength: clause.LockingStrengthUpdate}).First(&pocket, "id = ?", id.String())
+	if result.Error != nil {
+		return nil, dbError(result.Error)
+	}
+	return &pocket, nil
+}
+
+// SearchPockets returns the pockets of a user in the order they were created
+func (m *PgPocketRepository) SearchPockets(userID uuid.UUID) ([]wallet.Pocket, error) {
+	var pockets []wallet.Pocket
+	result := m.db.Where("user_id = ?", userID.String()).Order("created_at").Find(&pockets)
+	if result.Error != nil {
+		return nil, dbError(result.Error)
+	}
+	return pockets, nil
+}
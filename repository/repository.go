@@ -1,6 +1,9 @@
 package repository
 
-import "github.com/google/wire"
+import (
+	"github.com/google/wire"
+	"gorm.io/gorm"
+)
 
 var (
 	WireSet = wire.NewSet(
@@ -11,5 +14,34 @@ var (
 		ProvideTransactionRepository,
 		ProvideBalanceRepository,
 		ProvidePaymentHistoryRepository,
+		ProvideArchiveManifestRepository,
+		ProvideLedgerHashRepository,
+		ProvideAuditLogRepository,
+		ProvidePayoutRepository,
+		ProvideScheduledTransferRepository,
+		ProvideNotificationRepository,
+		ProvidePaymentRequestRepository,
+		ProvideHoldRepository,
+		ProvideEscrowRepository,
+		ProvideInterestRepository,
+		ProvidePocketRepository,
+		ProvideMemberRepository,
+		ProvideSharedTransferRepository,
+		ProvideOrganizationRepository,
+		ProvideBusinessMemberRepository,
+		ProvidePaymentPolicyRepository,
+		ProvideBusinessTransferRepository,
 	)
 )
+
+// byTrace filters rows on the trace_id and request_id columns, an empty id is not filtered on
+func byTrace(db *gorm.DB, traceID string, requestID string) *gorm.DB {
+	query := db
+	if traceID != "" {
+		query = query.Where("trace_id = ?", traceID)
+	}
+	if requestID != "" {
+		query = query.Where("request_id = ?", requestID)
+	}
+	return query
+}
@@ -0,0 +1,118 @@
+package repository
+
+import (
+	"github.com/google/uuid"
+	"github.com/raychongtk/wallet/model/schedule"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"time"
+)
+
+type ScheduledTransferRepository interface {
+	CreateSchedule(schedule *schedule.ScheduledTransfer) error
+	GetSchedule(id uuid.UUID) (*schedule.ScheduledTransfer, error)
+	SearchSchedules(payerUserID uuid.UUID) ([]schedule.ScheduledTransfer, error)
+	UpdateSchedule(db *gorm.DB, schedule *schedule.ScheduledTransfer) error
+	LockDueSchedule(db *gorm.DB, now time.Time) (*schedule.ScheduledTransfer, error)
+	CreateRun(db *gorm.DB, run *schedule.Run) error
+	ClaimRun(now time.Time, lease time.Duration) (*schedule.Run, error)
+	UpdateRun(run *schedule.Run) error
+	SearchRuns(scheduleID uuid.UUID, limit int) ([]schedule.Run, error)
+}
+
+type PgScheduledTransferRepository struct {
+	db *gorm.DB
+}
+
+func ProvideScheduledTransferRepository(db gorm.DB) ScheduledTransferRepository {
+	return &PgScheduledTransferRepository{&db}
+}
+
+func (m *PgScheduledTransferRepository) CreateSchedule(schedule *schedule.ScheduledTransfer) error {
+	return dbError(m.db.Create(schedule).Error)
+}
+
+func (m *PgScheduledTransferRepository) GetSchedule(id uuid.UUID) (*schedule.ScheduledTransfer, error) {
+	var scheduledTransfer schedule.ScheduledTransfer
+	result := m.db.First(&scheduledTransfer, "id = ?", id.String())
+	if result.Error != nil {
+		return nil, dbError(result.Error)
+	}
+	return &scheduledTransfer, nil
+}
+
+func (m *PgScheduledTransferRepository) SearchSchedules(payerUserID uuid.UUID) ([]schedule.ScheduledTransfer, error) {
+	var schedules []schedule.ScheduledTransfer
+	result := m.db.Where("payer_user_id = ?", payerUserID.String()).Order("created_at").Find(&schedules)
+	if result.Error != nil {
+		return nil, dbError(result.Error)
+	}
+	return schedules, nil
+}
+
+func (m *PgScheduledTransferRepository) UpdateSchedule(db *gorm.DB, schedule *schedule.ScheduledTransfer) error {
+	schedule.UpdatedAt = time.Now()
+	return dbError(db.Save(schedule).Error)
+}
+
+// LockDueSchedule locks the active schedule that has been due the longest and that no other transaction holds, it
+// returns nil when nothing is due
+func (m *PgScheduledTransferRepository) LockDueSchedule(db *gorm.DB, now time.Time) (*schedule.ScheduledTransfer, error) {
+	var schedules []schedule.ScheduledTransfer
+	result := db.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+		Where("status = ? AND next_run_at <= ?", schedule.StatusActive, now).
+		Order("next_run_at").
+		Limit(1).
+		Find(&schedules)
+	if result.Error != nil {
+		return nil, dbError(result.Error)
+	}
+	if len(schedules) == 0 {
+		return nil, nil
+	}
+	return &schedules[0], nil
+}
+
+// CreateRun stores an occurrence unless it exists already
+func (m *PgScheduledTransferRepository) CreateRun(db *gorm.DB, run *schedule.Run) error {
+	return dbError(db.Clauses(clause.OnConflict{DoNothing: true}).Create(run).Error)
+}
+
+// ClaimRun leases the occurrence that has been due the longest and nobody else holds, so each occurrence is run by
+// one instance at a time. It returns nil when there is nothing to run.
+func (m *PgScheduledTransferRepository) ClaimRun(now time.Time, lease time.Duration) (*schedule.Run, error) {
+	var runs []schedule.Run
+	result := m.db.Raw(`UPDATE scheduled_transfer_run SET locked_until = ?, updated_at = ?
+		WHERE id = (
+			SELECT id FROM scheduled_transfer_run
+			WHERE run_status IN ? AND next_attempt_at <= ? AND (locked_until IS NULL OR locked_until < ?)
+			ORDER BY next_attempt_at
+			LIMIT 1
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING *`,
+		now.Add(lease), now, []string{schedule.RunPending, schedule.RunRetrying}, now, now,
+	).Scan(&runs)
+	if result.Error != nil {
+		return nil, dbError(result.Error)
+	}
+	if len(runs) == 0 {
+		return nil, nil
+	}
+	return &runs[0], nil
+}
+
+func (m *PgScheduledTransferRepository) UpdateRun(run *schedule.Run) error {
+	run.UpdatedAt = time.Now()
+	return dbError(m.db.Save(run).Error)
+}
+
+// SearchRuns returns the latest occurrences of a schedule first
+func (m *PgScheduledTransferRepository) SearchRuns(scheduleID uuid.UUID, limit int) ([]schedule.Run, error) {
+	var runs []schedule.Run
+	result := m.db.Where("schedule_id = ?", scheduleID.String()).Order("scheduled_for desc").Limit(limit).Find(&runs)
+	if result.Error != nil {
+		return nil, dbError(result.Error)
+	}
+	return runs, nil
+}
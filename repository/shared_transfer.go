@@ -0,0 +1,84 @@
+package repository
+
+import (
+	"github.com/google/uuid"
+	"github.com/raychongtk/wallet/domain"
+	"github.com/raychongtk/wallet/model/payment"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"time"
+)
+
+type SharedTransferRepository interface {
+	CreateSharedTransfer(db *gorm.DB, transfer *payment.SharedTransfer) error
+	GetSharedTransfer(id uuid.UUID) (*payment.SharedTransfer, error)
+	GetSharedTransferByRequestID(requestID string) (*payment.SharedTransfer, error)
+	LockSharedTransfer(db *gorm.DB, id uuid.UUID) (*payment.SharedTransfer, error)
+	UpdateSharedTransfer(db *gorm.DB, transfer *payment.SharedTransfer) error
+	// SearchSharedTransfers returns the latest transfers out of an account first, of one status unless it is empty
+	SearchSharedTransfers(accountID uuid.UUID, status string, limit int) ([]payment.SharedTransfer, error)
+}
+
+type PgSharedTransferRepository struct {
+	db *gorm.DB
+}
+
+func ProvideSharedTransferRepository(db gorm.DB) SharedTransferRepository {
+	return &PgSharedTransferRepository{&db}
+}
+
+// CreateSharedTransfer stores a transfer, a request id that was used for another transfer is a duplicate request
+func (m *PgSharedTransferRepository) CreateSharedTransfer(db *gorm.DB, transfer *payment.SharedTransfer) error {
+	result := db.Create(transfer)
+	if isUniqueViolation(result.Error) {
+		return domain.ErrDuplicateRequest.Wrap(result.Error)
+	}
+	return dbError(result.Error)
+}
+
+func (m *PgSharedTransferRepository) GetSharedTransfer(id uuid.UUID) (*payment.SharedTransfer, error) {
+	var transfer payment.SharedTransfer
+	result := m.db.First(&transfer, "id = ?", id.String())
+	if result.Error != nil {
+		return nil, dbError(result.Error)
+	}
+	return &transfer, nil
+}
+
+// GetSharedTransferByRequestID finds a transfer by its idempotency key, for members retrying a transfer
+func (m *PgSharedTransferRepository) GetSharedTransferByRequestID(requestID string) (*payment.SharedTransfer, error) {
+	var transfer payment.SharedTransfer
+	result := m.db.First(&transfer, "request_id = ?", requestID)
+	if result.Error != nil {
+		return nil, dbError(result.Error)
+	}
+	return &transfer, nil
+}
+
+// LockSharedTransfer reads a transfer and holds it until the transaction ends, so it is approved or declined once
+func (m *PgSharedTransferRepository) LockSharedTransfer(db *gorm.DB, id uuid.UUID) (*payment.SharedTransfer, error) {
+	var transfer payment.SharedTransfer
+	result := db.Clauses(clause.Locking{Strength: clause.LockingStrengthUpdate}).First(&transfer, "id = ?", id.String())
+	if result.Error != nil {
+		return nil, dbError(result.Error)
+	}
+	return &transfer, nil
+}
+
+func (m *PgSharedTransferRepository) UpdateSharedTransfer(db *gorm.DB, transfer *payment.SharedTransfer) error {
+	transfer.UpdatedAt = time.Now()
+	return dbError(db.Save(transfer).Error)
+}
+
+func (m *PgSharedTransferRepository) SearchSharedTransfers(accountID uuid.UUID, status string, limit int) ([]payment.SharedTransfer, error) {
+	var transfers []payment.SharedTransfer
+	query := m.db.Where("account_id = ?", accountID.String())
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+	result := query.Order("created_at desc").Limit(limit).Find(&transfers)
+	if result.Error != nil {
+		return nil, dbError(result.Error)
+	}
+	return transfers, nil
+}
@@ -1,26 +1,90 @@
 package repository
 
 import (
+	"errors"
+	"github.com/google/uuid"
+	"github.com/raychongtk/wallet/datastore"
 	"github.com/raychongtk/wallet/model/movement"
 	"gorm.io/gorm"
+	"time"
 )
 
 type TransactionRepository interface {
 	CreateTransactions(db *gorm.DB, transaction []movement.Transaction) error
+	GetOldestTransaction() (*movement.Transaction, error)
+	SearchTransactionsCreatedBetween(from time.Time, to time.Time) ([]movement.Transaction, error)
+	SumBalance(walletID uuid.UUID, balanceType string, asOf time.Time) (int, error)
+	DeleteTransactions(db *gorm.DB, ids []uuid.UUID) (int64, error)
+	SearchTransactionsByMovementIDs(movementIDs []uuid.UUID) ([]movement.Transaction, error)
 }
 
 type PgTransactionRepository struct {
-	db *gorm.DB
+	db     *gorm.DB
+	router *datastore.ReplicaRouter
 }
 
-func ProvideTransactionRepository(db gorm.DB) TransactionRepository {
-	return &PgTransactionRepository{&db}
+func ProvideTransactionRepository(db gorm.DB, router *datastore.ReplicaRouter) TransactionRepository {
+	return &PgTransactionRepository{&db, router}
 }
 
 func (m *PgTransactionRepository) CreateTransactions(db *gorm.DB, transactions []movement.Transaction) error {
 	result := db.Create(transactions)
 	if result.Error != nil {
-		return result.Error
+		return dbError(result.Error)
 	}
 	return nil
 }
+
+func (m *PgTransactionRepository) GetOldestTransaction() (*movement.Transaction, error) {
+	var oldest movement.Transaction
+	result := m.db.Order("created_at").First(&oldest)
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if result.Error != nil {
+		return nil, dbError(result.Error)
+	}
+	return &oldest, nil
+}
+
+func (m *PgTransactionRepository) SearchTransactionsCreatedBetween(from time.Time, to time.Time) ([]movement.Transaction, error) {
+	var transactions []movement.Transaction
+	result := m.db.Where("created_at >= ? AND created_at < ?", from, to).Order("created_at").Find(&transactions)
+	if result.Error != nil {
+		return nil, dbError(result.Error)
+	}
+	return transactions, nil
+}
+
+// SumBalance replays the transactions of a wallet up to asOf, which gives the balance at that point in time
+func (m *PgTransactionRepository) SumBalance(walletID uuid.UUID, balanceType string, asOf time.Time) (int, error) {
+	var sum int
+	result := m.router.Reader(walletID.String()).Model(&movement.Transaction{}).
+		Select("COALESCE(SUM(balance), 0)").
+		Where("wallet_id = ? AND balance_type = ? AND created_at <= ?", walletID.String(), balanceType, asOf).
+		Scan(&sum)
+	if result.Error != nil {
+		return 0, dbError(result.Error)
+	}
+	return sum, nil
+}
+
+func (m *PgTransactionRepository) DeleteTransactions(db *gorm.DB, ids []uuid.UUID) (int64, error) {
+	result := db.Where("id IN ?", ids).Delete(&movement.Transaction{})
+	if result.Error != nil {
+		return 0, dbError(result.Error)
+	}
+	return result.RowsAffected, nil
+}
+
+func (m *PgTransactionRepository) SearchTransactionsByMovementIDs(movementIDs []uuid.UUID) ([]movement.Transaction, error) {
+	var transactions []movement.Transaction
+	if len(movementIDs) == 0 {
+		return transactions, nil
+	}
+	result := m.db.Where("movement_id IN ?", movementIDs).Order("created_at").Find(&transactions)
+	if result.Error != nil {
+		return nil, dbError(result.Error)
+	}
+	return transactions, nil
+}
@@ -1,8 +1,8 @@
 package repository
 
 import (
-	"errors"
 	"github.com/google/uuid"
+	"github.com/raychongtk/wallet/domain"
 	"github.com/raychongtk/wallet/model/user"
 	"gorm.io/gorm"
 )
@@ -31,10 +31,10 @@ func (m *PgUserRepository) find(id uuid.UUID) (*user.AppUser, error) {
 	var appUser user.AppUser
 	result := m.db.Where("id = ?", id.String()).Find(&appUser)
 	if result.Error != nil {
-		panic(result.Error)
+		return nil, dbError(result.Error)
 	}
 	if result.RowsAffected == 0 {
-		return nil, errors.New("user not found")
+		return nil, domain.ErrNotFound.WithMessage("user %s not found", id.String())
 	}
 
 	return &appUser, nil
@@ -1,14 +1,17 @@
 package repository
 
 import (
-	"errors"
 	"github.com/google/uuid"
+	"github.com/raychongtk/wallet/domain"
 	"github.com/raychongtk/wallet/model/wallet"
 	"gorm.io/gorm"
+	"time"
 )
 
 type WalletRepository interface {
+	// GetWallet is the main wallet of an account
 	GetWallet(accountId uuid.UUID) (*wallet.Wallet, error)
+	UpdateWalletStatus(walletID uuid.UUID, status string) error
 }
 
 type PgWalletRepository struct {
@@ -27,14 +30,28 @@ func (m *PgWalletRepository) GetWallet(accountId uuid.UUID) (*wallet.Wallet, err
 	return appUser, nil
 }
 
+func (m *PgWalletRepository) UpdateWalletStatus(walletID uuid.UUID, status string) error {
+	result := m.db.Model(&wallet.Wallet{}).Where("id = ?", walletID.String()).Updates(map[string]interface{}{
+		"wallet_status": status,
+		"updated_at":    time.Now(),
+	})
+	if result.Error != nil {
+		return dbError(result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return domain.ErrNotFound.WithMessage("wallet %s not found", walletID.String())
+	}
+	return nil
+}
+
 func (m *PgWalletRepository) find(accountId uuid.UUID) (*wallet.Wallet, error) {
 	var appWallet wallet.Wallet
-	result := m.db.Where("account_id = ?", accountId.String()).Find(&appWallet)
+	result := m.db.Where("account_id = ? AND kind = ?", accountId.String(), wallet.KindMain).Find(&appWallet)
 	if result.Error != nil {
-		panic(result.Error)
+		return nil, dbError(result.Error)
 	}
 	if result.RowsAffected == 0 {
-		return nil, errors.New("account not found")
+		return nil, domain.ErrNotFound.WithMessage("wallet of account %s not found", accountId.String())
 	}
 
 	return &appWallet, nil
@@ -0,0 +1,142 @@
+package rpc
+
+import (
+	"context"
+	"crypto/subtle"
+	"fmt"
+	"github.com/raychongtk/wallet/domain"
+	"github.com/raychongtk/wallet/problem"
+	"github.com/raychongtk/wallet/tracing"
+	"github.com/raychongtk/wallet/util"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"strings"
+	"time"
+)
+
+const (
+	authorizationKey = "authorization"
+	// CallerKey names the calling service in metadata, it is the actor of the audit logs
+	CallerKey     = "x-caller"
+	unknownCaller = "unknown"
+)
+
+type callerContextKey struct{}
+
+// caller is the service that made the call, as named by its x-caller metadata
+func caller(ctx context.Context) string {
+	if name, ok := ctx.Value(callerContextKey{}).(string); ok {
+		return name
+	}
+	return unknownCaller
+}
+
+// authenticate checks the bearer token in the authorization metadata. An empty token rejects every call so a server
+// that was not configured fails closed.
+func authenticate(ctx context.Context, token string) (context.Context, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	presented := strings.TrimPrefix(first(md, authorizationKey), "Bearer ")
+	if token == "" || subtle.ConstantTimeCompare([]byte(presented), []byte(token)) != 1 {
+		return nil, status.Error(codes.Unauthenticated, "missing or invalid bearer token")
+	}
+	name := first(md, CallerKey)
+	if name == "" {
+		name = unknownCaller
+	}
+	return context.WithValue(ctx, callerContextKey{}, name), nil
+}
+
+func authenticateUnary(token string) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		ctx, err := authenticate(ctx, token)
+		if err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+func authenticateStream(token string) grpc.StreamServerInterceptor {
+	return func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, err := authenticate(stream.Context(), token)
+		if err != nil {
+			return err
+		}
+		return handler(srv, &contextStream{ServerStream: stream, ctx: ctx})
+	}
+}
+
+// contextStream replaces the context of a stream so handlers see what the interceptors added
+type contextStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *contextStream) Context() context.Context {
+	return s.ctx
+}
+
+func logUnary(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	start := time.Now()
+	resp, err := handler(ctx, req)
+	logCall(ctx, info.FullMethod, start, err)
+	return resp, err
+}
+
+func logStream(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	start := time.Now()
+	err := handler(srv, stream)
+	logCall(stream.Context(), info.FullMethod, start, err)
+	return err
+}
+
+func logCall(ctx context.Context, method string, start time.Time, err error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	fields := []zap.Field{
+		zap.String("method", method),
+		zap.String("code", status.Code(err).String()),
+		zap.Duration("duration", time.Since(start)),
+		zap.String("caller", first(md, CallerKey)),
+		zap.String("request_id", tracing.RequestID(ctx)),
+		zap.String("trace_id", tracing.TraceID(ctx)),
+	}
+	if err != nil {
+		util.Error("gRPC call failed", append(fields, zap.Error(err))...)
+		return
+	}
+	util.Info("gRPC call", fields...)
+}
+
+// recoverUnary turns a panic into an internal error so one bad call does not take the server down
+func recoverUnary(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp interface{}, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = panicked(ctx, info.FullMethod, r)
+		}
+	}()
+	return handler(ctx, req)
+}
+
+func recoverStream(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = panicked(stream.Context(), info.FullMethod, r)
+		}
+	}()
+	return handler(srv, stream)
+}
+
+func panicked(ctx context.Context, method string, r interface{}) error {
+	util.Error("gRPC call panicked", zap.String("method", method), zap.Any("panic", r))
+	return problem.GRPCStatus(domain.ErrInternal.Wrap(fmt.Errorf("panic: %v", r)), tracing.RequestID(ctx)).Err()
+}
+
+func first(md metadata.MD, key string) string {
+	if values := md.Get(key); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
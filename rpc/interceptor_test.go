@@ -0,0 +1,106 @@
+package rpc
+
+import (
+	"context"
+	"github.com/raychongtk/wallet/problem"
+	ledgerv1 "github.com/raychongtk/wallet/proto/ledger/v1"
+	"github.com/raychongtk/wallet/util"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"io"
+	"net"
+	"testing"
+)
+
+// stubLedger answers without a database and remembers who called
+type stubLedger struct {
+	ledgerv1.UnimplementedLedgerServiceServer
+	callers []string
+}
+
+func (s *stubLedger) GetBalance(ctx context.Context, req *ledgerv1.GetBalanceRequest) (*ledgerv1.GetBalanceResponse, error) {
+	s.callers = append(s.callers, caller(ctx))
+	if req.GetUserId() == "panic" {
+		panic("stub ledger panicked")
+	}
+	return &ledgerv1.GetBalanceResponse{CustomerId: req.GetUserId(), Balance: "1.00"}, nil
+}
+
+func (s *stubLedger) ListPaymentHistory(req *ledgerv1.ListPaymentHistoryRequest, stream ledgerv1.LedgerService_ListPaymentHistoryServer) error {
+	s.callers = append(s.callers, caller(stream.Context()))
+	for _, payType := range []string{"DEPOSIT", "TRANSFER"} {
+		if err := stream.Send(&ledgerv1.PaymentHistory{PayType: payType}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func newTestClient(t *testing.T) (ledgerv1.LedgerServiceClient, *stubLedger) {
+	util.InitializeLogger(false)
+	stub := &stubLedger{}
+	listener := bufconn.Listen(1 << 20)
+	server := NewGRPCServer(stub, "secret-token")
+	go server.Serve(listener)
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	assert.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+	return ledgerv1.NewLedgerServiceClient(conn), stub
+}
+
+func TestCallsNeedTheBearerToken(t *testing.T) {
+	client, stub := newTestClient(t)
+
+	_, err := client.GetBalance(context.Background(), &ledgerv1.GetBalanceRequest{UserId: "john"})
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+	ctx := metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer wrong-token")
+	_, err = client.GetBalance(ctx, &ledgerv1.GetBalanceRequest{UserId: "john"})
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+	assert.Empty(t, stub.callers)
+
+	ctx = metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer secret-token", CallerKey, "payment-orchestrator")
+	resp, err := client.GetBalance(ctx, &ledgerv1.GetBalanceRequest{UserId: "john"})
+	assert.NoError(t, err)
+	assert.Equal(t, "1.00", resp.GetBalance())
+
+	stream, err := client.ListPaymentHistory(ctx, &ledgerv1.ListPaymentHistoryRequest{UserId: "john"})
+	assert.NoError(t, err)
+	var payTypes []string
+	for {
+		history, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		assert.NoError(t, err)
+		payTypes = append(payTypes, history.GetPayType())
+	}
+	assert.Equal(t, []string{"DEPOSIT", "TRANSFER"}, payTypes)
+	assert.Equal(t, []string{"payment-orchestrator", "payment-orchestrator"}, stub.callers)
+}
+
+func TestPanicsBecomeInternalErrors(t *testing.T) {
+	client, _ := newTestClient(t)
+
+	ctx := metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer secret-token", "x-request-id", "request-1")
+	_, err := client.GetBalance(ctx, &ledgerv1.GetBalanceRequest{UserId: "panic"})
+	st := status.Convert(err)
+	assert.Equal(t, codes.Internal, st.Code())
+	assert.Equal(t, "INTERNAL_ERROR", problem.ErrorCode(st))
+	assert.NotContains(t, st.Message(), "stub ledger panicked")
+
+	// the server keeps serving after a panic
+	_, err = client.GetBalance(ctx, &ledgerv1.GetBalanceRequest{UserId: "john"})
+	assert.NoError(t, err)
+}
@@ -0,0 +1,143 @@
+package rpc
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"github.com/raychongtk/wallet/audit"
+	"github.com/raychongtk/wallet/domain"
+	"github.com/raychongtk/wallet/idempotency"
+	"github.com/raychongtk/wallet/ledger"
+	"github.com/raychongtk/wallet/metrics"
+	"github.com/raychongtk/wallet/problem"
+	ledgerv1 "github.com/raychongtk/wallet/proto/ledger/v1"
+	"github.com/raychongtk/wallet/tracing"
+	"github.com/raychongtk/wallet/util"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/peer"
+	"net/http"
+	"time"
+)
+
+func (s *Server) Deposit(ctx context.Context, req *ledgerv1.DepositRequest) (*ledgerv1.MovementResponse, error) {
+	return s.move(ctx, ledger.OperationDeposit, req.GetUserId(), func(requestID string) (*ledger.Result, error) {
+		userID, err := parseUserID(req.GetUserId())
+		if err != nil {
+			return nil, err
+		}
+		amount, err := parseAmount(req.GetAmount())
+		if err != nil {
+			return nil, err
+		}
+		return s.ledger.Deposit(ctx, ledger.DepositCommand{UserID: userID, Amount: amount, RequestID: requestID})
+	})
+}
+
+func (s *Server) Withdraw(ctx context.Context, req *ledgerv1.WithdrawRequest) (*ledgerv1.MovementResponse, error) {
+	return s.move(ctx, ledger.OperationWithdrawal, req.GetUserId(), func(requestID string) (*ledger.Result, error) {
+		userID, err := parseUserID(req.GetUserId())
+		if err != nil {
+			return nil, err
+		}
+		amount, err := parseAmount(req.GetAmount())
+		if err != nil {
+			return nil, err
+		}
+		return s.ledger.Withdraw(ctx, ledger.WithdrawCommand{UserID: userID, Amount: amount, RequestID: requestID})
+	})
+}
+
+func (s *Server) Transfer(ctx context.Context, req *ledgerv1.TransferRequest) (*ledgerv1.MovementResponse, error) {
+	return s.move(ctx, ledger.OperationTransfer, req.GetFromUserId(), func(requestID string) (*ledger.Result, error) {
+		fromUserID, err := parseUserID(req.GetFromUserId())
+		if err != nil {
+			return nil, err
+		}
+		toUserID, err := parseUserID(req.GetToUserId())
+		if err != nil {
+			return nil, err
+		}
+		amount, err := parseAmount(req.GetAmount())
+		if err != nil {
+			return nil, err
+		}
+		return s.ledger.Transfer(ctx, ledger.TransferCommand{FromUserID: fromUserID, ToUserID: toUserID, Amount: amount, RequestID: requestID})
+	})
+}
+
+// move claims the x-request-id of the call, posts the money movement and records its outcome in the metrics and the
+// audit log the same way the REST endpoints do
+func (s *Server) move(ctx context.Context, operation string, userID string, post func(requestID string) (*ledger.Result, error)) (*ledgerv1.MovementResponse, error) {
+	start := time.Now()
+	requestID := tracing.RequestID(ctx)
+	result, err := func() (*ledger.Result, error) {
+		if err := idempotency.Claim(ctx, &s.memoryStore, requestID, s.config.Limits.IdempotencyTTL); err != nil {
+			return nil, err
+		}
+		return post(requestID)
+	}()
+	observe(operation, start, err)
+	s.audit(ctx, operation, userID, requestID, result, err)
+	if err != nil {
+		return nil, problem.GRPCStatus(err, requestID).Err()
+	}
+	return &ledgerv1.MovementResponse{
+		GroupId:  result.GroupID.String(),
+		WalletId: result.WalletID.String(),
+		Balance:  util.DisplayAmount(result.After[result.WalletID]),
+	}, nil
+}
+
+func observe(operation string, start time.Time, err error) {
+	result, errorCode := metrics.ResultSuccess, ""
+	if err != nil {
+		result, errorCode = metrics.ResultFailure, domain.From(err).Code
+	}
+	metrics.OperationTotal.WithLabelValues(operation, result, errorCode).Inc()
+	metrics.OperationDuration.WithLabelValues(operation, result).Observe(time.Since(start).Seconds())
+}
+
+func (s *Server) audit(ctx context.Context, operation string, userID string, requestID string, result *ledger.Result, err error) {
+	entry := audit.Entry{
+		ActorType:  audit.ActorService,
+		ActorID:    caller(ctx),
+		Action:     "wallet." + operation,
+		TargetType: audit.TargetUser,
+		TargetID:   userID,
+		RequestID:  requestID,
+		Outcome:    audit.OutcomeSuccess,
+	}
+	if p, ok := peer.FromContext(ctx); ok {
+		entry.SourceIP = p.Addr.String()
+	}
+	if result != nil {
+		entry.TargetType, entry.TargetID = audit.TargetWallet, result.WalletID.String()
+		entry.Before = map[string]interface{}{"balances": result.Before}
+		entry.After = map[string]interface{}{"balances": result.After, "group_id": result.GroupID}
+	}
+	if err != nil {
+		domainErr := domain.From(err)
+		entry.Outcome, entry.ErrorCode = audit.OutcomeDenied, domainErr.Code
+		if problem.Status(domainErr.Kind) >= http.StatusInternalServerError {
+			entry.Outcome = audit.OutcomeFailure
+		}
+	}
+	if auditErr := s.auditor.Record(context.WithoutCancel(ctx), entry); auditErr != nil {
+		util.Error("Record audit log failed", zap.String("action", entry.Action), zap.String("request_id", requestID), zap.Error(auditErr))
+	}
+}
+
+func parseUserID(value string) (uuid.UUID, error) {
+	userID, err := uuid.Parse(value)
+	if err != nil {
+		return uuid.UUID{}, domain.ErrInvalidAccount.Wrap(err)
+	}
+	return userID, nil
+}
+
+func parseAmount(value string) (int, error) {
+	amount, err := util.ConvertToInt(value)
+	if err != nil {
+		return 0, domain.ErrInvalidParameters.Wrap(err)
+	}
+	return amount, nil
+}
@@ -0,0 +1,86 @@
+package rpc
+
+import (
+	"context"
+	"github.com/raychongtk/wallet/problem"
+	ledgerv1 "github.com/raychongtk/wallet/proto/ledger/v1"
+	"github.com/raychongtk/wallet/util"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func (s *Server) GetBalance(ctx context.Context, req *ledgerv1.GetBalanceRequest) (*ledgerv1.GetBalanceResponse, error) {
+	userID, err := parseUserID(req.GetUserId())
+	if err != nil {
+		return nil, problem.GRPCStatus(err, "").Err()
+	}
+	customer, err := s.ledger.Customer(userID)
+	if err != nil {
+		return nil, problem.GRPCStatus(err, "").Err()
+	}
+
+	var balance int
+	if req.GetAsOf() != nil {
+		// replay hot and archived transactions because the balance table only holds the latest balance
+		asOf := req.GetAsOf().AsTime()
+		hotBalance, err := s.transactionRepo.SumBalance(customer.Wallet.ID, "COMMITTED", asOf)
+		if err != nil {
+			return nil, problem.GRPCStatus(err, "").Err()
+		}
+		archivedBalance, err := s.archiveReader.SumBalance(ctx, customer.Wallet.ID, "COMMITTED", asOf)
+		if err != nil {
+			return nil, problem.GRPCStatus(err, "").Err()
+		}
+		balance = hotBalance + archivedBalance
+	} else {
+		committed, err := s.balanceRepo.GetBalance(customer.Wallet.ID, "COMMITTED")
+		if err != nil {
+			return nil, problem.GRPCStatus(err, "").Err()
+		}
+		balance = committed.Balance
+	}
+	return &ledgerv1.GetBalanceResponse{
+		CustomerId: userID.String(),
+		Currency:   customer.Wallet.Currency,
+		Balance:    util.DisplayAmount(balance),
+	}, nil
+}
+
+// ListPaymentHistory sends the payments one message at a time, so callers can stop reading without loading the whole
+// history
+func (s *Server) ListPaymentHistory(req *ledgerv1.ListPaymentHistoryRequest, stream ledgerv1.LedgerService_ListPaymentHistoryServer) error {
+	ctx := stream.Context()
+	userID, err := parseUserID(req.GetUserId())
+	if err != nil {
+		return problem.GRPCStatus(err, "").Err()
+	}
+	customer, err := s.ledger.Customer(userID)
+	if err != nil {
+		return problem.GRPCStatus(err, "").Err()
+	}
+	histories, err := s.paymentHistoryRepo.SearchPaymentHistory(customer.User.ID.String())
+	if err != nil {
+		return problem.GRPCStatus(err, "").Err()
+	}
+	if req.GetIncludeArchived() {
+		archivedHistories, err := s.archiveReader.PaymentHistories(ctx, customer.User.ID.String())
+		if err != nil {
+			return problem.GRPCStatus(err, "").Err()
+		}
+		histories = append(archivedHistories, histories...)
+	}
+
+	for _, history := range histories {
+		err := stream.Send(&ledgerv1.PaymentHistory{
+			PayerName: history.PayerName,
+			PayeeName: history.PayeeName,
+			PayType:   history.PayType,
+			Amount:    util.DisplayAmount(history.SignedAmount(customer.User.ID.String())),
+			RequestId: history.RequestID,
+			CreatedAt: timestamppb.New(history.CreatedAt),
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
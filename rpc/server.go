@@ -0,0 +1,81 @@
+package rpc
+
+import (
+	"context"
+	"fmt"
+	"github.com/go-redis/redis/v8"
+	"github.com/google/wire"
+	"github.com/raychongtk/wallet/archive"
+	"github.com/raychongtk/wallet/audit"
+	"github.com/raychongtk/wallet/config"
+	"github.com/raychongtk/wallet/ledger"
+	ledgerv1 "github.com/raychongtk/wallet/proto/ledger/v1"
+	"github.com/raychongtk/wallet/repository"
+	"github.com/raychongtk/wallet/secret"
+	"github.com/raychongtk/wallet/tracing"
+	"google.golang.org/grpc"
+)
+
+var (
+	WireSet = wire.NewSet(ProvideServer, ProvideGRPCServer)
+)
+
+// Server is the LedgerService that internal services call over gRPC. It shares the ledger and the repositories with
+// the REST API, so both transports apply the same rules.
+type Server struct {
+	ledgerv1.UnimplementedLedgerServiceServer
+	ledger             *ledger.Ledger
+	balanceRepo        repository.BalanceRepository
+	transactionRepo    repository.TransactionRepository
+	paymentHistoryRepo repository.PaymentHistoryRepository
+	archiveReader      *archive.Reader
+	memoryStore        redis.Client
+	auditor            *audit.Auditor
+	config             *config.Config
+}
+
+func ProvideServer(
+	ledger *ledger.Ledger,
+	balanceRepo repository.BalanceRepository,
+	transactionRepo repository.TransactionRepository,
+	paymentHistoryRepo repository.PaymentHistoryRepository,
+	archiveReader *archive.Reader,
+	memoryStore redis.Client,
+	auditor *audit.Auditor,
+	cfg *config.Config,
+) *Server {
+	return &Server{
+		ledger:             ledger,
+		balanceRepo:        balanceRepo,
+		transactionRepo:    transactionRepo,
+		paymentHistoryRepo: paymentHistoryRepo,
+		archiveReader:      archiveReader,
+		memoryStore:        memoryStore,
+		auditor:            auditor,
+		config:             cfg,
+	}
+}
+
+// ProvideGRPCServer registers the LedgerService behind the tracing, recovery, logging and auth interceptors. The
+// token is only resolved when features.grpc is on, otherwise every call is rejected.
+func ProvideGRPCServer(server *Server, cfg *config.Config, secrets secret.Provider) (*grpc.Server, error) {
+	var token string
+	if cfg.Features.GRPC {
+		var err error
+		token, err = secret.Resolve(context.Background(), secrets, cfg.GRPC.Token)
+		if err != nil {
+			return nil, fmt.Errorf("resolve grpc token: %w", err)
+		}
+	}
+	return NewGRPCServer(server, token), nil
+}
+
+func NewGRPCServer(server ledgerv1.LedgerServiceServer, token string) *grpc.Server {
+	options := append(tracing.GRPCServerOptions(),
+		grpc.ChainUnaryInterceptor(recoverUnary, logUnary, authenticateUnary(token)),
+		grpc.ChainStreamInterceptor(recoverStream, logStream, authenticateStream(token)),
+	)
+	grpcServer := grpc.NewServer(options...)
+	ledgerv1.RegisterLedgerServiceServer(grpcServer, server)
+	return grpcServer
+}
@@ -0,0 +1,180 @@
+package scheduler
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Rule tells when a standing order runs. Next returns the first occurrence strictly after a time, or the zero time
+// when there is none.
+type Rule interface {
+	Next(after time.Time) time.Time
+}
+
+// ParseRule reads a calendar rule or a cron expression. Calendar rules repeat the start of the schedule: daily,
+// weekly or monthly at the same wall clock time, where monthly on the 31st falls on the last day of shorter months.
+// Cron expressions have the five standard fields, minute hour day-of-month month day-of-week, with lists, ranges and
+// steps, and are evaluated in loc.
+func ParseRule(spec string, start time.Time, loc *time.Location) (Rule, error) {
+	spec = strings.TrimSpace(spec)
+	switch strings.ToLower(spec) {
+	case "daily":
+		return calendar{start: start.In(loc), days: 1}, nil
+	case "weekly":
+		return calendar{start: start.In(loc), days: 7}, nil
+	case "monthly":
+		return calendar{start: start.In(loc), months: 1}, nil
+	}
+	return parseCron(spec, loc)
+}
+
+// calendar repeats start every days or every months, counting from start so that clamped days do not drift
+type calendar struct {
+	start  time.Time
+	days   int
+	months int
+}
+
+func (c calendar) Next(after time.Time) time.Time {
+	k := 0
+	if after.After(c.start) {
+		if c.days > 0 {
+			k = int(after.Sub(c.start).Hours()/24)/c.days - 1
+		} else {
+			k = monthsBetween(c.start, after)/c.months - 1
+		}
+	}
+	for k = max(k, 0); ; k++ {
+		if occurrence := c.occurrence(k); occurrence.After(after) {
+			return occurrence
+		}
+	}
+}
+
+func (c calendar) occurrence(k int) time.Time {
+	if c.days > 0 {
+		return c.start.AddDate(0, 0, k*c.days)
+	}
+	first := time.Date(c.start.Year(), c.start.Month()+time.Month(k*c.months), 1, c.start.Hour(), c.start.Minute(), c.start.Second(), 0, c.start.Location())
+	day := min(c.start.Day(), first.AddDate(0, 1, -1).Day())
+	return first.AddDate(0, 0, day-1)
+}
+
+func monthsBetween(from time.Time, to time.Time) int {
+	return (to.Year()-from.Year())*12 + int(to.Month()) - int(from.Month())
+}
+
+// cron matches minutes whose fields are all set, the day matches on either day field when both are restricted
+type cron struct {
+	minute, hour, dom, month, dow uint64
+	domStar, dowStar              bool
+	loc                           *time.Location
+}
+
+// cronSearchYears bounds the search for expressions that never match, such as the 30th of February
+const cronSearchYears = 5
+
+func parseCron(spec string, loc *time.Location) (Rule, error) {
+	fields := strings.Fields(spec)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("rule %q is not daily, weekly, monthly or a cron expression of five fields", spec)
+	}
+	c := cron{loc: loc, domStar: fields[2] == "*", dowStar: fields[4] == "*"}
+	bounds := []struct {
+		name     string
+		min, max int
+		bits     *uint64
+	}{
+		{"minute", 0, 59, &c.minute},
+		{"hour", 0, 23, &c.hour},
+		{"day of month", 1, 31, &c.dom},
+		{"month", 1, 12, &c.month},
+		{"day of week", 0, 7, &c.dow},
+	}
+	for i, b := range bounds {
+		bits, err := parseField(fields[i], b.min, b.max)
+		if err != nil {
+			return nil, fmt.Errorf("%s of rule %q: %w", b.name, spec, err)
+		}
+		*b.bits = bits
+	}
+	// 7 is Sunday as well
+	if c.dow&(1<<7) != 0 {
+		c.dow |= 1
+	}
+	return c, nil
+}
+
+func parseField(field string, low int, high int) (uint64, error) {
+	var bits uint64
+	for _, part := range strings.Split(field, ",") {
+		rangePart, step := part, 1
+		if i := strings.Index(part, "/"); i >= 0 {
+			n, err := strconv.Atoi(part[i+1:])
+			if err != nil || n <= 0 {
+				return 0, fmt.Errorf("bad step %q", part)
+			}
+			rangePart, step = part[:i], n
+		}
+		from, to := low, high
+		if rangePart != "*" {
+			bounds := strings.SplitN(rangePart, "-", 2)
+			var err error
+			if from, err = strconv.Atoi(bounds[0]); err != nil {
+				return 0, fmt.Errorf("bad value %q", part)
+			}
+			to = from
+			if len(bounds) == 2 {
+				if to, err = strconv.Atoi(bounds[1]); err != nil {
+					return 0, fmt.Errorf("bad range %q", part)
+				}
+			} else if step > 1 {
+				to = high
+			}
+		}
+		if from < low || to > high || from > to {
+			return 0, fmt.Errorf("%q is out of %d-%d", part, low, high)
+		}
+		for v := from; v <= to; v += step {
+			bits |= 1 << v
+		}
+	}
+	return bits, nil
+}
+
+// Next moves field by field, resetting the smaller fields whenever a larger one moves
+func (c cron) Next(after time.Time) time.Time {
+	t := after.In(c.loc).Truncate(time.Minute).Add(time.Minute)
+	limit := t.AddDate(cronSearchYears, 0, 0)
+	for t.Before(limit) {
+		if c.month&(1<<int(t.Month())) == 0 {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, c.loc)
+			continue
+		}
+		if !c.dayMatches(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, c.loc)
+			continue
+		}
+		if c.hour&(1<<t.Hour()) == 0 {
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, c.loc)
+			continue
+		}
+		if c.minute&(1<<t.Minute()) == 0 {
+			t = t.Add(time.Minute)
+			continue
+		}
+		return t
+	}
+	return time.Time{}
+}
+
+func (c cron) dayMatches(t time.Time) bool {
+	dom := c.dom&(1<<t.Day()) != 0
+	dow := c.dow&(1<<int(t.Weekday())) != 0
+	if c.domStar || c.dowStar {
+		return dom && dow
+	}
+	return dom || dow
+}
@@ -0,0 +1,69 @@
+package scheduler
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestMonthlyRuleKeepsTheDayOfTheStart(t *testing.T) {
+	start := time.Date(2024, time.January, 31, 9, 0, 0, 0, time.UTC)
+	rule, err := ParseRule("monthly", start, time.UTC)
+	assert.NoError(t, err)
+
+	var occurrences []time.Time
+	for at, i := start.Add(-time.Second), 0; i < 4; i++ {
+		at = rule.Next(at)
+		occurrences = append(occurrences, at)
+	}
+	// the last day of shorter months, and back to the 31st once the month has one
+	assert.Equal(t, []time.Time{
+		time.Date(2024, time.January, 31, 9, 0, 0, 0, time.UTC),
+		time.Date(2024, time.February, 29, 9, 0, 0, 0, time.UTC),
+		time.Date(2024, time.March, 31, 9, 0, 0, 0, time.UTC),
+		time.Date(2024, time.April, 30, 9, 0, 0, 0, time.UTC),
+	}, occurrences)
+	assert.Equal(t, time.Date(2025, time.March, 31, 9, 0, 0, 0, time.UTC), rule.Next(time.Date(2025, time.March, 2, 0, 0, 0, 0, time.UTC)))
+}
+
+func TestWeeklyRuleKeepsTheWallClockAcrossDaylightSaving(t *testing.T) {
+	london, err := time.LoadLocation("Europe/London")
+	if err != nil {
+		t.Skip("no time zone database")
+	}
+	start := time.Date(2024, time.March, 25, 8, 0, 0, 0, london).AddDate(0, 0, -7)
+	rule, err := ParseRule("weekly", start, london)
+	assert.NoError(t, err)
+
+	next := rule.Next(start)
+	assert.Equal(t, time.Date(2024, time.March, 25, 8, 0, 0, 0, london), next)
+	assert.Equal(t, 8, next.In(london).Hour())
+}
+
+func TestCronRule(t *testing.T) {
+	after := time.Date(2024, time.May, 15, 12, 30, 0, 0, time.UTC)
+	cases := []struct {
+		spec string
+		next time.Time
+	}{
+		{"0 9 1 * *", time.Date(2024, time.June, 1, 9, 0, 0, 0, time.UTC)},
+		{"*/15 * * * *", time.Date(2024, time.May, 15, 12, 45, 0, 0, time.UTC)},
+		{"0 8 * * 1-5", time.Date(2024, time.May, 16, 8, 0, 0, 0, time.UTC)},
+		{"0 10 * * 0", time.Date(2024, time.May, 19, 10, 0, 0, 0, time.UTC)},
+		{"0 10 * * 7", time.Date(2024, time.May, 19, 10, 0, 0, 0, time.UTC)},
+		{"30 12 15 5 *", time.Date(2025, time.May, 15, 12, 30, 0, 0, time.UTC)},
+		// both day fields restricted match on either of them
+		{"0 0 20 * 6", time.Date(2024, time.May, 18, 0, 0, 0, 0, time.UTC)},
+		{"0 0 30 2 *", time.Time{}},
+	}
+	for _, c := range cases {
+		rule, err := ParseRule(c.spec, after, time.UTC)
+		assert.NoError(t, err, c.spec)
+		assert.Equal(t, c.next, rule.Next(after), c.spec)
+	}
+
+	for _, spec := range []string{"", "hourly", "* * * *", "60 * * * *", "* * 0 * *", "*/0 * * * *", "5-1 * * * *"} {
+		_, err := ParseRule(spec, after, time.UTC)
+		assert.Error(t, err, spec)
+	}
+}
@@ -0,0 +1,353 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"github.com/google/uuid"
+	"github.com/google/wire"
+	"github.com/raychongtk/wallet/audit"
+	"github.com/raychongtk/wallet/config"
+	"github.com/raychongtk/wallet/domain"
+	"github.com/raychongtk/wallet/ledger"
+	"github.com/raychongtk/wallet/model/schedule"
+	"github.com/raychongtk/wallet/notify"
+	"github.com/raychongtk/wallet/repository"
+	"github.com/raychongtk/wallet/util"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"sync"
+	"time"
+)
+
+var (
+	WireSet = wire.NewSet(ProvideScheduler)
+)
+
+const (
+	// ErrorCodeMissed marks an occurrence that was due longer than scheduler.misfire_window ago
+	ErrorCodeMissed = "MISSED"
+	// ErrorCodeCancelled marks an occurrence whose schedule was cancelled before it ran
+	ErrorCodeCancelled = "CANCELLED"
+)
+
+// Scheduler runs standing orders. Every instance runs it: a due schedule is turned into an occurrence under a row
+// lock, and an occurrence is leased by one instance at a time. The transfer of an occurrence has the same request id
+// on every attempt, so an occurrence whose transfer was booked before a crash is only recorded, not paid again.
+type Scheduler struct {
+	ledger       *ledger.Ledger
+	scheduleRepo repository.ScheduledTransferRepository
+	db           gorm.DB
+	config       config.SchedulerConfig
+	notifier     *notify.Notifier
+	auditor      *audit.Auditor
+	mu           sync.Mutex
+	lastErr      error
+}
+
+func ProvideScheduler(
+	ledger *ledger.Ledger,
+	scheduleRepo repository.ScheduledTransferRepository,
+	db gorm.DB,
+	cfg *config.Config,
+	notifier *notify.Notifier,
+	auditor *audit.Auditor,
+) *Scheduler {
+	return &Scheduler{
+		ledger:       ledger,
+		scheduleRepo: scheduleRepo,
+		db:           db,
+		config:       cfg.Scheduler,
+		notifier:     notifier,
+		auditor:      auditor,
+	}
+}
+
+// CreateCommand is a standing order. Amount is in minor units, a zero MaxRuns or a nil EndAt does not limit it.
+type CreateCommand struct {
+	PayerUserID uuid.UUID
+	PayeeUserID uuid.UUID
+	Amount      int
+	Rule        string
+	TimeZone    string
+	StartAt     time.Time
+	EndAt       *time.Time
+	MaxRuns     int
+	RequestID   string
+}
+
+// Create validates a standing order the way a transfer is validated and stores it with its first occurrence. The
+// occurrences of a start in the past that are already over are not run.
+func (s *Scheduler) Create(ctx context.Context, cmd CreateCommand) (*schedule.ScheduledTransfer, error) {
+	if cmd.PayerUserID == cmd.PayeeUserID {
+		return nil, domain.ErrCannotTransferToSelf
+	}
+	if err := s.ledger.ValidAmount(cmd.Amount); err != nil {
+		return nil, err
+	}
+	if _, err := s.ledger.Payer(cmd.PayerUserID); err != nil {
+		return nil, err
+	}
+	if _, err := s.ledger.ActiveCustomer(cmd.PayeeUserID); err != nil {
+		return nil, err
+	}
+	// occurrences are kept to the second, like the timestamp columns they are stored in
+	cmd.StartAt = cmd.StartAt.Truncate(time.Second)
+	if cmd.TimeZone == "" {
+		cmd.TimeZone = "UTC"
+	}
+	loc, err := time.LoadLocation(cmd.TimeZone)
+	if err != nil {
+		return nil, domain.ErrInvalidParameters.WithMessage("unknown time zone %s", cmd.TimeZone)
+	}
+	if cmd.MaxRuns < 0 {
+		return nil, domain.ErrInvalidParameters.WithMessage("max_runs cannot be negative")
+	}
+	if cmd.EndAt != nil && !cmd.EndAt.After(cmd.StartAt) {
+		return nil, domain.ErrInvalidParameters.WithMessage("end_at must be after start_at")
+	}
+	rule, err := ParseRule(cmd.Rule, cmd.StartAt, loc)
+	if err != nil {
+		return nil, domain.ErrInvalidParameters.WithMessage("%s", err.Error())
+	}
+	from := cmd.StartAt
+	if now := time.Now(); from.Before(now) {
+		from = now
+	}
+	first := rule.Next(from.Add(-time.Nanosecond))
+	if first.IsZero() || (cmd.EndAt != nil && first.After(*cmd.EndAt)) {
+		return nil, domain.ErrInvalidParameters.WithMessage("rule %s never runs between start_at and end_at", cmd.Rule)
+	}
+
+	now := time.Now()
+	scheduledTransfer := &schedule.ScheduledTransfer{
+		ID:          uuid.New(),
+		PayerUserID: cmd.PayerUserID,
+		PayeeUserID: cmd.PayeeUserID,
+		Amount:      cmd.Amount,
+		Rule:        cmd.Rule,
+		TimeZone:    cmd.TimeZone,
+		StartAt:     cmd.StartAt,
+		EndAt:       cmd.EndAt,
+		MaxRuns:     cmd.MaxRuns,
+		NextRunAt:   &first,
+		Status:      schedule.StatusActive,
+		RequestID:   cmd.RequestID,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+	if err := s.scheduleRepo.CreateSchedule(scheduledTransfer); err != nil {
+		return nil, err
+	}
+	return scheduledTransfer, nil
+}
+
+// Cancel stops a schedule, occurrences that are still waiting for a retry are skipped
+func (s *Scheduler) Cancel(ctx context.Context, scheduleID uuid.UUID) (*schedule.ScheduledTransfer, error) {
+	scheduledTransfer, err := s.scheduleRepo.GetSchedule(scheduleID)
+	if err != nil {
+		return nil, err
+	}
+	if scheduledTransfer.Status != schedule.StatusActive {
+		return nil, domain.ErrInvalidParameters.WithMessage("schedule is %s already", scheduledTransfer.Status)
+	}
+	scheduledTransfer.Status, scheduledTransfer.NextRunAt = schedule.StatusCancelled, nil
+	if err := s.scheduleRepo.UpdateSchedule(s.db.WithContext(ctx), scheduledTransfer); err != nil {
+		return nil, err
+	}
+	return scheduledTransfer, nil
+}
+
+// Start runs due schedules every scheduler.interval until the context is cancelled
+func (s *Scheduler) Start(ctx context.Context) {
+	ticker := time.NewTicker(s.config.Interval)
+	defer ticker.Stop()
+	for {
+		err := s.Run(ctx)
+		if err != nil && ctx.Err() == nil {
+			util.Error("Run scheduled transfers failed", zap.Error(err))
+		}
+		s.mu.Lock()
+		s.lastErr = err
+		s.mu.Unlock()
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Health reports the error of the last scheduled run
+func (s *Scheduler) Health() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.lastErr
+}
+
+// Run creates the occurrences that are due, then runs every occurrence it can claim. An occurrence that fails for a
+// reason other than the rules of the ledger keeps its lease and is tried again once the lease expired.
+func (s *Scheduler) Run(ctx context.Context) error {
+	for ctx.Err() == nil {
+		created, err := s.createDueRun(ctx)
+		if err != nil {
+			return err
+		}
+		if !created {
+			break
+		}
+	}
+	var errs []error
+	for ctx.Err() == nil {
+		run, err := s.scheduleRepo.ClaimRun(time.Now(), s.config.Lease)
+		if err != nil {
+			return errors.Join(append(errs, err)...)
+		}
+		if run == nil {
+			break
+		}
+		if err := s.execute(ctx, run); err != nil {
+			util.Error("Scheduled transfer failed", zap.String("run_id", run.ID.String()), zap.Error(err))
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// createDueRun turns the next occurrence of one due schedule into a run and moves the schedule on. An occurrence that
+// was due longer than the misfire window ago is recorded as skipped and the schedule resumes with the next occurrence
+// inside the window, so an outage does not end in a burst of late payments.
+func (s *Scheduler) createDueRun(ctx context.Context) (bool, error) {
+	created := false
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		now := time.Now()
+		scheduledTransfer, err := s.scheduleRepo.LockDueSchedule(tx, now)
+		if err != nil || scheduledTransfer == nil {
+			return err
+		}
+		rule, err := s.rule(scheduledTransfer)
+		if err != nil {
+			return err
+		}
+		run := &schedule.Run{
+			ID:            uuid.New(),
+			ScheduleID:    scheduledTransfer.ID,
+			ScheduledFor:  *scheduledTransfer.NextRunAt,
+			RunStatus:     schedule.RunPending,
+			NextAttemptAt: *scheduledTransfer.NextRunAt,
+			CreatedAt:     now,
+			UpdatedAt:     now,
+		}
+		next := rule.Next(run.ScheduledFor)
+		if missed := now.Add(-s.config.MisfireWindow); run.ScheduledFor.Before(missed) {
+			run.RunStatus, run.ErrorCode = schedule.RunSkipped, ErrorCodeMissed
+			next = rule.Next(missed)
+		} else {
+			scheduledTransfer.RunCount++
+		}
+		if err := s.scheduleRepo.CreateRun(tx, run); err != nil {
+			return err
+		}
+		scheduledTransfer.NextRunAt = &next
+		if next.IsZero() || (scheduledTransfer.EndAt != nil && next.After(*scheduledTransfer.EndAt)) ||
+			(scheduledTransfer.MaxRuns > 0 && scheduledTransfer.RunCount >= scheduledTransfer.MaxRuns) {
+			scheduledTransfer.Status, scheduledTransfer.NextRunAt = schedule.StatusCompleted, nil
+		}
+		created = true
+		return s.scheduleRepo.UpdateSchedule(tx, scheduledTransfer)
+	})
+	return created, repository.DBError(err)
+}
+
+// execute transfers the money of one occurrence through the same ledger path as the transfer API. Insufficient funds
+// are retried with a doubling backoff until scheduler.max_attempts, the payer is notified when the occurrence fails.
+func (s *Scheduler) execute(ctx context.Context, run *schedule.Run) error {
+	scheduledTransfer, err := s.scheduleRepo.GetSchedule(run.ScheduleID)
+	if err != nil {
+		return err
+	}
+	if scheduledTransfer.Status == schedule.StatusCancelled {
+		run.RunStatus, run.ErrorCode, run.LockedUntil = schedule.RunSkipped, ErrorCodeCancelled, nil
+		return s.scheduleRepo.UpdateRun(run)
+	}
+
+	// a run whose lease ran out while its transfer was booked gets the booked group back rather than a second one
+	result, err := s.ledger.Transfer(ctx, ledger.TransferCommand{
+		FromUserID: scheduledTransfer.PayerUserID,
+		ToUserID:   scheduledTransfer.PayeeUserID,
+		Amount:     scheduledTransfer.Amount,
+		RequestID:  run.RequestID(),
+	})
+	if err == nil {
+		if !result.Replayed {
+			run.Attempts++
+		}
+		return s.succeed(ctx, scheduledTransfer, run, result.GroupID)
+	}
+	domainErr := domain.From(err)
+	if domainErr.Kind == domain.KindInternal || domainErr.Retryable {
+		return err
+	}
+	run.Attempts++
+	run.ErrorCode, run.LockedUntil = domainErr.Code, nil
+	if errors.Is(err, domain.ErrInsufficientFunds) && run.Attempts < s.config.MaxAttempts {
+		run.RunStatus = schedule.RunRetrying
+		run.NextAttemptAt = time.Now().Add(s.config.RetryBackoff << (run.Attempts - 1))
+		return s.scheduleRepo.UpdateRun(run)
+	}
+	run.RunStatus = schedule.RunFailed
+	if err := s.scheduleRepo.UpdateRun(run); err != nil {
+		return err
+	}
+	s.record(ctx, scheduledTransfer, run)
+	err = s.notifier.Notify(context.WithoutCancel(ctx), scheduledTransfer.PayerUserID, notify.KindScheduledTransferFailed,
+		"Your scheduled transfer of "+util.DisplayAmount(scheduledTransfer.Amount)+" could not be made: "+domainErr.Message,
+		map[string]interface{}{"schedule_id": scheduledTransfer.ID, "run_id": run.ID, "scheduled_for": run.ScheduledFor, "error_code": run.ErrorCode},
+	)
+	if err != nil {
+		util.Error("Notify failed scheduled transfer failed", zap.String("run_id", run.ID.String()), zap.Error(err))
+	}
+	return nil
+}
+
+func (s *Scheduler) succeed(ctx context.Context, scheduledTransfer *schedule.ScheduledTransfer, run *schedule.Run, groupID uuid.UUID) error {
+	run.RunStatus, run.GroupID, run.ErrorCode, run.LockedUntil = schedule.RunSucceeded, &groupID, "", nil
+	if err := s.scheduleRepo.UpdateRun(run); err != nil {
+		return err
+	}
+	s.record(ctx, scheduledTransfer, run)
+	return nil
+}
+
+// record keeps the outcome of an occurrence in the audit log, the scheduler acting for the payer
+func (s *Scheduler) record(ctx context.Context, scheduledTransfer *schedule.ScheduledTransfer, run *schedule.Run) {
+	entry := audit.Entry{
+		ActorType:  audit.ActorService,
+		ActorID:    "scheduler",
+		Action:     "schedule.run",
+		TargetType: audit.TargetSchedule,
+		TargetID:   scheduledTransfer.ID.String(),
+		RequestID:  run.RequestID(),
+		Outcome:    audit.OutcomeSuccess,
+		After: map[string]interface{}{
+			"run_id":        run.ID,
+			"scheduled_for": run.ScheduledFor,
+			"status":        run.RunStatus,
+			"attempts":      run.Attempts,
+			"group_id":      run.GroupID,
+		},
+	}
+	if run.RunStatus == schedule.RunFailed {
+		entry.Outcome, entry.ErrorCode = audit.OutcomeFailure, run.ErrorCode
+	}
+	if err := s.auditor.Record(context.WithoutCancel(ctx), entry); err != nil {
+		util.Error("Record audit log failed", zap.String("action", entry.Action), zap.Error(err))
+	}
+}
+
+func (s *Scheduler) rule(scheduledTransfer *schedule.ScheduledTransfer) (Rule, error) {
+	loc, err := time.LoadLocation(scheduledTransfer.TimeZone)
+	if err != nil {
+		return nil, err
+	}
+	return ParseRule(scheduledTransfer.Rule, scheduledTransfer.StartAt, loc)
+}
@@ -0,0 +1,62 @@
+package secret
+
+import (
+	"context"
+	"github.com/raychongtk/wallet/util"
+	"go.uber.org/zap"
+	"sync"
+	"time"
+)
+
+// CachingProvider keeps secrets for a TTL so that rotated values are picked up without a restart while the
+// backend is not hit on every new connection. When a refresh fails the last known value is served.
+type CachingProvider struct {
+	provider Provider
+	ttl      time.Duration
+	now      func() time.Time
+	mu       sync.Mutex
+	entries  map[string]cacheEntry
+}
+
+type cacheEntry struct {
+	value     string
+	expiresAt time.Time
+}
+
+func NewCachingProvider(provider Provider, ttl time.Duration) *CachingProvider {
+	return &CachingProvider{
+		provider: provider,
+		ttl:      ttl,
+		now:      time.Now,
+		entries:  make(map[string]cacheEntry),
+	}
+}
+
+func (p *CachingProvider) Get(ctx context.Context, name string) (string, error) {
+	p.mu.Lock()
+	entry, cached := p.entries[name]
+	p.mu.Unlock()
+	if cached && p.now().Before(entry.expiresAt) {
+		return entry.value, nil
+	}
+
+	value, err := p.provider.Get(ctx, name)
+	if err != nil {
+		if cached {
+			util.Warn("Refresh secret failed, serving cached value", zap.String("secret", name), zap.Error(err))
+			return entry.value, nil
+		}
+		return "", err
+	}
+	p.mu.Lock()
+	p.entries[name] = cacheEntry{value: value, expiresAt: p.now().Add(p.ttl)}
+	p.mu.Unlock()
+	return value, nil
+}
+
+// Invalidate drops a cached secret, e.g. after the backend rejected it
+func (p *CachingProvider) Invalidate(name string) {
+	p.mu.Lock()
+	delete(p.entries, name)
+	p.mu.Unlock()
+}
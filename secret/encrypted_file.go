@@ -0,0 +1,86 @@
+package secret
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+)
+
+// EncryptedFileProvider reads secrets from a JSON object of name to value sealed with AES-256-GCM.
+// The file is nonce followed by ciphertext, see Seal.
+type EncryptedFileProvider struct {
+	path string
+	key  []byte
+}
+
+func NewEncryptedFileProvider(path string, key []byte) (*EncryptedFileProvider, error) {
+	if len(key) != 32 {
+		return nil, fmt.Errorf("secrets key must be 32 bytes, got %d", len(key))
+	}
+	return &EncryptedFileProvider{path: path, key: key}, nil
+}
+
+func (p *EncryptedFileProvider) Get(_ context.Context, name string) (string, error) {
+	sealed, err := os.ReadFile(p.path)
+	if err != nil {
+		return "", err
+	}
+	secrets, err := Open(p.key, sealed)
+	if err != nil {
+		return "", err
+	}
+	value, ok := secrets[name]
+	if !ok {
+		return "", ErrNotFound
+	}
+	return value, nil
+}
+
+func Seal(key []byte, secrets map[string]string) ([]byte, error) {
+	aead, err := newAEAD(key)
+	if err != nil {
+		return nil, err
+	}
+	plaintext, err := json.Marshal(secrets)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	return aead.Seal(nonce, nonce, plaintext, nil), nil
+}
+
+func Open(key []byte, sealed []byte) (map[string]string, error) {
+	aead, err := newAEAD(key)
+	if err != nil {
+		return nil, err
+	}
+	if len(sealed) < aead.NonceSize() {
+		return nil, errors.New("secrets file is truncated")
+	}
+	nonce, ciphertext := sealed[:aead.NonceSize()], sealed[aead.NonceSize():]
+	plaintext, err := aead.Open(nil, nonce, ciphertext, nil)
+	if err != nil {
+		return nil, errors.New("secrets file cannot be decrypted with the given key")
+	}
+	var secrets map[string]string
+	if err := json.Unmarshal(plaintext, &secrets); err != nil {
+		return nil, err
+	}
+	return secrets, nil
+}
+
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
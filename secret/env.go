@@ -0,0 +1,25 @@
+package secret
+
+import (
+	"context"
+	"os"
+	"strings"
+)
+
+// EnvProvider reads secret db/password from the environment variable WALLET_SECRET_DB_PASSWORD
+type EnvProvider struct {
+	prefix string
+}
+
+func NewEnvProvider(prefix string) *EnvProvider {
+	return &EnvProvider{prefix: prefix}
+}
+
+func (p *EnvProvider) Get(_ context.Context, name string) (string, error) {
+	replacer := strings.NewReplacer("/", "_", "-", "_", ".", "_")
+	value, ok := os.LookupEnv(p.prefix + strings.ToUpper(replacer.Replace(name)))
+	if !ok {
+		return "", ErrNotFound
+	}
+	return value, nil
+}
@@ -0,0 +1,30 @@
+package secret
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// FileProvider reads secret db/password from <dir>/db/password, the layout of Docker and Kubernetes mounted secrets
+type FileProvider struct {
+	dir string
+}
+
+func NewFileProvider(dir string) *FileProvider {
+	return &FileProvider{dir: dir}
+}
+
+func (p *FileProvider) Get(_ context.Context, name string) (string, error) {
+	path := filepath.Join(p.dir, filepath.FromSlash(filepath.Clean("/"+name)))
+	value, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return "", ErrNotFound
+	}
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimRight(string(value), "\r\n"), nil
+}
@@ -0,0 +1,78 @@
+package secret
+
+import (
+	"encoding/base64"
+	"fmt"
+	"github.com/raychongtk/wallet/config"
+	"os"
+	"strings"
+	"sync"
+)
+
+const (
+	BackendEnv           = "env"
+	BackendFile          = "file"
+	BackendEncryptedFile = "encrypted_file"
+)
+
+// Factory builds a remote secret manager backend, e.g. Vault or a cloud secret manager
+type Factory func(cfg config.SecretsConfig) (Provider, error)
+
+var (
+	backendsMu sync.RWMutex
+	backends   = map[string]Factory{}
+)
+
+// RegisterBackend plugs in a remote secret manager that can then be selected with secrets.backend
+func RegisterBackend(name string, factory Factory) {
+	backendsMu.Lock()
+	defer backendsMu.Unlock()
+	backends[name] = factory
+}
+
+func ProvideProvider(cfg *config.Config) (Provider, error) {
+	provider, err := newBackend(cfg.Secrets)
+	if err != nil {
+		return nil, err
+	}
+	return NewCachingProvider(provider, cfg.Secrets.CacheTTL), nil
+}
+
+func newBackend(cfg config.SecretsConfig) (Provider, error) {
+	switch cfg.Backend {
+	case BackendEnv:
+		return NewEnvProvider("WALLET_SECRET_"), nil
+	case BackendFile:
+		return NewFileProvider(cfg.Dir), nil
+	case BackendEncryptedFile:
+		key, err := readKey(cfg.KeyFile)
+		if err != nil {
+			return nil, err
+		}
+		return NewEncryptedFileProvider(cfg.File, key)
+	}
+	backendsMu.RLock()
+	factory, ok := backends[cfg.Backend]
+	backendsMu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("unknown secrets backend %q", cfg.Backend)
+	}
+	return factory(cfg)
+}
+
+// readKey takes the base64 key from WALLET_SECRETS_KEY, or from the key file when the variable is not set
+func readKey(keyFile string) ([]byte, error) {
+	encoded := os.Getenv("WALLET_SECRETS_KEY")
+	if encoded == "" {
+		content, err := os.ReadFile(keyFile)
+		if err != nil {
+			return nil, fmt.Errorf("read secrets key: %w", err)
+		}
+		encoded = string(content)
+	}
+	key, err := base64.StdEncoding.DecodeString(strings.TrimSpace(encoded))
+	if err != nil {
+		return nil, fmt.Errorf("decode secrets key: %w", err)
+	}
+	return key, nil
+}
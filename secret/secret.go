@@ -0,0 +1,39 @@
+package secret
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Scheme prefixes configuration values that name a secret instead of holding it, e.g. secret://db/password
+const Scheme = "secret://"
+
+var ErrNotFound = errors.New("secret not found")
+
+// Provider looks up a secret by name, e.g. "db/password"
+type Provider interface {
+	Get(ctx context.Context, name string) (string, error)
+}
+
+func IsReference(value string) bool {
+	return strings.HasPrefix(value, Scheme)
+}
+
+// Resolve returns the secret a reference points to. Values that are not references are returned as they are so
+// that plain values keep working in local profiles.
+func Resolve(ctx context.Context, provider Provider, value string) (string, error) {
+	if !IsReference(value) {
+		return value, nil
+	}
+	name := strings.TrimPrefix(value, Scheme)
+	if name == "" {
+		return "", fmt.Errorf("empty secret reference %q", value)
+	}
+	resolved, err := provider.Get(ctx, name)
+	if err != nil {
+		return "", fmt.Errorf("resolve %s: %w", value, err)
+	}
+	return resolved, nil
+}
@@ -0,0 +1,104 @@
+package secret
+
+import (
+	"context"
+	"errors"
+	"github.com/raychongtk/wallet/util"
+	"github.com/stretchr/testify/assert"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+type countingProvider struct {
+	values map[string]string
+	err    error
+	calls  int
+}
+
+func (p *countingProvider) Get(_ context.Context, name string) (string, error) {
+	p.calls++
+	if p.err != nil {
+		return "", p.err
+	}
+	return p.values[name], nil
+}
+
+func TestResolve(t *testing.T) {
+	t.Setenv("WALLET_SECRET_DB_PASSWORD", "s3cret")
+	provider := NewEnvProvider("WALLET_SECRET_")
+
+	value, err := Resolve(context.Background(), provider, "secret://db/password")
+	assert.NoError(t, err)
+	assert.Equal(t, "s3cret", value)
+
+	value, err = Resolve(context.Background(), provider, "plain")
+	assert.NoError(t, err)
+	assert.Equal(t, "plain", value)
+
+	_, err = Resolve(context.Background(), provider, "secret://redis/password")
+	assert.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestFileProvider(t *testing.T) {
+	dir := t.TempDir()
+	assert.NoError(t, os.MkdirAll(filepath.Join(dir, "db"), 0o700))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "db", "password"), []byte("s3cret\n"), 0o600))
+
+	value, err := NewFileProvider(dir).Get(context.Background(), "db/password")
+	assert.NoError(t, err)
+	assert.Equal(t, "s3cret", value)
+
+	// names cannot escape the secrets directory
+	assert.NoError(t, os.WriteFile(filepath.Join(filepath.Dir(dir), "outside"), []byte("leak"), 0o600))
+	_, err = NewFileProvider(dir).Get(context.Background(), "../outside")
+	assert.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestEncryptedFileProvider(t *testing.T) {
+	key := make([]byte, 32)
+	sealed, err := Seal(key, map[string]string{"db/password": "s3cret"})
+	assert.NoError(t, err)
+	path := filepath.Join(t.TempDir(), "secrets.enc")
+	assert.NoError(t, os.WriteFile(path, sealed, 0o600))
+
+	provider, err := NewEncryptedFileProvider(path, key)
+	assert.NoError(t, err)
+	value, err := provider.Get(context.Background(), "db/password")
+	assert.NoError(t, err)
+	assert.Equal(t, "s3cret", value)
+
+	wrongKey := make([]byte, 32)
+	wrongKey[0] = 1
+	provider, err = NewEncryptedFileProvider(path, wrongKey)
+	assert.NoError(t, err)
+	_, err = provider.Get(context.Background(), "db/password")
+	assert.Error(t, err)
+}
+
+func TestCachingProviderRefreshesAfterTTL(t *testing.T) {
+	util.InitializeLogger(false)
+	backend := &countingProvider{values: map[string]string{"db/password": "old"}}
+	now := time.Now()
+	provider := NewCachingProvider(backend, time.Minute)
+	provider.now = func() time.Time { return now }
+
+	value, _ := provider.Get(context.Background(), "db/password")
+	assert.Equal(t, "old", value)
+	backend.values["db/password"] = "rotated"
+	value, _ = provider.Get(context.Background(), "db/password")
+	assert.Equal(t, "old", value)
+	assert.Equal(t, 1, backend.calls)
+
+	now = now.Add(2 * time.Minute)
+	value, _ = provider.Get(context.Background(), "db/password")
+	assert.Equal(t, "rotated", value)
+
+	// a failing backend keeps serving the last known value
+	now = now.Add(2 * time.Minute)
+	backend.err = errors.New("unavailable")
+	value, err := provider.Get(context.Background(), "db/password")
+	assert.NoError(t, err)
+	assert.Equal(t, "rotated", value)
+}
@@ -0,0 +1,7 @@
+package secret
+
+import "github.com/google/wire"
+
+var (
+	WireSet = wire.NewSet(ProvideProvider)
+)
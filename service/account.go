@@ -0,0 +1,412 @@
+package service
+
+import (
+	"context"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/raychongtk/wallet/audit"
+	"github.com/raychongtk/wallet/domain"
+	"github.com/raychongtk/wallet/joint"
+	"github.com/raychongtk/wallet/model/payment"
+	"github.com/raychongtk/wallet/model/wallet"
+	"github.com/raychongtk/wallet/problem"
+	"github.com/raychongtk/wallet/tracing"
+	"github.com/raychongtk/wallet/util"
+	"go.uber.org/zap"
+	"net/http"
+	"time"
+)
+
+const maxSharedTransfers = 100
+
+// GetAccounts lists the account a user holds and the accounts shared with them, with their role in each
+func (s *Service) GetAccounts(ctx *gin.Context) {
+	userId, err := uuid.Parse(ctx.Query("user_id"))
+	if err != nil {
+		problem.Respond(ctx, domain.ErrInvalidAccount.Wrap(err))
+		return
+	}
+	memberships, err := s.jointAccounts.Accounts(userId)
+	if err != nil {
+		util.Error("Search accounts failed", zap.Error(err))
+		problem.Respond(ctx, err)
+		return
+	}
+	response := SearchAccountMemberResponse{Members: []AccountMember{}}
+	for i := range memberships {
+		response.Members = append(response.Members, *newAccountMember(&memberships[i]))
+	}
+	ctx.JSON(http.StatusOK, &response)
+}
+
+// GetAccountMembers lists the holder and the members of an account to any of its members
+func (s *Service) GetAccountMembers(ctx *gin.Context) {
+	accountId, userId, ok := accountParams(ctx, ctx.Query("user_id"))
+	if !ok {
+		return
+	}
+	members, err := s.jointAccounts.Members(accountId, userId)
+	if err != nil {
+		problem.Respond(ctx, err)
+		return
+	}
+	response := SearchAccountMemberResponse{Members: []AccountMember{}}
+	for i := range members {
+		response.Members = append(response.Members, *newAccountMember(&members[i]))
+	}
+	ctx.JSON(http.StatusOK, &response)
+}
+
+// SaveAccountMember shares an account with a user or changes their role, which only an owner may do
+func (s *Service) SaveAccountMember(ctx *gin.Context) {
+	var req SaveAccountMemberRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		util.Error("Invalid params", zap.Error(err))
+		problem.Respond(ctx, domain.ErrInvalidParameters.Wrap(err))
+		return
+	}
+	accountId, ownerId, ok := accountParams(ctx, req.OwnerUserId)
+	if !ok {
+		return
+	}
+	userId, err := uuid.Parse(ctx.Param("user_id"))
+	if err != nil {
+		problem.Respond(ctx, domain.ErrInvalidAccount.Wrap(err))
+		return
+	}
+	audit.Actor(ctx, audit.ActorUser, ownerId.String())
+	audit.Target(ctx, audit.TargetAccount, accountId.String())
+	spendingLimit := 0
+	if req.SpendingLimit != "" {
+		if spendingLimit, err = util.ConvertToInt(req.SpendingLimit); err != nil {
+			problem.Respond(ctx, domain.ErrInvalidParameters.Wrap(err))
+			return
+		}
+	}
+
+	member, err := s.jointAccounts.SaveMember(ctx.Request.Context(), joint.MemberCommand{
+		AccountID:     accountId,
+		OwnerUserID:   ownerId,
+		UserID:        userId,
+		Role:          req.Role,
+		SpendingLimit: spendingLimit,
+	})
+	if err != nil {
+		util.Error("Save account member failed", zap.String("account_id", accountId.String()), zap.Error(err))
+		problem.Respond(ctx, err)
+		return
+	}
+	response := newAccountMember(member)
+	audit.Change(ctx, nil, response)
+	ctx.JSON(http.StatusOK, response)
+}
+
+// RemoveAccountMember takes an account away from a member, an owner removes anyone and a member may leave
+func (s *Service) RemoveAccountMember(ctx *gin.Context) {
+	accountId, byUserId, ok := accountParams(ctx, ctx.Query("by_user_id"))
+	if !ok {
+		return
+	}
+	userId, err := uuid.Parse(ctx.Param("user_id"))
+	if err != nil {
+		problem.Respond(ctx, domain.ErrInvalidAccount.Wrap(err))
+		return
+	}
+	audit.Actor(ctx, audit.ActorUser, byUserId.String())
+	audit.Target(ctx, audit.TargetAccount, accountId.String())
+	if err := s.jointAccounts.RemoveMember(ctx.Request.Context(), accountId, byUserId, userId); err != nil {
+		util.Error("Remove account member failed", zap.String("account_id", accountId.String()), zap.Error(err))
+		problem.Respond(ctx, err)
+		return
+	}
+	audit.Change(ctx, gin.H{"user_id": userId.String()}, nil)
+	ctx.Status(http.StatusNoContent)
+}
+
+func (s *Service) SetApprovalThreshold(ctx *gin.Context) {
+	var req SetApprovalThresholdRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		util.Error("Invalid params", zap.Error(err))
+		problem.Respond(ctx, domain.ErrInvalidParameters.Wrap(err))
+		return
+	}
+	accountId, ownerId, ok := accountParams(ctx, req.OwnerUserId)
+	if !ok {
+		return
+	}
+	audit.Actor(ctx, audit.ActorUser, ownerId.String())
+	audit.Target(ctx, audit.TargetAccount, accountId.String())
+	threshold, err := util.ConvertToInt(req.Amount)
+	if err != nil {
+		problem.Respond(ctx, domain.ErrInvalidParameters.Wrap(err))
+		return
+	}
+	account, err := s.jointAccounts.SetApprovalThreshold(ctx.Request.Context(), accountId, ownerId, threshold)
+	if err != nil {
+		util.Error("Set approval threshold failed", zap.String("account_id", accountId.String()), zap.Error(err))
+		problem.Respond(ctx, err)
+		return
+	}
+	audit.Change(ctx, nil, gin.H{"approval_threshold": account.ApprovalThreshold})
+	ctx.JSON(http.StatusOK, &ApprovalThresholdResponse{AccountId: account.ID.String(), Amount: util.DisplayAmount(account.ApprovalThreshold)})
+}
+
+// GetAccountBalance shows the balance of a shared account to any of its members
+func (s *Service) GetAccountBalance(ctx *gin.Context) {
+	accountId, userId, ok := accountParams(ctx, ctx.Query("user_id"))
+	if !ok {
+		return
+	}
+	account, _, err := s.jointAccounts.Member(accountId, userId)
+	if err != nil {
+		problem.Respond(ctx, err)
+		return
+	}
+	holder, err := s.ledger.Customer(account.UserID)
+	if err != nil {
+		problem.Respond(ctx, err)
+		return
+	}
+	s.respondBalance(ctx, holder)
+}
+
+// GetAccountPaymentHistory shows the payments of a shared account. A spender sees the payments they made, the other
+// roles see all of them.
+func (s *Service) GetAccountPaymentHistory(ctx *gin.Context) {
+	accountId, userId, ok := accountParams(ctx, ctx.Query("user_id"))
+	if !ok {
+		return
+	}
+	account, member, err := s.jointAccounts.Member(accountId, userId)
+	if err != nil {
+		problem.Respond(ctx, err)
+		return
+	}
+	initiatorUserId := ""
+	if !member.SeesEveryPayment() {
+		initiatorUserId = userId.String()
+	}
+	s.respondPaymentHistory(ctx, account.UserID, initiatorUserId)
+}
+
+// CreateSharedTransfer pays out of a shared account, or asks the other owners to approve it above the approval
+// threshold, in which case it answers 202
+func (s *Service) CreateSharedTransfer(ctx *gin.Context) {
+	var req CreateSharedTransferRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		util.Error("Invalid params", zap.Error(err))
+		problem.Respond(ctx, domain.ErrInvalidParameters.Wrap(err))
+		return
+	}
+	accountId, initiatorId, ok := accountParams(ctx, req.InitiatorUserId)
+	if !ok {
+		return
+	}
+	toUserId, err := uuid.Parse(req.ToUserId)
+	if err != nil {
+		problem.Respond(ctx, domain.ErrInvalidAccount.Wrap(err))
+		return
+	}
+	audit.Actor(ctx, audit.ActorUser, initiatorId.String())
+	audit.Target(ctx, audit.TargetAccount, accountId.String())
+	amount, err := util.ConvertToInt(req.Amount)
+	if err != nil {
+		problem.Respond(ctx, domain.ErrInvalidParameters.Wrap(err))
+		return
+	}
+
+	transfer, err := s.jointAccounts.Transfer(ctx.Request.Context(), joint.TransferCommand{
+		AccountID:       accountId,
+		InitiatorUserID: initiatorId,
+		ToUserID:        toUserId,
+		Amount:          amount,
+		RequestID:       ctx.GetHeader(tracing.RequestIDHeader),
+	})
+	if err != nil {
+		util.Error("Shared transfer failed", zap.String("account_id", accountId.String()), zap.Error(err))
+		problem.Respond(ctx, err)
+		return
+	}
+	response := newSharedTransfer(transfer)
+	audit.Change(ctx, nil, response)
+	if transfer.Status == payment.SharedTransferPending {
+		ctx.JSON(http.StatusAccepted, response)
+		return
+	}
+	ctx.JSON(http.StatusCreated, response)
+}
+
+// GetSharedTransfers lists the latest transfers out of a shared account, a spender sees the ones they made
+func (s *Service) GetSharedTransfers(ctx *gin.Context) {
+	accountId, userId, ok := accountParams(ctx, ctx.Query("user_id"))
+	if !ok {
+		return
+	}
+	_, member, err := s.jointAccounts.Member(accountId, userId)
+	if err != nil {
+		problem.Respond(ctx, err)
+		return
+	}
+	transfers, err := s.sharedTransferRepo.SearchSharedTransfers(accountId, ctx.Query("status"), maxSharedTransfers)
+	if err != nil {
+		util.Error("Search shared transfers failed", zap.Error(err))
+		problem.Respond(ctx, err)
+		return
+	}
+	response := SearchSharedTransferResponse{Transfers: []SharedTransfer{}}
+	for i := range transfers {
+		if member.SeesEveryPayment() || transfers[i].InitiatorUserID == userId {
+			response.Transfers = append(response.Transfers, *newSharedTransfer(&transfers[i]))
+		}
+	}
+	ctx.JSON(http.StatusOK, &response)
+}
+
+// ApproveSharedTransfer pays a pending transfer, which an owner other than the one who made it does
+func (s *Service) ApproveSharedTransfer(ctx *gin.Context) {
+	s.decideSharedTransfer(ctx, s.jointAccounts.Approve)
+}
+
+// DeclineSharedTransfer closes a pending transfer without paying it
+func (s *Service) DeclineSharedTransfer(ctx *gin.Context) {
+	s.decideSharedTransfer(ctx, s.jointAccounts.Decline)
+}
+
+func (s *Service) decideSharedTransfer(ctx *gin.Context, decide func(ctx context.Context, id uuid.UUID, userID uuid.UUID) (*payment.SharedTransfer, error)) {
+	var req DecideSharedTransferRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		util.Error("Invalid params", zap.Error(err))
+		problem.Respond(ctx, domain.ErrInvalidParameters.Wrap(err))
+		return
+	}
+	accountId, userId, ok := accountParams(ctx, req.UserId)
+	if !ok {
+		return
+	}
+	transferId, err := uuid.Parse(ctx.Param("transfer_id"))
+	if err != nil {
+		problem.Respond(ctx, domain.ErrInvalidParameters.Wrap(err))
+		return
+	}
+	audit.Actor(ctx, audit.ActorUser, userId.String())
+	audit.Target(ctx, audit.TargetSharedTransfer, transferId.String())
+	found, err := s.sharedTransferRepo.GetSharedTransfer(transferId)
+	if err == nil && found.AccountID != accountId {
+		err = domain.ErrNotFound.WithMessage("shared transfer not found")
+	}
+	if err != nil {
+		problem.Respond(ctx, err)
+		return
+	}
+	transfer, err := decide(ctx.Request.Context(), transferId, userId)
+	if err != nil {
+		util.Error("Decide shared transfer failed", zap.String("shared_transfer_id", transferId.String()), zap.Error(err))
+		problem.Respond(ctx, err)
+		return
+	}
+	audit.Change(ctx, gin.H{"status": payment.SharedTransferPending}, gin.H{"status": transfer.Status, "group_id": transfer.GroupID})
+	ctx.JSON(http.StatusOK, newSharedTransfer(transfer))
+}
+
+// accountParams parses the account of the path and the user acting on it, answering the request when one is invalid
+func accountParams(ctx *gin.Context, userIdParam string) (uuid.UUID, uuid.UUID, bool) {
+	accountId, err := uuid.Parse(ctx.Param("account_id"))
+	if err != nil {
+		problem.Respond(ctx, domain.ErrInvalidParameters.Wrap(err))
+		return uuid.Nil, uuid.Nil, false
+	}
+	userId, err := uuid.Parse(userIdParam)
+	if err != nil {
+		problem.Respond(ctx, domain.ErrInvalidAccount.Wrap(err))
+		return uuid.Nil, uuid.Nil, false
+	}
+	return accountId, userId, true
+}
+
+func newAccountMember(member *wallet.AccountMember) *AccountMember {
+	response := &AccountMember{
+		AccountId: member.AccountID.String(),
+		UserId:    member.UserID.String(),
+		Role:      member.Role,
+	}
+	if member.SpendingLimit > 0 {
+		response.SpendingLimit = util.DisplayAmount(member.SpendingLimit)
+	}
+	return response
+}
+
+func newSharedTransfer(transfer *payment.SharedTransfer) *SharedTransfer {
+	response := &SharedTransfer{
+		TransferId:      transfer.ID.String(),
+		AccountId:       transfer.AccountID.String(),
+		InitiatorUserId: transfer.InitiatorUserID.String(),
+		ToUserId:        transfer.ToUserID.String(),
+		Amount:          util.DisplayAmount(transfer.Amount),
+		Status:          transfer.Status,
+		CreatedAt:       transfer.CreatedAt.UTC().Format(time.RFC3339),
+	}
+	if transfer.ApproverUserID != nil {
+		response.DecidedBy = transfer.ApproverUserID.String()
+	}
+	if transfer.GroupID != nil {
+		response.GroupId = transfer.GroupID.String()
+	}
+	if transfer.DecidedAt != nil {
+		response.DecidedAt = transfer.DecidedAt.UTC().Format(time.RFC3339)
+	}
+	return response
+}
+
+type SaveAccountMemberRequest struct {
+	OwnerUserId   string `json:"owner_user_id" binding:"required"`
+	Role          string `json:"role" binding:"required"`
+	SpendingLimit string `json:"spending_limit"`
+}
+
+type SetApprovalThresholdRequest struct {
+	OwnerUserId string `json:"owner_user_id" binding:"required"`
+	Amount      string `json:"amount" binding:"required"`
+}
+
+type CreateSharedTransferRequest struct {
+	InitiatorUserId string `json:"initiator_user_id" binding:"required"`
+	ToUserId        string `json:"to_user_id" binding:"required"`
+	Amount          string `json:"amount" binding:"required"`
+}
+
+type DecideSharedTransferRequest struct {
+	UserId string `json:"user_id" binding:"required"`
+}
+
+type AccountMember struct {
+	AccountId     string `json:"account_id" binding:"required"`
+	UserId        string `json:"user_id" binding:"required"`
+	Role          string `json:"role" binding:"required"`
+	SpendingLimit string `json:"spending_limit,omitempty"`
+}
+
+type SearchAccountMemberResponse struct {
+	Members []AccountMember `json:"members"`
+}
+
+type ApprovalThresholdResponse struct {
+	AccountId string `json:"account_id" binding:"required"`
+	Amount    string `json:"amount" binding:"required"`
+}
+
+type SharedTransfer struct {
+	TransferId      string `json:"transfer_id" binding:"required"`
+	AccountId       string `json:"account_id" binding:"required"`
+	InitiatorUserId string `json:"initiator_user_id" binding:"required"`
+	ToUserId        string `json:"to_user_id" binding:"required"`
+	Amount          string `json:"amount" binding:"required"`
+	Status          string `json:"status" binding:"required"`
+	DecidedBy       string `json:"decided_by,omitempty"`
+	GroupId         string `json:"group_id,omitempty"`
+	CreatedAt       string `json:"created_at" binding:"required"`
+	DecidedAt       string `json:"decided_at,omitempty"`
+}
+
+type SearchSharedTransferResponse struct {
+	Transfers []SharedTransfer `json:"transfers"`
+}
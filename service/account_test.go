@@ -0,0 +1,230 @@
+package service
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func putJSON(path string, body interface{}) *httptest.ResponseRecorder {
+	raw, _ := json.Marshal(body)
+	req, _ := http.NewRequest(http.MethodPut, path, bytes.NewBuffer(raw))
+	req.Header.Set("Content-Type", "application/json")
+	resp := httptest.NewRecorder()
+	ProvideRoutes(service).ServeHTTP(resp, req)
+	return resp
+}
+
+func getAccountResource(t *testing.T, path string, status int, response interface{}) {
+	resp := httptest.NewRecorder()
+	ProvideRoutes(service).ServeHTTP(resp, httptest.NewRequest(http.MethodGet, path, nil))
+	assert.Equal(t, status, resp.Code)
+	if response != nil {
+		assert.NoError(t, json.Unmarshal(resp.Body.Bytes(), response))
+	}
+}
+
+func TestSharedAccountMembersSpendWithinTheirRole(t *testing.T) {
+	_, _, cleanup, err := setupTestDB()
+	if err != nil {
+		t.Fatalf("failed to set up test DB: %v", err)
+	}
+	defer cleanup()
+
+	deposit := postJSON("/api/v1/wallet/deposit", map[string]string{"user_id": johnUserId, "balance": "1000"})
+	assert.Equal(t, http.StatusOK, deposit.Code)
+	var accounts SearchAccountMemberResponse
+	getAccountResource(t, "/api/v1/accounts?user_id="+johnUserId, http.StatusOK, &accounts)
+	assert.Len(t, accounts.Members, 1)
+	assert.Equal(t, "OWNER", accounts.Members[0].Role)
+	accountPath := "/api/v1/accounts/" + accounts.Members[0].AccountId
+
+	// a user the account is not shared with does not see it at all
+	getAccountResource(t, accountPath+"/balance?user_id="+rayUserId, http.StatusNotFound, nil)
+
+	resp := putJSON(accountPath+"/members/"+rayUserId, map[string]string{"owner_user_id": johnUserId, "role": "VIEWER"})
+	assert.Equal(t, http.StatusOK, resp.Code)
+	var balance GetCustomerBalanceResponse
+	getAccountResource(t, accountPath+"/balance?user_id="+rayUserId, http.StatusOK, &balance)
+	assert.Equal(t, "1000.00", balance.Balance)
+	resp = postJSON(accountPath+"/transfers", map[string]string{"initiator_user_id": rayUserId, "to_user_id": rayUserId, "amount": "10"})
+	assert.Equal(t, http.StatusForbidden, resp.Code)
+
+	resp = putJSON(accountPath+"/members/"+rayUserId, map[string]string{"owner_user_id": johnUserId, "role": "SPENDER", "spending_limit": "50"})
+	assert.Equal(t, http.StatusOK, resp.Code)
+	resp = postJSON(accountPath+"/transfers", map[string]string{"initiator_user_id": rayUserId, "to_user_id": rayUserId, "amount": "60"})
+	assert.Equal(t, http.StatusBadRequest, resp.Code)
+	resp = postJSON(accountPath+"/transfers", map[string]string{"initiator_user_id": rayUserId, "to_user_id": rayUserId, "amount": "40"})
+	assert.Equal(t, http.StatusCreated, resp.Code)
+	// once the account is shared the holder pays through its transfers as well
+	resp = postJSON("/api/v1/wallet/transfer", map[string]string{"debit_user_id": johnUserId, "credit_user_id": rayUserId, "balance": "5"})
+	assert.Equal(t, http.StatusForbidden, resp.Code)
+	resp = postJSON(accountPath+"/transfers", map[string]string{"initiator_user_id": johnUserId, "to_user_id": rayUserId, "amount": "5"})
+	assert.Equal(t, http.StatusCreated, resp.Code)
+
+	// the spender sees the payment they made, the holder sees both with the one who made it
+	var history SearchPaymentHistoryResponse
+	getAccountResource(t, accountPath+"/payment-history?user_id="+rayUserId, http.StatusOK, &history)
+	assert.Len(t, history.Histories, 1)
+	assert.Equal(t, rayUserId, history.Histories[0].InitiatedBy)
+	getAccountResource(t, accountPath+"/payment-history?user_id="+johnUserId, http.StatusOK, &history)
+	assert.Len(t, history.Histories, 3)
+
+	// above the threshold a transfer waits for an owner other than the one who made it
+	resp = putJSON(accountPath+"/members/"+rayUserId, map[string]string{"owner_user_id": johnUserId, "role": "OWNER"})
+	assert.Equal(t, http.StatusOK, resp.Code)
+	resp = putJSON(accountPath+"/approval-threshold", map[string]string{"owner_user_id": johnUserId, "amount": "100"})
+	assert.Equal(t, http.StatusOK, resp.Code)
+	resp = postJSON(accountPath+"/transfers", map[string]string{"initiator_user_id": rayUserId, "to_user_id": rayUserId, "amount": "200"})
+	assert.Equal(t, http.StatusAccepted, resp.Code)
+	var pending SharedTransfer
+	assert.NoError(t, json.Unmarshal(resp.Body.Bytes(), &pending))
+	assert.Equal(t, "PENDING", pending.Status)
+	resp = postJSON(accountPath+"/transfers/"+pending.TransferId+"/approve", map[string]string{"user_id": rayUserId})
+	assert.Equal(t, http.StatusForbidden, resp.Code)
+	resp = postJSON(accountPath+"/transfers/"+pending.TransferId+"/approve", map[string]string{"user_id": johnUserId})
+	assert.Equal(t, http.StatusOK, resp.Code)
+	var approved SharedTransfer
+	assert.NoError(t, json.Unmarshal(resp.Body.Bytes(), &approved))
+	assert.Equal(t, "COMPLETED", approved.Status)
+	assert.Equal(t, johnUserId, approved.DecidedBy)
+	assert.NotEmpty(t, approved.GroupId)
+	resp = postJSON(accountPath+"/transfers/"+pending.TransferId+"/decline", map[string]string{"user_id": johnUserId})
+	assert.Equal(t, http.StatusConflict, resp.Code)
+
+	assert.Equal(t, "755.00", johnBalance(t).Balance)
+}
+
+// shareAccount has John, holding 1000.00, share his account with Ray in role and ask a second owner to approve above
+// 100.00
+func shareAccount(t *testing.T, role map[string]string) string {
+	resp := postJSON("/api/v1/wallet/deposit", map[string]string{"user_id": johnUserId, "balance": "1000"})
+	assert.Equal(t, http.StatusOK, resp.Code)
+	var accounts SearchAccountMemberResponse
+	getAccountResource(t, "/api/v1/accounts?user_id="+johnUserId, http.StatusOK, &accounts)
+	accountPath := "/api/v1/accounts/" + accounts.Members[0].AccountId
+	saveRay(t, accountPath, role)
+	resp = putJSON(accountPath+"/approval-threshold", map[string]string{"owner_user_id": johnUserId, "amount": "100"})
+	assert.Equal(t, http.StatusOK, resp.Code)
+	return accountPath
+}
+
+func saveRay(t *testing.T, accountPath string, role map[string]string) {
+	body := map[string]string{"owner_user_id": johnUserId}
+	for key, value := range role {
+		body[key] = value
+	}
+	resp := putJSON(accountPath+"/members/"+rayUserId, body)
+	assert.Equal(t, http.StatusOK, resp.Code)
+}
+
+// pendingSharedTransfer has Ray pay amount to himself out of the account, which waits for John to approve it
+func pendingSharedTransfer(t *testing.T, accountPath string, amount string) SharedTransfer {
+	resp := postJSON(accountPath+"/transfers", map[string]string{"initiator_user_id": rayUserId, "to_user_id": rayUserId, "amount": amount})
+	assert.Equal(t, http.StatusAccepted, resp.Code)
+	var pending SharedTransfer
+	assert.NoError(t, json.Unmarshal(resp.Body.Bytes(), &pending))
+	return pending
+}
+
+func approveAsJohn(accountPath string, transfer SharedTransfer) *httptest.ResponseRecorder {
+	return postJSON(accountPath+"/transfers/"+transfer.TransferId+"/approve", map[string]string{"user_id": johnUserId})
+}
+
+func TestSharedTransferApprovedTwiceAtOnceIsPaidOnce(t *testing.T) {
+	db, _, cleanup, err := setupTestDB()
+	if err != nil {
+		t.Fatalf("failed to set up test DB: %v", err)
+	}
+	defer cleanup()
+
+	accountPath := shareAccount(t, map[string]string{"role": "OWNER"})
+	pending := pendingSharedTransfer(t, accountPath, "200")
+	responses := race(t, db, 8, func() *httptest.ResponseRecorder {
+		return approveAsJohn(accountPath, pending)
+	})
+	for _, resp := range responses {
+		assert.Equal(t, http.StatusOK, resp.Code)
+		var approved SharedTransfer
+		assert.NoError(t, json.Unmarshal(resp.Body.Bytes(), &approved))
+		assert.Equal(t, "COMPLETED", approved.Status)
+	}
+	assert.Equal(t, "800.00", johnBalance(t).Balance)
+	var history SearchPaymentHistoryResponse
+	getAccountResource(t, accountPath+"/payment-history?user_id="+johnUserId, http.StatusOK, &history)
+	assert.Len(t, history.Histories, 2)
+}
+
+func TestDeclinedSharedTransferIsNotPaid(t *testing.T) {
+	_, _, cleanup, err := setupTestDB()
+	if err != nil {
+		t.Fatalf("failed to set up test DB: %v", err)
+	}
+	defer cleanup()
+
+	accountPath := shareAccount(t, map[string]string{"role": "OWNER"})
+	pending := pendingSharedTransfer(t, accountPath, "200")
+	resp := postJSON(accountPath+"/transfers/"+pending.TransferId+"/decline", map[string]string{"user_id": johnUserId})
+	assert.Equal(t, http.StatusOK, resp.Code)
+	var declined SharedTransfer
+	assert.NoError(t, json.Unmarshal(resp.Body.Bytes(), &declined))
+	assert.Equal(t, "DECLINED", declined.Status)
+	assert.Empty(t, declined.GroupId)
+
+	resp = approveAsJohn(accountPath, pending)
+	assert.Equal(t, http.StatusConflict, resp.Code)
+	assert.Equal(t, "1000.00", johnBalance(t).Balance)
+}
+
+func TestSharedTransferFailingToCompleteIsNotPaid(t *testing.T) {
+	db, _, cleanup, err := setupTestDB()
+	if err != nil {
+		t.Fatalf("failed to set up test DB: %v", err)
+	}
+	defer cleanup()
+
+	accountPath := shareAccount(t, map[string]string{"role": "OWNER"})
+	pending := pendingSharedTransfer(t, accountPath, "200")
+	restore := failCompletions(t, db, "shared_transfer")
+	resp := approveAsJohn(accountPath, pending)
+	assert.Equal(t, http.StatusInternalServerError, resp.Code)
+	// the transfer went with the update of its row
+	var queue SearchSharedTransferResponse
+	getAccountResource(t, accountPath+"/transfers?status=PENDING&user_id="+johnUserId, http.StatusOK, &queue)
+	assert.Len(t, queue.Transfers, 1)
+	assert.Equal(t, "1000.00", johnBalance(t).Balance)
+
+	restore()
+	resp = approveAsJohn(accountPath, pending)
+	assert.Equal(t, http.StatusOK, resp.Code)
+	var approved SharedTransfer
+	assert.NoError(t, json.Unmarshal(resp.Body.Bytes(), &approved))
+	assert.Equal(t, "COMPLETED", approved.Status)
+	assert.Equal(t, "800.00", johnBalance(t).Balance)
+}
+
+func TestSharedTransferIsPaidWithinTheRoleOfItsMemberWhenApproved(t *testing.T) {
+	_, _, cleanup, err := setupTestDB()
+	if err != nil {
+		t.Fatalf("failed to set up test DB: %v", err)
+	}
+	defer cleanup()
+
+	accountPath := shareAccount(t, map[string]string{"role": "SPENDER", "spending_limit": "300"})
+	pending := pendingSharedTransfer(t, accountPath, "200")
+
+	// the spending limit and the role Ray has when the transfer is paid apply, not the ones he had when he made it
+	saveRay(t, accountPath, map[string]string{"role": "SPENDER", "spending_limit": "150"})
+	assert.Equal(t, http.StatusBadRequest, approveAsJohn(accountPath, pending).Code)
+	saveRay(t, accountPath, map[string]string{"role": "VIEWER"})
+	assert.Equal(t, http.StatusForbidden, approveAsJohn(accountPath, pending).Code)
+	assert.Equal(t, "1000.00", johnBalance(t).Balance)
+
+	saveRay(t, accountPath, map[string]string{"role": "SPENDER", "spending_limit": "200"})
+	resp := approveAsJohn(accountPath, pending)
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Equal(t, "800.00", johnBalance(t).Balance)
+}
@@ -0,0 +1,93 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"github.com/google/uuid"
+	"github.com/raychongtk/wallet/archive"
+	"github.com/raychongtk/wallet/config"
+	"github.com/raychongtk/wallet/model/movement"
+	"github.com/raychongtk/wallet/repository"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestArchiveLedgerWithReadThrough(t *testing.T) {
+	db, _, cleanup, err := setupTestDB()
+	if err != nil {
+		t.Fatalf("failed to set up test DB: %v", err)
+	}
+	defer cleanup()
+
+	router := ProvideRoutes(service)
+	payload := map[string]string{
+		"user_id": "2d988f4a-a037-4ce9-a350-f13445793e88",
+		"balance": "100",
+	}
+	body, _ := json.Marshal(payload)
+
+	depositReq, _ := http.NewRequest(http.MethodPost, "/api/v1/wallet/deposit", bytes.NewBuffer(body))
+	depositReq.Header.Set("Content-Type", "application/json")
+	depositReq.Header.Set("X-Request-ID", uuid.New().String())
+
+	depositResp := httptest.NewRecorder()
+	router.ServeHTTP(depositResp, depositReq)
+	assert.Equal(t, http.StatusOK, depositResp.Code)
+
+	archiver := archive.NewArchiver(
+		service.movementRepo,
+		service.transactionRepo,
+		service.paymentHistoryRepo,
+		repository.ProvideArchiveManifestRepository(*db),
+		objectStore,
+		*db,
+		config.ArchiveConfig{},
+	)
+	err = archiver.Run(context.Background(), time.Now().Add(48*time.Hour))
+	assert.NoError(t, err)
+
+	var remaining int64
+	db.Model(&movement.Transaction{}).Count(&remaining)
+	assert.Equal(t, int64(0), remaining)
+
+	historyReq, _ := http.NewRequest(http.MethodGet, "/api/v1/wallet/payment-history?user_id=2d988f4a-a037-4ce9-a350-f13445793e88&include_archived=true", nil)
+	historyResp := httptest.NewRecorder()
+	router.ServeHTTP(historyResp, historyReq)
+
+	assert.Equal(t, http.StatusOK, historyResp.Code)
+	var historyResponse map[string]interface{}
+	assert.NoError(t, json.Unmarshal(historyResp.Body.Bytes(), &historyResponse))
+	histories, ok := historyResponse["histories"].([]interface{})
+	assert.True(t, ok)
+	assert.Equal(t, 1, len(histories))
+
+	asOf := time.Now().UTC().Add(time.Hour).Format(time.RFC3339)
+	balanceReq, _ := http.NewRequest(http.MethodGet, "/api/v1/wallet/balance?user_id=2d988f4a-a037-4ce9-a350-f13445793e88&as_of="+asOf, nil)
+	balanceResp := httptest.NewRecorder()
+	router.ServeHTTP(balanceResp, balanceReq)
+
+	assert.Equal(t, http.StatusOK, balanceResp.Code)
+	var balanceResponse map[string]interface{}
+	assert.NoError(t, json.Unmarshal(balanceResp.Body.Bytes(), &balanceResponse))
+	assert.Equal(t, "100.00", balanceResponse["balance"])
+
+	// after the archived day the balance is summed from the balances recorded with its manifest
+	var archivedBalances int64
+	db.Table("archive_balance").Count(&archivedBalances)
+	assert.Equal(t, int64(1), archivedBalances)
+	asOf = time.Now().UTC().Add(25 * time.Hour).Format(time.RFC3339)
+	balanceReq, _ = http.NewRequest(http.MethodGet, "/api/v1/wallet/balance?user_id=2d988f4a-a037-4ce9-a350-f13445793e88&as_of="+asOf, nil)
+	balanceResp = httptest.NewRecorder()
+	router.ServeHTTP(balanceResp, balanceReq)
+
+	assert.Equal(t, http.StatusOK, balanceResp.Code)
+	assert.NoError(t, json.Unmarshal(balanceResp.Body.Bytes(), &balanceResponse))
+	assert.Equal(t, "100.00", balanceResponse["balance"])
+
+	err = archiver.Run(context.Background(), time.Now().Add(48*time.Hour))
+	assert.NoError(t, err)
+}
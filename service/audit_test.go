@@ -0,0 +1,75 @@
+package service
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"github.com/google/uuid"
+	"github.com/raychongtk/wallet/audit"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestAuditLogRecordsMoneyMovement(t *testing.T) {
+	db, _, cleanup, err := setupTestDB()
+	if err != nil {
+		t.Fatalf("failed to set up test DB: %v", err)
+	}
+	defer cleanup()
+
+	router := ProvideRoutes(service)
+	requestID := uuid.New().String()
+	for i := 0; i < 2; i++ {
+		body, _ := json.Marshal(map[string]string{"user_id": "2d988f4a-a037-4ce9-a350-f13445793e88", "balance": "100"})
+		req, _ := http.NewRequest(http.MethodPost, "/api/v1/wallet/deposit", bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("X-Request-ID", requestID)
+		router.ServeHTTP(httptest.NewRecorder(), req)
+	}
+
+	req, _ := http.NewRequest(http.MethodGet, "/api/v1/audit/logs?action=wallet.deposit", nil)
+	req.Header.Set(audit.ActorTypeHeader, audit.ActorOperator)
+	req.Header.Set(audit.ActorIDHeader, "auditor")
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+	assert.Equal(t, http.StatusOK, resp.Code)
+
+	var response audit.SearchAuditLogResponse
+	assert.NoError(t, json.Unmarshal(resp.Body.Bytes(), &response))
+	assert.Len(t, response.Logs, 2)
+	deposited, denied := response.Logs[0], response.Logs[1]
+	assert.Equal(t, audit.OutcomeSuccess, deposited.Outcome)
+	assert.Equal(t, audit.ActorUser, deposited.ActorType)
+	assert.Equal(t, "2d988f4a-a037-4ce9-a350-f13445793e88", deposited.ActorID)
+	assert.Equal(t, audit.TargetWallet, deposited.TargetType)
+	assert.Equal(t, "1cc535a5-bc57-4731-a64b-041b7ff41c30", deposited.TargetID)
+	assert.Equal(t, requestID, deposited.RequestID)
+	assert.JSONEq(t, `{"balances":{"1cc535a5-bc57-4731-a64b-041b7ff41c30":0}}`, string(deposited.Before))
+	var after struct {
+		Balances map[string]int `json:"balances"`
+	}
+	assert.NoError(t, json.Unmarshal(deposited.After, &after))
+	assert.Equal(t, map[string]int{"1cc535a5-bc57-4731-a64b-041b7ff41c30": 10000}, after.Balances)
+
+	assert.Equal(t, audit.OutcomeDenied, denied.Outcome)
+	assert.Equal(t, "DUPLICATE_REQUEST", denied.ErrorCode)
+
+	exportReq, _ := http.NewRequest(http.MethodGet, "/api/v1/audit/logs/export?format=csv&target_id=1cc535a5-bc57-4731-a64b-041b7ff41c30", nil)
+	exportResp := httptest.NewRecorder()
+	router.ServeHTTP(exportResp, exportReq)
+	assert.Equal(t, http.StatusOK, exportResp.Code)
+	records, err := csv.NewReader(exportResp.Body).ReadAll()
+	assert.NoError(t, err)
+	assert.Len(t, records, 2)
+	assert.Equal(t, "wallet.deposit", records[1][4])
+
+	// reading the audit log is audited as well
+	var searches int64
+	assert.NoError(t, db.Table("audit_log").Where("action = ? AND actor_id = ?", "audit.search", "auditor").Count(&searches).Error)
+	assert.Equal(t, int64(1), searches)
+
+	assert.Error(t, db.Exec("UPDATE audit_log SET outcome = 'success'").Error)
+	assert.Error(t, db.Exec("DELETE FROM audit_log").Error)
+}
@@ -0,0 +1,586 @@
+package service
+
+import (
+	"context"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/raychongtk/wallet/audit"
+	"github.com/raychongtk/wallet/business"
+	"github.com/raychongtk/wallet/domain"
+	"github.com/raychongtk/wallet/model/payment"
+	"github.com/raychongtk/wallet/model/wallet"
+	"github.com/raychongtk/wallet/problem"
+	"github.com/raychongtk/wallet/tracing"
+	"github.com/raychongtk/wallet/util"
+	"go.uber.org/zap"
+	"net/http"
+	"time"
+)
+
+const maxBusinessTransfers = 100
+
+// OpenBusiness opens a business account for an organization with the user as its first admin
+func (s *Service) OpenBusiness(ctx *gin.Context) {
+	var req OpenBusinessRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		util.Error("Invalid params", zap.Error(err))
+		problem.Respond(ctx, domain.ErrInvalidParameters.Wrap(err))
+		return
+	}
+	userId, err := uuid.Parse(req.UserId)
+	if err != nil {
+		problem.Respond(ctx, domain.ErrInvalidAccount.Wrap(err))
+		return
+	}
+	audit.Actor(ctx, audit.ActorUser, userId.String())
+
+	organization, err := s.businesses.Open(ctx.Request.Context(), business.OpenCommand{
+		AdminUserID:        userId,
+		LegalName:          req.LegalName,
+		RegistrationNumber: req.RegistrationNumber,
+		Country:            req.Country,
+		Email:              req.Email,
+	})
+	if err != nil {
+		util.Error("Open business failed", zap.String("user_id", userId.String()), zap.Error(err))
+		problem.Respond(ctx, err)
+		return
+	}
+	response := newBusiness(organization)
+	audit.Target(ctx, audit.TargetBusiness, response.BusinessId)
+	audit.Change(ctx, nil, response)
+	ctx.JSON(http.StatusCreated, response)
+}
+
+// GetBusinesses lists the organizations a user is a member of or invited to
+func (s *Service) GetBusinesses(ctx *gin.Context) {
+	userId, err := uuid.Parse(ctx.Query("user_id"))
+	if err != nil {
+		problem.Respond(ctx, domain.ErrInvalidAccount.Wrap(err))
+		return
+	}
+	memberships, err := s.businesses.Memberships(userId)
+	if err != nil {
+		util.Error("Search businesses failed", zap.Error(err))
+		problem.Respond(ctx, err)
+		return
+	}
+	response := SearchBusinessMemberResponse{Members: []BusinessMember{}}
+	for i := range memberships {
+		response.Members = append(response.Members, *newBusinessMember(&memberships[i]))
+	}
+	ctx.JSON(http.StatusOK, &response)
+}
+
+// GetBusiness shows the profile of an organization to its members
+func (s *Service) GetBusiness(ctx *gin.Context) {
+	businessId, userId, ok := businessParams(ctx, ctx.Query("user_id"))
+	if !ok {
+		return
+	}
+	organization, _, err := s.businesses.Member(businessId, userId)
+	if err != nil {
+		problem.Respond(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, newBusiness(organization))
+}
+
+func (s *Service) GetBusinessMembers(ctx *gin.Context) {
+	businessId, userId, ok := businessParams(ctx, ctx.Query("user_id"))
+	if !ok {
+		return
+	}
+	members, err := s.businesses.Members(businessId, userId)
+	if err != nil {
+		problem.Respond(ctx, err)
+		return
+	}
+	response := SearchBusinessMemberResponse{Members: []BusinessMember{}}
+	for i := range members {
+		response.Members = append(response.Members, *newBusinessMember(&members[i]))
+	}
+	ctx.JSON(http.StatusOK, &response)
+}
+
+// SaveBusinessMember invites a user to an organization or changes the role of a member, which only an admin may do
+func (s *Service) SaveBusinessMember(ctx *gin.Context) {
+	var req SaveBusinessMemberRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		util.Error("Invalid params", zap.Error(err))
+		problem.Respond(ctx, domain.ErrInvalidParameters.Wrap(err))
+		return
+	}
+	businessId, adminId, ok := businessParams(ctx, req.AdminUserId)
+	if !ok {
+		return
+	}
+	userId, err := uuid.Parse(ctx.Param("user_id"))
+	if err != nil {
+		problem.Respond(ctx, domain.ErrInvalidAccount.Wrap(err))
+		return
+	}
+	audit.Actor(ctx, audit.ActorUser, adminId.String())
+	audit.Target(ctx, audit.TargetBusiness, businessId.String())
+
+	member, err := s.businesses.SaveMember(ctx.Request.Context(), business.MemberCommand{
+		OrganizationID: businessId,
+		AdminUserID:    adminId,
+		UserID:         userId,
+		Role:           req.Role,
+	})
+	if err != nil {
+		util.Error("Save business member failed", zap.String("business_id", businessId.String()), zap.Error(err))
+		problem.Respond(ctx, err)
+		return
+	}
+	response := newBusinessMember(member)
+	audit.Change(ctx, nil, response)
+	ctx.JSON(http.StatusOK, response)
+}
+
+// AcceptBusinessInvitation makes the invited user of the path an active member
+func (s *Service) AcceptBusinessInvitation(ctx *gin.Context) {
+	businessId, userId, ok := businessParams(ctx, ctx.Param("user_id"))
+	if !ok {
+		return
+	}
+	audit.Actor(ctx, audit.ActorUser, userId.String())
+	audit.Target(ctx, audit.TargetBusiness, businessId.String())
+	member, err := s.businesses.Accept(ctx.Request.Context(), businessId, userId)
+	if err != nil {
+		util.Error("Accept business invitation failed", zap.String("business_id", businessId.String()), zap.Error(err))
+		problem.Respond(ctx, err)
+		return
+	}
+	response := newBusinessMember(member)
+	audit.Change(ctx, gin.H{"status": wallet.BusinessMemberInvited}, response)
+	ctx.JSON(http.StatusOK, response)
+}
+
+// RemoveBusinessMember takes a member or an invitation away, an admin removes anyone and a member may leave
+func (s *Service) RemoveBusinessMember(ctx *gin.Context) {
+	businessId, byUserId, ok := businessParams(ctx, ctx.Query("by_user_id"))
+	if !ok {
+		return
+	}
+	userId, err := uuid.Parse(ctx.Param("user_id"))
+	if err != nil {
+		problem.Respond(ctx, domain.ErrInvalidAccount.Wrap(err))
+		return
+	}
+	audit.Actor(ctx, audit.ActorUser, byUserId.String())
+	audit.Target(ctx, audit.TargetBusiness, businessId.String())
+	if err := s.businesses.RemoveMember(ctx.Request.Context(), businessId, byUserId, userId); err != nil {
+		util.Error("Remove business member failed", zap.String("business_id", businessId.String()), zap.Error(err))
+		problem.Respond(ctx, err)
+		return
+	}
+	audit.Change(ctx, gin.H{"user_id": userId.String()}, nil)
+	ctx.Status(http.StatusNoContent)
+}
+
+func (s *Service) GetPaymentPolicies(ctx *gin.Context) {
+	businessId, userId, ok := businessParams(ctx, ctx.Query("user_id"))
+	if !ok {
+		return
+	}
+	policies, err := s.businesses.Policies(businessId, userId)
+	if err != nil {
+		problem.Respond(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, newPaymentPolicies(policies))
+}
+
+// SetPaymentPolicies replaces the payment policies of an organization, which only an admin may do
+func (s *Service) SetPaymentPolicies(ctx *gin.Context) {
+	var req SetPaymentPoliciesRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		util.Error("Invalid params", zap.Error(err))
+		problem.Respond(ctx, domain.ErrInvalidParameters.Wrap(err))
+		return
+	}
+	businessId, adminId, ok := businessParams(ctx, req.AdminUserId)
+	if !ok {
+		return
+	}
+	audit.Actor(ctx, audit.ActorUser, adminId.String())
+	audit.Target(ctx, audit.TargetBusiness, businessId.String())
+	commands := make([]business.PolicyCommand, 0, len(req.Policies))
+	for _, policy := range req.Policies {
+		aboveAmount, err := util.ConvertToInt(policy.AboveAmount)
+		if err != nil {
+			problem.Respond(ctx, domain.ErrInvalidParameters.Wrap(err))
+			return
+		}
+		commands = append(commands, business.PolicyCommand{AboveAmount: aboveAmount, Approvals: policy.Approvals})
+	}
+
+	before, err := s.businesses.Policies(businessId, adminId)
+	if err != nil {
+		problem.Respond(ctx, err)
+		return
+	}
+	policies, err := s.businesses.SetPolicies(ctx.Request.Context(), businessId, adminId, commands)
+	if err != nil {
+		util.Error("Set payment policies failed", zap.String("business_id", businessId.String()), zap.Error(err))
+		problem.Respond(ctx, err)
+		return
+	}
+	response := newPaymentPolicies(policies)
+	audit.Change(ctx, newPaymentPolicies(before), response)
+	ctx.JSON(http.StatusOK, response)
+}
+
+// GetBusinessBalance shows the balance of a business account to its members
+func (s *Service) GetBusinessBalance(ctx *gin.Context) {
+	businessId, userId, ok := businessParams(ctx, ctx.Query("user_id"))
+	if !ok {
+		return
+	}
+	organization, _, err := s.businesses.Member(businessId, userId)
+	if err != nil {
+		problem.Respond(ctx, err)
+		return
+	}
+	customer, err := s.ledger.Customer(organization.ID)
+	if err != nil {
+		problem.Respond(ctx, err)
+		return
+	}
+	s.respondBalance(ctx, customer)
+}
+
+// GetBusinessPaymentHistory shows every payment of a business account to its members
+func (s *Service) GetBusinessPaymentHistory(ctx *gin.Context) {
+	businessId, userId, ok := businessParams(ctx, ctx.Query("user_id"))
+	if !ok {
+		return
+	}
+	organization, _, err := s.businesses.Member(businessId, userId)
+	if err != nil {
+		problem.Respond(ctx, err)
+		return
+	}
+	s.respondPaymentHistory(ctx, organization.ID, "")
+}
+
+// CreateBusinessTransfer pays out of a business account, or queues the transfer for approval when a payment policy
+// covers the amount, in which case it answers 202
+func (s *Service) CreateBusinessTransfer(ctx *gin.Context) {
+	var req CreateBusinessTransferRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		util.Error("Invalid params", zap.Error(err))
+		problem.Respond(ctx, domain.ErrInvalidParameters.Wrap(err))
+		return
+	}
+	businessId, initiatorId, ok := businessParams(ctx, req.InitiatorUserId)
+	if !ok {
+		return
+	}
+	toUserId, err := uuid.Parse(req.ToUserId)
+	if err != nil {
+		problem.Respond(ctx, domain.ErrInvalidAccount.Wrap(err))
+		return
+	}
+	audit.Actor(ctx, audit.ActorUser, initiatorId.String())
+	audit.Target(ctx, audit.TargetBusiness, businessId.String())
+	amount, err := util.ConvertToInt(req.Amount)
+	if err != nil {
+		problem.Respond(ctx, domain.ErrInvalidParameters.Wrap(err))
+		return
+	}
+
+	transfer, err := s.businesses.Transfer(ctx.Request.Context(), business.TransferCommand{
+		OrganizationID:  businessId,
+		InitiatorUserID: initiatorId,
+		ToUserID:        toUserId,
+		Amount:          amount,
+		Memo:            req.Memo,
+		RequestID:       ctx.GetHeader(tracing.RequestIDHeader),
+	})
+	if err != nil {
+		util.Error("Business transfer failed", zap.String("business_id", businessId.String()), zap.Error(err))
+		problem.Respond(ctx, err)
+		return
+	}
+	response := newBusinessTransfer(transfer, nil)
+	audit.Change(ctx, nil, response)
+	if transfer.Status == payment.BusinessTransferPending {
+		ctx.JSON(http.StatusAccepted, response)
+		return
+	}
+	ctx.JSON(http.StatusCreated, response)
+}
+
+// GetBusinessTransfers lists the transfers out of a business account, status PENDING is the queue waiting for approval
+// with the oldest first
+func (s *Service) GetBusinessTransfers(ctx *gin.Context) {
+	businessId, userId, ok := businessParams(ctx, ctx.Query("user_id"))
+	if !ok {
+		return
+	}
+	if _, _, err := s.businesses.Member(businessId, userId); err != nil {
+		problem.Respond(ctx, err)
+		return
+	}
+	transfers, err := s.businessTransferRepo.SearchBusinessTransfers(businessId, ctx.Query("status"), maxBusinessTransfers)
+	if err != nil {
+		util.Error("Search business transfers failed", zap.Error(err))
+		problem.Respond(ctx, err)
+		return
+	}
+	response := SearchBusinessTransferResponse{Transfers: []BusinessTransfer{}}
+	for i := range transfers {
+		response.Transfers = append(response.Transfers, *newBusinessTransfer(&transfers[i], nil))
+	}
+	ctx.JSON(http.StatusOK, &response)
+}
+
+// GetBusinessTransfer shows a transfer with the trail of decisions on it
+func (s *Service) GetBusinessTransfer(ctx *gin.Context) {
+	businessId, userId, ok := businessParams(ctx, ctx.Query("user_id"))
+	if !ok {
+		return
+	}
+	if _, _, err := s.businesses.Member(businessId, userId); err != nil {
+		problem.Respond(ctx, err)
+		return
+	}
+	transfer, ok := s.businessTransfer(ctx, businessId)
+	if !ok {
+		return
+	}
+	s.respondBusinessTransfer(ctx, transfer)
+}
+
+// ApproveBusinessTransfer records an approval and pays the transfer once it has the approvals its policy asks for
+func (s *Service) ApproveBusinessTransfer(ctx *gin.Context) {
+	s.decideBusinessTransfer(ctx, s.businesses.Approve)
+}
+
+// RejectBusinessTransfer closes a pending transfer without paying it
+func (s *Service) RejectBusinessTransfer(ctx *gin.Context) {
+	s.decideBusinessTransfer(ctx, s.businesses.Reject)
+}
+
+func (s *Service) decideBusinessTransfer(ctx *gin.Context, decide func(ctx context.Context, id uuid.UUID, userID uuid.UUID, comment string) (*payment.BusinessTransfer, error)) {
+	var req DecideBusinessTransferRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		util.Error("Invalid params", zap.Error(err))
+		problem.Respond(ctx, domain.ErrInvalidParameters.Wrap(err))
+		return
+	}
+	businessId, userId, ok := businessParams(ctx, req.UserId)
+	if !ok {
+		return
+	}
+	audit.Actor(ctx, audit.ActorUser, userId.String())
+	audit.Target(ctx, audit.TargetBusinessTransfer, ctx.Param("transfer_id"))
+	found, ok := s.businessTransfer(ctx, businessId)
+	if !ok {
+		return
+	}
+	transfer, err := decide(ctx.Request.Context(), found.ID, userId, req.Comment)
+	if err != nil {
+		util.Error("Decide business transfer failed", zap.String("business_transfer_id", found.ID.String()), zap.Error(err))
+		problem.Respond(ctx, err)
+		return
+	}
+	audit.Change(ctx, gin.H{"status": found.Status}, gin.H{"status": transfer.Status, "group_id": transfer.GroupID, "comment": req.Comment})
+	s.respondBusinessTransfer(ctx, transfer)
+}
+
+// businessTransfer reads the transfer of the path, a transfer of another organization is not found
+func (s *Service) businessTransfer(ctx *gin.Context, businessId uuid.UUID) (*payment.BusinessTransfer, bool) {
+	transferId, err := uuid.Parse(ctx.Param("transfer_id"))
+	if err != nil {
+		problem.Respond(ctx, domain.ErrInvalidParameters.Wrap(err))
+		return nil, false
+	}
+	transfer, err := s.businessTransferRepo.GetBusinessTransfer(transferId)
+	if err == nil && transfer.OrganizationID != businessId {
+		err = domain.ErrNotFound.WithMessage("business transfer not found")
+	}
+	if err != nil {
+		problem.Respond(ctx, err)
+		return nil, false
+	}
+	return transfer, true
+}
+
+func (s *Service) respondBusinessTransfer(ctx *gin.Context, transfer *payment.BusinessTransfer) {
+	approvals, err := s.businesses.Approvals(transfer.ID)
+	if err != nil {
+		util.Error("Search business approvals failed", zap.String("business_transfer_id", transfer.ID.String()), zap.Error(err))
+		problem.Respond(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, newBusinessTransfer(transfer, approvals))
+}
+
+// businessParams parses the organization of the path and the user acting on it, answering the request when one is
+// invalid
+func businessParams(ctx *gin.Context, userIdParam string) (uuid.UUID, uuid.UUID, bool) {
+	businessId, err := uuid.Parse(ctx.Param("business_id"))
+	if err != nil {
+		problem.Respond(ctx, domain.ErrInvalidParameters.Wrap(err))
+		return uuid.Nil, uuid.Nil, false
+	}
+	userId, err := uuid.Parse(userIdParam)
+	if err != nil {
+		problem.Respond(ctx, domain.ErrInvalidAccount.Wrap(err))
+		return uuid.Nil, uuid.Nil, false
+	}
+	return businessId, userId, true
+}
+
+func newBusiness(organization *wallet.Organization) *Business {
+	return &Business{
+		BusinessId:         organization.ID.String(),
+		LegalName:          organization.LegalName,
+		RegistrationNumber: organization.RegistrationNumber,
+		Country:            organization.Country,
+		Email:              organization.Email,
+		CreatedAt:          organization.CreatedAt.UTC().Format(time.RFC3339),
+	}
+}
+
+func newBusinessMember(member *wallet.BusinessMember) *BusinessMember {
+	response := &BusinessMember{
+		BusinessId: member.OrganizationID.String(),
+		UserId:     member.UserID.String(),
+		Role:       member.Role,
+		Status:     member.Status,
+		InvitedBy:  member.InvitedByUserID.String(),
+	}
+	if member.JoinedAt != nil {
+		response.JoinedAt = member.JoinedAt.UTC().Format(time.RFC3339)
+	}
+	return response
+}
+
+func newPaymentPolicies(policies []payment.PaymentPolicy) *SearchPaymentPolicyResponse {
+	response := &SearchPaymentPolicyResponse{Policies: []PaymentPolicy{}}
+	for _, policy := range policies {
+		response.Policies = append(response.Policies, PaymentPolicy{AboveAmount: util.DisplayAmount(policy.AboveAmount), Approvals: policy.Approvals})
+	}
+	return response
+}
+
+func newBusinessTransfer(transfer *payment.BusinessTransfer, approvals []payment.BusinessApproval) *BusinessTransfer {
+	response := &BusinessTransfer{
+		TransferId:        transfer.ID.String(),
+		BusinessId:        transfer.OrganizationID.String(),
+		InitiatorUserId:   transfer.InitiatorUserID.String(),
+		ToUserId:          transfer.ToUserID.String(),
+		Amount:            util.DisplayAmount(transfer.Amount),
+		Memo:              transfer.Memo,
+		RequiredApprovals: transfer.RequiredApprovals,
+		Status:            transfer.Status,
+		CreatedAt:         transfer.CreatedAt.UTC().Format(time.RFC3339),
+	}
+	if transfer.GroupID != nil {
+		response.GroupId = transfer.GroupID.String()
+	}
+	if transfer.DecidedAt != nil {
+		response.DecidedAt = transfer.DecidedAt.UTC().Format(time.RFC3339)
+	}
+	for _, approval := range approvals {
+		response.Approvals = append(response.Approvals, BusinessApproval{
+			UserId:    approval.UserID.String(),
+			Decision:  approval.Decision,
+			Comment:   approval.Comment,
+			CreatedAt: approval.CreatedAt.UTC().Format(time.RFC3339),
+		})
+	}
+	return response
+}
+
+type OpenBusinessRequest struct {
+	UserId             string `json:"user_id" binding:"required"`
+	LegalName          string `json:"legal_name" binding:"required"`
+	RegistrationNumber string `json:"registration_number" binding:"required"`
+	Country            string `json:"country" binding:"required"`
+	Email              string `json:"email" binding:"required"`
+}
+
+type SaveBusinessMemberRequest struct {
+	AdminUserId string `json:"admin_user_id" binding:"required"`
+	Role        string `json:"role" binding:"required"`
+}
+
+type SetPaymentPoliciesRequest struct {
+	AdminUserId string          `json:"admin_user_id" binding:"required"`
+	Policies    []PaymentPolicy `json:"policies"`
+}
+
+type CreateBusinessTransferRequest struct {
+	InitiatorUserId string `json:"initiator_user_id" binding:"required"`
+	ToUserId        string `json:"to_user_id" binding:"required"`
+	Amount          string `json:"amount" binding:"required"`
+	Memo            string `json:"memo"`
+}
+
+type DecideBusinessTransferRequest struct {
+	UserId  string `json:"user_id" binding:"required"`
+	Comment string `json:"comment"`
+}
+
+type Business struct {
+	BusinessId         string `json:"business_id" binding:"required"`
+	LegalName          string `json:"legal_name" binding:"required"`
+	RegistrationNumber string `json:"registration_number" binding:"required"`
+	Country            string `json:"country" binding:"required"`
+	Email              string `json:"email" binding:"required"`
+	CreatedAt          string `json:"created_at" binding:"required"`
+}
+
+type BusinessMember struct {
+	BusinessId string `json:"business_id" binding:"required"`
+	UserId     string `json:"user_id" binding:"required"`
+	Role       string `json:"role" binding:"required"`
+	Status     string `json:"status" binding:"required"`
+	InvitedBy  string `json:"invited_by" binding:"required"`
+	JoinedAt   string `json:"joined_at,omitempty"`
+}
+
+type SearchBusinessMemberResponse struct {
+	Members []BusinessMember `json:"members"`
+}
+
+type PaymentPolicy struct {
+	AboveAmount string `json:"above_amount" binding:"required"`
+	Approvals   int    `json:"approvals" binding:"required"`
+}
+
+type SearchPaymentPolicyResponse struct {
+	Policies []PaymentPolicy `json:"policies"`
+}
+
+type BusinessApproval struct {
+	UserId    string `json:"user_id" binding:"required"`
+	Decision  string `json:"decision" binding:"required"`
+	Comment   string `json:"comment,omitempty"`
+	CreatedAt string `json:"created_at" binding:"required"`
+}
+
+type BusinessTransfer struct {
+	TransferId        string             `json:"transfer_id" binding:"required"`
+	BusinessId        string             `json:"business_id" binding:"required"`
+	InitiatorUserId   string             `json:"initiator_user_id" binding:"required"`
+	ToUserId          string             `json:"to_user_id" binding:"required"`
+	Amount            string             `json:"amount" binding:"required"`
+	Memo              string             `json:"memo,omitempty"`
+	RequiredApprovals int                `json:"required_approvals"`
+	Status            string             `json:"status" binding:"required"`
+	GroupId           string             `json:"group_id,omitempty"`
+	CreatedAt         string             `json:"created_at" binding:"required"`
+	DecidedAt         string             `json:"decided_at,omitempty"`
+	Approvals         []BusinessApproval `json:"approvals,omitempty"`
+}
+
+type SearchBusinessTransferResponse struct {
+	Transfers []BusinessTransfer `json:"transfers"`
+}
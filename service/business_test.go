@@ -0,0 +1,224 @@
+package service
+
+import (
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+)
+
+func TestBusinessTransfersWaitForTheApprovalsOfTheirPolicy(t *testing.T) {
+	_, _, cleanup, err := setupTestDB()
+	if err != nil {
+		t.Fatalf("failed to set up test DB: %v", err)
+	}
+	defer cleanup()
+
+	resp := postJSON("/api/v1/businesses", map[string]string{"user_id": johnUserId, "legal_name": "Acme Limited", "registration_number": "HK-1234", "country": "HK", "email": "finance@acme.test"})
+	assert.Equal(t, http.StatusCreated, resp.Code)
+	var business Business
+	assert.NoError(t, json.Unmarshal(resp.Body.Bytes(), &business))
+	businessPath := "/api/v1/businesses/" + business.BusinessId
+	deposit := postJSON("/api/v1/wallet/deposit", map[string]string{"user_id": business.BusinessId, "balance": "1000"})
+	assert.Equal(t, http.StatusOK, deposit.Code)
+
+	// a user who is not a member does not see the business, an invited one neither until they accept
+	getAccountResource(t, businessPath+"/balance?user_id="+rayUserId, http.StatusNotFound, nil)
+	resp = putJSON(businessPath+"/members/"+rayUserId, map[string]string{"admin_user_id": johnUserId, "role": "APPROVER"})
+	assert.Equal(t, http.StatusOK, resp.Code)
+	getAccountResource(t, businessPath+"/balance?user_id="+rayUserId, http.StatusNotFound, nil)
+	resp = postJSON(businessPath+"/members/"+rayUserId+"/accept", nil)
+	assert.Equal(t, http.StatusOK, resp.Code)
+	var balance GetCustomerBalanceResponse
+	getAccountResource(t, businessPath+"/balance?user_id="+rayUserId, http.StatusOK, &balance)
+	assert.Equal(t, "1000.00", balance.Balance)
+
+	// an approver initiates nothing and the business pays nothing outside of its members
+	resp = postJSON(businessPath+"/transfers", map[string]string{"initiator_user_id": rayUserId, "to_user_id": rayUserId, "amount": "10"})
+	assert.Equal(t, http.StatusForbidden, resp.Code)
+	resp = postJSON("/api/v1/wallet/transfer", map[string]string{"debit_user_id": business.BusinessId, "credit_user_id": rayUserId, "balance": "10"})
+	assert.Equal(t, http.StatusForbidden, resp.Code)
+
+	resp = putJSON(businessPath+"/policies", map[string]interface{}{"admin_user_id": johnUserId, "policies": []map[string]interface{}{{"above_amount": "100", "approvals": 1}}})
+	assert.Equal(t, http.StatusOK, resp.Code)
+	resp = postJSON(businessPath+"/transfers", map[string]string{"initiator_user_id": johnUserId, "to_user_id": rayUserId, "amount": "50"})
+	assert.Equal(t, http.StatusCreated, resp.Code)
+	resp = postJSON(businessPath+"/transfers", map[string]string{"initiator_user_id": johnUserId, "to_user_id": rayUserId, "amount": "200", "memo": "invoice 42"})
+	assert.Equal(t, http.StatusAccepted, resp.Code)
+	var pending BusinessTransfer
+	assert.NoError(t, json.Unmarshal(resp.Body.Bytes(), &pending))
+	assert.Equal(t, "PENDING", pending.Status)
+	assert.Equal(t, 1, pending.RequiredApprovals)
+	var queue SearchBusinessTransferResponse
+	getAccountResource(t, businessPath+"/transfers?status=PENDING&user_id="+rayUserId, http.StatusOK, &queue)
+	assert.Len(t, queue.Transfers, 1)
+
+	// the initiator does not approve their own transfer, another approver does and pays it
+	resp = postJSON(businessPath+"/transfers/"+pending.TransferId+"/approve", map[string]string{"user_id": johnUserId})
+	assert.Equal(t, http.StatusForbidden, resp.Code)
+	resp = postJSON(businessPath+"/transfers/"+pending.TransferId+"/approve", map[string]string{"user_id": rayUserId, "comment": "matches the invoice"})
+	assert.Equal(t, http.StatusOK, resp.Code)
+	var approved BusinessTransfer
+	assert.NoError(t, json.Unmarshal(resp.Body.Bytes(), &approved))
+	assert.Equal(t, "COMPLETED", approved.Status)
+	assert.NotEmpty(t, approved.GroupId)
+	assert.Len(t, approved.Approvals, 1)
+	assert.Equal(t, rayUserId, approved.Approvals[0].UserId)
+	resp = postJSON(businessPath+"/transfers/"+pending.TransferId+"/reject", map[string]string{"user_id": rayUserId})
+	assert.Equal(t, http.StatusConflict, resp.Code)
+
+	var history SearchPaymentHistoryResponse
+	getAccountResource(t, businessPath+"/payment-history?user_id="+rayUserId, http.StatusOK, &history)
+	assert.Len(t, history.Histories, 3)
+	getAccountResource(t, businessPath+"/balance?user_id="+johnUserId, http.StatusOK, &balance)
+	assert.Equal(t, "750.00", balance.Balance)
+}
+
+// failCompletions makes the database fail every update that completes a transfer of table, as a connection lost after
+// the ledger booked the transfer would, until the returned func drops the trigger
+func failCompletions(t *testing.T, db *gorm.DB, table string) func() {
+	assert.NoError(t, db.Exec(`create or replace function fail_completion() returns trigger as $$
+begin
+    if new.status = 'COMPLETED' then
+        raise exception 'connection reset by peer';
+    end if;
+    return new;
+end
+$$ language plpgsql`).Error)
+	assert.NoError(t, db.Exec("create trigger fail_completion before update on "+table+" for each row execute function fail_completion()").Error)
+	return func() {
+		assert.NoError(t, db.Exec("drop trigger fail_completion on "+table).Error)
+	}
+}
+
+// race sends n requests at once through a pool of fewer connections than requests, a request holding a connection
+// while it waits for another would starve the pool
+func race(t *testing.T, db *gorm.DB, n int, send func() *httptest.ResponseRecorder) []*httptest.ResponseRecorder {
+	pool, err := db.DB()
+	assert.NoError(t, err)
+	pool.SetMaxOpenConns(2)
+	defer pool.SetMaxOpenConns(0)
+	responses := make([]*httptest.ResponseRecorder, n)
+	var wg sync.WaitGroup
+	for i := range responses {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			responses[i] = send()
+		}(i)
+	}
+	wg.Wait()
+	return responses
+}
+
+// openBusiness has John open Acme with 1000.00, Ray as an approver and one approval asked above 100.00
+func openBusiness(t *testing.T) string {
+	resp := postJSON("/api/v1/businesses", map[string]string{"user_id": johnUserId, "legal_name": "Acme Limited", "registration_number": "HK-1234", "country": "HK", "email": "finance@acme.test"})
+	assert.Equal(t, http.StatusCreated, resp.Code)
+	var business Business
+	assert.NoError(t, json.Unmarshal(resp.Body.Bytes(), &business))
+	businessPath := "/api/v1/businesses/" + business.BusinessId
+	resp = postJSON("/api/v1/wallet/deposit", map[string]string{"user_id": business.BusinessId, "balance": "1000"})
+	assert.Equal(t, http.StatusOK, resp.Code)
+	resp = putJSON(businessPath+"/members/"+rayUserId, map[string]string{"admin_user_id": johnUserId, "role": "APPROVER"})
+	assert.Equal(t, http.StatusOK, resp.Code)
+	resp = postJSON(businessPath+"/members/"+rayUserId+"/accept", nil)
+	assert.Equal(t, http.StatusOK, resp.Code)
+	resp = putJSON(businessPath+"/policies", map[string]interface{}{"admin_user_id": johnUserId, "policies": []map[string]interface{}{{"above_amount": "100", "approvals": 1}}})
+	assert.Equal(t, http.StatusOK, resp.Code)
+	return businessPath
+}
+
+// pendingBusinessTransfer has John ask for amount to be paid to Ray, which waits for an approval
+func pendingBusinessTransfer(t *testing.T, businessPath string, amount string) BusinessTransfer {
+	resp := postJSON(businessPath+"/transfers", map[string]string{"initiator_user_id": johnUserId, "to_user_id": rayUserId, "amount": amount})
+	assert.Equal(t, http.StatusAccepted, resp.Code)
+	var pending BusinessTransfer
+	assert.NoError(t, json.Unmarshal(resp.Body.Bytes(), &pending))
+	return pending
+}
+
+func businessBalance(t *testing.T, businessPath string) string {
+	var balance GetCustomerBalanceResponse
+	getAccountResource(t, businessPath+"/balance?user_id="+johnUserId, http.StatusOK, &balance)
+	return balance.Balance
+}
+
+func TestBusinessTransferApprovedTwiceAtOnceIsPaidOnce(t *testing.T) {
+	db, _, cleanup, err := setupTestDB()
+	if err != nil {
+		t.Fatalf("failed to set up test DB: %v", err)
+	}
+	defer cleanup()
+
+	businessPath := openBusiness(t)
+	pending := pendingBusinessTransfer(t, businessPath, "200")
+	responses := race(t, db, 8, func() *httptest.ResponseRecorder {
+		return postJSON(businessPath+"/transfers/"+pending.TransferId+"/approve", map[string]string{"user_id": rayUserId})
+	})
+	for _, resp := range responses {
+		assert.Equal(t, http.StatusOK, resp.Code)
+		var approved BusinessTransfer
+		assert.NoError(t, json.Unmarshal(resp.Body.Bytes(), &approved))
+		assert.Equal(t, "COMPLETED", approved.Status)
+		assert.Len(t, approved.Approvals, 1)
+	}
+	assert.Equal(t, "800.00", businessBalance(t, businessPath))
+	var history SearchPaymentHistoryResponse
+	getAccountResource(t, businessPath+"/payment-history?user_id="+johnUserId, http.StatusOK, &history)
+	assert.Len(t, history.Histories, 2)
+}
+
+func TestRejectedBusinessTransferIsNotPaid(t *testing.T) {
+	_, _, cleanup, err := setupTestDB()
+	if err != nil {
+		t.Fatalf("failed to set up test DB: %v", err)
+	}
+	defer cleanup()
+
+	businessPath := openBusiness(t)
+	pending := pendingBusinessTransfer(t, businessPath, "200")
+	resp := postJSON(businessPath+"/transfers/"+pending.TransferId+"/reject", map[string]string{"user_id": rayUserId, "comment": "no invoice"})
+	assert.Equal(t, http.StatusOK, resp.Code)
+	var rejected BusinessTransfer
+	assert.NoError(t, json.Unmarshal(resp.Body.Bytes(), &rejected))
+	assert.Equal(t, "REJECTED", rejected.Status)
+	assert.Empty(t, rejected.GroupId)
+
+	// the approver decided already and the initiator finds it closed
+	resp = postJSON(businessPath+"/transfers/"+pending.TransferId+"/approve", map[string]string{"user_id": rayUserId})
+	assert.Equal(t, http.StatusBadRequest, resp.Code)
+	resp = postJSON(businessPath+"/transfers/"+pending.TransferId+"/reject", map[string]string{"user_id": johnUserId})
+	assert.Equal(t, http.StatusConflict, resp.Code)
+	assert.Equal(t, "1000.00", businessBalance(t, businessPath))
+}
+
+func TestBusinessTransferFailingToCompleteIsNotPaid(t *testing.T) {
+	db, _, cleanup, err := setupTestDB()
+	if err != nil {
+		t.Fatalf("failed to set up test DB: %v", err)
+	}
+	defer cleanup()
+
+	businessPath := openBusiness(t)
+	pending := pendingBusinessTransfer(t, businessPath, "200")
+	restore := failCompletions(t, db, "business_transfer")
+	resp := postJSON(businessPath+"/transfers/"+pending.TransferId+"/approve", map[string]string{"user_id": rayUserId})
+	assert.Equal(t, http.StatusInternalServerError, resp.Code)
+	// the transfer went with the update of its row, the approval was kept before
+	var transfer BusinessTransfer
+	getAccountResource(t, businessPath+"/transfers/"+pending.TransferId+"?user_id="+johnUserId, http.StatusOK, &transfer)
+	assert.Equal(t, "PENDING", transfer.Status)
+	assert.Len(t, transfer.Approvals, 1)
+	assert.Equal(t, "1000.00", businessBalance(t, businessPath))
+
+	restore()
+	resp = postJSON(businessPath+"/transfers/"+pending.TransferId+"/approve", map[string]string{"user_id": rayUserId})
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.NoError(t, json.Unmarshal(resp.Body.Bytes(), &transfer))
+	assert.Equal(t, "COMPLETED", transfer.Status)
+	assert.Equal(t, "800.00", businessBalance(t, businessPath))
+}
@@ -0,0 +1,41 @@
+package service
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"github.com/raychongtk/wallet/client"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestGeneratedClientSpeaksTheContract(t *testing.T) {
+	_, _, cleanup, err := setupTestDB()
+	if err != nil {
+		t.Fatalf("failed to set up test DB: %v", err)
+	}
+	defer cleanup()
+
+	server := httptest.NewServer(ProvideRoutes(service))
+	defer server.Close()
+	walletClient, err := client.NewClientWithResponses(server.URL)
+	assert.NoError(t, err)
+	ctx := context.Background()
+
+	params := &client.DepositParams{XRequestID: uuid.New().String()}
+	body := client.DepositRequest{UserId: "2d988f4a-a037-4ce9-a350-f13445793e88", Balance: "100"}
+	deposited, err := walletClient.DepositWithResponse(ctx, params, body)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, deposited.StatusCode())
+	assert.True(t, deposited.JSON200.Result)
+
+	duplicated, err := walletClient.DepositWithResponse(ctx, params, body)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusConflict, duplicated.StatusCode())
+	assert.Equal(t, "DUPLICATE_REQUEST", duplicated.JSONDefault.ErrorCode)
+
+	balance, err := walletClient.GetBalanceWithResponse(ctx, &client.GetBalanceParams{UserId: "2d988f4a-a037-4ce9-a350-f13445793e88"})
+	assert.NoError(t, err)
+	assert.Equal(t, "100.00", balance.JSON200.Balance)
+}
@@ -3,14 +3,14 @@ package service
 import (
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
-	"github.com/raychongtk/wallet/model/movement"
-	"github.com/raychongtk/wallet/model/payment"
-	"github.com/raychongtk/wallet/model/wallet"
+	"github.com/raychongtk/wallet/audit"
+	"github.com/raychongtk/wallet/domain"
+	"github.com/raychongtk/wallet/ledger"
+	"github.com/raychongtk/wallet/problem"
+	"github.com/raychongtk/wallet/tracing"
 	"github.com/raychongtk/wallet/util"
 	"go.uber.org/zap"
-	"gorm.io/gorm"
 	"net/http"
-	"time"
 )
 
 func (s *Service) Deposit(ctx *gin.Context) {
@@ -18,157 +18,37 @@ func (s *Service) Deposit(ctx *gin.Context) {
 	err := ctx.ShouldBindJSON(&req)
 	if err != nil {
 		util.Error("Invalid params", zap.Error(err))
-		ctx.JSON(http.StatusBadRequest, &DepositResponse{Result: false, ErrorCode: "INVALID_PARAMETERS"})
+		problem.Respond(ctx, domain.ErrInvalidParameters.Wrap(err))
 		return
 	}
 	userId, err := uuid.Parse(req.UserId)
 	if err != nil {
 		util.Error("Invalid user", zap.Error(err))
-		ctx.JSON(http.StatusBadRequest, &DepositResponse{Result: false, ErrorCode: "INVALID_ACCOUNT"})
+		problem.Respond(ctx, domain.ErrInvalidAccount.Wrap(err))
 		return
 	}
+	audit.Actor(ctx, audit.ActorUser, userId.String())
+	audit.Target(ctx, audit.TargetUser, userId.String())
 	balance, err := util.ConvertToInt(req.Balance)
-	if err != nil || balance <= 0 {
-		ctx.JSON(http.StatusBadRequest, &TransferResponse{Result: false, ErrorCode: "INVALID_PARAMETERS"})
-		return
-	}
-
-	appUser, err := s.userRepo.GetUser(userId)
-	if err != nil {
-		util.Error("Invalid user", zap.Error(err))
-		ctx.JSON(http.StatusBadRequest, &DepositResponse{Result: false, ErrorCode: "INVALID_ACCOUNT"})
-		return
-	}
-	account, err := s.accountRepo.GetAccount(appUser.ID)
-	if err != nil {
-		util.Error("Invalid account", zap.Error(err))
-		ctx.JSON(http.StatusBadRequest, &DepositResponse{Result: false, ErrorCode: "INVALID_ACCOUNT"})
-		return
-	}
-	userWallet, err := s.walletRepo.GetWallet(account.ID)
 	if err != nil {
-		util.Error("Invalid wallet", zap.Error(err))
-		ctx.JSON(http.StatusBadRequest, &DepositResponse{Result: false, ErrorCode: "INVALID_ACCOUNT"})
+		problem.Respond(ctx, domain.ErrInvalidParameters.Wrap(err))
 		return
 	}
 
-	tx := s.db.Begin()
-	defer func() {
-		if r := recover(); r != nil {
-			tx.Rollback()
-			ctx.JSON(http.StatusInternalServerError, &DepositResponse{Result: false, ErrorCode: "INTERNAL_ERROR"})
-		}
-	}()
-	groupId := uuid.New()
-	newMovement := &movement.Movement{
-		ID:             uuid.New(),
-		GroupID:        groupId,
-		DebitWalletID:  util.GetAssetAccount(),
-		CreditWalletID: userWallet.ID,
-		DebitBalance:   balance,
-		CreditBalance:  balance,
-		MovementStatus: "COMPLETED",
-		CreatedAt:      time.Now(),
-		UpdatedAt:      time.Now(),
-	}
-
-	createdMovement, err := s.movementRepo.CreateMovement(tx, newMovement)
-	if err != nil {
-		tx.Rollback()
-		util.Error("Create movement failed", zap.Error(err))
-		ctx.JSON(http.StatusBadRequest, &DepositResponse{Result: false, ErrorCode: "INTERNAL_ERROR"})
-		return
-	}
-	transactions := GenerateTransactions(balance, userWallet.ID, createdMovement.ID)
-	err = s.transactionRepo.CreateTransactions(tx, transactions)
-	if err != nil {
-		tx.Rollback()
-		util.Error("Create payment transaction failed", zap.Error(err))
-		ctx.JSON(http.StatusBadRequest, &DepositResponse{Result: false, ErrorCode: "INTERNAL_ERROR"})
-		return
-	}
-	paymentHistory := &payment.PaymentHistory{
-		ID:          uuid.New(),
-		PayerUserId: userId.String(),
-		PayerName:   appUser.FirstName + " " + appUser.LastName,
-		PayeeUserId: "System",
-		PayeeName:   "System",
-		PayType:     "DEPOSIT",
-		Amount:      balance,
-		CreatedAt:   time.Now(),
-		UpdatedAt:   time.Now(),
-	}
-	_, err = s.paymentHistoryRepo.CreatePaymentHistory(tx, paymentHistory)
+	result, err := s.ledger.Deposit(ctx.Request.Context(), ledger.DepositCommand{
+		UserID:    userId,
+		Amount:    balance,
+		RequestID: ctx.GetHeader(tracing.RequestIDHeader),
+	})
 	if err != nil {
-		tx.Rollback()
-		util.Error("Create history failed", zap.Error(err))
+		util.Error("Deposit failed", zap.String("user_id", userId.String()), zap.Int("balance", balance), zap.Error(err))
+		problem.Respond(ctx, err)
 		return
 	}
-	committed := commitBalance(s, userWallet, balance, tx)
-
-	if !committed {
-		tx.Rollback()
-		util.Error("Update balance failed", zap.Error(err))
-		ctx.JSON(http.StatusBadRequest, &DepositResponse{Result: false, ErrorCode: "INTERNAL_ERROR"})
-		return
-	}
-
-	if err := tx.Commit().Error; err != nil {
-		tx.Rollback()
-		util.Error("Commit transaction failed", zap.String("user_id", userId.String()), zap.Int("balance", balance), zap.Error(err))
-		ctx.JSON(http.StatusInternalServerError, &DepositResponse{Result: false, ErrorCode: "INTERNAL_ERROR"})
-		return
-	}
-
-	util.Info("Deposit successfully",
-		zap.String("user_id", userId.String()),
-		zap.Int("balance", balance),
-	)
+	auditResult(ctx, result)
 	ctx.JSON(http.StatusOK, &DepositResponse{Result: true})
 }
 
-func commitBalance(s *Service, wallet *wallet.Wallet, balance int, tx *gorm.DB) bool {
-	// Skip reserved balance because we don't need to wait for external clearing operations
-	customerAccountErr := s.balanceRepo.AddBalance(tx, wallet.ID, balance, "COMMITTED")
-	if customerAccountErr != nil {
-		tx.Rollback()
-		return false
-	}
-	chartAccountError := s.balanceRepo.AddBalance(tx, util.GetAssetAccount(), balance, "COMMITTED")
-	if chartAccountError != nil {
-		tx.Rollback()
-		return false
-	}
-	return true
-}
-
-func GenerateTransactions(balance int, creditWalletID uuid.UUID, movementID uuid.UUID) []movement.Transaction {
-	var transactions []movement.Transaction
-
-	debitTransaction := movement.Transaction{
-		ID:          uuid.New(),
-		MovementID:  movementID,
-		WalletID:    util.GetAssetAccount(),
-		BalanceType: "COMMITTED",
-		Balance:     balance,
-		CreatedAt:   time.Now(),
-		UpdatedAt:   time.Now(),
-	}
-	transactions = append(transactions, debitTransaction)
-
-	creditTransaction := movement.Transaction{
-		ID:          uuid.New(),
-		WalletID:    creditWalletID,
-		BalanceType: "COMMITTED",
-		Balance:     balance,
-		CreatedAt:   time.Now(),
-		UpdatedAt:   time.Now(),
-	}
-	transactions = append(transactions, creditTransaction)
-
-	return transactions
-}
-
 type DepositRequest struct {
 	UserId  string `json:"user_id" binding:"required"`
 	Balance string `json:"balance" binding:"required"`
@@ -0,0 +1,227 @@
+package service
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/raychongtk/wallet/audit"
+	"github.com/raychongtk/wallet/domain"
+	"github.com/raychongtk/wallet/escrow"
+	"github.com/raychongtk/wallet/model/payment"
+	"github.com/raychongtk/wallet/problem"
+	"github.com/raychongtk/wallet/tracing"
+	"github.com/raychongtk/wallet/util"
+	"go.uber.org/zap"
+	"net/http"
+	"time"
+)
+
+const maxEscrows = 100
+
+func (s *Service) CreateEscrow(ctx *gin.Context) {
+	var req CreateEscrowRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		util.Error("Invalid params", zap.Error(err))
+		problem.Respond(ctx, domain.ErrInvalidParameters.Wrap(err))
+		return
+	}
+	buyerId, err := uuid.Parse(req.BuyerUserId)
+	if err != nil {
+		problem.Respond(ctx, domain.ErrInvalidAccount.Wrap(err))
+		return
+	}
+	sellerId, err := uuid.Parse(req.SellerUserId)
+	if err != nil {
+		problem.Respond(ctx, domain.ErrInvalidAccount.Wrap(err))
+		return
+	}
+	audit.Actor(ctx, audit.ActorUser, buyerId.String())
+	audit.Target(ctx, audit.TargetUser, sellerId.String())
+	amount, err := util.ConvertToInt(req.Amount)
+	if err != nil {
+		problem.Respond(ctx, domain.ErrInvalidParameters.Wrap(err))
+		return
+	}
+	var deadlineIn time.Duration
+	if req.Deadline != "" {
+		deadline, err := time.Parse(time.RFC3339, req.Deadline)
+		if err != nil {
+			problem.Respond(ctx, domain.ErrInvalidParameters.WithMessage("deadline must be an RFC 3339 time"))
+			return
+		}
+		if deadlineIn = time.Until(deadline); deadlineIn <= 0 {
+			problem.Respond(ctx, domain.ErrInvalidParameters.WithMessage("deadline must be in the future"))
+			return
+		}
+	}
+
+	created, err := s.escrows.Create(ctx.Request.Context(), escrow.CreateCommand{
+		BuyerUserID:    buyerId,
+		SellerUserID:   sellerId,
+		Amount:         amount,
+		Reference:      req.Reference,
+		DeadlineIn:     deadlineIn,
+		DeadlineAction: req.DeadlineAction,
+		RequestID:      ctx.GetHeader(tracing.RequestIDHeader),
+	})
+	if err != nil {
+		util.Error("Create escrow failed", zap.String("buyer_user_id", buyerId.String()), zap.Error(err))
+		problem.Respond(ctx, err)
+		return
+	}
+	response := newEscrow(created)
+	audit.Target(ctx, audit.TargetEscrow, response.EscrowId)
+	audit.Change(ctx, nil, response)
+	ctx.JSON(http.StatusCreated, response)
+}
+
+// GetEscrows lists the escrows a user buys or sells in, newest first
+func (s *Service) GetEscrows(ctx *gin.Context) {
+	userId, err := uuid.Parse(ctx.Query("user_id"))
+	if err != nil {
+		problem.Respond(ctx, domain.ErrInvalidAccount.Wrap(err))
+		return
+	}
+	escrows, err := s.escrowRepo.SearchEscrows(userId, ctx.Query("status"), maxEscrows)
+	if err != nil {
+		util.Error("Search escrows failed", zap.Error(err))
+		problem.Respond(ctx, err)
+		return
+	}
+	response := SearchEscrowResponse{Escrows: []Escrow{}}
+	for i := range escrows {
+		response.Escrows = append(response.Escrows, *newEscrow(&escrows[i]))
+	}
+	ctx.JSON(http.StatusOK, &response)
+}
+
+func (s *Service) GetEscrow(ctx *gin.Context) {
+	escrowId, err := uuid.Parse(ctx.Param("escrow_id"))
+	if err != nil {
+		problem.Respond(ctx, domain.ErrInvalidParameters.Wrap(err))
+		return
+	}
+	found, err := s.escrowRepo.GetEscrow(escrowId)
+	if err != nil {
+		problem.Respond(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, newEscrow(found))
+}
+
+// ReleaseEscrow pays the whole escrow to the seller
+func (s *Service) ReleaseEscrow(ctx *gin.Context) {
+	s.settleEscrow(ctx, func(id uuid.UUID) (*payment.Escrow, error) {
+		return s.escrows.Release(ctx.Request.Context(), id)
+	})
+}
+
+// RefundEscrow gives the whole escrow back to the buyer
+func (s *Service) RefundEscrow(ctx *gin.Context) {
+	s.settleEscrow(ctx, func(id uuid.UUID) (*payment.Escrow, error) {
+		return s.escrows.Refund(ctx.Request.Context(), id)
+	})
+}
+
+// SplitEscrow pays seller_amount to the seller and refunds the rest to the buyer
+func (s *Service) SplitEscrow(ctx *gin.Context) {
+	var req SplitEscrowRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		util.Error("Invalid params", zap.Error(err))
+		problem.Respond(ctx, domain.ErrInvalidParameters.Wrap(err))
+		return
+	}
+	sellerAmount, err := util.ConvertToInt(req.SellerAmount)
+	if err != nil {
+		problem.Respond(ctx, domain.ErrInvalidParameters.Wrap(err))
+		return
+	}
+	s.settleEscrow(ctx, func(id uuid.UUID) (*payment.Escrow, error) {
+		return s.escrows.Split(ctx.Request.Context(), id, sellerAmount)
+	})
+}
+
+// settleEscrow answers with the settled escrow, settling it again the same way answers the same
+func (s *Service) settleEscrow(ctx *gin.Context, settle func(id uuid.UUID) (*payment.Escrow, error)) {
+	escrowId, err := uuid.Parse(ctx.Param("escrow_id"))
+	if err != nil {
+		problem.Respond(ctx, domain.ErrInvalidParameters.Wrap(err))
+		return
+	}
+	audit.Target(ctx, audit.TargetEscrow, escrowId.String())
+	settled, err := settle(escrowId)
+	if err != nil {
+		util.Error("Settle escrow failed", zap.String("escrow_id", escrowId.String()), zap.Error(err))
+		problem.Respond(ctx, err)
+		return
+	}
+	audit.Change(ctx, gin.H{"status": payment.EscrowFunded}, gin.H{
+		"status":          settled.Status,
+		"released_amount": settled.ReleasedAmount,
+		"refunded_amount": settled.RefundedAmount,
+	})
+	ctx.JSON(http.StatusOK, newEscrow(settled))
+}
+
+func newEscrow(found *payment.Escrow) *Escrow {
+	response := &Escrow{
+		EscrowId:       found.ID.String(),
+		BuyerUserId:    found.BuyerUserID.String(),
+		SellerUserId:   found.SellerUserID.String(),
+		Amount:         util.DisplayAmount(found.Amount),
+		Reference:      found.Reference,
+		Status:         found.Status,
+		Deadline:       found.Deadline.UTC().Format(time.RFC3339),
+		DeadlineAction: found.DeadlineAction,
+		FundGroupId:    found.FundGroupID.String(),
+		CreatedAt:      found.CreatedAt.UTC().Format(time.RFC3339),
+	}
+	if found.Status != payment.EscrowFunded {
+		response.ReleasedAmount = util.DisplayAmount(found.ReleasedAmount)
+		response.RefundedAmount = util.DisplayAmount(found.RefundedAmount)
+	}
+	if found.ReleaseGroupID != nil {
+		response.ReleaseGroupId = found.ReleaseGroupID.String()
+	}
+	if found.RefundGroupID != nil {
+		response.RefundGroupId = found.RefundGroupID.String()
+	}
+	if found.SettledAt != nil {
+		response.SettledAt = found.SettledAt.UTC().Format(time.RFC3339)
+	}
+	return response
+}
+
+type CreateEscrowRequest struct {
+	BuyerUserId    string `json:"buyer_user_id" binding:"required"`
+	SellerUserId   string `json:"seller_user_id" binding:"required"`
+	Amount         string `json:"amount" binding:"required"`
+	Reference      string `json:"reference"`
+	Deadline       string `json:"deadline"`
+	DeadlineAction string `json:"deadline_action"`
+}
+
+type SplitEscrowRequest struct {
+	SellerAmount string `json:"seller_amount" binding:"required"`
+}
+
+type Escrow struct {
+	EscrowId       string `json:"escrow_id" binding:"required"`
+	BuyerUserId    string `json:"buyer_user_id" binding:"required"`
+	SellerUserId   string `json:"seller_user_id" binding:"required"`
+	Amount         string `json:"amount" binding:"required"`
+	ReleasedAmount string `json:"released_amount,omitempty"`
+	RefundedAmount string `json:"refunded_amount,omitempty"`
+	Reference      string `json:"reference"`
+	Status         string `json:"status" binding:"required"`
+	Deadline       string `json:"deadline" binding:"required"`
+	DeadlineAction string `json:"deadline_action" binding:"required"`
+	FundGroupId    string `json:"fund_group_id" binding:"required"`
+	ReleaseGroupId string `json:"release_group_id,omitempty"`
+	RefundGroupId  string `json:"refund_group_id,omitempty"`
+	CreatedAt      string `json:"created_at" binding:"required"`
+	SettledAt      string `json:"settled_at,omitempty"`
+}
+
+type SearchEscrowResponse struct {
+	Escrows []Escrow `json:"escrows"`
+}
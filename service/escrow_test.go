@@ -0,0 +1,93 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/google/uuid"
+	"github.com/raychongtk/wallet/model/payment"
+	"github.com/raychongtk/wallet/util"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func createEscrow(t *testing.T, amount string, deadlineAction string) Escrow {
+	resp := postJSON("/api/v1/escrows", map[string]string{
+		"buyer_user_id":   johnUserId,
+		"seller_user_id":  rayUserId,
+		"amount":          amount,
+		"reference":       "order-1",
+		"deadline_action": deadlineAction,
+	})
+	assert.Equal(t, http.StatusCreated, resp.Code)
+	var escrow Escrow
+	assert.NoError(t, json.Unmarshal(resp.Body.Bytes(), &escrow))
+	assert.Equal(t, "FUNDED", escrow.Status)
+	return escrow
+}
+
+func TestEscrowReleaseRefundAndSplit(t *testing.T) {
+	db, _, cleanup, err := setupTestDB()
+	if err != nil {
+		t.Fatalf("failed to set up test DB: %v", err)
+	}
+	defer cleanup()
+
+	deposit := postJSON("/api/v1/wallet/deposit", map[string]string{"user_id": johnUserId, "balance": "100"})
+	assert.Equal(t, http.StatusOK, deposit.Code)
+	released := createEscrow(t, "30", "")
+	assert.Equal(t, "REFUND", released.DeadlineAction)
+	refunded := createEscrow(t, "20", "")
+	split := createEscrow(t, "40", "")
+	assert.Equal(t, "10.00", johnBalance(t).Balance)
+	escrowBalance, _ := service.balanceRepo.GetBalanceWithLock(db, util.GetEscrowAccount(), "COMMITTED")
+	assert.Equal(t, 9000, escrowBalance.Balance)
+
+	for i := 0; i < 2; i++ {
+		resp := postJSON("/api/v1/escrows/"+released.EscrowId+"/release", nil)
+		assert.Equal(t, http.StatusOK, resp.Code)
+	}
+	resp := postJSON("/api/v1/escrows/"+released.EscrowId+"/refund", nil)
+	assert.Equal(t, http.StatusConflict, resp.Code)
+	resp = postJSON("/api/v1/escrows/"+refunded.EscrowId+"/refund", nil)
+	assert.Equal(t, http.StatusOK, resp.Code)
+	resp = postJSON("/api/v1/escrows/"+split.EscrowId+"/split", map[string]string{"seller_amount": "40"})
+	assert.Equal(t, http.StatusBadRequest, resp.Code)
+	resp = postJSON("/api/v1/escrows/"+split.EscrowId+"/split", map[string]string{"seller_amount": "15"})
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.NoError(t, json.Unmarshal(resp.Body.Bytes(), &split))
+	assert.Equal(t, "SPLIT", split.Status)
+	assert.Equal(t, "15.00", split.ReleasedAmount)
+	assert.Equal(t, "25.00", split.RefundedAmount)
+	assert.NotEmpty(t, split.ReleaseGroupId)
+	assert.NotEmpty(t, split.RefundGroupId)
+
+	assert.Equal(t, "55.00", johnBalance(t).Balance)
+	sellerBalance, _ := service.balanceRepo.GetBalanceWithLock(db, uuid.MustParse("c7d90b83-e080-423a-ab1b-f48094d7533e"), "COMMITTED")
+	assert.Equal(t, 4500, sellerBalance.Balance)
+	escrowBalance, _ = service.balanceRepo.GetBalanceWithLock(db, util.GetEscrowAccount(), "COMMITTED")
+	assert.Equal(t, 0, escrowBalance.Balance)
+}
+
+func TestEscrowSettlesAtDeadline(t *testing.T) {
+	db, _, cleanup, err := setupTestDB()
+	if err != nil {
+		t.Fatalf("failed to set up test DB: %v", err)
+	}
+	defer cleanup()
+
+	deposit := postJSON("/api/v1/wallet/deposit", map[string]string{"user_id": johnUserId, "balance": "100"})
+	assert.Equal(t, http.StatusOK, deposit.Code)
+	released := createEscrow(t, "30", "RELEASE")
+	refunded := createEscrow(t, "20", "REFUND")
+	db.Model(&payment.Escrow{}).Where("id IN ?", []string{released.EscrowId, refunded.EscrowId}).Update("deadline", time.Now().Add(-time.Minute))
+	assert.NoError(t, service.escrows.SettleDue(context.Background()))
+
+	for id, status := range map[string]string{released.EscrowId: "RELEASED", refunded.EscrowId: "REFUNDED"} {
+		escrow, err := service.escrowRepo.GetEscrow(uuid.MustParse(id))
+		assert.NoError(t, err)
+		assert.Equal(t, status, escrow.Status)
+	}
+	assert.Equal(t, "70.00", johnBalance(t).Balance)
+}
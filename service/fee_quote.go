@@ -0,0 +1,46 @@
+package service
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/raychongtk/wallet/domain"
+	"github.com/raychongtk/wallet/problem"
+	"github.com/raychongtk/wallet/util"
+	"go.uber.org/zap"
+	"net/http"
+)
+
+// GetFeeQuote shows what a withdrawal or a transfer would cost before the user confirms it, nothing is posted
+func (s *Service) GetFeeQuote(ctx *gin.Context) {
+	userId, err := uuid.Parse(ctx.Query("user_id"))
+	if err != nil {
+		problem.Respond(ctx, domain.ErrInvalidAccount.Wrap(err))
+		return
+	}
+	amount, err := util.ConvertToInt(ctx.Query("amount"))
+	if err != nil {
+		problem.Respond(ctx, domain.ErrInvalidParameters.Wrap(err))
+		return
+	}
+	quote, err := s.ledger.QuoteFee(userId, ctx.Query("operation"), amount)
+	if err != nil {
+		util.Error("Quote fee failed", zap.String("user_id", userId.String()), zap.Error(err))
+		problem.Respond(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, &FeeQuoteResponse{
+		Operation: quote.Operation,
+		Currency:  quote.Currency,
+		Gross:     util.DisplayAmount(quote.GrossAmount()),
+		Net:       util.DisplayAmount(quote.Amount),
+		Fee:       util.DisplayAmount(quote.Fee),
+	})
+}
+
+type FeeQuoteResponse struct {
+	Operation string `json:"operation" binding:"required"`
+	Currency  string `json:"currency" binding:"required"`
+	Gross     string `json:"gross_amount" binding:"required"`
+	Net       string `json:"net_amount" binding:"required"`
+	Fee       string `json:"fee" binding:"required"`
+}
@@ -0,0 +1,52 @@
+package service
+
+import (
+	"encoding/json"
+	"github.com/raychongtk/wallet/model/user"
+	"github.com/raychongtk/wallet/util"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestFeesOfTheUserTier(t *testing.T) {
+	db, _, cleanup, err := setupTestDB()
+	if err != nil {
+		t.Fatalf("failed to set up test DB: %v", err)
+	}
+	defer cleanup()
+
+	// the test profile charges the BASIC tier only
+	db.Model(&user.AppUser{}).Where("id = ?", johnUserId).Update("tier", "BASIC")
+	deposit := postJSON("/api/v1/wallet/deposit", map[string]string{"user_id": johnUserId, "balance": "100"})
+	assert.Equal(t, http.StatusOK, deposit.Code)
+
+	resp := httptest.NewRecorder()
+	ProvideRoutes(service).ServeHTTP(resp, httptest.NewRequest(http.MethodGet, "/api/v1/wallet/fee-quote?user_id="+johnUserId+"&operation=transfer&amount=20", nil))
+	assert.Equal(t, http.StatusOK, resp.Code)
+	var quote FeeQuoteResponse
+	assert.NoError(t, json.Unmarshal(resp.Body.Bytes(), &quote))
+	assert.Equal(t, FeeQuoteResponse{Operation: "transfer", Currency: "USD", Gross: "20.50", Net: "20.00", Fee: "0.50"}, quote)
+
+	transfer := postJSON("/api/v1/wallet/transfer", map[string]string{"credit_user_id": johnUserId, "debit_user_id": rayUserId, "balance": "20"})
+	assert.Equal(t, http.StatusOK, transfer.Code)
+	withdrawal := postJSON("/api/v1/wallet/withdrawal", map[string]string{"user_id": johnUserId, "balance": "10"})
+	assert.Equal(t, http.StatusOK, withdrawal.Code)
+	assert.Equal(t, "68.00", johnBalance(t).Balance)
+	revenue, _ := service.balanceRepo.GetBalanceWithLock(db, util.GetFeeRevenueAccount(), "COMMITTED")
+	assert.Equal(t, 200, revenue.Balance)
+
+	resp = httptest.NewRecorder()
+	ProvideRoutes(service).ServeHTTP(resp, httptest.NewRequest(http.MethodGet, "/api/v1/wallet/payment-history?user_id="+johnUserId, nil))
+	var histories SearchPaymentHistoryResponse
+	assert.NoError(t, json.Unmarshal(resp.Body.Bytes(), &histories))
+	assert.Len(t, histories.Histories, 3)
+	assert.Equal(t, PaymentHistory{PayerName: "John Doe", PayeeName: "Ray Doe", PayType: "TRANSFER", Amount: "-20.50", Gross: "20.50", Net: "20.00", Fee: "0.50"}, histories.Histories[1])
+	assert.Equal(t, "-11.50", histories.Histories[2].Amount)
+
+	// Ray is STANDARD and pays nothing
+	transfer = postJSON("/api/v1/wallet/transfer", map[string]string{"credit_user_id": rayUserId, "debit_user_id": johnUserId, "balance": "20"})
+	assert.Equal(t, http.StatusOK, transfer.Code)
+	assert.Equal(t, "88.00", johnBalance(t).Balance)
+}
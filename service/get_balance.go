@@ -1,47 +1,122 @@
 package service
 
 import (
-	"fmt"
+	"errors"
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
+	"github.com/raychongtk/wallet/domain"
+	"github.com/raychongtk/wallet/ledger"
+	"github.com/raychongtk/wallet/model/wallet"
+	"github.com/raychongtk/wallet/problem"
 	"github.com/raychongtk/wallet/util"
 	"go.uber.org/zap"
 	"net/http"
+	"time"
 )
 
+// GetBalance reports the ledger balance, the part of it held for merchants, what is available to spend and the interest
+// accrued on it. Pockets are listed on their own and counted in the total only. A balance as of a time is the ledger
+// balance alone.
 func (s *Service) GetBalance(ctx *gin.Context) {
 	userId, err := uuid.Parse(ctx.Query("user_id"))
 	if err != nil {
 		util.Error("Invalid user", zap.Error(err))
-		ctx.Status(http.StatusBadRequest)
+		problem.Respond(ctx, domain.ErrInvalidAccount.Wrap(err))
 		return
 	}
 
-	appUser, err := s.userRepo.GetUser(userId)
+	customer, err := s.ledger.Customer(userId)
 	if err != nil {
-		util.Error("Invalid user", zap.Error(err))
-		ctx.Status(http.StatusBadRequest)
+		util.Error("Invalid wallet", zap.Error(err))
+		problem.Respond(ctx, err)
+		return
+	}
+	if asOf := ctx.Query("as_of"); asOf != "" {
+		s.getBalanceAsOf(ctx, userId, customer.Wallet, asOf)
 		return
 	}
-	account, err := s.accountRepo.GetAccount(appUser.ID)
+	s.respondBalance(ctx, customer)
+}
+
+// respondBalance answers with the balances of a customer and their pockets
+func (s *Service) respondBalance(ctx *gin.Context, customer *ledger.Customer) {
+	userId, userWallet := customer.User.ID, customer.Wallet
+	balance, err := s.balanceRepo.GetBalance(userWallet.ID, "COMMITTED")
 	if err != nil {
-		util.Error("Invalid account", zap.Error(err))
-		ctx.Status(http.StatusBadRequest)
+		util.Error("Get balance failed", zap.Error(err))
+		problem.Respond(ctx, err)
 		return
 	}
-	userWallet, err := s.walletRepo.GetWallet(account.ID)
+	held := 0
+	heldBalance, err := s.balanceRepo.GetBalance(userWallet.ID, "HELD")
+	if err == nil {
+		held = heldBalance.Balance
+	} else if !errors.Is(err, domain.ErrNotFound) {
+		util.Error("Get held balance failed", zap.Error(err))
+		problem.Respond(ctx, err)
+		return
+	}
+	accrued, err := s.accruedInterest(userWallet.ID)
 	if err != nil {
-		util.Error("Invalid wallet", zap.Error(err))
-		ctx.Status(http.StatusBadRequest)
+		util.Error("Get accrued interest failed", zap.Error(err))
+		problem.Respond(ctx, err)
 		return
 	}
-	balance, err := s.balanceRepo.GetBalance(userWallet.ID, "COMMITTED")
-	displayedBalance := fmt.Sprintf("%.2f", float64(balance.Balance)/100)
-	ctx.JSON(http.StatusOK, &GetCustomerBalanceResponse{CustomerID: userId.String(), Currency: userWallet.Currency, Balance: displayedBalance})
+	pockets, saved, err := s.pocketBalances(userId)
+	if err != nil {
+		util.Error("Get pocket balances failed", zap.Error(err))
+		problem.Respond(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, &GetCustomerBalanceResponse{
+		CustomerID:      userId.String(),
+		Currency:        userWallet.Currency,
+		Balance:         util.DisplayAmount(balance.Balance),
+		Held:            util.DisplayAmount(held),
+		Available:       util.DisplayAmount(balance.Balance - held),
+		AccruedInterest: util.DisplayAmount(accrued),
+		Total:           util.DisplayAmount(balance.Balance + saved),
+		Pockets:         pockets,
+	})
+}
+
+// getBalanceAsOf replays hot and archived transactions because the balance table only holds the latest balance
+func (s *Service) getBalanceAsOf(ctx *gin.Context, userId uuid.UUID, userWallet *wallet.Wallet, asOfParam string) {
+	asOf, err := time.Parse(time.RFC3339, asOfParam)
+	if err != nil {
+		util.Error("Invalid as of time", zap.Error(err))
+		problem.Respond(ctx, domain.ErrInvalidParameters.Wrap(err))
+		return
+	}
+	hotBalance, err := s.transactionRepo.SumBalance(userWallet.ID, "COMMITTED", asOf)
+	if err != nil {
+		util.Error("Sum balance failed", zap.Error(err))
+		problem.Respond(ctx, err)
+		return
+	}
+	archivedBalance, err := s.archiveReader.SumBalance(ctx, userWallet.ID, "COMMITTED", asOf)
+	if err != nil {
+		util.Error("Sum archived balance failed", zap.Error(err))
+		problem.Respond(ctx, err)
+		return
+	}
+	displayedBalance := util.DisplayAmount(hotBalance + archivedBalance)
+	ctx.JSON(http.StatusOK, &GetCustomerBalanceResponse{
+		CustomerID: userId.String(),
+		Currency:   userWallet.Currency,
+		Balance:    displayedBalance,
+		AsOf:       asOf.Format(time.RFC3339),
+	})
 }
 
 type GetCustomerBalanceResponse struct {
-	CustomerID string `json:"customer_id" binding:"required"`
-	Currency   string `json:"currency" binding:"required"`
-	Balance    string `json:"balance" binding:"required"`
+	CustomerID      string   `json:"customer_id" binding:"required"`
+	Currency        string   `json:"currency" binding:"required"`
+	Balance         string   `json:"balance" binding:"required"`
+	Held            string   `json:"held,omitempty"`
+	Available       string   `json:"available,omitempty"`
+	AccruedInterest string   `json:"accrued_interest,omitempty"`
+	Total           string   `json:"total,omitempty"`
+	Pockets         []Pocket `json:"pockets,omitempty"`
+	AsOf            string   `json:"as_of,omitempty"`
 }
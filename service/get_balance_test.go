@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"encoding/json"
 	"github.com/google/uuid"
+	"github.com/raychongtk/wallet/problem"
 	"github.com/stretchr/testify/assert"
 	"net/http"
 	"net/http/httptest"
@@ -85,9 +86,15 @@ func TestGetBalanceAPIWithInvalidUser(t *testing.T) {
 
 	router := ProvideRoutes(service)
 	req, _ := http.NewRequest(http.MethodGet, "/api/v1/wallet/balance?user_id=2d988f4a-a037-4ce9-a350-f13445793e81", nil)
+	req.Header.Set("X-Request-ID", "balance-request")
 
 	resp := httptest.NewRecorder()
 	router.ServeHTTP(resp, req)
 
 	assert.Equal(t, http.StatusBadRequest, resp.Code)
+	var response problem.Details
+	assert.NoError(t, json.Unmarshal(resp.Body.Bytes(), &response))
+	assert.Equal(t, "INVALID_ACCOUNT", response.ErrorCode)
+	assert.Equal(t, "balance-request", response.RequestID)
+	assert.NotEmpty(t, response.Message)
 }
@@ -0,0 +1,65 @@
+package service
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/raychongtk/wallet/domain"
+	"github.com/raychongtk/wallet/problem"
+	"github.com/raychongtk/wallet/util"
+	"go.uber.org/zap"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const notificationPageSize = 100
+
+// GetNotifications pages through the inbox of a user oldest first, next_after continues the page
+func (s *Service) GetNotifications(ctx *gin.Context) {
+	userId, err := uuid.Parse(ctx.Query("user_id"))
+	if err != nil {
+		problem.Respond(ctx, domain.ErrInvalidAccount.Wrap(err))
+		return
+	}
+	var after int64
+	if value := ctx.Query("after"); value != "" {
+		if after, err = strconv.ParseInt(value, 10, 64); err != nil {
+			problem.Respond(ctx, domain.ErrInvalidParameters.WithMessage("after must be a notification id"))
+			return
+		}
+	}
+	notifications, err := s.notificationRepo.SearchNotifications(userId, after, notificationPageSize)
+	if err != nil {
+		util.Error("Search notifications failed", zap.Error(err))
+		problem.Respond(ctx, err)
+		return
+	}
+
+	response := SearchNotificationResponse{Notifications: []Notification{}}
+	for _, n := range notifications {
+		response.Notifications = append(response.Notifications, Notification{
+			Id:        n.ID,
+			Kind:      n.Kind,
+			Message:   n.Message,
+			Data:      n.Data,
+			CreatedAt: n.CreatedAt.UTC().Format(time.RFC3339),
+		})
+	}
+	if len(notifications) == notificationPageSize {
+		response.NextAfter = notifications[len(notifications)-1].ID
+	}
+	ctx.JSON(http.StatusOK, &response)
+}
+
+type SearchNotificationResponse struct {
+	Notifications []Notification `json:"notifications"`
+	NextAfter     int64          `json:"next_after,omitempty"`
+}
+
+type Notification struct {
+	Id        int64       `json:"id" binding:"required"`
+	Kind      string      `json:"kind" binding:"required"`
+	Message   string      `json:"message" binding:"required"`
+	Data      interface{} `json:"data"`
+	CreatedAt string      `json:"created_at" binding:"required"`
+}
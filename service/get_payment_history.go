@@ -1,9 +1,10 @@
 package service
 
 import (
-	"fmt"
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
+	"github.com/raychongtk/wallet/domain"
+	"github.com/raychongtk/wallet/problem"
 	"github.com/raychongtk/wallet/util"
 	"go.uber.org/zap"
 	"net/http"
@@ -13,54 +14,68 @@ func (s *Service) GetPaymentHistory(ctx *gin.Context) {
 	userId, err := uuid.Parse(ctx.Query("user_id"))
 	if err != nil {
 		util.Error("Invalid user", zap.Error(err))
-		ctx.Status(http.StatusBadRequest)
+		problem.Respond(ctx, domain.ErrInvalidAccount.Wrap(err))
 		return
 	}
 
-	appUser, err := s.userRepo.GetUser(userId)
+	customer, err := s.ledger.Customer(userId)
 	if err != nil {
 		util.Error("Invalid user", zap.Error(err))
-		ctx.Status(http.StatusBadRequest)
+		problem.Respond(ctx, err)
 		return
 	}
-	histories, err := s.paymentHistoryRepo.SearchPaymentHistory(appUser.ID.String())
+	s.respondPaymentHistory(ctx, customer.User.ID, "")
+}
+
+// respondPaymentHistory answers with the payments of a user, only the ones initiatorUserId made unless it is empty
+func (s *Service) respondPaymentHistory(ctx *gin.Context, userId uuid.UUID, initiatorUserId string) {
+	histories, err := s.paymentHistoryRepo.SearchPaymentHistory(userId.String())
 	if err != nil {
 		util.Error("search payment history failed", zap.Error(err))
-		ctx.Status(http.StatusBadRequest)
+		problem.Respond(ctx, err)
 		return
 	}
+	if ctx.Query("include_archived") == "true" {
+		archivedHistories, err := s.archiveReader.PaymentHistories(ctx, userId.String())
+		if err != nil {
+			util.Error("search archived payment history failed", zap.Error(err))
+			problem.Respond(ctx, err)
+			return
+		}
+		histories = append(archivedHistories, histories...)
+	}
 
 	var paymentHistories []PaymentHistory
 	for i := 0; i < len(histories); i++ {
+		if initiatorUserId != "" && histories[i].InitiatorUserId != initiatorUserId {
+			continue
+		}
 		paymentHistory := PaymentHistory{
-			PayerName: histories[i].PayerName,
-			PayeeName: histories[i].PayeeName,
-			PayType:   histories[i].PayType,
-			Amount:    adjustBalanceByPaymentDirection(histories[i].PayType, histories[i].PayerUserId, appUser.ID.String(), histories[i].Amount),
+			PayerName:   histories[i].PayerName,
+			PayeeName:   histories[i].PayeeName,
+			PayType:     histories[i].PayType,
+			Amount:      util.DisplayAmount(histories[i].SignedAmount(userId.String())),
+			Gross:       util.DisplayAmount(histories[i].GrossAmount()),
+			Net:         util.DisplayAmount(histories[i].Amount),
+			Fee:         util.DisplayAmount(histories[i].Fee),
+			InitiatedBy: histories[i].InitiatorUserId,
 		}
 		paymentHistories = append(paymentHistories, paymentHistory)
 	}
 	ctx.JSON(http.StatusOK, &SearchPaymentHistoryResponse{Histories: paymentHistories})
 }
 
-func adjustBalanceByPaymentDirection(payType string, payerUserId string, requestedUserId string, amount int) string {
-	var multiplier = 1
-	if payType == "TRANSFER" && payerUserId == requestedUserId {
-		multiplier = -1
-	} else if payType == "WITHDRAWAL" {
-		multiplier = -1
-	}
-
-	return fmt.Sprintf("%.2f", float64(amount*multiplier)/100)
-}
-
 type SearchPaymentHistoryResponse struct {
 	Histories []PaymentHistory `json:"histories"`
 }
 
 type PaymentHistory struct {
-	PayerName string `json:"payer_name" binding:"required"`
-	PayeeName string `json:"payee_name" binding:"required"`
-	PayType   string `json:"pay_type" binding:"required"`
-	Amount    string `json:"amount" binding:"required"`
+	PayerName   string `json:"payer_name" binding:"required"`
+	PayeeName   string `json:"payee_name" binding:"required"`
+	PayType     string `json:"pay_type" binding:"required"`
+	Amount      string `json:"amount" binding:"required"`
+	Gross       string `json:"gross_amount" binding:"required"`
+	Net         string `json:"net_amount" binding:"required"`
+	Fee         string `json:"fee" binding:"required"`
+	InitiatedBy string `json:"initiated_by,omitempty"`
 }
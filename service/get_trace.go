@@ -0,0 +1,157 @@
+package service
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/raychongtk/wallet/domain"
+	"github.com/raychongtk/wallet/problem"
+	"github.com/raychongtk/wallet/tracing"
+	"github.com/raychongtk/wallet/util"
+	"go.uber.org/zap"
+	"net/http"
+	"time"
+)
+
+// GetTrace returns what a request did to the ledger, looked up by its trace id or X-Request-ID
+func (s *Service) GetTrace(ctx *gin.Context) {
+	traceID := ctx.Query("trace_id")
+	requestID := ctx.Query("request_id")
+	if traceID == "" && requestID == "" {
+		problem.Respond(ctx, domain.ErrInvalidParameters.WithMessage("trace_id or request_id is required"))
+		return
+	}
+
+	movements, err := s.movementRepo.SearchMovementsByTrace(traceID, requestID)
+	if err != nil {
+		util.Error("search movement by trace failed", zap.Error(err))
+		problem.Respond(ctx, err)
+		return
+	}
+	histories, err := s.paymentHistoryRepo.SearchPaymentHistoryByTrace(traceID, requestID)
+	if err != nil {
+		util.Error("search payment history by trace failed", zap.Error(err))
+		problem.Respond(ctx, err)
+		return
+	}
+	if len(movements) == 0 && len(histories) == 0 {
+		problem.Respond(ctx, domain.ErrNotFound.WithMessage("nothing was recorded for the trace"))
+		return
+	}
+	var movementIDs []uuid.UUID
+	for _, m := range movements {
+		movementIDs = append(movementIDs, m.ID)
+	}
+	transactions, err := s.transactionRepo.SearchTransactionsByMovementIDs(movementIDs)
+	if err != nil {
+		util.Error("search transaction by movement failed", zap.Error(err))
+		problem.Respond(ctx, err)
+		return
+	}
+
+	response := &GetTraceResponse{
+		TraceID:          traceID,
+		RequestID:        requestID,
+		Movements:        []TraceMovement{},
+		Transactions:     []TraceTransaction{},
+		BalanceChanges:   []BalanceChange{},
+		PaymentHistories: []TracePaymentHistory{},
+	}
+	for _, m := range movements {
+		response.TraceID, response.RequestID = m.TraceID, m.RequestID
+		response.Movements = append(response.Movements, TraceMovement{
+			ID:             m.ID.String(),
+			GroupID:        m.GroupID.String(),
+			DebitWalletID:  m.DebitWalletID.String(),
+			CreditWalletID: m.CreditWalletID.String(),
+			DebitBalance:   util.DisplayAmount(m.DebitBalance),
+			CreditBalance:  util.DisplayAmount(m.CreditBalance),
+			MovementStatus: m.MovementStatus,
+			CreatedAt:      m.CreatedAt.Format(time.RFC3339),
+		})
+	}
+	// balance changes net the transactions per wallet and balance type in the order they were first touched
+	changes := map[string]int{}
+	var changeKeys []BalanceChange
+	for _, t := range transactions {
+		response.Transactions = append(response.Transactions, TraceTransaction{
+			ID:          t.ID.String(),
+			MovementID:  t.MovementID.String(),
+			WalletID:    t.WalletID.String(),
+			BalanceType: t.BalanceType,
+			Balance:     util.DisplayAmount(t.Balance),
+			CreatedAt:   t.CreatedAt.Format(time.RFC3339),
+		})
+		key := t.WalletID.String() + "/" + t.BalanceType
+		if _, ok := changes[key]; !ok {
+			changeKeys = append(changeKeys, BalanceChange{WalletID: t.WalletID.String(), BalanceType: t.BalanceType})
+		}
+		changes[key] += t.Balance
+	}
+	for _, change := range changeKeys {
+		change.Amount = util.DisplayAmount(changes[change.WalletID+"/"+change.BalanceType])
+		response.BalanceChanges = append(response.BalanceChanges, change)
+	}
+	for _, h := range histories {
+		response.TraceID, response.RequestID = h.TraceID, h.RequestID
+		response.PaymentHistories = append(response.PaymentHistories, TracePaymentHistory{
+			ID:          h.ID.String(),
+			PayerUserID: h.PayerUserId,
+			PayeeUserID: h.PayeeUserId,
+			PayType:     h.PayType,
+			Amount:      util.DisplayAmount(h.Amount),
+			Fee:         util.DisplayAmount(h.Fee),
+			CreatedAt:   h.CreatedAt.Format(time.RFC3339),
+		})
+	}
+	ctx.JSON(http.StatusOK, response)
+}
+
+// traceIDs are stored with every movement and payment history so that funds can be traced back to a request
+func traceIDs(ctx *gin.Context) (string, string) {
+	return tracing.TraceID(ctx.Request.Context()), ctx.GetHeader(tracing.RequestIDHeader)
+}
+
+type GetTraceResponse struct {
+	TraceID          string                `json:"trace_id"`
+	RequestID        string                `json:"request_id"`
+	Movements        []TraceMovement       `json:"movements"`
+	Transactions     []TraceTransaction    `json:"transactions"`
+	BalanceChanges   []BalanceChange       `json:"balance_changes"`
+	PaymentHistories []TracePaymentHistory `json:"payment_histories"`
+}
+
+type TraceMovement struct {
+	ID             string `json:"id"`
+	GroupID        string `json:"group_id"`
+	DebitWalletID  string `json:"debit_wallet_id"`
+	CreditWalletID string `json:"credit_wallet_id"`
+	DebitBalance   string `json:"debit_balance"`
+	CreditBalance  string `json:"credit_balance"`
+	MovementStatus string `json:"movement_status"`
+	CreatedAt      string `json:"created_at"`
+}
+
+type TraceTransaction struct {
+	ID          string `json:"id"`
+	MovementID  string `json:"movement_id"`
+	WalletID    string `json:"wallet_id"`
+	BalanceType string `json:"balance_type"`
+	Balance     string `json:"balance"`
+	CreatedAt   string `json:"created_at"`
+}
+
+type BalanceChange struct {
+	WalletID    string `json:"wallet_id"`
+	BalanceType string `json:"balance_type"`
+	Amount      string `json:"amount"`
+}
+
+type TracePaymentHistory struct {
+	ID          string `json:"id"`
+	PayerUserID string `json:"payer_user_id"`
+	PayeeUserID string `json:"payee_user_id"`
+	PayType     string `json:"pay_type"`
+	Amount      string `json:"amount"`
+	Fee         string `json:"fee"`
+	CreatedAt   string `json:"created_at"`
+}
@@ -0,0 +1,82 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"github.com/google/uuid"
+	"github.com/raychongtk/wallet/config"
+	"github.com/raychongtk/wallet/tracing"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestGetTraceAPI(t *testing.T) {
+	db, _, cleanup, err := setupTestDB()
+	if err != nil {
+		t.Fatalf("failed to set up test DB: %v", err)
+	}
+	defer cleanup()
+	cfg, err := config.Load(config.ProfileTest, "../config")
+	assert.NoError(t, err)
+	tracerProvider, err := tracing.ProvideTracerProvider(cfg)
+	assert.NoError(t, err)
+	defer tracerProvider.Shutdown(context.Background())
+	assert.NoError(t, db.Use(tracing.GormPlugin{}))
+
+	router := ProvideRoutes(service)
+	requestID := uuid.New().String()
+	depositPayload := map[string]string{
+		"user_id": "2d988f4a-a037-4ce9-a350-f13445793e88",
+		"balance": "200",
+	}
+	body, _ := json.Marshal(depositPayload)
+	req, _ := http.NewRequest(http.MethodPost, "/api/v1/wallet/deposit", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Request-ID", requestID)
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+	assert.Equal(t, http.StatusOK, resp.Code)
+	traceID := resp.Header().Get(tracing.TraceIDHeader)
+	assert.NotEmpty(t, traceID)
+
+	for _, query := range []string{"request_id=" + requestID, "trace_id=" + traceID} {
+		traceReq, _ := http.NewRequest(http.MethodGet, "/api/v1/wallet/trace?"+query, nil)
+		traceResp := httptest.NewRecorder()
+		router.ServeHTTP(traceResp, traceReq)
+		assert.Equal(t, http.StatusOK, traceResp.Code)
+
+		var response GetTraceResponse
+		assert.NoError(t, json.Unmarshal(traceResp.Body.Bytes(), &response))
+		assert.Equal(t, traceID, response.TraceID)
+		assert.Equal(t, requestID, response.RequestID)
+		assert.Len(t, response.Movements, 1)
+		assert.Len(t, response.Transactions, 2)
+		assert.Len(t, response.PaymentHistories, 1)
+		assert.Equal(t, []BalanceChange{
+			{WalletID: "338b3f97-e428-4bff-9775-f759b5fccc4d", BalanceType: "COMMITTED", Amount: "200.00"},
+			{WalletID: "1cc535a5-bc57-4731-a64b-041b7ff41c30", BalanceType: "COMMITTED", Amount: "200.00"},
+		}, response.BalanceChanges)
+	}
+}
+
+func TestGetTraceAPIWithUnknownRequest(t *testing.T) {
+	_, _, cleanup, err := setupTestDB()
+	if err != nil {
+		t.Fatalf("failed to set up test DB: %v", err)
+	}
+	defer cleanup()
+
+	router := ProvideRoutes(service)
+	req, _ := http.NewRequest(http.MethodGet, "/api/v1/wallet/trace?request_id="+uuid.New().String(), nil)
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+	assert.Equal(t, http.StatusNotFound, resp.Code)
+
+	req, _ = http.NewRequest(http.MethodGet, "/api/v1/wallet/trace", nil)
+	resp = httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+	assert.Equal(t, http.StatusBadRequest, resp.Code)
+}
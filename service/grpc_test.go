@@ -0,0 +1,87 @@
+package service
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"github.com/raychongtk/wallet/problem"
+	ledgerv1 "github.com/raychongtk/wallet/proto/ledger/v1"
+	"github.com/raychongtk/wallet/rpc"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"io"
+	"net"
+	"testing"
+)
+
+func TestGRPCMovesMoneyThroughTheSameLedger(t *testing.T) {
+	_, _, cleanup, err := setupTestDB()
+	if err != nil {
+		t.Fatalf("failed to set up test DB: %v", err)
+	}
+	defer cleanup()
+
+	listener := bufconn.Listen(1 << 20)
+	server := rpc.NewGRPCServer(rpc.ProvideServer(
+		service.ledger,
+		service.balanceRepo,
+		service.transactionRepo,
+		service.paymentHistoryRepo,
+		service.archiveReader,
+		service.memoryStore,
+		service.auditor,
+		service.config,
+	), "test-token")
+	go server.Serve(listener)
+	defer server.Stop()
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	assert.NoError(t, err)
+	defer conn.Close()
+	client := ledgerv1.NewLedgerServiceClient(conn)
+
+	call := func(requestID string) context.Context {
+		return metadata.AppendToOutgoingContext(context.Background(),
+			"authorization", "Bearer test-token", rpc.CallerKey, "payment-orchestrator", "x-request-id", requestID)
+	}
+	requestID := uuid.New().String()
+	deposited, err := client.Deposit(call(requestID), &ledgerv1.DepositRequest{UserId: "2d988f4a-a037-4ce9-a350-f13445793e88", Amount: "100"})
+	assert.NoError(t, err)
+	assert.Equal(t, "1cc535a5-bc57-4731-a64b-041b7ff41c30", deposited.GetWalletId())
+	assert.Equal(t, "100.00", deposited.GetBalance())
+
+	_, err = client.Deposit(call(requestID), &ledgerv1.DepositRequest{UserId: "2d988f4a-a037-4ce9-a350-f13445793e88", Amount: "100"})
+	assert.Equal(t, codes.AlreadyExists, status.Code(err))
+	assert.Equal(t, "DUPLICATE_REQUEST", problem.ErrorCode(status.Convert(err)))
+
+	_, err = client.Withdraw(call(uuid.New().String()), &ledgerv1.WithdrawRequest{UserId: "2d988f4a-a037-4ce9-a350-f13445793e88", Amount: "100.01"})
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+	assert.Equal(t, "INSUFFICIENT_FUNDS", problem.ErrorCode(status.Convert(err)))
+
+	balance, err := client.GetBalance(call(""), &ledgerv1.GetBalanceRequest{UserId: "2d988f4a-a037-4ce9-a350-f13445793e88"})
+	assert.NoError(t, err)
+	assert.Equal(t, "100.00", balance.GetBalance())
+
+	stream, err := client.ListPaymentHistory(call(""), &ledgerv1.ListPaymentHistoryRequest{UserId: "2d988f4a-a037-4ce9-a350-f13445793e88"})
+	assert.NoError(t, err)
+	var histories []*ledgerv1.PaymentHistory
+	for {
+		history, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		assert.NoError(t, err)
+		histories = append(histories, history)
+	}
+	assert.Len(t, histories, 1)
+	assert.Equal(t, "DEPOSIT", histories[0].GetPayType())
+	assert.Equal(t, requestID, histories[0].GetRequestId())
+}
@@ -0,0 +1,218 @@
+package service
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/raychongtk/wallet/audit"
+	"github.com/raychongtk/wallet/domain"
+	"github.com/raychongtk/wallet/hold"
+	"github.com/raychongtk/wallet/ledger"
+	"github.com/raychongtk/wallet/model/wallet"
+	"github.com/raychongtk/wallet/problem"
+	"github.com/raychongtk/wallet/tracing"
+	"github.com/raychongtk/wallet/util"
+	"go.uber.org/zap"
+	"net/http"
+	"time"
+)
+
+const maxHolds = 100
+
+func (s *Service) PlaceHold(ctx *gin.Context) {
+	var req PlaceHoldRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		util.Error("Invalid params", zap.Error(err))
+		problem.Respond(ctx, domain.ErrInvalidParameters.Wrap(err))
+		return
+	}
+	userId, err := uuid.Parse(req.UserId)
+	if err != nil {
+		problem.Respond(ctx, domain.ErrInvalidAccount.Wrap(err))
+		return
+	}
+	merchantId, err := uuid.Parse(req.MerchantUserId)
+	if err != nil {
+		problem.Respond(ctx, domain.ErrInvalidAccount.Wrap(err))
+		return
+	}
+	audit.Actor(ctx, audit.ActorUser, merchantId.String())
+	audit.Target(ctx, audit.TargetUser, userId.String())
+	amount, err := util.ConvertToInt(req.Amount)
+	if err != nil {
+		problem.Respond(ctx, domain.ErrInvalidParameters.Wrap(err))
+		return
+	}
+	var expiresIn time.Duration
+	if req.ExpiresAt != "" {
+		expiresAt, err := time.Parse(time.RFC3339, req.ExpiresAt)
+		if err != nil {
+			problem.Respond(ctx, domain.ErrInvalidParameters.WithMessage("expires_at must be an RFC 3339 time"))
+			return
+		}
+		if expiresIn = time.Until(expiresAt); expiresIn <= 0 {
+			problem.Respond(ctx, domain.ErrInvalidParameters.WithMessage("expires_at must be in the future"))
+			return
+		}
+	}
+
+	placed, err := s.holds.Place(ctx.Request.Context(), hold.PlaceCommand{
+		UserID:         userId,
+		MerchantUserID: merchantId,
+		Amount:         amount,
+		Reference:      req.Reference,
+		ExpiresIn:      expiresIn,
+		RequestID:      ctx.GetHeader(tracing.RequestIDHeader),
+	})
+	if err != nil {
+		util.Error("Place hold failed", zap.String("user_id", userId.String()), zap.Error(err))
+		problem.Respond(ctx, err)
+		return
+	}
+	response := newHold(placed)
+	audit.Target(ctx, audit.TargetHold, response.HoldId)
+	audit.Change(ctx, nil, response)
+	ctx.JSON(http.StatusCreated, response)
+}
+
+// GetHolds lists the holds on the wallet of a user, newest first
+func (s *Service) GetHolds(ctx *gin.Context) {
+	userId, err := uuid.Parse(ctx.Query("user_id"))
+	if err != nil {
+		problem.Respond(ctx, domain.ErrInvalidAccount.Wrap(err))
+		return
+	}
+	holds, err := s.holdRepo.SearchHolds(userId, ctx.Query("status"), maxHolds)
+	if err != nil {
+		util.Error("Search holds failed", zap.Error(err))
+		problem.Respond(ctx, err)
+		return
+	}
+	response := SearchHoldResponse{Holds: []Hold{}}
+	for i := range holds {
+		response.Holds = append(response.Holds, *newHold(&holds[i]))
+	}
+	ctx.JSON(http.StatusOK, &response)
+}
+
+func (s *Service) GetHold(ctx *gin.Context) {
+	holdId, err := uuid.Parse(ctx.Param("hold_id"))
+	if err != nil {
+		problem.Respond(ctx, domain.ErrInvalidParameters.Wrap(err))
+		return
+	}
+	walletHold, err := s.holdRepo.GetHold(holdId)
+	if err != nil {
+		problem.Respond(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, newHold(walletHold))
+}
+
+// CaptureHold books all or part of a hold to the merchant and gives the rest back. A captured hold answers with the
+// same capture again.
+func (s *Service) CaptureHold(ctx *gin.Context) {
+	holdId, err := uuid.Parse(ctx.Param("hold_id"))
+	if err != nil {
+		problem.Respond(ctx, domain.ErrInvalidParameters.Wrap(err))
+		return
+	}
+	audit.Target(ctx, audit.TargetHold, holdId.String())
+	var req CaptureHoldRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		util.Error("Invalid params", zap.Error(err))
+		problem.Respond(ctx, domain.ErrInvalidParameters.Wrap(err))
+		return
+	}
+	amount, err := util.ConvertToInt(req.Amount)
+	if err != nil {
+		problem.Respond(ctx, domain.ErrInvalidParameters.Wrap(err))
+		return
+	}
+
+	captured, result, err := s.ledger.CaptureHold(ctx.Request.Context(), ledger.CaptureHoldCommand{
+		HoldID:    holdId,
+		Amount:    amount,
+		RequestID: ctx.GetHeader(tracing.RequestIDHeader),
+	})
+	if err != nil {
+		util.Error("Capture hold failed", zap.String("hold_id", holdId.String()), zap.Error(err))
+		problem.Respond(ctx, err)
+		return
+	}
+	audit.Actor(ctx, audit.ActorUser, captured.MerchantUserID.String())
+	if result != nil {
+		auditResult(ctx, result)
+	}
+	ctx.JSON(http.StatusOK, newHold(captured))
+}
+
+func (s *Service) ReleaseHold(ctx *gin.Context) {
+	holdId, err := uuid.Parse(ctx.Param("hold_id"))
+	if err != nil {
+		problem.Respond(ctx, domain.ErrInvalidParameters.Wrap(err))
+		return
+	}
+	audit.Target(ctx, audit.TargetHold, holdId.String())
+	released, err := s.ledger.ReleaseHold(ctx.Request.Context(), holdId, wallet.HoldReleased)
+	if err != nil {
+		util.Error("Release hold failed", zap.String("hold_id", holdId.String()), zap.Error(err))
+		problem.Respond(ctx, err)
+		return
+	}
+	audit.Actor(ctx, audit.ActorUser, released.MerchantUserID.String())
+	audit.Change(ctx, gin.H{"status": wallet.HoldActive}, gin.H{"status": released.Status})
+	ctx.JSON(http.StatusOK, newHold(released))
+}
+
+func newHold(walletHold *wallet.Hold) *Hold {
+	response := &Hold{
+		HoldId:         walletHold.ID.String(),
+		UserId:         walletHold.UserID.String(),
+		MerchantUserId: walletHold.MerchantUserID.String(),
+		Amount:         util.DisplayAmount(walletHold.Amount),
+		Reference:      walletHold.Reference,
+		Status:         walletHold.Status,
+		ExpiresAt:      walletHold.ExpiresAt.UTC().Format(time.RFC3339),
+		CreatedAt:      walletHold.CreatedAt.UTC().Format(time.RFC3339),
+	}
+	if walletHold.Status == wallet.HoldCaptured {
+		response.CapturedAmount = util.DisplayAmount(walletHold.CapturedAmount)
+	}
+	if walletHold.GroupID != nil {
+		response.GroupId = walletHold.GroupID.String()
+	}
+	if walletHold.ResolvedAt != nil {
+		response.ResolvedAt = walletHold.ResolvedAt.UTC().Format(time.RFC3339)
+	}
+	return response
+}
+
+type PlaceHoldRequest struct {
+	UserId         string `json:"user_id" binding:"required"`
+	MerchantUserId string `json:"merchant_user_id" binding:"required"`
+	Amount         string `json:"amount" binding:"required"`
+	Reference      string `json:"reference"`
+	ExpiresAt      string `json:"expires_at"`
+}
+
+type CaptureHoldRequest struct {
+	Amount string `json:"amount" binding:"required"`
+}
+
+type Hold struct {
+	HoldId         string `json:"hold_id" binding:"required"`
+	UserId         string `json:"user_id" binding:"required"`
+	MerchantUserId string `json:"merchant_user_id" binding:"required"`
+	Amount         string `json:"amount" binding:"required"`
+	CapturedAmount string `json:"captured_amount,omitempty"`
+	Reference      string `json:"reference"`
+	Status         string `json:"status" binding:"required"`
+	ExpiresAt      string `json:"expires_at" binding:"required"`
+	GroupId        string `json:"group_id,omitempty"`
+	CreatedAt      string `json:"created_at" binding:"required"`
+	ResolvedAt     string `json:"resolved_at,omitempty"`
+}
+
+type SearchHoldResponse struct {
+	Holds []Hold `json:"holds"`
+}
@@ -0,0 +1,96 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/raychongtk/wallet/model/wallet"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func placeHold(t *testing.T, amount string) Hold {
+	resp := postJSON("/api/v1/holds", map[string]string{
+		"user_id":          johnUserId,
+		"merchant_user_id": rayUserId,
+		"amount":           amount,
+		"reference":        "order-1",
+	})
+	assert.Equal(t, http.StatusCreated, resp.Code)
+	var hold Hold
+	assert.NoError(t, json.Unmarshal(resp.Body.Bytes(), &hold))
+	assert.Equal(t, "ACTIVE", hold.Status)
+	return hold
+}
+
+func johnBalance(t *testing.T) GetCustomerBalanceResponse {
+	resp := httptest.NewRecorder()
+	ProvideRoutes(service).ServeHTTP(resp, httptest.NewRequest(http.MethodGet, "/api/v1/wallet/balance?user_id="+johnUserId, nil))
+	assert.Equal(t, http.StatusOK, resp.Code)
+	var balance GetCustomerBalanceResponse
+	assert.NoError(t, json.Unmarshal(resp.Body.Bytes(), &balance))
+	return balance
+}
+
+func TestHoldCaptureAndRelease(t *testing.T) {
+	_, _, cleanup, err := setupTestDB()
+	if err != nil {
+		t.Fatalf("failed to set up test DB: %v", err)
+	}
+	defer cleanup()
+
+	deposit := postJSON("/api/v1/wallet/deposit", map[string]string{"user_id": johnUserId, "balance": "100"})
+	assert.Equal(t, http.StatusOK, deposit.Code)
+	hold := placeHold(t, "60")
+	balance := johnBalance(t)
+	assert.Equal(t, "100.00", balance.Balance)
+	assert.Equal(t, "60.00", balance.Held)
+	assert.Equal(t, "40.00", balance.Available)
+
+	withdrawal := postJSON("/api/v1/wallet/withdrawal", map[string]string{"user_id": johnUserId, "balance": "50"})
+	assert.Equal(t, http.StatusBadRequest, withdrawal.Code)
+
+	capture := "/api/v1/holds/" + hold.HoldId + "/capture"
+	resp := postJSON(capture, map[string]string{"amount": "45"})
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.NoError(t, json.Unmarshal(resp.Body.Bytes(), &hold))
+	assert.Equal(t, "CAPTURED", hold.Status)
+	assert.Equal(t, "45.00", hold.CapturedAmount)
+	assert.NotEmpty(t, hold.GroupId)
+	// capturing again books nothing more
+	resp = postJSON(capture, map[string]string{"amount": "45"})
+	assert.Equal(t, http.StatusOK, resp.Code)
+	balance = johnBalance(t)
+	assert.Equal(t, "55.00", balance.Balance)
+	assert.Equal(t, "0.00", balance.Held)
+	assert.Equal(t, "55.00", balance.Available)
+
+	released := placeHold(t, "20")
+	resp = postJSON("/api/v1/holds/"+released.HoldId+"/release", nil)
+	assert.Equal(t, http.StatusOK, resp.Code)
+	resp = postJSON("/api/v1/holds/"+released.HoldId+"/capture", map[string]string{"amount": "20"})
+	assert.Equal(t, http.StatusConflict, resp.Code)
+	assert.Equal(t, "0.00", johnBalance(t).Held)
+}
+
+func TestHoldExpires(t *testing.T) {
+	db, _, cleanup, err := setupTestDB()
+	if err != nil {
+		t.Fatalf("failed to set up test DB: %v", err)
+	}
+	defer cleanup()
+
+	deposit := postJSON("/api/v1/wallet/deposit", map[string]string{"user_id": johnUserId, "balance": "100"})
+	assert.Equal(t, http.StatusOK, deposit.Code)
+	hold := placeHold(t, "30")
+	db.Model(&wallet.Hold{}).Where("id = ?", hold.HoldId).Update("expires_at", time.Now().Add(-time.Minute))
+	assert.NoError(t, service.holds.Expire(context.Background()))
+
+	resp := httptest.NewRecorder()
+	ProvideRoutes(service).ServeHTTP(resp, httptest.NewRequest(http.MethodGet, "/api/v1/holds/"+hold.HoldId, nil))
+	assert.NoError(t, json.Unmarshal(resp.Body.Bytes(), &hold))
+	assert.Equal(t, "EXPIRED", hold.Status)
+	assert.Equal(t, "100.00", johnBalance(t).Available)
+}
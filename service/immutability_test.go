@@ -0,0 +1,116 @@
+package service
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/google/uuid"
+	"github.com/raychongtk/wallet/model/archive"
+	"github.com/raychongtk/wallet/model/movement"
+	"github.com/raychongtk/wallet/repository"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestLedgerTablesRejectUpdateAndDelete(t *testing.T) {
+	db, _, cleanup, err := setupTestDB()
+	if err != nil {
+		t.Fatalf("failed to set up test DB: %v", err)
+	}
+	defer cleanup()
+
+	router := ProvideRoutes(service)
+	body, _ := json.Marshal(map[string]string{"user_id": "2d988f4a-a037-4ce9-a350-f13445793e88", "balance": "100"})
+	req, _ := http.NewRequest(http.MethodPost, "/api/v1/wallet/deposit", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Request-ID", uuid.New().String())
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+	assert.Equal(t, http.StatusOK, resp.Code)
+
+	var deposited movement.Movement
+	assert.NoError(t, db.First(&deposited).Error)
+
+	_, err = service.movementRepo.DeleteMovements(db, []uuid.UUID{deposited.ID})
+	assert.ErrorIs(t, err, repository.ErrImmutableRecord)
+	var immutabilityErr *repository.ImmutabilityError
+	assert.ErrorAs(t, err, &immutabilityErr)
+	assert.Equal(t, "movement", immutabilityErr.Table)
+
+	var transaction movement.Transaction
+	assert.NoError(t, db.First(&transaction).Error)
+	_, err = service.transactionRepo.DeleteTransactions(db, []uuid.UUID{transaction.ID})
+	assert.ErrorIs(t, err, repository.ErrImmutableRecord)
+
+	assert.Error(t, db.Exec("UPDATE transaction SET balance = 1").Error)
+	assert.Error(t, db.Exec("UPDATE payment_history SET amount = 1").Error)
+	assert.Error(t, db.Exec("UPDATE movement SET debit_balance = 1").Error)
+	assert.Error(t, db.Exec("TRUNCATE transaction").Error)
+
+	err = service.movementRepo.UpdateMovementStatus(db, deposited.ID, "PENDING")
+	assert.ErrorIs(t, err, repository.ErrInvalidStatusTransition)
+	assert.NoError(t, service.movementRepo.UpdateMovementStatus(db, deposited.ID, "REVERSED"))
+
+	var reversed movement.Movement
+	assert.NoError(t, db.First(&reversed, "id = ?", deposited.ID).Error)
+	assert.Equal(t, "REVERSED", reversed.MovementStatus)
+}
+
+func TestApplicationRoleCannotArchiveLedgerRows(t *testing.T) {
+	db, _, cleanup, err := setupTestDB()
+	if err != nil {
+		t.Fatalf("failed to set up test DB: %v", err)
+	}
+	defer cleanup()
+
+	router := ProvideRoutes(service)
+	body, _ := json.Marshal(map[string]string{"user_id": "2d988f4a-a037-4ce9-a350-f13445793e88", "balance": "100"})
+	req, _ := http.NewRequest(http.MethodPost, "/api/v1/wallet/deposit", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Request-ID", uuid.New().String())
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+	assert.Equal(t, http.StatusOK, resp.Code)
+
+	var deposited movement.Movement
+	assert.NoError(t, db.First(&deposited).Error)
+	manifest := &archive.Manifest{
+		ID:          uuid.New(),
+		SourceTable: "movement",
+		RangeStart:  deposited.CreatedAt.Add(-time.Hour),
+		RangeEnd:    deposited.CreatedAt.Add(time.Hour),
+		ObjectKey:   "movement/forged.ndjson.gz",
+		Format:      "NDJSON_GZIP",
+		CreatedAt:   time.Now(),
+	}
+	manifestRepo := repository.ProvideArchiveManifestRepository(*db)
+
+	// the application may neither record a manifest
+	err = db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec("SET LOCAL ROLE wallet_app").Error; err != nil {
+			return err
+		}
+		_, err := manifestRepo.CreateManifest(tx, manifest, nil)
+		return err
+	})
+	assert.Error(t, err)
+
+	// nor delete the rows a manifest covers
+	_, err = manifestRepo.CreateManifest(db, manifest, nil)
+	assert.NoError(t, err)
+	err = db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec("SET LOCAL ROLE wallet_app").Error; err != nil {
+			return err
+		}
+		if err := tx.Exec("SELECT set_config('wallet.archive_manifest_id', ?, true)", manifest.ID.String()).Error; err != nil {
+			return err
+		}
+		_, err := service.movementRepo.DeleteMovements(tx, []uuid.UUID{deposited.ID})
+		return err
+	})
+	assert.Error(t, err)
+	assert.NoError(t, db.First(&movement.Movement{}, "id = ?", deposited.ID).Error)
+}
@@ -4,20 +4,47 @@ import (
 	"context"
 	"fmt"
 	"github.com/go-redis/redis/v8"
+	"github.com/raychongtk/wallet/archive"
+	"github.com/raychongtk/wallet/audit"
+	"github.com/raychongtk/wallet/business"
+	"github.com/raychongtk/wallet/config"
+	"github.com/raychongtk/wallet/datastore"
+	"github.com/raychongtk/wallet/escrow"
+	"github.com/raychongtk/wallet/health"
+	"github.com/raychongtk/wallet/hold"
+	"github.com/raychongtk/wallet/integrity"
+	"github.com/raychongtk/wallet/interest"
+	"github.com/raychongtk/wallet/joint"
+	"github.com/raychongtk/wallet/ledger"
+	"github.com/raychongtk/wallet/migration"
+	"github.com/raychongtk/wallet/notify"
+	"github.com/raychongtk/wallet/openapi"
+	"github.com/raychongtk/wallet/paymentrequest"
+	"github.com/raychongtk/wallet/payout"
 	"github.com/raychongtk/wallet/repository"
+	"github.com/raychongtk/wallet/scheduler"
 	"github.com/raychongtk/wallet/util"
 	"github.com/testcontainers/testcontainers-go"
 	"github.com/testcontainers/testcontainers-go/wait"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
+	"os"
 	"time"
 )
 
 var service *Service
 
+var objectStore datastore.ObjectStore
+
+var replicaRouter *datastore.ReplicaRouter
+
 func setupTestDB() (*gorm.DB, *redis.Client, func(), error) {
 	util.InitializeLogger(false)
 	ctx := context.Background()
+	cfg, err := config.Load(config.ProfileTest, "../config")
+	if err != nil {
+		return nil, nil, nil, err
+	}
 
 	req := testcontainers.ContainerRequest{
 		Image:        "postgres:latest",
@@ -53,6 +80,9 @@ func setupTestDB() (*gorm.DB, *redis.Client, func(), error) {
 	if err != nil {
 		return nil, nil, nil, err
 	}
+	if err := migration.ProvideMigrator(*db).Migrate(ctx); err != nil {
+		return nil, nil, nil, err
+	}
 
 	redisReq := testcontainers.ContainerRequest{
 		Image:        "redis:6.2-alpine",
@@ -82,21 +112,91 @@ func setupTestDB() (*gorm.DB, *redis.Client, func(), error) {
 		return nil, nil, nil, err
 	}
 
+	archiveDir, err := os.MkdirTemp("", "wallet-archive")
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	objectStore, err = datastore.NewLocalObjectStore(archiveDir)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	replicaRouter = datastore.NewReplicaRouter(db, nil, redisClient, cfg.DB.Replica)
+
+	userRepo := repository.ProvideUserRepository(*db)
+	movementRepo := repository.ProvideMovementRepository(*db)
+	accountRepo := repository.ProvideAccountRepository(*db)
+	walletRepo := repository.ProvideWalletRepository(*db)
+	transactionRepo := repository.ProvideTransactionRepository(*db, replicaRouter)
+	balanceRepo := repository.ProvideBalanceRepository(*db, replicaRouter)
+	paymentHistoryRepo := repository.ProvidePaymentHistoryRepository(*db, replicaRouter)
+	ledgerHashRepo := repository.ProvideLedgerHashRepository(*db)
+	chain := integrity.ProvideChain(ledgerHashRepo)
+	payoutRepo := repository.ProvidePayoutRepository(*db)
+	scheduleRepo := repository.ProvideScheduledTransferRepository(*db)
+	notificationRepo := repository.ProvideNotificationRepository(*db)
+	paymentRequestRepo := repository.ProvidePaymentRequestRepository(*db)
+	holdRepo := repository.ProvideHoldRepository(*db)
+	escrowRepo := repository.ProvideEscrowRepository(*db)
+	interestRepo := repository.ProvideInterestRepository(*db)
+	pocketRepo := repository.ProvidePocketRepository(*db)
+	memberRepo := repository.ProvideMemberRepository(*db)
+	sharedTransferRepo := repository.ProvideSharedTransferRepository(*db)
+	organizationRepo := repository.ProvideOrganizationRepository(*db)
+	businessTransferRepo := repository.ProvideBusinessTransferRepository(*db)
+	notifier := notify.ProvideNotifier(notificationRepo, *db)
+	unitOfWork := ledger.ProvideUnitOfWork(*db, movementRepo, transactionRepo, balanceRepo, paymentHistoryRepo, holdRepo, escrowRepo, interestRepo, pocketRepo, memberRepo, sharedTransferRepo, businessTransferRepo, chain, replicaRouter)
+	walletLedger := ledger.ProvideLedger(userRepo, accountRepo, organizationRepo, walletRepo, memberRepo, unitOfWork, cfg)
+	auditor := audit.ProvideAuditor(repository.ProvideAuditLogRepository(*db), *db)
+
+	archiveReader := archive.ProvideReader(repository.ProvideArchiveManifestRepository(*db), objectStore)
+
+	validator, err := openapi.ProvideValidator(cfg)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
 	service = &Service{
-		repository.ProvideUserRepository(*db),
-		repository.ProvideMovementRepository(*db),
-		repository.ProvideAccountRepository(*db),
-		repository.ProvideWalletRepository(*db),
-		repository.ProvideTransactionRepository(*db),
-		repository.ProvideBalanceRepository(*db),
-		repository.ProvidePaymentHistoryRepository(*db),
+		userRepo,
+		movementRepo,
+		accountRepo,
+		walletRepo,
+		transactionRepo,
+		balanceRepo,
+		paymentHistoryRepo,
+		payoutRepo,
+		scheduleRepo,
+		notificationRepo,
+		paymentRequestRepo,
+		holdRepo,
+		escrowRepo,
+		interestRepo,
+		pocketRepo,
+		sharedTransferRepo,
+		businessTransferRepo,
 		*db,
 		*redisClient,
+		archiveReader,
+		cfg,
+		walletLedger,
+		auditor,
+		validator,
+		payout.ProvideProcessor(walletLedger, payoutRepo, movementRepo, balanceRepo, *db, cfg, auditor),
+		scheduler.ProvideScheduler(walletLedger, scheduleRepo, *db, cfg, notifier, auditor),
+		paymentrequest.ProvideManager(walletLedger, paymentRequestRepo, *db, cfg, notifier),
+		hold.ProvideManager(walletLedger, holdRepo, cfg),
+		escrow.ProvideManager(walletLedger, escrowRepo, cfg),
+		interest.ProvideManager(walletLedger, interestRepo, transactionRepo, archiveReader, cfg),
+		joint.ProvideManager(walletLedger, accountRepo, memberRepo, sharedTransferRepo, *db, notifier),
+		business.ProvideManager(walletLedger, organizationRepo, repository.ProvideBusinessMemberRepository(*db), repository.ProvidePaymentPolicyRepository(*db), businessTransferRepo, *db, notifier),
+		health.NewChecker(cfg.Server.HealthTimeout),
+		integrity.NewCheckpointer(ledgerHashRepo, *db, nil, cfg.Ledger.CheckpointInterval),
 	}
 
 	cleanup := func() {
 		postgresC.Terminate(ctx)
 		redisC.Terminate(ctx)
+		os.RemoveAll(archiveDir)
 	}
 
 	return db, redisClient, cleanup, nil
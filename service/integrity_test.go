@@ -0,0 +1,93 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"encoding/base64"
+	"encoding/json"
+	"github.com/google/uuid"
+	"github.com/raychongtk/wallet/integrity"
+	"github.com/raychongtk/wallet/repository"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestLedgerHashChainDetectsTampering(t *testing.T) {
+	db, _, cleanup, err := setupTestDB()
+	if err != nil {
+		t.Fatalf("failed to set up test DB: %v", err)
+	}
+	defer cleanup()
+
+	router := ProvideRoutes(service)
+	requests := []struct {
+		path    string
+		payload map[string]string
+	}{
+		{"/api/v1/wallet/deposit", map[string]string{"user_id": "2d988f4a-a037-4ce9-a350-f13445793e88", "balance": "200"}},
+		{"/api/v1/wallet/transfer", map[string]string{"credit_user_id": "2d988f4a-a037-4ce9-a350-f13445793e88", "debit_user_id": "c6e97817-0254-43ad-8610-7ac9d3f7af92", "balance": "50"}},
+		{"/api/v1/wallet/withdrawal", map[string]string{"user_id": "2d988f4a-a037-4ce9-a350-f13445793e88", "balance": "100"}},
+	}
+	for _, request := range requests {
+		body, _ := json.Marshal(request.payload)
+		req, _ := http.NewRequest(http.MethodPost, request.path, bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("X-Request-ID", uuid.New().String())
+		resp := httptest.NewRecorder()
+		router.ServeHTTP(resp, req)
+		assert.Equal(t, http.StatusOK, resp.Code)
+	}
+
+	hashRepo := repository.ProvideLedgerHashRepository(*db)
+	signer, err := integrity.NewSigner(base64.StdEncoding.EncodeToString(make([]byte, ed25519.SeedSize)))
+	assert.NoError(t, err)
+	checkpoint, err := integrity.NewCheckpointer(hashRepo, *db, signer, time.Hour).Checkpoint(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), checkpoint.Sequence)
+
+	verifier := integrity.NewVerifier(service.movementRepo, service.transactionRepo, hashRepo, service.archiveReader, signer.PublicKey())
+	report, err := verifier.Verify(context.Background())
+	assert.NoError(t, err)
+	assert.True(t, report.OK())
+	assert.Equal(t, int64(3), report.Entries)
+	assert.Equal(t, checkpoint.Hash, report.HeadHash)
+	// deposit and withdrawal touch two wallets, the transfer touches three
+	assert.Equal(t, int64(7), report.WalletEntries)
+
+	second, err := hashRepo.GetEntry(2)
+	assert.NoError(t, err)
+	tamper(t, db, `UPDATE transaction SET balance = balance + 1
+		WHERE movement_id IN (SELECT id FROM movement WHERE group_id = ?)`, second.GroupID)
+
+	report, err = verifier.Verify(context.Background())
+	assert.NoError(t, err)
+	assert.False(t, report.OK())
+	assert.Equal(t, int64(2), report.Failures[0].Sequence)
+	assert.Equal(t, "movement or transaction was altered", report.Failures[0].Reason)
+
+	tamper(t, db, "UPDATE ledger_hash SET hash = ? WHERE sequence = 3", integrity.GenesisHash)
+	report, err = verifier.Verify(context.Background())
+	assert.NoError(t, err)
+	var reasons []string
+	for _, failure := range report.Failures {
+		reasons = append(reasons, failure.Reason)
+	}
+	assert.Contains(t, reasons, "entry hash was altered")
+	assert.Contains(t, reasons, "chain does not match the signed checkpoint")
+}
+
+// tamper rewrites history the way a superuser could, with the immutability triggers switched off
+func tamper(t *testing.T, db *gorm.DB, sql string, values ...interface{}) {
+	err := db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec("SET LOCAL session_replication_role = replica").Error; err != nil {
+			return err
+		}
+		return tx.Exec(sql, values...).Error
+	})
+	assert.NoError(t, err)
+}
@@ -0,0 +1,88 @@
+package service
+
+import (
+	"errors"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/raychongtk/wallet/domain"
+	"github.com/raychongtk/wallet/problem"
+	"github.com/raychongtk/wallet/util"
+	"go.uber.org/zap"
+	"net/http"
+	"time"
+)
+
+// maxAccruals is about three months of daily accruals
+const maxAccruals = 100
+
+// GetInterest shows the interest accrued and not paid out yet with the latest daily accruals behind it
+func (s *Service) GetInterest(ctx *gin.Context) {
+	userId, err := uuid.Parse(ctx.Query("user_id"))
+	if err != nil {
+		problem.Respond(ctx, domain.ErrInvalidAccount.Wrap(err))
+		return
+	}
+	customer, err := s.ledger.Customer(userId)
+	if err != nil {
+		problem.Respond(ctx, err)
+		return
+	}
+	accrued, err := s.accruedInterest(customer.Wallet.ID)
+	if err != nil {
+		util.Error("Get accrued interest failed", zap.Error(err))
+		problem.Respond(ctx, err)
+		return
+	}
+	accruals, err := s.interestRepo.SearchAccruals(customer.Wallet.ID, maxAccruals)
+	if err != nil {
+		util.Error("Search accruals failed", zap.Error(err))
+		problem.Respond(ctx, err)
+		return
+	}
+	response := GetInterestResponse{
+		CustomerID: userId.String(),
+		Currency:   customer.Wallet.Currency,
+		Accrued:    util.DisplayAmount(accrued),
+		Accruals:   []InterestAccrual{},
+	}
+	for _, accrual := range accruals {
+		interestAccrual := InterestAccrual{
+			Day:      accrual.Day.Format(time.DateOnly),
+			Balance:  util.DisplayAmount(accrual.Balance),
+			Interest: util.DisplayAmount(accrual.Amount),
+			Paid:     accrual.PaidAt != nil,
+		}
+		if accrual.PayoutGroupID != nil {
+			interestAccrual.PayoutGroupId = accrual.PayoutGroupID.String()
+		}
+		response.Accruals = append(response.Accruals, interestAccrual)
+	}
+	ctx.JSON(http.StatusOK, &response)
+}
+
+// accruedInterest is the ACCRUED_INTEREST balance, a wallet without one accrued nothing
+func (s *Service) accruedInterest(walletID uuid.UUID) (int, error) {
+	balance, err := s.balanceRepo.GetBalance(walletID, "ACCRUED_INTEREST")
+	if errors.Is(err, domain.ErrNotFound) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	return balance.Balance, nil
+}
+
+type GetInterestResponse struct {
+	CustomerID string            `json:"customer_id" binding:"required"`
+	Currency   string            `json:"currency" binding:"required"`
+	Accrued    string            `json:"accrued_interest" binding:"required"`
+	Accruals   []InterestAccrual `json:"accruals" binding:"required"`
+}
+
+type InterestAccrual struct {
+	Day           string `json:"day" binding:"required"`
+	Balance       string `json:"balance" binding:"required"`
+	Interest      string `json:"interest" binding:"required"`
+	Paid          bool   `json:"paid"`
+	PayoutGroupId string `json:"payout_group_id,omitempty"`
+}
@@ -0,0 +1,59 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func johnInterest(t *testing.T) GetInterestResponse {
+	resp := httptest.NewRecorder()
+	ProvideRoutes(service).ServeHTTP(resp, httptest.NewRequest(http.MethodGet, "/api/v1/wallet/interest?user_id="+johnUserId, nil))
+	assert.Equal(t, http.StatusOK, resp.Code)
+	var interest GetInterestResponse
+	assert.NoError(t, json.Unmarshal(resp.Body.Bytes(), &interest))
+	return interest
+}
+
+func TestInterestAccruesDailyAndIsPaidMonthly(t *testing.T) {
+	_, _, cleanup, err := setupTestDB()
+	if err != nil {
+		t.Fatalf("failed to set up test DB: %v", err)
+	}
+	defer cleanup()
+
+	deposit := postJSON("/api/v1/wallet/deposit", map[string]string{"user_id": johnUserId, "balance": "100"})
+	assert.Equal(t, http.StatusOK, deposit.Code)
+	ctx := context.Background()
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+
+	// running as of tomorrow accrues today, running it again accrues nothing more
+	for i := 0; i < 2; i++ {
+		assert.NoError(t, service.interests.Run(ctx, today.AddDate(0, 0, 1)))
+	}
+	interest := johnInterest(t)
+	assert.Len(t, interest.Accruals, 1)
+	assert.Equal(t, "100.00", interest.Accruals[0].Balance)
+	assert.Equal(t, "0.10", interest.Accruals[0].Interest)
+	assert.Error(t, service.interests.Accrue(ctx, today))
+
+	// once the month ended every day of it is paid out into the balance
+	nextMonth := time.Date(today.Year(), today.Month()+1, 1, 0, 0, 0, 0, time.UTC)
+	days := int(nextMonth.Sub(today).Hours() / 24)
+	assert.NoError(t, service.interests.Run(ctx, nextMonth))
+	interest = johnInterest(t)
+	assert.Len(t, interest.Accruals, days)
+	assert.Equal(t, "0.00", interest.Accrued)
+	for _, accrual := range interest.Accruals {
+		assert.True(t, accrual.Paid)
+		assert.Equal(t, interest.Accruals[0].PayoutGroupId, accrual.PayoutGroupId)
+	}
+	balance := johnBalance(t)
+	assert.Equal(t, fmt.Sprintf("%.2f", 100+float64(days)*0.1), balance.Balance)
+	assert.Equal(t, "0.00", balance.AccruedInterest)
+}
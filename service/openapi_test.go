@@ -0,0 +1,31 @@
+package service
+
+import (
+	"github.com/raychongtk/wallet/config"
+	"github.com/raychongtk/wallet/openapi"
+	"github.com/stretchr/testify/assert"
+	"regexp"
+	"testing"
+)
+
+var ginParam = regexp.MustCompile(`:(\w+)`)
+
+// The document is the contract clients are generated from, so every route must be in it and nothing else
+func TestRoutesMatchOpenAPIDocument(t *testing.T) {
+	cfg, err := config.Load(config.ProfileTest, "../config")
+	assert.NoError(t, err)
+	spec, err := openapi.Load()
+	assert.NoError(t, err)
+
+	documented := map[string]bool{}
+	for path, item := range spec.Paths.Map() {
+		for method := range item.Operations() {
+			documented[method+" "+path] = true
+		}
+	}
+	registered := map[string]bool{}
+	for _, route := range ProvideRoutes(&Service{config: cfg}).Routes() {
+		registered[route.Method+" "+ginParam.ReplaceAllString(route.Path, "{$1}")] = true
+	}
+	assert.Equal(t, documented, registered)
+}
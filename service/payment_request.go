@@ -0,0 +1,233 @@
+package service
+
+import (
+	"context"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/raychongtk/wallet/audit"
+	"github.com/raychongtk/wallet/domain"
+	"github.com/raychongtk/wallet/model/payment"
+	"github.com/raychongtk/wallet/paymentrequest"
+	"github.com/raychongtk/wallet/problem"
+	"github.com/raychongtk/wallet/repository"
+	"github.com/raychongtk/wallet/tracing"
+	"github.com/raychongtk/wallet/util"
+	"go.uber.org/zap"
+	"net/http"
+	"time"
+)
+
+const maxPaymentRequests = 100
+
+func (s *Service) CreatePaymentRequest(ctx *gin.Context) {
+	var req CreatePaymentRequestRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		util.Error("Invalid params", zap.Error(err))
+		problem.Respond(ctx, domain.ErrInvalidParameters.Wrap(err))
+		return
+	}
+	requesterId, err := uuid.Parse(req.RequesterUserId)
+	if err != nil {
+		problem.Respond(ctx, domain.ErrInvalidAccount.Wrap(err))
+		return
+	}
+	payerId, err := uuid.Parse(req.PayerUserId)
+	if err != nil {
+		problem.Respond(ctx, domain.ErrInvalidAccount.Wrap(err))
+		return
+	}
+	audit.Actor(ctx, audit.ActorUser, requesterId.String())
+	audit.Target(ctx, audit.TargetUser, payerId.String())
+	amount, err := util.ConvertToInt(req.Amount)
+	if err != nil {
+		problem.Respond(ctx, domain.ErrInvalidParameters.Wrap(err))
+		return
+	}
+	var expiresIn time.Duration
+	if req.ExpiresAt != "" {
+		expiresAt, err := time.Parse(time.RFC3339, req.ExpiresAt)
+		if err != nil {
+			problem.Respond(ctx, domain.ErrInvalidParameters.WithMessage("expires_at must be an RFC 3339 time"))
+			return
+		}
+		if expiresIn = time.Until(expiresAt); expiresIn <= 0 {
+			problem.Respond(ctx, domain.ErrInvalidParameters.WithMessage("expires_at must be in the future"))
+			return
+		}
+	}
+
+	paymentRequest, err := s.paymentRequests.Create(ctx.Request.Context(), paymentrequest.CreateCommand{
+		RequesterUserID: requesterId,
+		PayerUserID:     payerId,
+		Amount:          amount,
+		Memo:            req.Memo,
+		ExpiresIn:       expiresIn,
+		RequestID:       ctx.GetHeader(tracing.RequestIDHeader),
+	})
+	if err != nil {
+		util.Error("Create payment request failed", zap.String("user_id", requesterId.String()), zap.Error(err))
+		problem.Respond(ctx, err)
+		return
+	}
+	response := newPaymentRequest(paymentRequest)
+	audit.Target(ctx, audit.TargetPaymentRequest, response.PaymentRequestId)
+	audit.Change(ctx, nil, response)
+	ctx.JSON(http.StatusCreated, response)
+}
+
+// GetPaymentRequests lists the requests a user sent or the ones they were asked to pay, newest first
+func (s *Service) GetPaymentRequests(ctx *gin.Context) {
+	userId, err := uuid.Parse(ctx.Query("user_id"))
+	if err != nil {
+		problem.Respond(ctx, domain.ErrInvalidAccount.Wrap(err))
+		return
+	}
+	filter := repository.PaymentRequestFilter{Status: ctx.Query("status")}
+	switch ctx.DefaultQuery("role", "received") {
+	case "sent":
+		filter.RequesterUserID = userId
+	case "received":
+		filter.PayerUserID = userId
+	default:
+		problem.Respond(ctx, domain.ErrInvalidParameters.WithMessage("role must be sent or received"))
+		return
+	}
+	paymentRequests, err := s.paymentRequestRepo.SearchPaymentRequests(filter, maxPaymentRequests)
+	if err != nil {
+		util.Error("Search payment requests failed", zap.Error(err))
+		problem.Respond(ctx, err)
+		return
+	}
+	response := SearchPaymentRequestResponse{PaymentRequests: []PaymentRequest{}}
+	for i := range paymentRequests {
+		response.PaymentRequests = append(response.PaymentRequests, *newPaymentRequest(&paymentRequests[i]))
+	}
+	ctx.JSON(http.StatusOK, &response)
+}
+
+// GetPaymentRequest returns a request with every status it went through
+func (s *Service) GetPaymentRequest(ctx *gin.Context) {
+	id, err := uuid.Parse(ctx.Param("payment_request_id"))
+	if err != nil {
+		problem.Respond(ctx, domain.ErrInvalidParameters.Wrap(err))
+		return
+	}
+	paymentRequest, err := s.paymentRequestRepo.GetPaymentRequest(id)
+	if err != nil {
+		problem.Respond(ctx, err)
+		return
+	}
+	events, err := s.paymentRequestRepo.SearchPaymentRequestEvents(id)
+	if err != nil {
+		util.Error("Search payment request events failed", zap.Error(err))
+		problem.Respond(ctx, err)
+		return
+	}
+	response := newPaymentRequest(paymentRequest)
+	response.Events = []PaymentRequestEvent{}
+	for _, event := range events {
+		response.Events = append(response.Events, PaymentRequestEvent{
+			FromStatus: event.FromStatus,
+			ToStatus:   event.ToStatus,
+			Actor:      event.Actor,
+			CreatedAt:  event.CreatedAt.UTC().Format(time.RFC3339),
+		})
+	}
+	ctx.JSON(http.StatusOK, response)
+}
+
+// ApprovePaymentRequest pays a request. It can be retried safely, a request is paid once whatever the request id.
+func (s *Service) ApprovePaymentRequest(ctx *gin.Context) {
+	s.resolvePaymentRequest(ctx, s.paymentRequests.Approve)
+}
+
+func (s *Service) DeclinePaymentRequest(ctx *gin.Context) {
+	s.resolvePaymentRequest(ctx, s.paymentRequests.Decline)
+}
+
+func (s *Service) resolvePaymentRequest(ctx *gin.Context, resolve func(ctx context.Context, id uuid.UUID, payerUserID uuid.UUID) (*payment.PaymentRequest, error)) {
+	id, err := uuid.Parse(ctx.Param("payment_request_id"))
+	if err != nil {
+		problem.Respond(ctx, domain.ErrInvalidParameters.Wrap(err))
+		return
+	}
+	audit.Target(ctx, audit.TargetPaymentRequest, id.String())
+	var req ResolvePaymentRequestRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		util.Error("Invalid params", zap.Error(err))
+		problem.Respond(ctx, domain.ErrInvalidParameters.Wrap(err))
+		return
+	}
+	payerId, err := uuid.Parse(req.PayerUserId)
+	if err != nil {
+		problem.Respond(ctx, domain.ErrInvalidAccount.Wrap(err))
+		return
+	}
+	audit.Actor(ctx, audit.ActorUser, payerId.String())
+
+	paymentRequest, err := resolve(ctx.Request.Context(), id, payerId)
+	if err != nil {
+		util.Error("Resolve payment request failed", zap.String("payment_request_id", id.String()), zap.Error(err))
+		problem.Respond(ctx, err)
+		return
+	}
+	audit.Change(ctx, gin.H{"status": payment.RequestPending}, gin.H{"status": paymentRequest.Status, "group_id": paymentRequest.GroupID})
+	ctx.JSON(http.StatusOK, newPaymentRequest(paymentRequest))
+}
+
+func newPaymentRequest(paymentRequest *payment.PaymentRequest) *PaymentRequest {
+	response := &PaymentRequest{
+		PaymentRequestId: paymentRequest.ID.String(),
+		RequesterUserId:  paymentRequest.RequesterUserID.String(),
+		PayerUserId:      paymentRequest.PayerUserID.String(),
+		Amount:           util.DisplayAmount(paymentRequest.Amount),
+		Memo:             paymentRequest.Memo,
+		Status:           paymentRequest.Status,
+		ExpiresAt:        paymentRequest.ExpiresAt.UTC().Format(time.RFC3339),
+		CreatedAt:        paymentRequest.CreatedAt.UTC().Format(time.RFC3339),
+	}
+	if paymentRequest.GroupID != nil {
+		response.GroupId = paymentRequest.GroupID.String()
+	}
+	if paymentRequest.ResolvedAt != nil {
+		response.ResolvedAt = paymentRequest.ResolvedAt.UTC().Format(time.RFC3339)
+	}
+	return response
+}
+
+type CreatePaymentRequestRequest struct {
+	RequesterUserId string `json:"requester_user_id" binding:"required"`
+	PayerUserId     string `json:"payer_user_id" binding:"required"`
+	Amount          string `json:"amount" binding:"required"`
+	Memo            string `json:"memo"`
+	ExpiresAt       string `json:"expires_at"`
+}
+
+type ResolvePaymentRequestRequest struct {
+	PayerUserId string `json:"payer_user_id" binding:"required"`
+}
+
+type PaymentRequest struct {
+	PaymentRequestId string                `json:"payment_request_id" binding:"required"`
+	RequesterUserId  string                `json:"requester_user_id" binding:"required"`
+	PayerUserId      string                `json:"payer_user_id" binding:"required"`
+	Amount           string                `json:"amount" binding:"required"`
+	Memo             string                `json:"memo"`
+	Status           string                `json:"status" binding:"required"`
+	ExpiresAt        string                `json:"expires_at" binding:"required"`
+	GroupId          string                `json:"group_id,omitempty"`
+	CreatedAt        string                `json:"created_at" binding:"required"`
+	ResolvedAt       string                `json:"resolved_at,omitempty"`
+	Events           []PaymentRequestEvent `json:"events,omitempty"`
+}
+
+type SearchPaymentRequestResponse struct {
+	PaymentRequests []PaymentRequest `json:"payment_requests"`
+}
+
+type PaymentRequestEvent struct {
+	FromStatus string `json:"from_status"`
+	ToStatus   string `json:"to_status" binding:"required"`
+	Actor      string `json:"actor" binding:"required"`
+	CreatedAt  string `json:"created_at" binding:"required"`
+}
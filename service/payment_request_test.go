@@ -0,0 +1,132 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"github.com/google/uuid"
+	"github.com/raychongtk/wallet/model/payment"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+const (
+	johnUserId = "2d988f4a-a037-4ce9-a350-f13445793e88"
+	rayUserId  = "c6e97817-0254-43ad-8610-7ac9d3f7af92"
+)
+
+// postJSON posts a body with a fresh request id
+func postJSON(path string, body interface{}) *httptest.ResponseRecorder {
+	raw, _ := json.Marshal(body)
+	req, _ := http.NewRequest(http.MethodPost, path, bytes.NewBuffer(raw))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Request-ID", uuid.New().String())
+	resp := httptest.NewRecorder()
+	ProvideRoutes(service).ServeHTTP(resp, req)
+	return resp
+}
+
+// createPaymentRequest has Ray ask John for money
+func createPaymentRequest(t *testing.T, amount string) PaymentRequest {
+	resp := postJSON("/api/v1/payment-requests", map[string]string{
+		"requester_user_id": rayUserId,
+		"payer_user_id":     johnUserId,
+		"amount":            amount,
+		"memo":              "dinner",
+	})
+	assert.Equal(t, http.StatusCreated, resp.Code)
+	var paymentRequest PaymentRequest
+	assert.NoError(t, json.Unmarshal(resp.Body.Bytes(), &paymentRequest))
+	assert.Equal(t, "PENDING", paymentRequest.Status)
+	return paymentRequest
+}
+
+func TestPaymentRequestApprovedOnce(t *testing.T) {
+	db, _, cleanup, err := setupTestDB()
+	if err != nil {
+		t.Fatalf("failed to set up test DB: %v", err)
+	}
+	defer cleanup()
+
+	deposit := postJSON("/api/v1/wallet/deposit", map[string]string{"user_id": johnUserId, "balance": "100"})
+	assert.Equal(t, http.StatusOK, deposit.Code)
+	paymentRequest := createPaymentRequest(t, "30")
+
+	approve := "/api/v1/payment-requests/" + paymentRequest.PaymentRequestId + "/approve"
+	resp := postJSON(approve, map[string]string{"payer_user_id": rayUserId})
+	assert.Equal(t, http.StatusNotFound, resp.Code)
+
+	var approved PaymentRequest
+	for i := 0; i < 2; i++ {
+		resp = postJSON(approve, map[string]string{"payer_user_id": johnUserId})
+		assert.Equal(t, http.StatusOK, resp.Code)
+		var again PaymentRequest
+		assert.NoError(t, json.Unmarshal(resp.Body.Bytes(), &again))
+		assert.Equal(t, "APPROVED", again.Status)
+		assert.NotEmpty(t, again.GroupId)
+		if i > 0 {
+			assert.Equal(t, approved.GroupId, again.GroupId)
+		}
+		approved = again
+	}
+	payerBalance, _ := service.balanceRepo.GetBalanceWithLock(db, uuid.MustParse("1cc535a5-bc57-4731-a64b-041b7ff41c30"), "COMMITTED")
+	assert.Equal(t, 7000, payerBalance.Balance)
+	requesterBalance, _ := service.balanceRepo.GetBalanceWithLock(db, uuid.MustParse("c7d90b83-e080-423a-ab1b-f48094d7533e"), "COMMITTED")
+	assert.Equal(t, 3000, requesterBalance.Balance)
+
+	resp = postJSON("/api/v1/payment-requests/"+paymentRequest.PaymentRequestId+"/decline", map[string]string{"payer_user_id": johnUserId})
+	assert.Equal(t, http.StatusConflict, resp.Code)
+
+	resp = httptest.NewRecorder()
+	ProvideRoutes(service).ServeHTTP(resp, httptest.NewRequest(http.MethodGet, "/api/v1/payment-requests/"+paymentRequest.PaymentRequestId, nil))
+	assert.Equal(t, http.StatusOK, resp.Code)
+	var detail PaymentRequest
+	assert.NoError(t, json.Unmarshal(resp.Body.Bytes(), &detail))
+	assert.Len(t, detail.Events, 2)
+	assert.Equal(t, "APPROVED", detail.Events[1].ToStatus)
+	assert.Equal(t, johnUserId, detail.Events[1].Actor)
+
+	resp = httptest.NewRecorder()
+	ProvideRoutes(service).ServeHTTP(resp, httptest.NewRequest(http.MethodGet, "/api/v1/payment-requests?user_id="+rayUserId+"&role=sent", nil))
+	assert.Equal(t, http.StatusOK, resp.Code)
+	var sent SearchPaymentRequestResponse
+	assert.NoError(t, json.Unmarshal(resp.Body.Bytes(), &sent))
+	assert.Len(t, sent.PaymentRequests, 1)
+}
+
+func TestPaymentRequestDeclinedAndExpired(t *testing.T) {
+	db, _, cleanup, err := setupTestDB()
+	if err != nil {
+		t.Fatalf("failed to set up test DB: %v", err)
+	}
+	defer cleanup()
+
+	declined := createPaymentRequest(t, "10")
+	resp := postJSON("/api/v1/payment-requests/"+declined.PaymentRequestId+"/decline", map[string]string{"payer_user_id": johnUserId})
+	assert.Equal(t, http.StatusOK, resp.Code)
+
+	expired := createPaymentRequest(t, "20")
+	db.Model(&payment.PaymentRequest{}).Where("id = ?", expired.PaymentRequestId).Update("expires_at", time.Now().Add(-time.Minute))
+	assert.NoError(t, service.paymentRequests.Expire(context.Background()))
+	resp = postJSON("/api/v1/payment-requests/"+expired.PaymentRequestId+"/approve", map[string]string{"payer_user_id": johnUserId})
+	assert.Equal(t, http.StatusConflict, resp.Code)
+
+	resp = httptest.NewRecorder()
+	ProvideRoutes(service).ServeHTTP(resp, httptest.NewRequest(http.MethodGet, "/api/v1/payment-requests?user_id="+johnUserId+"&status=PENDING", nil))
+	var pending SearchPaymentRequestResponse
+	assert.NoError(t, json.Unmarshal(resp.Body.Bytes(), &pending))
+	assert.Empty(t, pending.PaymentRequests)
+
+	resp = httptest.NewRecorder()
+	ProvideRoutes(service).ServeHTTP(resp, httptest.NewRequest(http.MethodGet, "/api/v1/notifications?user_id="+rayUserId, nil))
+	var notifications SearchNotificationResponse
+	assert.NoError(t, json.Unmarshal(resp.Body.Bytes(), &notifications))
+	kinds := []string{}
+	for _, n := range notifications.Notifications {
+		kinds = append(kinds, n.Kind)
+	}
+	assert.Equal(t, []string{"PAYMENT_REQUEST_DECLINED", "PAYMENT_REQUEST_EXPIRED"}, kinds)
+}
@@ -0,0 +1,252 @@
+package service
+
+import (
+	"errors"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/raychongtk/wallet/audit"
+	"github.com/raychongtk/wallet/domain"
+	payoutbatch "github.com/raychongtk/wallet/model/payout"
+	"github.com/raychongtk/wallet/payout"
+	"github.com/raychongtk/wallet/problem"
+	"github.com/raychongtk/wallet/tracing"
+	"github.com/raychongtk/wallet/util"
+	"go.uber.org/zap"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	defaultPayoutLineLimit = 100
+	maxPayoutLineLimit     = 1000
+)
+
+// SubmitPayout accepts a batch as JSON, or as CSV with the funding user and the mode in the query, and answers 202 once
+// it is validated and stored. Progress is polled with GetPayout.
+func (s *Service) SubmitPayout(ctx *gin.Context) {
+	var req SubmitPayoutRequest
+	if strings.HasPrefix(ctx.ContentType(), "text/csv") {
+		inputs, err := payout.ParseCSV(ctx.Request.Body)
+		if err != nil {
+			problem.Respond(ctx, err)
+			return
+		}
+		req.FundingUserId, req.Mode = ctx.Query("funding_user_id"), ctx.Query("mode")
+		for _, input := range inputs {
+			req.Lines = append(req.Lines, PayoutLineRequest{RecipientUserId: input.RecipientUserID, Amount: input.Amount, Reference: input.Reference})
+		}
+	} else if err := ctx.ShouldBindJSON(&req); err != nil {
+		util.Error("Invalid params", zap.Error(err))
+		problem.Respond(ctx, domain.ErrInvalidParameters.Wrap(err))
+		return
+	}
+	fundingUserId, err := uuid.Parse(req.FundingUserId)
+	if err != nil {
+		util.Error("Invalid user", zap.Error(err))
+		problem.Respond(ctx, domain.ErrInvalidAccount.Wrap(err))
+		return
+	}
+	audit.Actor(ctx, audit.ActorUser, fundingUserId.String())
+	audit.Target(ctx, audit.TargetUser, fundingUserId.String())
+
+	cmd := payout.SubmitCommand{
+		FundingUserID: fundingUserId,
+		Mode:          strings.ToUpper(req.Mode),
+		RequestID:     ctx.GetHeader(tracing.RequestIDHeader),
+	}
+	for _, line := range req.Lines {
+		cmd.Lines = append(cmd.Lines, payout.Input{RecipientUserID: line.RecipientUserId, Amount: line.Amount, Reference: line.Reference})
+	}
+	batch, _, err := s.payouts.Submit(ctx.Request.Context(), cmd)
+	if err != nil {
+		util.Error("Submit payout failed", zap.String("user_id", fundingUserId.String()), zap.Int("lines", len(cmd.Lines)), zap.Error(err))
+		var rejected *payout.RejectedError
+		if errors.As(err, &rejected) {
+			respondRejectedPayout(ctx, err, rejected)
+			return
+		}
+		problem.Respond(ctx, err)
+		return
+	}
+	audit.Target(ctx, audit.TargetPayout, batch.ID.String())
+	audit.Change(ctx, nil, gin.H{"line_count": batch.LineCount, "total_amount": batch.TotalAmount, "failed_count": batch.FailedCount})
+	ctx.JSON(http.StatusAccepted, newPayoutBatch(batch))
+}
+
+// GetPayout reports the progress of a batch, by batch id or by the request id it was submitted with
+func (s *Service) GetPayout(ctx *gin.Context) {
+	var batch *payoutbatch.Batch
+	var err error
+	if requestId := ctx.Query("request_id"); requestId != "" {
+		batch, err = s.payoutRepo.GetBatchByRequestID(requestId)
+	} else {
+		batchId, parseErr := uuid.Parse(ctx.Param("batch_id"))
+		if parseErr != nil {
+			problem.Respond(ctx, domain.ErrInvalidParameters.WithMessage("batch_id or request_id is required"))
+			return
+		}
+		batch, err = s.payoutRepo.GetBatch(batchId)
+	}
+	if err != nil {
+		util.Error("Get payout failed", zap.Error(err))
+		problem.Respond(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, newPayoutBatch(batch))
+}
+
+// GetPayoutLines pages through the results of a batch in line order, next_after continues the page
+func (s *Service) GetPayoutLines(ctx *gin.Context) {
+	batchId, err := uuid.Parse(ctx.Param("batch_id"))
+	if err != nil {
+		problem.Respond(ctx, domain.ErrInvalidParameters.Wrap(err))
+		return
+	}
+	after, limit := 0, defaultPayoutLineLimit
+	if value := ctx.Query("after"); value != "" {
+		if after, err = strconv.Atoi(value); err != nil || after < 0 {
+			problem.Respond(ctx, domain.ErrInvalidParameters.WithMessage("after must be a line number"))
+			return
+		}
+	}
+	if value := ctx.Query("limit"); value != "" {
+		if limit, err = strconv.Atoi(value); err != nil || limit < 1 || limit > maxPayoutLineLimit {
+			problem.Respond(ctx, domain.ErrInvalidParameters.WithMessage("limit must be between 1 and %d", maxPayoutLineLimit))
+			return
+		}
+	}
+	if _, err := s.payoutRepo.GetBatch(batchId); err != nil {
+		problem.Respond(ctx, err)
+		return
+	}
+	lines, err := s.payoutRepo.SearchLines(batchId, strings.ToUpper(ctx.Query("status")), after, limit)
+	if err != nil {
+		util.Error("Search payout lines failed", zap.Error(err))
+		problem.Respond(ctx, err)
+		return
+	}
+
+	response := SearchPayoutLineResponse{Lines: []PayoutLine{}}
+	for _, line := range lines {
+		payoutLine := PayoutLine{
+			LineNo:          line.LineNo,
+			RecipientUserId: line.RecipientUserID.String(),
+			Amount:          util.DisplayAmount(line.Amount),
+			Reference:       line.Reference,
+			Status:          line.LineStatus,
+			ErrorCode:       line.ErrorCode,
+		}
+		if line.GroupID != nil {
+			payoutLine.GroupId = line.GroupID.String()
+		}
+		response.Lines = append(response.Lines, payoutLine)
+	}
+	if len(lines) == limit {
+		response.NextAfter = lines[len(lines)-1].LineNo
+	}
+	ctx.JSON(http.StatusOK, &response)
+}
+
+// respondRejectedPayout sends the error envelope with every line that made the batch invalid
+func respondRejectedPayout(ctx *gin.Context, err error, rejected *payout.RejectedError) {
+	response := RejectedPayoutResponse{Details: *problem.New(ctx, err)}
+	for _, line := range rejected.Lines {
+		response.Lines = append(response.Lines, PayoutLineError{
+			Line:      line.Line,
+			Reference: line.Reference,
+			ErrorCode: line.Code,
+			Message:   line.Message,
+		})
+	}
+	ctx.AbortWithStatusJSON(response.Status, &response)
+}
+
+func newPayoutBatch(batch *payoutbatch.Batch) *PayoutBatch {
+	response := &PayoutBatch{
+		BatchId:        batch.ID.String(),
+		RequestId:      batch.RequestID,
+		FundingUserId:  batch.FundingUserID.String(),
+		Mode:           batch.Mode,
+		Status:         batch.BatchStatus,
+		LineCount:      batch.LineCount,
+		PaidCount:      batch.PaidCount,
+		FailedCount:    batch.FailedCount,
+		PendingCount:   batch.LineCount - batch.PaidCount - batch.FailedCount,
+		TotalAmount:    util.DisplayAmount(batch.TotalAmount),
+		ReservedAmount: util.DisplayAmount(batch.ReservedAmount),
+		PaidAmount:     util.DisplayAmount(batch.PaidAmount),
+		ErrorCode:      batch.ErrorCode,
+		CreatedAt:      batch.CreatedAt.Format(time.RFC3339),
+	}
+	if batch.ReserveGroupID != nil {
+		response.ReserveGroupId = batch.ReserveGroupID.String()
+	}
+	if batch.RefundGroupID != nil {
+		response.RefundGroupId = batch.RefundGroupID.String()
+	}
+	if batch.CompletedAt != nil {
+		response.CompletedAt = batch.CompletedAt.Format(time.RFC3339)
+	}
+	return response
+}
+
+type SubmitPayoutRequest struct {
+	FundingUserId string              `json:"funding_user_id" binding:"required"`
+	Mode          string              `json:"mode" binding:"required"`
+	Lines         []PayoutLineRequest `json:"lines" binding:"required"`
+}
+
+type PayoutLineRequest struct {
+	RecipientUserId string `json:"recipient_user_id"`
+	Amount          string `json:"amount"`
+	Reference       string `json:"reference"`
+}
+
+type PayoutBatch struct {
+	BatchId        string `json:"batch_id" binding:"required"`
+	RequestId      string `json:"request_id" binding:"required"`
+	FundingUserId  string `json:"funding_user_id" binding:"required"`
+	Mode           string `json:"mode" binding:"required"`
+	Status         string `json:"status" binding:"required"`
+	LineCount      int    `json:"line_count" binding:"required"`
+	PaidCount      int    `json:"paid_count" binding:"required"`
+	FailedCount    int    `json:"failed_count" binding:"required"`
+	PendingCount   int    `json:"pending_count" binding:"required"`
+	TotalAmount    string `json:"total_amount" binding:"required"`
+	ReservedAmount string `json:"reserved_amount" binding:"required"`
+	PaidAmount     string `json:"paid_amount" binding:"required"`
+	ReserveGroupId string `json:"reserve_group_id,omitempty"`
+	RefundGroupId  string `json:"refund_group_id,omitempty"`
+	ErrorCode      string `json:"error_code,omitempty"`
+	CreatedAt      string `json:"created_at" binding:"required"`
+	CompletedAt    string `json:"completed_at,omitempty"`
+}
+
+type SearchPayoutLineResponse struct {
+	Lines     []PayoutLine `json:"lines"`
+	NextAfter int          `json:"next_after,omitempty"`
+}
+
+type PayoutLine struct {
+	LineNo          int    `json:"line_no" binding:"required"`
+	RecipientUserId string `json:"recipient_user_id" binding:"required"`
+	Amount          string `json:"amount" binding:"required"`
+	Reference       string `json:"reference" binding:"required"`
+	Status          string `json:"status" binding:"required"`
+	GroupId         string `json:"group_id,omitempty"`
+	ErrorCode       string `json:"error_code,omitempty"`
+}
+
+type RejectedPayoutResponse struct {
+	problem.Details
+	Lines []PayoutLineError `json:"lines"`
+}
+
+type PayoutLineError struct {
+	Line      int    `json:"line" binding:"required"`
+	Reference string `json:"reference"`
+	ErrorCode string `json:"error_code" binding:"required"`
+	Message   string `json:"message" binding:"required"`
+}
@@ -0,0 +1,102 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestPayoutAPI(t *testing.T) {
+	db, _, cleanup, err := setupTestDB()
+	if err != nil {
+		t.Fatalf("failed to set up test DB: %v", err)
+	}
+	defer cleanup()
+
+	router := ProvideRoutes(service)
+
+	deposit, _ := json.Marshal(map[string]string{"user_id": "2d988f4a-a037-4ce9-a350-f13445793e88", "balance": "100"})
+	depositReq, _ := http.NewRequest(http.MethodPost, "/api/v1/wallet/deposit", bytes.NewBuffer(deposit))
+	depositReq.Header.Set("Content-Type", "application/json")
+	depositReq.Header.Set("X-Request-ID", uuid.New().String())
+	router.ServeHTTP(httptest.NewRecorder(), depositReq)
+
+	file := "recipient_user_id,amount,reference\n" +
+		"c6e97817-0254-43ad-8610-7ac9d3f7af92,30,payroll-1\n" +
+		"c6e97817-0254-43ad-8610-7ac9d3f7af92,20.5,payroll-2\n"
+	req, _ := http.NewRequest(http.MethodPost, "/api/v1/payouts?funding_user_id=2d988f4a-a037-4ce9-a350-f13445793e88&mode=all_or_nothing", strings.NewReader(file))
+	req.Header.Set("Content-Type", "text/csv")
+	req.Header.Set("X-Request-ID", uuid.New().String())
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusAccepted, resp.Code)
+	var batch PayoutBatch
+	assert.NoError(t, json.Unmarshal(resp.Body.Bytes(), &batch))
+	assert.Equal(t, "PENDING", batch.Status)
+	assert.Equal(t, 2, batch.PendingCount)
+	assert.Equal(t, "50.50", batch.TotalAmount)
+
+	assert.NoError(t, service.payouts.Run(context.Background()))
+
+	resp = httptest.NewRecorder()
+	router.ServeHTTP(resp, httptest.NewRequest(http.MethodGet, "/api/v1/payouts/"+batch.BatchId, nil))
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.NoError(t, json.Unmarshal(resp.Body.Bytes(), &batch))
+	assert.Equal(t, "COMPLETED", batch.Status)
+	assert.Equal(t, 2, batch.PaidCount)
+	assert.Equal(t, "50.50", batch.PaidAmount)
+
+	resp = httptest.NewRecorder()
+	router.ServeHTTP(resp, httptest.NewRequest(http.MethodGet, "/api/v1/payouts/"+batch.BatchId+"/lines", nil))
+	assert.Equal(t, http.StatusOK, resp.Code)
+	var lines SearchPayoutLineResponse
+	assert.NoError(t, json.Unmarshal(resp.Body.Bytes(), &lines))
+	assert.Len(t, lines.Lines, 2)
+	assert.Equal(t, "PAID", lines.Lines[0].Status)
+	assert.NotEmpty(t, lines.Lines[0].GroupId)
+
+	funderBalance, _ := service.balanceRepo.GetBalanceWithLock(db, uuid.MustParse("1cc535a5-bc57-4731-a64b-041b7ff41c30"), "COMMITTED")
+	assert.Equal(t, 4950, funderBalance.Balance)
+	recipientBalance, _ := service.balanceRepo.GetBalanceWithLock(db, uuid.MustParse("c7d90b83-e080-423a-ab1b-f48094d7533e"), "COMMITTED")
+	assert.Equal(t, 5050, recipientBalance.Balance)
+}
+
+func TestPayoutAPIRejectsInvalidLines(t *testing.T) {
+	_, _, cleanup, err := setupTestDB()
+	if err != nil {
+		t.Fatalf("failed to set up test DB: %v", err)
+	}
+	defer cleanup()
+
+	router := ProvideRoutes(service)
+
+	body, _ := json.Marshal(map[string]interface{}{
+		"funding_user_id": "2d988f4a-a037-4ce9-a350-f13445793e88",
+		"mode":            "BEST_EFFORT",
+		"lines": []map[string]string{
+			{"recipient_user_id": "c6e97817-0254-43ad-8610-7ac9d3f7af92", "amount": "1", "reference": "payroll-1"},
+			{"recipient_user_id": "not-a-user", "amount": "1", "reference": "payroll-2"},
+			{"recipient_user_id": "c6e97817-0254-43ad-8610-7ac9d3f7af92", "amount": "1", "reference": "payroll-1"},
+		},
+	})
+	req, _ := http.NewRequest(http.MethodPost, "/api/v1/payouts", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Request-ID", uuid.New().String())
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusBadRequest, resp.Code)
+	var response RejectedPayoutResponse
+	assert.NoError(t, json.Unmarshal(resp.Body.Bytes(), &response))
+	assert.Equal(t, "INVALID_PARAMETERS", response.ErrorCode)
+	assert.Len(t, response.Lines, 2)
+	assert.Equal(t, 2, response.Lines[0].Line)
+	assert.Equal(t, 3, response.Lines[1].Line)
+}
@@ -0,0 +1,232 @@
+package service
+
+import (
+	"context"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/raychongtk/wallet/audit"
+	"github.com/raychongtk/wallet/domain"
+	"github.com/raychongtk/wallet/ledger"
+	"github.com/raychongtk/wallet/model/wallet"
+	"github.com/raychongtk/wallet/problem"
+	"github.com/raychongtk/wallet/tracing"
+	"github.com/raychongtk/wallet/util"
+	"go.uber.org/zap"
+	"net/http"
+	"time"
+)
+
+func (s *Service) CreatePocket(ctx *gin.Context) {
+	var req CreatePocketRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		util.Error("Invalid params", zap.Error(err))
+		problem.Respond(ctx, domain.ErrInvalidParameters.Wrap(err))
+		return
+	}
+	userId, err := uuid.Parse(req.UserId)
+	if err != nil {
+		problem.Respond(ctx, domain.ErrInvalidAccount.Wrap(err))
+		return
+	}
+	audit.Actor(ctx, audit.ActorUser, userId.String())
+	goalAmount := 0
+	if req.GoalAmount != "" {
+		if goalAmount, err = util.ConvertToInt(req.GoalAmount); err != nil {
+			problem.Respond(ctx, domain.ErrInvalidParameters.Wrap(err))
+			return
+		}
+	}
+	var targetDate *time.Time
+	if req.TargetDate != "" {
+		date, err := time.Parse(time.DateOnly, req.TargetDate)
+		if err != nil {
+			problem.Respond(ctx, domain.ErrInvalidParameters.WithMessage("target date must be a date like 2026-12-31"))
+			return
+		}
+		targetDate = &date
+	}
+
+	pocket, err := s.ledger.CreatePocket(ctx.Request.Context(), ledger.CreatePocketCommand{
+		UserID:     userId,
+		Name:       req.Name,
+		GoalAmount: goalAmount,
+		TargetDate: targetDate,
+		Locked:     req.Locked,
+	})
+	if err != nil {
+		util.Error("Create pocket failed", zap.String("user_id", userId.String()), zap.Error(err))
+		problem.Respond(ctx, err)
+		return
+	}
+	response := newPocket(pocket, 0)
+	audit.Target(ctx, audit.TargetPocket, response.PocketId)
+	audit.Change(ctx, nil, response)
+	ctx.JSON(http.StatusCreated, response)
+}
+
+// GetPockets lists the pockets of a user in the order they were opened
+func (s *Service) GetPockets(ctx *gin.Context) {
+	userId, err := uuid.Parse(ctx.Query("user_id"))
+	if err != nil {
+		problem.Respond(ctx, domain.ErrInvalidAccount.Wrap(err))
+		return
+	}
+	pockets, _, err := s.pocketBalances(userId)
+	if err != nil {
+		util.Error("Search pockets failed", zap.Error(err))
+		problem.Respond(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, &SearchPocketResponse{Pockets: pockets})
+}
+
+func (s *Service) GetPocket(ctx *gin.Context) {
+	pocketId, err := uuid.Parse(ctx.Param("pocket_id"))
+	if err != nil {
+		problem.Respond(ctx, domain.ErrInvalidParameters.Wrap(err))
+		return
+	}
+	found, err := s.pocketRepo.GetPocket(pocketId)
+	if err != nil {
+		problem.Respond(ctx, err)
+		return
+	}
+	balance, err := s.balanceRepo.GetBalance(found.WalletID, "COMMITTED")
+	if err != nil {
+		util.Error("Get pocket balance failed", zap.Error(err))
+		problem.Respond(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, newPocket(found, balance.Balance))
+}
+
+// DepositToPocket moves money of the main wallet into the pocket
+func (s *Service) DepositToPocket(ctx *gin.Context) {
+	s.movePocket(ctx, s.ledger.MoveToPocket)
+}
+
+// WithdrawFromPocket moves money of the pocket back into the main wallet
+func (s *Service) WithdrawFromPocket(ctx *gin.Context) {
+	s.movePocket(ctx, s.ledger.MoveFromPocket)
+}
+
+// movePocket answers with the main wallet and pocket balances after the move
+func (s *Service) movePocket(ctx *gin.Context, move func(ctx context.Context, cmd ledger.PocketCommand) (*ledger.Result, error)) {
+	pocketId, err := uuid.Parse(ctx.Param("pocket_id"))
+	if err != nil {
+		problem.Respond(ctx, domain.ErrInvalidParameters.Wrap(err))
+		return
+	}
+	var req PocketMovementRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		util.Error("Invalid params", zap.Error(err))
+		problem.Respond(ctx, domain.ErrInvalidParameters.Wrap(err))
+		return
+	}
+	userId, err := uuid.Parse(req.UserId)
+	if err != nil {
+		problem.Respond(ctx, domain.ErrInvalidAccount.Wrap(err))
+		return
+	}
+	audit.Actor(ctx, audit.ActorUser, userId.String())
+	audit.Target(ctx, audit.TargetPocket, pocketId.String())
+	amount, err := util.ConvertToInt(req.Amount)
+	if err != nil {
+		problem.Respond(ctx, domain.ErrInvalidParameters.Wrap(err))
+		return
+	}
+
+	result, err := move(ctx.Request.Context(), ledger.PocketCommand{
+		UserID:    userId,
+		PocketID:  pocketId,
+		Amount:    amount,
+		RequestID: ctx.GetHeader(tracing.RequestIDHeader),
+	})
+	if err != nil {
+		util.Error("Move pocket failed", zap.String("pocket_id", pocketId.String()), zap.Error(err))
+		problem.Respond(ctx, err)
+		return
+	}
+	audit.Change(ctx, gin.H{"balances": result.Before}, gin.H{"balances": result.After, "group_id": result.GroupID})
+	found, err := s.pocketRepo.GetPocket(pocketId)
+	if err != nil {
+		util.Error("Get pocket failed", zap.String("pocket_id", pocketId.String()), zap.Error(err))
+		problem.Respond(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, &PocketMovementResponse{
+		GroupId: result.GroupID.String(),
+		Balance: util.DisplayAmount(result.After[result.WalletID]),
+		Pocket:  *newPocket(found, result.After[found.WalletID]),
+	})
+}
+
+// pocketBalances reads every pocket of a user with its balance and the sum of them
+func (s *Service) pocketBalances(userId uuid.UUID) ([]Pocket, int, error) {
+	pockets, err := s.pocketRepo.SearchPockets(userId)
+	if err != nil {
+		return nil, 0, err
+	}
+	response, saved := []Pocket{}, 0
+	for i := range pockets {
+		balance, err := s.balanceRepo.GetBalance(pockets[i].WalletID, "COMMITTED")
+		if err != nil {
+			return nil, 0, err
+		}
+		saved += balance.Balance
+		response = append(response, *newPocket(&pockets[i], balance.Balance))
+	}
+	return response, saved, nil
+}
+
+func newPocket(found *wallet.Pocket, balance int) *Pocket {
+	response := &Pocket{
+		PocketId:  found.ID.String(),
+		UserId:    found.UserID.String(),
+		Name:      found.Name,
+		Balance:   util.DisplayAmount(balance),
+		Locked:    found.LockedAt(time.Now()),
+		CreatedAt: found.CreatedAt.UTC().Format(time.RFC3339),
+	}
+	if found.GoalAmount > 0 {
+		response.GoalAmount = util.DisplayAmount(found.GoalAmount)
+	}
+	if found.TargetDate != nil {
+		response.TargetDate = found.TargetDate.Format(time.DateOnly)
+	}
+	return response
+}
+
+type CreatePocketRequest struct {
+	UserId     string `json:"user_id" binding:"required"`
+	Name       string `json:"name" binding:"required"`
+	GoalAmount string `json:"goal_amount"`
+	TargetDate string `json:"target_date"`
+	Locked     bool   `json:"locked"`
+}
+
+type PocketMovementRequest struct {
+	UserId string `json:"user_id" binding:"required"`
+	Amount string `json:"amount" binding:"required"`
+}
+
+type Pocket struct {
+	PocketId   string `json:"pocket_id" binding:"required"`
+	UserId     string `json:"user_id" binding:"required"`
+	Name       string `json:"name" binding:"required"`
+	Balance    string `json:"balance" binding:"required"`
+	GoalAmount string `json:"goal_amount,omitempty"`
+	TargetDate string `json:"target_date,omitempty"`
+	Locked     bool   `json:"locked"`
+	CreatedAt  string `json:"created_at" binding:"required"`
+}
+
+type PocketMovementResponse struct {
+	GroupId string `json:"group_id" binding:"required"`
+	Balance string `json:"balance" binding:"required"`
+	Pocket  Pocket `json:"pocket" binding:"required"`
+}
+
+type SearchPocketResponse struct {
+	Pockets []Pocket `json:"pockets"`
+}
@@ -0,0 +1,60 @@
+package service
+
+import (
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func createPocket(t *testing.T, body map[string]interface{}) Pocket {
+	resp := postJSON("/api/v1/pockets", body)
+	assert.Equal(t, http.StatusCreated, resp.Code)
+	var pocket Pocket
+	assert.NoError(t, json.Unmarshal(resp.Body.Bytes(), &pocket))
+	return pocket
+}
+
+func TestPocketsAreSavedInsideTheAccount(t *testing.T) {
+	_, _, cleanup, err := setupTestDB()
+	if err != nil {
+		t.Fatalf("failed to set up test DB: %v", err)
+	}
+	defer cleanup()
+
+	deposit := postJSON("/api/v1/wallet/deposit", map[string]string{"user_id": johnUserId, "balance": "100"})
+	assert.Equal(t, http.StatusOK, deposit.Code)
+	targetDate := time.Now().AddDate(1, 0, 0).Format(time.DateOnly)
+	holiday := createPocket(t, map[string]interface{}{"user_id": johnUserId, "name": "Holiday", "goal_amount": "500", "target_date": targetDate, "locked": true})
+	assert.True(t, holiday.Locked)
+	assert.Equal(t, "500.00", holiday.GoalAmount)
+	emergency := createPocket(t, map[string]interface{}{"user_id": johnUserId, "name": "Emergency"})
+	resp := postJSON("/api/v1/pockets", map[string]interface{}{"user_id": johnUserId, "name": "holiday"})
+	assert.Equal(t, http.StatusBadRequest, resp.Code)
+
+	resp = postJSON("/api/v1/pockets/"+holiday.PocketId+"/deposit", map[string]string{"user_id": johnUserId, "amount": "30"})
+	assert.Equal(t, http.StatusOK, resp.Code)
+	resp = postJSON("/api/v1/pockets/"+emergency.PocketId+"/deposit", map[string]string{"user_id": johnUserId, "amount": "20"})
+	assert.Equal(t, http.StatusOK, resp.Code)
+	var moved PocketMovementResponse
+	assert.NoError(t, json.Unmarshal(resp.Body.Bytes(), &moved))
+	assert.Equal(t, "50.00", moved.Balance)
+	assert.Equal(t, "20.00", moved.Pocket.Balance)
+
+	// a locked pocket keeps its money, another user does not see the pocket at all
+	resp = postJSON("/api/v1/pockets/"+holiday.PocketId+"/withdrawal", map[string]string{"user_id": johnUserId, "amount": "10"})
+	assert.Equal(t, http.StatusConflict, resp.Code)
+	resp = postJSON("/api/v1/pockets/"+emergency.PocketId+"/deposit", map[string]string{"user_id": rayUserId, "amount": "1"})
+	assert.Equal(t, http.StatusNotFound, resp.Code)
+	resp = postJSON("/api/v1/pockets/"+emergency.PocketId+"/withdrawal", map[string]string{"user_id": johnUserId, "amount": "5"})
+	assert.Equal(t, http.StatusOK, resp.Code)
+
+	balance := johnBalance(t)
+	assert.Equal(t, "55.00", balance.Balance)
+	assert.Equal(t, "100.00", balance.Total)
+	assert.Len(t, balance.Pockets, 2)
+	assert.Equal(t, "Holiday", balance.Pockets[0].Name)
+	assert.Equal(t, "30.00", balance.Pockets[0].Balance)
+	assert.Equal(t, "15.00", balance.Pockets[1].Balance)
+}
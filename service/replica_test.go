@@ -0,0 +1,35 @@
+package service
+
+import (
+	"context"
+	"github.com/raychongtk/wallet/config"
+	"github.com/raychongtk/wallet/datastore"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+	"testing"
+	"time"
+)
+
+func TestReplicaRouterWithReadYourWrites(t *testing.T) {
+	db, redisClient, cleanup, err := setupTestDB()
+	if err != nil {
+		t.Fatalf("failed to set up test DB: %v", err)
+	}
+	defer cleanup()
+
+	replicaDB := db.Session(&gorm.Session{})
+	router := datastore.NewReplicaRouter(db, []*gorm.DB{replicaDB}, redisClient, config.ReplicaConfig{
+		Stickiness: time.Minute,
+		MaxLag:     10 * time.Second,
+	})
+
+	// lag is unknown until the first check, so reads stay on the primary
+	assert.Same(t, db, router.Reader("1cc535a5-bc57-4731-a64b-041b7ff41c30"))
+
+	router.CheckLag(context.Background())
+	assert.Same(t, replicaDB, router.Reader("1cc535a5-bc57-4731-a64b-041b7ff41c30"))
+
+	router.MarkWrite("1cc535a5-bc57-4731-a64b-041b7ff41c30")
+	assert.Same(t, db, router.Reader("1cc535a5-bc57-4731-a64b-041b7ff41c30"))
+	assert.Same(t, replicaDB, router.Reader("c7d90b83-e080-423a-ab1b-f48094d7533e"))
+}
@@ -0,0 +1,235 @@
+package service
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/raychongtk/wallet/audit"
+	"github.com/raychongtk/wallet/domain"
+	"github.com/raychongtk/wallet/model/schedule"
+	"github.com/raychongtk/wallet/problem"
+	"github.com/raychongtk/wallet/scheduler"
+	"github.com/raychongtk/wallet/tracing"
+	"github.com/raychongtk/wallet/util"
+	"go.uber.org/zap"
+	"net/http"
+	"time"
+)
+
+const maxScheduledTransferRuns = 100
+
+func (s *Service) CreateScheduledTransfer(ctx *gin.Context) {
+	var req CreateScheduledTransferRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		util.Error("Invalid params", zap.Error(err))
+		problem.Respond(ctx, domain.ErrInvalidParameters.Wrap(err))
+		return
+	}
+	payerId, err := uuid.Parse(req.PayerUserId)
+	if err != nil {
+		problem.Respond(ctx, domain.ErrInvalidAccount.Wrap(err))
+		return
+	}
+	payeeId, err := uuid.Parse(req.PayeeUserId)
+	if err != nil {
+		problem.Respond(ctx, domain.ErrInvalidAccount.Wrap(err))
+		return
+	}
+	audit.Actor(ctx, audit.ActorUser, payerId.String())
+	audit.Target(ctx, audit.TargetUser, payerId.String())
+	amount, err := util.ConvertToInt(req.Amount)
+	if err != nil {
+		problem.Respond(ctx, domain.ErrInvalidParameters.Wrap(err))
+		return
+	}
+	startAt, err := time.Parse(time.RFC3339, req.StartAt)
+	if err != nil {
+		problem.Respond(ctx, domain.ErrInvalidParameters.WithMessage("start_at must be an RFC 3339 time"))
+		return
+	}
+	var endAt *time.Time
+	if req.EndAt != "" {
+		end, err := time.Parse(time.RFC3339, req.EndAt)
+		if err != nil {
+			problem.Respond(ctx, domain.ErrInvalidParameters.WithMessage("end_at must be an RFC 3339 time"))
+			return
+		}
+		endAt = &end
+	}
+
+	scheduledTransfer, err := s.scheduler.Create(ctx.Request.Context(), scheduler.CreateCommand{
+		PayerUserID: payerId,
+		PayeeUserID: payeeId,
+		Amount:      amount,
+		Rule:        req.Rule,
+		TimeZone:    req.TimeZone,
+		StartAt:     startAt,
+		EndAt:       endAt,
+		MaxRuns:     req.MaxRuns,
+		RequestID:   ctx.GetHeader(tracing.RequestIDHeader),
+	})
+	if err != nil {
+		util.Error("Create scheduled transfer failed", zap.String("user_id", payerId.String()), zap.Error(err))
+		problem.Respond(ctx, err)
+		return
+	}
+	response := newScheduledTransfer(scheduledTransfer)
+	audit.Target(ctx, audit.TargetSchedule, response.ScheduleId)
+	audit.Change(ctx, nil, response)
+	ctx.JSON(http.StatusCreated, response)
+}
+
+// GetScheduledTransfers lists the standing orders a user pays
+func (s *Service) GetScheduledTransfers(ctx *gin.Context) {
+	userId, err := uuid.Parse(ctx.Query("user_id"))
+	if err != nil {
+		problem.Respond(ctx, domain.ErrInvalidAccount.Wrap(err))
+		return
+	}
+	schedules, err := s.scheduleRepo.SearchSchedules(userId)
+	if err != nil {
+		util.Error("Search scheduled transfers failed", zap.Error(err))
+		problem.Respond(ctx, err)
+		return
+	}
+	response := SearchScheduledTransferResponse{ScheduledTransfers: []ScheduledTransfer{}}
+	for i := range schedules {
+		response.ScheduledTransfers = append(response.ScheduledTransfers, *newScheduledTransfer(&schedules[i]))
+	}
+	ctx.JSON(http.StatusOK, &response)
+}
+
+func (s *Service) GetScheduledTransfer(ctx *gin.Context) {
+	scheduleId, err := uuid.Parse(ctx.Param("schedule_id"))
+	if err != nil {
+		problem.Respond(ctx, domain.ErrInvalidParameters.Wrap(err))
+		return
+	}
+	scheduledTransfer, err := s.scheduleRepo.GetSchedule(scheduleId)
+	if err != nil {
+		problem.Respond(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, newScheduledTransfer(scheduledTransfer))
+}
+
+func (s *Service) CancelScheduledTransfer(ctx *gin.Context) {
+	scheduleId, err := uuid.Parse(ctx.Param("schedule_id"))
+	if err != nil {
+		problem.Respond(ctx, domain.ErrInvalidParameters.Wrap(err))
+		return
+	}
+	audit.Target(ctx, audit.TargetSchedule, scheduleId.String())
+	scheduledTransfer, err := s.scheduler.Cancel(ctx.Request.Context(), scheduleId)
+	if err != nil {
+		util.Error("Cancel scheduled transfer failed", zap.String("schedule_id", scheduleId.String()), zap.Error(err))
+		problem.Respond(ctx, err)
+		return
+	}
+	audit.Actor(ctx, audit.ActorUser, scheduledTransfer.PayerUserID.String())
+	audit.Change(ctx, gin.H{"status": schedule.StatusActive}, gin.H{"status": scheduledTransfer.Status})
+	ctx.JSON(http.StatusOK, newScheduledTransfer(scheduledTransfer))
+}
+
+// GetScheduledTransferRuns lists the latest occurrences of a standing order, each with its attempts and the movement
+// group of its transfer
+func (s *Service) GetScheduledTransferRuns(ctx *gin.Context) {
+	scheduleId, err := uuid.Parse(ctx.Param("schedule_id"))
+	if err != nil {
+		problem.Respond(ctx, domain.ErrInvalidParameters.Wrap(err))
+		return
+	}
+	if _, err := s.scheduleRepo.GetSchedule(scheduleId); err != nil {
+		problem.Respond(ctx, err)
+		return
+	}
+	runs, err := s.scheduleRepo.SearchRuns(scheduleId, maxScheduledTransferRuns)
+	if err != nil {
+		util.Error("Search scheduled transfer runs failed", zap.Error(err))
+		problem.Respond(ctx, err)
+		return
+	}
+	response := SearchScheduledTransferRunResponse{Runs: []ScheduledTransferRun{}}
+	for _, run := range runs {
+		scheduledTransferRun := ScheduledTransferRun{
+			RunId:         run.ID.String(),
+			ScheduledFor:  run.ScheduledFor.UTC().Format(time.RFC3339),
+			Status:        run.RunStatus,
+			Attempts:      run.Attempts,
+			NextAttemptAt: run.NextAttemptAt.UTC().Format(time.RFC3339),
+			RequestId:     run.RequestID(),
+			ErrorCode:     run.ErrorCode,
+		}
+		if run.GroupID != nil {
+			scheduledTransferRun.GroupId = run.GroupID.String()
+		}
+		response.Runs = append(response.Runs, scheduledTransferRun)
+	}
+	ctx.JSON(http.StatusOK, &response)
+}
+
+func newScheduledTransfer(scheduledTransfer *schedule.ScheduledTransfer) *ScheduledTransfer {
+	response := &ScheduledTransfer{
+		ScheduleId:  scheduledTransfer.ID.String(),
+		PayerUserId: scheduledTransfer.PayerUserID.String(),
+		PayeeUserId: scheduledTransfer.PayeeUserID.String(),
+		Amount:      util.DisplayAmount(scheduledTransfer.Amount),
+		Rule:        scheduledTransfer.Rule,
+		TimeZone:    scheduledTransfer.TimeZone,
+		StartAt:     scheduledTransfer.StartAt.UTC().Format(time.RFC3339),
+		MaxRuns:     scheduledTransfer.MaxRuns,
+		RunCount:    scheduledTransfer.RunCount,
+		Status:      scheduledTransfer.Status,
+	}
+	if scheduledTransfer.EndAt != nil {
+		response.EndAt = scheduledTransfer.EndAt.UTC().Format(time.RFC3339)
+	}
+	if scheduledTransfer.NextRunAt != nil {
+		response.NextRunAt = scheduledTransfer.NextRunAt.UTC().Format(time.RFC3339)
+	}
+	return response
+}
+
+type CreateScheduledTransferRequest struct {
+	PayerUserId string `json:"payer_user_id" binding:"required"`
+	PayeeUserId string `json:"payee_user_id" binding:"required"`
+	Amount      string `json:"amount" binding:"required"`
+	Rule        string `json:"rule" binding:"required"`
+	TimeZone    string `json:"time_zone"`
+	StartAt     string `json:"start_at" binding:"required"`
+	EndAt       string `json:"end_at"`
+	MaxRuns     int    `json:"max_runs"`
+}
+
+type ScheduledTransfer struct {
+	ScheduleId  string `json:"schedule_id" binding:"required"`
+	PayerUserId string `json:"payer_user_id" binding:"required"`
+	PayeeUserId string `json:"payee_user_id" binding:"required"`
+	Amount      string `json:"amount" binding:"required"`
+	Rule        string `json:"rule" binding:"required"`
+	TimeZone    string `json:"time_zone" binding:"required"`
+	StartAt     string `json:"start_at" binding:"required"`
+	EndAt       string `json:"end_at,omitempty"`
+	MaxRuns     int    `json:"max_runs"`
+	RunCount    int    `json:"run_count"`
+	NextRunAt   string `json:"next_run_at,omitempty"`
+	Status      string `json:"status" binding:"required"`
+}
+
+type SearchScheduledTransferResponse struct {
+	ScheduledTransfers []ScheduledTransfer `json:"scheduled_transfers"`
+}
+
+type SearchScheduledTransferRunResponse struct {
+	Runs []ScheduledTransferRun `json:"runs"`
+}
+
+type ScheduledTransferRun struct {
+	RunId         string `json:"run_id" binding:"required"`
+	ScheduledFor  string `json:"scheduled_for" binding:"required"`
+	Status        string `json:"status" binding:"required"`
+	Attempts      int    `json:"attempts"`
+	NextAttemptAt string `json:"next_attempt_at" binding:"required"`
+	RequestId     string `json:"request_id" binding:"required"`
+	GroupId       string `json:"group_id,omitempty"`
+	ErrorCode     string `json:"error_code,omitempty"`
+}
@@ -0,0 +1,117 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"github.com/google/uuid"
+	"github.com/raychongtk/wallet/model/schedule"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func createScheduledTransfer(t *testing.T, db *gorm.DB, amount string) ScheduledTransfer {
+	router := ProvideRoutes(service)
+	body, _ := json.Marshal(map[string]interface{}{
+		"payer_user_id": "2d988f4a-a037-4ce9-a350-f13445793e88",
+		"payee_user_id": "c6e97817-0254-43ad-8610-7ac9d3f7af92",
+		"amount":        amount,
+		"rule":          "monthly",
+		"time_zone":     "Asia/Hong_Kong",
+		"start_at":      time.Now().Format(time.RFC3339),
+		"max_runs":      12,
+	})
+	req, _ := http.NewRequest(http.MethodPost, "/api/v1/scheduled-transfers", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Request-ID", uuid.New().String())
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusCreated, resp.Code)
+	var scheduledTransfer ScheduledTransfer
+	assert.NoError(t, json.Unmarshal(resp.Body.Bytes(), &scheduledTransfer))
+	assert.Equal(t, "ACTIVE", scheduledTransfer.Status)
+
+	// the start has just passed, so the first occurrence is next month, make the start itself due
+	db.Model(&schedule.ScheduledTransfer{}).Where("id = ?", scheduledTransfer.ScheduleId).Update("next_run_at", gorm.Expr("start_at"))
+	return scheduledTransfer
+}
+
+func scheduledTransferRuns(t *testing.T, scheduleId string) []ScheduledTransferRun {
+	resp := httptest.NewRecorder()
+	ProvideRoutes(service).ServeHTTP(resp, httptest.NewRequest(http.MethodGet, "/api/v1/scheduled-transfers/"+scheduleId+"/runs", nil))
+	assert.Equal(t, http.StatusOK, resp.Code)
+	var runs SearchScheduledTransferRunResponse
+	assert.NoError(t, json.Unmarshal(resp.Body.Bytes(), &runs))
+	return runs.Runs
+}
+
+func TestScheduledTransferAPI(t *testing.T) {
+	db, _, cleanup, err := setupTestDB()
+	if err != nil {
+		t.Fatalf("failed to set up test DB: %v", err)
+	}
+	defer cleanup()
+
+	router := ProvideRoutes(service)
+	deposit, _ := json.Marshal(map[string]string{"user_id": "2d988f4a-a037-4ce9-a350-f13445793e88", "balance": "100"})
+	depositReq, _ := http.NewRequest(http.MethodPost, "/api/v1/wallet/deposit", bytes.NewBuffer(deposit))
+	depositReq.Header.Set("Content-Type", "application/json")
+	depositReq.Header.Set("X-Request-ID", uuid.New().String())
+	router.ServeHTTP(httptest.NewRecorder(), depositReq)
+
+	scheduledTransfer := createScheduledTransfer(t, db, "30")
+	assert.NoError(t, service.scheduler.Run(context.Background()))
+	// a second instance finds nothing left to do
+	assert.NoError(t, service.scheduler.Run(context.Background()))
+
+	runs := scheduledTransferRuns(t, scheduledTransfer.ScheduleId)
+	assert.Len(t, runs, 1)
+	assert.Equal(t, "SUCCEEDED", runs[0].Status)
+	assert.NotEmpty(t, runs[0].GroupId)
+	payeeBalance, _ := service.balanceRepo.GetBalanceWithLock(db, uuid.MustParse("c7d90b83-e080-423a-ab1b-f48094d7533e"), "COMMITTED")
+	assert.Equal(t, 3000, payeeBalance.Balance)
+
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, httptest.NewRequest(http.MethodDelete, "/api/v1/scheduled-transfers/"+scheduledTransfer.ScheduleId, nil))
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.NoError(t, json.Unmarshal(resp.Body.Bytes(), &scheduledTransfer))
+	assert.Equal(t, "CANCELLED", scheduledTransfer.Status)
+	assert.Equal(t, 1, scheduledTransfer.RunCount)
+	assert.Empty(t, scheduledTransfer.NextRunAt)
+}
+
+func TestScheduledTransferRetriesThenNotifies(t *testing.T) {
+	db, _, cleanup, err := setupTestDB()
+	if err != nil {
+		t.Fatalf("failed to set up test DB: %v", err)
+	}
+	defer cleanup()
+
+	scheduledTransfer := createScheduledTransfer(t, db, "30")
+	for attempt := 1; attempt <= service.config.Scheduler.MaxAttempts; attempt++ {
+		assert.NoError(t, service.scheduler.Run(context.Background()))
+		runs := scheduledTransferRuns(t, scheduledTransfer.ScheduleId)
+		assert.Len(t, runs, 1)
+		assert.Equal(t, attempt, runs[0].Attempts)
+		assert.Equal(t, "INSUFFICIENT_FUNDS", runs[0].ErrorCode)
+		if attempt < service.config.Scheduler.MaxAttempts {
+			assert.Equal(t, "RETRYING", runs[0].Status)
+			db.Model(&schedule.Run{}).Where("id = ?", runs[0].RunId).Update("next_attempt_at", time.Now().Add(-time.Second))
+		} else {
+			assert.Equal(t, "FAILED", runs[0].Status)
+		}
+	}
+
+	resp := httptest.NewRecorder()
+	ProvideRoutes(service).ServeHTTP(resp, httptest.NewRequest(http.MethodGet, "/api/v1/notifications?user_id=2d988f4a-a037-4ce9-a350-f13445793e88", nil))
+	assert.Equal(t, http.StatusOK, resp.Code)
+	var notifications SearchNotificationResponse
+	assert.NoError(t, json.Unmarshal(resp.Body.Bytes(), &notifications))
+	assert.Len(t, notifications.Notifications, 1)
+	assert.Equal(t, "SCHEDULED_TRANSFER_FAILED", notifications.Notifications[0].Kind)
+}
@@ -4,7 +4,24 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/go-redis/redis/v8"
 	"github.com/google/wire"
+	"github.com/raychongtk/wallet/archive"
+	"github.com/raychongtk/wallet/audit"
+	"github.com/raychongtk/wallet/business"
+	"github.com/raychongtk/wallet/config"
+	"github.com/raychongtk/wallet/escrow"
+	"github.com/raychongtk/wallet/health"
+	"github.com/raychongtk/wallet/hold"
+	"github.com/raychongtk/wallet/integrity"
+	"github.com/raychongtk/wallet/interest"
+	"github.com/raychongtk/wallet/joint"
+	"github.com/raychongtk/wallet/ledger"
+	"github.com/raychongtk/wallet/metrics"
+	"github.com/raychongtk/wallet/openapi"
+	"github.com/raychongtk/wallet/paymentrequest"
+	"github.com/raychongtk/wallet/payout"
 	"github.com/raychongtk/wallet/repository"
+	"github.com/raychongtk/wallet/scheduler"
+	"github.com/raychongtk/wallet/tracing"
 	"gorm.io/gorm"
 )
 
@@ -13,15 +30,40 @@ var (
 )
 
 type Service struct {
-	userRepo           repository.UserRepository
-	movementRepo       repository.MovementRepository
-	accountRepo        repository.AccountRepository
-	walletRepo         repository.WalletRepository
-	transactionRepo    repository.TransactionRepository
-	balanceRepo        repository.BalanceRepository
-	paymentHistoryRepo repository.PaymentHistoryRepository
-	db                 gorm.DB
-	memoryStore        redis.Client
+	userRepo             repository.UserRepository
+	movementRepo         repository.MovementRepository
+	accountRepo          repository.AccountRepository
+	walletRepo           repository.WalletRepository
+	transactionRepo      repository.TransactionRepository
+	balanceRepo          repository.BalanceRepository
+	paymentHistoryRepo   repository.PaymentHistoryRepository
+	payoutRepo           repository.PayoutRepository
+	scheduleRepo         repository.ScheduledTransferRepository
+	notificationRepo     repository.NotificationRepository
+	paymentRequestRepo   repository.PaymentRequestRepository
+	holdRepo             repository.HoldRepository
+	escrowRepo           repository.EscrowRepository
+	interestRepo         repository.InterestRepository
+	pocketRepo           repository.PocketRepository
+	sharedTransferRepo   repository.SharedTransferRepository
+	businessTransferRepo repository.BusinessTransferRepository
+	db                   gorm.DB
+	memoryStore          redis.Client
+	archiveReader        *archive.Reader
+	config               *config.Config
+	ledger               *ledger.Ledger
+	auditor              *audit.Auditor
+	validator            *openapi.Validator
+	payouts              *payout.Processor
+	scheduler            *scheduler.Scheduler
+	paymentRequests      *paymentrequest.Manager
+	holds                *hold.Manager
+	escrows              *escrow.Manager
+	interests            *interest.Manager
+	jointAccounts        *joint.Manager
+	businesses           *business.Manager
+	checker              *health.Checker
+	checkpointer         *integrity.Checkpointer
 }
 
 func ProvideService(
@@ -32,32 +74,191 @@ func ProvideService(
 	transactionRepo repository.TransactionRepository,
 	balanceRepo repository.BalanceRepository,
 	paymentHistoryRepo repository.PaymentHistoryRepository,
+	payoutRepo repository.PayoutRepository,
+	scheduleRepo repository.ScheduledTransferRepository,
+	notificationRepo repository.NotificationRepository,
+	paymentRequestRepo repository.PaymentRequestRepository,
+	holdRepo repository.HoldRepository,
+	escrowRepo repository.EscrowRepository,
+	interestRepo repository.InterestRepository,
+	pocketRepo repository.PocketRepository,
+	sharedTransferRepo repository.SharedTransferRepository,
+	businessTransferRepo repository.BusinessTransferRepository,
 	db gorm.DB,
 	memoryStore redis.Client,
+	archiveReader *archive.Reader,
+	cfg *config.Config,
+	ledger *ledger.Ledger,
+	auditor *audit.Auditor,
+	validator *openapi.Validator,
+	payouts *payout.Processor,
+	scheduler *scheduler.Scheduler,
+	paymentRequests *paymentrequest.Manager,
+	holds *hold.Manager,
+	escrows *escrow.Manager,
+	interests *interest.Manager,
+	jointAccounts *joint.Manager,
+	businesses *business.Manager,
+	checker *health.Checker,
+	checkpointer *integrity.Checkpointer,
 ) (*Service, error) {
 	return &Service{
-		userRepo:           userRepo,
-		movementRepo:       movementRepo,
-		accountRepo:        accountRepo,
-		walletRepo:         walletRepo,
-		transactionRepo:    transactionRepo,
-		balanceRepo:        balanceRepo,
-		paymentHistoryRepo: paymentHistoryRepo,
-		db:                 db,
-		memoryStore:        memoryStore,
+		userRepo:             userRepo,
+		movementRepo:         movementRepo,
+		accountRepo:          accountRepo,
+		walletRepo:           walletRepo,
+		transactionRepo:      transactionRepo,
+		balanceRepo:          balanceRepo,
+		paymentHistoryRepo:   paymentHistoryRepo,
+		payoutRepo:           payoutRepo,
+		scheduleRepo:         scheduleRepo,
+		notificationRepo:     notificationRepo,
+		paymentRequestRepo:   paymentRequestRepo,
+		holdRepo:             holdRepo,
+		escrowRepo:           escrowRepo,
+		interestRepo:         interestRepo,
+		pocketRepo:           pocketRepo,
+		sharedTransferRepo:   sharedTransferRepo,
+		businessTransferRepo: businessTransferRepo,
+		db:                   db,
+		memoryStore:          memoryStore,
+		archiveReader:        archiveReader,
+		config:               cfg,
+		ledger:               ledger,
+		auditor:              auditor,
+		validator:            validator,
+		payouts:              payouts,
+		scheduler:            scheduler,
+		paymentRequests:      paymentRequests,
+		holds:                holds,
+		escrows:              escrows,
+		interests:            interests,
+		jointAccounts:        jointAccounts,
+		businesses:           businesses,
+		checker:              checker,
+		checkpointer:         checkpointer,
 	}, nil
 }
 
 func ProvideRoutes(service *Service) *gin.Engine {
+	gin.SetMode(service.config.Server.Mode)
 	r := gin.New()
+	r.Use(tracing.Middleware(service.config.Tracing.ServiceName)...)
+	r.Use(metrics.HTTPMiddleware())
 
 	protected := r.Group("/api/v1/wallet")
-	protected.Use(service.ValidateRequestID())
-	protected.POST("/deposit", service.Deposit)
-	protected.POST("/withdrawal", service.Withdraw)
-	protected.POST("/transfer", service.Transfer)
+	protected.POST("/deposit", service.moneyMovement("deposit", service.Deposit)...)
+	protected.POST("/withdrawal", service.moneyMovement("withdrawal", service.Withdraw)...)
+	protected.POST("/transfer", service.moneyMovement("transfer", service.Transfer)...)
 
-	r.GET("/api/v1/wallet/balance", service.GetBalance)
-	r.GET("/api/v1/wallet/payment-history", service.GetPaymentHistory)
+	validate := service.validator.Middleware()
+	r.GET("/api/v1/wallet/balance", validate, service.GetBalance)
+	r.GET("/api/v1/wallet/payment-history", validate, service.GetPaymentHistory)
+	r.GET("/api/v1/wallet/trace", validate, service.GetTrace)
+	r.GET("/api/v1/wallet/fee-quote", validate, service.GetFeeQuote)
+	r.GET("/api/v1/wallet/interest", validate, service.GetInterest)
+
+	payoutRoutes := r.Group("/api/v1/payouts")
+	payoutRoutes.POST("", service.auditor.Middleware("payout.submit"), service.ValidateRequestID(), metrics.Operation("payout"), validate, service.SubmitPayout)
+	payoutRoutes.GET("", validate, service.GetPayout)
+	payoutRoutes.GET("/:batch_id", validate, service.GetPayout)
+	payoutRoutes.GET("/:batch_id/lines", validate, service.GetPayoutLines)
+
+	scheduleRoutes := r.Group("/api/v1/scheduled-transfers")
+	scheduleRoutes.POST("", service.auditor.Middleware("schedule.create"), service.ValidateRequestID(), validate, service.CreateScheduledTransfer)
+	scheduleRoutes.GET("", validate, service.GetScheduledTransfers)
+	scheduleRoutes.GET("/:schedule_id", validate, service.GetScheduledTransfer)
+	scheduleRoutes.DELETE("/:schedule_id", service.auditor.Middleware("schedule.cancel"), validate, service.CancelScheduledTransfer)
+	scheduleRoutes.GET("/:schedule_id/runs", validate, service.GetScheduledTransferRuns)
+
+	paymentRequestRoutes := r.Group("/api/v1/payment-requests")
+	paymentRequestRoutes.POST("", service.auditor.Middleware("payment_request.create"), service.ValidateRequestID(), validate, service.CreatePaymentRequest)
+	paymentRequestRoutes.GET("", validate, service.GetPaymentRequests)
+	paymentRequestRoutes.GET("/:payment_request_id", validate, service.GetPaymentRequest)
+	paymentRequestRoutes.POST("/:payment_request_id/approve", service.auditor.Middleware("payment_request.approve"), metrics.Operation("payment_request"), validate, service.ApprovePaymentRequest)
+	paymentRequestRoutes.POST("/:payment_request_id/decline", service.auditor.Middleware("payment_request.decline"), validate, service.DeclinePaymentRequest)
+
+	holdRoutes := r.Group("/api/v1/holds")
+	holdRoutes.POST("", service.auditor.Middleware("hold.place"), service.ValidateRequestID(), metrics.Operation(ledger.OperationHold), validate, service.PlaceHold)
+	holdRoutes.GET("", validate, service.GetHolds)
+	holdRoutes.GET("/:hold_id", validate, service.GetHold)
+	holdRoutes.POST("/:hold_id/capture", service.auditor.Middleware("hold.capture"), service.ValidateRequestID(), metrics.Operation(ledger.OperationCapture), validate, service.CaptureHold)
+	holdRoutes.POST("/:hold_id/release", service.auditor.Middleware("hold.release"), validate, service.ReleaseHold)
+
+	escrowRoutes := r.Group("/api/v1/escrows")
+	escrowRoutes.POST("", service.auditor.Middleware("escrow.create"), service.ValidateRequestID(), metrics.Operation(ledger.OperationEscrow), validate, service.CreateEscrow)
+	escrowRoutes.GET("", validate, service.GetEscrows)
+	escrowRoutes.GET("/:escrow_id", validate, service.GetEscrow)
+	escrowRoutes.POST("/:escrow_id/release", service.auditor.Middleware("escrow.release"), metrics.Operation(ledger.OperationEscrow), validate, service.ReleaseEscrow)
+	escrowRoutes.POST("/:escrow_id/refund", service.auditor.Middleware("escrow.refund"), metrics.Operation(ledger.OperationEscrow), validate, service.RefundEscrow)
+	escrowRoutes.POST("/:escrow_id/split", service.auditor.Middleware("escrow.split"), metrics.Operation(ledger.OperationEscrow), validate, service.SplitEscrow)
+
+	pocketRoutes := r.Group("/api/v1/pockets")
+	pocketRoutes.POST("", service.auditor.Middleware("pocket.create"), validate, service.CreatePocket)
+	pocketRoutes.GET("", validate, service.GetPockets)
+	pocketRoutes.GET("/:pocket_id", validate, service.GetPocket)
+	pocketRoutes.POST("/:pocket_id/deposit", service.auditor.Middleware("pocket.deposit"), service.ValidateRequestID(), metrics.Operation(ledger.OperationPocket), validate, service.DepositToPocket)
+	pocketRoutes.POST("/:pocket_id/withdrawal", service.auditor.Middleware("pocket.withdrawal"), service.ValidateRequestID(), metrics.Operation(ledger.OperationPocket), validate, service.WithdrawFromPocket)
+
+	accountRoutes := r.Group("/api/v1/accounts")
+	accountRoutes.GET("", validate, service.GetAccounts)
+	accountRoutes.GET("/:account_id/members", validate, service.GetAccountMembers)
+	accountRoutes.PUT("/:account_id/members/:user_id", service.auditor.Middleware("account.member.save"), validate, service.SaveAccountMember)
+	accountRoutes.DELETE("/:account_id/members/:user_id", service.auditor.Middleware("account.member.remove"), validate, service.RemoveAccountMember)
+	accountRoutes.PUT("/:account_id/approval-threshold", service.auditor.Middleware("account.approval_threshold"), validate, service.SetApprovalThreshold)
+	accountRoutes.GET("/:account_id/balance", validate, service.GetAccountBalance)
+	accountRoutes.GET("/:account_id/payment-history", validate, service.GetAccountPaymentHistory)
+	accountRoutes.POST("/:account_id/transfers", service.auditor.Middleware("account.transfer"), service.ValidateRequestID(), metrics.Operation(ledger.OperationTransfer), validate, service.CreateSharedTransfer)
+	accountRoutes.GET("/:account_id/transfers", validate, service.GetSharedTransfers)
+	accountRoutes.POST("/:account_id/transfers/:transfer_id/approve", service.auditor.Middleware("account.transfer.approve"), metrics.Operation(ledger.OperationTransfer), validate, service.ApproveSharedTransfer)
+	accountRoutes.POST("/:account_id/transfers/:transfer_id/decline", service.auditor.Middleware("account.transfer.decline"), validate, service.DeclineSharedTransfer)
+
+	businessRoutes := r.Group("/api/v1/businesses")
+	businessRoutes.POST("", service.auditor.Middleware("business.open"), validate, service.OpenBusiness)
+	businessRoutes.GET("", validate, service.GetBusinesses)
+	businessRoutes.GET("/:business_id", validate, service.GetBusiness)
+	businessRoutes.GET("/:business_id/members", validate, service.GetBusinessMembers)
+	businessRoutes.PUT("/:business_id/members/:user_id", service.auditor.Middleware("business.member.save"), validate, service.SaveBusinessMember)
+	businessRoutes.DELETE("/:business_id/members/:user_id", service.auditor.Middleware("business.member.remove"), validate, service.RemoveBusinessMember)
+	businessRoutes.POST("/:business_id/members/:user_id/accept", service.auditor.Middleware("business.member.accept"), validate, service.AcceptBusinessInvitation)
+	businessRoutes.GET("/:business_id/policies", validate, service.GetPaymentPolicies)
+	businessRoutes.PUT("/:business_id/policies", service.auditor.Middleware("business.policy.save"), validate, service.SetPaymentPolicies)
+	businessRoutes.GET("/:business_id/balance", validate, service.GetBusinessBalance)
+	businessRoutes.GET("/:business_id/payment-history", validate, service.GetBusinessPaymentHistory)
+	businessRoutes.POST("/:business_id/transfers", service.auditor.Middleware("business.transfer"), service.ValidateRequestID(), metrics.Operation(ledger.OperationTransfer), validate, service.CreateBusinessTransfer)
+	businessRoutes.GET("/:business_id/transfers", validate, service.GetBusinessTransfers)
+	businessRoutes.GET("/:business_id/transfers/:transfer_id", validate, service.GetBusinessTransfer)
+	businessRoutes.POST("/:business_id/transfers/:transfer_id/approve", service.auditor.Middleware("business.transfer.approve"), metrics.Operation(ledger.OperationTransfer), validate, service.ApproveBusinessTransfer)
+	businessRoutes.POST("/:business_id/transfers/:transfer_id/reject", service.auditor.Middleware("business.transfer.reject"), validate, service.RejectBusinessTransfer)
+
+	r.GET("/api/v1/notifications", validate, service.GetNotifications)
+
+	auditRoutes := r.Group("/api/v1/audit")
+	auditRoutes.GET("/logs", service.auditor.Middleware("audit.search"), validate, service.auditor.Search)
+	auditRoutes.GET("/logs/export", service.auditor.Middleware("audit.export"), validate, service.auditor.Export)
+
+	r.GET("/api/v1/ledger/checkpoints", service.checkpointer.Export)
+	r.GET("/healthz", service.checker.Liveness)
+	r.GET("/readyz", service.checker.Readiness)
+	r.GET("/metrics", metrics.Handler())
+
+	r.GET("/openapi.json", openapi.Handler)
 	return r
 }
+
+// moneyMovement audits ahead of the idempotency check so rejected duplicates are recorded as denied attempts
+func (s *Service) moneyMovement(operation string, handler gin.HandlerFunc) []gin.HandlerFunc {
+	return []gin.HandlerFunc{
+		s.auditor.Middleware("wallet." + operation),
+		s.ValidateRequestID(),
+		metrics.Operation(operation),
+		s.validator.Middleware(),
+		handler,
+	}
+}
+
+// auditResult keeps the customer balances a money movement changed in the audit log
+func auditResult(ctx *gin.Context, result *ledger.Result) {
+	audit.Target(ctx, audit.TargetWallet, result.WalletID.String())
+	audit.Change(ctx, gin.H{"balances": result.Before}, gin.H{"balances": result.After, "group_id": result.GroupID})
+}
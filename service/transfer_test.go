@@ -88,6 +88,8 @@ func TestTransferAPIFailedWithInsufficientBalance(t *testing.T) {
 	responseErr := json.Unmarshal(resp.Body.Bytes(), &response)
 	assert.NoError(t, responseErr)
 	assert.False(t, response["result"].(bool))
+	assert.Equal(t, "INSUFFICIENT_FUNDS", response["error_code"])
+	assert.Equal(t, false, response["retryable"])
 }
 
 func TestTransferAPIFailedWithInvalidUser(t *testing.T) {
@@ -0,0 +1,80 @@
+package tracing
+
+import (
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"gorm.io/gorm"
+)
+
+const spanKey = "tracing:span"
+
+// GormPlugin adds a client span for every statement run with a traced context, e.g. db.WithContext(ctx)
+type GormPlugin struct{}
+
+func (GormPlugin) Name() string {
+	return "tracing"
+}
+
+func (GormPlugin) Initialize(db *gorm.DB) error {
+	callback := db.Callback()
+	if err := callback.Create().Before("gorm:create").Register("tracing:before_create", startSpan("create")); err != nil {
+		return err
+	}
+	if err := callback.Create().After("gorm:create").Register("tracing:after_create", endSpan); err != nil {
+		return err
+	}
+	if err := callback.Query().Before("gorm:query").Register("tracing:before_query", startSpan("query")); err != nil {
+		return err
+	}
+	if err := callback.Query().After("gorm:query").Register("tracing:after_query", endSpan); err != nil {
+		return err
+	}
+	if err := callback.Update().Before("gorm:update").Register("tracing:before_update", startSpan("update")); err != nil {
+		return err
+	}
+	if err := callback.Update().After("gorm:update").Register("tracing:after_update", endSpan); err != nil {
+		return err
+	}
+	if err := callback.Delete().Before("gorm:delete").Register("tracing:before_delete", startSpan("delete")); err != nil {
+		return err
+	}
+	if err := callback.Delete().After("gorm:delete").Register("tracing:after_delete", endSpan); err != nil {
+		return err
+	}
+	if err := callback.Raw().Before("gorm:raw").Register("tracing:before_raw", startSpan("raw")); err != nil {
+		return err
+	}
+	return callback.Raw().After("gorm:raw").Register("tracing:after_raw", endSpan)
+}
+
+// startSpan skips statements without a parent span so that background queries do not each start a trace
+func startSpan(kind string) func(db *gorm.DB) {
+	return func(db *gorm.DB) {
+		ctx := db.Statement.Context
+		if ctx == nil || !trace.SpanContextFromContext(ctx).IsValid() {
+			return
+		}
+		ctx, span := tracer().Start(ctx, "gorm."+kind, trace.WithSpanKind(trace.SpanKindClient),
+			trace.WithAttributes(attribute.String("db.system", "postgresql"), attribute.String("db.sql.table", db.Statement.Table)))
+		db.Statement.Context = ctx
+		db.InstanceSet(spanKey, span)
+	}
+}
+
+func endSpan(db *gorm.DB) {
+	value, ok := db.InstanceGet(spanKey)
+	if !ok {
+		return
+	}
+	span := value.(trace.Span)
+	span.SetAttributes(
+		attribute.String("db.statement", db.Statement.SQL.String()),
+		attribute.Int64("db.rows_affected", db.RowsAffected),
+	)
+	if db.Error != nil && db.Error != gorm.ErrRecordNotFound {
+		span.RecordError(db.Error)
+		span.SetStatus(codes.Error, db.Error.Error())
+	}
+	span.End()
+}
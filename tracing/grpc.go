@@ -0,0 +1,45 @@
+package tracing
+
+import (
+	"context"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"strings"
+)
+
+// GRPCServerOptions start a server span per call like Middleware does for HTTP. The span is tagged with the
+// x-request-id metadata and the trace id is returned in the x-trace-id header.
+func GRPCServerOptions() []grpc.ServerOption {
+	return []grpc.ServerOption{
+		grpc.StatsHandler(otelgrpc.NewServerHandler()),
+		grpc.ChainUnaryInterceptor(func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+			tagCall(ctx)
+			return handler(ctx, req)
+		}),
+		grpc.ChainStreamInterceptor(func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+			tagCall(stream.Context())
+			return handler(srv, stream)
+		}),
+	}
+}
+
+// RequestID reads the x-request-id metadata of an incoming call
+func RequestID(ctx context.Context) string {
+	values := metadata.ValueFromIncomingContext(ctx, strings.ToLower(RequestIDHeader))
+	if len(values) == 0 {
+		return ""
+	}
+	return values[0]
+}
+
+func tagCall(ctx context.Context) {
+	if requestID := RequestID(ctx); requestID != "" {
+		trace.SpanFromContext(ctx).SetAttributes(attribute.String("wallet.request_id", requestID))
+	}
+	if traceID := TraceID(ctx); traceID != "" {
+		_ = grpc.SetHeader(ctx, metadata.Pairs(strings.ToLower(TraceIDHeader), traceID))
+	}
+}
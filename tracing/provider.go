@@ -0,0 +1,78 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/raychongtk/wallet/config"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"io"
+	"os"
+)
+
+// Provider owns the global tracer provider so that buffered spans are flushed on shutdown
+type Provider struct {
+	tracerProvider *sdktrace.TracerProvider
+	closer         io.Closer
+}
+
+// ProvideTracerProvider installs the global tracer provider and W3C propagator. Spans are always created so
+// that trace ids can be stored with the ledger rows, the exporter only decides where they are shipped.
+func ProvideTracerProvider(cfg *config.Config) (*Provider, error) {
+	tracingConfig := cfg.Tracing
+	options := []sdktrace.TracerProviderOption{
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(tracingConfig.SampleRatio))),
+		sdktrace.WithResource(resource.NewSchemaless(semconv.ServiceName(tracingConfig.ServiceName))),
+	}
+	provider := &Provider{}
+	switch tracingConfig.Exporter {
+	case "stdout":
+		exporter, err := stdouttrace.New()
+		if err != nil {
+			return nil, fmt.Errorf("create stdout span exporter: %w", err)
+		}
+		options = append(options, sdktrace.WithBatcher(exporter))
+	case "file":
+		file, err := os.OpenFile(tracingConfig.File, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+		if err != nil {
+			return nil, fmt.Errorf("open span file %s: %w", tracingConfig.File, err)
+		}
+		exporter, err := stdouttrace.New(stdouttrace.WithWriter(file))
+		if err != nil {
+			_ = file.Close()
+			return nil, fmt.Errorf("create file span exporter: %w", err)
+		}
+		provider.closer = file
+		options = append(options, sdktrace.WithBatcher(exporter))
+	case "otlp":
+		exporterOptions := []otlptracehttp.Option{otlptracehttp.WithEndpoint(tracingConfig.Endpoint)}
+		if tracingConfig.Insecure {
+			exporterOptions = append(exporterOptions, otlptracehttp.WithInsecure())
+		}
+		exporter, err := otlptracehttp.New(context.Background(), exporterOptions...)
+		if err != nil {
+			return nil, fmt.Errorf("create otlp span exporter: %w", err)
+		}
+		options = append(options, sdktrace.WithBatcher(exporter))
+	}
+
+	provider.tracerProvider = sdktrace.NewTracerProvider(options...)
+	otel.SetTracerProvider(provider.tracerProvider)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+	return provider, nil
+}
+
+// Shutdown flushes the spans still buffered and closes the exporter
+func (p *Provider) Shutdown(ctx context.Context) error {
+	err := p.tracerProvider.Shutdown(ctx)
+	if p.closer != nil {
+		err = errors.Join(err, p.closer.Close())
+	}
+	return err
+}
@@ -0,0 +1,46 @@
+package tracing
+
+import (
+	"context"
+	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/contrib/instrumentation/github.com/gin-gonic/gin/otelgin"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	instrumentation = "github.com/raychongtk/wallet"
+
+	TraceIDHeader   = "X-Trace-ID"
+	RequestIDHeader = "X-Request-ID"
+)
+
+func tracer() trace.Tracer {
+	return otel.Tracer(instrumentation)
+}
+
+// TraceID returns the id of the trace carried by the context, empty when there is none
+func TraceID(ctx context.Context) string {
+	spanContext := trace.SpanContextFromContext(ctx)
+	if !spanContext.HasTraceID() {
+		return ""
+	}
+	return spanContext.TraceID().String()
+}
+
+// Middleware starts a server span per request, tags it with the X-Request-ID and returns the trace id in X-Trace-ID
+func Middleware(serviceName string) []gin.HandlerFunc {
+	return []gin.HandlerFunc{
+		otelgin.Middleware(serviceName),
+		func(c *gin.Context) {
+			if requestID := c.GetHeader(RequestIDHeader); requestID != "" {
+				trace.SpanFromContext(c.Request.Context()).SetAttributes(attribute.String("wallet.request_id", requestID))
+			}
+			if traceID := TraceID(c.Request.Context()); traceID != "" {
+				c.Header(TraceIDHeader, traceID)
+			}
+			c.Next()
+		},
+	}
+}
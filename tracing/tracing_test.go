@@ -0,0 +1,54 @@
+package tracing
+
+import (
+	"context"
+	"github.com/gin-gonic/gin"
+	"github.com/raychongtk/wallet/config"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestMiddlewareReturnsTraceID(t *testing.T) {
+	provider, err := ProvideTracerProvider(&config.Config{Tracing: config.TracingConfig{Exporter: "none", ServiceName: "wallet", SampleRatio: 1}})
+	assert.NoError(t, err)
+	defer provider.Shutdown(context.Background())
+
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(Middleware("wallet")...)
+	var traceID string
+	r.GET("/", func(c *gin.Context) {
+		traceID = TraceID(c.Request.Context())
+		c.Status(http.StatusOK)
+	})
+
+	resp := httptest.NewRecorder()
+	r.ServeHTTP(resp, httptest.NewRequest(http.MethodGet, "/", nil))
+	assert.Len(t, traceID, 32)
+	assert.Equal(t, traceID, resp.Header().Get(TraceIDHeader))
+}
+
+func TestMiddlewareContinuesIncomingTrace(t *testing.T) {
+	provider, err := ProvideTracerProvider(&config.Config{Tracing: config.TracingConfig{Exporter: "none", ServiceName: "wallet", SampleRatio: 1}})
+	assert.NoError(t, err)
+	defer provider.Shutdown(context.Background())
+
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(Middleware("wallet")...)
+	r.GET("/", func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	resp := httptest.NewRecorder()
+	r.ServeHTTP(resp, req)
+	assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", resp.Header().Get(TraceIDHeader))
+}
+
+func TestTraceIDWithoutSpan(t *testing.T) {
+	assert.Equal(t, "", TraceID(context.Background()))
+}
@@ -0,0 +1,7 @@
+package tracing
+
+import "github.com/google/wire"
+
+var (
+	WireSet = wire.NewSet(ProvideTracerProvider)
+)
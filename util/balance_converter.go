@@ -1,6 +1,9 @@
 package util
 
-import "strconv"
+import (
+	"fmt"
+	"strconv"
+)
 
 func ConvertToInt(value string) (int, error) {
 	floatValue, err := strconv.ParseFloat(value, 64)
@@ -9,3 +12,8 @@ func ConvertToInt(value string) (int, error) {
 	}
 	return int(floatValue * 100), nil
 }
+
+// DisplayAmount formats minor units the way the API does, the reverse of ConvertToInt
+func DisplayAmount(amount int) string {
+	return fmt.Sprintf("%.2f", float64(amount)/100)
+}
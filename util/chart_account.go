@@ -18,3 +18,48 @@ func GetLiabilityAccount() (accountId uuid.UUID) {
 	}
 	return accountId
 }
+
+// GetEscrowAccount is the chart account funded escrows are kept in until they settle
+func GetEscrowAccount() (accountId uuid.UUID) {
+	accountId, err := uuid.Parse("a8f2d9c4-5e61-4b07-8d3a-2c9e7f1b6a40")
+	if err != nil {
+		return
+	}
+	return accountId
+}
+
+// GetEscrowUser is the user of the escrow chart account, payment history names it as the other side of an escrow
+func GetEscrowUser() (userId uuid.UUID) {
+	userId, err := uuid.Parse("9e3f5a70-1c2b-4d8e-a6f4-3b7c2d1e0f59")
+	if err != nil {
+		return
+	}
+	return userId
+}
+
+// GetFeeRevenueAccount is the chart account the fees are paid into
+func GetFeeRevenueAccount() (accountId uuid.UUID) {
+	accountId, err := uuid.Parse("f4a6c8e2-9b1d-4c37-a5e0-3d7b2f8c1a96")
+	if err != nil {
+		return
+	}
+	return accountId
+}
+
+// GetInterestExpenseAccount is the chart account the interest of savings wallets is paid from
+func GetInterestExpenseAccount() (accountId uuid.UUID) {
+	accountId, err := uuid.Parse("b5d1f7a3-4e9c-4b28-a6f0-e3c8b2d4f917")
+	if err != nil {
+		return
+	}
+	return accountId
+}
+
+// GetInterestExpenseUser is the user of the interest expense chart account, payment history names it as the payer
+func GetInterestExpenseUser() (userId uuid.UUID) {
+	userId, err := uuid.Parse("3f8b2d6e-5c1a-4e97-b0d4-9a6e2c8f1b75")
+	if err != nil {
+		return
+	}
+	return userId
+}
@@ -12,13 +12,26 @@ var (
 
 // InitializeLogger initializes the logger as a singleton
 func InitializeLogger(isProduction bool) {
+	InitializeLoggerWithLevel(isProduction, "")
+}
+
+// InitializeLoggerWithLevel initializes the logger as a singleton with a minimum level such as "info".
+// An empty level keeps the default of the production or development preset.
+func InitializeLoggerWithLevel(isProduction bool, level string) {
 	once.Do(func() {
-		var err error
+		config := zap.NewDevelopmentConfig()
 		if isProduction {
-			logger, err = zap.NewProduction()
-		} else {
-			logger, err = zap.NewDevelopment()
+			config = zap.NewProductionConfig()
 		}
+		if level != "" {
+			parsedLevel, err := zap.ParseAtomicLevel(level)
+			if err != nil {
+				panic("failed to initialize logger: " + err.Error())
+			}
+			config.Level = parsedLevel
+		}
+		var err error
+		logger, err = config.Build()
 		if err != nil {
 			panic("failed to initialize logger: " + err.Error())
 		}
@@ -8,28 +8,124 @@ package main
 
 import (
 	"context"
-	"github.com/gin-gonic/gin"
+	"github.com/raychongtk/wallet/archive"
+	"github.com/raychongtk/wallet/audit"
+	"github.com/raychongtk/wallet/business"
+	"github.com/raychongtk/wallet/config"
 	"github.com/raychongtk/wallet/datastore"
+	"github.com/raychongtk/wallet/escrow"
+	"github.com/raychongtk/wallet/hold"
+	"github.com/raychongtk/wallet/integrity"
+	"github.com/raychongtk/wallet/interest"
+	"github.com/raychongtk/wallet/joint"
+	"github.com/raychongtk/wallet/ledger"
+	"github.com/raychongtk/wallet/migration"
+	"github.com/raychongtk/wallet/notify"
+	"github.com/raychongtk/wallet/openapi"
+	"github.com/raychongtk/wallet/paymentrequest"
+	"github.com/raychongtk/wallet/payout"
 	"github.com/raychongtk/wallet/repository"
+	"github.com/raychongtk/wallet/rpc"
+	"github.com/raychongtk/wallet/scheduler"
+	"github.com/raychongtk/wallet/secret"
 	"github.com/raychongtk/wallet/service"
+	"github.com/raychongtk/wallet/tracing"
 )
 
-// Injectors from inject_routes.go:
+// Injectors from inject_app.go:
 
-func injectRoutes(ctx context.Context) (*gin.Engine, error) {
-	db := datastore.ProvideDBConnection()
+func injectApp(ctx context.Context) (*App, error) {
+	configConfig, err := config.ProvideConfig()
+	if err != nil {
+		return nil, err
+	}
+	provider, err := secret.ProvideProvider(configConfig)
+	if err != nil {
+		return nil, err
+	}
+	db, err := datastore.ProvideDBConnection(configConfig, provider)
+	if err != nil {
+		return nil, err
+	}
 	userRepository := repository.ProvideUserRepository(db)
 	movementRepository := repository.ProvideMovementRepository(db)
 	accountRepository := repository.ProvideAccountRepository(db)
 	walletRepository := repository.ProvideWalletRepository(db)
-	transactionRepository := repository.ProvideTransactionRepository(db)
-	balanceRepository := repository.ProvideBalanceRepository(db)
-	paymentHistoryRepository := repository.ProvidePaymentHistoryRepository(db)
-	client := datastore.ProvideRedis()
-	serviceService, err := service.ProvideService(userRepository, movementRepository, accountRepository, walletRepository, transactionRepository, balanceRepository, paymentHistoryRepository, db, client)
+	client, err := datastore.ProvideRedis(configConfig, provider)
+	if err != nil {
+		return nil, err
+	}
+	replicaRouter, err := datastore.ProvideReplicaRouter(db, client, configConfig, provider)
+	if err != nil {
+		return nil, err
+	}
+	transactionRepository := repository.ProvideTransactionRepository(db, replicaRouter)
+	balanceRepository := repository.ProvideBalanceRepository(db, replicaRouter)
+	paymentHistoryRepository := repository.ProvidePaymentHistoryRepository(db, replicaRouter)
+	payoutRepository := repository.ProvidePayoutRepository(db)
+	scheduledTransferRepository := repository.ProvideScheduledTransferRepository(db)
+	notificationRepository := repository.ProvideNotificationRepository(db)
+	paymentRequestRepository := repository.ProvidePaymentRequestRepository(db)
+	holdRepository := repository.ProvideHoldRepository(db)
+	escrowRepository := repository.ProvideEscrowRepository(db)
+	interestRepository := repository.ProvideInterestRepository(db)
+	pocketRepository := repository.ProvidePocketRepository(db)
+	sharedTransferRepository := repository.ProvideSharedTransferRepository(db)
+	businessTransferRepository := repository.ProvideBusinessTransferRepository(db)
+	archiveManifestRepository := repository.ProvideArchiveManifestRepository(db)
+	objectStore, err := datastore.ProvideObjectStore(configConfig)
+	if err != nil {
+		return nil, err
+	}
+	reader := archive.ProvideReader(archiveManifestRepository, objectStore)
+	organizationRepository := repository.ProvideOrganizationRepository(db)
+	memberRepository := repository.ProvideMemberRepository(db)
+	ledgerHashRepository := repository.ProvideLedgerHashRepository(db)
+	chain := integrity.ProvideChain(ledgerHashRepository)
+	unitOfWork := ledger.ProvideUnitOfWork(db, movementRepository, transactionRepository, balanceRepository, paymentHistoryRepository, holdRepository, escrowRepository, interestRepository, pocketRepository, memberRepository, sharedTransferRepository, businessTransferRepository, chain, replicaRouter)
+	ledgerLedger := ledger.ProvideLedger(userRepository, accountRepository, organizationRepository, walletRepository, memberRepository, unitOfWork, configConfig)
+	auditLogRepository := repository.ProvideAuditLogRepository(db)
+	auditor := audit.ProvideAuditor(auditLogRepository, db)
+	validator, err := openapi.ProvideValidator(configConfig)
+	if err != nil {
+		return nil, err
+	}
+	processor := payout.ProvideProcessor(ledgerLedger, payoutRepository, movementRepository, balanceRepository, db, configConfig, auditor)
+	notifier := notify.ProvideNotifier(notificationRepository, db)
+	schedulerScheduler := scheduler.ProvideScheduler(ledgerLedger, scheduledTransferRepository, db, configConfig, notifier, auditor)
+	manager := paymentrequest.ProvideManager(ledgerLedger, paymentRequestRepository, db, configConfig, notifier)
+	holdManager := hold.ProvideManager(ledgerLedger, holdRepository, configConfig)
+	escrowManager := escrow.ProvideManager(ledgerLedger, escrowRepository, configConfig)
+	interestManager := interest.ProvideManager(ledgerLedger, interestRepository, transactionRepository, reader, configConfig)
+	jointManager := joint.ProvideManager(ledgerLedger, accountRepository, memberRepository, sharedTransferRepository, db, notifier)
+	businessMemberRepository := repository.ProvideBusinessMemberRepository(db)
+	paymentPolicyRepository := repository.ProvidePaymentPolicyRepository(db)
+	businessManager := business.ProvideManager(ledgerLedger, organizationRepository, businessMemberRepository, paymentPolicyRepository, businessTransferRepository, db, notifier)
+	migrator := migration.ProvideMigrator(db)
+	archiverDB, err := datastore.ProvideArchiverDB(db, configConfig, provider)
+	if err != nil {
+		return nil, err
+	}
+	archiver := archive.ProvideArchiver(movementRepository, transactionRepository, paymentHistoryRepository, archiveManifestRepository, objectStore, archiverDB, configConfig, auditor)
+	checkpointer, err := integrity.ProvideCheckpointer(ledgerHashRepository, db, configConfig, provider, auditor)
+	if err != nil {
+		return nil, err
+	}
+	checker := ProvideHealthChecker(configConfig, db, client, migrator, archiver, replicaRouter, checkpointer, processor, schedulerScheduler, manager, holdManager, escrowManager, interestManager)
+	serviceService, err := service.ProvideService(userRepository, movementRepository, accountRepository, walletRepository, transactionRepository, balanceRepository, paymentHistoryRepository, payoutRepository, scheduledTransferRepository, notificationRepository, paymentRequestRepository, holdRepository, escrowRepository, interestRepository, pocketRepository, sharedTransferRepository, businessTransferRepository, db, client, reader, configConfig, ledgerLedger, auditor, validator, processor, schedulerScheduler, manager, holdManager, escrowManager, interestManager, jointManager, businessManager, checker, checkpointer)
 	if err != nil {
 		return nil, err
 	}
 	engine := service.ProvideRoutes(serviceService)
-	return engine, nil
+	server := rpc.ProvideServer(ledgerLedger, balanceRepository, transactionRepository, paymentHistoryRepository, reader, client, auditor, configConfig)
+	grpcServer, err := rpc.ProvideGRPCServer(server, configConfig, provider)
+	if err != nil {
+		return nil, err
+	}
+	tracingProvider, err := tracing.ProvideTracerProvider(configConfig)
+	if err != nil {
+		return nil, err
+	}
+	app := ProvideApp(configConfig, engine, grpcServer, checker, migrator, archiver, replicaRouter, checkpointer, processor, schedulerScheduler, manager, holdManager, escrowManager, interestManager, balanceRepository, tracingProvider, db, client)
+	return app, nil
 }